@@ -0,0 +1,397 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+)
+
+// ArtifactCollector collects debugging artifacts of a container, e.g. heap dumps or profiles,
+// writing them to the given directory of the host. See WithArtifactsOnFailure.
+type ArtifactCollector func(ctx context.Context, ctr Container, destDir string) error
+
+// ArtifactFiles collects the files of the container matching the globs, see CollectArtifacts.
+func ArtifactFiles(globs ...string) ArtifactCollector {
+	return func(ctx context.Context, ctr Container, destDir string) error {
+		return CollectArtifacts(ctx, ctr, globs, destDir)
+	}
+}
+
+// HeapDumps collects the heap dumps of the JVM, written with the .hprof extension in /tmp
+// or in the working directory of the container, the default path of -XX:+HeapDumpOnOutOfMemoryError.
+func HeapDumps() ArtifactCollector {
+	return func(ctx context.Context, ctr Container, destDir string) error {
+		globs, err := workingDirGlobs(ctx, ctr, "/tmp/*.hprof", "*.hprof")
+		if err != nil {
+			return err
+		}
+
+		return CollectArtifacts(ctx, ctr, globs, destDir)
+	}
+}
+
+// CoreDumps collects the core files written in /tmp, /var/crash or in the working directory of the container.
+// The path of the core files is set by the kernel of the Docker host, see core(5): the hosts piping
+// them to a crash reporter, e.g. apport, don't write them in the containers.
+func CoreDumps() ArtifactCollector {
+	return func(ctx context.Context, ctr Container, destDir string) error {
+		globs, err := workingDirGlobs(ctx, ctr, "/tmp/core*", "/var/crash/*", "core*")
+		if err != nil {
+			return err
+		}
+
+		return CollectArtifacts(ctx, ctr, globs, destDir)
+	}
+}
+
+// PprofProfiles collects the profiles of a Go service exposing the net/http/pprof endpoints on the given port,
+// through its mapped port, e.g. "heap" or "goroutine?debug=2", writing them to the pprof directory. The default
+// profiles are heap and goroutine. The container must be running.
+func PprofProfiles(port nat.Port, profiles ...string) ArtifactCollector {
+	if len(profiles) == 0 {
+		profiles = []string{"heap", "goroutine"}
+	}
+
+	return func(ctx context.Context, ctr Container, destDir string) error {
+		endpoint, err := ctr.PortEndpoint(ctx, port, "http")
+		if err != nil {
+			return fmt.Errorf("pprof endpoint: %w", err)
+		}
+
+		dir := filepath.Join(destDir, "pprof")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create pprof directory: %w", err)
+		}
+
+		var errs []error
+		for _, profile := range profiles {
+			if err := downloadProfile(ctx, endpoint+"/debug/pprof/"+profile, filepath.Join(dir, profileFileName(profile))); err != nil {
+				errs = append(errs, fmt.Errorf("pprof %s: %w", profile, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// profileFileName returns the name of the file of the profile: the text profiles, e.g. goroutine?debug=2,
+// are written to .txt files, and the others to .pb.gz files.
+func profileFileName(profile string) string {
+	name, query, _ := strings.Cut(profile, "?")
+	name = unsafeFileNameChars.ReplaceAllString(name, "_")
+
+	if strings.Contains(query, "debug=") && !strings.Contains(query, "debug=0") {
+		return name + ".txt"
+	}
+
+	return name + ".pb.gz"
+}
+
+// downloadProfile writes the response of the URL to the file.
+func downloadProfile(ctx context.Context, url string, file string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, resp.Body)
+
+	return errors.Join(err, f.Close())
+}
+
+// WithArtifactsOnFailure runs the collectors before the container is terminated, if the test failed,
+// writing the artifacts to a directory named after the test and the container in destDir,
+// e.g. artifacts/TestFoo/funny_name, to be archived by the CI build. The errors of the collectors
+// are logged to the test, without failing the termination.
+func WithArtifactsOnFailure(tb testing.TB, destDir string, collectors ...ArtifactCollector) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if destDir == "" {
+			return errors.New("empty artifacts directory")
+		}
+		if len(collectors) == 0 {
+			return errors.New("no artifact collector")
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreTerminates: []ContainerHook{
+				func(ctx context.Context, ctr Container) error {
+					if !tb.Failed() {
+						return nil
+					}
+
+					name, err := containerLogName(ctx, ctr)
+					if err != nil {
+						tb.Logf("collect artifacts: %v", err)
+						return nil
+					}
+
+					dir := filepath.Join(destDir, unsafeFileNameChars.ReplaceAllString(tb.Name(), "_"), name)
+					for _, collect := range collectors {
+						if err := collect(ctx, ctr, dir); err != nil {
+							tb.Logf("collect artifacts of %s: %v", name, err)
+						}
+					}
+
+					tb.Logf("artifacts of %s collected in %s", name, dir)
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// artifactsCollector is implemented by the containers copying their files to the host, see CollectArtifacts.
+type artifactsCollector interface {
+	CollectArtifacts(ctx context.Context, globs []string, destDir string) error
+}
+
+// CollectArtifacts copies the files of the container matching the absolute globs, e.g. /tmp/*.hprof, to destDir,
+// keeping their paths in the container, to debug the failed tests. It works on the stopped containers too.
+func CollectArtifacts(ctx context.Context, ctr Container, globs []string, destDir string) error {
+	c, ok := asContainer[artifactsCollector](ctr)
+	if !ok {
+		return unsupportedError(ctr, "collect artifacts")
+	}
+
+	return c.CollectArtifacts(ctx, globs, destDir)
+}
+
+// CollectArtifacts copies the files of the container matching the globs to destDir, keeping their paths
+// in the container, e.g. /tmp/java_pid1.hprof is copied to destDir/tmp/java_pid1.hprof. It works on the
+// stopped containers too, e.g. after a crash.
+func (c *DockerContainer) CollectArtifacts(ctx context.Context, globs []string, destDir string) error {
+	return collectArtifacts(globs, destDir, func(base string, fn artifactFunc) error {
+		r, _, err := c.provider.client.CopyFromContainer(ctx, c.ID, base)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		defer r.Close()
+
+		return walkArtifactsTar(r, path.Dir(base), fn)
+	})
+}
+
+// CollectArtifacts copies the files of the container matching the globs to destDir, keeping their paths
+// in the container, as with the Docker provider.
+func (c *NerdctlContainer) CollectArtifacts(ctx context.Context, globs []string, destDir string) error {
+	return collectArtifacts(globs, destDir, func(base string, fn artifactFunc) error {
+		dir, err := os.MkdirTemp("", "testcontainers-nerdctl-cp")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		if _, err := c.provider.run(ctx, "cp", c.ID+":"+base, dir); err != nil {
+			if strings.Contains(err.Error(), "no such file or directory") {
+				return nil
+			}
+			return err
+		}
+
+		return walkArtifactsDir(dir, path.Dir(base), fn)
+	})
+}
+
+// artifactFunc is called with the path in the container and the content of each file copied from the container.
+type artifactFunc func(containerPath string, r io.Reader) error
+
+// copyArtifactsFunc copies the base path of the container, calling fn for each file, and ignoring the missing paths.
+type copyArtifactsFunc func(base string, fn artifactFunc) error
+
+// collectArtifacts copies the base directory of each glob with copyFn, once per directory,
+// and writes the files matching the globs to destDir.
+func collectArtifacts(globs []string, destDir string, copyFn copyArtifactsFunc) error {
+	if destDir == "" {
+		return errors.New("collect artifacts: empty destination directory")
+	}
+
+	bases := map[string][]string{}
+	var order []string
+	for _, glob := range globs {
+		base, err := globBase(glob)
+		if err != nil {
+			return fmt.Errorf("collect artifacts: %w", err)
+		}
+
+		if _, ok := bases[base]; !ok {
+			order = append(order, base)
+		}
+		bases[base] = append(bases[base], path.Clean(glob))
+	}
+
+	var errs []error
+	for _, base := range order {
+		err := copyFn(base, func(containerPath string, r io.Reader) error {
+			if !matchArtifact(bases[base], containerPath) {
+				return nil
+			}
+
+			return writeArtifact(filepath.Join(destDir, filepath.FromSlash(containerPath)), r)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("collect artifacts in %s: %w", base, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// globBase returns the directory of the glob without pattern, copied from the container,
+// or the glob itself if it has no pattern. The globs must be absolute, and not match the root directory.
+func globBase(glob string) (string, error) {
+	if !path.IsAbs(glob) {
+		return "", fmt.Errorf("relative glob %s", glob)
+	}
+
+	segments := strings.Split(path.Clean(glob), "/")
+	base := path.Clean(glob)
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, `*?[\`) {
+			base = path.Clean("/" + strings.Join(segments[:i], "/"))
+			break
+		}
+	}
+
+	if base == "/" {
+		return "", fmt.Errorf("glob %s would copy the whole filesystem", glob)
+	}
+
+	return base, nil
+}
+
+// matchArtifact returns true if a glob matches the path or one of its parent directories,
+// so a glob matching a directory collects all its files.
+func matchArtifact(globs []string, containerPath string) bool {
+	for p := containerPath; p != "/" && p != "."; p = path.Dir(p) {
+		for _, glob := range globs {
+			if ok, _ := path.Match(glob, p); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// walkArtifactsTar calls fn for each regular file of the tar archive, whose entries are relative to parent.
+func walkArtifactsTar(r io.Reader, parent string, fn artifactFunc) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := fn(path.Join(parent, hdr.Name), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// walkArtifactsDir calls fn for each regular file of the directory of the host, whose entries are relative to parent.
+func walkArtifactsDir(dir string, parent string, fn artifactFunc) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return fn(path.Join(parent, filepath.ToSlash(rel)), f)
+	})
+}
+
+// writeArtifact writes the content to the file, creating its directory.
+func writeArtifact(file string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, r)
+
+	return errors.Join(err, f.Close())
+}
+
+// workingDirGlobs returns the absolute globs, and the relative ones joined to the working directory
+// of the container, unless it's the root directory.
+func workingDirGlobs(ctx context.Context, ctr Container, globs ...string) ([]string, error) {
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	var workingDir string
+	if inspect.Config != nil {
+		workingDir = path.Clean("/" + inspect.Config.WorkingDir)
+	}
+
+	result := make([]string, 0, len(globs))
+	for _, glob := range globs {
+		switch {
+		case path.IsAbs(glob):
+			result = append(result, glob)
+		case workingDir != "" && workingDir != "/":
+			result = append(result, path.Join(workingDir, glob))
+		}
+	}
+
+	return result, nil
+}
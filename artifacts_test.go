@@ -0,0 +1,226 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeArtifactsContainer is a container recording the collections of its artifacts.
+type fakeArtifactsContainer struct {
+	Container
+	workingDir string
+	globs      []string
+	destDir    string
+}
+
+func (c *fakeArtifactsContainer) Inspect(context.Context, ...InspectOption) (*types.ContainerJSON, error) {
+	return &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{Name: "/funny_name"},
+		Config:            &container.Config{WorkingDir: c.workingDir},
+	}, nil
+}
+
+func (c *fakeArtifactsContainer) CollectArtifacts(_ context.Context, globs []string, destDir string) error {
+	c.globs = globs
+	c.destDir = destDir
+
+	return nil
+}
+
+// artifactsTar returns a tar archive with the given files, as returned by the Docker API.
+func artifactsTar(t *testing.T, files map[string]string) io.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	return &buf
+}
+
+func TestGlobBase(t *testing.T) {
+	for glob, want := range map[string]string{
+		"/tmp/*.hprof":          "/tmp",
+		"/var/crash/*":          "/var/crash",
+		"/var/log/app/[ab].log": "/var/log/app",
+		"/app/heap.hprof":       "/app/heap.hprof",
+		"/var/log/":             "/var/log",
+	} {
+		base, err := globBase(glob)
+		require.NoError(t, err, glob)
+		require.Equal(t, want, base, glob)
+	}
+
+	_, err := globBase("*.hprof")
+	require.ErrorContains(t, err, "relative glob")
+
+	_, err = globBase("/*.hprof")
+	require.ErrorContains(t, err, "whole filesystem")
+}
+
+func TestMatchArtifact(t *testing.T) {
+	globs := []string{"/tmp/*.hprof", "/var/crash"}
+
+	require.True(t, matchArtifact(globs, "/tmp/java_pid1.hprof"))
+	require.True(t, matchArtifact(globs, "/var/crash/app/core.1"))
+	require.False(t, matchArtifact(globs, "/tmp/data/heap.hprof"))
+	require.False(t, matchArtifact(globs, "/tmp/app.log"))
+}
+
+func TestProfileFileName(t *testing.T) {
+	require.Equal(t, "heap.pb.gz", profileFileName("heap"))
+	require.Equal(t, "goroutine.txt", profileFileName("goroutine?debug=2"))
+	require.Equal(t, "profile.pb.gz", profileFileName("profile?seconds=5"))
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	destDir := t.TempDir()
+
+	var bases []string
+	err := collectArtifacts([]string{"/tmp/*.hprof", "/tmp/core*", "/var/crash/*"}, destDir, func(base string, fn artifactFunc) error {
+		bases = append(bases, base)
+
+		if base != "/tmp" {
+			return nil
+		}
+
+		return walkArtifactsTar(artifactsTar(t, map[string]string{
+			"tmp/java_pid1.hprof": "heap",
+			"tmp/core.42":         "core",
+			"tmp/app.log":         "log",
+		}), "/", fn)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/tmp", "/var/crash"}, bases)
+
+	content, err := os.ReadFile(filepath.Join(destDir, "tmp", "java_pid1.hprof"))
+	require.NoError(t, err)
+	require.Equal(t, "heap", string(content))
+
+	require.FileExists(t, filepath.Join(destDir, "tmp", "core.42"))
+	require.NoFileExists(t, filepath.Join(destDir, "tmp", "app.log"))
+}
+
+func TestWalkArtifactsDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "crash", "app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crash", "app", "core.1"), []byte("core"), 0o644))
+
+	var paths []string
+	err := walkArtifactsDir(dir, "/var", func(containerPath string, _ io.Reader) error {
+		paths = append(paths, containerPath)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/var/crash/app/core.1"}, paths)
+}
+
+func TestHeapDumps(t *testing.T) {
+	ctx := context.Background()
+
+	ctr := &fakeArtifactsContainer{workingDir: "/app"}
+	require.NoError(t, HeapDumps()(ctx, ctr, "artifacts"))
+	require.Equal(t, []string{"/tmp/*.hprof", "/app/*.hprof"}, ctr.globs)
+
+	ctr = &fakeArtifactsContainer{}
+	require.NoError(t, CoreDumps()(ctx, ctr, "artifacts"))
+	require.Equal(t, []string{"/tmp/core*", "/var/crash/*"}, ctr.globs)
+}
+
+func TestWithArtifactsOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.Error(t, WithArtifactsOnFailure(t, "", HeapDumps())(&req))
+		require.Error(t, WithArtifactsOnFailure(t, "artifacts")(&req))
+	})
+
+	for _, failed := range []bool{false, true} {
+		tb := &failingTest{TB: t, name: "TestHandler/create order", failed: failed}
+
+		req := GenericContainerRequest{}
+		require.NoError(t, WithArtifactsOnFailure(tb, "artifacts", ArtifactFiles("/tmp/*.hprof"))(&req))
+		require.Len(t, req.LifecycleHooks, 1)
+		require.Len(t, req.LifecycleHooks[0].PreTerminates, 1)
+
+		ctr := &fakeArtifactsContainer{}
+		require.NoError(t, req.LifecycleHooks[0].PreTerminates[0](ctx, ctr))
+
+		if !failed {
+			require.Empty(t, ctr.destDir)
+			continue
+		}
+
+		require.Equal(t, []string{"/tmp/*.hprof"}, ctr.globs)
+		require.Equal(t, filepath.Join("artifacts", "TestHandler_create_order", "funny_name"), ctr.destDir)
+	}
+}
+
+func TestDockerContainer_CollectArtifacts(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "alpine:3.20",
+			Cmd:   []string{"sh", "-c", "echo heap > /tmp/java_pid1.hprof && echo log > /tmp/app.log"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// the files are copied from the stopped container too
+	require.NoError(t, ctr.Stop(ctx, nil))
+
+	destDir := t.TempDir()
+
+	// collectArtifacts {
+	err = CollectArtifacts(ctx, ctr, []string{"/tmp/*.hprof", "/var/crash/*"}, destDir)
+	// }
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(destDir, "tmp", "java_pid1.hprof"))
+	require.NoError(t, err)
+	require.Equal(t, "heap\n", string(content))
+	require.NoFileExists(t, filepath.Join(destDir, "tmp", "app.log"))
+}
+
+func TestPprofProfiles(t *testing.T) {
+	ctx := context.Background()
+
+	// withArtifactsOnFailure {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+		},
+		Started: true,
+	}
+	err := WithArtifactsOnFailure(t, "artifacts", HeapDumps(), CoreDumps(), PprofProfiles("80/tcp"))(&req)
+	// }
+	require.NoError(t, err)
+
+	ctr, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// nginx doesn't expose the pprof endpoints
+	err = PprofProfiles("80/tcp", "heap")(ctx, ctr, t.TempDir())
+	require.ErrorContains(t, err, "unexpected status code 404")
+}
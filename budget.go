@@ -0,0 +1,139 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// ErrResourceBudgetExceeded is returned when a container cannot be created because it exceeds
+// the resource budget of the test session, configured with the "session.max.containers" and
+// "session.max.memory" properties.
+var ErrResourceBudgetExceeded = errors.New("resource budget exceeded")
+
+var (
+	sessionBudgetInstance *resourceBudget
+	sessionBudgetOnce     sync.Once
+)
+
+// sessionBudget returns the resource budget of the test session, built from the configuration.
+func sessionBudget() *resourceBudget {
+	sessionBudgetOnce.Do(func() {
+		cfg := config.Read()
+		sessionBudgetInstance = newResourceBudget(cfg.SessionMaxContainers, cfg.SessionMaxMemory, cfg.SessionBudgetFailFast)
+	})
+
+	return sessionBudgetInstance
+}
+
+// resourceBudget limits the number of containers, and the sum of their memory limits,
+// that can be running at the same time.
+type resourceBudget struct {
+	maxContainers int   // zero means no limit
+	maxMemory     int64 // zero means no limit
+	failFast      bool  // fail instead of waiting for the resources to be released
+
+	mtx        sync.Mutex
+	containers int
+	memory     int64
+	released   chan struct{} // closed, and replaced, every time resources are released
+}
+
+func newResourceBudget(maxContainers int, maxMemory int64, failFast bool) *resourceBudget {
+	return &resourceBudget{
+		maxContainers: maxContainers,
+		maxMemory:     maxMemory,
+		failFast:      failFast,
+		released:      make(chan struct{}),
+	}
+}
+
+// enabled returns true if the budget has any limit.
+func (b *resourceBudget) enabled() bool {
+	return b.maxContainers > 0 || b.maxMemory > 0
+}
+
+// fits returns true if a container with the given memory limit fits in the budget.
+// It must be called with the lock held.
+func (b *resourceBudget) fits(memory int64) bool {
+	if b.maxContainers > 0 && b.containers+1 > b.maxContainers {
+		return false
+	}
+
+	if b.maxMemory > 0 && b.memory+memory > b.maxMemory {
+		return false
+	}
+
+	return true
+}
+
+// acquire reserves the resources of a container with the given memory limit, waiting
+// until there are enough resources or the context is done. If the budget fails fast,
+// it returns an error as soon as the container does not fit.
+func (b *resourceBudget) acquire(ctx context.Context, memory int64) error {
+	if !b.enabled() {
+		return nil
+	}
+
+	if b.maxMemory > 0 && memory > b.maxMemory {
+		return fmt.Errorf("%w: container memory limit of %d bytes is greater than the session maximum of %d bytes", ErrResourceBudgetExceeded, memory, b.maxMemory)
+	}
+
+	for {
+		b.mtx.Lock()
+		if b.fits(memory) {
+			b.containers++
+			b.memory += memory
+			b.mtx.Unlock()
+			return nil
+		}
+
+		usage := fmt.Sprintf("%d containers using %d bytes of memory", b.containers, b.memory)
+		released := b.released
+		b.mtx.Unlock()
+
+		if b.failFast {
+			return fmt.Errorf("%w: %s", ErrResourceBudgetExceeded, usage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: waiting for resources, %s: %w", ErrResourceBudgetExceeded, usage, ctx.Err())
+		case <-released:
+		}
+	}
+}
+
+// release frees the resources of a container with the given memory limit,
+// waking up the creations waiting for resources.
+func (b *resourceBudget) release(memory int64) {
+	if !b.enabled() {
+		return
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.containers--
+	b.memory -= memory
+
+	close(b.released)
+	b.released = make(chan struct{})
+}
+
+// applyDefaultResources sets the default memory and CPU limits from the configuration
+// to the host config, when the container does not define them.
+func applyDefaultResources(cfg config.Config, hostConfig *container.HostConfig) {
+	if hostConfig.Memory == 0 && cfg.ContainerDefaultMemory > 0 {
+		hostConfig.Memory = cfg.ContainerDefaultMemory
+	}
+
+	if hostConfig.NanoCPUs == 0 && hostConfig.CPUQuota == 0 && cfg.ContainerDefaultCPUs > 0 {
+		hostConfig.NanoCPUs = int64(cfg.ContainerDefaultCPUs * 1e9)
+	}
+}
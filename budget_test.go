@@ -0,0 +1,116 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestResourceBudget(t *testing.T) {
+	t.Run("no-limits", func(t *testing.T) {
+		b := newResourceBudget(0, 0, true)
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, b.acquire(context.Background(), 1<<30))
+		}
+	})
+
+	t.Run("max-containers/fail-fast", func(t *testing.T) {
+		b := newResourceBudget(2, 0, true)
+
+		require.NoError(t, b.acquire(context.Background(), 0))
+		require.NoError(t, b.acquire(context.Background(), 0))
+		require.ErrorIs(t, b.acquire(context.Background(), 0), ErrResourceBudgetExceeded)
+
+		b.release(0)
+		require.NoError(t, b.acquire(context.Background(), 0))
+	})
+
+	t.Run("max-memory/fail-fast", func(t *testing.T) {
+		b := newResourceBudget(0, 100, true)
+
+		require.NoError(t, b.acquire(context.Background(), 60))
+		require.ErrorIs(t, b.acquire(context.Background(), 60), ErrResourceBudgetExceeded)
+		require.NoError(t, b.acquire(context.Background(), 40))
+	})
+
+	t.Run("max-memory/container-greater-than-budget", func(t *testing.T) {
+		b := newResourceBudget(0, 100, false)
+
+		require.ErrorIs(t, b.acquire(context.Background(), 101), ErrResourceBudgetExceeded)
+	})
+
+	t.Run("queue/released", func(t *testing.T) {
+		b := newResourceBudget(1, 0, false)
+
+		require.NoError(t, b.acquire(context.Background(), 0))
+
+		acquired := make(chan error)
+		go func() {
+			acquired <- b.acquire(context.Background(), 0)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("the container should wait for the resources to be released")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		b.release(0)
+		require.NoError(t, <-acquired)
+	})
+
+	t.Run("queue/context-done", func(t *testing.T) {
+		b := newResourceBudget(1, 0, false)
+
+		require.NoError(t, b.acquire(context.Background(), 0))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := b.acquire(ctx, 0)
+		require.ErrorIs(t, err, ErrResourceBudgetExceeded)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestApplyDefaultResources(t *testing.T) {
+	cfg := config.Config{
+		ContainerDefaultMemory: 512 * 1024 * 1024,
+		ContainerDefaultCPUs:   1.5,
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		hostConfig := &container.HostConfig{}
+		applyDefaultResources(cfg, hostConfig)
+
+		require.Equal(t, int64(512*1024*1024), hostConfig.Memory)
+		require.Equal(t, int64(1_500_000_000), hostConfig.NanoCPUs)
+	})
+
+	t.Run("container-limits-win", func(t *testing.T) {
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{
+				Memory:   64 * 1024 * 1024,
+				CPUQuota: 50000,
+			},
+		}
+		applyDefaultResources(cfg, hostConfig)
+
+		require.Equal(t, int64(64*1024*1024), hostConfig.Memory)
+		require.Zero(t, hostConfig.NanoCPUs)
+	})
+
+	t.Run("no-defaults", func(t *testing.T) {
+		hostConfig := &container.HostConfig{}
+		applyDefaultResources(config.Config{}, hostConfig)
+
+		require.Zero(t, hostConfig.Memory)
+		require.Zero(t, hostConfig.NanoCPUs)
+	})
+}
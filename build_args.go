@@ -0,0 +1,29 @@
+package testcontainers
+
+import "os"
+
+// BuildArgsFromEnv returns the build args with the values of the environment variables of the test process
+// of the same name, skipping the variables that are not set, e.g. BuildArgsFromEnv("GOPROXY", "GOFLAGS").
+func BuildArgsFromEnv(names ...string) map[string]*string {
+	args := make(map[string]*string, len(names))
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			args[name] = &value
+		}
+	}
+
+	return args
+}
+
+// ExpandBuildArgs returns the build args with their values expanded with the environment variables
+// of the test process, replacing ${var} or $var, e.g. {"VERSION": "${APP_VERSION}-test"}.
+// The variables that are not set are replaced by the empty string.
+func ExpandBuildArgs(args map[string]string) map[string]*string {
+	expanded := make(map[string]*string, len(args))
+	for name, value := range args {
+		value := os.ExpandEnv(value)
+		expanded[name] = &value
+	}
+
+	return expanded
+}
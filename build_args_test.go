@@ -0,0 +1,79 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildArgsFromEnv(t *testing.T) {
+	t.Setenv("TC_BUILD_GOPROXY", "https://proxy.golang.org")
+	t.Setenv("TC_BUILD_EMPTY", "")
+
+	args := BuildArgsFromEnv("TC_BUILD_GOPROXY", "TC_BUILD_EMPTY", "TC_BUILD_UNSET")
+	require.Len(t, args, 2)
+	require.Equal(t, "https://proxy.golang.org", *args["TC_BUILD_GOPROXY"])
+	require.Empty(t, *args["TC_BUILD_EMPTY"])
+	require.NotContains(t, args, "TC_BUILD_UNSET")
+}
+
+func TestExpandBuildArgs(t *testing.T) {
+	t.Setenv("TC_BUILD_VERSION", "1.2.3")
+
+	args := ExpandBuildArgs(map[string]string{
+		"VERSION": "${TC_BUILD_VERSION}-test",
+		"COMMIT":  "$TC_BUILD_UNSET",
+		"STAGE":   "test",
+	})
+	require.Equal(t, "1.2.3-test", *args["VERSION"])
+	require.Empty(t, *args["COMMIT"])
+	require.Equal(t, "test", *args["STAGE"])
+}
+
+func TestBuildOptions_target(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "target.Dockerfile",
+			Target:     "target1",
+			BuildArgs:  ExpandBuildArgs(map[string]string{"STAGE": "test"}),
+			BuildOptionsModifier: func(buildOptions *types.ImageBuildOptions) {
+				buildOptions.Target = "target2"
+			},
+		},
+	}
+
+	buildOptions, err := req.BuildOptions()
+	require.NoError(t, err)
+	defer tryClose(buildOptions.Context)
+
+	require.Equal(t, "target1", buildOptions.Target)
+	require.Equal(t, "test", *buildOptions.BuildArgs["STAGE"])
+}
+
+func TestBuildOptions_platform(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "target.Dockerfile",
+		},
+		ImagePlatform: "linux/arm64",
+	}
+
+	buildOptions, err := req.BuildOptions()
+	require.NoError(t, err)
+	defer tryClose(buildOptions.Context)
+
+	require.Equal(t, "linux/arm64", buildOptions.Platform)
+
+	req.FromDockerfile.BuildOptionsModifier = func(buildOptions *types.ImageBuildOptions) {
+		buildOptions.Platform = "linux/amd64"
+	}
+
+	buildOptions, err = req.BuildOptions()
+	require.NoError(t, err)
+	defer tryClose(buildOptions.Context)
+
+	require.Equal(t, "linux/amd64", buildOptions.Platform)
+}
@@ -0,0 +1,183 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// BuildSecret is a BuildKit secret of an image build, read from a file or from an environment variable
+// of the test process. The RUN instructions of the Dockerfile mount it with --mount=type=secret,id=<ID>,
+// so it's not stored in the layers of the image. Exactly one of File and Env must be set.
+type BuildSecret struct {
+	ID   string // the id of the secret in the Dockerfile
+	File string // the path of the file with the value of the secret
+	Env  string // the environment variable with the value of the secret
+}
+
+// BuildSSH is an SSH agent socket or a set of SSH keys forwarded to an image build. The RUN instructions
+// of the Dockerfile mount it with --mount=type=ssh,id=<ID>, e.g. to clone private repositories.
+type BuildSSH struct {
+	ID    string   // the id of the SSH forwarding in the Dockerfile, defaults to "default"
+	Paths []string // the paths of the SSH agent sockets or keys, defaults to the SSH_AUTH_SOCK agent
+}
+
+// buildKitSessionInfo is implemented by the ImageBuildInfo needing a BuildKit session to forward
+// secrets or SSH agents to the build, which the Docker API doesn't provide without the BuildKit client.
+type buildKitSessionInfo interface {
+	GetBuildSecrets() []BuildSecret
+	GetBuildSSH() []BuildSSH
+}
+
+// GetBuildSecrets returns the BuildKit secrets of the build.
+func (c *ContainerRequest) GetBuildSecrets() []BuildSecret {
+	return c.FromDockerfile.Secrets
+}
+
+// GetBuildSSH returns the SSH forwardings of the build.
+func (c *ContainerRequest) GetBuildSSH() []BuildSSH {
+	return c.FromDockerfile.SSH
+}
+
+// validateBuildSecrets ensures that the secrets have an id and a single source.
+func (c *ContainerRequest) validateBuildSecrets() error {
+	var errs []error
+	for _, secret := range c.FromDockerfile.Secrets {
+		if secret.ID == "" {
+			errs = append(errs, errors.New("build secret id must be specified"))
+			continue
+		}
+
+		if (secret.File == "") == (secret.Env == "") {
+			errs = append(errs, fmt.Errorf("build secret %s: exactly one of File and Env must be specified", secret.ID))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// usesBuildKitSession returns true if the build forwards secrets or SSH agents.
+func usesBuildKitSession(img ImageBuildInfo) (buildKitSessionInfo, bool) {
+	info, ok := img.(buildKitSessionInfo)
+	if !ok || (len(info.GetBuildSecrets()) == 0 && len(info.GetBuildSSH()) == 0) {
+		return nil, false
+	}
+
+	return info, true
+}
+
+// buildImageWithBuildKit builds the image with the BuildKit of the docker CLI, which must be in the PATH,
+// as the secrets and the SSH agents are forwarded to the build in a BuildKit session.
+// The build context is sent in the standard input of the CLI.
+func (p *DockerProvider) buildImageWithBuildKit(ctx context.Context, img ImageBuildInfo, info buildKitSessionInfo) (string, error) {
+	docker, err := exec.LookPath("docker")
+	if err != nil {
+		return "", fmt.Errorf("build secrets and SSH forwarding need the docker CLI: %w", err)
+	}
+
+	buildOptions, err := img.BuildOptions()
+	if err != nil {
+		return "", fmt.Errorf("build options: %w", err)
+	}
+	defer tryClose(buildOptions.Context) // release resources in any case
+
+	var output bytes.Buffer
+	var stdout io.Writer = &output
+	if img.ShouldPrintBuildLog() {
+		stdout = io.MultiWriter(&output, os.Stderr)
+	}
+
+	cmd := exec.CommandContext(ctx, docker, buildKitArgs(buildOptions, info)...)
+	cmd.Stdin = buildOptions.Context
+	cmd.Stdout = stdout
+	cmd.Stderr = stdout
+	cmd.Env = append(os.Environ(), "DOCKER_BUILDKIT=1")
+	if host := p.DockerHost(); host != "" {
+		cmd.Env = append(cmd.Env, "DOCKER_HOST="+host)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("build image: %w: %s", err, strings.TrimSpace(output.String()))
+	}
+
+	if buildOptions.Labels[core.LabelSessionID] != "" {
+		if err := p.registerBuiltImage(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	// the first tag is the one we want
+	return buildOptions.Tags[0], nil
+}
+
+// buildKitArgs returns the arguments of docker build for the build options, the secrets and the SSH forwardings,
+// reading the build context from the standard input.
+func buildKitArgs(buildOptions types.ImageBuildOptions, info buildKitSessionInfo) []string {
+	args := []string{"build", "--progress", "plain", "--file", buildOptions.Dockerfile}
+
+	for _, tag := range buildOptions.Tags {
+		args = append(args, "--tag", tag)
+	}
+
+	buildArgs := make([]string, 0, len(buildOptions.BuildArgs))
+	for k := range buildOptions.BuildArgs {
+		buildArgs = append(buildArgs, k)
+	}
+	sort.Strings(buildArgs)
+	for _, k := range buildArgs {
+		// a nil value takes the value of the environment variable of the same name, as with the Docker API
+		if v := buildOptions.BuildArgs[k]; v != nil {
+			args = append(args, "--build-arg", k+"="+*v)
+		} else {
+			args = append(args, "--build-arg", k)
+		}
+	}
+
+	args = append(args, labelArgs(buildOptions.Labels)...)
+
+	if buildOptions.Target != "" {
+		args = append(args, "--target", buildOptions.Target)
+	}
+	if buildOptions.Platform != "" {
+		args = append(args, "--platform", buildOptions.Platform)
+	}
+	if buildOptions.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if buildOptions.PullParent {
+		args = append(args, "--pull")
+	}
+
+	for _, secret := range info.GetBuildSecrets() {
+		if secret.File != "" {
+			args = append(args, "--secret", "id="+secret.ID+",src="+secret.File)
+		} else {
+			args = append(args, "--secret", "id="+secret.ID+",env="+secret.Env)
+		}
+	}
+
+	for _, ssh := range info.GetBuildSSH() {
+		id := ssh.ID
+		if id == "" {
+			id = "default"
+		}
+
+		if len(ssh.Paths) > 0 {
+			id += "=" + strings.Join(ssh.Paths, ",")
+		}
+
+		args = append(args, "--ssh", id)
+	}
+
+	return append(args, "-")
+}
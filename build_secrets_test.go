@@ -0,0 +1,67 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRequest_validateBuildSecrets(t *testing.T) {
+	validate := func(secrets ...BuildSecret) error {
+		req := ContainerRequest{FromDockerfile: FromDockerfile{Context: ".", Secrets: secrets}}
+		return req.validateBuildSecrets()
+	}
+
+	require.NoError(t, validate())
+	require.NoError(t, validate(BuildSecret{ID: "netrc", File: "/home/user/.netrc"}, BuildSecret{ID: "token", Env: "GITHUB_TOKEN"}))
+	require.ErrorContains(t, validate(BuildSecret{File: "/home/user/.netrc"}), "id must be specified")
+	require.ErrorContains(t, validate(BuildSecret{ID: "netrc"}), "exactly one of File and Env")
+	require.ErrorContains(t, validate(BuildSecret{ID: "netrc", File: "/home/user/.netrc", Env: "NETRC"}), "exactly one of File and Env")
+}
+
+func TestBuildKitArgs(t *testing.T) {
+	goproxy := "https://proxy.golang.org"
+
+	req := &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context: ".",
+			Secrets: []BuildSecret{
+				{ID: "netrc", File: "/home/user/.netrc"},
+				{ID: "token", Env: "GITHUB_TOKEN"},
+			},
+			SSH: []BuildSSH{
+				{},
+				{ID: "deploy", Paths: []string{"/keys/deploy"}},
+			},
+		},
+	}
+
+	info, ok := usesBuildKitSession(req)
+	require.True(t, ok)
+
+	args := buildKitArgs(types.ImageBuildOptions{
+		Dockerfile: "Dockerfile",
+		Tags:       []string{"app:test"},
+		BuildArgs:  map[string]*string{"GOPROXY": &goproxy, "GOFLAGS": nil},
+		Labels:     map[string]string{"org.testcontainers": "true"},
+		Target:     "test",
+	}, info)
+
+	require.Equal(t, []string{
+		"build", "--progress", "plain", "--file", "Dockerfile",
+		"--tag", "app:test",
+		"--build-arg", "GOFLAGS",
+		"--build-arg", "GOPROXY=https://proxy.golang.org",
+		"--label", "org.testcontainers=true",
+		"--target", "test",
+		"--secret", "id=netrc,src=/home/user/.netrc",
+		"--secret", "id=token,env=GITHUB_TOKEN",
+		"--ssh", "default",
+		"--ssh", "deploy=/keys/deploy",
+		"-",
+	}, args)
+
+	_, ok = usesBuildKitSession(&ContainerRequest{FromDockerfile: FromDockerfile{Context: "."}})
+	require.False(t, ok)
+}
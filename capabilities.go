@@ -0,0 +1,66 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotSupported is returned when a feature is not supported by the container runtime of the provider.
+// The errors wrapping it name the missing Capability.
+var ErrNotSupported = errors.New("not supported by the container runtime")
+
+// Capability is a feature that a container runtime may not support, e.g. when running containerd without dockerd.
+type Capability string
+
+// The capabilities of the container runtimes. The Docker provider supports all of them.
+const (
+	// CapabilityReaper is the removal of the containers at the end of the test session by the reaper.
+	CapabilityReaper Capability = "reaper"
+	// CapabilityImageBuild is the build of the images from a Dockerfile, see FromDockerfile.
+	CapabilityImageBuild Capability = "image-build"
+	// CapabilityReuse is the reuse of the containers by name, see GenericContainerRequest.Reuse.
+	CapabilityReuse Capability = "reuse"
+	// CapabilityHostPortAccess is the access to the ports of the host from the containers, see ContainerRequest.HostAccessPorts.
+	CapabilityHostPortAccess Capability = "host-port-access"
+	// CapabilityNetworkAliases is the resolution of the containers by their network aliases, see ContainerRequest.NetworkAliases.
+	CapabilityNetworkAliases Capability = "network-aliases"
+	// CapabilityNetworkConnect is the connection of the running containers to networks, see ConnectNetwork.
+	CapabilityNetworkConnect Capability = "network-connect"
+	// CapabilityFilesystemChanges is the listing of the changes of the filesystem of the containers, see Changes.
+	CapabilityFilesystemChanges Capability = "filesystem-changes"
+	// CapabilityNetworkShaping is the degradation of the network traffic of the containers, see ShapeNetwork.
+	CapabilityNetworkShaping Capability = "network-shaping"
+	// CapabilityPacketCapture is the capture of the network traffic of the containers, see StartPacketCapture.
+	CapabilityPacketCapture Capability = "packet-capture"
+	// CapabilityPause is the freeze of the processes of the containers, see Pause.
+	CapabilityPause Capability = "pause"
+	// CapabilityHealthCheck is the health check of the containers, see ContainerRequest.HealthCheck.
+	CapabilityHealthCheck Capability = "health-check"
+	// CapabilityStdin is the attachment of a reader to the stdin of the containers, see ContainerRequest.Stdin.
+	CapabilityStdin Capability = "stdin"
+	// CapabilityBindMounts is the bind mount of the files and directories of the host, see ContainerRequest.Files and BindMount.
+	CapabilityBindMounts Capability = "bind-mounts"
+)
+
+// CapabilitiesProvider is implemented by the providers of the container runtimes that don't support
+// all the capabilities, to detect them before creating the containers.
+type CapabilitiesProvider interface {
+	// Supports returns true if the container runtime supports the capability.
+	Supports(ctx context.Context, capability Capability) bool
+}
+
+// Supports returns true if the container runtime of the provider supports the capability.
+// The providers not implementing CapabilitiesProvider, like the Docker provider, support all of them.
+func Supports(ctx context.Context, provider any, capability Capability) bool {
+	if p, ok := provider.(CapabilitiesProvider); ok {
+		return p.Supports(ctx, capability)
+	}
+
+	return true
+}
+
+// notSupportedError returns the error for a capability not supported by the container runtime.
+func notSupportedError(capability Capability) error {
+	return fmt.Errorf("%s: %w", capability, ErrNotSupported)
+}
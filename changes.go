@@ -0,0 +1,101 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ErrUnexpectedWrites is returned by CheckNoWritesOutside when the container wrote outside the allowed directories.
+var ErrUnexpectedWrites = errors.New("unexpected writes to the container filesystem")
+
+// changesLister is implemented by the containers listing the changes of their filesystem, see Changes.
+type changesLister interface {
+	Changes(ctx context.Context) ([]container.FilesystemChange, error)
+}
+
+// Changes returns the changes of the filesystem of the container since it was created, as docker diff does.
+func Changes(ctx context.Context, ctr Container) ([]container.FilesystemChange, error) {
+	l, ok := asContainer[changesLister](ctr)
+	if !ok {
+		return nil, notSupportedError(CapabilityFilesystemChanges)
+	}
+
+	return l.Changes(ctx)
+}
+
+// Changes returns the changes of the filesystem of the container since it was created, as docker diff does,
+// i.e. the files and directories added, modified or deleted in its writable layer. The writes to the volumes
+// and to the tmpfs mounts are not part of the changes.
+func (c *DockerContainer) Changes(ctx context.Context) ([]container.FilesystemChange, error) {
+	changes, err := c.provider.client.ContainerDiff(ctx, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("container diff: %w", err)
+	}
+	defer c.provider.Close()
+
+	return changes, nil
+}
+
+// Changes is not supported by nerdctl, it returns an error wrapping ErrNotSupported.
+func (c *NerdctlContainer) Changes(context.Context) ([]container.FilesystemChange, error) {
+	return nil, notSupportedError(CapabilityFilesystemChanges)
+}
+
+// WritesOutside returns the changes outside the allowed directories, e.g. /tmp and /var/log, ignoring
+// the modification of their parent directories, e.g. /var, which is reported when a file is added to /var/log.
+func WritesOutside(changes []container.FilesystemChange, allowed ...string) []container.FilesystemChange {
+	var outside []container.FilesystemChange
+	for _, change := range changes {
+		if !allowedChange(change, allowed) {
+			outside = append(outside, change)
+		}
+	}
+
+	return outside
+}
+
+// allowedChange returns true if the change is in an allowed directory, or is the modification
+// of one of their parent directories.
+func allowedChange(change container.FilesystemChange, allowed []string) bool {
+	p := path.Clean("/" + change.Path)
+	for _, dir := range allowed {
+		dir = path.Clean("/" + dir)
+
+		if p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/") {
+			return true
+		}
+
+		if change.Kind == container.ChangeModify && (p == "/" || strings.HasPrefix(dir, p+"/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckNoWritesOutside returns an error wrapping ErrUnexpectedWrites, listing the changes, if the container
+// changed its filesystem outside the allowed directories, e.g. to validate that it runs with a read-only root
+// filesystem, writing only to /tmp and /var/log.
+func CheckNoWritesOutside(ctx context.Context, ctr Container, allowed ...string) error {
+	changes, err := Changes(ctx, ctr)
+	if err != nil {
+		return err
+	}
+
+	outside := WritesOutside(changes, allowed...)
+	if len(outside) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(outside))
+	for _, change := range outside {
+		lines = append(lines, change.Kind.String()+" "+change.Path)
+	}
+
+	return fmt.Errorf("%w:\n%s", ErrUnexpectedWrites, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,83 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChangesContainer is a container returning the given changes of its filesystem.
+type fakeChangesContainer struct {
+	Container
+	changes []container.FilesystemChange
+}
+
+func (c fakeChangesContainer) Changes(context.Context) ([]container.FilesystemChange, error) {
+	return c.changes, nil
+}
+
+func TestWritesOutside(t *testing.T) {
+	changes := []container.FilesystemChange{
+		{Kind: container.ChangeModify, Path: "/var"},
+		{Kind: container.ChangeModify, Path: "/var/log"},
+		{Kind: container.ChangeAdd, Path: "/var/log/app.log"},
+		{Kind: container.ChangeModify, Path: "/tmp"},
+		{Kind: container.ChangeAdd, Path: "/tmp/cache"},
+		{Kind: container.ChangeAdd, Path: "/tmpfile"},
+		{Kind: container.ChangeModify, Path: "/etc"},
+		{Kind: container.ChangeAdd, Path: "/etc/app.conf"},
+		{Kind: container.ChangeDelete, Path: "/var/lib"},
+	}
+
+	require.Equal(t, []container.FilesystemChange{
+		{Kind: container.ChangeAdd, Path: "/tmpfile"},
+		{Kind: container.ChangeModify, Path: "/etc"},
+		{Kind: container.ChangeAdd, Path: "/etc/app.conf"},
+		{Kind: container.ChangeDelete, Path: "/var/lib"},
+	}, WritesOutside(changes, "/tmp", "/var/log/"))
+
+	require.Len(t, WritesOutside(changes), len(changes))
+}
+
+func TestCheckNoWritesOutside(t *testing.T) {
+	ctx := context.Background()
+
+	ctr := fakeChangesContainer{changes: []container.FilesystemChange{
+		{Kind: container.ChangeModify, Path: "/tmp"},
+		{Kind: container.ChangeAdd, Path: "/tmp/cache"},
+	}}
+	require.NoError(t, CheckNoWritesOutside(ctx, ctr, "/tmp", "/var/log"))
+
+	ctr.changes = append(ctr.changes, container.FilesystemChange{Kind: container.ChangeAdd, Path: "/app/data.db"})
+	err := CheckNoWritesOutside(ctx, ctr, "/tmp", "/var/log")
+	require.ErrorIs(t, err, ErrUnexpectedWrites)
+	require.ErrorContains(t, err, "A /app/data.db")
+}
+
+func TestDockerContainer_Changes(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "alpine:3.20",
+			Cmd:   []string{"sh", "-c", "echo started > /tmp/started && echo data > /data.db && sleep infinity"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		changes, err := Changes(ctx, ctr)
+		return err == nil && len(WritesOutside(changes, "/tmp")) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// checkNoWritesOutside {
+	err = CheckNoWritesOutside(ctx, ctr, "/tmp", "/var/log")
+	// }
+	require.ErrorIs(t, err, ErrUnexpectedWrites)
+	require.ErrorContains(t, err, "A /data.db")
+}
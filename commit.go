@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// CommitOptions are the options of CommitAndPush.
+type CommitOptions struct {
+	// Author is the author of the image, e.g. "Jane Doe <jane@example.com>".
+	Author string
+	// Message is the commit message of the image.
+	Message string
+	// Changes are the Dockerfile instructions applied to the image, e.g. `CMD ["app", "--fixtures"]`.
+	Changes []string
+	// Labels are added to the labels of the image.
+	Labels map[string]string
+	// NoPause does not pause the container during the commit.
+	NoPause bool
+	// SkipPush only commits the image, without pushing it, e.g. to reuse it locally.
+	SkipPush bool
+}
+
+// commitLabels returns the labels of the committed image: the labels of the options, and the session label
+// of the container unset, so the image is not reaped with the session.
+func (o CommitOptions) commitLabels() map[string]string {
+	labels := map[string]string{
+		core.LabelSessionID: "",
+	}
+	for k, v := range o.Labels {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// committer is implemented by the containers which can be committed into images, see CommitAndPush.
+type committer interface {
+	CommitAndPush(ctx context.Context, ref string, opts CommitOptions) (string, error)
+}
+
+// CommitAndPush commits the current state of the container into an image with the given reference,
+// and pushes it to its registry unless the SkipPush option is set, returning the ID of the image.
+func CommitAndPush(ctx context.Context, ctr Container, ref string, opts CommitOptions) (string, error) {
+	c, ok := asContainer[committer](ctr)
+	if !ok {
+		return "", unsupportedError(ctr, "commit")
+	}
+
+	return c.CommitAndPush(ctx, ref, opts)
+}
+
+// CommitAndPush commits the current state of the container into an image with the given reference,
+// and pushes it using the registry credentials of the Docker config, returning the ID of the image.
+// The image is not reaped with the session, so it can be reused by tag, e.g. as a golden fixture
+// built once nightly.
+func (c *DockerContainer) CommitAndPush(ctx context.Context, ref string, opts CommitOptions) (string, error) {
+	resp, err := c.provider.client.ContainerCommit(ctx, c.ID, container.CommitOptions{
+		Reference: ref,
+		Comment:   opts.Message,
+		Author:    opts.Author,
+		Changes:   opts.Changes,
+		Pause:     !opts.NoPause,
+		Config: &container.Config{
+			Labels: opts.commitLabels(),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("commit container: %w", err)
+	}
+
+	if opts.SkipPush {
+		return resp.ID, nil
+	}
+
+	if err := c.provider.pushImage(ctx, ref); err != nil {
+		return resp.ID, err
+	}
+
+	return resp.ID, nil
+}
+
+// pushImage pushes the image with the registry credentials of the Docker config, if any.
+func (p *DockerProvider) pushImage(ctx context.Context, ref string) error {
+	registry, imageAuth, err := DockerImageAuth(ctx, ref)
+	if err != nil {
+		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, ref, err)
+	}
+
+	// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
+	encodedJSON, err := json.Marshal(imageAuth)
+	if err != nil {
+		return fmt.Errorf("encode image auth: %w", err)
+	}
+
+	push, err := p.client.ImagePush(ctx, ref, image.PushOptions{
+		RegistryAuth: base64.URLEncoding.EncodeToString(encodedJSON),
+	})
+	if err != nil {
+		return fmt.Errorf("push image %s: %w", ref, err)
+	}
+	defer push.Close()
+
+	// the errors of the push are reported in the stream
+	if err := jsonmessage.DisplayJSONMessagesStream(push, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("push image %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+// CommitAndPush commits the current state of the container into an image with the given reference,
+// and pushes it with nerdctl, returning the ID of the image.
+func (c *NerdctlContainer) CommitAndPush(ctx context.Context, ref string, opts CommitOptions) (string, error) {
+	args := []string{"commit"}
+	if opts.Author != "" {
+		args = append(args, "--author", opts.Author)
+	}
+	if opts.Message != "" {
+		args = append(args, "--message", opts.Message)
+	}
+	for _, change := range opts.Changes {
+		args = append(args, "--change", change)
+	}
+	labels := opts.commitLabels()
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--change", fmt.Sprintf("LABEL %s=%q", k, labels[k]))
+	}
+	if opts.NoPause {
+		args = append(args, "--pause=false")
+	}
+
+	if _, err := c.provider.run(ctx, append(args, c.ID, ref)...); err != nil {
+		return "", err
+	}
+
+	out, err := c.provider.run(ctx, "image", "inspect", "--format", "{{.ID}}", ref)
+	if err != nil {
+		return "", err
+	}
+	id := strings.TrimSpace(string(out))
+
+	if opts.SkipPush {
+		return id, nil
+	}
+
+	if _, err := c.provider.run(ctx, "push", ref); err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
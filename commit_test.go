@@ -0,0 +1,55 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestCommitOptions_commitLabels(t *testing.T) {
+	labels := CommitOptions{Labels: map[string]string{"fixture": "orders"}}.commitLabels()
+
+	require.Equal(t, map[string]string{core.LabelSessionID: "", "fixture": "orders"}, labels)
+}
+
+func TestDockerContainer_CommitAndPush(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	code, _, err := ctr.Exec(ctx, []string{"sh", "-c", "echo golden > /fixture.txt"})
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	ref := "testcontainers/golden:" + core.SessionID()[:12]
+	id, err := CommitAndPush(ctx, ctr, ref, CommitOptions{
+		Message:  "golden fixture",
+		Changes:  []string{`CMD ["cat", "/fixture.txt"]`},
+		Labels:   map[string]string{"fixture": "golden"},
+		SkipPush: true,
+	})
+	require.NoError(t, err)
+
+	cli := ctr.(*DockerContainer).provider.client
+	t.Cleanup(func() {
+		_, err := cli.ImageRemove(ctx, id, image.RemoveOptions{Force: true, PruneChildren: true})
+		require.NoError(t, err)
+	})
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, id, inspect.ID)
+	require.Equal(t, "golden fixture", inspect.Comment)
+	require.Equal(t, "golden", inspect.Config.Labels["fixture"])
+	require.Empty(t, inspect.Config.Labels[core.LabelSessionID])
+	require.Equal(t, []string{"cat", "/fixture.txt"}, []string(inspect.Config.Cmd))
+}
@@ -4,6 +4,10 @@ import (
 	"github.com/testcontainers/testcontainers-go/internal/config"
 )
 
+// Config represents the configuration for Testcontainers, read from the properties file
+// and the environment variables. See SetConfig to override it from Go code.
+type Config = config.Config
+
 // TestcontainersConfig represents the configuration for Testcontainers
 type TestcontainersConfig struct {
 	Host           string `properties:"docker.host,default="`                    // Deprecated: use Config.Host instead
@@ -27,3 +31,18 @@ func ReadConfig() TestcontainersConfig {
 		Config:         cfg,
 	}
 }
+
+// SetConfig overrides the configuration of Testcontainers for Go from Go code, ignoring the
+// ~/.testcontainers.properties file and the environment variables. Start from the current
+// configuration to override only some properties:
+//
+//	cfg := testcontainers.ReadConfig().Config
+//	cfg.RyukDisabled = true
+//	testcontainers.SetConfig(cfg)
+//
+// Several resources, like the Docker host, the reaper or the resource budget, read the configuration
+// once and reuse it for the whole test session, so SetConfig must be called before any container is
+// created, e.g. in an init function or TestMain. It is not safe for concurrent use.
+func SetConfig(cfg Config) {
+	config.Set(cfg)
+}
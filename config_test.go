@@ -0,0 +1,23 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestSetConfig(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	cfg := ReadConfig().Config
+	cfg.RyukDisabled = true
+	cfg.HubImageNamePrefix = "registry.example.com/mirror"
+
+	SetConfig(cfg)
+
+	tcConfig := ReadConfig()
+	require.Equal(t, cfg, tcConfig.Config)
+	require.True(t, tcConfig.RyukDisabled)
+}
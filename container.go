@@ -8,7 +8,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/cpuguy83/dockercfg"
@@ -37,20 +39,21 @@ type DeprecatedContainer interface {
 
 // Container allows getting info about and controlling a single container instance
 type Container interface {
-	GetContainerID() string                                         // get the container id from the provider
-	Endpoint(context.Context, string) (string, error)               // get proto://ip:port string for the lowest exposed port
-	PortEndpoint(context.Context, nat.Port, string) (string, error) // get proto://ip:port string for the given exposed port
-	Host(context.Context) (string, error)                           // get host where the container port is exposed
-	Inspect(context.Context) (*types.ContainerJSON, error)          // get container info
-	MappedPort(context.Context, nat.Port) (nat.Port, error)         // get externally mapped port for a container port
-	Ports(context.Context) (nat.PortMap, error)                     // Deprecated: Use c.Inspect(ctx).NetworkSettings.Ports instead
-	SessionID() string                                              // get session id
-	IsRunning() bool                                                // IsRunning returns true if the container is running, false otherwise.
-	Start(context.Context) error                                    // start the container
-	Stop(context.Context, *time.Duration) error                     // stop the container
+	GetContainerID() string                                                  // get the container id from the provider
+	Endpoint(context.Context, string) (string, error)                        // get proto://ip:port string for the lowest exposed port
+	PortEndpoint(context.Context, nat.Port, string) (string, error)          // get proto://ip:port string for the given exposed port
+	Host(context.Context) (string, error)                                    // get host where the container port is exposed
+	Inspect(context.Context, ...InspectOption) (*types.ContainerJSON, error) // get container info
+	MappedPort(context.Context, nat.Port) (nat.Port, error)                  // get externally mapped port for a container port
+	Ports(context.Context) (nat.PortMap, error)                              // Deprecated: Use c.Inspect(ctx).NetworkSettings.Ports instead
+	SessionID() string                                                       // get session id
+	IsRunning() bool                                                         // IsRunning returns true if the container is running, false otherwise.
+	Start(context.Context) error                                             // start the container
+	Stop(context.Context, *time.Duration) error                              // stop the container
 
 	// Terminate stops and removes the container and its image if it was built and not flagged as kept.
-	Terminate(ctx context.Context) error
+	// The options can stop the container gracefully before removing it, or keep its volumes.
+	Terminate(ctx context.Context, opts ...TerminateOption) error
 
 	Logs(context.Context) (io.ReadCloser, error)                    // Get logs of the container
 	FollowOutput(LogConsumer)                                       // Deprecated: it will be removed in the next major release
@@ -70,6 +73,15 @@ type Container interface {
 	GetLogProductionErrorChannel() <-chan error
 }
 
+// InspectOption customizes the inspection of a container, see Container.Inspect.
+type InspectOption = wait.InspectOption
+
+// Refresh makes Container.Inspect query the container runtime, instead of returning the info
+// cached by the container, e.g. to read the health status of a running container.
+func Refresh() InspectOption {
+	return wait.Refresh()
+}
+
 // ImageBuildInfo defines what is needed to build an image
 type ImageBuildInfo interface {
 	BuildOptions() (types.ImageBuildOptions, error) // converts the ImageBuildInfo to a types.ImageBuildOptions
@@ -91,7 +103,8 @@ type FromDockerfile struct {
 	Dockerfile     string                         // the path from the context to the Dockerfile for the image, defaults to "Dockerfile"
 	Repo           string                         // the repo label for image, defaults to UUID
 	Tag            string                         // the tag label for image, defaults to UUID
-	BuildArgs      map[string]*string             // enable user to pass build args to docker daemon
+	BuildArgs      map[string]*string             // enable user to pass build args to docker daemon, see BuildArgsFromEnv and ExpandBuildArgs
+	Target         string                         // the stage of a multi-stage Dockerfile to build, defaults to the last stage
 	PrintBuildLog  bool                           // enable user to print build log
 	AuthConfigs    map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Enable auth configs to be able to pull from an authenticated docker registry
 	// KeepImage describes whether DockerContainer.Terminate should not delete the
@@ -102,6 +115,12 @@ type FromDockerfile struct {
 	// advanced configurations while building the image. Please consider that the modifier
 	// is called after the default build options are set.
 	BuildOptionsModifier func(*types.ImageBuildOptions)
+	// Secrets are the BuildKit secrets of the build, which the RUN instructions of the Dockerfile mount
+	// with --mount=type=secret, so they are not stored in the layers of the image.
+	Secrets []BuildSecret
+	// SSH are the SSH agent sockets or keys forwarded to the build, which the RUN instructions of the Dockerfile
+	// mount with --mount=type=ssh, e.g. to download private Go modules.
+	SSH []BuildSSH
 }
 
 type ContainerFile struct {
@@ -124,6 +143,60 @@ func (c *ContainerFile) validate() error {
 	return nil
 }
 
+// HealthCheck represents the Docker healthcheck to be run for the container, overriding
+// the one defined in the image, if any. Zero values inherit the value from the image,
+// or the engine default if the image does not define it.
+type HealthCheck struct {
+	// Test is the test to perform to check the container is healthy. Possible values are:
+	//   - {} : inherit the test from the image
+	//   - {"NONE"} : disable the healthcheck
+	//   - {"CMD", args...} : exec arguments directly
+	//   - {"CMD-SHELL", command} : run the command with the system's default shell
+	Test          []string
+	Interval      time.Duration // time to wait between checks
+	Timeout       time.Duration // time to wait before considering the check to have hung
+	StartPeriod   time.Duration // start period for the container to initialize before failing checks count towards the retries
+	StartInterval time.Duration // time to wait between checks during the start period
+	Retries       int           // number of consecutive failures needed to consider the container as unhealthy
+}
+
+// validate validates the HealthCheck
+func (h *HealthCheck) validate() error {
+	if len(h.Test) > 0 {
+		switch h.Test[0] {
+		case "NONE":
+		case "CMD", "CMD-SHELL":
+			if len(h.Test) < 2 {
+				return fmt.Errorf("healthcheck test %q requires a command", h.Test[0])
+			}
+		default:
+			return fmt.Errorf("healthcheck test must start with NONE, CMD or CMD-SHELL, got %q", h.Test[0])
+		}
+	}
+
+	if h.Interval < 0 || h.Timeout < 0 || h.StartPeriod < 0 || h.StartInterval < 0 {
+		return errors.New("healthcheck durations must not be negative")
+	}
+
+	if h.Retries < 0 {
+		return errors.New("healthcheck retries must not be negative")
+	}
+
+	return nil
+}
+
+// dockerConfig converts the HealthCheck into the Docker representation
+func (h *HealthCheck) dockerConfig() *container.HealthConfig {
+	return &container.HealthConfig{
+		Test:          h.Test,
+		Interval:      h.Interval,
+		Timeout:       h.Timeout,
+		StartPeriod:   h.StartPeriod,
+		StartInterval: h.StartInterval,
+		Retries:       h.Retries,
+	}
+}
+
 // ContainerRequest represents the parameters used to get a running container
 type ContainerRequest struct {
 	FromDockerfile
@@ -165,6 +238,13 @@ type ContainerRequest struct {
 	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
 	LifecycleHooks          []ContainerLifecycleHooks                  // define hooks to be executed during container lifecycle
 	LogConsumerCfg          *LogConsumerConfig                         // define the configuration for the log producer and its log consumers to follow the logs
+	Stdin                   io.Reader                                  // attach this reader to the container's stdin when it starts, closing stdin when the reader returns EOF
+	HealthCheck             *HealthCheck                               // define the healthcheck of the container, overriding the one from the image
+	SensitiveValues         []string                                   // values masked in the logs and the errors of the library, see WithSecretEnv
+	AccessToHost            bool                                       // make the host reachable from the container, see WithAccessToHost
+	HostNetwork             bool                                       // run the container in the host network mode, validating it's supported, see WithHostNetwork
+	OperationTimeouts       *OperationTimeouts                         // the timeouts of the pull, the start and the stop of the container, overriding the ones of the configuration
+	KeepOnFailure           testing.TB                                 // keep the container running when it's terminated after the test failed, see SkipTerminationOnFailure
 }
 
 // containerOptions functional options for a container
@@ -193,24 +273,34 @@ func WithRegistryCredentials(registryCredentials string) ContainerOption {
 	}
 }
 
+var (
+	ErrInvalidPort = errors.New("invalid port")
+	ErrInvalidEnv  = errors.New("invalid environment variable")
+)
+
 // Validate ensures that the ContainerRequest does not have invalid parameters configured to it
-// ex. make sure you are not specifying both an image as well as a context
+// ex. make sure you are not specifying both an image as well as a context.
+// It doesn't call the Docker daemon, and returns all the errors found, joined, so they can be fixed at once.
 func (c *ContainerRequest) Validate() error {
 	validationMethods := []func() error{
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
+		c.validateExposedPorts,
+		c.validateHostAccessPorts,
+		c.validateEnv,
 		c.validateMounts,
+		c.validateHealthCheck,
+		c.validateBuildSecrets,
 	}
 
-	var err error
+	var errs []error
 	for _, validationMethod := range validationMethods {
-		err = validationMethod()
-		if err != nil {
-			return err
+		if err := validationMethod(); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetContext retrieve the build context for the request
@@ -421,6 +511,14 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	// apply mandatory values after the modifier
 	buildOptions.BuildArgs = c.GetBuildArgs()
 	buildOptions.Dockerfile = c.GetDockerfile()
+	if c.FromDockerfile.Target != "" {
+		buildOptions.Target = c.FromDockerfile.Target
+	}
+	if buildOptions.Platform == "" {
+		// build the platform the container is created for, which the containerd image store
+		// requires to find the built image
+		buildOptions.Platform = c.ImagePlatform
+	}
 
 	// Make sure the auth configs from the Dockerfile are set right after the user-defined build options.
 	authsFromDockerfile, err := getAuthConfigsFromDockerfile(c)
@@ -459,8 +557,17 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 		buildOptions.Tags = []string{tag}
 	}
 
+	if buildOptions.Labels == nil {
+		buildOptions.Labels = map[string]string{}
+	}
+
+	// the built images are tagged with the session that built them, see PruneBuiltImages,
+	// and the images not kept are reaped with the session
+	buildOptions.Labels[core.LabelImageBuilt] = core.SessionID()
 	if !c.ShouldKeepBuiltImage() {
-		buildOptions.Labels = core.DefaultLabels(core.SessionID())
+		for k, v := range core.DefaultLabels(core.SessionID()) {
+			buildOptions.Labels[k] = v
+		}
 	}
 
 	// Do this as late as possible to ensure we don't leak the context on error/panic.
@@ -475,7 +582,7 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 }
 
 func (c *ContainerRequest) validateContextAndImage() error {
-	if c.FromDockerfile.Context != "" && c.Image != "" {
+	if (c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil) && c.Image != "" {
 		return errors.New("you cannot specify both an Image and Context in a ContainerRequest")
 	}
 
@@ -490,6 +597,75 @@ func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
 	return nil
 }
 
+// commandWarnings returns the warnings about the executable of the container, which is the first element
+// of the entrypoint, or of the command if there is no entrypoint, when it looks like a mistake: an empty string,
+// or a whole command line, e.g. "redis-server --port 6380". They are only warnings, as the Docker daemon
+// accepts them, e.g. an empty entrypoint resets the entrypoint of the image, and paths can contain spaces.
+func (c *ContainerRequest) commandWarnings() []string {
+	field, args := "Entrypoint", c.Entrypoint
+	if len(args) == 0 {
+		field, args = "Cmd", c.Cmd
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	executable := args[0]
+	switch {
+	case strings.TrimSpace(executable) == "" && field == "Cmd":
+		return []string{"the first element of Cmd should be the executable, got an empty string"}
+	case strings.ContainsAny(executable, " \t\n") && !strings.Contains(executable, `\`):
+		return []string{fmt.Sprintf("the first element of %s should be the executable, got %q: split the command line into one element per argument, unless it's a path with spaces", field, executable)}
+	}
+
+	return nil
+}
+
+// validateExposedPorts ensures that the exposed ports are valid port specs,
+// e.g. 80, 80/tcp, 8080:80/tcp or 127.0.0.1:8080:80/tcp.
+func (c *ContainerRequest) validateExposedPorts() error {
+	var errs []error
+	for _, spec := range c.ExposedPorts {
+		if _, err := nat.ParsePortSpec(spec); err != nil {
+			errs = append(errs, fmt.Errorf("%w: exposed port %q: %w", ErrInvalidPort, spec, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateHostAccessPorts ensures that the host ports exposed to the container are valid port numbers.
+func (c *ContainerRequest) validateHostAccessPorts() error {
+	var errs []error
+	for _, port := range c.HostAccessPorts {
+		if port < 1 || port > 65535 {
+			errs = append(errs, fmt.Errorf("%w: host access port %d must be between 1 and 65535", ErrInvalidPort, port))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateEnv ensures that the names of the environment variables are not empty,
+// and do not contain the = separator or whitespaces.
+func (c *ContainerRequest) validateEnv() error {
+	names := make([]string, 0, len(c.Env))
+	for name := range c.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, "= \t\n\x00") {
+			errs = append(errs, fmt.Errorf("%w: name %q must not be empty, nor contain '=' or whitespaces", ErrInvalidEnv, name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // validateMounts ensures that the mounts do not have duplicate targets.
 // It will check the Mounts and HostConfigModifier.Binds fields.
 func (c *ContainerRequest) validateMounts() error {
@@ -498,6 +674,10 @@ func (c *ContainerRequest) validateMounts() error {
 	for idx := range c.Mounts {
 		m := c.Mounts[idx]
 		targetPath := m.Target.Target()
+		if targetPath == "" {
+			return fmt.Errorf("%w: empty target", ErrInvalidMount)
+		}
+
 		if targets[targetPath] {
 			return fmt.Errorf("%w: %s", ErrDuplicateMountTarget, targetPath)
 		} else {
@@ -515,8 +695,13 @@ func (c *ContainerRequest) validateMounts() error {
 
 	if hostConfig.Binds != nil && len(hostConfig.Binds) > 0 {
 		for _, bind := range hostConfig.Binds {
+			// source:target[:options]
 			parts := strings.Split(bind, ":")
-			if len(parts) != 2 {
+			if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("%w: %s", ErrInvalidBindMount, bind)
+			}
+
+			if len(parts) == 3 && !validBindOptions(parts[2]) {
 				return fmt.Errorf("%w: %s", ErrInvalidBindMount, bind)
 			}
 			targetPath := parts[1]
@@ -530,3 +715,30 @@ func (c *ContainerRequest) validateMounts() error {
 
 	return nil
 }
+
+// validBindOptions returns true if the comma-separated options of a bind mount are supported by Docker.
+func validBindOptions(options string) bool {
+	for _, opt := range strings.Split(options, ",") {
+		switch opt {
+		case "ro", "rw", "z", "Z", "nocopy", "consistent", "cached", "delegated",
+			"shared", "rshared", "slave", "rslave", "private", "rprivate":
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateHealthCheck ensures that the healthcheck, if defined, is valid.
+func (c *ContainerRequest) validateHealthCheck() error {
+	if c.HealthCheck == nil {
+		return nil
+	}
+
+	if err := c.HealthCheck.validate(); err != nil {
+		return fmt.Errorf("invalid healthcheck: %w", err)
+	}
+
+	return nil
+}
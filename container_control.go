@@ -0,0 +1,148 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// The Container interface only has the methods common to all the containers. The other features of
+// the containers are implemented by DockerContainer and NerdctlContainer, and used with the functions
+// of the package taking a Container, e.g. Pause, which return an error wrapping ErrNotSupported
+// for the containers not implementing them.
+
+// pauser is implemented by the containers whose processes can be frozen, see Pause.
+type pauser interface {
+	Pause(ctx context.Context) error
+	Unpause(ctx context.Context) error
+}
+
+// signalStopper is implemented by the containers which can be stopped with a signal, see StopWithSignal.
+type signalStopper interface {
+	StopWithSignal(ctx context.Context, signal string, timeout *time.Duration) error
+}
+
+// signaler is implemented by the containers whose processes can be signaled, see Signal.
+type signaler interface {
+	Signal(ctx context.Context, signal string) error
+}
+
+// processKiller is implemented by the containers whose processes can be killed, see KillProcess.
+type processKiller interface {
+	KillProcess(ctx context.Context, pattern string) error
+}
+
+// networkConnector is implemented by the containers which can be connected to networks when running,
+// see ConnectNetwork.
+type networkConnector interface {
+	ConnectNetwork(ctx context.Context, networkName string, aliases ...string) error
+	DisconnectNetwork(ctx context.Context, networkName string) error
+}
+
+// Pause pauses all the processes of the container, simulating a freeze.
+func Pause(ctx context.Context, ctr Container) error {
+	p, ok := asContainer[pauser](ctr)
+	if !ok {
+		return notSupportedError(CapabilityPause)
+	}
+
+	return p.Pause(ctx)
+}
+
+// Unpause unpauses all the processes of a paused container, see Pause.
+func Unpause(ctx context.Context, ctr Container) error {
+	p, ok := asContainer[pauser](ctr)
+	if !ok {
+		return notSupportedError(CapabilityPause)
+	}
+
+	return p.Unpause(ctx)
+}
+
+// StopWithSignal stops the container sending the given signal, e.g. SIGTERM, instead of the stop signal
+// of the image, and killing it if it didn't exit after the timeout. A nil timeout uses the default one.
+func StopWithSignal(ctx context.Context, ctr Container, signal string, timeout *time.Duration) error {
+	s, ok := asContainer[signalStopper](ctr)
+	if !ok {
+		return unsupportedError(ctr, "stop with signal")
+	}
+
+	return s.StopWithSignal(ctx, signal, timeout)
+}
+
+// Signal sends the signal, e.g. SIGHUP, to the main process of the container, without stopping it,
+// unless the process exits on the signal.
+func Signal(ctx context.Context, ctr Container, signal string) error {
+	s, ok := asContainer[signaler](ctr)
+	if !ok {
+		return unsupportedError(ctr, "signal")
+	}
+
+	return s.Signal(ctx, signal)
+}
+
+// KillProcess kills the processes of the container whose full command line matches the extended regular
+// expression, e.g. a process of a multi-process container run by supervisord, to test its restart.
+func KillProcess(ctx context.Context, ctr Container, pattern string) error {
+	k, ok := asContainer[processKiller](ctr)
+	if !ok {
+		return unsupportedError(ctr, "kill process")
+	}
+
+	return k.KillProcess(ctx, pattern)
+}
+
+// ConnectNetwork connects the running container to the network, with the given aliases,
+// e.g. to recover from a network partition simulated with DisconnectNetwork.
+func ConnectNetwork(ctx context.Context, ctr Container, networkName string, aliases ...string) error {
+	n, ok := asContainer[networkConnector](ctr)
+	if !ok {
+		return notSupportedError(CapabilityNetworkConnect)
+	}
+
+	return n.ConnectNetwork(ctx, networkName, aliases...)
+}
+
+// DisconnectNetwork disconnects the running container from the network, e.g. to simulate
+// a network partition between the members of a cluster.
+func DisconnectNetwork(ctx context.Context, ctr Container, networkName string) error {
+	n, ok := asContainer[networkConnector](ctr)
+	if !ok {
+		return notSupportedError(CapabilityNetworkConnect)
+	}
+
+	return n.DisconnectNetwork(ctx, networkName)
+}
+
+// asContainer returns the container, or the container it wraps, implementing T. The containers
+// of the modules wrap the container by embedding the Container interface, which doesn't promote
+// the methods of the wrapped container missing from the interface.
+func asContainer[T any](ctr Container) (T, bool) {
+	for ctr != nil {
+		if t, ok := ctr.(T); ok {
+			return t, true
+		}
+
+		v := reflect.Indirect(reflect.ValueOf(ctr))
+		if v.Kind() != reflect.Struct {
+			break
+		}
+
+		field, ok := v.Type().FieldByName("Container")
+		if !ok || !field.Anonymous || len(field.Index) != 1 {
+			break
+		}
+
+		ctr, _ = v.Field(field.Index[0]).Interface().(Container)
+	}
+
+	var zero T
+	return zero, false
+}
+
+// unsupportedError returns the error for a feature not implemented by the container,
+// e.g. a custom implementation of Container.
+func unsupportedError(ctr Container, feature string) error {
+	return fmt.Errorf("%s of %T: %w", feature, ctr, ErrNotSupported)
+}
@@ -0,0 +1,65 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pausableContainer is a container implementing only the pause of the optional features.
+type pausableContainer struct {
+	Container
+	paused bool
+}
+
+func (c *pausableContainer) Pause(context.Context) error {
+	c.paused = true
+	return nil
+}
+
+func (c *pausableContainer) Unpause(context.Context) error {
+	c.paused = false
+	return nil
+}
+
+// moduleContainer wraps a container as the containers of the modules do.
+type moduleContainer struct {
+	Container
+}
+
+func TestOptionalContainerFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("implemented", func(t *testing.T) {
+		ctr := &pausableContainer{}
+
+		require.NoError(t, Pause(ctx, ctr))
+		require.True(t, ctr.paused)
+
+		require.NoError(t, Unpause(ctx, ctr))
+		require.False(t, ctr.paused)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		ctr := &pausableContainer{}
+
+		require.NoError(t, Pause(ctx, &moduleContainer{Container: &moduleContainer{Container: ctr}}))
+		require.True(t, ctr.paused)
+	})
+
+	t.Run("not-implemented", func(t *testing.T) {
+		ctr := &pausableContainer{}
+
+		err := Signal(ctx, ctr, "SIGHUP")
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		err = ConnectNetwork(ctx, &moduleContainer{Container: ctr}, "network")
+		require.ErrorIs(t, err, ErrNotSupported)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		err := Pause(ctx, &moduleContainer{})
+		require.ErrorIs(t, err, ErrNotSupported)
+	})
+}
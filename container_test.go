@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
 	"testing"
 	"time"
 
@@ -83,6 +84,134 @@ func Test_ContainerValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:          "Can mount with options",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.Binds = []string{"/data:/data:ro,z"}
+				},
+			},
+		},
+		{
+			Name:          "Invalid bind mount options",
+			ExpectedError: errors.New("invalid bind mount: /data:/data:readonly"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.Binds = []string{"/data:/data:readonly"}
+				},
+			},
+		},
+		{
+			Name:          "Invalid mount without target",
+			ExpectedError: errors.New("invalid mount: empty target"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:  "redis:latest",
+				Mounts: testcontainers.Mounts(testcontainers.VolumeMount("data", "")),
+			},
+		},
+		{
+			Name:          "cannot set both context archive and image",
+			ExpectedError: errors.New("you cannot specify both an Image and Context in a ContainerRequest"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				FromDockerfile: testcontainers.FromDockerfile{
+					ContextArchive: strings.NewReader(""),
+				},
+				Image: "redis:latest",
+			},
+		},
+		{
+			Name:          "Can set entrypoint and command",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"sh", "-c"},
+				Cmd:        []string{"redis-server --port 6380"},
+			},
+		},
+		{
+			Name:          "Can set shell entrypoint with positional parameters",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"sh", "-c", `exec redis-server --port "$1"`},
+				Cmd:        []string{"sh", "6380"},
+			},
+		},
+		{
+			Name:          "Can set executable path with spaces",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"/opt/redis server/redis-server", "--port", "6380"},
+			},
+		},
+		{
+			Name:          "Invalid host access port",
+			ExpectedError: errors.New("invalid port: host access port 70000 must be between 1 and 65535"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:           "redis:latest",
+				HostAccessPorts: []int{8080, 70000},
+			},
+		},
+		{
+			Name:          "Multiple errors are joined",
+			ExpectedError: errors.New(`invalid port: exposed port "6379/foo": invalid proto: foo` + "\n" + `invalid environment variable: name "" must not be empty, nor contain '=' or whitespaces` + "\n" + `invalid environment variable: name "MY KEY" must not be empty, nor contain '=' or whitespaces`),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        "redis:latest",
+				ExposedPorts: []string{"6379/tcp", "6379/foo"},
+				Env: map[string]string{
+					"MY_KEY": "value",
+					"MY KEY": "value",
+					"":       "value",
+				},
+			},
+		},
+		{
+			Name:          "Can set a healthcheck",
+			ExpectedError: nil,
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HealthCheck: &testcontainers.HealthCheck{
+					Test:        []string{"CMD", "redis-cli", "ping"},
+					Interval:    time.Second,
+					StartPeriod: 5 * time.Second,
+					Retries:     3,
+				},
+			},
+		},
+		{
+			Name:          "Invalid healthcheck test",
+			ExpectedError: errors.New(`invalid healthcheck: healthcheck test must start with NONE, CMD or CMD-SHELL, got "redis-cli"`),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HealthCheck: &testcontainers.HealthCheck{
+					Test: []string{"redis-cli", "ping"},
+				},
+			},
+		},
+		{
+			Name:          "Invalid healthcheck test without command",
+			ExpectedError: errors.New(`invalid healthcheck: healthcheck test "CMD-SHELL" requires a command`),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HealthCheck: &testcontainers.HealthCheck{
+					Test: []string{"CMD-SHELL"},
+				},
+			},
+		},
+		{
+			Name:          "Invalid healthcheck negative interval",
+			ExpectedError: errors.New("invalid healthcheck: healthcheck durations must not be negative"),
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "redis:latest",
+				HealthCheck: &testcontainers.HealthCheck{
+					Interval: -time.Second,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -0,0 +1,128 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// coverageImage is the image of the init container making the coverage volume writable by any user.
+	coverageImage = "alpine:3.20"
+
+	// coverageDir is the directory of the coverage files in the container.
+	coverageDir = "/tmp/testcontainers-cover"
+)
+
+// WithGoCoverage collects the coverage of a Go service built with coverage instrumentation, i.e. with go build -cover,
+// so the end-to-end tests contribute to the coverage numbers. It sets the GOCOVERDIR environment variable
+// of the container to a volume, and before the container is terminated, it stops the container gracefully,
+// so the service writes its coverage files, and copies them to the given directory of the host.
+//
+// The default directory is the one of the -test.gocoverdir flag of the test binary, set by go test -cover,
+// then the one of the GOCOVERDIR environment variable, so the coverage of the service is merged with the one
+// of the tests. The files can be merged and reported with go tool covdata, e.g. go tool covdata percent -i=dir.
+//
+// The service writes the coverage files when it exits normally, i.e. returning from main or calling os.Exit,
+// so it must handle the stop signal of the container, SIGTERM by default, instead of being killed by it.
+func WithGoCoverage(dir string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if dir == "" {
+			dir = defaultCoverageDir()
+		}
+		if dir == "" {
+			return errors.New("go coverage: no coverage directory: run the tests with go test -cover or set GOCOVERDIR")
+		}
+
+		volume := "testcontainers-cover-" + uuid.NewString()
+
+		// the volume is owned by root, and the service may run as another user
+		err := WithInitContainer(ContainerRequest{
+			Image: coverageImage,
+			Cmd:   []string{"chmod", "1777", coverageDir},
+		})(req)
+		if err != nil {
+			return fmt.Errorf("go coverage: %w", err)
+		}
+
+		req.Mounts = append(req.Mounts, VolumeMount(volume, coverageDir))
+
+		if req.Env == nil {
+			req.Env = make(map[string]string)
+		}
+		req.Env["GOCOVERDIR"] = coverageDir
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreTerminates: []ContainerHook{
+				func(ctx context.Context, ctr Container) error {
+					return collectCoverage(ctx, ctr, dir)
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// defaultCoverageDir returns the directory of the -test.gocoverdir flag, if set, or the GOCOVERDIR environment variable.
+func defaultCoverageDir() string {
+	if f := flag.Lookup("test.gocoverdir"); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+
+	return os.Getenv("GOCOVERDIR")
+}
+
+// collectCoverage stops the container, so the service writes its coverage files, and copies them to dir.
+// The coverage files have unique names, so the files of several containers can be copied to the same directory.
+func collectCoverage(ctx context.Context, ctr Container, dir string) error {
+	if err := ctr.Stop(ctx, nil); err != nil {
+		return fmt.Errorf("go coverage: stop: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp("", "testcontainers-cover")
+	if err != nil {
+		return fmt.Errorf("go coverage: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := CollectArtifacts(ctx, ctr, []string{coverageDir}, tmp); err != nil {
+		return fmt.Errorf("go coverage: %w", err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(tmp, filepath.FromSlash(coverageDir)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("go coverage: no coverage file: check that the service is built with go build -cover and exits normally on the stop signal")
+		}
+		return fmt.Errorf("go coverage: %w", err)
+	}
+
+	for _, f := range files {
+		if !f.Type().IsRegular() {
+			continue
+		}
+
+		if err := copyCoverageFile(filepath.Join(tmp, filepath.FromSlash(coverageDir), f.Name()), filepath.Join(dir, f.Name())); err != nil {
+			return fmt.Errorf("go coverage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyCoverageFile copies the coverage file to dst, which may be on another filesystem.
+func copyCoverageFile(src string, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return writeArtifact(dst, f)
+}
@@ -0,0 +1,115 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// fakeCoverageContainer is a container writing the given coverage files when they are collected.
+type fakeCoverageContainer struct {
+	Container
+	files   []string
+	stopped bool
+}
+
+func (c *fakeCoverageContainer) Stop(context.Context, *time.Duration) error {
+	c.stopped = true
+
+	return nil
+}
+
+func (c *fakeCoverageContainer) CollectArtifacts(_ context.Context, _ []string, destDir string) error {
+	for _, f := range c.files {
+		if err := writeArtifact(filepath.Join(destDir, filepath.FromSlash(coverageDir), f), http.NoBody); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestWithGoCoverage(t *testing.T) {
+	t.Run("request", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.NoError(t, WithGoCoverage(t.TempDir())(&req))
+		require.Equal(t, coverageDir, req.Env["GOCOVERDIR"])
+		require.Len(t, req.Mounts, 1)
+		require.Equal(t, ContainerMountTarget(coverageDir), req.Mounts[0].Target)
+
+		// the init containers, then the collection of the coverage
+		require.Len(t, req.LifecycleHooks, 2)
+		require.Len(t, req.LifecycleHooks[1].PreTerminates, 1)
+	})
+
+	t.Run("default-dir", func(t *testing.T) {
+		if defaultCoverageDir() != "" {
+			t.Skip("the tests run with coverage")
+		}
+
+		req := GenericContainerRequest{}
+		require.ErrorContains(t, WithGoCoverage("")(&req), "no coverage directory")
+
+		dir := t.TempDir()
+		t.Setenv("GOCOVERDIR", dir)
+		require.Equal(t, dir, defaultCoverageDir())
+	})
+}
+
+func TestCollectCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		ctr := &fakeCoverageContainer{files: []string{"covmeta.1a2b", "covcounters.1a2b.7.1700000000"}}
+
+		require.NoError(t, collectCoverage(ctx, ctr, dir))
+		require.True(t, ctr.stopped)
+		require.FileExists(t, filepath.Join(dir, "covmeta.1a2b"))
+		require.FileExists(t, filepath.Join(dir, "covcounters.1a2b.7.1700000000"))
+	})
+
+	t.Run("no-file", func(t *testing.T) {
+		err := collectCoverage(ctx, &fakeCoverageContainer{}, t.TempDir())
+		require.ErrorContains(t, err, "no coverage file")
+	})
+}
+
+func TestWithGoCoverage_Docker(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// withGoCoverage {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context: filepath.Join("testdata", "coverage"),
+			},
+			ExposedPorts: []string{"8080/tcp"},
+			WaitingFor:   wait.ForHTTP("/").WithPort("8080/tcp"),
+		},
+		Started: true,
+	}
+	// the service is built with go build -cover
+	err := WithGoCoverage(dir)(&req)
+	// }
+	require.NoError(t, err)
+
+	ctr, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+
+	// the coverage files are copied when the container is terminated
+	require.NoError(t, ctr.Terminate(ctx))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+}
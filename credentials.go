@@ -0,0 +1,65 @@
+package testcontainers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// generatedUsernamePrefix identifies the users generated by GenerateCredentials.
+	generatedUsernamePrefix = "tc_"
+	generatedUsernameLength = 8
+	generatedPasswordLength = 24
+
+	// the usernames are lowercase, as some databases fold the unquoted identifiers to lowercase.
+	usernameAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// the passwords are alphanumeric, so they don't need to be escaped in URLs, DSNs or shell commands.
+	passwordAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// Credentials are the username and the password of a user of the service running in a container.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// GenerateCredentials returns random credentials, generated with a cryptographically secure source:
+// a lowercase username prefixed with tc_, and an alphanumeric password of 24 characters.
+//
+// The modules use it in their WithGeneratedCredentials option, replacing the hard-coded credentials
+// of their defaults, so no hard-coded password is reported by the secret scanners. The generated password
+// is masked in the logs and the errors, and the credentials are returned by the Credentials method
+// of the container.
+func GenerateCredentials() (Credentials, error) {
+	username, err := randomString(usernameAlphabet, generatedUsernameLength)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("generate username: %w", err)
+	}
+
+	password, err := randomString(passwordAlphabet, generatedPasswordLength)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("generate password: %w", err)
+	}
+
+	return Credentials{
+		Username: generatedUsernamePrefix + username,
+		Password: password,
+	}, nil
+}
+
+// randomString returns a string of the given length, with characters picked uniformly from the alphabet.
+func randomString(alphabet string, length int) (string, error) {
+	size := big.NewInt(int64(len(alphabet)))
+
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[n.Int64()]
+	}
+
+	return string(b), nil
+}
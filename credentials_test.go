@@ -0,0 +1,24 @@
+package testcontainers
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCredentials(t *testing.T) {
+	username := regexp.MustCompile(`^tc_[a-z0-9]{8}$`)
+	password := regexp.MustCompile(`^[A-Za-z0-9]{24}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		creds, err := GenerateCredentials()
+		require.NoError(t, err)
+		require.Regexp(t, username, creds.Username)
+		require.Regexp(t, password, creds.Password)
+
+		require.False(t, seen[creds.Password], "the password was already generated")
+		seen[creds.Password] = true
+	}
+}
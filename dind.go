@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+var inContainerReaperWarningOnce sync.Once
+
+// hostMountPath translates a path of the test process to the path on the Docker host, using the mapping
+// with the longest matching prefix. The mappings are in the <local path>=<host path> format.
+// The path is returned as is if no mapping matches.
+func hostMountPath(mappings []string, path string) string {
+	var local, host string
+	for _, m := range mappings {
+		l, h, ok := strings.Cut(m, "=")
+		l = strings.TrimSuffix(l, "/")
+		if !ok || l == "" || len(l) <= len(local) {
+			continue
+		}
+
+		if path == l || strings.HasPrefix(path, l+"/") {
+			local, host = l, strings.TrimSuffix(h, "/")
+		}
+	}
+
+	if local == "" {
+		return path
+	}
+
+	return host + strings.TrimPrefix(path, local)
+}
+
+// translateHostMountPaths translates the sources of the bind mounts of the host config to the paths on the
+// Docker host, for the tests running in a container that uses the Docker socket of the host.
+func translateHostMountPaths(mappings []string, hostConfig *container.HostConfig) {
+	if len(mappings) == 0 {
+		return
+	}
+
+	for i, m := range hostConfig.Mounts {
+		if m.Type == mount.TypeBind {
+			hostConfig.Mounts[i].Source = hostMountPath(mappings, m.Source)
+		}
+	}
+
+	for i, bind := range hostConfig.Binds {
+		// only absolute paths are bind mounts, the rest are named volumes
+		src, dst, ok := strings.Cut(bind, ":")
+		if !ok || !strings.HasPrefix(src, "/") {
+			continue
+		}
+
+		hostConfig.Binds[i] = hostMountPath(mappings, src) + ":" + dst
+	}
+}
+
+// inContainerReaperIssue returns the reason why the reaper cannot work when the tests run in a container,
+// or an empty string if it can. The reaper mounts the Docker socket, which is resolved by the Docker daemon
+// on the host: a socket out of the default location is likely only valid inside the test container,
+// unless it's overridden with TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE, or mapped with the host mount paths.
+func (p *DockerProvider) inContainerReaperIssue(ctx context.Context) string {
+	if !core.InAContainer() || !strings.HasPrefix(p.host, core.DockerSocketSchema) {
+		return ""
+	}
+
+	if os.Getenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE") != "" {
+		return ""
+	}
+
+	socket := core.MustExtractDockerSocket(ctx)
+	if socket == core.DockerSocketPath || hostMountPath(p.config.HostMountPaths, socket) != socket {
+		return ""
+	}
+
+	return fmt.Sprintf("the Docker socket %s of the test container cannot be mounted in the reaper container", socket)
+}
@@ -0,0 +1,61 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostMountPath(t *testing.T) {
+	mappings := []string{
+		"/builds=/srv/runner/builds",
+		"/builds/project/data=/mnt/data/",
+		"/workspace/=/home/runner/work",
+		"invalid",
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "no-match", path: "/tmp/file", want: "/tmp/file"},
+		{name: "exact", path: "/builds", want: "/srv/runner/builds"},
+		{name: "prefix", path: "/builds/project/main.go", want: "/srv/runner/builds/project/main.go"},
+		{name: "longest-prefix", path: "/builds/project/data/db.sql", want: "/mnt/data/db.sql"},
+		{name: "trailing-slash", path: "/workspace/app", want: "/home/runner/work/app"},
+		{name: "partial-segment", path: "/builds-cache/file", want: "/builds-cache/file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hostMountPath(mappings, tt.path))
+		})
+	}
+}
+
+func TestTranslateHostMountPaths(t *testing.T) {
+	hostConfig := &container.HostConfig{
+		Binds: []string{
+			"/builds/project/testdata:/data:ro",
+			"my-volume:/volume",
+			"/var/run/docker.sock:/var/run/docker.sock",
+		},
+		Mounts: []mount.Mount{
+			{Type: mount.TypeBind, Source: "/builds/project/config", Target: "/config"},
+			{Type: mount.TypeVolume, Source: "/builds", Target: "/volume"},
+		},
+	}
+
+	translateHostMountPaths([]string{"/builds=/srv/runner/builds"}, hostConfig)
+
+	require.Equal(t, []string{
+		"/srv/runner/builds/project/testdata:/data:ro",
+		"my-volume:/volume",
+		"/var/run/docker.sock:/var/run/docker.sock",
+	}, hostConfig.Binds)
+	require.Equal(t, "/srv/runner/builds/project/config", hostConfig.Mounts[0].Source)
+	require.Equal(t, "/builds", hostConfig.Mounts[1].Source)
+}
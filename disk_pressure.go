@@ -0,0 +1,177 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/container"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// volumeFillFile is the name of the file filling a volume, see FillVolume.
+const volumeFillFile = ".testcontainers-fill"
+
+// IOThrottle limits the I/O of a container on a block device of the Docker host, to simulate a slow storage.
+// The zero limits are not applied.
+type IOThrottle struct {
+	// Device is the path of the block device on the Docker host, e.g. /dev/sda, backing the filesystem of the container
+	// or its volumes.
+	Device string
+	// ReadBps is the maximum number of bytes read per second.
+	ReadBps uint64
+	// WriteBps is the maximum number of bytes written per second.
+	WriteBps uint64
+	// ReadIOps is the maximum number of read operations per second.
+	ReadIOps uint64
+	// WriteIOps is the maximum number of write operations per second.
+	WriteIOps uint64
+}
+
+// WithIOThrottle limits the I/O of the container on the block devices of the Docker host, setting the blkio
+// device limits of its host config, so tests can verify the behavior of the application with a slow storage.
+// The original host config modifier, if any, is preserved.
+func WithIOThrottle(throttles ...IOThrottle) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		for _, t := range throttles {
+			if t.Device == "" {
+				return errors.New("io throttle: empty device")
+			}
+			if t.ReadBps == 0 && t.WriteBps == 0 && t.ReadIOps == 0 && t.WriteIOps == 0 {
+				return fmt.Errorf("io throttle: no limit for device %s", t.Device)
+			}
+		}
+
+		if req.HostConfigModifier == nil {
+			req.HostConfigModifier = func(hostConfig *container.HostConfig) {}
+		}
+
+		originalHCM := req.HostConfigModifier
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			originalHCM(hostConfig)
+
+			for _, t := range throttles {
+				hostConfig.BlkioDeviceReadBps = appendThrottleDevice(hostConfig.BlkioDeviceReadBps, t.Device, t.ReadBps)
+				hostConfig.BlkioDeviceWriteBps = appendThrottleDevice(hostConfig.BlkioDeviceWriteBps, t.Device, t.WriteBps)
+				hostConfig.BlkioDeviceReadIOps = appendThrottleDevice(hostConfig.BlkioDeviceReadIOps, t.Device, t.ReadIOps)
+				hostConfig.BlkioDeviceWriteIOps = appendThrottleDevice(hostConfig.BlkioDeviceWriteIOps, t.Device, t.WriteIOps)
+			}
+		}
+
+		return nil
+	}
+}
+
+// appendThrottleDevice appends the limit of the device to the devices, unless it's zero.
+func appendThrottleDevice(devices []*blkiodev.ThrottleDevice, device string, rate uint64) []*blkiodev.ThrottleDevice {
+	if rate == 0 {
+		return devices
+	}
+
+	return append(devices, &blkiodev.ThrottleDevice{Path: device, Rate: rate})
+}
+
+// FillVolume fills the filesystem mounted at the given path of the running container, e.g. a volume, until
+// the given utilization, between 0 and 1, writing a file named .testcontainers-fill in it, so tests can verify
+// the behavior of the application under disk pressure. Filling it again replaces the previous file,
+// and the zero utilization removes it. The filesystem is not filled if its utilization is already higher.
+// The image of the container must provide a shell, df and dd.
+func FillVolume(ctx context.Context, ctr Container, path string, utilization float64) error {
+	if utilization < 0 || utilization > 1 {
+		return fmt.Errorf("fill volume: utilization %v out of range", utilization)
+	}
+
+	// the path is passed as an argument of the scripts, to avoid quoting it
+	if _, err := execScript(ctx, ctr, `rm -f "$1/`+volumeFillFile+`"`, path); err != nil {
+		return fmt.Errorf("fill volume: remove fill file: %w", err)
+	}
+
+	if utilization == 0 {
+		return nil
+	}
+
+	out, err := execScript(ctx, ctr, `df -Pk "$1" | awk 'NR == 2 { print $2, $3 }'`, path)
+	if err != nil {
+		return fmt.Errorf("fill volume: disk usage: %w", err)
+	}
+
+	size, err := fillSize(out, utilization)
+	if err != nil {
+		return fmt.Errorf("fill volume: disk usage of %s: %w", path, err)
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	if _, err := execScript(ctx, ctr, `dd if=/dev/zero of="$1/`+volumeFillFile+`" bs=1M count=`+strconv.FormatUint(size, 10), path); err != nil {
+		return fmt.Errorf("fill volume: write fill file: %w", err)
+	}
+
+	return nil
+}
+
+// fillSize returns the size of the fill file, in MiB, reaching the utilization of a filesystem
+// from the output of df, i.e. its size and its used space in KiB.
+func fillSize(df string, utilization float64) (uint64, error) {
+	fields := strings.Fields(df)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("unexpected output %q", df)
+	}
+
+	total, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("size: %w", err)
+	}
+
+	used, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("used: %w", err)
+	}
+
+	target := uint64(float64(total) * utilization)
+	if target <= used {
+		return 0, nil
+	}
+
+	return (target - used) / 1024, nil
+}
+
+// execScript runs the shell script with the given arguments in the container,
+// returning its output, or an error with its output if it exits with a non-zero code.
+func execScript(ctx context.Context, ctr Container, script string, args ...string) (string, error) {
+	exitCode, output, err := execOutput(ctx, ctr, append([]string{"/bin/sh", "-c", script, "sh"}, args...))
+	if err != nil {
+		return "", err
+	}
+
+	if exitCode != 0 {
+		return "", fmt.Errorf("exit code %d: %s", exitCode, output)
+	}
+
+	return output, nil
+}
+
+// execOutput runs the command in the container, returning its exit code and its trimmed output.
+func execOutput(ctx context.Context, ctr Container, cmd []string) (int, string, error) {
+	exitCode, reader, err := ctr.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return 0, "", err
+	}
+
+	var output string
+	if reader != nil {
+		b, err := io.ReadAll(reader)
+		if err != nil {
+			return 0, "", fmt.Errorf("read output: %w", err)
+		}
+		output = strings.TrimSpace(string(b))
+	}
+
+	return exitCode, output, nil
+}
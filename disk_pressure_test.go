@@ -0,0 +1,139 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// fakeExecContainer is a container recording the scripts it executes, returning the given output for df.
+type fakeExecContainer struct {
+	Container
+	df      string
+	scripts []string
+}
+
+func (c *fakeExecContainer) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	script := cmd[2]
+	c.scripts = append(c.scripts, script)
+
+	if strings.HasPrefix(script, "df") {
+		return 0, strings.NewReader(c.df), nil
+	}
+
+	return 0, strings.NewReader(""), nil
+}
+
+func TestWithIOThrottle(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.ErrorContains(t, WithIOThrottle(IOThrottle{ReadBps: 1})(&req), "empty device")
+		require.ErrorContains(t, WithIOThrottle(IOThrottle{Device: "/dev/sda"})(&req), "no limit")
+		require.Nil(t, req.HostConfigModifier)
+	})
+
+	t.Run("host-config", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				HostConfigModifier: func(hostConfig *container.HostConfig) {
+					hostConfig.Memory = 1024
+				},
+			},
+		}
+
+		// withIOThrottle {
+		err := WithIOThrottle(IOThrottle{Device: "/dev/sda", ReadBps: 1024 * 1024, WriteIOps: 10})(&req)
+		// }
+		require.NoError(t, err)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		require.Equal(t, int64(1024), hostConfig.Memory)
+		require.Equal(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 1024 * 1024}}, hostConfig.BlkioDeviceReadBps)
+		require.Equal(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 10}}, hostConfig.BlkioDeviceWriteIOps)
+		require.Empty(t, hostConfig.BlkioDeviceWriteBps)
+		require.Empty(t, hostConfig.BlkioDeviceReadIOps)
+	})
+}
+
+func TestFillSize(t *testing.T) {
+	size, err := fillSize("1048576 262144", 0.75)
+	require.NoError(t, err)
+	require.Equal(t, uint64(512), size)
+
+	size, err = fillSize("1048576 943718", 0.5)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	_, err = fillSize("df: /data: No such file or directory", 0.5)
+	require.Error(t, err)
+}
+
+func TestFillVolume(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		require.ErrorContains(t, FillVolume(ctx, &fakeExecContainer{}, "/data", 1.5), "out of range")
+	})
+
+	t.Run("fill", func(t *testing.T) {
+		ctr := &fakeExecContainer{df: "1048576 262144\n"}
+
+		require.NoError(t, FillVolume(ctx, ctr, "/data", 0.75))
+		require.Len(t, ctr.scripts, 3)
+		require.Equal(t, `rm -f "$1/.testcontainers-fill"`, ctr.scripts[0])
+		require.Equal(t, `dd if=/dev/zero of="$1/.testcontainers-fill" bs=1M count=512`, ctr.scripts[2])
+	})
+
+	t.Run("release", func(t *testing.T) {
+		ctr := &fakeExecContainer{}
+
+		require.NoError(t, FillVolume(ctx, ctr, "/data", 0))
+		require.Len(t, ctr.scripts, 1)
+	})
+}
+
+func TestFillVolume_Docker(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "alpine:3.20",
+			Cmd:   []string{"sleep", "infinity"},
+			Mounts: ContainerMounts{
+				{
+					Source: DockerTmpfsMountSource{TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 64 * 1024 * 1024}},
+					Target: "/data",
+				},
+			},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// fillVolume {
+	err = FillVolume(ctx, ctr, "/data", 0.9)
+	// }
+	require.NoError(t, err)
+
+	out, err := execScript(ctx, ctr, `df -Pk "$1" | awk 'NR == 2 { print $2, $3 }'`, "/data")
+	require.NoError(t, err)
+
+	// the fill file is rounded down to MiB
+	size, err := fillSize(out, 0.9)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	require.NoError(t, FillVolume(ctx, ctr, "/data", 0))
+}
@@ -14,10 +14,10 @@ import (
 	"net"
 	"net/url"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -33,6 +33,7 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/term"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/crypto/ssh"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/config"
@@ -45,6 +46,7 @@ var _ Container = (*DockerContainer)(nil)
 
 const (
 	Bridge        = "bridge" // Bridge network name (as well as driver)
+	windowsOSType = "windows"
 	Podman        = "podman"
 	ReaperDefault = "reaper_default" // Default network name when bridge is not available
 	packagePath   = "github.com/testcontainers/testcontainers-go"
@@ -54,6 +56,10 @@ const (
 
 var createContainerFailDueToNameConflictRegex = regexp.MustCompile("Conflict. The container name .* is already in use by container .*")
 
+// windowsReaperWarningOnce makes sure the warning about the reaper not being supported
+// for Windows containers is only logged once per test session.
+var windowsReaperWarningOnce sync.Once
+
 // DockerContainer represents a container started using Docker
 type DockerContainer struct {
 	// Container ID from Docker
@@ -88,6 +94,32 @@ type DockerContainer struct {
 	lifecycleHooks       []ContainerLifecycleHooks
 
 	healthStatus string // container health status, will default to healthStatusNone if no healthcheck is present
+
+	// tunnels are the SSH tunnels of the mapped ports, when the Docker host is a remote daemon accessed over SSH.
+	tunnels map[nat.Port]*portTunnel
+	// tunnelsClient is the SSH connection of the tunnels, closed with them.
+	tunnelsClient *ssh.Client
+	tunnelsLock   sync.Mutex
+
+	// releaseBudget frees the resources reserved for the container in the resource budget of the session, if any.
+	releaseBudget func()
+
+	// metrics holds the startup metrics of the container, if it was created in this test session.
+	metrics *ContainerMetrics
+
+	// relays are the socat containers relaying the ports exposed with ExposeAdditionalPort.
+	relays portRelays
+
+	// timeouts are the timeouts of the start and the stop of the container, zero meaning no timeout.
+	timeouts OperationTimeouts
+
+	// keepOnFailure is the test the container is kept running for if it failed,
+	// see SkipTerminationOnFailure and KeepContainersOnFailure.
+	keepOnFailure testing.TB
+
+	// inspectCache is the last inspection of the container, reset when its state is changed.
+	inspectCache *types.ContainerJSON
+	inspectLock  sync.Mutex
 }
 
 // SetLogger sets the logger for the container
@@ -121,15 +153,36 @@ func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, e
 		return "", err
 	}
 
-	// Get lowest numbered bound port.
-	var lowestPort nat.Port
-	for port := range inspect.NetworkSettings.Ports {
-		if lowestPort == "" || port.Int() < lowestPort.Int() {
-			lowestPort = port
+	ports := inspect.NetworkSettings.Ports
+	if inspect.HostConfig.NetworkMode.IsHost() {
+		// the ports are not bound in the host network mode, but listen on the host directly
+		ports = make(nat.PortMap, len(inspect.Config.ExposedPorts))
+		for port := range inspect.Config.ExposedPorts {
+			ports[port] = nil
+		}
+	}
+
+	return c.PortEndpoint(ctx, lowestPort(ports, proto), proto)
+}
+
+// lowestPort returns the lowest numbered port of the port map. If proto is
+// a transport protocol, e.g. "udp", only the ports of that protocol are considered.
+func lowestPort(ports nat.PortMap, proto string) nat.Port {
+	var lowest nat.Port
+	for port := range ports {
+		switch proto {
+		case "tcp", "udp", "sctp":
+			if port.Proto() != proto {
+				continue
+			}
+		}
+
+		if lowest == "" || port.Int() < lowest.Int() {
+			lowest = port
 		}
 	}
 
-	return c.PortEndpoint(ctx, lowestPort, proto)
+	return lowest
 }
 
 // PortEndpoint gets proto://host:port string for the given exposed port
@@ -154,7 +207,8 @@ func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto
 }
 
 // Host gets host (ip or name) of the docker daemon where the container port is exposed
-// Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
+// Warning: this is based on your Docker host setting. For remote daemons accessed over SSH,
+// it returns 127.0.0.1 if the SSH tunnels are enabled, see the "docker.ssh.tunnel" property.
 // You can use the "TESTCONTAINERS_HOST_OVERRIDE" env variable to set this yourself
 func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 	host, err := c.provider.DaemonHost(ctx)
@@ -164,8 +218,22 @@ func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 	return host, nil
 }
 
-// Inspect gets the raw container info
-func (c *DockerContainer) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
+// Inspect gets the raw container info. The info is cached until the container is started, stopped,
+// paused, unpaused, connected to or disconnected from a network, or terminated, so the helpers of
+// the container don't query the daemon again and again. Use the Refresh option to get the current info,
+// e.g. the health status of a running container. MappedPort refreshes the info when the cached one
+// is not running. The returned info is shared and must not be modified.
+func (c *DockerContainer) Inspect(ctx context.Context, opts ...InspectOption) (*types.ContainerJSON, error) {
+	if !wait.NewInspectOptions(opts...).Refresh {
+		c.inspectLock.Lock()
+		inspect := c.inspectCache
+		c.inspectLock.Unlock()
+
+		if inspect != nil {
+			return inspect, nil
+		}
+	}
+
 	jsonRaw, err := c.inspectRawContainer(ctx)
 	if err != nil {
 		return nil, err
@@ -176,11 +244,27 @@ func (c *DockerContainer) Inspect(ctx context.Context) (*types.ContainerJSON, er
 
 // MappedPort gets externally mapped port for a container port
 func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if relay := c.relays.get(port); relay != nil {
+		return relay.MappedPort(ctx, port)
+	}
+
 	inspect, err := c.Inspect(ctx)
 	if err != nil {
 		return "", err
 	}
-	if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
+	if inspect.State == nil || !inspect.State.Running {
+		// the cached info is stale if the daemon started the container since, e.g. with a restart policy,
+		// and the ports are mapped again each time the container starts
+		inspect, err = c.Inspect(ctx, Refresh())
+		if err != nil {
+			return "", err
+		}
+	}
+	if inspect.ContainerJSONBase.HostConfig.NetworkMode.IsHost() {
+		// the container port is directly reachable on the host in the host network mode
+		if c.provider.sshTunnelEnabled() {
+			return c.tunnelPort(ctx, port)
+		}
 		return port, nil
 	}
 
@@ -196,7 +280,17 @@ func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Po
 		if len(p) == 0 {
 			continue
 		}
-		return nat.NewPort(k.Proto(), p[0].HostPort)
+
+		mappedPort, err := nat.NewPort(k.Proto(), p[0].HostPort)
+		if err != nil {
+			return "", err
+		}
+
+		if c.provider.sshTunnelEnabled() {
+			return c.tunnelPort(ctx, mappedPort)
+		}
+
+		return mappedPort, nil
 	}
 
 	return "", errors.New("port not found")
@@ -218,13 +312,38 @@ func (c *DockerContainer) SessionID() string {
 }
 
 // Start will start an already created container
-func (c *DockerContainer) Start(ctx context.Context) error {
-	err := c.startingHook(ctx)
+func (c *DockerContainer) Start(ctx context.Context) (err error) {
+	ctx, cancel := withOperationTimeout(ctx, "start "+c.Image, c.timeouts.Start)
+	defer cancel()
+
+	defer func() {
+		err = operationError(ctx, err)
+		countResult(err, &stats.containersStarted, &stats.containerFailures)
+	}()
+
+	// the metrics reflect the last start of the container,
+	// with the time spent waiting recorded by the readiness hook
+	start := time.Now()
+	updateContainerMetrics(c.metrics, func(m *ContainerMetrics) {
+		m.Wait = 0
+	})
+	defer func() {
+		updateContainerMetrics(c.metrics, func(m *ContainerMetrics) {
+			m.Start = time.Since(start) - m.Wait
+		})
+		if err == nil {
+			observeContainerStarted(c.metrics)
+		}
+	}()
+
+	err = c.startingHook(ctx)
 	if err != nil {
 		return fmt.Errorf("starting hook: %w", err)
 	}
 
-	if err := c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
+	err = c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{})
+	c.invalidateInspect()
+	if err != nil {
 		return fmt.Errorf("container start: %w", err)
 	}
 	defer c.provider.Close()
@@ -259,20 +378,41 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 //
 // If the container is already stopped, the method is a no-op.
 func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) error {
+	return c.stop(ctx, "", timeout)
+}
+
+// StopWithSignal stops the container sending the given signal, e.g. SIGTERM or SIGINT, instead of the
+// stop signal of the image, so tests can exercise the graceful shutdown of the container. If the container
+// doesn't exit after the timeout, it's killed. A nil timeout uses the default timeout of the container.
+//
+// All the stop lifecycle hooks are executed, like in Stop.
+func (c *DockerContainer) StopWithSignal(ctx context.Context, signal string, timeout *time.Duration) error {
+	return c.stop(ctx, signal, timeout)
+}
+
+// stop stops the container sending the signal, or the stop signal of the image if empty.
+func (c *DockerContainer) stop(ctx context.Context, signal string, timeout *time.Duration) error {
+	ctx, cancel := withOperationTimeout(ctx, "stop "+c.Image, c.timeouts.Stop)
+	defer cancel()
+
 	err := c.stoppingHook(ctx)
 	if err != nil {
-		return err
+		return operationError(ctx, err)
 	}
 
-	var options container.StopOptions
+	options := container.StopOptions{
+		Signal: signal,
+	}
 
 	if timeout != nil {
 		timeoutSeconds := int(timeout.Seconds())
 		options.Timeout = &timeoutSeconds
 	}
 
-	if err := c.provider.client.ContainerStop(ctx, c.ID, options); err != nil {
-		return err
+	err = c.provider.client.ContainerStop(ctx, c.ID, options)
+	c.invalidateInspect()
+	if err != nil {
+		return operationError(ctx, err)
 	}
 	defer c.provider.Close()
 
@@ -280,30 +420,105 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 
 	err = c.stoppedHook(ctx)
 	if err != nil {
-		return err
+		return operationError(ctx, err)
+	}
+
+	return nil
+}
+
+// Pause pauses all the processes of the container, using the cgroups freezer, to simulate
+// a frozen service, e.g. to test timeouts. The container keeps its state and resources.
+func (c *DockerContainer) Pause(ctx context.Context) error {
+	err := c.provider.client.ContainerPause(ctx, c.ID)
+	c.invalidateInspect()
+	if err != nil {
+		return fmt.Errorf("pause container: %w", err)
 	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// Unpause unpauses all the processes of a paused container.
+func (c *DockerContainer) Unpause(ctx context.Context) error {
+	err := c.provider.client.ContainerUnpause(ctx, c.ID)
+	c.invalidateInspect()
+	if err != nil {
+		return fmt.Errorf("unpause container: %w", err)
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// Signal sends the signal, e.g. SIGHUP or SIGUSR1, to the main process of the container, without stopping it,
+// unless the process exits on the signal. Use KillProcess to send a signal to another process of the container.
+func (c *DockerContainer) Signal(ctx context.Context, signal string) error {
+	err := c.provider.client.ContainerKill(ctx, c.ID, signal)
+	c.invalidateInspect()
+	if err != nil {
+		return fmt.Errorf("signal %s: %w", signal, err)
+	}
+	defer c.provider.Close()
 
 	return nil
 }
 
+// KillProcess kills the processes of the container whose command line matches the pattern,
+// see the KillProcess function.
+func (c *DockerContainer) KillProcess(ctx context.Context, pattern string) error {
+	return killProcess(ctx, c, pattern)
+}
+
 // Terminate is used to kill the container. It is usually triggered by as defer function.
-func (c *DockerContainer) Terminate(ctx context.Context) error {
-	select {
-	// close reaper if it was created
-	case c.terminationSignal <- true:
-	default:
+// If the container is kept for the debugging of a failed test, see SkipTerminationOnFailure,
+// it's left running and the instructions to connect to it are logged in the test. Its SSH tunnels
+// are closed and its resource budget released, but the connection to the reaper is kept open,
+// so the reaper doesn't remove it before the test process exits.
+func (c *DockerContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	if tb := failedTest(c.keepOnFailure); tb != nil {
+		tb.Log(c.keptContainer(ctx).message(tb.Name()))
+
+		if c.releaseBudget != nil {
+			c.releaseBudget()
+		}
+
+		return c.closeTunnels()
 	}
 
+	options := newTerminateOptions(opts...)
+
+	// close the connection to the reaper once the container is removed,
+	// so the reaper doesn't report it if it was the last connection
+	defer func() {
+		select {
+		case c.terminationSignal <- true:
+		default:
+		}
+	}()
+
 	defer c.provider.client.Close()
 
-	errs := []error{
+	ctx, cancel := withOperationTimeout(ctx, "terminate "+c.Image, c.timeouts.Stop)
+	defer cancel()
+
+	stats.containersTerminated.Add(1)
+
+	var errs []error
+	if options.stopTimeout != nil {
+		errs = append(errs, c.Stop(ctx, options.stopTimeout))
+	}
+
+	errs = append(errs,
 		c.terminatingHook(ctx),
+		c.relays.terminate(ctx),
 		c.provider.client.ContainerRemove(ctx, c.GetContainerID(), container.RemoveOptions{
-			RemoveVolumes: true,
+			RemoveVolumes: !options.retainVolumes,
 			Force:         true,
 		}),
 		c.terminatedHook(ctx),
-	}
+		c.closeTunnels(),
+	)
 
 	if c.imageWasBuilt && !c.keepBuiltImage {
 		_, err := c.provider.client.ImageRemove(ctx, c.Image, image.RemoveOptions{
@@ -313,10 +528,39 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		errs = append(errs, err)
 	}
 
+	if c.releaseBudget != nil {
+		c.releaseBudget()
+	}
+
 	c.sessionID = ""
 	c.isRunning = false
+	c.invalidateInspect()
 
-	return errors.Join(errs...)
+	return operationError(ctx, errors.Join(errs...))
+}
+
+// keptContainer describes the container kept for the debugging of a failed test.
+func (c *DockerContainer) keptContainer(ctx context.Context) keptContainer {
+	kept := keptContainer{
+		cli:    "docker",
+		id:     c.ID,
+		reaped: c.terminationSignal != nil,
+	}
+
+	// the description is best effort, as the test already failed
+	if inspect, err := c.Inspect(ctx, Refresh()); err == nil {
+		kept.name = inspect.Name
+		kept.ports = inspect.NetworkSettings.Ports
+	}
+	kept.host, _ = c.Host(ctx)
+	if kept.host == "127.0.0.1" && c.provider.sshTunnelEnabled() {
+		// the tunnels are closed with the termination, so the ports are reached on the remote Docker host
+		if daemonURL, err := url.Parse(c.provider.client.DaemonHost()); err == nil {
+			kept.host = daemonURL.Hostname()
+		}
+	}
+
+	return kept
 }
 
 // update container raw info
@@ -327,9 +571,20 @@ func (c *DockerContainer) inspectRawContainer(ctx context.Context) (*types.Conta
 		return nil, err
 	}
 
+	c.inspectLock.Lock()
+	c.inspectCache = &inspect
+	c.inspectLock.Unlock()
+
 	return &inspect, nil
 }
 
+// invalidateInspect resets the cached info of the container, after a change of its state.
+func (c *DockerContainer) invalidateInspect() {
+	c.inspectLock.Lock()
+	c.inspectCache = nil
+	c.inspectLock.Unlock()
+}
+
 // Logs will fetch both STDOUT and STDERR from the current container. Returns a
 // ReadCloser and leaves it up to the caller to extract what it wants.
 func (c *DockerContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
@@ -432,6 +687,38 @@ func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	return n, nil
 }
 
+// ConnectNetwork connects the running container to the network, with the given aliases,
+// e.g. to recover from a network partition simulated with DisconnectNetwork.
+// The aliases the container had in the network before disconnecting it are not restored.
+func (c *DockerContainer) ConnectNetwork(ctx context.Context, networkName string, aliases ...string) error {
+	endpointSettings := network.EndpointSettings{
+		Aliases: aliases,
+	}
+
+	err := c.provider.client.NetworkConnect(ctx, networkName, c.ID, &endpointSettings)
+	c.invalidateInspect()
+	if err != nil {
+		return fmt.Errorf("network connect %s: %w", networkName, err)
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// DisconnectNetwork disconnects the running container from the network, e.g. to simulate
+// a network partition between the members of a cluster: the other containers in the network
+// can't reach it, until it's connected again with ConnectNetwork.
+func (c *DockerContainer) DisconnectNetwork(ctx context.Context, networkName string) error {
+	err := c.provider.client.NetworkDisconnect(ctx, networkName, c.ID, false)
+	c.invalidateInspect()
+	if err != nil {
+		return fmt.Errorf("network disconnect %s: %w", networkName, err)
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
 // ContainerIP gets the IP address of the primary network within the container.
 func (c *DockerContainer) ContainerIP(ctx context.Context) (string, error) {
 	inspect, err := c.Inspect(ctx)
@@ -516,6 +803,11 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 		return 0, nil, fmt.Errorf("container exec attach: %w", err)
 	}
 
+	// the hijacked connection doesn't honor the context, so close it if the context is done
+	// while the output is read by the options, or the exec is running
+	stop := context.AfterFunc(ctx, hijack.Close)
+	defer stop()
+
 	processOptions.Reader = hijack.Reader
 
 	// second loop to process the multiplexed option, as now we have a reader
@@ -536,12 +828,45 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 			break
 		}
 
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return 0, nil, fmt.Errorf("container exec inspect: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
 
 	return exitCode, processOptions.Reader, nil
 }
 
+// attachStdin attaches to the stdin of the container, streaming the content of the reader
+// into it. Stdin is closed when the reader returns io.EOF, so the process running in the
+// container receives an EOF. It must be called before the container is started to avoid
+// losing any input.
+func (c *DockerContainer) attachStdin(ctx context.Context, stdin io.Reader) error {
+	hijack, err := c.provider.client.ContainerAttach(ctx, c.ID, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("container attach: %w", err)
+	}
+
+	go func() {
+		defer hijack.Close()
+
+		if _, err := io.Copy(hijack.Conn, stdin); err != nil {
+			c.logger.Printf("failed writing to container stdin: %v", err)
+		}
+
+		// half-close the connection, so the daemon closes the stdin of the container.
+		if err := hijack.CloseWrite(); err != nil {
+			c.logger.Printf("failed closing container stdin: %v", err)
+		}
+	}()
+
+	return nil
+}
+
 type FileFromContainer struct {
 	underlying *io.ReadCloser
 	tarreader  *tar.Reader
@@ -597,8 +922,14 @@ func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath st
 		return err
 	}
 
-	// create the directory under its parent
-	parent := filepath.Dir(containerParentPath)
+	osType, err := c.osType(ctx)
+	if err != nil {
+		return err
+	}
+
+	// create the directory under its parent, using the path separator of the container
+	// and not the one of the host, as they could differ.
+	parent := containerPathDir(osType, containerParentPath)
 
 	err = c.provider.client.CopyToContainer(ctx, c.ID, parent, buff, container.CopyToContainerOptions{})
 	if err != nil {
@@ -653,7 +984,34 @@ func (c *DockerContainer) CopyToContainer(ctx context.Context, fileContent []byt
 }
 
 func (c *DockerContainer) copyToContainer(ctx context.Context, fileContent func(tw io.Writer) error, fileContentSize int64, containerFilePath string, fileMode int64) error {
-	buffer, err := tarFile(containerFilePath, fileContent, fileContentSize, fileMode)
+	osType, err := c.osType(ctx)
+	if err != nil {
+		return err
+	}
+
+	buffer, err := tarFile(containerTarPath(osType, containerFilePath), fileContent, fileContentSize, fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = c.provider.client.CopyToContainer(ctx, c.ID, "/", buffer, container.CopyToContainerOptions{})
+	if err != nil {
+		return err
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// copyFSToContainer copies the files of the file system to the target directory in the container,
+// in a single upload.
+func (c *DockerContainer) copyFSToContainer(ctx context.Context, fsys fs.FS, target string) error {
+	osType, err := c.osType(ctx)
+	if err != nil {
+		return err
+	}
+
+	buffer, err := tarFS(fsys, containerTarPath(osType, target))
 	if err != nil {
 		return err
 	}
@@ -667,6 +1025,16 @@ func (c *DockerContainer) copyToContainer(ctx context.Context, fileContent func(
 	return nil
 }
 
+// osType returns the operating system of the container, i.e. "linux" or "windows".
+func (c *DockerContainer) osType(ctx context.Context) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("inspect: %w", err)
+	}
+
+	return inspect.Platform, nil
+}
+
 type LogProductionOption func(*DockerContainer)
 
 // WithLogProductionTimeout is a functional option that sets the timeout for the log production.
@@ -714,6 +1082,10 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 
 	c.logProductionError = make(chan error, 1)
 
+	// the log production outlives the context of the hook starting it, e.g. with a start timeout,
+	// and is stopped with stopLogProduction
+	ctx = context.WithoutCancel(ctx)
+
 	go func() {
 		defer func() {
 			close(c.logProductionError)
@@ -730,10 +1102,10 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 			Since:      since,
 		}
 
-		ctx, cancel := context.WithTimeout(ctx, *c.logProductionTimeout)
+		timeoutCtx, cancel := context.WithTimeout(ctx, *c.logProductionTimeout)
 		defer cancel()
 
-		r, err := c.provider.client.ContainerLogs(ctx, c.GetContainerID(), options)
+		r, err := c.provider.client.ContainerLogs(timeoutCtx, c.GetContainerID(), options)
 		if err != nil {
 			c.logProductionError <- err
 			return
@@ -758,8 +1130,12 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 					since = fmt.Sprintf("%d.%09d", now.Unix(), int64(now.Nanosecond()))
 					goto BEGIN
 				case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
-					// Probably safe to continue here
-					continue
+					// the logs request timed out, request the logs again from now,
+					// instead of reading the cancelled request until the log production is stopped
+					_ = r.Close()
+					now := time.Now()
+					since = fmt.Sprintf("%d.%09d", now.Unix(), int64(now.Nanosecond()))
+					goto BEGIN
 				default:
 					_, _ = fmt.Fprintf(os.Stderr, "container log error: %+v. %s", err, logStoppedForOutOfSyncMessage)
 					// if we would continue here, the next header-read will result into random data...
@@ -787,8 +1163,11 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 				// TODO: add-logger: use logger to log out this error
 				_, _ = fmt.Fprintf(os.Stderr, "error occurred reading log with known length %s", err.Error())
 				if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-					// Probably safe to continue here
-					continue
+					// the logs request timed out in the middle of a log, request the logs again from now
+					_ = r.Close()
+					now := time.Now()
+					since = fmt.Sprintf("%d.%09d", now.Unix(), int64(now.Nanosecond()))
+					goto BEGIN
 				}
 				// we can not continue here as the next read most likely will not be the next header
 				_, _ = fmt.Fprintln(os.Stderr, logStoppedForOutOfSyncMessage)
@@ -848,11 +1227,14 @@ type DockerNetwork struct {
 
 // Remove is used to remove the network. It is usually triggered by as defer function.
 func (n *DockerNetwork) Remove(ctx context.Context) error {
-	select {
-	// close reaper if it was created
-	case n.terminationSignal <- true:
-	default:
-	}
+	// close the connection to the reaper once the network is removed,
+	// so the reaper doesn't report it if it was the last connection
+	defer func() {
+		select {
+		case n.terminationSignal <- true:
+		default:
+		}
+	}()
 
 	defer n.provider.Close()
 
@@ -866,10 +1248,14 @@ func (n *DockerNetwork) SetTerminationSignal(signal chan bool) {
 // DockerProvider implements the ContainerProvider interface
 type DockerProvider struct {
 	*DockerProviderOptions
-	client    client.APIClient
-	host      string
-	hostCache string
-	config    config.Config
+	client      client.APIClient
+	host        string
+	hostCache   string
+	osTypeCache string
+	config      config.Config
+
+	// containerdStoreCache caches whether the daemon uses the containerd image store.
+	containerdStoreCache *bool
 }
 
 // Client gets the docker client used by the provider
@@ -893,8 +1279,13 @@ func (p *DockerProvider) SetClient(c client.APIClient) {
 
 var _ ContainerProvider = (*DockerProvider)(nil)
 
-// BuildImage will build and image from context and Dockerfile, then return the tag
+// BuildImage will build and image from context and Dockerfile, then return the tag.
+// The builds forwarding secrets or SSH agents are run with the BuildKit of the docker CLI.
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
+	if info, ok := usesBuildKitSession(img); ok {
+		return p.buildImageWithBuildKit(ctx, img, info)
+	}
+
 	var buildOptions types.ImageBuildOptions
 	resp, err := backoff.RetryNotifyWithData(
 		func() (types.ImageBuildResponse, error) {
@@ -939,17 +1330,41 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 		return "", fmt.Errorf("build image: %w", err)
 	}
 
+	if buildOptions.Labels[core.LabelSessionID] != "" {
+		if err := p.registerBuiltImage(ctx); err != nil {
+			return "", err
+		}
+	}
+
 	// the first tag is the one we want
 	return buildOptions.Tags[0], nil
 }
 
 // CreateContainer fulfils a request for a container without starting it
 func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	c, err := p.createContainer(ctx, req)
+	countResult(err, &stats.containersCreated, &stats.containerFailures)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// createContainer creates the container, see CreateContainer.
+func (p *DockerProvider) createContainer(ctx context.Context, req ContainerRequest) (Container, error) {
 	var err error
 
 	// defer the close of the Docker client connection the soonest
 	defer p.Close()
 
+	// validate the request before calling the Docker daemon
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+
+	timeouts := req.OperationTimeouts.resolve(p.config)
+
 	// Make sure that bridge network exists
 	// In case it is disabled we will create reaper_default network
 	if p.DefaultNetwork == "" {
@@ -990,7 +1405,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	var termSignal chan bool
 	// the reaper does not need to start a reaper for itself
 	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
-	if !p.config.RyukDisabled && !isReaperContainer {
+	if p.reaperEnabled(ctx) && !isReaperContainer {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
 		if err != nil {
 			return nil, fmt.Errorf("%w: creating reaper failed", err)
@@ -1008,20 +1423,40 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 	}()
 
-	if err = req.Validate(); err != nil {
-		return nil, err
-	}
-
 	// always append the hub substitutor after the user-defined ones
 	req.ImageSubstitutors = append(req.ImageSubstitutors, newPrependHubRegistry(p.config.HubImageNamePrefix))
 
 	var platform *specs.Platform
 
+	pullStart := time.Now()
+	var pullDuration time.Duration
+
+	// pullPlatformOnCreate defers the pull of the platform of the image to the creation of the container
+	var pullPlatformOnCreate bool
+
+	pullImage := func() error {
+		pullOpt := image.PullOptions{
+			Platform: req.ImagePlatform, // may be empty
+		}
+		pullCtx, cancel := withOperationTimeout(ctx, "pull "+imageName, timeouts.Pull)
+		err := operationError(pullCtx, p.attemptToPullImage(pullCtx, imageName, pullOpt))
+		cancel()
+		countResult(err, &stats.imagePulls, &stats.imagePullFailures)
+		if err != nil {
+			return err
+		}
+		pullDuration = time.Since(pullStart)
+
+		return nil
+	}
+
 	if req.ShouldBuildImage() {
 		imageName, err = p.BuildImage(ctx, &req)
+		countResult(err, &stats.imageBuilds, &stats.imageBuildFailures)
 		if err != nil {
 			return nil, err
 		}
+		pullDuration = time.Since(pullStart)
 	} else {
 		for _, is := range req.ImageSubstitutors {
 			modifiedTag, err := is.Substitute(imageName)
@@ -1056,16 +1491,19 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 					return nil, err
 				}
 			}
-			if platform != nil && (img.Architecture != platform.Architecture || img.Os != platform.OS) {
-				shouldPullImage = true
+			if err == nil && platform != nil && (img.Architecture != platform.Architecture || img.Os != platform.OS) {
+				// the containerd image store keeps all the pulled platforms of an image, and the inspection
+				// only describes one of them, so the platform is pulled only if the container can't be created
+				if p.usesContainerdImageStore(ctx) {
+					pullPlatformOnCreate = true
+				} else {
+					shouldPullImage = true
+				}
 			}
 		}
 
 		if shouldPullImage {
-			pullOpt := image.PullOptions{
-				Platform: req.ImagePlatform, // may be empty
-			}
-			if err := p.attemptToPullImage(ctx, imageName, pullOpt); err != nil {
+			if err := pullImage(); err != nil {
 				return nil, err
 			}
 		}
@@ -1089,6 +1527,18 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		WorkingDir: req.WorkingDir,
 	}
 
+	if req.HealthCheck != nil {
+		dockerInput.Healthcheck = req.HealthCheck.dockerConfig()
+	}
+
+	if req.Stdin != nil {
+		// keep stdin open until the attached reader is drained,
+		// closing it afterwards so the process receives an EOF.
+		dockerInput.AttachStdin = true
+		dockerInput.OpenStdin = true
+		dockerInput.StdinOnce = true
+	}
+
 	hostConfig := &container.HostConfig{
 		Privileged: req.Privileged,
 		ShmSize:    req.ShmSize,
@@ -1102,32 +1552,60 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		DefaultLoggingHook(p.Logger),
 		defaultPreCreateHook(p, dockerInput, hostConfig, networkingConfig),
 		defaultCopyFileToContainerHook(req.Files),
+		defaultStdinHook(req.Stdin),
 		defaultLogConsumersHook(req.LogConsumerCfg),
 		defaultReadinessHook(),
 	}
 
-	// in the case the container needs to access a local port
-	// we need to forward the local port to the container
-	if len(req.HostAccessPorts) > 0 {
-		// a container lifecycle hook will be added, which will expose the host ports to the container
-		// using a SSHD server running in a container. The SSHD server will be started and will
-		// forward the host ports to the container ports.
-		sshdForwardPortsHook, err := exposeHostPorts(ctx, &req, req.HostAccessPorts...)
+	// in the case the container needs to access the host,
+	// we need to resolve the host or to forward the local ports to the container
+	if len(req.HostAccessPorts) > 0 || req.AccessToHost {
+		sshdForwardPortsHook, err := p.exposeHost(ctx, &req)
 		if err != nil {
-			return nil, fmt.Errorf("expose host ports: %w", err)
+			return nil, err
 		}
 
-		defaultHooks = append(defaultHooks, sshdForwardPortsHook)
+		if sshdForwardPortsHook != nil {
+			defaultHooks = append(defaultHooks, *sshdForwardPortsHook)
+		}
 	}
 
 	req.LifecycleHooks = []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)}
 
+	createStart := time.Now()
+
 	err = req.creatingHook(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// the reaper and the SSHD containers are helpers of the library, out of the resource budget
+	var releaseBudget func()
+	if !isReaperContainer && req.Image != sshdImage {
+		applyDefaultResources(p.config, hostConfig)
+
+		budget, memory := sessionBudget(), hostConfig.Memory
+		if err = budget.acquire(ctx, memory); err != nil {
+			return nil, err
+		}
+		releaseBudget = sync.OnceFunc(func() { budget.release(memory) })
+	}
+
+	// Release the budget on error, otherwise set releaseBudget to nil before successful return.
+	defer func() {
+		if releaseBudget != nil {
+			releaseBudget()
+		}
+	}()
+
 	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+	if err != nil && pullPlatformOnCreate && errdefs.IsNotFound(err) {
+		// the platform of the image is not in the containerd image store
+		if err := pullImage(); err != nil {
+			return nil, err
+		}
+		resp, err = p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("container create: %w", err)
 	}
@@ -1160,8 +1638,11 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		exposedPorts:      req.ExposedPorts,
 		provider:          p,
 		terminationSignal: termSignal,
-		logger:            p.Logger,
+		logger:            req.redactLogger(p.Logger),
 		lifecycleHooks:    req.LifecycleHooks,
+		releaseBudget:     releaseBudget,
+		timeouts:          timeouts,
+		keepOnFailure:     keepOnFailureTest(req.KeepOnFailure),
 	}
 
 	err = c.createdHook(ctx)
@@ -1169,8 +1650,17 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
+	c.metrics = recordContainerMetrics(ContainerMetrics{
+		ContainerID: c.ID,
+		Image:       imageName,
+		Name:        req.Name,
+		Pull:        pullDuration,
+		Create:      time.Since(createStart),
+	})
+
 	// Disable cleanup on success
 	termSignal = nil
+	releaseBudget = nil
 
 	return c, nil
 }
@@ -1242,7 +1732,7 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 	sessionID := core.SessionID()
 
 	var termSignal chan bool
-	if !p.config.RyukDisabled {
+	if p.reaperEnabled(ctx) {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), sessionID, p)
 		if err != nil {
 			return nil, fmt.Errorf("reaper: %w", err)
@@ -1268,8 +1758,10 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		exposedPorts:      req.ExposedPorts,
 		provider:          p,
 		terminationSignal: termSignal,
-		logger:            p.Logger,
+		logger:            req.redactLogger(p.Logger),
 		lifecycleHooks:    []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)},
+		timeouts:          req.OperationTimeouts.resolve(p.config),
+		keepOnFailure:     keepOnFailureTest(req.KeepOnFailure),
 	}
 
 	err = dc.startedHook(ctx)
@@ -1289,47 +1781,82 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
+// The pulls are retried with a jittered exponential backoff when the registry rate limit is exceeded, and
+// the Docker Hub images are pulled from the mirror of the configuration, if any, from then on.
 func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt image.PullOptions) error {
-	registry, imageAuth, err := DockerImageAuth(ctx, tag)
+	release, err := pulls.acquire(ctx, p.config.PullConcurrency)
 	if err != nil {
-		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, tag, err)
-	} else {
-		// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
-		encodedJSON, err := json.Marshal(imageAuth)
-		if err != nil {
-			p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is: %s", tag, err)
-		} else {
-			pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
-		}
+		return err
 	}
+	defer release()
 
-	var pull io.ReadCloser
+	ref := tag
 	err = backoff.RetryNotify(
 		func() error {
-			pull, err = p.client.ImagePull(ctx, tag, pullOpt)
-			if err != nil {
-				if isPermanentClientError(err) {
-					return backoff.Permanent(err)
+			err := p.pullImage(ctx, ref, pullOpt)
+			switch {
+			case err == nil:
+				return nil
+			case isRateLimitError(err):
+				if mirrorRef, ok := hubMirrorImage(p.config.HubMirror, tag); ok && ref != mirrorRef {
+					p.Logger.Printf("Docker Hub rate limit exceeded pulling %s, failing over to %s", tag, mirrorRef)
+					ref = mirrorRef
 				}
 				return err
+			case isPermanentClientError(err):
+				return backoff.Permanent(err)
+			default:
+				return err
 			}
-			defer p.Close()
-
-			return nil
 		},
-		backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
+		pullBackOff(ctx),
 		func(err error, duration time.Duration) {
-			p.Logger.Printf("Failed to pull image: %s, will retry", err)
+			p.Logger.Printf("Failed to pull image: %s, will retry in %s", err, duration.Round(time.Millisecond))
 		},
 	)
 	if err != nil {
 		return err
 	}
+
+	if ref != tag {
+		// the containers are created with the original name of the image
+		if err := p.client.ImageTag(ctx, ref, tag); err != nil {
+			return fmt.Errorf("tag mirror image %s as %s: %w", ref, tag, err)
+		}
+	}
+
+	return nil
+}
+
+// pullImage pulls the image, reading the whole progress stream, which reports the errors
+// happening after the pull started, e.g. the rate limit of the registry.
+func (p *DockerProvider) pullImage(ctx context.Context, ref string, pullOpt image.PullOptions) error {
+	registry, imageAuth, err := DockerImageAuth(ctx, ref)
+	if err != nil {
+		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, ref, err)
+	} else {
+		// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
+		encodedJSON, err := json.Marshal(imageAuth)
+		if err != nil {
+			p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is: %s", ref, err)
+		} else {
+			pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
+		}
+	}
+
+	pull, err := p.client.ImagePull(ctx, ref, pullOpt)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
 	defer pull.Close()
 
 	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	if err := jsonmessage.DisplayJSONMessagesStream(pull, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("pull %s: %w", ref, err)
+	}
+
+	return nil
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1368,20 +1895,183 @@ func (p *DockerProvider) Config() TestcontainersConfig {
 	}
 }
 
+// DaemonInfo represents the information about the Docker daemon used by the provider,
+// including the endpoint that was selected to connect to it, and how it was discovered.
+type DaemonInfo struct {
+	Host            string   // the endpoint of the Docker daemon, e.g. unix:///var/run/docker.sock
+	HostSource      string   // the strategy that discovered the endpoint, e.g. DOCKER_HOST environment variable
+	SocketPath      string   // the path to the Docker socket, used to mount it in containers
+	OSType          string   // the operating system of the containers, i.e. linux or windows
+	OperatingSystem string   // the operating system of the daemon, e.g. Docker Desktop
+	ServerVersion   string   // the version of the daemon
+	InAContainer    bool     // whether the tests run inside a container, e.g. in a CI runner
+	MappedPortsHost string   // the host where the mapped ports are reachable, e.g. the gateway when running in a container
+	ReaperEnabled   bool     // whether the reaper cleans up the resources of the session
+	HostMountPaths  []string // the mappings from the paths of the tests to the paths on the Docker host, for the bind mounts
+}
+
+// DaemonInfo returns the information about the Docker daemon used by the provider.
+func (p *DockerProvider) DaemonInfo(ctx context.Context) (DaemonInfo, error) {
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return DaemonInfo{}, fmt.Errorf("docker info: %w", err)
+	}
+	defer p.Close()
+
+	mappedPortsHost, err := p.DaemonHost(ctx)
+	if err != nil {
+		return DaemonInfo{}, fmt.Errorf("daemon host: %w", err)
+	}
+
+	return DaemonInfo{
+		Host:            p.host,
+		HostSource:      core.MustExtractDockerHostSource(ctx),
+		SocketPath:      core.MustExtractDockerSocket(ctx),
+		OSType:          info.OSType,
+		OperatingSystem: info.OperatingSystem,
+		ServerVersion:   info.ServerVersion,
+		InAContainer:    core.InAContainer(),
+		MappedPortsHost: mappedPortsHost,
+		ReaperEnabled:   p.reaperEnabled(ctx),
+		HostMountPaths:  p.config.HostMountPaths,
+	}, nil
+}
+
+// DaemonOSType returns the operating system of the containers run by the Docker daemon,
+// i.e. "linux" or "windows", caching the result.
+func (p *DockerProvider) DaemonOSType(ctx context.Context) (string, error) {
+	if p.osTypeCache != "" {
+		return p.osTypeCache, nil
+	}
+
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return "", fmt.Errorf("docker info: %w", err)
+	}
+	defer p.Close()
+
+	p.osTypeCache = info.OSType
+
+	return p.osTypeCache, nil
+}
+
+// containerdSnapshotterDriverType is the driver type reported by the daemons storing the images in containerd.
+const containerdSnapshotterDriverType = "io.containerd.snapshotter.v1"
+
+// ContainerdImageStore returns true if the Docker daemon stores the images in containerd, i.e. with the containerd
+// image store of Docker Desktop and Docker Engine, instead of the classic storage drivers. The containerd image store
+// keeps all the pulled platforms of the multi-platform images, and pulls the layers in parallel, including the zstd
+// compressed ones.
+func (p *DockerProvider) ContainerdImageStore(ctx context.Context) (bool, error) {
+	if p.containerdStoreCache != nil {
+		return *p.containerdStoreCache, nil
+	}
+
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return false, fmt.Errorf("docker info: %w", err)
+	}
+	defer p.Close()
+
+	containerdStore := isContainerdImageStore(info.DriverStatus)
+	p.containerdStoreCache = &containerdStore
+
+	return containerdStore, nil
+}
+
+// usesContainerdImageStore returns true if the daemon uses the containerd image store,
+// assuming it doesn't if it can't be detected.
+func (p *DockerProvider) usesContainerdImageStore(ctx context.Context) bool {
+	containerdStore, err := p.ContainerdImageStore(ctx)
+	if err != nil {
+		p.Logger.Printf("Failed to detect the containerd image store, assuming the classic storage: %s", err)
+		return false
+	}
+
+	return containerdStore
+}
+
+// isContainerdImageStore returns true if the driver status of the daemon info reports the containerd snapshotter.
+func isContainerdImageStore(driverStatus [][2]string) bool {
+	for _, status := range driverStatus {
+		if status[0] == "driver-type" && status[1] == containerdSnapshotterDriverType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reaperEnabled returns true if the reaper must be used to clean up the resources of the session.
+// The reaper is disabled by configuration, when the tests run in a container and the Docker socket
+// cannot be mounted in the reaper, or when the Docker daemon runs Windows containers, as the reaper
+// image is only available for Linux.
+func (p *DockerProvider) reaperEnabled(ctx context.Context) bool {
+	if p.config.RyukDisabled {
+		return false
+	}
+
+	if issue := p.inContainerReaperIssue(ctx); issue != "" {
+		inContainerReaperWarningOnce.Do(func() {
+			p.Logger.Printf("⚠️ The reaper is disabled, %s: set TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE to the path of the socket on the Docker host to enable it", issue)
+		})
+		return false
+	}
+
+	osType, err := p.DaemonOSType(ctx)
+	if err != nil {
+		// keep the reaper enabled, the error will be reported when creating it.
+		return true
+	}
+
+	if osType == windowsOSType {
+		windowsReaperWarningOnce.Do(func() {
+			p.Logger.Printf("⚠️ The reaper is not supported for Windows containers, resources must be cleaned up by the tests")
+		})
+		return false
+	}
+
+	return true
+}
+
 // DaemonHost gets the host or ip of the Docker daemon where ports are exposed on
 // Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
-// You can use the "TESTCONTAINERS_HOST_OVERRIDE" env variable to set this yourself
+// You can use the WithHostOverride provider option, or the "TESTCONTAINERS_HOST_OVERRIDE"
+// env variable, to set this yourself
 func (p *DockerProvider) DaemonHost(ctx context.Context) (string, error) {
 	return daemonHost(ctx, p)
 }
 
+// DockerHost returns the address used to reach the Docker daemon, which can differ from
+// the host where the mapped ports are reachable (see DaemonHost), and from the address
+// the containers use to reach the host (see HostInternalOverride).
+func (p *DockerProvider) DockerHost() string {
+	return p.host
+}
+
+// HostInternalOverride returns the address the containers use to reach the host, if overridden
+// with the WithHostInternalOverride provider option or the host.internal.override property.
+// An empty value means the host ports are forwarded to the containers by an SSHD container.
+func (p *DockerProvider) HostInternalOverride() string {
+	if p.hostInternalOverride != "" {
+		return p.hostInternalOverride
+	}
+
+	return p.config.HostInternalOverride
+}
+
 func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 	if p.hostCache != "" {
 		return p.hostCache, nil
 	}
 
-	host, exists := os.LookupEnv("TESTCONTAINERS_HOST_OVERRIDE")
-	if exists {
+	// the TESTCONTAINERS_HOST_OVERRIDE env variable is read into the configuration,
+	// unless it's overridden with SetConfig
+	host := p.hostOverride
+	if host == "" {
+		host = p.config.HostOverride
+	}
+	if host != "" {
 		p.hostCache = host
 		return p.hostCache, nil
 	}
@@ -1396,6 +2086,14 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 	switch daemonURL.Scheme {
 	case "http", "https", "tcp":
 		p.hostCache = daemonURL.Hostname()
+	case "ssh":
+		// the mapped ports are tunneled to the local host, if enabled
+		if p.sshTunnelEnabled() {
+			// the tunnels listen on the IPv4 loopback address, localhost could resolve to ::1
+			p.hostCache = "127.0.0.1"
+		} else {
+			p.hostCache = daemonURL.Hostname()
+		}
 	case "unix", "npipe":
 		if core.InAContainer() {
 			ip, err := p.GetGatewayIP(ctx)
@@ -1443,12 +2141,13 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		Attachable: req.Attachable,
 		Labels:     req.Labels,
 		IPAM:       req.IPAM,
+		Options:    req.Options,
 	}
 
 	sessionID := core.SessionID()
 
 	var termSignal chan bool
-	if !p.config.RyukDisabled {
+	if p.reaperEnabled(ctx) {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), sessionID, p)
 		if err != nil {
 			return nil, fmt.Errorf("%w: creating network reaper failed", err)
@@ -1573,6 +2272,12 @@ func containerFromDockerResponse(ctx context.Context, response types.Container)
 		return nil, err
 	}
 
+	return containerFromDockerResponseWithProvider(ctx, provider, response)
+}
+
+// containerFromDockerResponseWithProvider builds a Docker container struct from the response of the Docker API,
+// using the given provider, which must be the provider of the Docker daemon of the container.
+func containerFromDockerResponseWithProvider(ctx context.Context, provider *DockerProvider, response types.Container) (*DockerContainer, error) {
 	ctr := DockerContainer{}
 
 	ctr.ID = response.ID
@@ -1655,7 +2360,10 @@ func (p *DockerProvider) SaveImages(ctx context.Context, output string, images .
 
 // PullImage pulls image from registry
 func (p *DockerProvider) PullImage(ctx context.Context, img string) error {
-	return p.attemptToPullImage(ctx, img, image.PullOptions{})
+	ctx, cancel := withOperationTimeout(ctx, "pull "+img, p.config.PullTimeout)
+	defer cancel()
+
+	return operationError(ctx, p.attemptToPullImage(ctx, img, image.PullOptions{}))
 }
 
 var permanentClientErrors = []func(error) bool{
@@ -59,7 +59,7 @@ func (c *DockerClient) Info(ctx context.Context) (system.Info, error) {
   Operating System: %v
   Total Memory: %v MB%s
   Testcontainers for Go Version: v%s
-  Resolved Docker Host: %s
+  Resolved Docker Host: %s (from %s)
   Resolved Docker Socket Path: %s
   Test SessionID: %s
   Test ProcessID: %s
@@ -80,6 +80,7 @@ func (c *DockerClient) Info(ctx context.Context) (system.Info, error) {
 		infoLabels,
 		internal.Version,
 		core.MustExtractDockerHost(ctx),
+		core.MustExtractDockerHostSource(ctx),
 		core.MustExtractDockerSocket(ctx),
 		core.SessionID(),
 		core.ProcessID(),
@@ -0,0 +1,139 @@
+package testcontainers
+
+import (
+	"context"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// daemonDockerSocket is the Docker socket mounted in the reaper of the Docker daemons
+// set with WithDockerClient or WithDockerHost, in the host of the daemon.
+const daemonDockerSocket = "/var/run/docker.sock"
+
+// daemonReapers are the reapers of the Docker daemons set with WithDockerClient or WithDockerHost,
+// keyed by their Docker host. The reaper of the default Docker daemon is the reaperInstance.
+var daemonReapers = map[string]*Reaper{}
+
+// WithDockerClient returns an option that sets the Docker client to be used, instead of the client
+// of the default Docker host, so a test can create containers in different Docker daemons,
+// e.g. in an amd64 and an arm64 daemon.
+//
+// It can be used to set the client for providers and containers.
+func WithDockerClient(cli client.APIClient) DockerDaemonOption {
+	return DockerDaemonOption{
+		client: cli,
+	}
+}
+
+// WithDockerHost returns an option that sets the Docker host to be used, instead of the default Docker host,
+// e.g. tcp://arm64-builder:2376 or ssh://user@remote-host, so a test can create containers in different Docker daemons.
+// The client for the host is created with the same configuration as the client of the default Docker host.
+//
+// It can be used to set the host for providers and containers.
+func WithDockerHost(host string) DockerDaemonOption {
+	return DockerDaemonOption{
+		host: host,
+	}
+}
+
+// DockerDaemonOption is an option that sets the Docker daemon to be used, with its client or its host.
+// Each Docker daemon gets its own reaper, which removes the containers created in it at the end of the session.
+type DockerDaemonOption struct {
+	client client.APIClient
+	host   string
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+// It's a NOOP, as the Docker daemon is only applied to the Docker providers, see ApplyDockerTo.
+func (o DockerDaemonOption) ApplyGenericTo(*GenericProviderOptions) {}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o DockerDaemonOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.dockerClient = o.client
+	opts.dockerHost = o.host
+}
+
+// Customize implements ContainerCustomizer.
+func (o DockerDaemonOption) Customize(req *GenericContainerRequest) error {
+	req.DockerClient = o.client
+	req.DockerHost = o.host
+	return nil
+}
+
+// daemonOptions returns the provider options of the Docker daemon of the request, if any.
+func (req GenericContainerRequest) daemonOptions() []GenericProviderOption {
+	if req.DockerClient == nil && req.DockerHost == "" {
+		return nil
+	}
+
+	return []GenericProviderOption{DockerDaemonOption{client: req.DockerClient, host: req.DockerHost}}
+}
+
+// newDaemonClient returns the Docker client of the Docker daemon of the provider options,
+// and its Docker host.
+func newDaemonClient(ctx context.Context, o *DockerProviderOptions) (client.APIClient, string, error) {
+	if o.dockerClient != nil {
+		return o.dockerClient, o.dockerClient.DaemonHost(), nil
+	}
+
+	opts := []client.Opt{client.WithHost(o.dockerHost)}
+	if core.IsSSHDockerHost(o.dockerHost) {
+		opts = append(opts, client.WithDialContext(core.SSHDialContext(o.dockerHost)))
+	}
+
+	cli, err := NewDockerClientWithOpts(ctx, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return cli, o.dockerHost, nil
+}
+
+// isDaemonOverride returns true if the provider uses a Docker daemon set with WithDockerClient or WithDockerHost.
+func (p *DockerProvider) isDaemonOverride() bool {
+	return p.DockerProviderOptions != nil && (p.dockerClient != nil || p.dockerHost != "")
+}
+
+// reuseOrCreateDaemonReaper returns the reaper of the Docker daemon of the provider, which was set with
+// WithDockerClient or WithDockerHost, creating it if it does not exist or it's not running anymore.
+func reuseOrCreateDaemonReaper(ctx context.Context, sessionID string, provider *DockerProvider) (*Reaper, error) {
+	reaperMutex.Lock()
+	defer reaperMutex.Unlock()
+
+	if r, ok := daemonReapers[provider.host]; ok {
+		state, err := r.container.State(ctx)
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+		} else if state.Running {
+			return r, nil
+		}
+
+		delete(daemonReapers, provider.host)
+	}
+
+	// the reaper could have been created by a different test process of the session
+	reaperContainer, err := lookUpDaemonReaperContainer(ctx, sessionID, provider)
+	if err == nil && reaperContainer != nil {
+		provider.Logger.Printf("🔥 Reaper obtained from Docker host %s for this test session %s", provider.host, reaperContainer.ID)
+		r, err := reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
+		if err != nil {
+			return nil, err
+		}
+
+		daemonReapers[provider.host] = r
+		return r, nil
+	}
+
+	r, err := newReaper(ctx, sessionID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	daemonReapers[provider.host] = r
+	return r, nil
+}
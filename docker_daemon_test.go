@@ -0,0 +1,83 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestDockerDaemonOption(t *testing.T) {
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://127.0.0.1:2375"))
+	require.NoError(t, err)
+
+	t.Run("customize", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.NoError(t, WithDockerHost("tcp://127.0.0.1:2375").Customize(&req))
+		require.Equal(t, "tcp://127.0.0.1:2375", req.DockerHost)
+		require.Nil(t, req.DockerClient)
+
+		require.NoError(t, WithDockerClient(cli).Customize(&req))
+		require.Equal(t, cli, req.DockerClient)
+		require.Empty(t, req.DockerHost)
+	})
+
+	t.Run("no-daemon", func(t *testing.T) {
+		require.Empty(t, GenericContainerRequest{}.daemonOptions())
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		provider, err := NewDockerProvider(WithDockerClient(cli))
+		require.NoError(t, err)
+
+		require.True(t, provider.isDaemonOverride())
+		require.Equal(t, cli, provider.Client())
+		require.Equal(t, "tcp://127.0.0.1:2375", provider.host)
+	})
+
+	t.Run("generic-provider", func(t *testing.T) {
+		req := GenericContainerRequest{DockerClient: cli}
+
+		provider, err := ProviderDocker.GetProvider(req.daemonOptions()...)
+		require.NoError(t, err)
+
+		dockerProvider, ok := provider.(*DockerProvider)
+		require.True(t, ok)
+		require.True(t, dockerProvider.isDaemonOverride())
+		require.Equal(t, cli, dockerProvider.Client())
+	})
+}
+
+func TestGenericContainer_withDockerHost(t *testing.T) {
+	ctx := context.Background()
+
+	host := core.MustExtractDockerHost(ctx)
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	}
+	require.NoError(t, WithDockerHost(host).Customize(&req))
+
+	ctr, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	dockerContainer, ok := ctr.(*DockerContainer)
+	require.True(t, ok)
+	require.True(t, dockerContainer.provider.isDaemonOverride())
+	require.Equal(t, host, dockerContainer.provider.host)
+
+	if !dockerContainer.provider.config.RyukDisabled {
+		reaperMutex.Lock()
+		defer reaperMutex.Unlock()
+
+		require.Contains(t, daemonReapers, host)
+	}
+}
@@ -0,0 +1,146 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// inspectMockCli is a mock implementation of client.APIClient counting the inspections of the containers.
+type inspectMockCli struct {
+	client.APIClient
+
+	inspectCount int
+	status       string
+	hostPort     string
+}
+
+func (f *inspectMockCli) ContainerInspect(_ context.Context, id string) (types.ContainerJSON, error) {
+	f.inspectCount++
+
+	ports := nat.PortMap{}
+	if f.hostPort != "" {
+		ports["80/tcp"] = []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: f.hostPort}}
+	}
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         id,
+			State:      &types.ContainerState{Status: f.status, Running: f.status == "running"},
+			HostConfig: &container.HostConfig{},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{Ports: ports},
+		},
+	}, nil
+}
+
+func (f *inspectMockCli) ContainerPause(_ context.Context, _ string) error {
+	f.status = "paused"
+	return nil
+}
+
+func (f *inspectMockCli) ContainerUnpause(_ context.Context, _ string) error {
+	f.status = "running"
+	return nil
+}
+
+func (f *inspectMockCli) Close() error {
+	return nil
+}
+
+func TestDockerContainer_Inspect_cache(t *testing.T) {
+	ctx := context.Background()
+
+	m := &inspectMockCli{status: "running"}
+	ctr := &DockerContainer{
+		ID:       "inspect-cache",
+		provider: &DockerProvider{client: m},
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		for range 3 {
+			inspect, err := ctr.Inspect(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "running", inspect.State.Status)
+		}
+		require.Equal(t, 1, m.inspectCount)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		_, err := ctr.Inspect(ctx, Refresh())
+		require.NoError(t, err)
+		require.Equal(t, 2, m.inspectCount)
+
+		_, err = ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, m.inspectCount)
+	})
+
+	t.Run("state-updates-cache", func(t *testing.T) {
+		m.status = "exited"
+
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "exited", state.Status)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "exited", inspect.State.Status)
+		require.Equal(t, 3, m.inspectCount)
+	})
+
+	t.Run("invalidated-on-state-change", func(t *testing.T) {
+		require.NoError(t, ctr.Pause(ctx))
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "paused", inspect.State.Status)
+		require.Equal(t, 4, m.inspectCount)
+
+		require.NoError(t, ctr.Unpause(ctx))
+
+		inspect, err = ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "running", inspect.State.Status)
+		require.Equal(t, 5, m.inspectCount)
+	})
+}
+
+func TestDockerContainer_MappedPort_staleInspect(t *testing.T) {
+	ctx := context.Background()
+
+	m := &inspectMockCli{status: "running", hostPort: "32768"}
+	ctr := &DockerContainer{
+		ID:       "stale-inspect",
+		provider: &DockerProvider{client: m},
+	}
+
+	t.Run("running", func(t *testing.T) {
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, nat.Port("32768/tcp"), port)
+
+		_, err = ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, 1, m.inspectCount)
+	})
+
+	t.Run("restarted-by-daemon", func(t *testing.T) {
+		// the container exited, and was started again by the daemon with new ports
+		m.status, m.hostPort = "exited", ""
+		_, err := ctr.Inspect(ctx, Refresh())
+		require.NoError(t, err)
+
+		m.status, m.hostPort = "running", "32769"
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, nat.Port("32769/tcp"), port)
+		require.Equal(t, 3, m.inspectCount)
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -331,6 +332,62 @@ func TestContainerStateAfterTermination(t *testing.T) {
 	})
 }
 
+func TestContainerStopWithSignal(t *testing.T) {
+	ctx := context.Background()
+
+	// the shell traps SIGUSR1 only, exiting with a known code to prove the signal was received
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "alpine",
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{"trap 'exit 42' USR1; echo ready; while true; do sleep 0.1; done"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	timeout := 10 * time.Second
+	err = StopWithSignal(ctx, ctr, "SIGUSR1", &timeout)
+	require.NoError(t, err)
+
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Running)
+	require.Equal(t, 42, state.ExitCode)
+}
+
+func TestContainerPauseUnpause(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	require.NoError(t, Pause(ctx, ctr))
+
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Paused)
+
+	require.NoError(t, Unpause(ctx, ctr))
+
+	state, err = ctr.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Paused)
+	require.True(t, state.Running)
+}
+
 func TestContainerTerminationRemovesDockerImage(t *testing.T) {
 	t.Run("if not built from Dockerfile", func(t *testing.T) {
 		ctx := context.Background()
@@ -2307,3 +2364,20 @@ func TestCustomPrefixTrailingSlashIsProperlyRemovedIfPresent(t *testing.T) {
 	dockerContainer := c.(*DockerContainer)
 	assert.Equal(t, fmt.Sprintf("%s%s", hubPrefixWithTrailingSlash, dockerImage), dockerContainer.Image)
 }
+
+func TestLowestPort(t *testing.T) {
+	ports := nat.PortMap{
+		"8080/tcp":  nil,
+		"80/tcp":    nil,
+		"53/udp":    nil,
+		"514/udp":   nil,
+		"3868/sctp": nil,
+	}
+
+	require.Equal(t, nat.Port("53/udp"), lowestPort(ports, ""))
+	require.Equal(t, nat.Port("53/udp"), lowestPort(ports, "http"))
+	require.Equal(t, nat.Port("80/tcp"), lowestPort(ports, "tcp"))
+	require.Equal(t, nat.Port("53/udp"), lowestPort(ports, "udp"))
+	require.Equal(t, nat.Port("3868/sctp"), lowestPort(ports, "sctp"))
+	require.Equal(t, nat.Port(""), lowestPort(nat.PortMap{"80/tcp": nil}, "udp"))
+}
@@ -0,0 +1,294 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// envFileKey matches the valid variable names of an env file.
+var envFileKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// WithEnvFile sets the environment variables of the container from the env file at the given path,
+// in the dotenv format used by Docker Compose. The values can reference variables with ${VAR} or $VAR,
+// which are expanded with the variables defined before in the file, then with the environment variables
+// already set in the request, then with the environment variables of the test process.
+//
+// The variables override the ones already set in the request, and are overridden by the options
+// applied after, e.g. WithEnv, so env files can be layered.
+func WithEnvFile(path string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read env file: %w", err)
+		}
+
+		return applyEnvFile(req, path, content)
+	}
+}
+
+// WithEnvFileFS is like WithEnvFile, reading the env file at the given path of the file system,
+// e.g. an embed.FS.
+func WithEnvFileFS(fsys fs.FS, path string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("read env file: %w", err)
+		}
+
+		return applyEnvFile(req, path, content)
+	}
+}
+
+// applyEnvFile parses the env file content and sets its variables in the request.
+func applyEnvFile(req *GenericContainerRequest, path string, content []byte) error {
+	env, err := parseEnvFile(string(content), func(name string) (string, bool) {
+		if value, ok := req.Env[name]; ok {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	})
+	if err != nil {
+		return fmt.Errorf("env file %s: %w", filepath.Base(path), err)
+	}
+
+	if req.Env == nil {
+		req.Env = map[string]string{}
+	}
+
+	for key, val := range env {
+		req.Env[key] = val
+	}
+
+	return nil
+}
+
+// parseEnvFile parses the content of an env file, expanding the values with the variables
+// defined before in the file, then with lookup:
+//   - empty lines and lines starting with # are ignored.
+//   - the optional export prefix is ignored.
+//   - a variable without value, e.g. VAR, takes the value returned by lookup, if any.
+//   - unquoted values are trimmed, and the comments preceded by a space are removed.
+//   - single-quoted values are literal and can span several lines.
+//   - double-quoted values support the \n, \r, \t, \" and \\ escapes, and can span several lines.
+//   - ${VAR:-default}, ${VAR-default}, ${VAR:?error} and ${VAR?error} are supported.
+func parseEnvFile(content string, lookup func(string) (string, bool)) (map[string]string, error) {
+	env := map[string]string{}
+	resolve := func(name string) (string, bool) {
+		if value, ok := env[name]; ok {
+			return value, true
+		}
+		return lookup(name)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, hasValue := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !envFileKey.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, key)
+		}
+
+		if !hasValue {
+			if value, ok := lookup(key); ok {
+				env[key] = value
+			}
+			continue
+		}
+
+		value = strings.TrimLeft(value, " \t")
+
+		var err error
+		switch {
+		case strings.HasPrefix(value, "'"), strings.HasPrefix(value, `"`):
+			quote := value[:1]
+
+			// the quoted value ends at the first unescaped quote, possibly on a following line
+			raw := value[1:]
+			end := closingQuote(raw, quote)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				raw += "\n" + lines[i]
+				end = closingQuote(raw, quote)
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value of %s", lineNumber, key)
+			}
+
+			if rest := strings.TrimSpace(raw[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: unexpected characters after the quoted value of %s", lineNumber, key)
+			}
+
+			value = raw[:end]
+			if quote == `"` {
+				value, err = expandEnvValue(value, true, resolve)
+			}
+		default:
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				value = value[:idx]
+			}
+			if idx := strings.Index(value, "\t#"); idx >= 0 {
+				value = value[:idx]
+			}
+
+			value, err = expandEnvValue(strings.TrimSpace(value), false, resolve)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		env[key] = value
+	}
+
+	return env, nil
+}
+
+// closingQuote returns the index of the closing quote in s, or -1 if there is none.
+// Double quotes can be escaped with a backslash, single quotes can't.
+func closingQuote(s string, quote string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == `"`:
+			i++
+		case s[i] == quote[0]:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// expandEnvValue expands the variables of the value with resolve, processing the backslash escapes if escapes is true.
+func expandEnvValue(value string, escapes bool, resolve func(string) (string, bool)) (string, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case c == '\\' && escapes && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(value[i])
+			}
+		case c == '$' && i+1 < len(value) && value[i+1] == '{':
+			end := closingBrace(value, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable in %q", value)
+			}
+
+			expanded, err := expandEnvVariable(value[i+2:end], escapes, resolve)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(expanded)
+			i = end
+		case c == '$' && i+1 < len(value) && isEnvNameStart(value[i+1]):
+			end := i + 1
+			for end < len(value) && isEnvNameChar(value[end]) {
+				end++
+			}
+
+			expanded, _ := resolve(value[i+1 : end])
+			sb.WriteString(expanded)
+			i = end - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// expandEnvVariable expands the content of a ${...} variable, with its optional default value or error.
+func expandEnvVariable(expr string, escapes bool, resolve func(string) (string, bool)) (string, error) {
+	end := 0
+	for end < len(expr) && isEnvNameChar(expr[end]) {
+		end++
+	}
+
+	name, modifier := expr[:end], expr[end:]
+	if name == "" {
+		return "", fmt.Errorf("invalid variable ${%s}", expr)
+	}
+
+	value, ok := resolve(name)
+
+	for _, op := range []string{":-", "-", ":?", "?"} {
+		arg, found := strings.CutPrefix(modifier, op)
+		if !found {
+			continue
+		}
+
+		// the colon variants also apply to the empty variables
+		unset := !ok || (strings.HasPrefix(op, ":") && value == "")
+		if !unset {
+			return value, nil
+		}
+
+		arg, err := expandEnvValue(arg, escapes, resolve)
+		if err != nil {
+			return "", err
+		}
+
+		if strings.HasSuffix(op, "?") {
+			if arg == "" {
+				arg = "not set"
+			}
+			return "", fmt.Errorf("%s: %w", name, errors.New(arg))
+		}
+
+		return arg, nil
+	}
+
+	if modifier != "" {
+		return "", fmt.Errorf("invalid variable ${%s}", expr)
+	}
+
+	return value, nil
+}
+
+// closingBrace returns the index of the brace closing the variable starting at start, or -1 if there is none.
+func closingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func isEnvNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isEnvNameChar(c byte) bool {
+	return isEnvNameStart(c) || (c >= '0' && c <= '9')
+}
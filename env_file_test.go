@@ -0,0 +1,103 @@
+package testcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"HOME": "/home/gopher", "EMPTY": ""}[name]
+		return value, ok
+	}
+
+	content := `# database settings
+export DB_HOST=db
+DB_PORT = 5432 # the default port
+DB_URL=postgres://${DB_HOST}:$DB_PORT/app
+SINGLE='literal ${DB_HOST} # not a comment'
+DOUBLE="line1\nline2 \"quoted\" ${DB_HOST}"
+MULTILINE="first
+second"
+DEFAULT=${UNSET:-fallback}
+EMPTY_DEFAULT=${EMPTY:-fallback}
+EMPTY_KEPT=${EMPTY-fallback}
+NESTED=${UNSET:-${HOME}/data}
+HOME
+UNSET_WITHOUT_VALUE
+HASH=a#b
+`
+
+	env, err := parseEnvFile(content, lookup)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"DB_HOST":       "db",
+		"DB_PORT":       "5432",
+		"DB_URL":        "postgres://db:5432/app",
+		"SINGLE":        "literal ${DB_HOST} # not a comment",
+		"DOUBLE":        "line1\nline2 \"quoted\" db",
+		"MULTILINE":     "first\nsecond",
+		"DEFAULT":       "fallback",
+		"EMPTY_DEFAULT": "fallback",
+		"EMPTY_KEPT":    "",
+		"NESTED":        "/home/gopher/data",
+		"HOME":          "/home/gopher",
+		"HASH":          "a#b",
+	}, env)
+
+	t.Run("errors", func(t *testing.T) {
+		for content, expected := range map[string]string{
+			"1VAR=value":                "line 1: invalid variable name",
+			"A=1\nVAR=\"unterminated":   "line 2: unterminated quoted value",
+			"VAR='value' trailing":      "line 1: unexpected characters",
+			"VAR=${UNSET:?is required}": "line 1: UNSET: is required",
+			"VAR=${UNSET?}":             "line 1: UNSET: not set",
+			"VAR=${DB_HOST":             "line 1: unterminated variable",
+			"VAR=${DB_HOST/x}":          "line 1: invalid variable",
+		} {
+			_, err := parseEnvFile(content, lookup)
+			require.ErrorContains(t, err, expected, content)
+		}
+	})
+}
+
+func TestWithEnvFile(t *testing.T) {
+	t.Setenv("TC_ENV_FILE_REGISTRY", "registry.local")
+
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("IMAGE=${TC_ENV_FILE_REGISTRY}/app\nLEVEL=info\nNAME=${APP}-1\n"), 0o600))
+
+	fsys := fstest.MapFS{
+		"env/test.env": &fstest.MapFile{Data: []byte("LEVEL=debug\n")},
+	}
+
+	req := &GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Env: map[string]string{"APP": "api", "LEVEL": "warn"}},
+	}
+
+	for _, opt := range []CustomizeRequestOption{
+		WithEnvFile(path),
+		WithEnvFileFS(fsys, "env/test.env"),
+		WithEnv(map[string]string{"EXTRA": "1"}),
+	} {
+		require.NoError(t, opt.Customize(req))
+	}
+
+	require.Equal(t, map[string]string{
+		"APP":   "api",
+		"IMAGE": "registry.local/app",
+		"LEVEL": "debug",
+		"NAME":  "api-1",
+		"EXTRA": "1",
+	}, req.Env)
+
+	err := WithEnvFile(filepath.Join(t.TempDir(), "missing.env")).Customize(req)
+	require.ErrorContains(t, err, "read env file")
+
+	err = WithEnvFileFS(fstest.MapFS{"bad.env": &fstest.MapFile{Data: []byte("=value")}}, "bad.env").Customize(req)
+	require.ErrorContains(t, err, "env file bad.env: line 1")
+}
@@ -0,0 +1,67 @@
+// Package environments provides presets of test environments combining several modules, e.g. the database,
+// the cache and the mail server of a web application, attached to a network, started in one call,
+// returning the endpoints of the services, and torn down together:
+//
+//	import (
+//		"github.com/testcontainers/testcontainers-go/environments"
+//		_ "github.com/testcontainers/testcontainers-go/modules/postgres"
+//		_ "github.com/testcontainers/testcontainers-go/modules/redis"
+//	)
+//
+//	env, err := environments.WebApp(ctx, environments.WithPostgres(), environments.WithRedis(), environments.WithMailpit())
+//
+// The modules run through the catalog of the modules package, so their packages must be imported to register them.
+package environments
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Endpoint is the address of a port of a service, from the host and from the network of the environment.
+type Endpoint struct {
+	// Host is the host of the service from the tests, e.g. localhost.
+	Host string
+	// Port is the port of the service from the tests, i.e. the mapped port.
+	Port string
+	// Alias is the host of the service from the containers attached to the network of the environment.
+	Alias string
+	// ContainerPort is the port of the service from the containers attached to the network of the environment.
+	ContainerPort string
+}
+
+// Address returns the host:port address of the service from the tests.
+func (e Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, e.Port)
+}
+
+// NetworkAddress returns the host:port address of the service from the containers attached
+// to the network of the environment.
+func (e Endpoint) NetworkAddress() string {
+	return net.JoinHostPort(e.Alias, e.ContainerPort)
+}
+
+// endpoint returns the endpoint of the port of the container, which has the given network alias.
+func endpoint(ctx context.Context, ctr testcontainers.Container, alias string, port nat.Port) (Endpoint, error) {
+	host, err := ctr.Host(ctx)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("host: %w", err)
+	}
+
+	mapped, err := ctr.MappedPort(ctx, port)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("mapped port %s: %w", port, err)
+	}
+
+	return Endpoint{
+		Host:          host,
+		Port:          mapped.Port(),
+		Alias:         alias,
+		ContainerPort: port.Port(),
+	}, nil
+}
@@ -0,0 +1,323 @@
+package environments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// The network aliases of the services of the lakehouse environment.
+const (
+	MinIOAlias       = "minio"
+	IcebergRESTAlias = "iceberg-rest"
+)
+
+const (
+	// defaultIcebergRESTImage is the image of the Iceberg REST catalog.
+	defaultIcebergRESTImage = "tabulario/iceberg-rest:1.6.0"
+	// defaultWarehouseBucket is the bucket of the warehouse of the lakehouse environment.
+	defaultWarehouseBucket = "warehouse"
+	// defaultS3Region is the region of the S3 API of MinIO.
+	defaultS3Region = "us-east-1"
+)
+
+// MinIO is the MinIO object storage of an environment, run with the minio module, serving the S3 API.
+type MinIO struct {
+	Container testcontainers.Container
+	Endpoint  Endpoint
+
+	AccessKey string
+	SecretKey string
+	Region    string
+}
+
+// URL returns the URL of the S3 API from the tests.
+func (m *MinIO) URL() string {
+	return "http://" + m.Endpoint.Address()
+}
+
+// NetworkURL returns the URL of the S3 API from the containers attached to the network of the environment.
+func (m *MinIO) NetworkURL() string {
+	return "http://" + m.Endpoint.NetworkAddress()
+}
+
+// IcebergCatalog is the Iceberg REST catalog of an environment, storing the tables in MinIO.
+type IcebergCatalog struct {
+	Container testcontainers.Container
+	Endpoint  Endpoint
+}
+
+// URI returns the URI of the catalog from the tests, the uri property of the Iceberg REST clients.
+func (c *IcebergCatalog) URI() string {
+	return "http://" + c.Endpoint.Address()
+}
+
+// NetworkURI returns the URI of the catalog from the containers attached to the network of the environment.
+func (c *IcebergCatalog) NetworkURI() string {
+	return "http://" + c.Endpoint.NetworkAddress()
+}
+
+// LakehouseEnvironment is a lakehouse environment, with an Iceberg REST catalog storing the tables
+// in a bucket of MinIO, its warehouse.
+type LakehouseEnvironment struct {
+	// Network is the network the services are attached to, with their alias.
+	Network *testcontainers.DockerNetwork
+
+	MinIO   *MinIO
+	Catalog *IcebergCatalog
+
+	// Warehouse is the location of the warehouse of the catalog, e.g. s3://warehouse/.
+	Warehouse string
+
+	// containers are the containers of the services, in their start order
+	containers []testcontainers.Container
+}
+
+// CatalogProperties returns the properties of the Iceberg REST catalog clients run by the tests, e.g. PyIceberg
+// or iceberg-go: the URI of the catalog, the warehouse, and the endpoint and the credentials of the S3 API.
+func (e *LakehouseEnvironment) CatalogProperties() map[string]string {
+	return e.catalogProperties(e.Catalog.URI(), e.MinIO.URL())
+}
+
+// NetworkCatalogProperties returns the properties of CatalogProperties, for the clients run in a container attached
+// to the network of the environment, e.g. Spark or Trino, so the services are reached with their alias.
+func (e *LakehouseEnvironment) NetworkCatalogProperties() map[string]string {
+	return e.catalogProperties(e.Catalog.NetworkURI(), e.MinIO.NetworkURL())
+}
+
+func (e *LakehouseEnvironment) catalogProperties(uri string, s3Endpoint string) map[string]string {
+	return map[string]string{
+		"uri":                  uri,
+		"warehouse":            e.Warehouse,
+		"s3.endpoint":          s3Endpoint,
+		"s3.access-key-id":     e.MinIO.AccessKey,
+		"s3.secret-access-key": e.MinIO.SecretKey,
+		"s3.region":            e.MinIO.Region,
+		"s3.path-style-access": "true",
+	}
+}
+
+// Customize implements the testcontainers.ContainerCustomizer interface, attaching the container of the application
+// to the network of the environment, and adding the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_REGION variables
+// to its environment, except the variables already set.
+func (e *LakehouseEnvironment) Customize(req *testcontainers.GenericContainerRequest) error {
+	req.Networks = append(req.Networks, e.Network.Name)
+
+	if req.Env == nil {
+		req.Env = map[string]string{}
+	}
+	for k, v := range e.MinIO.awsEnv() {
+		if _, ok := req.Env[k]; !ok {
+			req.Env[k] = v
+		}
+	}
+
+	return nil
+}
+
+// Terminate terminates the containers of the services, then removes the network.
+// The options are passed to the termination of each container.
+func (e *LakehouseEnvironment) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	var errs []error
+	if err := testcontainers.TerminateAll(ctx, e.containers, opts...); err != nil {
+		errs = append(errs, err)
+	}
+
+	if e.Network != nil {
+		if err := e.Network.Remove(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("remove network: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// awsEnv returns the environment variables of the AWS SDKs with the credentials of MinIO.
+func (m *MinIO) awsEnv() map[string]string {
+	return map[string]string{
+		"AWS_ACCESS_KEY_ID":     m.AccessKey,
+		"AWS_SECRET_ACCESS_KEY": m.SecretKey,
+		"AWS_REGION":            m.Region,
+	}
+}
+
+// lakehouseOptions are the options of the services of the lakehouse environment.
+type lakehouseOptions struct {
+	bucket  string
+	minio   []testcontainers.ContainerCustomizer
+	catalog []testcontainers.ContainerCustomizer
+}
+
+// LakehouseOption is an option of the lakehouse environment.
+type LakehouseOption func(*lakehouseOptions)
+
+// WithWarehouseBucket sets the bucket of the warehouse of the catalog, created in MinIO. The default is warehouse.
+func WithWarehouseBucket(bucket string) LakehouseOption {
+	return func(o *lakehouseOptions) {
+		o.bucket = bucket
+	}
+}
+
+// WithMinIOOptions passes the options to the minio module, e.g. minio.WithUsername.
+func WithMinIOOptions(opts ...testcontainers.ContainerCustomizer) LakehouseOption {
+	return func(o *lakehouseOptions) {
+		o.minio = append(o.minio, opts...)
+	}
+}
+
+// WithCatalogOptions customizes the container of the Iceberg REST catalog, e.g. testcontainers.WithImage.
+func WithCatalogOptions(opts ...testcontainers.ContainerCustomizer) LakehouseOption {
+	return func(o *lakehouseOptions) {
+		o.catalog = append(o.catalog, opts...)
+	}
+}
+
+// Lakehouse starts a lakehouse environment: MinIO, run with the minio module, whose package must be imported,
+// with the bucket of the warehouse, and an Iceberg REST catalog storing its tables in the bucket, attached to a new
+// network with their alias, e.g. minio. If a service fails to start, the started containers and the network are removed.
+// Terminate the returned environment to tear it down.
+func Lakehouse(ctx context.Context, opts ...LakehouseOption) (*LakehouseEnvironment, error) {
+	options := lakehouseOptions{bucket: defaultWarehouseBucket}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// fail before creating the network if the module is not registered
+	if _, ok := modules.Lookup("minio"); !ok {
+		return nil, fmt.Errorf("%w: minio, import its package to register it", modules.ErrModuleNotFound)
+	}
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new network: %w", err)
+	}
+
+	env := &LakehouseEnvironment{
+		Network:   nw,
+		Warehouse: "s3://" + options.bucket + "/",
+	}
+
+	// tear down what was started if a service fails
+	fail := func(err error) (*LakehouseEnvironment, error) {
+		if termErr := env.Terminate(context.Background()); termErr != nil {
+			err = errors.Join(err, termErr)
+		}
+		return nil, err
+	}
+
+	if env.MinIO, err = env.runMinIO(ctx, options.bucket, options.minio); err != nil {
+		return fail(fmt.Errorf("minio: %w", err))
+	}
+
+	if env.Catalog, err = env.runCatalog(ctx, options.catalog); err != nil {
+		return fail(fmt.Errorf("iceberg rest catalog: %w", err))
+	}
+
+	return env, nil
+}
+
+// run creates and starts the container of a service, attached to the network with the alias,
+// recording it for the termination of the environment.
+func (e *LakehouseEnvironment) run(alias string, run func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error), opts []testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	opts = append([]testcontainers.ContainerCustomizer{network.WithNetwork([]string{alias}, e.Network)}, opts...)
+
+	ctr, err := run(opts...)
+	if ctr != nil {
+		e.containers = append(e.containers, ctr)
+	}
+
+	return ctr, err
+}
+
+// runMinIO starts MinIO, reading its credentials from the environment of the container, as the options
+// of the module may have changed them, then creates the bucket of the warehouse with the mc client of the image.
+func (e *LakehouseEnvironment) runMinIO(ctx context.Context, bucket string, opts []testcontainers.ContainerCustomizer) (*MinIO, error) {
+	ctr, err := e.run(MinIOAlias, func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		return modules.Run(ctx, "minio", opts...)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	m := &MinIO{Container: ctr, Region: defaultS3Region}
+	for _, kv := range inspect.Config.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		switch key {
+		case "MINIO_ROOT_USER":
+			m.AccessKey = value
+		case "MINIO_ROOT_PASSWORD":
+			m.SecretKey = value
+		case "MINIO_REGION":
+			m.Region = value
+		}
+	}
+
+	if m.Endpoint, err = endpoint(ctx, ctr, MinIOAlias, "9000/tcp"); err != nil {
+		return nil, err
+	}
+
+	cmd := []string{"sh", "-c", `mc alias set local http://localhost:9000 "$MINIO_ROOT_USER" "$MINIO_ROOT_PASSWORD" && mc mb --ignore-existing local/` + bucket}
+	code, r, err := ctr.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return nil, fmt.Errorf("create bucket %s: %w", bucket, err)
+	}
+	if code != 0 {
+		out, _ := io.ReadAll(r)
+		return nil, fmt.Errorf("create bucket %s: exit code %d: %s", bucket, code, strings.TrimSpace(string(out)))
+	}
+
+	return m, nil
+}
+
+// runCatalog starts the Iceberg REST catalog, storing its tables in the bucket of the warehouse.
+func (e *LakehouseEnvironment) runCatalog(ctx context.Context, opts []testcontainers.ContainerCustomizer) (*IcebergCatalog, error) {
+	ctr, err := e.run(IcebergRESTAlias, func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        defaultIcebergRESTImage,
+				ExposedPorts: []string{"8181/tcp"},
+				Env: map[string]string{
+					"CATALOG_WAREHOUSE":              e.Warehouse,
+					"CATALOG_IO__IMPL":               "org.apache.iceberg.aws.s3.S3FileIO",
+					"CATALOG_S3_ENDPOINT":            e.MinIO.NetworkURL(),
+					"CATALOG_S3_PATH__STYLE__ACCESS": "true",
+					"AWS_ACCESS_KEY_ID":              e.MinIO.AccessKey,
+					"AWS_SECRET_ACCESS_KEY":          e.MinIO.SecretKey,
+					"AWS_REGION":                     e.MinIO.Region,
+				},
+				WaitingFor: wait.ForHTTP("/v1/config").WithPort("8181/tcp"),
+			},
+			Started: true,
+		}
+		for _, opt := range opts {
+			if err := opt.Customize(&req); err != nil {
+				return nil, err
+			}
+		}
+
+		return testcontainers.GenericContainer(ctx, req)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &IcebergCatalog{Container: ctr}
+	if c.Endpoint, err = endpoint(ctx, ctr, IcebergRESTAlias, "8181/tcp"); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,68 @@
+package environments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules"
+)
+
+// testLakehouseEnvironment returns a lakehouse environment, without containers.
+func testLakehouseEnvironment() *LakehouseEnvironment {
+	return &LakehouseEnvironment{
+		Network: &testcontainers.DockerNetwork{Name: "lakehouse-network"},
+		MinIO: &MinIO{
+			Endpoint:  Endpoint{Host: "localhost", Port: "32768", Alias: MinIOAlias, ContainerPort: "9000"},
+			AccessKey: "minioadmin",
+			SecretKey: "secret",
+			Region:    "us-east-1",
+		},
+		Catalog: &IcebergCatalog{
+			Endpoint: Endpoint{Host: "localhost", Port: "32769", Alias: IcebergRESTAlias, ContainerPort: "8181"},
+		},
+		Warehouse: "s3://warehouse/",
+	}
+}
+
+func TestLakehouseEnvironment_CatalogProperties(t *testing.T) {
+	env := testLakehouseEnvironment()
+
+	require.Equal(t, map[string]string{
+		"uri":                  "http://localhost:32769",
+		"warehouse":            "s3://warehouse/",
+		"s3.endpoint":          "http://localhost:32768",
+		"s3.access-key-id":     "minioadmin",
+		"s3.secret-access-key": "secret",
+		"s3.region":            "us-east-1",
+		"s3.path-style-access": "true",
+	}, env.CatalogProperties())
+
+	properties := env.NetworkCatalogProperties()
+	require.Equal(t, "http://iceberg-rest:8181", properties["uri"])
+	require.Equal(t, "http://minio:9000", properties["s3.endpoint"])
+}
+
+func TestLakehouseEnvironment_Customize(t *testing.T) {
+	env := testLakehouseEnvironment()
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Env: map[string]string{"AWS_REGION": "eu-west-1"},
+		},
+	}
+	require.NoError(t, env.Customize(&req))
+
+	require.Equal(t, []string{"lakehouse-network"}, req.Networks)
+	require.Equal(t, "eu-west-1", req.Env["AWS_REGION"])
+	require.Equal(t, "minioadmin", req.Env["AWS_ACCESS_KEY_ID"])
+	require.Equal(t, "secret", req.Env["AWS_SECRET_ACCESS_KEY"])
+}
+
+func TestLakehouse_moduleNotRegistered(t *testing.T) {
+	_, err := Lakehouse(context.Background())
+	require.ErrorIs(t, err, modules.ErrModuleNotFound)
+	require.ErrorContains(t, err, "minio")
+}
@@ -0,0 +1,390 @@
+package environments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// The network aliases of the services of the web application environment.
+const (
+	PostgresAlias = "postgres"
+	RedisAlias    = "redis"
+	MailpitAlias  = "mailpit"
+)
+
+// defaultMailpitImage is the image of the Mailpit mail server.
+const defaultMailpitImage = "axllent/mailpit:v1.20"
+
+// Postgres is the Postgres database of an environment, run with the postgres module.
+type Postgres struct {
+	Container testcontainers.Container
+	Endpoint  Endpoint
+
+	User     string
+	Password string
+	Database string
+}
+
+// URL returns the connection URL of the database from the tests.
+func (p *Postgres) URL() string {
+	return p.url(p.Endpoint.Address())
+}
+
+// NetworkURL returns the connection URL of the database from the containers attached to the network of the environment.
+func (p *Postgres) NetworkURL() string {
+	return p.url(p.Endpoint.NetworkAddress())
+}
+
+func (p *Postgres) url(address string) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.User, p.Password),
+		Host:     address,
+		Path:     p.Database,
+		RawQuery: "sslmode=disable",
+	}
+
+	return u.String()
+}
+
+// Redis is the Redis server of an environment, run with the redis module.
+type Redis struct {
+	Container testcontainers.Container
+	Endpoint  Endpoint
+}
+
+// URL returns the connection URL of the server from the tests.
+func (r *Redis) URL() string {
+	return "redis://" + r.Endpoint.Address()
+}
+
+// NetworkURL returns the connection URL of the server from the containers attached to the network of the environment.
+func (r *Redis) NetworkURL() string {
+	return "redis://" + r.Endpoint.NetworkAddress()
+}
+
+// Mailpit is the Mailpit mail server of an environment, receiving the mails sent over SMTP,
+// which the tests read with its HTTP API.
+type Mailpit struct {
+	Container testcontainers.Container
+	SMTP      Endpoint
+	HTTP      Endpoint
+}
+
+// APIURL returns the base URL of the HTTP API of the server, from the tests.
+func (m *Mailpit) APIURL() string {
+	return "http://" + m.HTTP.Address()
+}
+
+// WebAppEnvironment is the environment of a web application, with the services requested
+// by the options of WebApp, the others being nil.
+type WebAppEnvironment struct {
+	// Network is the network the services are attached to, with their alias.
+	Network *testcontainers.DockerNetwork
+
+	Postgres *Postgres
+	Redis    *Redis
+	Mailpit  *Mailpit
+
+	// containers are the containers of the services, in their start order
+	containers []testcontainers.Container
+}
+
+// Env returns the environment variables configuring an application run by the tests to use the services:
+// DATABASE_URL, REDIS_URL, SMTP_HOST, SMTP_PORT and MAIL_API_URL, depending on the services of the environment.
+func (e *WebAppEnvironment) Env() map[string]string {
+	env := map[string]string{}
+
+	if e.Postgres != nil {
+		env["DATABASE_URL"] = e.Postgres.URL()
+	}
+
+	if e.Redis != nil {
+		env["REDIS_URL"] = e.Redis.URL()
+	}
+
+	if e.Mailpit != nil {
+		env["SMTP_HOST"] = e.Mailpit.SMTP.Host
+		env["SMTP_PORT"] = e.Mailpit.SMTP.Port
+		env["MAIL_API_URL"] = e.Mailpit.APIURL()
+	}
+
+	return env
+}
+
+// NetworkEnv returns the environment variables of Env, configuring an application run in a container attached
+// to the network of the environment, so the services are reached with their alias.
+func (e *WebAppEnvironment) NetworkEnv() map[string]string {
+	env := map[string]string{}
+
+	if e.Postgres != nil {
+		env["DATABASE_URL"] = e.Postgres.NetworkURL()
+	}
+
+	if e.Redis != nil {
+		env["REDIS_URL"] = e.Redis.NetworkURL()
+	}
+
+	if e.Mailpit != nil {
+		env["SMTP_HOST"] = e.Mailpit.SMTP.Alias
+		env["SMTP_PORT"] = e.Mailpit.SMTP.ContainerPort
+		env["MAIL_API_URL"] = "http://" + e.Mailpit.HTTP.NetworkAddress()
+	}
+
+	return env
+}
+
+// Customize implements the testcontainers.ContainerCustomizer interface, attaching the container of the application
+// to the network of the environment, and adding the variables of NetworkEnv to its environment,
+// except the variables already set.
+func (e *WebAppEnvironment) Customize(req *testcontainers.GenericContainerRequest) error {
+	req.Networks = append(req.Networks, e.Network.Name)
+
+	if req.Env == nil {
+		req.Env = map[string]string{}
+	}
+	for k, v := range e.NetworkEnv() {
+		if _, ok := req.Env[k]; !ok {
+			req.Env[k] = v
+		}
+	}
+
+	return nil
+}
+
+// Terminate terminates the containers of the services, then removes the network.
+// The options are passed to the termination of each container.
+func (e *WebAppEnvironment) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	var errs []error
+	if err := testcontainers.TerminateAll(ctx, e.containers, opts...); err != nil {
+		errs = append(errs, err)
+	}
+
+	if e.Network != nil {
+		if err := e.Network.Remove(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("remove network: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// webAppOptions are the services requested by the options of WebApp, with their options.
+type webAppOptions struct {
+	postgres []testcontainers.ContainerCustomizer
+	redis    []testcontainers.ContainerCustomizer
+	mailpit  []testcontainers.ContainerCustomizer
+}
+
+// WebAppOption requests a service of the web application environment.
+type WebAppOption func(*webAppOptions)
+
+// WithPostgres adds a Postgres database to the environment, run with the postgres module, whose package
+// must be imported. The database, the user and the password default to test, and can be changed with the options
+// of the module, which are passed to it, e.g. postgres.WithDatabase.
+func WithPostgres(opts ...testcontainers.ContainerCustomizer) WebAppOption {
+	return func(o *webAppOptions) {
+		o.postgres = append([]testcontainers.ContainerCustomizer{}, opts...)
+	}
+}
+
+// WithRedis adds a Redis server to the environment, run with the redis module, whose package must be imported.
+// The options are passed to the module.
+func WithRedis(opts ...testcontainers.ContainerCustomizer) WebAppOption {
+	return func(o *webAppOptions) {
+		o.redis = append([]testcontainers.ContainerCustomizer{}, opts...)
+	}
+}
+
+// WithMailpit adds a Mailpit mail server to the environment. The options customize its container,
+// e.g. testcontainers.WithImage.
+func WithMailpit(opts ...testcontainers.ContainerCustomizer) WebAppOption {
+	return func(o *webAppOptions) {
+		o.mailpit = append([]testcontainers.ContainerCustomizer{}, opts...)
+	}
+}
+
+// WebApp starts the environment of a web application, with the services requested by the options, attached
+// to a new network with their alias, e.g. postgres. If a service fails to start, the started containers
+// and the network are removed. Terminate the returned environment to tear it down.
+func WebApp(ctx context.Context, opts ...WebAppOption) (*WebAppEnvironment, error) {
+	var options webAppOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.postgres == nil && options.redis == nil && options.mailpit == nil {
+		return nil, errors.New("no service, use WithPostgres, WithRedis or WithMailpit")
+	}
+
+	// fail before creating the network if a module is not registered
+	var required []string
+	if options.postgres != nil {
+		required = append(required, "postgres")
+	}
+	if options.redis != nil {
+		required = append(required, "redis")
+	}
+	for _, name := range required {
+		if _, ok := modules.Lookup(name); !ok {
+			return nil, fmt.Errorf("%w: %s, import its package to register it", modules.ErrModuleNotFound, name)
+		}
+	}
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new network: %w", err)
+	}
+
+	env := &WebAppEnvironment{Network: nw}
+
+	// tear down what was started if a service fails
+	fail := func(err error) (*WebAppEnvironment, error) {
+		if termErr := env.Terminate(context.Background()); termErr != nil {
+			err = errors.Join(err, termErr)
+		}
+		return nil, err
+	}
+
+	if options.postgres != nil {
+		if env.Postgres, err = env.runPostgres(ctx, options.postgres); err != nil {
+			return fail(fmt.Errorf("postgres: %w", err))
+		}
+	}
+
+	if options.redis != nil {
+		if env.Redis, err = env.runRedis(ctx, options.redis); err != nil {
+			return fail(fmt.Errorf("redis: %w", err))
+		}
+	}
+
+	if options.mailpit != nil {
+		if env.Mailpit, err = env.runMailpit(ctx, options.mailpit); err != nil {
+			return fail(fmt.Errorf("mailpit: %w", err))
+		}
+	}
+
+	return env, nil
+}
+
+// run creates and starts the container of a service, attached to the network with the alias,
+// recording it for the termination of the environment.
+func (e *WebAppEnvironment) run(alias string, run func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error), opts []testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	opts = append([]testcontainers.ContainerCustomizer{network.WithNetwork([]string{alias}, e.Network)}, opts...)
+
+	ctr, err := run(opts...)
+	if ctr != nil {
+		e.containers = append(e.containers, ctr)
+	}
+
+	return ctr, err
+}
+
+// runPostgres starts the Postgres database, reading its credentials from the environment of the container,
+// as the options of the module may have changed them.
+func (e *WebAppEnvironment) runPostgres(ctx context.Context, opts []testcontainers.ContainerCustomizer) (*Postgres, error) {
+	defaults := []testcontainers.ContainerCustomizer{
+		testcontainers.WithEnv(map[string]string{
+			"POSTGRES_USER":     "test",
+			"POSTGRES_PASSWORD": "test",
+			"POSTGRES_DB":       "test",
+		}),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+			wait.ForListeningPort("5432/tcp"),
+		),
+	}
+
+	ctr, err := e.run(PostgresAlias, func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		return modules.Run(ctx, "postgres", opts...)
+	}, append(defaults, opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	pg := &Postgres{Container: ctr}
+	for _, kv := range inspect.Config.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		switch key {
+		case "POSTGRES_USER":
+			pg.User = value
+		case "POSTGRES_PASSWORD":
+			pg.Password = value
+		case "POSTGRES_DB":
+			pg.Database = value
+		}
+	}
+	if pg.Database == "" {
+		pg.Database = pg.User
+	}
+
+	if pg.Endpoint, err = endpoint(ctx, ctr, PostgresAlias, "5432/tcp"); err != nil {
+		return nil, err
+	}
+
+	return pg, nil
+}
+
+// runRedis starts the Redis server.
+func (e *WebAppEnvironment) runRedis(ctx context.Context, opts []testcontainers.ContainerCustomizer) (*Redis, error) {
+	ctr, err := e.run(RedisAlias, func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		return modules.Run(ctx, "redis", opts...)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Redis{Container: ctr}
+	if r.Endpoint, err = endpoint(ctx, ctr, RedisAlias, "6379/tcp"); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// runMailpit starts the Mailpit mail server.
+func (e *WebAppEnvironment) runMailpit(ctx context.Context, opts []testcontainers.ContainerCustomizer) (*Mailpit, error) {
+	ctr, err := e.run(MailpitAlias, func(opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        defaultMailpitImage,
+				ExposedPorts: []string{"1025/tcp", "8025/tcp"},
+				WaitingFor:   wait.ForAll(wait.ForListeningPort("1025/tcp"), wait.ForListeningPort("8025/tcp")),
+			},
+			Started: true,
+		}
+		for _, opt := range opts {
+			if err := opt.Customize(&req); err != nil {
+				return nil, err
+			}
+		}
+
+		return testcontainers.GenericContainer(ctx, req)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mailpit{Container: ctr}
+	if m.SMTP, err = endpoint(ctx, ctr, MailpitAlias, "1025/tcp"); err != nil {
+		return nil, err
+	}
+	if m.HTTP, err = endpoint(ctx, ctr, MailpitAlias, "8025/tcp"); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,129 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/google/uuid"
+)
+
+// ErrMuslLibc is returned when the fake time is requested for an image based on musl libc, e.g. Alpine,
+// which libfaketime doesn't support.
+var ErrMuslLibc = errors.New("musl libc is not supported by libfaketime")
+
+const (
+	// fakeTimeImage is the image of the init container installing libfaketime.
+	// Its glibc is old enough for the library to load in most glibc images.
+	fakeTimeImage = "debian:bullseye-slim"
+
+	// fakeTimeDir is the directory of libfaketime in the container.
+	fakeTimeDir = "/opt/testcontainers/faketime"
+)
+
+// muslLoaders are the dynamic loaders of musl libc, one per architecture.
+var muslLoaders = []string{
+	"/lib/ld-musl-x86_64.so.1",
+	"/lib/ld-musl-aarch64.so.1",
+	"/lib/ld-musl-armhf.so.1",
+	"/lib/ld-musl-i386.so.1",
+	"/lib/ld-musl-ppc64le.so.1",
+	"/lib/ld-musl-s390x.so.1",
+	"/lib/ld-musl-riscv64.so.1",
+}
+
+// WithFakeTime makes the processes of the container see a fake clock, starting at the given time when each process
+// starts, and running at the given rate, e.g. 1 for the real speed, or 60 for one minute per second, so time-dependent
+// behavior, e.g. token expiry or cron schedules, can be tested deterministically. It installs libfaketime
+// in a volume with an init container, and preloads it in the processes of the container, which requires
+// an image based on glibc: the creation of the container fails with ErrMuslLibc for musl images, e.g. Alpine.
+// The statically linked binaries, e.g. most Go binaries, don't load libfaketime, so they see the real clock.
+func WithFakeTime(start time.Time, rate float64) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if start.IsZero() {
+			return errors.New("fake time: zero start time")
+		}
+		if rate <= 0 {
+			return fmt.Errorf("fake time: invalid rate %v", rate)
+		}
+
+		volume := "testcontainers-faketime-" + uuid.NewString()
+		lib := fakeTimeDir + "/libfaketime.so.1"
+
+		err := WithInitContainer(ContainerRequest{
+			Image: fakeTimeImage,
+			Cmd: []string{
+				"sh", "-c",
+				"apt-get update -qq && apt-get install -qq -y --no-install-recommends libfaketime > /dev/null" +
+					" && cp /usr/lib/*/faketime/libfaketime.so.1 " + fakeTimeDir,
+			},
+			Mounts: ContainerMounts{VolumeMount(volume, fakeTimeDir)},
+		})(req)
+		if err != nil {
+			return fmt.Errorf("fake time: %w", err)
+		}
+
+		req.Mounts = append(req.Mounts, ContainerMount{
+			Source:   GenericVolumeMountSource{Name: volume},
+			Target:   fakeTimeDir,
+			ReadOnly: true,
+		})
+
+		if req.Env == nil {
+			req.Env = make(map[string]string)
+		}
+		for k, v := range fakeTimeEnv(start, rate) {
+			req.Env[k] = v
+		}
+		if preload := req.Env["LD_PRELOAD"]; preload != "" {
+			req.Env["LD_PRELOAD"] = lib + ":" + preload
+		} else {
+			req.Env["LD_PRELOAD"] = lib
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			Name:        "fake time",
+			ErrorPolicy: HookErrorPolicyFailFast,
+			PostCreates: []ContainerHook{checkGlibc},
+		})
+
+		return nil
+	}
+}
+
+// fakeTimeEnv returns the environment variables of libfaketime, except LD_PRELOAD. The start time is
+// formatted in UTC, which libfaketime interprets in the time zone of the container, UTC by default.
+// The monotonic clock isn't faked, so the timeouts of the processes are not affected by the rate.
+func fakeTimeEnv(start time.Time, rate float64) map[string]string {
+	faketime := "@" + start.UTC().Format(time.DateTime)
+	if rate != 1 {
+		faketime += " x" + strconv.FormatFloat(rate, 'f', -1, 64)
+	}
+
+	return map[string]string{
+		"FAKETIME":                     faketime,
+		"FAKETIME_DONT_FAKE_MONOTONIC": "1",
+	}
+}
+
+// checkGlibc returns an error wrapping ErrMuslLibc if the created container has the dynamic loader of musl libc.
+func checkGlibc(ctx context.Context, ctr Container) error {
+	for _, loader := range muslLoaders {
+		r, err := ctr.CopyFileFromContainer(ctx, loader)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("fake time: check %s: %w", loader, err)
+		}
+		r.Close()
+
+		return fmt.Errorf("fake time: %w: %s", ErrMuslLibc, strings.TrimPrefix(loader, "/lib/"))
+	}
+
+	return nil
+}
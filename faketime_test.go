@@ -0,0 +1,128 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// fakeFilesContainer is a container only holding the given files.
+type fakeFilesContainer struct {
+	Container
+	files map[string]string
+}
+
+func (c fakeFilesContainer) CopyFileFromContainer(_ context.Context, filePath string) (io.ReadCloser, error) {
+	content, ok := c.files[filePath]
+	if !ok {
+		return nil, errdefs.NotFound(errors.New("no such file"))
+	}
+
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func TestFakeTimeEnv(t *testing.T) {
+	start := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	require.Equal(t, map[string]string{
+		"FAKETIME":                     "@2030-01-02 02:04:05",
+		"FAKETIME_DONT_FAKE_MONOTONIC": "1",
+	}, fakeTimeEnv(start, 1))
+
+	require.Equal(t, "@2030-01-02 02:04:05 x0.5", fakeTimeEnv(start, 0.5)["FAKETIME"])
+}
+
+func TestWithFakeTime(t *testing.T) {
+	start := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.ErrorContains(t, WithFakeTime(time.Time{}, 1)(&req), "zero start time")
+		require.ErrorContains(t, WithFakeTime(start, 0)(&req), "invalid rate")
+		require.Empty(t, req.LifecycleHooks)
+	})
+
+	t.Run("request", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Env: map[string]string{"LD_PRELOAD": "/usr/lib/libjemalloc.so"},
+			},
+		}
+
+		require.NoError(t, WithFakeTime(start, 2)(&req))
+		require.Equal(t, "@2030-01-01 00:00:00 x2", req.Env["FAKETIME"])
+		require.Equal(t, fakeTimeDir+"/libfaketime.so.1:/usr/lib/libjemalloc.so", req.Env["LD_PRELOAD"])
+
+		require.Len(t, req.Mounts, 1)
+		require.Equal(t, ContainerMountTarget(fakeTimeDir), req.Mounts[0].Target)
+		require.True(t, req.Mounts[0].ReadOnly)
+
+		require.Len(t, req.LifecycleHooks, 2)
+		require.Len(t, req.LifecycleHooks[0].PreCreates, 1)
+		require.Len(t, req.LifecycleHooks[1].PostCreates, 1)
+	})
+}
+
+func TestCheckGlibc(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, checkGlibc(ctx, fakeFilesContainer{}))
+
+	err := checkGlibc(ctx, fakeFilesContainer{files: map[string]string{"/lib/ld-musl-aarch64.so.1": ""}})
+	require.ErrorIs(t, err, ErrMuslLibc)
+	require.ErrorContains(t, err, "ld-musl-aarch64.so.1")
+}
+
+func TestWithFakeTime_Docker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("glibc", func(t *testing.T) {
+		// withFakeTime {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "debian:bookworm-slim",
+				Cmd:        []string{"date", "-u", "+%Y-%m-%d"},
+				WaitingFor: wait.ForExit(),
+			},
+			Started: true,
+		}
+		require.NoError(t, WithFakeTime(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), 1)(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		// }
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		r, err := ctr.Logs(ctx)
+		require.NoError(t, err)
+		defer r.Close()
+
+		logs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Contains(t, string(logs), "2030-01-01")
+	})
+
+	t.Run("musl", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine:3.20",
+				Cmd:   []string{"date"},
+			},
+			Started: true,
+		}
+		require.NoError(t, WithFakeTime(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), 1)(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, ErrMuslLibc)
+	})
+}
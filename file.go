@@ -6,7 +6,9 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -139,3 +141,109 @@ func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentS
 
 	return buffer, nil
 }
+
+// tarFS compress the files of a file system using tar + gzip algorithms, placing them
+// under the target directory, which must be a tar entry name (see containerTarPath).
+// The permissions of the files are preserved, and symlinks are skipped.
+func tarFS(fsys fs.FS, target string) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+
+	// tar > gzip > buffer
+	zr := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(zr)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, errFn error) error {
+		if errFn != nil {
+			return fmt.Errorf("error traversing the file system: %w", errFn)
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("error getting file info: %w", err)
+		}
+
+		header, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return fmt.Errorf("error getting file info header: %w", err)
+		}
+
+		header.Name = path.Join(target, name)
+		header.Mode = int64(fi.Mode().Perm())
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := fsys.Open(name)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("error copying file: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return buffer, err
+	}
+
+	// produce tar
+	if err := tw.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing tar file: %w", err)
+	}
+	// produce gzip
+	if err := zr.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing gzip file: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// containerPathDir returns the parent directory of a path inside a container,
+// using the path conventions of the container's operating system instead of the
+// ones of the host running the tests, which could differ (e.g. Linux containers
+// run from a Windows host).
+func containerPathDir(osType string, p string) string {
+	if osType != windowsOSType {
+		return path.Dir(p)
+	}
+
+	idx := strings.LastIndexAny(p, `\/`)
+	if idx < 0 {
+		return "."
+	}
+
+	dir := p[:idx]
+	if dir == "" || strings.HasSuffix(dir, ":") {
+		// keep the root of the volume, e.g. "C:\"
+		dir += `\`
+	}
+
+	return dir
+}
+
+// containerTarPath converts a path inside a container into the name of a tar entry.
+// Tar entries always use forward slashes, so for Windows containers the volume name
+// is removed and the backslashes are converted into forward slashes.
+func containerTarPath(osType string, p string) string {
+	if osType != windowsOSType {
+		return p
+	}
+
+	if len(p) >= 2 && p[1] == ':' {
+		p = p[2:]
+	}
+
+	return strings.ReplaceAll(p, `\`, "/")
+}
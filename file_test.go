@@ -6,11 +6,14 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,6 +142,77 @@ func Test_TarFile(t *testing.T) {
 	assert.Equal(t, b, untarBytes)
 }
 
+func Test_TarFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.yaml":      {Data: []byte("key: value"), Mode: 0o644},
+		"scripts/init.sh":  {Data: []byte("#!/bin/sh"), Mode: 0o755},
+		"scripts/link.sh":  {Data: []byte("init.sh"), Mode: fs.ModeSymlink},
+		"scripts/empty.sh": {Mode: 0o600},
+	}
+
+	buff, err := tarFS(fsys, "/etc/app")
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(buff)
+	require.NoError(t, err)
+
+	type entry struct {
+		mode    int64
+		content string
+	}
+
+	entries := map[string]entry{}
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		entries[hdr.Name] = entry{mode: hdr.Mode, content: string(content)}
+	}
+
+	require.Equal(t, map[string]entry{
+		"/etc/app":                  {mode: 0o555},
+		"/etc/app/config.yaml":      {mode: 0o644, content: "key: value"},
+		"/etc/app/scripts":          {mode: 0o555},
+		"/etc/app/scripts/init.sh":  {mode: 0o755, content: "#!/bin/sh"},
+		"/etc/app/scripts/empty.sh": {mode: 0o600},
+	}, entries)
+}
+
+func Test_ContainerPathDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		osType string
+		path   string
+		expect string
+	}{
+		{name: "linux", osType: "linux", path: "/tmp/dir", expect: "/tmp"},
+		{name: "linux root", osType: "linux", path: "/dir", expect: "/"},
+		{name: "windows", osType: "windows", path: `C:\app\dir`, expect: `C:\app`},
+		{name: "windows root", osType: "windows", path: `C:\dir`, expect: `C:\`},
+		{name: "windows forward slashes", osType: "windows", path: "C:/app/dir", expect: "C:/app"},
+		{name: "windows relative", osType: "windows", path: "dir", expect: "."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, containerPathDir(tc.osType, tc.path))
+		})
+	}
+}
+
+func Test_ContainerTarPath(t *testing.T) {
+	require.Equal(t, "/tmp/file.txt", containerTarPath("linux", "/tmp/file.txt"))
+	require.Equal(t, "/app/file.txt", containerTarPath("windows", `C:\app\file.txt`))
+	require.Equal(t, "/app/file.txt", containerTarPath("windows", `\app\file.txt`))
+}
+
 // untar takes a destination path and a reader; a tar reader loops over the tarfile
 // creating the file structure at 'dst' along the way, and writing any files
 func untar(dst string, r io.Reader) error {
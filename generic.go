@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/docker/docker/client"
+
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
@@ -17,11 +19,13 @@ var (
 
 // GenericContainerRequest represents parameters to a generic container
 type GenericContainerRequest struct {
-	ContainerRequest              // embedded request for provider
-	Started          bool         // whether to auto-start the container
-	ProviderType     ProviderType // which provider to use, Docker if empty
-	Logger           Logging      // provide a container specific Logging - use default global logger if empty
-	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	ContainerRequest                  // embedded request for provider
+	Started          bool             // whether to auto-start the container
+	ProviderType     ProviderType     // which provider to use, Docker if empty
+	Logger           Logging          // provide a container specific Logging - use default global logger if empty
+	Reuse            bool             // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	DockerClient     client.APIClient // Docker client used to create the container, instead of the client of the default Docker host
+	DockerHost       string           // Docker host used to create the container, instead of the default Docker host
 }
 
 // Deprecated: will be removed in the future.
@@ -46,17 +50,33 @@ func GenericNetwork(ctx context.Context, req GenericNetworkRequest) (Network, er
 	return network, nil
 }
 
-// GenericContainer creates a generic container with parameters
+// GenericContainer creates a generic container with parameters.
+// The sensitive values of the request are masked in the returned error.
 func GenericContainer(ctx context.Context, req GenericContainerRequest) (Container, error) {
+	c, err := genericContainer(ctx, req)
+	return c, req.redactError(err)
+}
+
+func genericContainer(ctx context.Context, req GenericContainerRequest) (Container, error) {
 	if req.Reuse && req.Name == "" {
 		return nil, ErrReuseEmptyName
 	}
 
+	// fail fast, before connecting to the Docker daemon
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	logging := req.Logger
 	if logging == nil {
 		logging = Logger
 	}
-	provider, err := req.ProviderType.GetProvider(WithLogger(logging))
+	logging = req.redactLogger(logging)
+	for _, warning := range req.commandWarnings() {
+		logging.Printf("⚠️ %s", warning)
+	}
+
+	provider, err := req.ProviderType.GetProvider(append([]GenericProviderOption{WithLogger(logging)}, req.daemonOptions()...)...)
 	if err != nil {
 		return nil, fmt.Errorf("get provider: %w", err)
 	}
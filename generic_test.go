@@ -197,3 +197,37 @@ func TestHelperContainerStarterProcess(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, nginxC.IsRunning())
 }
+
+func TestContainerRequest_commandWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ContainerRequest
+		want []string
+	}{
+		{name: "no-command"},
+		{
+			name: "shell-positional-parameters",
+			req:  ContainerRequest{Entrypoint: []string{"sh", "-c", `echo "$1"`}, Cmd: []string{"sh", "hello"}},
+		},
+		{
+			name: "reset-entrypoint",
+			req:  ContainerRequest{Entrypoint: []string{""}},
+		},
+		{
+			name: "empty-cmd-executable",
+			req:  ContainerRequest{Cmd: []string{"", "--port", "6380"}},
+			want: []string{"the first element of Cmd should be the executable, got an empty string"},
+		},
+		{
+			name: "command-line-as-executable",
+			req:  ContainerRequest{Entrypoint: []string{"redis-server --port 6380"}},
+			want: []string{`the first element of Entrypoint should be the executable, got "redis-server --port 6380": split the command line into one element per argument, unless it's a path with spaces`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.req.commandWarnings())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// hostGateway is the special address resolved by the Docker daemon to the IP of the host,
+// available since Docker 20.10, and on Docker Desktop.
+const hostGateway = "host-gateway"
+
+// dockerDesktopHostInternal is the hostname built into Docker Desktop to reach the host.
+const dockerDesktopHostInternal = "host.docker.internal"
+
+// ErrHostNotReachable is returned when the containers can't reach the host directly,
+// e.g. when the Docker daemon runs on a remote machine.
+var ErrHostNotReachable = errors.New("the host is not directly reachable from the containers")
+
+// WithAccessToHost makes the host reachable from the container at the host.testcontainers.internal hostname,
+// whatever the platform:
+//   - the address set with the host.internal.override property or WithHostInternalOverride, if any.
+//   - the host gateway of the Docker daemon, when it runs on the host, e.g. a native Linux daemon or Docker Desktop.
+//     All the ports of the host are reachable.
+//   - else, e.g. for remote Docker daemons, the given host ports are forwarded to the container by an SSHD
+//     container, like WithHostPortAccess does.
+func WithAccessToHost(ports ...int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.AccessToHost = true
+		req.HostAccessPorts = append(req.HostAccessPorts, ports...)
+
+		return nil
+	}
+}
+
+// HostInternalAddress returns the address the containers of the default Docker host use to reach the host,
+// e.g. for the services needing an address in their configuration instead of the host.testcontainers.internal
+// hostname. See DockerProvider.HostInternalAddress.
+func HostInternalAddress(ctx context.Context) (string, error) {
+	p, err := NewDockerProvider()
+	if err != nil {
+		return "", fmt.Errorf("new docker provider: %w", err)
+	}
+	defer p.Close()
+
+	return p.HostInternalAddress(ctx)
+}
+
+// HostInternalAddress returns the address the containers use to reach the host: the overridden address,
+// if any, then host.docker.internal on Docker Desktop, or the gateway of the default network for the other
+// daemons running on the host. It returns ErrHostNotReachable if the host is not directly reachable from
+// the containers, e.g. for remote Docker daemons: use WithAccessToHost with the ports to forward instead.
+func (p *DockerProvider) HostInternalAddress(ctx context.Context) (string, error) {
+	if address := p.HostInternalOverride(); address != "" {
+		return address, nil
+	}
+
+	if !p.hostDirectlyReachable() {
+		return "", fmt.Errorf("%w: the Docker host is %s", ErrHostNotReachable, p.client.DaemonHost())
+	}
+
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return "", fmt.Errorf("docker info: %w", err)
+	}
+	defer p.Close()
+
+	if info.OperatingSystem == "Docker Desktop" {
+		return dockerDesktopHostInternal, nil
+	}
+
+	ip, err := p.GetGatewayIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gateway IP: %w", err)
+	}
+
+	return ip, nil
+}
+
+// hostDirectlyReachable returns true if the Docker daemon runs on the host of the tests,
+// so the host gateway of the containers is the host of the tests.
+func (p *DockerProvider) hostDirectlyReachable() bool {
+	return isLocalDaemon(p.client.DaemonHost(), core.InAContainer())
+}
+
+// isLocalDaemon returns true if the Docker daemon at the given endpoint runs on the host of the tests.
+// When the tests run in a container sharing the socket of the Docker host, the daemon runs on another host.
+func isLocalDaemon(daemonHost string, inAContainer bool) bool {
+	if inAContainer {
+		return false
+	}
+
+	daemonURL, err := url.Parse(daemonHost)
+	if err != nil {
+		return false
+	}
+
+	switch daemonURL.Scheme {
+	case "unix", "npipe":
+		return true
+	case "tcp", "http", "https":
+		host := daemonURL.Hostname()
+		if host == "localhost" {
+			return true
+		}
+
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return false
+	}
+}
+
+// exposeHost makes the host reachable from the container of the request, resolving the
+// host.testcontainers.internal hostname to the host, or forwarding the host ports with an SSHD container.
+// It returns the lifecycle hooks managing the SSHD container, if any.
+func (p *DockerProvider) exposeHost(ctx context.Context, req *ContainerRequest) (*ContainerLifecycleHooks, error) {
+	switch {
+	case p.HostInternalOverride() != "":
+		// the host is directly reachable from the container, so the internal hostname
+		// is resolved to the overridden address, without forwarding the host ports.
+		exposeHostInternal(req, p.HostInternalOverride())
+	case req.AccessToHost && p.hostDirectlyReachable():
+		exposeHostInternal(req, hostGateway)
+	case len(req.HostAccessPorts) > 0:
+		// a container lifecycle hook will be added, which will expose the host ports to the container
+		// using a SSHD server running in a container. The SSHD server will be started and will
+		// forward the host ports to the container ports.
+		sshdForwardPortsHook, err := exposeHostPorts(ctx, req, req.HostAccessPorts...)
+		if err != nil {
+			return nil, fmt.Errorf("expose host ports: %w", err)
+		}
+
+		return &sshdForwardPortsHook, nil
+	default:
+		return nil, fmt.Errorf("%w: the Docker host is %s, pass the host ports to WithAccessToHost to forward them", ErrHostNotReachable, p.client.DaemonHost())
+	}
+
+	return nil, nil
+}
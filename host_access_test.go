@@ -0,0 +1,90 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestWithAccessToHost(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	require.NoError(t, WithAccessToHost()(&req))
+	require.True(t, req.AccessToHost)
+	require.Empty(t, req.HostAccessPorts)
+
+	require.NoError(t, WithAccessToHost(8080, 9090)(&req))
+	require.Equal(t, []int{8080, 9090}, req.HostAccessPorts)
+}
+
+func TestIsLocalDaemon(t *testing.T) {
+	for daemonHost, expected := range map[string]bool{
+		"unix:///var/run/docker.sock":    true,
+		"npipe:////./pipe/docker_engine": true,
+		"tcp://localhost:2375":           true,
+		"tcp://127.0.0.1:2375":           true,
+		"https://[::1]:2376":             true,
+		"tcp://docker.example.com:2376":  false,
+		"tcp://10.0.0.2:2375":            false,
+		"ssh://user@docker.example.com":  false,
+		"::invalid":                      false,
+	} {
+		require.Equal(t, expected, isLocalDaemon(daemonHost, false), daemonHost)
+	}
+
+	require.False(t, isLocalDaemon("unix:///var/run/docker.sock", true))
+}
+
+func TestDockerProvider_exposeHost(t *testing.T) {
+	ctx := context.Background()
+
+	newProvider := func(t *testing.T, daemonHost string, opts ...DockerProviderOption) *DockerProvider {
+		t.Helper()
+
+		cli, err := client.NewClientWithOpts(client.WithHost(daemonHost))
+		require.NoError(t, err)
+
+		o := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+		for _, opt := range opts {
+			opt.ApplyDockerTo(o)
+		}
+
+		return &DockerProvider{DockerProviderOptions: o, client: cli, config: config.Config{}}
+	}
+
+	extraHosts := func(req *ContainerRequest) []string {
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+		return hostConfig.ExtraHosts
+	}
+
+	t.Run("override", func(t *testing.T) {
+		p := newProvider(t, "tcp://docker.example.com:2376", WithHostInternalOverride("10.0.0.1"))
+
+		req := &ContainerRequest{AccessToHost: true}
+		hooks, err := p.exposeHost(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, hooks)
+		require.Equal(t, []string{HostInternal + ":10.0.0.1"}, extraHosts(req))
+
+		address, err := p.HostInternalAddress(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1", address)
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		p := newProvider(t, "tcp://docker.example.com:2376")
+
+		_, err := p.exposeHost(ctx, &ContainerRequest{AccessToHost: true})
+		require.ErrorIs(t, err, ErrHostNotReachable)
+		require.ErrorContains(t, err, "pass the host ports to WithAccessToHost")
+
+		_, err = p.HostInternalAddress(ctx)
+		require.ErrorIs(t, err, ErrHostNotReachable)
+	})
+}
@@ -0,0 +1,199 @@
+package testcontainers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+const (
+	defaultHTTPClientRetries    = 5
+	defaultHTTPClientBackoff    = 100 * time.Millisecond
+	defaultHTTPClientMaxBackoff = 2 * time.Second
+)
+
+// httpClientOptions are the options of the HTTP client of a container.
+type httpClientOptions struct {
+	tlsConfig *tls.Config
+	retries   int
+	backoff   time.Duration
+	timeout   time.Duration
+}
+
+// HTTPClientOption is an option for the HTTP client of a container, see HTTPClient.
+type HTTPClientOption func(*httpClientOptions)
+
+// HTTPClientTLS sets the TLS configuration of the client, e.g. trusting the certificate authority
+// of the service, and makes the relative URLs use the https scheme.
+func HTTPClientTLS(cfg *tls.Config) HTTPClientOption {
+	return func(o *httpClientOptions) {
+		o.tlsConfig = cfg
+	}
+}
+
+// HTTPClientRetries sets the number of retries of a request failing to connect, e.g. while the service finishes
+// its warm-up, and the initial backoff between them, doubled after each retry up to 2s.
+// The default is 5 retries, with an initial backoff of 100ms. Zero retries disable them.
+func HTTPClientRetries(retries int, backoff time.Duration) HTTPClientOption {
+	return func(o *httpClientOptions) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// HTTPClientTimeout sets the timeout of the requests, including their retries. There is no timeout by default.
+func HTTPClientTimeout(timeout time.Duration) HTTPClientOption {
+	return func(o *httpClientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// httpClientProvider is implemented by the containers returning the HTTP clients of their ports, see HTTPClient.
+type httpClientProvider interface {
+	HTTPClient(port nat.Port, opts ...HTTPClientOption) *http.Client
+}
+
+// HTTPClient returns an HTTP client for the given port of the container, resolving the relative URLs
+// of the requests against its host and mapped port, and retrying the requests failing to connect.
+// It works with any implementation of Container, using its host and mapped port.
+func HTTPClient(ctr Container, port nat.Port, opts ...HTTPClientOption) *http.Client {
+	if p, ok := asContainer[httpClientProvider](ctr); ok {
+		return p.HTTPClient(port, opts...)
+	}
+
+	return newContainerHTTPClient(ctr, port, opts...)
+}
+
+// HTTPClient returns an HTTP client for the given port of the container, resolving the relative URLs of the requests,
+// e.g. /health, against the host and the mapped port of the container, and retrying the requests failing to connect,
+// with a backoff, while the service finishes its warm-up.
+func (c *DockerContainer) HTTPClient(port nat.Port, opts ...HTTPClientOption) *http.Client {
+	return newContainerHTTPClient(c, port, opts...)
+}
+
+// HTTPClient returns an HTTP client for the given port of the container, as with the Docker provider.
+func (c *NerdctlContainer) HTTPClient(port nat.Port, opts ...HTTPClientOption) *http.Client {
+	return newContainerHTTPClient(c, port, opts...)
+}
+
+// newContainerHTTPClient returns the HTTP client of the port of the container.
+func newContainerHTTPClient(ctr Container, port nat.Port, opts ...HTTPClientOption) *http.Client {
+	o := httpClientOptions{
+		retries: defaultHTTPClientRetries,
+		backoff: defaultHTTPClientBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if o.tlsConfig != nil {
+		base.TLSClientConfig = o.tlsConfig
+	}
+
+	return &http.Client{
+		Transport: &containerTransport{
+			ctr:     ctr,
+			port:    port,
+			base:    base,
+			options: o,
+		},
+		Timeout: o.timeout,
+	}
+}
+
+// containerTransport is the transport of the HTTP client of a container, resolving the relative URLs
+// and retrying the requests failing to connect.
+type containerTransport struct {
+	ctr     Container
+	port    nat.Port
+	base    http.RoundTripper
+	options httpClientOptions
+}
+
+// RoundTrip resolves the URL of the request, if it's relative, then sends it, retrying it if it failed to connect.
+func (t *containerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == "" {
+		resolved, err := t.resolve(req)
+		if err != nil {
+			return nil, err
+		}
+		req = resolved
+	}
+
+	backoff := t.options.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil || attempt >= t.options.retries || !retryableHTTPError(err) {
+			return resp, err
+		}
+
+		// the body was consumed by the failed attempt
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, err
+			}
+
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, errors.Join(err, bodyErr)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, errors.Join(err, req.Context().Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, defaultHTTPClientMaxBackoff)
+	}
+}
+
+// resolve returns a copy of the request, whose relative URL is resolved against the host and the mapped port of the container.
+func (t *containerTransport) resolve(req *http.Request) (*http.Request, error) {
+	ctx := req.Context()
+
+	host, err := t.ctr.Host(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("host: %w", err)
+	}
+
+	mappedPort, err := t.ctr.MappedPort(ctx, t.port)
+	if err != nil {
+		return nil, fmt.Errorf("mapped port %s: %w", t.port, err)
+	}
+
+	resolved := req.Clone(ctx)
+	resolved.URL.Scheme = "http"
+	if t.options.tlsConfig != nil {
+		resolved.URL.Scheme = "https"
+	}
+	resolved.URL.Host = net.JoinHostPort(host, mappedPort.Port())
+	resolved.Host = ""
+
+	return resolved, nil
+}
+
+// retryableHTTPError returns true if the request failed to connect to the service, which may still be starting:
+// the connection is refused, or it's closed by the proxy of the mapped port, as the service doesn't listen yet.
+func retryableHTTPError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
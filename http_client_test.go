@@ -0,0 +1,136 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHTTPContainer is a container whose port is mapped to the given address.
+type fakeHTTPContainer struct {
+	Container
+	host string
+	port string
+}
+
+func (c fakeHTTPContainer) Host(context.Context) (string, error) {
+	return c.host, nil
+}
+
+func (c fakeHTTPContainer) MappedPort(context.Context, nat.Port) (nat.Port, error) {
+	return nat.NewPort("tcp", c.port)
+}
+
+func newFakeHTTPContainer(t *testing.T, addr string) fakeHTTPContainer {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	return fakeHTTPContainer{host: host, port: port}
+}
+
+func TestContainerHTTPClient_resolvesRelativeURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	client := newContainerHTTPClient(newFakeHTTPContainer(t, srv.Listener.Addr().String()), "80/tcp")
+
+	resp, err := client.Get("/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "/health", string(body))
+}
+
+func TestContainerHTTPClient_retriesConnectionRefused(t *testing.T) {
+	// reserve a port, then start listening on it after the first attempts failed
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	var requests atomic.Int32
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		})}
+		t.Cleanup(func() { _ = srv.Close() })
+		_ = srv.Serve(l)
+	}()
+
+	client := newContainerHTTPClient(newFakeHTTPContainer(t, addr), "80/tcp",
+		HTTPClientRetries(10, 50*time.Millisecond), HTTPClientTimeout(10*time.Second))
+
+	resp, err := client.Post("/jobs", "text/plain", strings.NewReader("job"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestContainerHTTPClient_noRetries(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	client := newContainerHTTPClient(newFakeHTTPContainer(t, addr), "80/tcp", HTTPClientRetries(0, 0))
+
+	_, err = client.Get("/health")
+	require.ErrorIs(t, err, syscall.ECONNREFUSED)
+}
+
+func TestRetryableHTTPError(t *testing.T) {
+	require.True(t, retryableHTTPError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}))
+	require.True(t, retryableHTTPError(io.EOF))
+	require.False(t, retryableHTTPError(context.DeadlineExceeded))
+	require.False(t, retryableHTTPError(errors.Join(io.EOF, context.Canceled)))
+	require.False(t, retryableHTTPError(errors.New("tls: bad certificate")))
+}
+
+func TestDockerContainer_HTTPClient(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// httpClient {
+	client := HTTPClient(ctr, "80/tcp", HTTPClientTimeout(30*time.Second))
+
+	resp, err := client.Get("/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	// }
+}
@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// builtImagesReapers are the Docker hosts whose reaper has a connection registering the built images
+// of the session, which is kept open until the end of the test process.
+var builtImagesReapers sync.Map
+
+// registerBuiltImage registers the images built and not kept in the session with the reaper, so they are
+// reaped with the session even if no container is created from them, e.g. when BuildImage is called directly.
+func (p *DockerProvider) registerBuiltImage(ctx context.Context) error {
+	if !p.reaperEnabled(ctx) {
+		return nil
+	}
+
+	if _, loaded := builtImagesReapers.LoadOrStore(p.host, true); loaded {
+		return nil
+	}
+
+	r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
+	if err == nil {
+		// the connection is not terminated, so the images are reaped at the end of the session
+		_, err = r.Connect()
+	}
+	if err != nil {
+		builtImagesReapers.Delete(p.host)
+		return fmt.Errorf("register built image: %w", err)
+	}
+
+	return nil
+}
+
+// PruneBuiltImages removes the images built from a Dockerfile by any test session, including the kept ones,
+// which were created more than olderThan ago, returning the IDs of the removed images. The images in use
+// by a container are skipped. Use it to clean up the images accumulated on CI machines, e.g. in TestMain.
+func PruneBuiltImages(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new docker client: %w", err)
+	}
+	defer cli.Close()
+
+	images, err := cli.ImageList(ctx, image.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", core.LabelImageBuilt)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+
+	threshold := time.Now().Add(-olderThan)
+
+	var removed []string
+	var errs []error
+	for _, img := range images {
+		if !time.Unix(img.Created, 0).Before(threshold) {
+			continue
+		}
+
+		if _, err := cli.ImageRemove(ctx, img.ID, image.RemoveOptions{Force: true, PruneChildren: true}); err != nil {
+			if errdefs.IsConflict(err) || errdefs.IsNotFound(err) {
+				// in use by a container, or already removed with a parent image
+				continue
+			}
+
+			errs = append(errs, fmt.Errorf("remove image %s: %w", img.ID, err))
+			continue
+		}
+
+		removed = append(removed, img.ID)
+	}
+
+	return removed, errors.Join(errs...)
+}
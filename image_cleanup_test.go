@@ -0,0 +1,46 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestPruneBuiltImages(t *testing.T) {
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	cli := provider.Client()
+
+	ctx := context.Background()
+
+	tag, err := provider.BuildImage(ctx, &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "echo.Dockerfile",
+			KeepImage:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, tag)
+	require.NoError(t, err)
+	require.Equal(t, core.SessionID(), inspect.Config.Labels[core.LabelImageBuilt])
+	require.NotContains(t, inspect.Config.Labels, core.LabelSessionID)
+
+	removed, err := PruneBuiltImages(ctx, time.Hour)
+	require.NoError(t, err)
+	require.NotContains(t, removed, inspect.ID)
+
+	removed, err = PruneBuiltImages(ctx, 0)
+	require.NoError(t, err)
+	require.Contains(t, removed, inspect.ID)
+
+	_, _, err = cli.ImageInspectWithRaw(ctx, tag)
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// WithInitContainer runs the given containers to completion, one after the other, before the container is created,
+// e.g. to load a schema, to generate certificates or to download data, without adding these steps to its image.
+// The init containers are attached to the networks of the container, and mount its named volumes, unless they
+// define their own networks or mount the same targets. The creation of the container fails if an init container
+// exits with a non-zero code, the error containing its logs. The init containers are removed once they exit.
+func WithInitContainer(reqs ...ContainerRequest) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		for _, initReq := range reqs {
+			if initReq.Image == "" && initReq.FromDockerfile.Context == "" && initReq.FromDockerfile.ContextArchive == nil {
+				return errors.New("init container without image")
+			}
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			Name:        "init containers",
+			ErrorPolicy: HookErrorPolicyFailFast,
+			PreCreates: []ContainerRequestHook{
+				func(ctx context.Context, mainReq ContainerRequest) error {
+					for i, initReq := range reqs {
+						if err := runUntilExit(ctx, GenericContainerRequest{ContainerRequest: initContainerRequest(mainReq, initReq)}); err != nil {
+							return fmt.Errorf("init container %d: %w", i, err)
+						}
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// initContainerRequest returns the request of the init container, sharing the networks and the named volumes
+// of the main container, and waiting for the init container to exit.
+func initContainerRequest(mainReq ContainerRequest, initReq ContainerRequest) ContainerRequest {
+	if len(initReq.Networks) == 0 {
+		initReq.Networks = slices.Clone(mainReq.Networks)
+	}
+
+	initReq.Mounts = slices.Clone(initReq.Mounts)
+	for _, m := range mainReq.Mounts {
+		if m.Source.Type() != MountTypeVolume {
+			continue
+		}
+
+		if slices.ContainsFunc(initReq.Mounts, func(initMount ContainerMount) bool {
+			return initMount.Target == m.Target
+		}) {
+			continue
+		}
+
+		initReq.Mounts = append(initReq.Mounts, m)
+	}
+
+	if initReq.WaitingFor == nil {
+		initReq.WaitingFor = wait.ForExit()
+	}
+
+	return initReq
+}
+
+// runUntilExit runs the short-lived container, e.g. an init container, until it exits,
+// returning an error with its output if it exits with a non-zero code. The container is removed once it exits.
+func runUntilExit(ctx context.Context, req GenericContainerRequest) error {
+	res, err := RunJob(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if res.ExitCode == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("exit code %d: %s", res.ExitCode, strings.TrimSpace(res.Stderr+res.Stdout))
+}
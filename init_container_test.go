@@ -0,0 +1,121 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWithInitContainer(t *testing.T) {
+	t.Run("no-image", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		err := WithInitContainer(ContainerRequest{Cmd: []string{"true"}})(&req)
+		require.Error(t, err)
+		require.Empty(t, req.LifecycleHooks)
+	})
+
+	t.Run("hook", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.NoError(t, WithInitContainer(ContainerRequest{Image: "alpine"})(&req))
+		require.Len(t, req.LifecycleHooks, 1)
+		require.Equal(t, HookErrorPolicyFailFast, req.LifecycleHooks[0].ErrorPolicy)
+		require.Len(t, req.LifecycleHooks[0].PreCreates, 1)
+	})
+}
+
+func TestInitContainerRequest(t *testing.T) {
+	mainReq := ContainerRequest{
+		Image:    "postgres:16-alpine",
+		Networks: []string{"backend"},
+		Mounts: ContainerMounts{
+			VolumeMount("data", "/data"),
+			VolumeMount("certs", "/certs"),
+			{Source: GenericTmpfsMountSource{}, Target: "/tmp"},
+		},
+	}
+
+	t.Run("shared", func(t *testing.T) {
+		initReq := initContainerRequest(mainReq, ContainerRequest{
+			Image:  "alpine",
+			Mounts: ContainerMounts{VolumeMount("other-certs", "/certs")},
+		})
+
+		require.Equal(t, []string{"backend"}, initReq.Networks)
+		require.Equal(t, ContainerMounts{
+			VolumeMount("other-certs", "/certs"),
+			VolumeMount("data", "/data"),
+		}, initReq.Mounts)
+		require.IsType(t, &wait.ExitStrategy{}, initReq.WaitingFor)
+	})
+
+	t.Run("own", func(t *testing.T) {
+		strategy := wait.ForLog("done")
+
+		initReq := initContainerRequest(mainReq, ContainerRequest{
+			Image:      "alpine",
+			Networks:   []string{"frontend"},
+			WaitingFor: strategy,
+		})
+
+		require.Equal(t, []string{"frontend"}, initReq.Networks)
+		require.Same(t, strategy, initReq.WaitingFor)
+	})
+}
+
+func TestWithInitContainer_run(t *testing.T) {
+	ctx := context.Background()
+
+	// withInitContainer {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:  "alpine",
+			Cmd:    []string{"cat", "/data/ready"},
+			Mounts: ContainerMounts{VolumeMount("init-container-data", "/data")},
+		},
+		Started: true,
+	}
+
+	err := WithInitContainer(ContainerRequest{
+		// the init container mounts the named volumes of the container
+		Image: "alpine",
+		Cmd:   []string{"sh", "-c", "echo ready > /data/ready"},
+	})(&req)
+	// }
+	require.NoError(t, err)
+
+	ctr, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	require.NoError(t, wait.ForExit().WaitUntilReady(ctx, ctr))
+
+	r, err := ctr.Logs(ctx)
+	require.NoError(t, err)
+	defer r.Close()
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "ready\n", string(logs))
+
+	t.Run("failure", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "alpine"},
+			Started:          true,
+		}
+
+		require.NoError(t, WithInitContainer(ContainerRequest{
+			Image: "alpine",
+			Cmd:   []string{"sh", "-c", "echo no data; exit 3"},
+		})(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorContains(t, err, "init container 0: exit code 3: no data")
+	})
+}
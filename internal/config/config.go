@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -81,10 +82,130 @@ type Config struct {
 	// Environment variable: TESTCONTAINERS_RYUK_VERBOSE
 	RyukVerbose bool `properties:"ryuk.verbose,default=false"`
 
+	// RyukAudit is a flag to log the resources pending reaping by the Garbage Collector when the last
+	// connection of the test process to it is closed, and the label filter they match.
+	//
+	// Environment variable: TESTCONTAINERS_RYUK_AUDIT
+	RyukAudit bool `properties:"ryuk.audit,default=false"`
+
+	// RyukDryRun is a flag to make the Garbage Collector remove nothing, only logging the resources
+	// it would remove, as with RyukAudit.
+	//
+	// Environment variable: TESTCONTAINERS_RYUK_DRY_RUN
+	RyukDryRun bool `properties:"ryuk.dry.run,default=false"`
+
+	// HostOverride is the host used to reach the mapped ports of the containers,
+	// overriding the one inferred from the Docker host.
+	//
+	// Environment variable: TESTCONTAINERS_HOST_OVERRIDE
+	HostOverride string `properties:"host.override,default="`
+
+	// HostInternalOverride is the address the containers use to reach the host, for the ports exposed
+	// with the HostAccessPorts field of the container request. When set, the host.testcontainers.internal
+	// hostname is resolved to this address in the containers, instead of to the SSHD container forwarding
+	// the host ports. Use it when the host is directly reachable from the containers, e.g. over a VPN.
+	//
+	// Environment variable: TESTCONTAINERS_HOST_INTERNAL_OVERRIDE
+	HostInternalOverride string `properties:"host.internal.override,default="`
+
 	// TestcontainersHost is the address of the Testcontainers host.
 	//
 	// Environment variable: TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE
 	TestcontainersHost string `properties:"tc.host,default="`
+
+	// DockerHostCandidates is the list of Docker socket paths to probe, in order, when the Docker host
+	// is not found using any other strategy. It overrides the well-known socket locations of the
+	// Docker Desktop alternatives (Colima, Rancher Desktop, Lima and Podman machine).
+	// Values are separated by semicolons.
+	//
+	// Environment variable: TESTCONTAINERS_DOCKER_HOST_CANDIDATES
+	DockerHostCandidates []string `properties:"docker.host.candidates,default="`
+
+	// HostMountPaths is the list of mappings from the paths in the test process to the paths on the Docker host,
+	// in the <local path>=<host path> format, applied to the sources of the bind mounts of the containers.
+	// They are needed when the tests run in a container using the Docker socket of the host (Docker-outside-of-Docker),
+	// as the bind mounts are resolved by the Docker daemon on the host. Values are separated by semicolons.
+	//
+	// Environment variable: TESTCONTAINERS_HOST_MOUNT_PATHS
+	HostMountPaths []string `properties:"host.mount.paths,default="`
+
+	// SSHTunnel is a flag to enable or disable the automatic SSH tunnels for the mapped ports of the containers,
+	// when the Docker host is a remote daemon accessed over SSH (ssh://). When enabled, the mapped ports
+	// are forwarded to a random port on the local host, so the containers are reachable on localhost.
+	//
+	// Environment variable: TESTCONTAINERS_DOCKER_SSH_TUNNEL
+	SSHTunnel bool `properties:"docker.ssh.tunnel,default=false"`
+
+	// SessionMaxContainers is the maximum number of containers that can be running at the same time
+	// in the test session. Zero means no limit.
+	//
+	// Environment variable: TESTCONTAINERS_SESSION_MAX_CONTAINERS
+	SessionMaxContainers int `properties:"session.max.containers,default=0"`
+
+	// SessionMaxMemory is the maximum amount of memory, in bytes, that the containers running at the same time
+	// in the test session can be limited to, computed as the sum of their memory limits. Zero means no limit.
+	//
+	// Environment variable: TESTCONTAINERS_SESSION_MAX_MEMORY
+	SessionMaxMemory int64 `properties:"session.max.memory,default=0"`
+
+	// SessionBudgetFailFast is a flag to fail the creation of a container as soon as it exceeds the resource budget
+	// of the test session. By default, the creation waits until there are enough resources, or the context is done.
+	//
+	// Environment variable: TESTCONTAINERS_SESSION_BUDGET_FAIL_FAST
+	SessionBudgetFailFast bool `properties:"session.budget.fail.fast,default=false"`
+
+	// ContainerDefaultMemory is the memory limit, in bytes, applied to the containers not defining one. Zero means no limit.
+	//
+	// Environment variable: TESTCONTAINERS_CONTAINER_DEFAULT_MEMORY
+	ContainerDefaultMemory int64 `properties:"container.default.memory,default=0"`
+
+	// ContainerDefaultCPUs is the number of CPUs applied to the containers not defining a CPU limit,
+	// with the same semantics as the --cpus flag of docker run. Zero means no limit.
+	//
+	// Environment variable: TESTCONTAINERS_CONTAINER_DEFAULT_CPUS
+	ContainerDefaultCPUs float64 `properties:"container.default.cpus,default=0"`
+
+	// MicroVMRuntime is the containerd runtime of the microVM provider, which runs the containers as microVMs.
+	// Empty means aws.firecracker, the runtime of firecracker-containerd.
+	//
+	// Environment variable: TESTCONTAINERS_MICROVM_RUNTIME
+	MicroVMRuntime string `properties:"microvm.runtime,default="`
+
+	// MicroVMSnapshotter is the containerd snapshotter of the microVM provider, which must provide
+	// block devices to the microVMs. Empty means devmapper.
+	//
+	// Environment variable: TESTCONTAINERS_MICROVM_SNAPSHOTTER
+	MicroVMSnapshotter string `properties:"microvm.snapshotter,default="`
+
+	// PullTimeout is the maximum time to pull an image, including the retries, so an unresponsive registry
+	// fails the creation of the container instead of stalling the tests. Zero means no timeout.
+	//
+	// Environment variable: TESTCONTAINERS_PULL_TIMEOUT
+	PullTimeout time.Duration `properties:"pull.timeout,default=0"`
+
+	// StartTimeout is the maximum time to start a container, including the lifecycle hooks and the wait strategy.
+	// Zero means no timeout, other than the ones of the wait strategies.
+	//
+	// Environment variable: TESTCONTAINERS_START_TIMEOUT
+	StartTimeout time.Duration `properties:"start.timeout,default=0"`
+
+	// StopTimeout is the maximum time to stop or terminate a container, including the lifecycle hooks.
+	// Zero means no timeout.
+	//
+	// Environment variable: TESTCONTAINERS_STOP_TIMEOUT
+	StopTimeout time.Duration `properties:"stop.timeout,default=0"`
+
+	// PullConcurrency is the maximum number of images pulled at the same time by the test process,
+	// so large parallel suites don't exceed the rate limits of the registries. Zero means no limit.
+	//
+	// Environment variable: TESTCONTAINERS_PULL_CONCURRENCY
+	PullConcurrency int `properties:"pull.concurrency,default=0"`
+
+	// HubMirror is the registry mirroring Docker Hub, e.g. mirror.gcr.io, the Docker Hub images are pulled from
+	// when the rate limit of Docker Hub is exceeded. Unlike HubImageNamePrefix, it's only used as a fallback.
+	//
+	// Environment variable: TESTCONTAINERS_HUB_MIRROR
+	HubMirror string `properties:"hub.mirror,default="`
 }
 
 // }
@@ -99,6 +220,16 @@ func Read() Config {
 	return tcConfig
 }
 
+// Set overrides the singleton instance of the Config struct, so Read returns the passed configuration,
+// ignoring the properties file and the environment variables.
+// This function is not thread-safe: call it before any configuration is read, e.g. in an init function or TestMain.
+func Set(cfg Config) {
+	tcConfigOnce = new(sync.Once)
+	tcConfigOnce.Do(func() {
+		tcConfig = cfg
+	})
+}
+
 // Reset resets the singleton instance of the Config struct,
 // allowing to read the configuration again.
 // Handy for testing, so do not use it in production code
@@ -131,6 +262,16 @@ func read() Config {
 			config.RyukVerbose = ryukVerboseEnv == "true"
 		}
 
+		ryukAuditEnv := os.Getenv("TESTCONTAINERS_RYUK_AUDIT")
+		if parseBool(ryukAuditEnv) {
+			config.RyukAudit = ryukAuditEnv == "true"
+		}
+
+		ryukDryRunEnv := os.Getenv("TESTCONTAINERS_RYUK_DRY_RUN")
+		if parseBool(ryukDryRunEnv) {
+			config.RyukDryRun = ryukDryRunEnv == "true"
+		}
+
 		ryukReconnectionTimeoutEnv := os.Getenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT")
 		if timeout, err := time.ParseDuration(ryukReconnectionTimeoutEnv); err == nil {
 			config.RyukReconnectionTimeout = timeout
@@ -141,6 +282,84 @@ func read() Config {
 			config.RyukConnectionTimeout = timeout
 		}
 
+		sshTunnelEnv := os.Getenv("TESTCONTAINERS_DOCKER_SSH_TUNNEL")
+		if parseBool(sshTunnelEnv) {
+			config.SSHTunnel = sshTunnelEnv == "true"
+		}
+
+		if hostOverride := os.Getenv("TESTCONTAINERS_HOST_OVERRIDE"); hostOverride != "" {
+			config.HostOverride = hostOverride
+		}
+
+		if hostInternalOverride := os.Getenv("TESTCONTAINERS_HOST_INTERNAL_OVERRIDE"); hostInternalOverride != "" {
+			config.HostInternalOverride = hostInternalOverride
+		}
+
+		if maxContainers, err := strconv.Atoi(os.Getenv("TESTCONTAINERS_SESSION_MAX_CONTAINERS")); err == nil {
+			config.SessionMaxContainers = maxContainers
+		}
+
+		if maxMemory, err := strconv.ParseInt(os.Getenv("TESTCONTAINERS_SESSION_MAX_MEMORY"), 10, 64); err == nil {
+			config.SessionMaxMemory = maxMemory
+		}
+
+		budgetFailFastEnv := os.Getenv("TESTCONTAINERS_SESSION_BUDGET_FAIL_FAST")
+		if parseBool(budgetFailFastEnv) {
+			config.SessionBudgetFailFast = budgetFailFastEnv == "true"
+		}
+
+		if defaultMemory, err := strconv.ParseInt(os.Getenv("TESTCONTAINERS_CONTAINER_DEFAULT_MEMORY"), 10, 64); err == nil {
+			config.ContainerDefaultMemory = defaultMemory
+		}
+
+		if defaultCPUs, err := strconv.ParseFloat(os.Getenv("TESTCONTAINERS_CONTAINER_DEFAULT_CPUS"), 64); err == nil {
+			config.ContainerDefaultCPUs = defaultCPUs
+		}
+
+		if dockerHostCandidatesEnv := os.Getenv("TESTCONTAINERS_DOCKER_HOST_CANDIDATES"); dockerHostCandidatesEnv != "" {
+			config.DockerHostCandidates = splitList(dockerHostCandidatesEnv)
+		}
+
+		if hostMountPathsEnv := os.Getenv("TESTCONTAINERS_HOST_MOUNT_PATHS"); hostMountPathsEnv != "" {
+			config.HostMountPaths = splitList(hostMountPathsEnv)
+		}
+
+		if microVMRuntime := os.Getenv("TESTCONTAINERS_MICROVM_RUNTIME"); microVMRuntime != "" {
+			config.MicroVMRuntime = microVMRuntime
+		}
+
+		if microVMSnapshotter := os.Getenv("TESTCONTAINERS_MICROVM_SNAPSHOTTER"); microVMSnapshotter != "" {
+			config.MicroVMSnapshotter = microVMSnapshotter
+		}
+
+		if timeout, err := time.ParseDuration(os.Getenv("TESTCONTAINERS_PULL_TIMEOUT")); err == nil {
+			config.PullTimeout = timeout
+		}
+
+		if timeout, err := time.ParseDuration(os.Getenv("TESTCONTAINERS_START_TIMEOUT")); err == nil {
+			config.StartTimeout = timeout
+		}
+
+		if timeout, err := time.ParseDuration(os.Getenv("TESTCONTAINERS_STOP_TIMEOUT")); err == nil {
+			config.StopTimeout = timeout
+		}
+
+		if pullConcurrency, err := strconv.Atoi(os.Getenv("TESTCONTAINERS_PULL_CONCURRENCY")); err == nil {
+			config.PullConcurrency = pullConcurrency
+		}
+
+		if hubMirror := os.Getenv("TESTCONTAINERS_HUB_MIRROR"); hubMirror != "" {
+			config.HubMirror = hubMirror
+		}
+
+		if len(config.HostMountPaths) == 0 {
+			config.HostMountPaths = nil
+		}
+
+		if len(config.DockerHostCandidates) == 0 {
+			config.DockerHostCandidates = nil
+		}
+
 		return config
 	}
 
@@ -164,6 +383,17 @@ func read() Config {
 	return applyEnvironmentConfiguration(config)
 }
 
+// splitList splits a semicolon-separated list, trimming the spaces and omitting empty elements.
+func splitList(input string) []string {
+	var list []string
+	for _, v := range strings.Split(input, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func parseBool(input string) bool {
 	_, err := strconv.ParseBool(input)
 	return err == nil
@@ -23,8 +23,27 @@ func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "")
+	t.Setenv("TESTCONTAINERS_RYUK_AUDIT", "")
+	t.Setenv("TESTCONTAINERS_RYUK_DRY_RUN", "")
 	t.Setenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_HOST_CANDIDATES", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_SSH_TUNNEL", "")
+	t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "")
+	t.Setenv("TESTCONTAINERS_HOST_INTERNAL_OVERRIDE", "")
+	t.Setenv("TESTCONTAINERS_HOST_MOUNT_PATHS", "")
+	t.Setenv("TESTCONTAINERS_SESSION_MAX_CONTAINERS", "")
+	t.Setenv("TESTCONTAINERS_SESSION_MAX_MEMORY", "")
+	t.Setenv("TESTCONTAINERS_SESSION_BUDGET_FAIL_FAST", "")
+	t.Setenv("TESTCONTAINERS_CONTAINER_DEFAULT_MEMORY", "")
+	t.Setenv("TESTCONTAINERS_CONTAINER_DEFAULT_CPUS", "")
+	t.Setenv("TESTCONTAINERS_MICROVM_RUNTIME", "")
+	t.Setenv("TESTCONTAINERS_MICROVM_SNAPSHOTTER", "")
+	t.Setenv("TESTCONTAINERS_PULL_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_START_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_STOP_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_PULL_CONCURRENCY", "")
+	t.Setenv("TESTCONTAINERS_HUB_MIRROR", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -54,6 +73,27 @@ func TestReadConfig(t *testing.T) {
 	})
 }
 
+func TestSetConfig(t *testing.T) {
+	resetTestEnv(t)
+	t.Cleanup(Reset)
+
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	expected := Config{
+		RyukPrivileged: true,
+		HostOverride:   "example.com",
+	}
+
+	Set(expected)
+
+	// the environment is ignored
+	assert.Equal(t, expected, Read())
+
+	Reset()
+
+	assert.True(t, Read().RyukDisabled)
+}
+
 func TestReadTCConfig(t *testing.T) {
 	resetTestEnv(t)
 
@@ -515,6 +555,205 @@ func TestReadTCConfig(t *testing.T) {
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
 				},
 			},
+			{
+				"With Docker host candidates set as a property",
+				`docker.host.candidates=/path/to/colima.sock; unix:///path/to/lima.sock`,
+				map[string]string{},
+				Config{
+					DockerHostCandidates:    []string{"/path/to/colima.sock", "unix:///path/to/lima.sock"},
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With Docker host candidates set as a property and an env var",
+				`docker.host.candidates=/path/to/colima.sock`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_HOST_CANDIDATES": "/path/to/rd.sock;/path/to/podman.sock",
+				},
+				Config{
+					DockerHostCandidates:    []string{"/path/to/rd.sock", "/path/to/podman.sock"},
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With resource budget set as properties",
+				`session.max.containers=4
+				session.max.memory=4294967296
+				session.budget.fail.fast=true
+				container.default.memory=536870912
+				container.default.cpus=0.5`,
+				map[string]string{},
+				Config{
+					SessionMaxContainers:    4,
+					SessionMaxMemory:        4294967296,
+					SessionBudgetFailFast:   true,
+					ContainerDefaultMemory:  536870912,
+					ContainerDefaultCPUs:    0.5,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With resource budget set as properties and env vars: Env vars win",
+				`session.max.containers=4
+				session.max.memory=4294967296
+				container.default.memory=536870912
+				container.default.cpus=0.5`,
+				map[string]string{
+					"TESTCONTAINERS_SESSION_MAX_CONTAINERS":   "2",
+					"TESTCONTAINERS_SESSION_MAX_MEMORY":       "2147483648",
+					"TESTCONTAINERS_SESSION_BUDGET_FAIL_FAST": "true",
+					"TESTCONTAINERS_CONTAINER_DEFAULT_MEMORY": "268435456",
+					"TESTCONTAINERS_CONTAINER_DEFAULT_CPUS":   "1.5",
+				},
+				Config{
+					SessionMaxContainers:    2,
+					SessionMaxMemory:        2147483648,
+					SessionBudgetFailFast:   true,
+					ContainerDefaultMemory:  268435456,
+					ContainerDefaultCPUs:    1.5,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With host override set as a property and an env var: Env var wins",
+				`host.override=props.example.com`,
+				map[string]string{
+					"TESTCONTAINERS_HOST_OVERRIDE": "env.example.com",
+				},
+				Config{
+					HostOverride:            "env.example.com",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With host overrides set as properties",
+				`host.override=mapped.example.com
+	host.internal.override=10.0.0.1`,
+				map[string]string{},
+				Config{
+					HostOverride:            "mapped.example.com",
+					HostInternalOverride:    "10.0.0.1",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With host internal override set as a property and an env var: Env var wins",
+				`host.internal.override=10.0.0.1`,
+				map[string]string{
+					"TESTCONTAINERS_HOST_INTERNAL_OVERRIDE": "10.0.0.2",
+				},
+				Config{
+					HostInternalOverride:    "10.0.0.2",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With host mount paths set as a property",
+				`host.mount.paths=/builds=/srv/runner/builds;/tmp=/srv/runner/tmp`,
+				map[string]string{},
+				Config{
+					HostMountPaths:          []string{"/builds=/srv/runner/builds", "/tmp=/srv/runner/tmp"},
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With host mount paths set as a property and an env var: Env var wins",
+				`host.mount.paths=/builds=/srv/runner/builds`,
+				map[string]string{
+					"TESTCONTAINERS_HOST_MOUNT_PATHS": "/workspace=/home/runner/work",
+				},
+				Config{
+					HostMountPaths:          []string{"/workspace=/home/runner/work"},
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With microVM runtime and snapshotter set as properties and the runtime as an env var: Env var wins",
+				`microvm.runtime=io.containerd.kata.v2
+microvm.snapshotter=devmapper`,
+				map[string]string{
+					"TESTCONTAINERS_MICROVM_RUNTIME": "aws.firecracker",
+				},
+				Config{
+					MicroVMRuntime:          "aws.firecracker",
+					MicroVMSnapshotter:      "devmapper",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With operation timeouts set as properties and the pull timeout as an env var: Env var wins",
+				`pull.timeout=5m
+start.timeout=2m
+stop.timeout=30s`,
+				map[string]string{
+					"TESTCONTAINERS_PULL_TIMEOUT": "10m",
+				},
+				Config{
+					PullTimeout:             10 * time.Minute,
+					StartTimeout:            2 * time.Minute,
+					StopTimeout:             30 * time.Second,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With pull concurrency and hub mirror set as properties and the mirror as an env var: Env var wins",
+				`pull.concurrency=4
+hub.mirror=mirror.gcr.io`,
+				map[string]string{
+					"TESTCONTAINERS_HUB_MIRROR": "registry.example.com/dockerhub",
+				},
+				Config{
+					PullConcurrency:         4,
+					HubMirror:               "registry.example.com/dockerhub",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With Ryuk audit and dry-run set as properties and the dry-run disabled as an env var: Env var wins",
+				`ryuk.audit=true
+ryuk.dry.run=true`,
+				map[string]string{
+					"TESTCONTAINERS_RYUK_DRY_RUN": "false",
+				},
+				Config{
+					RyukAudit:               true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With SSH tunnel set as a property",
+				`docker.ssh.tunnel=true`,
+				map[string]string{},
+				Config{
+					SSHTunnel:               true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With SSH tunnel set as a property and disabled as an env var: Env var wins",
+				`docker.ssh.tunnel=true`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_SSH_TUNNEL": "false",
+				},
+				Config{
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(fmt.Sprintf(tt.name), func(t *testing.T) {
@@ -28,6 +28,11 @@ func NewClient(ctx context.Context, ops ...client.Opt) (*client.Client, error) {
 
 			opts = append(opts, client.WithTLSClientConfig(cacertPath, certPath, keyPath))
 		}
+
+		// remote daemons accessed over SSH are reached through the remote Docker socket
+		if IsSSHDockerHost(dockerHost) {
+			opts = append(opts, client.WithDialContext(SSHDialContext(dockerHost)))
+		}
 	}
 
 	opts = append(opts, client.WithHTTPHeaders(
@@ -31,10 +31,28 @@ var (
 )
 
 var (
-	dockerHostCache string
-	dockerHostOnce  sync.Once
+	dockerHostCache       string
+	dockerHostSourceCache string
+	dockerHostOnce        sync.Once
 )
 
+// The sources of the Docker host, identifying the strategy that discovered it.
+const (
+	DockerHostSourceTestcontainersHost = "tc.host property"
+	DockerHostSourceEnv                = "DOCKER_HOST environment variable"
+	DockerHostSourceContext            = "context"
+	DockerHostSourceDefaultSocket      = "default Docker socket"
+	DockerHostSourceProperties         = "docker.host property"
+	DockerHostSourceRootless           = "rootless Docker socket"
+	DockerHostSourceWellKnownSocket    = "well-known Docker socket"
+)
+
+// dockerHostStrategy represents a strategy to discover the Docker host.
+type dockerHostStrategy struct {
+	source string
+	fn     func(context.Context) (string, error)
+}
+
 var (
 	dockerSocketPathCache string
 	dockerSocketPathOnce  sync.Once
@@ -59,7 +77,12 @@ func DefaultGatewayIP() (string, error) {
 // dockerHostCheck Use a vanilla Docker client to check if the Docker host is reachable.
 // It will avoid recursive calls to this function.
 var dockerHostCheck = func(ctx context.Context, host string) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithHost(host), client.WithAPIVersionNegotiation())
+	opts := []client.Opt{client.FromEnv, client.WithHost(host), client.WithAPIVersionNegotiation()}
+	if IsSSHDockerHost(host) {
+		opts = append(opts, client.WithDialContext(SSHDialContext(host)))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return fmt.Errorf("new client: %w", err)
 	}
@@ -74,7 +97,7 @@ var dockerHostCheck = func(ctx context.Context, host string) error {
 }
 
 // MustExtractDockerHost Extracts the docker host from the different alternatives, caching the result to avoid unnecessary
-// calculations. Use this function to get the actual Docker host. This function does not consider Windows containers at the moment.
+// calculations. Use this function to get the actual Docker host, which could be a named pipe (npipe://) for Windows daemons.
 // The possible alternatives are:
 //
 //  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
@@ -83,30 +106,41 @@ var dockerHostCheck = func(ctx context.Context, host string) error {
 //  4. Docker host from the default docker socket path, without the unix schema.
 //  5. Docker host from the "docker.host" property in the ~/.testcontainers.properties file.
 //  6. Rootless docker socket path.
-//  7. Else, because the Docker host is not set, it panics.
+//  7. Well-known docker socket paths of Docker Desktop alternatives (Colima, Rancher Desktop, Lima and Podman machine).
+//  8. Else, because the Docker host is not set, it panics.
 func MustExtractDockerHost(ctx context.Context) string {
 	dockerHostOnce.Do(func() {
-		cache, err := extractDockerHost(ctx)
+		cache, source, err := extractDockerHostWithSource(ctx)
 		if err != nil {
 			panic(err)
 		}
 
 		dockerHostCache = cache
+		dockerHostSourceCache = source
 	})
 
 	return dockerHostCache
 }
 
+// MustExtractDockerHostSource returns the source of the Docker host returned by MustExtractDockerHost,
+// i.e. the name of the strategy that discovered it. It panics if the Docker host is not set.
+func MustExtractDockerHostSource(ctx context.Context) string {
+	MustExtractDockerHost(ctx)
+
+	return dockerHostSourceCache
+}
+
 // MustExtractDockerSocket Extracts the docker socket from the different alternatives, removing the socket schema and
 // caching the result to avoid unnecessary calculations. Use this function to get the docker socket path,
-// not the host (e.g. mounting the socket in a container). This function does not consider Windows containers at the moment.
+// not the host (e.g. mounting the socket in a container). For Windows daemons, the named pipe path is returned without the npipe schema.
 // The possible alternatives are:
 //
 //  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
 //  2. The TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE environment variable.
 //  3. Using a Docker client, check if the Info().OperativeSystem is "Docker Desktop" and return the default docker socket path for rootless docker.
 //  4. Else, Get the current Docker Host from the existing strategies: see MustExtractDockerHost.
-//  5. If the socket contains the unix schema, the schema is removed (e.g. unix:///var/run/docker.sock -> /var/run/docker.sock)
+//  5. If the socket contains the unix or npipe schema, the schema is removed (e.g. unix:///var/run/docker.sock -> /var/run/docker.sock)
+//     If the socket contains the ssh schema, the path of the Docker socket in the remote machine is used (defaults to /var/run/docker.sock)
 //  6. Else, the default location of the docker socket is used (/var/run/docker.sock)
 //
 // It panics if a Docker client cannot be created, or the Docker host cannot be discovered.
@@ -121,18 +155,26 @@ func MustExtractDockerSocket(ctx context.Context) string {
 // extractDockerHost Extracts the docker host from the different alternatives, without caching the result.
 // This internal method is handy for testing purposes.
 func extractDockerHost(ctx context.Context) (string, error) {
-	dockerHostFns := []func(context.Context) (string, error){
-		testcontainersHostFromProperties,
-		dockerHostFromEnv,
-		dockerHostFromContext,
-		dockerSocketPath,
-		dockerHostFromProperties,
-		rootlessDockerSocketPath,
+	dockerHost, _, err := extractDockerHostWithSource(ctx)
+	return dockerHost, err
+}
+
+// extractDockerHostWithSource Extracts the docker host from the different alternatives, without caching the result,
+// returning the source of the Docker host too.
+func extractDockerHostWithSource(ctx context.Context) (string, string, error) {
+	dockerHostStrategies := []dockerHostStrategy{
+		{source: DockerHostSourceTestcontainersHost, fn: testcontainersHostFromProperties},
+		{source: DockerHostSourceEnv, fn: dockerHostFromEnv},
+		{source: DockerHostSourceContext, fn: dockerHostFromContext},
+		{source: DockerHostSourceDefaultSocket, fn: dockerSocketPath},
+		{source: DockerHostSourceProperties, fn: dockerHostFromProperties},
+		{source: DockerHostSourceRootless, fn: rootlessDockerSocketPath},
+		{source: DockerHostSourceWellKnownSocket, fn: wellKnownDockerSocketPath},
 	}
 
 	var errs []error
-	for _, dockerHostFn := range dockerHostFns {
-		dockerHost, err := dockerHostFn(ctx)
+	for _, strategy := range dockerHostStrategies {
+		dockerHost, err := strategy.fn(ctx)
 		if err != nil {
 			if !isHostNotSet(err) {
 				errs = append(errs, err)
@@ -145,14 +187,14 @@ func extractDockerHost(ctx context.Context) (string, error) {
 			continue
 		}
 
-		return dockerHost, nil
+		return dockerHost, strategy.source, nil
 	}
 
 	if len(errs) > 0 {
-		return "", errors.Join(errs...)
+		return "", "", errors.Join(errs...)
 	}
 
-	return "", ErrSocketNotFound
+	return "", "", ErrSocketNotFound
 }
 
 // extractDockerSocket Extracts the docker socket from the different alternatives, without caching the result.
@@ -185,6 +227,16 @@ func extractDockerSocketFromClient(ctx context.Context, cli client.APIClient) st
 			return strings.Replace(socket, DockerSocketSchema, "", 1)
 		}
 
+		// remote daemons accessed over SSH use the Docker socket of the remote machine
+		if IsSSHDockerHost(socket) {
+			return SSHRemoteSocketPath(socket)
+		}
+
+		// named pipes are used by Windows daemons, e.g. npipe:////./pipe/docker_engine
+		if strings.HasPrefix(socket, NpipeSchema) {
+			return strings.Replace(socket, NpipeSchema, "", 1)
+		}
+
 		return socket
 	}
 
@@ -232,7 +284,8 @@ func isHostNotSet(err error) bool {
 		errors.Is(err, ErrXDGRuntimeDirNotSet),
 		errors.Is(err, ErrRootlessDockerNotFoundHomeRunDir),
 		errors.Is(err, ErrRootlessDockerNotFoundHomeDesktopDir),
-		errors.Is(err, ErrRootlessDockerNotFoundRunDir):
+		errors.Is(err, ErrRootlessDockerNotFoundRunDir),
+		errors.Is(err, ErrWellKnownDockerSocketNotFound):
 		return true
 	default:
 		return false
@@ -309,10 +362,26 @@ func testcontainersHostFromProperties(ctx context.Context) (string, error) {
 	return "", ErrTestcontainersHostNotSetInProperties
 }
 
-// InAContainer returns true if the code is running inside a container
+var (
+	inAContainerCache bool
+	inAContainerOnce  sync.Once
+)
+
+// containerCgroupMarkers are the fragments of the control groups of the processes running in a container,
+// for Docker, Kubernetes, containerd and Podman. The control groups of other tools, like LXC or the GitHub
+// Actions runners, are not markers, as they can be used for processes running on the host.
+var containerCgroupMarkers = []string{"/docker", "/kubepods", "/containerd", "/libpod"}
+
+// InAContainer returns true if the code is running inside a container, e.g. in a CI runner.
+// It checks the marker files created by Docker and Podman, and the control groups of the init process
+// for other runtimes, like containerd in Kubernetes. The result is cached.
 // See https://github.com/docker/docker/blob/a9fa38b1edf30b23cae3eade0be48b3d4b1de14b/daemon/initlayer/setup_unix.go#L25
 func InAContainer() bool {
-	return inAContainer("/.dockerenv")
+	inAContainerOnce.Do(func() {
+		inAContainerCache = inAContainer("/.dockerenv") || inAContainer("/run/.containerenv") || inAContainerCgroup("/proc/1/cgroup")
+	})
+
+	return inAContainerCache
 }
 
 func inAContainer(path string) bool {
@@ -322,3 +391,29 @@ func inAContainer(path string) bool {
 	}
 	return false
 }
+
+// inAContainerCgroup returns true if the control groups file, in the /proc/<pid>/cgroup format,
+// contains the control group of a container. Only cgroup v1 exposes the container control groups,
+// as cgroup v2 namespaces them, so the marker files must be checked too.
+func inAContainerCgroup(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		for _, marker := range containerCgroupMarkers {
+			if strings.Contains(parts[2], marker) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -113,6 +113,14 @@ func TestExtractDockerHost(t *testing.T) {
 		require.Equal(t, "/path/to/docker.sock", host)
 	})
 
+	t.Run("Docker Host source", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "/path/to/docker.sock")
+		host, source, err := extractDockerHostWithSource(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "/path/to/docker.sock", host)
+		require.Equal(t, DockerHostSourceEnv, source)
+	})
+
 	t.Run("Malformed Docker Host is passed in context", func(t *testing.T) {
 		setupDockerSocketNotFound(t)
 		setupRootlessNotFound(t)
@@ -366,6 +374,10 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", testRemoteHost)
 		host = extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
 		require.Equal(t, DockerSocketPath, host)
+
+		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", NpipeSchema+"//./pipe/docker_engine")
+		host = extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
+		require.Equal(t, "//./pipe/docker_engine", host)
 	})
 
 	t.Run("Unix Docker Socket is passed as DOCKER_HOST variable (Docker Desktop on non-Windows)", func(t *testing.T) {
@@ -486,6 +498,88 @@ func TestInAContainer(t *testing.T) {
 
 		assert.True(t, inAContainer(f))
 	})
+
+	for _, marker := range []string{".dockerenv", filepath.Join("run", ".containerenv")} {
+		t.Run(marker, func(t *testing.T) {
+			f := filepath.Join(t.TempDir(), marker)
+			require.NoError(t, os.MkdirAll(filepath.Dir(f), 0o755))
+			require.NoError(t, os.WriteFile(f, nil, 0o644))
+
+			assert.True(t, inAContainer(f))
+		})
+	}
+}
+
+func TestInAContainerCgroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name:    "docker",
+			content: "12:memory:/docker/3601745b3bd54d9780436faa5f0e4f72bb46231663bb99a6bb892764917832c2\n11:cpu:/docker/3601745b3bd5\n",
+			want:    true,
+		},
+		{
+			name:    "kubernetes",
+			content: "11:devices:/kubepods/besteffort/pod3d5b5c1b-b3b1-4b0e-9f0a-4b6f0e3f1a2b/1f2e3d\n",
+			want:    true,
+		},
+		{
+			name:    "docker/systemd-driver",
+			content: "12:memory:/system.slice/docker-3601745b3bd54d9780436faa5f0e4f72bb46231663bb99a6bb892764917832c2.scope\n",
+			want:    true,
+		},
+		{
+			name:    "containerd",
+			content: "11:devices:/containerd/default/1f2e3d4c5b6a\n",
+			want:    true,
+		},
+		{
+			name:    "podman",
+			content: "12:memory:/machine.slice/libpod-3601745b3bd54d9780436faa5f0e4f72bb46231663bb99a6bb892764917832c2.scope\n",
+			want:    true,
+		},
+		{
+			name:    "host/lxc",
+			content: "12:memory:/lxc/runner\n",
+			want:    false,
+		},
+		{
+			name:    "host/ecs",
+			content: "12:memory:/ecs/0f3b6d1e-7f4a-4d8e-9c2b-5a6e7d8f9a0b/agent\n",
+			want:    false,
+		},
+		{
+			name:    "host/actions-job",
+			content: "12:memory:/actions_job/3601745b3bd5\n",
+			want:    false,
+		},
+		{
+			name:    "host/cgroup-v1",
+			content: "12:memory:/user.slice\n11:cpu:/\n",
+			want:    false,
+		},
+		{
+			name:    "cgroup-v2",
+			content: "0::/\n",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := filepath.Join(t.TempDir(), "cgroup")
+			require.NoError(t, os.WriteFile(f, []byte(tt.content), 0o644))
+
+			assert.Equal(t, tt.want, inAContainerCgroup(f))
+		})
+	}
+
+	t.Run("file does not exist", func(t *testing.T) {
+		assert.False(t, inAContainerCgroup(filepath.Join(t.TempDir(), "cgroup")))
+	})
 }
 
 func createTmpDir(dir string) error {
@@ -19,6 +19,9 @@ var DockerSocketPathWithSchema = DockerSocketSchema + DockerSocketPath
 // TCPSchema is the tcp schema.
 var TCPSchema = "tcp://"
 
+// NpipeSchema is the named pipe schema, used by Docker daemons on Windows.
+var NpipeSchema = "npipe://"
+
 // WindowsDockerSocketPath is the path to the docker socket under windows systems.
 var WindowsDockerSocketPath = "//var/run/docker.sock"
 
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHSchema is the ssh schema, used to access remote Docker daemons over SSH.
+var SSHSchema = "ssh://"
+
+const defaultSSHPort = "22"
+
+var (
+	sshClients     = map[string]*ssh.Client{}
+	sshClientsLock sync.Mutex
+)
+
+// sshDockerHost represents the parts of an ssh:// Docker host.
+type sshDockerHost struct {
+	user       string
+	password   string
+	addr       string // host:port of the SSH server
+	socketPath string // path to the Docker socket in the remote machine
+}
+
+// IsSSHDockerHost returns true if the Docker host is a remote daemon accessed over SSH.
+func IsSSHDockerHost(host string) bool {
+	return strings.HasPrefix(host, SSHSchema)
+}
+
+// parseSSHDockerHost parses an ssh:// Docker host, e.g. ssh://user@remote:2222/run/user/1000/docker.sock.
+// The port defaults to 22, the user to the current user, and the path of the remote Docker socket
+// to the default Docker socket path.
+func parseSSHDockerHost(host string) (sshDockerHost, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return sshDockerHost{}, fmt.Errorf("parse ssh docker host: %w", err)
+	}
+
+	if u.Scheme != "ssh" {
+		return sshDockerHost{}, fmt.Errorf("unexpected schema in docker host: %s", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return sshDockerHost{}, errors.New("no host in ssh docker host")
+	}
+
+	h := sshDockerHost{
+		addr:       net.JoinHostPort(u.Hostname(), defaultSSHPort),
+		socketPath: DockerSocketPath,
+	}
+
+	if u.Port() != "" {
+		h.addr = net.JoinHostPort(u.Hostname(), u.Port())
+	}
+
+	if u.Path != "" && u.Path != "/" {
+		h.socketPath = u.Path
+	}
+
+	if u.User != nil {
+		h.user = u.User.Username()
+		h.password, _ = u.User.Password()
+	}
+
+	if h.user == "" {
+		current, err := user.Current()
+		if err != nil {
+			return sshDockerHost{}, fmt.Errorf("current user: %w", err)
+		}
+		h.user = current.Username
+	}
+
+	return h, nil
+}
+
+// SSHRemoteSocketPath returns the path to the Docker socket in the remote machine of an ssh:// Docker host.
+func SSHRemoteSocketPath(host string) string {
+	h, err := parseSSHDockerHost(host)
+	if err != nil {
+		return DockerSocketPath
+	}
+
+	return h.socketPath
+}
+
+// SSHClient returns the SSH client connected to the remote machine of an ssh:// Docker host.
+// Clients are cached by Docker host, so the same SSH connection is reused to talk to the
+// Docker daemon.
+//
+// The client authenticates using the password in the Docker host, if any, the SSH agent
+// listening on SSH_AUTH_SOCK, and the default private keys in ~/.ssh. The host key of the
+// remote machine is verified against ~/.ssh/known_hosts.
+func SSHClient(ctx context.Context, host string) (*ssh.Client, error) {
+	sshClientsLock.Lock()
+	defer sshClientsLock.Unlock()
+
+	if cli, ok := sshClients[host]; ok {
+		return cli, nil
+	}
+
+	cli, err := DialSSH(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	sshClients[host] = cli
+
+	go func() {
+		// forget the client when the connection is closed, so it's reconnected on next use
+		_ = cli.Wait()
+
+		sshClientsLock.Lock()
+		defer sshClientsLock.Unlock()
+		if sshClients[host] == cli {
+			delete(sshClients, host)
+		}
+	}()
+
+	return cli, nil
+}
+
+// DialSSH connects to the remote machine of an ssh:// Docker host, authenticating as SSHClient does.
+// The client is not cached, so it must be closed by the caller.
+func DialSSH(ctx context.Context, host string) (*ssh.Client, error) {
+	h, err := parseSSHDockerHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, closeAgent, err := sshClientConfig(h)
+	if err != nil {
+		return nil, err
+	}
+	// the SSH agent is only used to authenticate, during the handshake
+	defer closeAgent()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", h.addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial ssh %s: %w", h.addr, err)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, h.addr, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ssh handshake %s: %w", h.addr, err)
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// SSHDialContext returns a dial function connecting to the remote Docker socket of an ssh:// Docker host.
+// It's used as the dialer of the Docker client.
+func SSHDialContext(host string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		cli, err := SSHClient(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		return cli.Dial("unix", SSHRemoteSocketPath(host))
+	}
+}
+
+// sshClientConfig builds the SSH client configuration for the given ssh:// Docker host.
+// The returned function closes the connection to the SSH agent, if any, once the client is authenticated.
+func sshClientConfig(h sshDockerHost) (*ssh.ClientConfig, func(), error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil, fmt.Errorf("user home dir: %w", err)
+	}
+
+	hostKeyCallback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("known hosts: %w", err)
+	}
+
+	var auths []ssh.AuthMethod
+	if h.password != "" {
+		auths = append(auths, ssh.Password(h.password))
+	}
+
+	closeAgent := func() {}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			auths = append(auths, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			closeAgent = func() { _ = conn.Close() }
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		key, err := os.ReadFile(filepath.Join(home, ".ssh", name))
+		if err != nil {
+			continue
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			// passphrase protected keys are only supported through the SSH agent
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		auths = append(auths, ssh.PublicKeys(signers...))
+	}
+
+	if len(auths) == 0 {
+		return nil, nil, errors.New("no ssh authentication method available: set a password in the docker host, start an ssh agent or add a private key to ~/.ssh")
+	}
+
+	return &ssh.ClientConfig{
+		User:            h.user,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+	}, closeAgent, nil
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// ErrWellKnownDockerSocketNotFound is returned when the Docker socket is not found in any of the well-known locations.
+var ErrWellKnownDockerSocketNotFound = errors.New("docker socket not found in the well-known locations")
+
+// wellKnownDockerSocketPaths returns the well-known locations of the Docker sockets exposed by
+// the most common Docker Desktop alternatives, in the order they are probed:
+//
+//  1. Colima: ~/.colima/default/docker.sock and ~/.config/colima/default/docker.sock.
+//  2. Rancher Desktop: ~/.rd/docker.sock.
+//  3. Lima: ~/.lima/docker/sock/docker.sock and ~/.lima/default/sock/docker.sock.
+//  4. Podman machine: ~/.local/share/containers/podman/machine/podman.sock and
+//     ~/.local/share/containers/podman/machine/qemu/podman.sock.
+func wellKnownDockerSocketPaths() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		filepath.Join(home, ".colima", "default", "docker.sock"),
+		filepath.Join(home, ".config", "colima", "default", "docker.sock"),
+		filepath.Join(home, ".rd", "docker.sock"),
+		filepath.Join(home, ".lima", "docker", "sock", "docker.sock"),
+		filepath.Join(home, ".lima", "default", "sock", "docker.sock"),
+		filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock"),
+		filepath.Join(home, ".local", "share", "containers", "podman", "machine", "qemu", "podman.sock"),
+	}, nil
+}
+
+// wellKnownDockerSocketPath returns the first existing Docker socket from the well-known locations.
+// The list of locations can be overridden with the "docker.host.candidates" property in the
+// ~/.testcontainers.properties file, or the TESTCONTAINERS_DOCKER_HOST_CANDIDATES environment variable.
+// It should include the Docker socket schema (unix://) in the returned path.
+func wellKnownDockerSocketPath(_ context.Context) (string, error) {
+	if IsWindows() {
+		return "", ErrWellKnownDockerSocketNotFound
+	}
+
+	candidates := config.Read().DockerHostCandidates
+	if len(candidates) == 0 {
+		paths, err := wellKnownDockerSocketPaths()
+		if err != nil {
+			return "", err
+		}
+		candidates = paths
+	}
+
+	for _, candidate := range candidates {
+		socket := strings.TrimPrefix(candidate, DockerSocketSchema)
+		if fileExists(socket) {
+			return DockerSocketSchema + socket, nil
+		}
+	}
+
+	return "", ErrWellKnownDockerSocketNotFound
+}
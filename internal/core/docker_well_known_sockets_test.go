@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestWellKnownDockerSocketPath(t *testing.T) {
+	if IsWindows() {
+		t.Skip("Skip for Windows")
+	}
+
+	setupHome := func(t *testing.T) string {
+		t.Cleanup(config.Reset)
+		config.Reset()
+
+		t.Setenv("TESTCONTAINERS_DOCKER_HOST_CANDIDATES", "")
+
+		homeDir := filepath.Join(t.TempDir(), "home")
+		err := createTmpDir(homeDir)
+		require.NoError(t, err)
+		t.Setenv("HOME", homeDir)
+
+		return homeDir
+	}
+
+	t.Run("Not found", func(t *testing.T) {
+		setupHome(t)
+
+		socket, err := wellKnownDockerSocketPath(context.Background())
+		require.ErrorIs(t, err, ErrWellKnownDockerSocketNotFound)
+		require.Empty(t, socket)
+	})
+
+	t.Run("Colima", func(t *testing.T) {
+		homeDir := setupHome(t)
+
+		colimaDir := filepath.Join(homeDir, ".colima", "default")
+		err := createTmpDockerSocket(colimaDir)
+		require.NoError(t, err)
+
+		socket, err := wellKnownDockerSocketPath(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, DockerSocketSchema+filepath.Join(colimaDir, "docker.sock"), socket)
+	})
+
+	t.Run("Colima takes precedence over Rancher Desktop", func(t *testing.T) {
+		homeDir := setupHome(t)
+
+		colimaDir := filepath.Join(homeDir, ".colima", "default")
+		err := createTmpDockerSocket(colimaDir)
+		require.NoError(t, err)
+
+		err = createTmpDockerSocket(filepath.Join(homeDir, ".rd"))
+		require.NoError(t, err)
+
+		socket, err := wellKnownDockerSocketPath(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, DockerSocketSchema+filepath.Join(colimaDir, "docker.sock"), socket)
+	})
+
+	t.Run("Rancher Desktop", func(t *testing.T) {
+		homeDir := setupHome(t)
+
+		rdDir := filepath.Join(homeDir, ".rd")
+		err := createTmpDockerSocket(rdDir)
+		require.NoError(t, err)
+
+		socket, err := wellKnownDockerSocketPath(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, DockerSocketSchema+filepath.Join(rdDir, "docker.sock"), socket)
+	})
+
+	t.Run("Candidates override the well-known locations", func(t *testing.T) {
+		homeDir := setupHome(t)
+
+		err := createTmpDockerSocket(filepath.Join(homeDir, ".rd"))
+		require.NoError(t, err)
+
+		customDir := filepath.Join(homeDir, "custom")
+		err = createTmpDockerSocket(customDir)
+		require.NoError(t, err)
+
+		t.Setenv("TESTCONTAINERS_DOCKER_HOST_CANDIDATES", "/does/not/exist.sock;"+DockerSocketSchema+filepath.Join(customDir, "docker.sock"))
+
+		socket, err := wellKnownDockerSocketPath(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, DockerSocketSchema+filepath.Join(customDir, "docker.sock"), socket)
+	})
+}
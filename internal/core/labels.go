@@ -11,6 +11,14 @@ const (
 	LabelRyuk      = LabelBase + ".ryuk"
 	LabelSessionID = LabelBase + ".sessionId"
 	LabelVersion   = LabelBase + ".version"
+
+	// LabelImageBuilt is set on the images built from a Dockerfile, including the kept ones,
+	// with the ID of the session that built them.
+	LabelImageBuilt = LabelBase + ".image.built"
+
+	// LabelSessionScope is the prefix of the labels of the nested sessions, one per session
+	// the resource belongs to, including the parent sessions.
+	LabelSessionScope = LabelBase + ".session."
 )
 
 func DefaultLabels(sessionID string) map[string]string {
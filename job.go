@@ -0,0 +1,114 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// jobOutputLimit is the maximum size of the stdout and of the stderr of a job kept in its result.
+const jobOutputLimit = 1 << 20
+
+// JobResult is the result of a container run to completion by RunJob.
+type JobResult struct {
+	// ExitCode is the exit code of the container.
+	ExitCode int
+	// Stdout is the standard output of the container, up to its first MiB.
+	Stdout string
+	// Stderr is the standard error of the container, up to its first MiB.
+	Stderr string
+	// Truncated reports whether the standard output or the standard error was larger than a MiB.
+	Truncated bool
+}
+
+// RunJob runs the container of the request to completion, e.g. a one-shot CLI such as mc, aws or kubectl,
+// and returns its exit code and its output. The container is started, even if the request is not,
+// and RunJob waits for it to exit once the wait strategy of the request, if any, is satisfied.
+// The container is removed once it exits, or if the context is done first.
+// A non-zero exit code is not an error: it's returned in the result, with the output of the container.
+func RunJob(ctx context.Context, req GenericContainerRequest) (JobResult, error) {
+	req.Started = true
+
+	ctr, err := GenericContainer(ctx, req)
+	if ctr != nil {
+		defer func() {
+			_ = ctr.Terminate(context.Background())
+		}()
+	}
+	if err != nil {
+		return JobResult{}, fmt.Errorf("run %s: %w", req.Image, err)
+	}
+
+	if err := wait.ForExit().WaitUntilReady(ctx, ctr); err != nil {
+		return JobResult{}, fmt.Errorf("wait for exit of %s: %w", req.Image, err)
+	}
+
+	state, err := ctr.State(ctx)
+	if err != nil {
+		return JobResult{}, fmt.Errorf("state: %w", err)
+	}
+
+	stdout := &limitedBuffer{limit: jobOutputLimit}
+	stderr := &limitedBuffer{limit: jobOutputLimit}
+	if err := jobOutput(ctx, ctr, stdout, stderr); err != nil {
+		return JobResult{}, fmt.Errorf("output: %w", err)
+	}
+
+	return JobResult{
+		ExitCode:  state.ExitCode,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}, nil
+}
+
+// jobOutput writes the standard output and the standard error of the exited container to the writers.
+// The logs of the containers not run by Docker are not demultiplexed, so they are all written to stdout.
+func jobOutput(ctx context.Context, ctr Container, stdout io.Writer, stderr io.Writer) error {
+	dc, ok := ctr.(*DockerContainer)
+	if !ok {
+		r, err := ctr.Logs(ctx)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		_, err = io.Copy(stdout, r)
+		return err
+	}
+
+	rc, err := dc.provider.client.ContainerLogs(ctx, dc.ID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = stdcopy.StdCopy(stdout, stderr, rc)
+	return err
+}
+
+// limitedBuffer is a buffer keeping the first bytes written to it, up to its limit, and discarding the others.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write implements io.Writer, never failing so the writes go on once the limit is reached.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if left := b.limit - b.Len(); n > left {
+		p = p[:max(left, 0)]
+		b.truncated = true
+	}
+
+	b.Buffer.Write(p)
+
+	return n, nil
+}
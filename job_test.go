@@ -0,0 +1,90 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 8}
+
+	n, err := fmt.Fprint(b, "hello")
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.False(t, b.truncated)
+
+	n, err = fmt.Fprint(b, " world")
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+	require.True(t, b.truncated)
+
+	_, err = fmt.Fprint(b, "!")
+	require.NoError(t, err)
+	require.Equal(t, "hello wo", b.String())
+}
+
+func TestRunJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		// runJob {
+		res, err := RunJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"sh", "-c", "echo created; echo skipped >&2"},
+			},
+		})
+		require.NoError(t, err)
+		// }
+		require.Equal(t, JobResult{Stdout: "created\n", Stderr: "skipped\n"}, res)
+	})
+
+	t.Run("exit-code", func(t *testing.T) {
+		res, err := RunJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"sh", "-c", "echo no such bucket >&2; exit 3"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, res.ExitCode)
+		require.Equal(t, "no such bucket\n", res.Stderr)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		res, err := RunJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"sh", "-c", "head -c 2000000 /dev/zero | tr '\\0' a"},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, res.Truncated)
+		require.Equal(t, strings.Repeat("a", jobOutputLimit), res.Stdout)
+	})
+
+	t.Run("context-done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		_, err := RunJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"sleep", "60"},
+			},
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid-image", func(t *testing.T) {
+		_, err := RunJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "testcontainers/does-not-exist:latest"},
+		})
+		require.ErrorContains(t, err, "run testcontainers/does-not-exist:latest")
+	})
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // ContainerRequestHook is a hook that will be called before a container is created.
@@ -35,10 +38,31 @@ type ContainerRequestHook func(ctx context.Context, req ContainerRequest) error
 // For that, it will receive a Container, modify it and return an error if needed.
 type ContainerHook func(ctx context.Context, container Container) error
 
+// HookErrorPolicy defines how the errors of the hooks of a lifecycle hooks bundle are handled.
+type HookErrorPolicy int
+
+const (
+	// HookErrorPolicyCollect runs all the hooks of the lifecycle phase, even if a hook of the bundle fails,
+	// and returns their joined errors. It's the default policy.
+	HookErrorPolicyCollect HookErrorPolicy = iota
+	// HookErrorPolicyFailFast stops running the hooks of the lifecycle phase as soon as a hook of the bundle fails,
+	// skipping the remaining hooks of the bundle and of the bundles after it.
+	HookErrorPolicyFailFast
+)
+
 // ContainerLifecycleHooks is a struct that contains all the hooks that can be used
 // to modify the container lifecycle. All the container lifecycle hooks except the PreCreates hooks
-// will be passed to the container once it's created
+// will be passed to the container once it's created.
+// The hooks of a struct form a bundle, which can be named, and which defines the timeout of each of its hooks
+// and how their errors are handled. The bundles of a request run in the order they were defined.
 type ContainerLifecycleHooks struct {
+	// Name identifies the bundle in the errors returned by its hooks. It's optional.
+	Name string
+	// Timeout is the maximum duration of each hook of the bundle. Zero means no timeout.
+	Timeout time.Duration
+	// ErrorPolicy defines how the errors of the hooks of the bundle are handled. The default is HookErrorPolicyCollect.
+	ErrorPolicy HookErrorPolicy
+
 	PreCreates     []ContainerRequestHook
 	PostCreates    []ContainerHook
 	PreStarts      []ContainerHook
@@ -161,6 +185,24 @@ var defaultCopyFileToContainerHook = func(files []ContainerFile) ContainerLifecy
 	}
 }
 
+// defaultStdinHook is a hook that will attach the reader to the container's stdin right before
+// the container is started, so no input is lost
+var defaultStdinHook = func(stdin io.Reader) ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PreStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if stdin == nil {
+					return nil
+				}
+
+				dockerContainer := c.(*DockerContainer)
+
+				return dockerContainer.attachStdin(ctx, stdin)
+			},
+		},
+	}
+}
+
 // defaultLogConsumersHook is a hook that will start log consumers after the container is started
 var defaultLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
@@ -266,13 +308,29 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 			func(ctx context.Context, c Container) error {
 				dockerContainer := c.(*DockerContainer)
 
+				// without a Wait Strategy, wait for the readiness probes defined by the labels of the image, if any
+				if dockerContainer.WaitingFor == nil {
+					inspect, err := dockerContainer.Inspect(ctx)
+					if err != nil {
+						return fmt.Errorf("inspect: %w", err)
+					}
+					if wait.HasProbeLabels(inspect.Config.Labels) {
+						dockerContainer.WaitingFor = wait.ForImageProbes()
+					}
+				}
+
 				// if a Wait Strategy has been specified, wait before returning
 				if dockerContainer.WaitingFor != nil {
 					dockerContainer.logger.Printf(
 						"⏳ Waiting for container id %s image: %s. Waiting for: %+v",
 						dockerContainer.ID[:12], dockerContainer.Image, dockerContainer.WaitingFor,
 					)
-					if err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+					waitStart := time.Now()
+					err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c)
+					updateContainerMetrics(dockerContainer.metrics, func(m *ContainerMetrics) {
+						m.Wait = time.Since(waitStart)
+					})
+					if err != nil {
 						return fmt.Errorf("wait until ready: %w", err)
 					}
 				}
@@ -287,9 +345,13 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 
 // creatingHook is a hook that will be called before a container is created.
 func (req ContainerRequest) creatingHook(ctx context.Context) error {
-	errs := make([]error, len(req.LifecycleHooks))
-	for i, lifecycleHooks := range req.LifecycleHooks {
-		errs[i] = lifecycleHooks.Creating(ctx)(req)
+	errs := make([]error, 0, len(req.LifecycleHooks))
+	for _, lifecycleHooks := range req.LifecycleHooks {
+		err := lifecycleHooks.Creating(ctx)(req)
+		errs = append(errs, err)
+		if isFailFast(err) {
+			break
+		}
 	}
 
 	return errors.Join(errs...)
@@ -371,9 +433,13 @@ func (c *DockerContainer) terminatedHook(ctx context.Context) error {
 
 // applyLifecycleHooks applies all lifecycle hooks reporting the container logs on error if logError is true.
 func (c *DockerContainer) applyLifecycleHooks(ctx context.Context, logError bool, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook) error {
-	errs := make([]error, len(c.lifecycleHooks))
-	for i, lifecycleHooks := range c.lifecycleHooks {
-		errs[i] = containerHookFn(ctx, hooks(lifecycleHooks))(c)
+	errs := make([]error, 0, len(c.lifecycleHooks))
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := containerHookFn(ctx, wrapHooks(lifecycleHooks, hooks(lifecycleHooks)))(c)
+		errs = append(errs, err)
+		if isFailFast(err) {
+			break
+		}
 	}
 
 	if err := errors.Join(errs...); err != nil {
@@ -398,7 +464,7 @@ func (c *DockerContainer) applyLifecycleHooks(ctx context.Context, logError bool
 // Creating is a hook that will be called before a container is created.
 func (c ContainerLifecycleHooks) Creating(ctx context.Context) func(req ContainerRequest) error {
 	return func(req ContainerRequest) error {
-		for _, hook := range c.PreCreates {
+		for _, hook := range wrapHooks(c, c.PreCreates) {
 			if err := hook(ctx, req); err != nil {
 				return err
 			}
@@ -409,61 +475,136 @@ func (c ContainerLifecycleHooks) Creating(ctx context.Context) func(req Containe
 }
 
 // containerHookFn is a helper function that will create a function to be returned by all the different
-// container lifecycle hooks. The created function will iterate over all the hooks and call them one by one.
+// container lifecycle hooks. The created function will iterate over all the hooks and call them one by one,
+// stopping at the first error of a hook with the fail-fast error policy.
+// The context passed to the hooks carries the container, see ContainerFromContext.
 func containerHookFn(ctx context.Context, containerHook []ContainerHook) func(container Container) error {
 	return func(container Container) error {
-		errs := make([]error, len(containerHook))
-		for i, hook := range containerHook {
-			errs[i] = hook(ctx, container)
+		ctx := context.WithValue(ctx, containerContextKey{}, container)
+
+		errs := make([]error, 0, len(containerHook))
+		for _, hook := range containerHook {
+			err := hook(ctx, container)
+			errs = append(errs, err)
+			if isFailFast(err) {
+				break
+			}
 		}
 
 		return errors.Join(errs...)
 	}
 }
 
+// containerContextKey is the key of the container in the context passed to the container hooks.
+type containerContextKey struct{}
+
+// ContainerFromContext returns the container carried by the context passed to the container lifecycle hooks,
+// so the functions called by a hook can access the container without receiving it as argument.
+func ContainerFromContext(ctx context.Context) (Container, bool) {
+	c, ok := ctx.Value(containerContextKey{}).(Container)
+	return c, ok
+}
+
+// failFastError is the error of a hook with the fail-fast error policy,
+// which stops running the remaining hooks of the lifecycle phase.
+type failFastError struct {
+	err error
+}
+
+func (e *failFastError) Error() string {
+	return e.err.Error()
+}
+
+func (e *failFastError) Unwrap() error {
+	return e.err
+}
+
+// isFailFast returns true if the error comes from a hook with the fail-fast error policy.
+func isFailFast(err error) bool {
+	var ffErr *failFastError
+	return errors.As(err, &ffErr)
+}
+
+// wrapHooks applies the name, timeout and error policy of the bundle to its hooks.
+// The hooks are returned as they are if the bundle does not define any of them.
+func wrapHooks[H ~func(context.Context, T) error, T any](c ContainerLifecycleHooks, hooks []H) []H {
+	if c.Name == "" && c.Timeout <= 0 && c.ErrorPolicy == HookErrorPolicyCollect {
+		return hooks
+	}
+
+	wrapped := make([]H, 0, len(hooks))
+	for _, hook := range hooks {
+		wrapped = append(wrapped, func(ctx context.Context, arg T) error {
+			if c.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+				defer cancel()
+			}
+
+			err := hook(ctx, arg)
+			if err == nil {
+				return nil
+			}
+
+			if c.Name != "" {
+				err = fmt.Errorf("%s: %w", c.Name, err)
+			}
+
+			if c.ErrorPolicy == HookErrorPolicyFailFast {
+				err = &failFastError{err: err}
+			}
+
+			return err
+		})
+	}
+
+	return wrapped
+}
+
 // Created is a hook that will be called after a container is created
 func (c ContainerLifecycleHooks) Created(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PostCreates)
+	return containerHookFn(ctx, wrapHooks(c, c.PostCreates))
 }
 
 // Starting is a hook that will be called before a container is started
 func (c ContainerLifecycleHooks) Starting(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PreStarts)
+	return containerHookFn(ctx, wrapHooks(c, c.PreStarts))
 }
 
 // Started is a hook that will be called after a container is started
 func (c ContainerLifecycleHooks) Started(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PostStarts)
+	return containerHookFn(ctx, wrapHooks(c, c.PostStarts))
 }
 
 // Readied is a hook that will be called after a container is ready
 func (c ContainerLifecycleHooks) Readied(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PostReadies)
+	return containerHookFn(ctx, wrapHooks(c, c.PostReadies))
 }
 
 // Stopping is a hook that will be called before a container is stopped
 func (c ContainerLifecycleHooks) Stopping(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PreStops)
+	return containerHookFn(ctx, wrapHooks(c, c.PreStops))
 }
 
 // Stopped is a hook that will be called after a container is stopped
 func (c ContainerLifecycleHooks) Stopped(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PostStops)
+	return containerHookFn(ctx, wrapHooks(c, c.PostStops))
 }
 
 // Terminating is a hook that will be called before a container is terminated
 func (c ContainerLifecycleHooks) Terminating(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PreTerminates)
+	return containerHookFn(ctx, wrapHooks(c, c.PreTerminates))
 }
 
 // Terminated is a hook that will be called after a container is terminated
 func (c ContainerLifecycleHooks) Terminated(ctx context.Context) func(container Container) error {
-	return containerHookFn(ctx, c.PostTerminates)
+	return containerHookFn(ctx, wrapHooks(c, c.PostTerminates))
 }
 
 func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req ContainerRequest, dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) error {
 	// prepare mounts
 	hostConfig.Mounts = mapToDockerMounts(req.Mounts)
+	labelSessionVolumes(hostConfig.Mounts, req.Labels)
 
 	endpointSettings := map[string]*network.EndpointSettings{}
 
@@ -498,6 +639,9 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	}
 	req.HostConfigModifier(hostConfig)
 
+	// the bind mounts are resolved by the Docker daemon, so they must use the paths on the Docker host
+	translateHostMountPaths(p.config.HostMountPaths, hostConfig)
+
 	if req.EnpointSettingsModifier != nil {
 		req.EnpointSettingsModifier(endpointSettings)
 	}
@@ -523,6 +667,19 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 
 	dockerInput.ExposedPorts = exposedPortSet
 
+	// only the requests using WithHostNetwork are validated, as the daemon accepts the host network mode
+	// set by a host config modifier, even if it's not the host of the tests, e.g. on Docker Desktop
+	if req.HostNetwork && hostConfig.NetworkMode.IsHost() {
+		info, err := p.client.Info(ctx)
+		if err != nil {
+			return fmt.Errorf("docker info: %w", err)
+		}
+
+		if err := validateHostNetworkMode(info, exposedPortMap, hostConfig.PortBindings); err != nil {
+			return err
+		}
+	}
+
 	// only exposing those ports automatically if the container request exposes zero ports and the container does not run in a container network
 	if len(exposedPorts) == 0 && !hostConfig.NetworkMode.IsContainer() {
 		hostConfig.PortBindings = exposedPortMap
@@ -533,12 +690,41 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	return nil
 }
 
+// ErrInvalidHostNetworkMode is returned when a container cannot run in the host network mode.
+var ErrInvalidHostNetworkMode = errors.New("invalid host network mode")
+
+// validateHostNetworkMode checks that a container can run in the host network mode on the Docker daemon,
+// which is only supported for Linux containers out of Docker Desktop, and that its exposed ports are not mapped
+// to different host ports, as they are published on the host with the same number.
+func validateHostNetworkMode(info system.Info, portMaps ...nat.PortMap) error {
+	if info.OSType != "linux" {
+		return fmt.Errorf("%w: the host network mode is not supported for %s containers", ErrInvalidHostNetworkMode, info.OSType)
+	}
+
+	if info.OperatingSystem == "Docker Desktop" {
+		return fmt.Errorf("%w: the host network mode is not supported on Docker Desktop, the host is the Docker Desktop VM", ErrInvalidHostNetworkMode)
+	}
+
+	for _, portBindings := range portMaps {
+		for port, bindings := range portBindings {
+			for _, binding := range bindings {
+				if binding.HostPort != "" && binding.HostPort != port.Port() {
+					return fmt.Errorf("%w: port %s cannot be mapped to host port %s", ErrInvalidHostNetworkMode, port, binding.HostPort)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // combineContainerHooks it returns just one ContainerLifecycle hook, as the result of combining
 // the default hooks with the user-defined hooks. The function will loop over all the default hooks,
 // storing each of the hooks in a slice, and then it will loop over all the user-defined hooks,
 // appending or prepending them to the slice of hooks. The order of hooks is the following:
 // - for Pre-hooks, always run the default hooks first, then append the user-defined hooks
 // - for Post-hooks, always run the user-defined hooks first, then the default hooks
+// The name, timeout and error policy of each bundle are applied to its hooks before combining them.
 func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHooks) ContainerLifecycleHooks {
 	preCreates := []ContainerRequestHook{}
 	postCreates := []ContainerHook{}
@@ -550,6 +736,9 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 	preTerminates := []ContainerHook{}
 	postTerminates := []ContainerHook{}
 
+	defaultHooks = wrapBundles(defaultHooks)
+	userDefinedHooks = wrapBundles(userDefinedHooks)
+
 	for _, defaultHook := range defaultHooks {
 		preCreates = append(preCreates, defaultHook.PreCreates...)
 		preStarts = append(preStarts, defaultHook.PreStarts...)
@@ -594,6 +783,26 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 	}
 }
 
+// wrapBundles returns the bundles with their name, timeout and error policy applied to their hooks.
+func wrapBundles(bundles []ContainerLifecycleHooks) []ContainerLifecycleHooks {
+	wrapped := make([]ContainerLifecycleHooks, 0, len(bundles))
+	for _, b := range bundles {
+		wrapped = append(wrapped, ContainerLifecycleHooks{
+			PreCreates:     wrapHooks(b, b.PreCreates),
+			PostCreates:    wrapHooks(b, b.PostCreates),
+			PreStarts:      wrapHooks(b, b.PreStarts),
+			PostStarts:     wrapHooks(b, b.PostStarts),
+			PostReadies:    wrapHooks(b, b.PostReadies),
+			PreStops:       wrapHooks(b, b.PreStops),
+			PostStops:      wrapHooks(b, b.PostStops),
+			PreTerminates:  wrapHooks(b, b.PreTerminates),
+			PostTerminates: wrapHooks(b, b.PostTerminates),
+		})
+	}
+
+	return wrapped
+}
+
 func mergePortBindings(configPortMap, exposedPortMap nat.PortMap, exposedPorts []string) nat.PortMap {
 	if exposedPortMap == nil {
 		exposedPortMap = make(map[nat.Port][]nat.PortBinding)
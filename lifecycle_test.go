@@ -3,6 +3,7 @@ package testcontainers
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -456,6 +458,68 @@ func TestMergePortBindings(t *testing.T) {
 	}
 }
 
+func TestValidateHostNetworkMode(t *testing.T) {
+	linux := system.Info{OSType: "linux", OperatingSystem: "Ubuntu 24.04 LTS"}
+
+	tests := []struct {
+		name         string
+		info         system.Info
+		portBindings []nat.PortMap
+		wantErr      bool
+	}{
+		{
+			name: "linux/no-ports",
+			info: linux,
+		},
+		{
+			name: "linux/exposed-ports",
+			info: linux,
+			portBindings: []nat.PortMap{{
+				"80/tcp":   {{HostPort: ""}},
+				"8080/tcp": {{HostIP: "0.0.0.0", HostPort: "8080"}},
+			}},
+		},
+		{
+			name: "linux/mapped-ports",
+			info: linux,
+			portBindings: []nat.PortMap{{
+				"80/tcp": {{HostPort: "8080"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "linux/modified-port-bindings",
+			info: linux,
+			portBindings: []nat.PortMap{
+				{"80/tcp": {{HostPort: ""}}},
+				{"80/tcp": {{HostPort: "8080"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "windows",
+			info:    system.Info{OSType: "windows"},
+			wantErr: true,
+		},
+		{
+			name:    "docker-desktop",
+			info:    system.Info{OSType: "linux", OperatingSystem: "Docker Desktop"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHostNetworkMode(tt.info, tt.portBindings...)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidHostNetworkMode)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestPortMappingCheck(t *testing.T) {
 	makePortMap := func(ports ...string) nat.PortMap {
 		out := make(nat.PortMap)
@@ -880,6 +944,135 @@ func TestLifecycleHooks_WithMultipleHooks(t *testing.T) {
 	require.Len(t, dl.data, 24)
 }
 
+func TestLifecycleHooks_BundleSettings(t *testing.T) {
+	ctx := context.Background()
+
+	errHook := errors.New("hook failed")
+
+	failing := func(ctx context.Context, _ Container) error {
+		return errHook
+	}
+
+	t.Run("name", func(t *testing.T) {
+		c := &DockerContainer{lifecycleHooks: []ContainerLifecycleHooks{
+			{Name: "seed", PreStops: []ContainerHook{failing}},
+		}}
+
+		err := c.stoppingHook(ctx)
+		require.ErrorIs(t, err, errHook)
+		require.EqualError(t, err, "seed: hook failed")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := &DockerContainer{lifecycleHooks: []ContainerLifecycleHooks{
+			{
+				Timeout: 10 * time.Millisecond,
+				PreStops: []ContainerHook{
+					func(ctx context.Context, _ Container) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+				},
+			},
+		}}
+
+		err := c.stoppingHook(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("collect", func(t *testing.T) {
+		var calls int
+		counting := func(ctx context.Context, _ Container) error {
+			calls++
+			return nil
+		}
+
+		c := &DockerContainer{lifecycleHooks: []ContainerLifecycleHooks{
+			{Name: "first", PreStops: []ContainerHook{failing, counting}},
+			{Name: "second", PreStops: []ContainerHook{failing, counting}},
+		}}
+
+		err := c.stoppingHook(ctx)
+		require.EqualError(t, err, "first: hook failed\nsecond: hook failed")
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("fail-fast", func(t *testing.T) {
+		var calls int
+		counting := func(ctx context.Context, _ Container) error {
+			calls++
+			return nil
+		}
+
+		c := &DockerContainer{lifecycleHooks: []ContainerLifecycleHooks{
+			{Name: "first", ErrorPolicy: HookErrorPolicyFailFast, PreStops: []ContainerHook{counting, failing, counting}},
+			{Name: "second", PreStops: []ContainerHook{counting}},
+		}}
+
+		err := c.stoppingHook(ctx)
+		require.ErrorIs(t, err, errHook)
+		require.EqualError(t, err, "first: hook failed")
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("fail-fast/combined", func(t *testing.T) {
+		var calls int
+		counting := func(ctx context.Context, _ Container) error {
+			calls++
+			return nil
+		}
+
+		hooks := combineContainerHooks(
+			[]ContainerLifecycleHooks{{PostStarts: []ContainerHook{counting}}},
+			[]ContainerLifecycleHooks{{Name: "user", ErrorPolicy: HookErrorPolicyFailFast, PostStarts: []ContainerHook{failing}}},
+		)
+
+		err := hooks.Started(ctx)(&DockerContainer{})
+		require.EqualError(t, err, "user: hook failed")
+		require.Zero(t, calls)
+	})
+
+	t.Run("fail-fast/creating", func(t *testing.T) {
+		req := ContainerRequest{LifecycleHooks: []ContainerLifecycleHooks{
+			{Name: "first", ErrorPolicy: HookErrorPolicyFailFast, PreCreates: []ContainerRequestHook{
+				func(ctx context.Context, _ ContainerRequest) error {
+					return errHook
+				},
+			}},
+			{Name: "second", PreCreates: []ContainerRequestHook{
+				func(ctx context.Context, _ ContainerRequest) error {
+					return errors.New("unexpected call")
+				},
+			}},
+		}}
+
+		err := req.creatingHook(ctx)
+		require.EqualError(t, err, "first: hook failed")
+	})
+
+	t.Run("container-from-context", func(t *testing.T) {
+		var got Container
+		c := &DockerContainer{lifecycleHooks: []ContainerLifecycleHooks{
+			{PreStops: []ContainerHook{
+				func(ctx context.Context, _ Container) error {
+					var ok bool
+					got, ok = ContainerFromContext(ctx)
+					if !ok {
+						return errors.New("no container in context")
+					}
+					return nil
+				},
+			}},
+		}}
+
+		require.NoError(t, c.stoppingHook(ctx))
+		require.Same(t, c, got)
+
+		_, ok := ContainerFromContext(ctx)
+		require.False(t, ok)
+	})
+}
+
 type linesTestLogger struct {
 	data []string
 }
@@ -0,0 +1,262 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	defaultLogFileMaxSize    = 10 * 1024 * 1024
+	defaultLogFileMaxBackups = 5
+)
+
+// unsafeFileNameChars matches the characters replaced in the names of the log files.
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// LogFileOption is an option for the log files written by WithLogFile.
+type LogFileOption func(*logFile)
+
+// LogFileTest adds the name of the test to the names of the log files,
+// so the logs of the containers of each test can be told apart.
+func LogFileTest(tb testing.TB) LogFileOption {
+	return func(f *logFile) {
+		f.test = tb.Name()
+	}
+}
+
+// LogFileMaxSize sets the size in bytes from which a log file is rotated. The default is 10MiB.
+func LogFileMaxSize(size int64) LogFileOption {
+	return func(f *logFile) {
+		f.maxSize = size
+	}
+}
+
+// LogFileMaxBackups sets the number of rotated log files kept for each container,
+// named with the .1, .2, ... suffixes from the newest to the oldest. The default is 5.
+func LogFileMaxBackups(n int) LogFileOption {
+	return func(f *logFile) {
+		f.maxBackups = n
+	}
+}
+
+// WithLogFile writes the stdout and stderr of the container to a file of the given directory,
+// named after the test, if set with LogFileTest, the session and the container,
+// e.g. TestFoo_0123456789ab_funny_name.log. The file is rotated when it reaches its maximum size.
+// The directory is created if it does not exist, and the file is closed when the container is terminated.
+// The file is written by a log consumer added to the ones of the request, so WithLogConsumers,
+// which replaces the consumers, must be set before this option.
+func WithLogFile(dir string, opts ...LogFileOption) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if dir == "" {
+			return errors.New("empty log file directory")
+		}
+
+		f := &logFile{
+			dir:        dir,
+			maxSize:    defaultLogFileMaxSize,
+			maxBackups: defaultLogFileMaxBackups,
+		}
+		for _, opt := range opts {
+			opt(f)
+		}
+
+		if req.LogConsumerCfg == nil {
+			req.LogConsumerCfg = &LogConsumerConfig{}
+		}
+		req.LogConsumerCfg.Consumers = append(req.LogConsumerCfg.Consumers, f)
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostStarts: []ContainerHook{
+				// The user-defined post-start hooks run before the log production starts,
+				// see combineContainerHooks, so the file is opened before the first log is accepted.
+				func(ctx context.Context, c Container) error {
+					name, err := containerLogName(ctx, c)
+					if err != nil {
+						return fmt.Errorf("log file: %w", err)
+					}
+
+					return f.open(name)
+				},
+			},
+			PostTerminates: []ContainerHook{
+				func(_ context.Context, _ Container) error {
+					return f.Close()
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// containerLogName returns the name of the container without its leading slash,
+// or its short ID if it has no name.
+func containerLogName(ctx context.Context, c Container) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("inspect: %w", err)
+	}
+
+	if name := strings.TrimPrefix(inspect.Name, "/"); name != "" {
+		return name, nil
+	}
+
+	id := c.GetContainerID()
+	if len(id) > 12 {
+		id = id[:12]
+	}
+
+	return id, nil
+}
+
+// Compiler check to ensure that logFile implements the LogConsumer interface.
+var _ LogConsumer = (*logFile)(nil)
+
+// logFile is the log consumer writing the logs of a container to a file, rotated by size.
+type logFile struct {
+	dir        string
+	test       string
+	maxSize    int64
+	maxBackups int
+
+	mtx  sync.Mutex
+	path string
+	file *os.File
+	size int64
+	err  error
+}
+
+// open opens the log file of the given container in append mode, creating its directory if needed.
+// It's a no-op if the file is already open, e.g. when the container is restarted.
+func (f *logFile) open(container string) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+
+	sessionID := SessionID()
+	if len(sessionID) > 12 {
+		sessionID = sessionID[:12]
+	}
+
+	parts := make([]string, 0, 3)
+	if f.test != "" {
+		parts = append(parts, f.test)
+	}
+	parts = append(parts, sessionID, container)
+
+	name := unsafeFileNameChars.ReplaceAllString(strings.Join(parts, "_"), "_")
+	f.path = filepath.Join(f.dir, name+".log")
+
+	return f.openFile()
+}
+
+// openFile opens the file at the path of the log file, reading its current size.
+func (f *logFile) openFile() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(fmt.Errorf("stat log file: %w", err), file.Close())
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// Accept writes the content of the log to the file, rotating it first if the content
+// would exceed its maximum size. The first error is kept and returned by Close,
+// as the log consumers can't return errors.
+func (f *logFile) Accept(l Log) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil || f.err != nil {
+		return
+	}
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(l.Content)) > f.maxSize {
+		if f.err = f.rotate(); f.err != nil {
+			return
+		}
+	}
+
+	n, err := f.file.Write(l.Content)
+	f.size += int64(n)
+	if err != nil {
+		f.err = fmt.Errorf("write log file: %w", err)
+	}
+}
+
+// rotate closes the log file, shifts the rotated files, removing the oldest one,
+// and opens a new file.
+func (f *logFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	f.file = nil
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("remove log file: %w", err)
+		}
+
+		return f.openFile()
+	}
+
+	oldest := fmt.Sprintf("%s.%d", f.path, f.maxBackups)
+	if err := os.Remove(oldest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove rotated log file: %w", err)
+	}
+
+	for i := f.maxBackups - 1; i > 0; i-- {
+		src := fmt.Sprintf("%s.%d", f.path, i)
+		if err := os.Rename(src, fmt.Sprintf("%s.%d", f.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+
+	return f.openFile()
+}
+
+// Close closes the log file, returning the first error found writing it, if any.
+func (f *logFile) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	var errs []error
+	if f.err != nil {
+		errs = append(errs, f.err)
+	}
+
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close log file: %w", err))
+		}
+		f.file = nil
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package testcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWithLogFile(t *testing.T) {
+	t.Run("empty-dir", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		err := WithLogFile("")(&req)
+		require.EqualError(t, err, "empty log file directory")
+	})
+
+	t.Run("appends-consumer", func(t *testing.T) {
+		req := GenericContainerRequest{}
+
+		require.NoError(t, WithLogConsumers(&StdoutLogConsumer{})(&req))
+		require.NoError(t, WithLogFile(t.TempDir())(&req))
+
+		require.Len(t, req.LogConsumerCfg.Consumers, 2)
+		require.Len(t, req.LifecycleHooks, 1)
+	})
+
+	t.Run("container", func(t *testing.T) {
+		ctx := context.Background()
+		dir := t.TempDir()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "alpine:latest",
+				Cmd:        []string{"sh", "-c", "echo stdout-line; echo stderr-line >&2; echo done; sleep 60"},
+				WaitingFor: wait.ForLog("done"),
+			},
+			Started: true,
+		}
+		require.NoError(t, WithLogFile(dir, LogFileTest(t))(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		name, err := containerLogName(ctx, ctr)
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "TestWithLogFile_container_*"+name+".log"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		require.Eventually(t, func() bool {
+			content, err := os.ReadFile(files[0])
+			return err == nil && strings.Contains(string(content), "stdout-line\n") && strings.Contains(string(content), "stderr-line\n")
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestLogFile_Rotate(t *testing.T) {
+	dir := t.TempDir()
+
+	f := &logFile{dir: dir, test: "Test/rotate", maxSize: 10, maxBackups: 2}
+	require.NoError(t, f.open("ctr"))
+
+	require.True(t, strings.HasPrefix(filepath.Base(f.path), "Test_rotate_"))
+	require.True(t, strings.HasSuffix(f.path, "_ctr.log"))
+
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		f.Accept(Log{LogType: StdoutLog, Content: []byte(line)})
+	}
+	require.NoError(t, f.Close())
+
+	read := func(path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	require.Equal(t, "line-4\n", read(f.path))
+	require.Equal(t, "line-3\n", read(f.path+".1"))
+	require.Equal(t, "line-2\n", read(f.path+".2"))
+	require.NoFileExists(t, f.path+".3")
+}
+
+func TestLogFile_NoBackups(t *testing.T) {
+	f := &logFile{dir: t.TempDir(), maxSize: 10}
+	require.NoError(t, f.open("ctr"))
+
+	f.Accept(Log{LogType: StdoutLog, Content: []byte("line-1\n")})
+	f.Accept(Log{LogType: StderrLog, Content: []byte("line-2\n")})
+	require.NoError(t, f.Close())
+
+	content, err := os.ReadFile(f.path)
+	require.NoError(t, err)
+	require.Equal(t, "line-2\n", string(content))
+	require.NoFileExists(t, f.path+".1")
+}
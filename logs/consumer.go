@@ -0,0 +1,203 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const defaultFlushInterval = 100 * time.Millisecond
+
+// Compiler check to ensure that Consumer implements the testcontainers.LogConsumer
+// and testcontainers.ContainerCustomizer interfaces.
+var (
+	_ testcontainers.LogConsumer         = (*Consumer)(nil)
+	_ testcontainers.ContainerCustomizer = (*Consumer)(nil)
+)
+
+// Option is an option for the Consumer.
+type Option func(*Consumer)
+
+// WithMultiline merges the lines matching the continuation expression into the record of the previous line,
+// e.g. the lines of a stack trace. A merged record is emitted when a line not matching the expression is received,
+// when no line is received within the flush interval, or when the consumer is flushed.
+func WithMultiline(continuation *regexp.Regexp) Option {
+	return func(c *Consumer) {
+		c.continuation = continuation
+	}
+}
+
+// WithFlushInterval sets the time without new lines after which a pending multiline record is emitted.
+// The default is 100ms.
+func WithFlushInterval(d time.Duration) Option {
+	return func(c *Consumer) {
+		c.flushInterval = d
+	}
+}
+
+// WithContainerID sets the ID of the container in the records. It's set automatically
+// when the consumer is passed as an option to create the container.
+func WithContainerID(id string) Option {
+	return func(c *Consumer) {
+		c.containerID = id
+	}
+}
+
+// Consumer is a log consumer turning the logs of a container into structured records,
+// passed to its handler in order. It can be passed as an option to create a container,
+// which adds it to the log consumers of the container.
+type Consumer struct {
+	handler       func(Record)
+	continuation  *regexp.Regexp
+	flushInterval time.Duration
+
+	mtx         sync.Mutex
+	containerID string
+	partial     map[string]string
+	pending     *Record
+	timer       *time.Timer
+	// generation identifies the last timer, so a timer firing while a newer one is set does nothing.
+	generation int
+}
+
+// NewConsumer returns a consumer passing the records of the logs to the handler.
+func NewConsumer(handler func(Record), opts ...Option) *Consumer {
+	c := &Consumer{
+		handler:       handler,
+		flushInterval: defaultFlushInterval,
+		partial:       map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// NewJSONConsumer returns a consumer writing the records of the logs to w as JSON, one record per line.
+func NewJSONConsumer(w io.Writer, opts ...Option) *Consumer {
+	enc := json.NewEncoder(w)
+
+	var mtx sync.Mutex
+	return NewConsumer(func(r Record) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		_ = enc.Encode(r)
+	}, opts...)
+}
+
+// Customize adds the consumer to the log consumers of the request, setting the ID of the container
+// in its records once the container is started.
+func (c *Consumer) Customize(req *testcontainers.GenericContainerRequest) error {
+	if req.LogConsumerCfg == nil {
+		req.LogConsumerCfg = &testcontainers.LogConsumerConfig{}
+	}
+	req.LogConsumerCfg.Consumers = append(req.LogConsumerCfg.Consumers, c)
+
+	req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostStarts: []testcontainers.ContainerHook{
+			func(_ context.Context, ctr testcontainers.Container) error {
+				c.mtx.Lock()
+				defer c.mtx.Unlock()
+
+				c.containerID = ctr.GetContainerID()
+				return nil
+			},
+		},
+		PreTerminates: []testcontainers.ContainerHook{
+			func(_ context.Context, _ testcontainers.Container) error {
+				c.Flush()
+				return nil
+			},
+		},
+	})
+
+	return nil
+}
+
+// Accept implements the testcontainers.LogConsumer interface, splitting the content in lines,
+// and keeping the last line of each stream until it's complete.
+func (c *Consumer) Accept(l testcontainers.Log) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stream := streamOf(l.LogType)
+	content := c.partial[stream] + string(l.Content)
+
+	lines := strings.SplitAfter(content, "\n")
+	c.partial[stream] = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		c.emit(parseLine(c.containerID, stream, line))
+	}
+}
+
+// Flush emits the pending multiline record and the incomplete lines, if any.
+func (c *Consumer) Flush() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, stream := range []string{StreamStdout, StreamStderr} {
+		if line := c.partial[stream]; line != "" {
+			c.partial[stream] = ""
+			c.emit(parseLine(c.containerID, stream, line))
+		}
+	}
+
+	c.flushPending()
+}
+
+// emit passes the record to the handler, or merges it into the pending record
+// when it's a continuation line. It must be called holding the lock.
+func (c *Consumer) emit(r Record) {
+	if c.continuation == nil {
+		c.handler(r)
+		return
+	}
+
+	if c.pending != nil && c.pending.Stream == r.Stream && c.continuation.MatchString(r.Line) {
+		c.pending.Line += "\n" + r.Line
+	} else {
+		c.flushPending()
+		c.pending = &r
+	}
+
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	c.generation++
+	generation := c.generation
+	c.timer = time.AfterFunc(c.flushInterval, func() {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		if c.generation == generation {
+			c.flushPending()
+		}
+	})
+}
+
+// flushPending passes the pending record to the handler, if any. It must be called holding the lock.
+func (c *Consumer) flushPending() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	if c.pending == nil {
+		return
+	}
+
+	r := *c.pending
+	c.pending = nil
+	c.handler(r)
+}
@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type recorder struct {
+	mtx     sync.Mutex
+	records []Record
+}
+
+func (r *recorder) handle(rec Record) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.records = append(r.records, rec)
+}
+
+func (r *recorder) lines() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	lines := make([]string, 0, len(r.records))
+	for _, rec := range r.records {
+		lines = append(lines, rec.Stream+": "+rec.Line)
+	}
+
+	return lines
+}
+
+func TestParseLine(t *testing.T) {
+	t.Run("timestamp", func(t *testing.T) {
+		r := parseLine("abc", StreamStdout, "2024-05-01T10:00:00.123456789Z hello world\n")
+
+		require.Equal(t, "abc", r.ContainerID)
+		require.Equal(t, StreamStdout, r.Stream)
+		require.Equal(t, "hello world", r.Line)
+		require.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC), r.Time)
+	})
+
+	t.Run("no-timestamp", func(t *testing.T) {
+		before := time.Now()
+		r := parseLine("abc", StreamStderr, "hello world\r\n")
+
+		require.Equal(t, "hello world", r.Line)
+		require.False(t, r.Time.Before(before))
+	})
+}
+
+func TestConsumer(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		rec := &recorder{}
+		c := NewConsumer(rec.handle, WithContainerID("abc"))
+
+		c.Accept(testcontainers.Log{LogType: testcontainers.StdoutLog, Content: []byte("first\nsec")})
+		c.Accept(testcontainers.Log{LogType: testcontainers.StderrLog, Content: []byte("error\n")})
+		c.Accept(testcontainers.Log{LogType: testcontainers.StdoutLog, Content: []byte("ond\nthird")})
+		require.Equal(t, []string{"stdout: first", "stderr: error", "stdout: second"}, rec.lines())
+
+		c.Flush()
+		require.Equal(t, []string{"stdout: first", "stderr: error", "stdout: second", "stdout: third"}, rec.lines())
+		require.Equal(t, "abc", rec.records[0].ContainerID)
+	})
+
+	t.Run("multiline", func(t *testing.T) {
+		rec := &recorder{}
+		c := NewConsumer(rec.handle, WithMultiline(regexp.MustCompile(`^\s+at `)), WithFlushInterval(time.Hour))
+
+		c.Accept(testcontainers.Log{LogType: testcontainers.StderrLog, Content: []byte("Exception: boom\n")})
+		c.Accept(testcontainers.Log{LogType: testcontainers.StderrLog, Content: []byte("    at Foo.bar\n    at Foo.main\n")})
+		c.Accept(testcontainers.Log{LogType: testcontainers.StdoutLog, Content: []byte("next\n")})
+		require.Equal(t, []string{"stderr: Exception: boom\n    at Foo.bar\n    at Foo.main"}, rec.lines())
+
+		c.Flush()
+		require.Equal(t, []string{"stderr: Exception: boom\n    at Foo.bar\n    at Foo.main", "stdout: next"}, rec.lines())
+	})
+
+	t.Run("multiline/flush-interval", func(t *testing.T) {
+		rec := &recorder{}
+		c := NewConsumer(rec.handle, WithMultiline(regexp.MustCompile(`^\s+at `)), WithFlushInterval(10*time.Millisecond))
+
+		c.Accept(testcontainers.Log{LogType: testcontainers.StderrLog, Content: []byte("Exception: boom\n    at Foo.bar\n")})
+
+		require.Eventually(t, func() bool {
+			return len(rec.lines()) == 1
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, []string{"stderr: Exception: boom\n    at Foo.bar"}, rec.lines())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		c := NewJSONConsumer(&buf, WithContainerID("abc"))
+
+		c.Accept(testcontainers.Log{LogType: testcontainers.StdoutLog, Content: []byte("2024-05-01T10:00:00Z hello\n")})
+
+		var r map[string]string
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+		require.Equal(t, map[string]string{
+			"timestamp":   "2024-05-01T10:00:00Z",
+			"stream":      "stdout",
+			"containerID": "abc",
+			"line":        "hello",
+		}, r)
+	})
+
+	t.Run("customize", func(t *testing.T) {
+		c := NewConsumer(func(Record) {})
+		req := testcontainers.GenericContainerRequest{}
+
+		require.NoError(t, c.Customize(&req))
+		require.Equal(t, []testcontainers.LogConsumer{c}, req.LogConsumerCfg.Consumers)
+		require.Len(t, req.LifecycleHooks, 1)
+	})
+}
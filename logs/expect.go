@@ -0,0 +1,145 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// streamHeaderSize is the size of the header of the frames of the multiplexed logs.
+const streamHeaderSize = 8
+
+// ErrNoMatch is the error returned by Expect when no log line matches the expression within the duration.
+var ErrNoMatch = errors.New("no matching log line")
+
+// Expect waits until the container writes a log line matching the expression, returning its record.
+// It reads the logs from the start, so the lines written before calling it are matched too.
+// It returns an error if no line matches within the given duration.
+func Expect(ctx context.Context, ctr testcontainers.Container, re *regexp.Regexp, within time.Duration) (Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, within)
+	defer cancel()
+
+	var found *Record
+	err := follow(ctx, ctr, func(r Record) bool {
+		if re.MatchString(r.Line) {
+			found = &r
+			return false
+		}
+
+		return true
+	})
+	if found != nil {
+		return *found, nil
+	}
+
+	if err == nil || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return Record{}, fmt.Errorf("%w: %q within %s", ErrNoMatch, re, within)
+	}
+
+	return Record{}, fmt.Errorf("follow logs: %w", err)
+}
+
+// ExpectNot checks that the container does not write a log line matching the expression within the given duration,
+// returning an error with the record of the first matching line otherwise.
+func ExpectNot(ctx context.Context, ctr testcontainers.Container, re *regexp.Regexp, within time.Duration) error {
+	r, err := Expect(ctx, ctr, re, within)
+	if err == nil {
+		return fmt.Errorf("unexpected log line matching %q on %s at %s: %s", re, r.Stream, r.Time.Format(time.RFC3339Nano), r.Line)
+	}
+
+	if errors.Is(err, ErrNoMatch) {
+		return nil
+	}
+
+	return err
+}
+
+// follow follows the logs of the container, with their timestamps, passing their records to fn
+// until it returns false, the logs end, or the context is done.
+func follow(ctx context.Context, ctr testcontainers.Container, fn func(Record) bool) error {
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect: %w", err)
+	}
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("new docker client: %w", err)
+	}
+	defer cli.Close()
+
+	rc, err := cli.ContainerLogs(ctx, ctr.GetContainerID(), container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+	})
+	if err != nil {
+		return fmt.Errorf("container logs: %w", err)
+	}
+	defer rc.Close()
+
+	// The logs of the containers with a TTY are not multiplexed, and all of them come from the stdout.
+	if inspect.Config != nil && inspect.Config.Tty {
+		return followLines(ctx, ctr.GetContainerID(), StreamStdout, rc, fn)
+	}
+
+	return followFrames(ctx, ctr.GetContainerID(), rc, fn)
+}
+
+// followLines passes the records of the lines of the reader to fn until it returns false.
+func followLines(ctx context.Context, containerID string, stream string, r io.Reader, fn func(Record) bool) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" && !fn(parseLine(containerID, stream, line)) {
+			return nil
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return ctx.Err()
+			}
+
+			return err
+		}
+	}
+}
+
+// followFrames passes the records of the lines of the multiplexed frames of the reader to fn until it returns false.
+// With timestamps, Docker writes a frame for each line, or for each chunk of a long line.
+func followFrames(ctx context.Context, containerID string, r io.Reader, fn func(Record) bool) error {
+	header := make([]byte, streamHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		stream := StreamStdout
+		if header[0] == 2 {
+			stream = StreamStderr
+		}
+
+		payload := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+
+		if !fn(parseLine(containerID, stream, string(payload))) {
+			return nil
+		}
+	}
+}
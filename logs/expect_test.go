@@ -0,0 +1,81 @@
+package logs_test
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/logs"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestExpect(t *testing.T) {
+	ctx := context.Background()
+
+	var mtx sync.Mutex
+	var records []logs.Record
+	consumer := logs.NewConsumer(func(r logs.Record) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		records = append(records, r)
+	})
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "alpine:latest",
+			Cmd:        []string{"sh", "-c", "echo started; sleep 1; echo 'user=admin logged in' >&2; sleep 60"},
+			WaitingFor: wait.ForLog("started"),
+		},
+		Started: true,
+	}
+	require.NoError(t, consumer.Customize(&req))
+
+	ctr, err := testcontainers.GenericContainer(ctx, req)
+	if ctr != nil {
+		t.Cleanup(func() {
+			require.NoError(t, ctr.Terminate(context.Background()))
+		})
+	}
+	require.NoError(t, err)
+
+	t.Run("match", func(t *testing.T) {
+		r, err := logs.Expect(ctx, ctr, regexp.MustCompile(`user=\w+ logged in`), 10*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, logs.StreamStderr, r.Stream)
+		require.Equal(t, "user=admin logged in", r.Line)
+		require.Equal(t, ctr.GetContainerID(), r.ContainerID)
+		require.False(t, r.Time.IsZero())
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		_, err := logs.Expect(ctx, ctr, regexp.MustCompile(`panic`), time.Second)
+		require.ErrorIs(t, err, logs.ErrNoMatch)
+	})
+
+	t.Run("expect-not", func(t *testing.T) {
+		require.NoError(t, logs.ExpectNot(ctx, ctr, regexp.MustCompile(`panic`), time.Second))
+		require.Error(t, logs.ExpectNot(ctx, ctr, regexp.MustCompile(`started`), time.Second))
+	})
+
+	t.Run("consumer", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			return len(records) >= 2
+		}, 5*time.Second, 100*time.Millisecond)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		require.Equal(t, "started", records[0].Line)
+		require.Equal(t, logs.StreamStdout, records[0].Stream)
+		require.Equal(t, ctr.GetContainerID(), records[0].ContainerID)
+	})
+}
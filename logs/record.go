@@ -0,0 +1,62 @@
+// Package logs provides a structured log consumer for the containers, and utilities to assert on their logs.
+package logs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// StreamStdout is the stream of the records written to the standard output of the container.
+	StreamStdout = "stdout"
+	// StreamStderr is the stream of the records written to the standard error of the container.
+	StreamStderr = "stderr"
+)
+
+// Record is a structured log line of a container.
+type Record struct {
+	// Time is the time of the line, from the Docker timestamp of the line if any,
+	// or the time the line was received otherwise.
+	Time time.Time `json:"timestamp"`
+	// Stream is the stream of the line, StreamStdout or StreamStderr.
+	Stream string `json:"stream"`
+	// ContainerID is the ID of the container writing the line.
+	ContainerID string `json:"containerID"`
+	// Line is the content of the line, without the timestamp and the trailing newline.
+	// The lines merged into a multiline record are separated by newlines.
+	Line string `json:"line"`
+}
+
+// streamOf returns the stream of the given log type of a testcontainers.Log.
+func streamOf(logType string) string {
+	if logType == testcontainers.StderrLog {
+		return StreamStderr
+	}
+
+	return StreamStdout
+}
+
+// parseLine returns the record of the line, parsing the leading timestamp added by Docker
+// when the logs are requested with timestamps, e.g. 2024-05-01T10:00:00.123456789Z message.
+func parseLine(containerID string, stream string, line string) Record {
+	r := Record{
+		Time:        time.Now(),
+		Stream:      stream,
+		ContainerID: containerID,
+		Line:        strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"),
+	}
+
+	ts, rest, ok := strings.Cut(r.Line, " ")
+	if !ok {
+		return r
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		r.Time = t
+		r.Line = rest
+	}
+
+	return r
+}
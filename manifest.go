@@ -0,0 +1,519 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Manifest is the declarative definition of the containers of a test environment, read by RunManifest
+// from a YAML or JSON file, e.g.:
+//
+//	networks:
+//	  - backend
+//	services:
+//	  db:
+//	    module: postgres
+//	    env:
+//	      POSTGRES_PASSWORD: ${DB_PASSWORD:-secret}
+//	    networks: [backend]
+//	  api:
+//	    image: myorg/api:latest
+//	    ports: ["8080/tcp"]
+//	    env:
+//	      DB_HOST: db
+//	    networks: [backend]
+//	    dependsOn: [db]
+//	    wait:
+//	      http:
+//	        path: /health
+//	        port: 8080/tcp
+//	      timeout: 60s
+type Manifest struct {
+	// Networks are the names of the networks shared by the services.
+	Networks []string `yaml:"networks"`
+	// Services are the containers of the environment, by name.
+	Services map[string]ManifestService `yaml:"services"`
+}
+
+// ManifestService is a container of a manifest, created from an image or from a module.
+type ManifestService struct {
+	// Image is the image of the container. For a module, it overrides its default image.
+	Image string `yaml:"image"`
+	// Module is the name of the module of the container, e.g. postgres, in the module catalog.
+	Module string `yaml:"module"`
+	// Env are the environment variables of the container. The ${VAR} variables are expanded from the
+	// environment of the tests, e.g. to pass the secrets of the CI.
+	Env map[string]string `yaml:"env"`
+	// Ports are the ports to expose, in addition to the ones of the module, e.g. 8080/tcp.
+	Ports []string `yaml:"ports"`
+	// Cmd is the command of the container.
+	Cmd []string `yaml:"cmd"`
+	// Networks are the networks of the manifest the container is attached to.
+	Networks []string `yaml:"networks"`
+	// Aliases are the network aliases of the container, which defaults to the name of the service.
+	Aliases []string `yaml:"aliases"`
+	// DependsOn are the services started before the container.
+	DependsOn []string `yaml:"dependsOn"`
+	// Wait is the condition for the container to be ready, replacing the one of the module if any.
+	Wait *ManifestWait `yaml:"wait"`
+}
+
+// ManifestWait is the condition for a container of a manifest to be ready. All the set conditions must be met.
+type ManifestWait struct {
+	// Log is the log line to wait for.
+	Log string `yaml:"log"`
+	// Occurrence is the number of occurrences of the log line to wait for, which defaults to 1.
+	Occurrence int `yaml:"occurrence"`
+	// Port is the port to wait for, e.g. 5432/tcp.
+	Port string `yaml:"port"`
+	// HTTP is the HTTP endpoint to wait for.
+	HTTP *ManifestHTTPWait `yaml:"http"`
+	// Exec is the command to run in the container until it exits with the code 0.
+	Exec []string `yaml:"exec"`
+	// HealthCheck waits for the health check of the image to be healthy.
+	HealthCheck bool `yaml:"healthCheck"`
+	// Timeout is the timeout of the conditions, e.g. 60s, which defaults to 60 seconds.
+	Timeout string `yaml:"timeout"`
+}
+
+// ManifestHTTPWait is the HTTP endpoint to wait for.
+type ManifestHTTPWait struct {
+	// Path is the path of the endpoint, which defaults to /.
+	Path string `yaml:"path"`
+	// Port is the port of the endpoint, which defaults to the lowest exposed port.
+	Port string `yaml:"port"`
+	// Status is the expected status code, which defaults to 200.
+	Status int `yaml:"status"`
+}
+
+// ManifestModuleRunner runs the module with the given name, e.g. modules.Run of the module catalog.
+type ManifestModuleRunner func(ctx context.Context, name string, opts ...ContainerCustomizer) (Container, error)
+
+// ManifestOption is an option for RunManifest.
+type ManifestOption func(*manifestOptions)
+
+type manifestOptions struct {
+	modules    ManifestModuleRunner
+	customizer map[string][]ContainerCustomizer
+}
+
+// WithManifestModules sets the runner of the services defined with a module, usually modules.Run,
+// importing the packages of the modules used by the manifest to register them:
+//
+//	import (
+//		"github.com/testcontainers/testcontainers-go/modules"
+//		_ "github.com/testcontainers/testcontainers-go/modules/postgres"
+//	)
+//
+//	env, err := testcontainers.RunManifest(ctx, os.DirFS("testdata"), "fixtures.yaml",
+//		testcontainers.WithManifestModules(modules.Run))
+func WithManifestModules(run ManifestModuleRunner) ManifestOption {
+	return func(o *manifestOptions) {
+		o.modules = run
+	}
+}
+
+// WithManifestCustomizers adds options to the container of the service, for the settings
+// that can't be declared in the manifest, e.g. log consumers.
+func WithManifestCustomizers(service string, opts ...ContainerCustomizer) ManifestOption {
+	return func(o *manifestOptions) {
+		o.customizer[service] = append(o.customizer[service], opts...)
+	}
+}
+
+// ManifestEnvironment is the test environment started from a manifest.
+type ManifestEnvironment struct {
+	// Containers are the containers of the services, by name.
+	Containers map[string]Container
+	// Networks are the networks of the manifest, by name.
+	Networks map[string]*DockerNetwork
+
+	// order is the start order of the services
+	order []string
+}
+
+// Terminate terminates the containers, in the reverse order of their start, then removes the networks.
+func (e *ManifestEnvironment) Terminate(ctx context.Context) error {
+	var errs []error
+	for i := len(e.order) - 1; i >= 0; i-- {
+		name := e.order[i]
+		if ctr, ok := e.Containers[name]; ok {
+			if err := ctr.Terminate(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("terminate %s: %w", name, err))
+			}
+		}
+	}
+
+	for name, nw := range e.Networks {
+		if err := nw.Remove(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseManifest reads the manifest from a YAML or JSON document, failing on the unknown fields,
+// and validates it.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var m Manifest
+	if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Validate checks the services and their references to the other services and to the networks.
+func (m *Manifest) Validate() error {
+	if len(m.Services) == 0 {
+		return errors.New("no services")
+	}
+
+	for _, name := range m.serviceNames() {
+		svc := m.Services[name]
+		if svc.Image == "" && svc.Module == "" {
+			return fmt.Errorf("service %s: image or module is required", name)
+		}
+
+		for _, nw := range svc.Networks {
+			if !slices.Contains(m.Networks, nw) {
+				return fmt.Errorf("service %s: undefined network %s", name, nw)
+			}
+		}
+
+		for _, dep := range svc.DependsOn {
+			if _, ok := m.Services[dep]; !ok {
+				return fmt.Errorf("service %s: depends on undefined service %s", name, dep)
+			}
+		}
+
+		if svc.Wait != nil {
+			if _, err := svc.Wait.strategy(); err != nil {
+				return fmt.Errorf("service %s: wait: %w", name, err)
+			}
+		}
+	}
+
+	_, err := m.startOrder()
+	return err
+}
+
+// startOrder returns the services sorted so that each one is started after its dependencies,
+// in the alphabetical order otherwise.
+func (m *Manifest) startOrder() ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(m.Services))
+	order := make([]string, 0, len(m.Services))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		state[name] = visiting
+
+		deps := slices.Clone(m.Services[name].DependsOn)
+		slices.Sort(deps)
+		for _, dep := range deps {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range m.serviceNames() {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// RunManifest starts the test environment defined by the manifest file of fsys, e.g. os.DirFS("testdata"):
+// it creates the networks, then starts the services after their dependencies. If a service fails to start,
+// the started containers and the networks are removed. Terminate the returned environment to tear it down.
+func RunManifest(ctx context.Context, fsys fs.FS, path string, opts ...ManifestOption) (*ManifestEnvironment, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	m, err := ParseManifest(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+
+	return m.Run(ctx, opts...)
+}
+
+// Run starts the test environment defined by the manifest. See RunManifest.
+func (m *Manifest) Run(ctx context.Context, opts ...ManifestOption) (*ManifestEnvironment, error) {
+	options := manifestOptions{customizer: map[string][]ContainerCustomizer{}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	order, err := m.startOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	env := &ManifestEnvironment{
+		Containers: make(map[string]Container, len(m.Services)),
+		Networks:   make(map[string]*DockerNetwork, len(m.Networks)),
+	}
+
+	// tear down what was started if a step fails
+	fail := func(err error) (*ManifestEnvironment, error) {
+		if termErr := env.Terminate(context.Background()); termErr != nil {
+			err = errors.Join(err, termErr)
+		}
+		return nil, err
+	}
+
+	for _, name := range m.Networks {
+		nw, err := newManifestNetwork(ctx)
+		if err != nil {
+			return fail(fmt.Errorf("create network %s: %w", name, err))
+		}
+		env.Networks[name] = nw
+	}
+
+	for _, name := range order {
+		svc := m.Services[name]
+
+		customizers, err := svc.customizers(name, env.Networks)
+		if err != nil {
+			return fail(fmt.Errorf("service %s: %w", name, err))
+		}
+		customizers = append(customizers, options.customizer[name]...)
+
+		ctr, err := svc.run(ctx, options.modules, customizers)
+		if ctr != nil {
+			env.Containers[name] = ctr
+			env.order = append(env.order, name)
+		}
+		if err != nil {
+			return fail(fmt.Errorf("service %s: %w", name, err))
+		}
+	}
+
+	return env, nil
+}
+
+// newManifestNetwork creates a network of a manifest, with a unique name.
+func newManifestNetwork(ctx context.Context) (*DockerNetwork, error) {
+	//nolint:staticcheck
+	nw, err := GenericNetwork(ctx, GenericNetworkRequest{
+		NetworkRequest: NetworkRequest{
+			Driver: "bridge",
+			Name:   uuid.NewString(),
+			Labels: GenericLabels(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nw.(*DockerNetwork), nil
+}
+
+// run creates and starts the container of the service.
+func (s ManifestService) run(ctx context.Context, modules ManifestModuleRunner, customizers []ContainerCustomizer) (Container, error) {
+	if s.Module != "" {
+		if modules == nil {
+			return nil, fmt.Errorf("module %s: no module runner, use WithManifestModules", s.Module)
+		}
+
+		ctr, err := modules(ctx, s.Module, customizers...)
+		if err != nil {
+			return ctr, fmt.Errorf("run module %s: %w", s.Module, err)
+		}
+		return ctr, nil
+	}
+
+	req := GenericContainerRequest{Started: true}
+	for _, opt := range customizers {
+		if err := opt.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	return GenericContainer(ctx, req)
+}
+
+// customizers returns the options creating the container of the service, named name,
+// attached to the given networks of the manifest.
+func (s ManifestService) customizers(name string, networks map[string]*DockerNetwork) ([]ContainerCustomizer, error) {
+	var opts []ContainerCustomizer
+
+	if s.Image != "" {
+		opts = append(opts, WithImage(s.Image))
+	}
+
+	if len(s.Env) > 0 {
+		env := make(map[string]string, len(s.Env))
+		for key, value := range s.Env {
+			expanded, err := expandEnvValue(value, false, os.LookupEnv)
+			if err != nil {
+				return nil, fmt.Errorf("env %s: %w", key, err)
+			}
+			env[key] = expanded
+		}
+		opts = append(opts, WithEnv(env))
+	}
+
+	aliases := s.Aliases
+	if len(aliases) == 0 {
+		aliases = []string{name}
+	}
+
+	opts = append(opts, CustomizeRequestOption(func(req *GenericContainerRequest) error {
+		req.ExposedPorts = append(req.ExposedPorts, s.Ports...)
+
+		if len(s.Cmd) > 0 {
+			req.Cmd = s.Cmd
+		}
+
+		for _, nw := range s.Networks {
+			dockerName := networks[nw].Name
+			req.Networks = append(req.Networks, dockerName)
+
+			if req.NetworkAliases == nil {
+				req.NetworkAliases = make(map[string][]string)
+			}
+			req.NetworkAliases[dockerName] = append(req.NetworkAliases[dockerName], aliases...)
+		}
+
+		return nil
+	}))
+
+	if s.Wait != nil {
+		strategy, err := s.Wait.strategy()
+		if err != nil {
+			return nil, fmt.Errorf("wait: %w", err)
+		}
+
+		opts = append(opts, CustomizeRequestOption(func(req *GenericContainerRequest) error {
+			req.WaitingFor = strategy
+			return nil
+		}))
+	}
+
+	return opts, nil
+}
+
+// strategy returns the wait strategy of the conditions.
+func (w *ManifestWait) strategy() (wait.Strategy, error) {
+	timeout := 60 * time.Second
+	if w.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(w.Timeout); err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+	}
+
+	var strategies []wait.Strategy
+
+	if w.Log != "" {
+		occurrence := w.Occurrence
+		if occurrence < 1 {
+			occurrence = 1
+		}
+		strategies = append(strategies, wait.ForLog(w.Log).WithOccurrence(occurrence))
+	}
+
+	if w.Port != "" {
+		port, err := nat.NewPort(nat.SplitProtoPort(w.Port))
+		if err != nil {
+			return nil, fmt.Errorf("port: %w", err)
+		}
+		strategies = append(strategies, wait.ForListeningPort(port))
+	}
+
+	if w.HTTP != nil {
+		path := w.HTTP.Path
+		if path == "" {
+			path = "/"
+		}
+
+		strategy := wait.ForHTTP(path)
+		if w.HTTP.Port != "" {
+			port, err := nat.NewPort(nat.SplitProtoPort(w.HTTP.Port))
+			if err != nil {
+				return nil, fmt.Errorf("http port: %w", err)
+			}
+			strategy = strategy.WithPort(port)
+		}
+
+		if status := w.HTTP.Status; status != 0 {
+			strategy = strategy.WithStatusCodeMatcher(func(actual int) bool {
+				return actual == status
+			})
+		}
+
+		strategies = append(strategies, strategy)
+	}
+
+	if len(w.Exec) > 0 {
+		strategies = append(strategies, wait.ForExec(w.Exec))
+	}
+
+	if w.HealthCheck {
+		strategies = append(strategies, wait.ForHealthCheck())
+	}
+
+	if len(strategies) == 0 {
+		return nil, errors.New("no condition")
+	}
+
+	return wait.ForAll(strategies...).WithDeadline(timeout), nil
+}
+
+// serviceNames returns the names of the services, sorted.
+func (m *Manifest) serviceNames() []string {
+	names := make([]string, 0, len(m.Services))
+	for name := range m.Services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
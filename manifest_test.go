@@ -0,0 +1,252 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifest(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		m, err := ParseManifest(strings.NewReader(`
+networks: [backend]
+services:
+  db:
+    module: postgres
+    networks: [backend]
+  api:
+    image: api:latest
+    ports: ["8080/tcp"]
+    networks: [backend]
+    dependsOn: [db]
+    wait:
+      http:
+        path: /health
+        port: 8080/tcp
+      timeout: 30s
+`))
+		require.NoError(t, err)
+		require.Equal(t, []string{"backend"}, m.Networks)
+		require.Equal(t, "postgres", m.Services["db"].Module)
+		require.Equal(t, []string{"db"}, m.Services["api"].DependsOn)
+		require.Equal(t, "/health", m.Services["api"].Wait.HTTP.Path)
+		require.Equal(t, "30s", m.Services["api"].Wait.Timeout)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		m, err := ParseManifest(strings.NewReader(`{
+  "services": {
+    "web": {"image": "nginx:alpine", "ports": ["80/tcp"], "wait": {"port": "80/tcp"}}
+  }
+}`))
+		require.NoError(t, err)
+		require.Equal(t, "nginx:alpine", m.Services["web"].Image)
+		require.Equal(t, []string{"80/tcp"}, m.Services["web"].Ports)
+	})
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "empty",
+			manifest: "",
+			err:      "no services",
+		},
+		{
+			name:     "unknown-field",
+			manifest: "services:\n  web:\n    image: nginx\n    volumes: [data]\n",
+			err:      "field volumes not found",
+		},
+		{
+			name:     "no-image",
+			manifest: "services:\n  web:\n    ports: [80/tcp]\n",
+			err:      "service web: image or module is required",
+		},
+		{
+			name:     "undefined-network",
+			manifest: "services:\n  web:\n    image: nginx\n    networks: [backend]\n",
+			err:      "service web: undefined network backend",
+		},
+		{
+			name:     "undefined-dependency",
+			manifest: "services:\n  web:\n    image: nginx\n    dependsOn: [db]\n",
+			err:      "service web: depends on undefined service db",
+		},
+		{
+			name:     "invalid-wait",
+			manifest: "services:\n  web:\n    image: nginx\n    wait:\n      timeout: 30s\n",
+			err:      "service web: wait: no condition",
+		},
+		{
+			name:     "cycle",
+			manifest: "services:\n  a:\n    image: nginx\n    dependsOn: [b]\n  b:\n    image: nginx\n    dependsOn: [a]\n",
+			err:      "dependency cycle: a -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest(strings.NewReader(tt.manifest))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestManifestStartOrder(t *testing.T) {
+	m := &Manifest{
+		Services: map[string]ManifestService{
+			"api":    {Image: "api", DependsOn: []string{"db", "cache"}},
+			"cache":  {Image: "redis"},
+			"db":     {Module: "postgres"},
+			"worker": {Image: "worker", DependsOn: []string{"api"}},
+			"admin":  {Image: "admin"},
+		},
+	}
+
+	order, err := m.startOrder()
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "cache", "db", "api", "worker"}, order)
+}
+
+func TestManifestWaitStrategy(t *testing.T) {
+	t.Run("conditions", func(t *testing.T) {
+		w := &ManifestWait{
+			Log:         "ready",
+			Port:        "5432/tcp",
+			HTTP:        &ManifestHTTPWait{Port: "8080/tcp", Status: http.StatusNoContent},
+			Exec:        []string{"true"},
+			HealthCheck: true,
+		}
+
+		strategy, err := w.strategy()
+		require.NoError(t, err)
+		require.NotNil(t, strategy)
+	})
+
+	t.Run("invalid-timeout", func(t *testing.T) {
+		_, err := (&ManifestWait{Log: "ready", Timeout: "soon"}).strategy()
+		require.ErrorContains(t, err, "timeout")
+	})
+
+	t.Run("invalid-port", func(t *testing.T) {
+		_, err := (&ManifestWait{Port: "db/tcp"}).strategy()
+		require.ErrorContains(t, err, "port")
+	})
+
+	t.Run("no-condition", func(t *testing.T) {
+		_, err := (&ManifestWait{}).strategy()
+		require.EqualError(t, err, "no condition")
+	})
+}
+
+func TestManifestServiceCustomizers(t *testing.T) {
+	t.Setenv("MANIFEST_TEST_PASSWORD", "s3cr3t")
+
+	svc := ManifestService{
+		Image: "api:latest",
+		Env: map[string]string{
+			"PASSWORD": "${MANIFEST_TEST_PASSWORD}",
+			"USER":     "${MANIFEST_TEST_USER:-admin}",
+		},
+		Ports:    []string{"8080/tcp"},
+		Cmd:      []string{"serve"},
+		Networks: []string{"backend"},
+		Wait:     &ManifestWait{Port: "8080/tcp"},
+	}
+
+	opts, err := svc.customizers("api", map[string]*DockerNetwork{"backend": {Name: "tc-backend"}})
+	require.NoError(t, err)
+
+	req := GenericContainerRequest{}
+	for _, opt := range opts {
+		require.NoError(t, opt.Customize(&req))
+	}
+
+	require.Equal(t, "api:latest", req.Image)
+	require.Equal(t, map[string]string{"PASSWORD": "s3cr3t", "USER": "admin"}, req.Env)
+	require.Equal(t, []string{"8080/tcp"}, req.ExposedPorts)
+	require.Equal(t, []string{"serve"}, req.Cmd)
+	require.Equal(t, []string{"tc-backend"}, req.Networks)
+	require.Equal(t, map[string][]string{"tc-backend": {"api"}}, req.NetworkAliases)
+	require.NotNil(t, req.WaitingFor)
+}
+
+func TestManifestServiceRunModule(t *testing.T) {
+	ctx := context.Background()
+	svc := ManifestService{Module: "postgres"}
+
+	t.Run("no-runner", func(t *testing.T) {
+		_, err := svc.run(ctx, nil, nil)
+		require.ErrorContains(t, err, "no module runner")
+	})
+
+	t.Run("runner", func(t *testing.T) {
+		var name string
+		runner := func(_ context.Context, n string, _ ...ContainerCustomizer) (Container, error) {
+			name = n
+			return &moduleTestContainer{}, nil
+		}
+
+		ctr, err := svc.run(ctx, runner, nil)
+		require.NoError(t, err)
+		require.NotNil(t, ctr)
+		require.Equal(t, "postgres", name)
+	})
+
+	t.Run("runner-error", func(t *testing.T) {
+		errRun := errors.New("run failed")
+		runner := func(context.Context, string, ...ContainerCustomizer) (Container, error) {
+			return nil, errRun
+		}
+
+		_, err := svc.run(ctx, runner, nil)
+		require.ErrorIs(t, err, errRun)
+	})
+}
+
+func TestRunManifest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing-file", func(t *testing.T) {
+		_, err := RunManifest(ctx, fstest.MapFS{}, "fixtures.yaml")
+		require.ErrorContains(t, err, "read manifest")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fsys := fstest.MapFS{"fixtures.yaml": {Data: []byte("services: {}\n")}}
+
+		_, err := RunManifest(ctx, fsys, "fixtures.yaml")
+		require.ErrorContains(t, err, "parse manifest fixtures.yaml: no services")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		env, err := RunManifest(ctx, os.DirFS("testdata/manifest"), "fixtures.yaml")
+		if env != nil {
+			t.Cleanup(func() {
+				require.NoError(t, env.Terminate(context.Background()))
+			})
+		}
+		require.NoError(t, err)
+		require.Len(t, env.Networks, 1)
+		require.Contains(t, env.Containers, "web")
+		require.Contains(t, env.Containers, "client")
+
+		// the client reaches the web server by its service name
+		code, reader, err := env.Containers["client"].Exec(ctx, []string{"wget", "-q", "-O", "-", "http://web"})
+		require.NoError(t, err)
+		require.Zero(t, code)
+
+		out, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Contains(t, string(out), "Welcome to nginx")
+	})
+}
@@ -0,0 +1,80 @@
+package testcontainers
+
+import (
+	"strings"
+	"testing"
+)
+
+// imageMatrix is the configuration of ForEachImage.
+type imageMatrix struct {
+	sequential bool
+}
+
+// ImageMatrixOption is an option for ForEachImage.
+type ImageMatrixOption func(*imageMatrix)
+
+// MatrixSequential runs the subtests of the images one after the other, instead of in parallel,
+// e.g. for images too heavy to run together.
+func MatrixSequential() ImageMatrixOption {
+	return func(m *imageMatrix) {
+		m.sequential = true
+	}
+}
+
+// ForEachImage runs fn in a subtest for each image, e.g. the tags of a module image, to test the compatibility
+// with several versions of a service. The subtests are named after the images, with the slashes replaced,
+// e.g. TestCompat/postgres:16-alpine, so one version can be selected with go test -run. They run in parallel,
+// as the containers of the images are independent, unless MatrixSequential is set, and within the limit
+// of the -parallel flag of go test. Combine it with WithReuseByName and ImageReuseName to reuse the container
+// of each image across the runs, e.g. while developing.
+func ForEachImage(t *testing.T, images []string, fn func(t *testing.T, img string), opts ...ImageMatrixOption) {
+	t.Helper()
+
+	m := imageMatrix{}
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	if len(images) == 0 {
+		t.Fatal("no image")
+	}
+
+	seen := make(map[string]bool, len(images))
+	for _, img := range images {
+		if img == "" {
+			t.Fatal("empty image")
+		}
+		if seen[img] {
+			t.Fatalf("duplicate image %s", img)
+		}
+		seen[img] = true
+	}
+
+	for _, img := range images {
+		t.Run(imageSubtestName(img), func(t *testing.T) {
+			if !m.sequential {
+				t.Parallel()
+			}
+
+			fn(t, img)
+		})
+	}
+}
+
+// imageSubtestName returns the name of the subtest of the image, replacing the slashes,
+// which separate the levels of the subtests in the -run flag of go test.
+func imageSubtestName(img string) string {
+	return strings.ReplaceAll(img, "/", "_")
+}
+
+// ImageReuseName returns a valid container name for the image, starting with the given prefix,
+// e.g. compat-postgres-16-alpine for the compat prefix and the postgres:16-alpine image,
+// to reuse the container of each image of ForEachImage with WithReuseByName.
+func ImageReuseName(prefix string, img string) string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(strings.NewReplacer(":", "-", "/", "-", "@", "-").Replace(img), "-"), "-._")
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "-" + name
+}
@@ -0,0 +1,50 @@
+package testcontainers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachImage(t *testing.T) {
+	var mu sync.Mutex
+	var images []string
+
+	t.Run("matrix", func(t *testing.T) {
+		ForEachImage(t, []string{"postgres:15-alpine", "docker.io/postgres:16-alpine"}, func(t *testing.T, img string) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			images = append(images, img)
+			require.Contains(t, []string{"TestForEachImage/matrix/postgres:15-alpine", "TestForEachImage/matrix/docker.io_postgres:16-alpine"}, t.Name())
+		})
+	})
+
+	// the parallel subtests are done when their parent is
+	require.ElementsMatch(t, []string{"postgres:15-alpine", "docker.io/postgres:16-alpine"}, images)
+}
+
+func TestImageReuseName(t *testing.T) {
+	require.Equal(t, "compat-postgres-16-alpine", ImageReuseName("compat", "postgres:16-alpine"))
+	require.Equal(t, "docker.io-library-redis-7", ImageReuseName("", "docker.io/library/redis:7"))
+}
+
+func TestForEachImage_Docker(t *testing.T) {
+	// forEachImage {
+	ForEachImage(t, []string{"redis:6-alpine", "redis:7-alpine"}, func(t *testing.T, img string) {
+		ctx := context.Background()
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        img,
+				ExposedPorts: []string{"6379/tcp"},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+	})
+	// }
+}
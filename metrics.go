@@ -0,0 +1,183 @@
+package testcontainers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ContainerMetrics holds the durations of the startup phases of a container
+// created in the test session.
+type ContainerMetrics struct {
+	ContainerID string
+	Image       string
+	Name        string
+
+	// Pull is the time spent pulling, or building, the image of the container.
+	// It's zero if the image was already present.
+	Pull time.Duration
+
+	// Create is the time spent creating the container, including the creation lifecycle hooks.
+	Create time.Duration
+
+	// Start is the time spent starting the container, including the start lifecycle hooks,
+	// but not the time spent waiting for the container to be ready.
+	Start time.Duration
+
+	// Wait is the time spent waiting for the container to be ready, using its wait strategy.
+	Wait time.Duration
+}
+
+// Total returns the sum of the durations of all the startup phases of the container.
+func (m ContainerMetrics) Total() time.Duration {
+	return m.Pull + m.Create + m.Start + m.Wait
+}
+
+// The startup phases of a container, passed to the observers registered with ObserveMetrics.
+const (
+	StartupPhasePull   = "pull"
+	StartupPhaseCreate = "create"
+	StartupPhaseStart  = "start"
+	StartupPhaseWait   = "wait"
+	StartupPhaseTotal  = "total"
+)
+
+// MetricsObserver is called with the duration of a startup phase of a container, once the phase completes.
+type MetricsObserver func(phase string, d time.Duration)
+
+// maxContainerMetrics is the number of containers whose metrics are retained, the most recently created ones,
+// so long-lived processes creating many containers don't accumulate them.
+var maxContainerMetrics = 1000
+
+var (
+	containerMetrics     []*ContainerMetrics
+	containerMetricsLock sync.Mutex
+
+	metricsObservers     = map[int]MetricsObserver{}
+	metricsObserversNext int
+	metricsObserversLock sync.RWMutex
+)
+
+// ObserveMetrics registers an observer of the startup phases of the containers created from now on
+// in the test session, e.g. to export them as a histogram. The pull and create phases are observed
+// once the container is created, and the start, wait and total phases each time the container
+// is started successfully. It returns a function to unregister the observer.
+func ObserveMetrics(observer MetricsObserver) func() {
+	metricsObserversLock.Lock()
+	defer metricsObserversLock.Unlock()
+
+	id := metricsObserversNext
+	metricsObserversNext++
+	metricsObservers[id] = observer
+
+	return func() {
+		metricsObserversLock.Lock()
+		defer metricsObserversLock.Unlock()
+
+		delete(metricsObservers, id)
+	}
+}
+
+// notifyMetricsObservers calls the registered observers with the duration of the phase.
+func notifyMetricsObservers(phase string, d time.Duration) {
+	metricsObserversLock.RLock()
+	defer metricsObserversLock.RUnlock()
+
+	for _, observer := range metricsObservers {
+		observer(phase, d)
+	}
+}
+
+// recordContainerMetrics registers the metrics of a new container, returning the record to be
+// updated with the durations of the following phases. The metrics of the oldest container are
+// dropped once maxContainerMetrics containers are retained.
+func recordContainerMetrics(m ContainerMetrics) *ContainerMetrics {
+	containerMetricsLock.Lock()
+	if len(containerMetrics) >= maxContainerMetrics {
+		n := copy(containerMetrics, containerMetrics[len(containerMetrics)-maxContainerMetrics+1:])
+		clear(containerMetrics[n:])
+		containerMetrics = containerMetrics[:n]
+	}
+	containerMetrics = append(containerMetrics, &m)
+	containerMetricsLock.Unlock()
+
+	notifyMetricsObservers(StartupPhasePull, m.Pull)
+	notifyMetricsObservers(StartupPhaseCreate, m.Create)
+
+	return &m
+}
+
+// observeContainerStarted notifies the observers of the start, wait and total phases
+// of a container, once it's started and ready.
+func observeContainerStarted(m *ContainerMetrics) {
+	if m == nil {
+		return
+	}
+
+	containerMetricsLock.Lock()
+	started := *m
+	containerMetricsLock.Unlock()
+
+	notifyMetricsObservers(StartupPhaseStart, started.Start)
+	notifyMetricsObservers(StartupPhaseWait, started.Wait)
+	notifyMetricsObservers(StartupPhaseTotal, started.Total())
+}
+
+// updateContainerMetrics applies the update function to the metrics of a container, if any.
+func updateContainerMetrics(m *ContainerMetrics, update func(m *ContainerMetrics)) {
+	if m == nil {
+		return
+	}
+
+	containerMetricsLock.Lock()
+	defer containerMetricsLock.Unlock()
+
+	update(m)
+}
+
+// Metrics returns the startup metrics of the containers created in the test session,
+// sorted by total startup time, slowest first. Only the metrics of the last 1000 containers
+// are retained: use ObserveMetrics to aggregate the phases of all the containers.
+func Metrics() []ContainerMetrics {
+	containerMetricsLock.Lock()
+	metrics := make([]ContainerMetrics, 0, len(containerMetrics))
+	for _, m := range containerMetrics {
+		metrics = append(metrics, *m)
+	}
+	containerMetricsLock.Unlock()
+
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].Total() > metrics[j].Total()
+	})
+
+	return metrics
+}
+
+// WriteMetricsSummary writes a report with the startup metrics of the containers created
+// in the test session, sorted by total startup time, slowest first, see Metrics.
+// Call it at the end of TestMain to find the containers that dominate the test time.
+func WriteMetricsSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "CONTAINER\tIMAGE\tPULL\tCREATE\tSTART\tWAIT\tTOTAL")
+	for _, m := range Metrics() {
+		id := m.ContainerID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			id, m.Image,
+			m.Pull.Round(time.Millisecond),
+			m.Create.Round(time.Millisecond),
+			m.Start.Round(time.Millisecond),
+			m.Wait.Round(time.Millisecond),
+			m.Total().Round(time.Millisecond),
+		)
+	}
+
+	return tw.Flush()
+}
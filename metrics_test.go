@@ -0,0 +1,126 @@
+package testcontainers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	containerMetricsLock.Lock()
+	previous := containerMetrics
+	containerMetrics = nil
+	containerMetricsLock.Unlock()
+	t.Cleanup(func() {
+		containerMetricsLock.Lock()
+		containerMetrics = previous
+		containerMetricsLock.Unlock()
+	})
+
+	fast := recordContainerMetrics(ContainerMetrics{
+		ContainerID: "0123456789abcdef",
+		Image:       "nginx:alpine",
+		Create:      100 * time.Millisecond,
+	})
+	slow := recordContainerMetrics(ContainerMetrics{
+		ContainerID: "fedcba9876543210",
+		Image:       "postgres:16",
+		Pull:        2 * time.Second,
+		Create:      200 * time.Millisecond,
+	})
+
+	updateContainerMetrics(fast, func(m *ContainerMetrics) {
+		m.Start = 300 * time.Millisecond
+		m.Wait = 500 * time.Millisecond
+	})
+	updateContainerMetrics(slow, func(m *ContainerMetrics) {
+		m.Start = 400 * time.Millisecond
+		m.Wait = 3 * time.Second
+	})
+	// containers not created in this test session are not recorded
+	updateContainerMetrics(nil, func(m *ContainerMetrics) {
+		t.Fatal("no metrics to update")
+	})
+
+	metrics := Metrics()
+	require.Len(t, metrics, 2)
+	require.Equal(t, "postgres:16", metrics[0].Image)
+	require.Equal(t, 5600*time.Millisecond, metrics[0].Total())
+	require.Equal(t, "nginx:alpine", metrics[1].Image)
+	require.Equal(t, 900*time.Millisecond, metrics[1].Total())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetricsSummary(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"CONTAINER", "IMAGE", "PULL", "CREATE", "START", "WAIT", "TOTAL"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"fedcba987654", "postgres:16", "2s", "200ms", "400ms", "3s", "5.6s"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"0123456789ab", "nginx:alpine", "0s", "100ms", "300ms", "500ms", "900ms"}, strings.Fields(lines[2]))
+}
+
+func TestObserveMetrics(t *testing.T) {
+	containerMetricsLock.Lock()
+	previous := containerMetrics
+	containerMetrics = nil
+	containerMetricsLock.Unlock()
+	t.Cleanup(func() {
+		containerMetricsLock.Lock()
+		containerMetrics = previous
+		containerMetricsLock.Unlock()
+	})
+
+	observed := map[string][]time.Duration{}
+	unregister := ObserveMetrics(func(phase string, d time.Duration) {
+		observed[phase] = append(observed[phase], d)
+	})
+
+	m := recordContainerMetrics(ContainerMetrics{
+		ContainerID: "0123456789abcdef",
+		Image:       "nginx:alpine",
+		Create:      100 * time.Millisecond,
+	})
+	require.Equal(t, map[string][]time.Duration{
+		StartupPhasePull:   {0},
+		StartupPhaseCreate: {100 * time.Millisecond},
+	}, observed)
+
+	updateContainerMetrics(m, func(m *ContainerMetrics) {
+		m.Start = 300 * time.Millisecond
+		m.Wait = 500 * time.Millisecond
+	})
+	observeContainerStarted(m)
+	require.Equal(t, []time.Duration{300 * time.Millisecond}, observed[StartupPhaseStart])
+	require.Equal(t, []time.Duration{500 * time.Millisecond}, observed[StartupPhaseWait])
+	require.Equal(t, []time.Duration{900 * time.Millisecond}, observed[StartupPhaseTotal])
+
+	// each phase is observed once, when it completes
+	unregister()
+	observeContainerStarted(m)
+	require.Len(t, observed[StartupPhaseTotal], 1)
+}
+
+func TestMetrics_retention(t *testing.T) {
+	containerMetricsLock.Lock()
+	previous, previousMax := containerMetrics, maxContainerMetrics
+	containerMetrics, maxContainerMetrics = nil, 2
+	containerMetricsLock.Unlock()
+	t.Cleanup(func() {
+		containerMetricsLock.Lock()
+		containerMetrics, maxContainerMetrics = previous, previousMax
+		containerMetricsLock.Unlock()
+	})
+
+	recordContainerMetrics(ContainerMetrics{Image: "first", Create: 300 * time.Millisecond})
+	recordContainerMetrics(ContainerMetrics{Image: "second", Create: 200 * time.Millisecond})
+	recordContainerMetrics(ContainerMetrics{Image: "third", Create: 100 * time.Millisecond})
+
+	// the oldest container is dropped
+	metrics := Metrics()
+	require.Len(t, metrics, 2)
+	require.Equal(t, "second", metrics[0].Image)
+	require.Equal(t, "third", metrics[1].Image)
+}
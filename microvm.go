@@ -0,0 +1,40 @@
+package testcontainers
+
+// The defaults of the microVM provider, for firecracker-containerd.
+const (
+	defaultMicroVMRuntime     = "aws.firecracker"
+	defaultMicroVMSnapshotter = "devmapper"
+)
+
+// NewMicroVMProvider returns the experimental provider running the containers as microVMs, for the environments
+// whose security policy forbids sharing the kernel of the host with the containers, e.g. in CI. The containers
+// are run with the nerdctl CLI, which must be in the PATH, using a containerd runtime that launches each container
+// in its own microVM, like the aws.firecracker runtime of firecracker-containerd, which is the default.
+//
+// The runtime and the snapshotter, which must provide block devices to the microVMs, are configured with
+// the microvm.runtime and microvm.snapshotter properties, or the TESTCONTAINERS_MICROVM_RUNTIME and
+// TESTCONTAINERS_MICROVM_SNAPSHOTTER environment variables.
+//
+// The container requests are the same as with the NerdctlProvider, except that the microVMs don't share the
+// filesystem of the host, so the files of the requests and the bind mounts are not supported, and the containers
+// can't be paused. See Supports to detect the capabilities of the provider.
+func NewMicroVMProvider(opts ...GenericProviderOption) (*NerdctlProvider, error) {
+	p, err := NewNerdctlProvider(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.microVM = true
+
+	p.runtime = p.config.MicroVMRuntime
+	if p.runtime == "" {
+		p.runtime = defaultMicroVMRuntime
+	}
+
+	p.snapshotter = p.config.MicroVMSnapshotter
+	if p.snapshotter == "" {
+		p.snapshotter = defaultMicroVMSnapshotter
+	}
+
+	return p, nil
+}
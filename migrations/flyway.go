@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// DefaultFlywayImage is the image of Flyway used by RunFlyway.
+	DefaultFlywayImage = "flyway/flyway:10.17.3-alpine"
+
+	flywayDir = "/flyway/sql"
+)
+
+// FlywayResult is the result of the migrate command of Flyway.
+type FlywayResult struct {
+	// Database is the name of the database.
+	Database string `json:"database"`
+	// SchemaName is the schema of the schema history table.
+	SchemaName string `json:"schemaName"`
+	// InitialSchemaVersion is the version of the schema before the migrations, empty for an empty schema.
+	InitialSchemaVersion string `json:"initialSchemaVersion"`
+	// TargetSchemaVersion is the version of the schema after the migrations, empty if no migration was applied.
+	TargetSchemaVersion string `json:"targetSchemaVersion"`
+	// MigrationsExecuted is the number of applied migrations.
+	MigrationsExecuted int `json:"migrationsExecuted"`
+	// Migrations are the applied migrations, in their order.
+	Migrations []FlywayMigration `json:"migrations"`
+	// Warnings are the warnings of Flyway, e.g. about an unsupported version of the database.
+	Warnings []string `json:"warnings"`
+	// FlywayVersion is the version of Flyway.
+	FlywayVersion string `json:"flywayVersion"`
+}
+
+// FlywayMigration is a migration applied by Flyway.
+type FlywayMigration struct {
+	// Category is the category of the migration, Versioned or Repeatable.
+	Category string `json:"category"`
+	// Version is the version of the migration, empty for a repeatable migration.
+	Version string `json:"version"`
+	// Description is the description of the migration, from its file name.
+	Description string `json:"description"`
+	// Type is the type of the migration, e.g. SQL.
+	Type string `json:"type"`
+	// Filepath is the path of the migration in the container.
+	Filepath string `json:"filepath"`
+	// ExecutionTime is the duration of the migration, in milliseconds.
+	ExecutionTime int `json:"executionTime"`
+}
+
+// flywayOutput is the JSON output of Flyway, with its result or its error.
+type flywayOutput struct {
+	FlywayResult
+	Error *struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	} `json:"error"`
+}
+
+// RunFlyway applies the migrations of the file system, e.g. an embed.FS of V1__init.sql-like files, to the database
+// with the migrate command of Flyway, in a container removed once it exits. The database must be a Postgres, MySQL
+// or SQL Server database. It returns the result of the migrations, or an error with the message of Flyway.
+func RunFlyway(ctx context.Context, db testcontainers.ConnectionSource, migrations fs.FS, opts ...Option) (*FlywayResult, error) {
+	settings := defaultOptions(DefaultFlywayImage)
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	t, err := resolveTarget(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	jdbcURL, err := t.jdbcURL()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := []string{
+		"-url=" + jdbcURL,
+		"-user=" + t.details.Username,
+		"-password=" + t.details.Password,
+		"-locations=filesystem:" + flywayDir,
+		"-connectRetries=10",
+		"-outputType=json",
+		"migrate",
+	}
+
+	logs, code, err := t.run(ctx, settings, cmd, flywayDir, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("flyway: %w", err)
+	}
+
+	result, err := parseFlywayOutput(logs)
+	if err != nil {
+		if code != 0 {
+			return nil, fmt.Errorf("flyway: %w", exitError(code, logs))
+		}
+		return nil, fmt.Errorf("flyway: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseFlywayOutput returns the result of the JSON output of Flyway, starting on its own line
+// after the logs, or the error of Flyway.
+func parseFlywayOutput(logs string) (*FlywayResult, error) {
+	start := strings.Index(logs, "\n{")
+	if strings.HasPrefix(logs, "{") {
+		start = 0
+	} else if start < 0 {
+		return nil, errors.New("no JSON output")
+	} else {
+		start++
+	}
+
+	var out flywayOutput
+	if err := json.NewDecoder(strings.NewReader(logs[start:])).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode output: %w", err)
+	}
+
+	if out.Error != nil {
+		return nil, fmt.Errorf("%s: %s", out.Error.ErrorCode, out.Error.Message)
+	}
+
+	return &out.FlywayResult, nil
+}
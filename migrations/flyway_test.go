@@ -0,0 +1,40 @@
+package migrations_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/migrations"
+)
+
+func TestRunFlyway(t *testing.T) {
+	ctx := context.Background()
+	pg := runPostgres(t)
+
+	// runFlyway {
+	result, err := migrations.RunFlyway(ctx, pg, os.DirFS("testdata/flyway"))
+	// }
+	require.NoError(t, err)
+	require.Equal(t, 2, result.MigrationsExecuted)
+	require.Equal(t, "2", result.TargetSchemaVersion)
+	require.Len(t, result.Migrations, 2)
+	require.Equal(t, "create users", result.Migrations[0].Description)
+
+	t.Run("up-to-date", func(t *testing.T) {
+		result, err := migrations.RunFlyway(ctx, pg, os.DirFS("testdata/flyway"))
+		require.NoError(t, err)
+		require.Zero(t, result.MigrationsExecuted)
+		require.Equal(t, "2", result.InitialSchemaVersion)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		broken := fstest.MapFS{"V1__broken.sql": {Data: []byte("CREATE TABL broken;")}}
+
+		_, err := migrations.RunFlyway(ctx, runPostgres(t), broken)
+		require.ErrorContains(t, err, "V1__broken.sql")
+	})
+}
@@ -0,0 +1,102 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// DefaultLiquibaseImage is the image of Liquibase used by RunLiquibase.
+	DefaultLiquibaseImage = "liquibase/liquibase:4.29.2"
+
+	liquibaseDir = "/liquibase/changelog"
+)
+
+// LiquibaseResult is the result of the update command of Liquibase, from its update summary.
+type LiquibaseResult struct {
+	// Run is the number of change sets run by the update.
+	Run int
+	// PreviouslyRun is the number of change sets run by a previous update.
+	PreviouslyRun int
+	// FilteredOut is the number of change sets filtered out, e.g. by their contexts or labels.
+	FilteredOut int
+	// Total is the number of change sets of the changelog.
+	Total int
+	// Output is the output of Liquibase.
+	Output string
+}
+
+// summaryLine matches the lines of the update summary of Liquibase, e.g. "Run:   2".
+var summaryLine = regexp.MustCompile(`(?m)^(Run|Previously run|Filtered out|Total change sets):\s+(\d+)\s*$`)
+
+// RunLiquibase applies the changelog file of the file system, e.g. changelog.xml in an embed.FS, to the database
+// with the update command of Liquibase, in a container removed once it exits. The changelog can include the other
+// files of the file system. The database must be a Postgres, MySQL or SQL Server database. It returns the update summary,
+// or an error with the output of Liquibase.
+func RunLiquibase(ctx context.Context, db testcontainers.ConnectionSource, changelogs fs.FS, changelogFile string, opts ...Option) (*LiquibaseResult, error) {
+	settings := defaultOptions(DefaultLiquibaseImage)
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	t, err := resolveTarget(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	jdbcURL, err := t.jdbcURL()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.details.Scheme == "mysql" {
+		// the image doesn't provide the JDBC driver of MySQL, for licensing reasons
+		settings.env["INSTALL_MYSQL"] = "true"
+	}
+
+	cmd := []string{
+		"--url=" + jdbcURL,
+		"--username=" + t.details.Username,
+		"--password=" + t.details.Password,
+		"--search-path=" + liquibaseDir,
+		"--changelog-file=" + changelogFile,
+		"update",
+	}
+
+	logs, code, err := t.run(ctx, settings, cmd, liquibaseDir, changelogs)
+	if err != nil {
+		return nil, fmt.Errorf("liquibase: %w", err)
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("liquibase: %w", exitError(code, logs))
+	}
+
+	return parseLiquibaseOutput(logs), nil
+}
+
+// parseLiquibaseOutput returns the result of the update summary of the output of Liquibase.
+func parseLiquibaseOutput(logs string) *LiquibaseResult {
+	result := &LiquibaseResult{Output: logs}
+
+	for _, m := range summaryLine.FindAllStringSubmatch(logs, -1) {
+		n, _ := strconv.Atoi(m[2])
+		switch m[1] {
+		case "Run":
+			result.Run = n
+		case "Previously run":
+			result.PreviouslyRun = n
+		case "Filtered out":
+			result.FilteredOut = n
+		case "Total change sets":
+			result.Total = n
+		}
+	}
+
+	return result
+}
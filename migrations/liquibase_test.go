@@ -0,0 +1,31 @@
+package migrations_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/migrations"
+)
+
+func TestRunLiquibase(t *testing.T) {
+	ctx := context.Background()
+	pg := runPostgres(t)
+
+	// runLiquibase {
+	result, err := migrations.RunLiquibase(ctx, pg, os.DirFS("testdata/liquibase"), "changelog.yaml")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Run)
+	require.Equal(t, 2, result.Total)
+
+	result, err = migrations.RunLiquibase(ctx, pg, os.DirFS("testdata/liquibase"), "changelog.yaml")
+	require.NoError(t, err)
+	require.Zero(t, result.Run)
+	require.Equal(t, 2, result.PreviouslyRun)
+
+	_, err = migrations.RunLiquibase(ctx, pg, os.DirFS("testdata/liquibase"), "missing.yaml")
+	require.ErrorContains(t, err, "liquibase: exit code")
+}
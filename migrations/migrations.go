@@ -0,0 +1,212 @@
+// Package migrations runs the database migration tools, Flyway and Liquibase, in one-shot containers
+// against a database container, returning the results of the migrations, e.g.:
+//
+//	//go:embed testdata/sql
+//	var sql embed.FS
+//
+//	result, err := migrations.RunFlyway(ctx, pgContainer, sql)
+//
+// The database is given as a testcontainers.ConnectionSource, e.g. the container of the postgres module.
+// The migration container shares the network namespace of the database container, so it reaches the database
+// on localhost. A database which isn't a container is reached from the container through the host.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type options struct {
+	image string
+	args  []string
+	env   map[string]string
+}
+
+func defaultOptions(img string) options {
+	return options{
+		image: img,
+		env:   map[string]string{},
+	}
+}
+
+// Option is an option of the migration container.
+type Option func(*options)
+
+// WithImage sets the image of the migration tool, e.g. flyway/flyway:10.17.3-alpine.
+func WithImage(img string) Option {
+	return func(o *options) {
+		o.image = img
+	}
+}
+
+// WithArgs adds arguments to the command of the migration tool, e.g. -schemas=app for Flyway,
+// or --contexts=test for Liquibase.
+func WithArgs(args ...string) Option {
+	return func(o *options) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// WithEnv sets an environment variable of the migration container, e.g. FLYWAY_PLACEHOLDERS_OWNER.
+func WithEnv(key string, value string) Option {
+	return func(o *options) {
+		o.env[key] = value
+	}
+}
+
+// target is the database as seen from the migration container.
+type target struct {
+	details *testcontainers.ConnectionDetails
+	host    string
+	port    string
+	// container is the database container, whose network namespace is shared, if any.
+	container testcontainers.Container
+}
+
+// resolveTarget returns the address of the database from the migration container: localhost and the container port
+// of the database, if it's a container, or the host and the port of the connection details, reached through the host.
+func resolveTarget(ctx context.Context, db testcontainers.ConnectionSource) (target, error) {
+	details, err := db.ConnectionDetails(ctx)
+	if err != nil {
+		return target{}, fmt.Errorf("connection details: %w", err)
+	}
+
+	ctr, ok := db.(testcontainers.Container)
+	if !ok {
+		return target{details: details, host: testcontainers.HostInternal, port: details.Port}, nil
+	}
+
+	port, err := containerPort(ctx, ctr, details.Port)
+	if err != nil {
+		return target{}, err
+	}
+
+	return target{details: details, host: "localhost", port: port, container: ctr}, nil
+}
+
+// containerPort returns the port of the container mapped to the given host port.
+func containerPort(ctx context.Context, ctr testcontainers.Container, mapped string) (string, error) {
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("inspect: %w", err)
+	}
+
+	for port, bindings := range inspect.NetworkSettings.Ports {
+		for _, b := range bindings {
+			if b.HostPort == mapped {
+				return port.Port(), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no container port mapped to %s", mapped)
+}
+
+// jdbcURL returns the JDBC URL of the database of the target, for the Postgres, MySQL and SQL Server schemes.
+func (t target) jdbcURL() (string, error) {
+	address := net.JoinHostPort(t.host, t.port)
+	d := t.details
+
+	switch d.Scheme {
+	case "postgres":
+		u := "jdbc:postgresql://" + address + "/" + d.Database
+		if len(d.Params) > 0 {
+			u += "?" + d.Params.Encode()
+		}
+		return u, nil
+	case "mysql":
+		// MySQL 8 requires the public key of the server to authenticate without TLS
+		params := url.Values{"allowPublicKeyRetrieval": {"true"}, "useSSL": {"false"}}
+		return "jdbc:mysql://" + address + "/" + d.Database + "?" + params.Encode(), nil
+	case "sqlserver":
+		u := "jdbc:sqlserver://" + address + ";encrypt=false;trustServerCertificate=true"
+		if d.Database != "" {
+			u += ";databaseName=" + d.Database
+		}
+		return u, nil
+	}
+
+	return "", fmt.Errorf("unsupported database scheme %q", d.Scheme)
+}
+
+// run runs the migration container until it exits, with the files of the file system copied to dir,
+// returning its logs and its exit code.
+func (t target) run(ctx context.Context, settings options, cmd []string, dir string, source fs.FS) (string, int, error) {
+	if source == nil {
+		return "", 0, errors.New("nil migrations file system")
+	}
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      settings.image,
+			Cmd:        append(cmd, settings.args...),
+			Env:        settings.env,
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	}
+
+	if t.container != nil {
+		id := t.container.GetContainerID()
+		req.HostConfigModifier = func(hc *container.HostConfig) {
+			hc.NetworkMode = container.NetworkMode("container:" + id)
+		}
+	} else {
+		port, err := strconv.Atoi(t.port)
+		if err != nil {
+			return "", 0, fmt.Errorf("port %s: %w", t.port, err)
+		}
+		if err := testcontainers.WithAccessToHost(port)(&req); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if err := testcontainers.WithFS(dir, source)(&req); err != nil {
+		return "", 0, err
+	}
+
+	ctr, err := testcontainers.GenericContainer(ctx, req)
+	if ctr != nil {
+		defer func() {
+			_ = ctr.Terminate(context.Background())
+		}()
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("run %s: %w", settings.image, err)
+	}
+
+	state, err := ctr.State(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("state: %w", err)
+	}
+
+	r, err := ctr.Logs(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("logs: %w", err)
+	}
+	defer r.Close()
+
+	logs, err := io.ReadAll(r)
+	if err != nil {
+		return "", 0, fmt.Errorf("read logs: %w", err)
+	}
+
+	return string(logs), state.ExitCode, nil
+}
+
+// exitError returns the error of a migration container exiting with a non-zero code, with its logs.
+func exitError(code int, logs string) error {
+	return fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(logs))
+}
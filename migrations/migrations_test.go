@@ -0,0 +1,130 @@
+package migrations
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// connectionSource is a database which isn't a container.
+type connectionSource struct {
+	details testcontainers.ConnectionDetails
+}
+
+func (s connectionSource) ConnectionDetails(context.Context) (*testcontainers.ConnectionDetails, error) {
+	return &s.details, nil
+}
+
+func TestResolveTarget(t *testing.T) {
+	tg, err := resolveTarget(context.Background(), connectionSource{details: testcontainers.ConnectionDetails{
+		Scheme: "postgres",
+		Host:   "localhost",
+		Port:   "5432",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, testcontainers.HostInternal, tg.host)
+	require.Equal(t, "5432", tg.port)
+	require.Nil(t, tg.container)
+}
+
+func TestTarget_jdbcURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		details testcontainers.ConnectionDetails
+		want    string
+	}{
+		{
+			name:    "postgres",
+			details: testcontainers.ConnectionDetails{Scheme: "postgres", Database: "app", Params: url.Values{"sslmode": {"disable"}}},
+			want:    "jdbc:postgresql://localhost:5432/app?sslmode=disable",
+		},
+		{
+			name:    "mysql",
+			details: testcontainers.ConnectionDetails{Scheme: "mysql", Database: "app"},
+			want:    "jdbc:mysql://localhost:5432/app?allowPublicKeyRetrieval=true&useSSL=false",
+		},
+		{
+			name:    "sqlserver",
+			details: testcontainers.ConnectionDetails{Scheme: "sqlserver", Database: "app"},
+			want:    "jdbc:sqlserver://localhost:5432;encrypt=false;trustServerCertificate=true;databaseName=app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := target{details: &tt.details, host: "localhost", port: "5432"}.jdbcURL()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := target{details: &testcontainers.ConnectionDetails{Scheme: "redis"}}.jdbcURL()
+		require.ErrorContains(t, err, `unsupported database scheme "redis"`)
+	})
+}
+
+func TestParseFlywayOutput(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := parseFlywayOutput(`WARNING: Storing migrations in 'sql' is not recommended
+{
+  "initialSchemaVersion": null,
+  "targetSchemaVersion": "2",
+  "schemaName": "public",
+  "migrations": [
+    {"category": "Versioned", "version": "1", "description": "create users", "type": "SQL", "filepath": "/flyway/sql/V1__create_users.sql", "executionTime": 12},
+    {"category": "Versioned", "version": "2", "description": "seed users", "type": "SQL", "filepath": "/flyway/sql/V2__seed_users.sql", "executionTime": 3}
+  ],
+  "migrationsExecuted": 2,
+  "success": true,
+  "flywayVersion": "10.17.3",
+  "database": "app",
+  "warnings": [],
+  "operation": "migrate"
+}
+`)
+		require.NoError(t, err)
+		require.Equal(t, "2", result.TargetSchemaVersion)
+		require.Empty(t, result.InitialSchemaVersion)
+		require.Equal(t, 2, result.MigrationsExecuted)
+		require.Equal(t, "app", result.Database)
+		require.Equal(t, FlywayMigration{
+			Category:      "Versioned",
+			Version:       "2",
+			Description:   "seed users",
+			Type:          "SQL",
+			Filepath:      "/flyway/sql/V2__seed_users.sql",
+			ExecutionTime: 3,
+		}, result.Migrations[1])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := parseFlywayOutput(`{"error": {"errorCode": "FAULT", "message": "Migration V2__seed_users.sql failed"}}`)
+		require.EqualError(t, err, "FAULT: Migration V2__seed_users.sql failed")
+	})
+
+	t.Run("no-json", func(t *testing.T) {
+		_, err := parseFlywayOutput("ERROR: Unable to connect to the database\n")
+		require.EqualError(t, err, "no JSON output")
+	})
+}
+
+func TestParseLiquibaseOutput(t *testing.T) {
+	output := `Running Changeset: changelog.yaml::create-users::testcontainers
+
+UPDATE SUMMARY
+Run:                          2
+Previously run:               1
+Filtered out:                 0
+-------------------------------
+Total change sets:            3
+
+Liquibase command 'update' was executed successfully.
+`
+
+	require.Equal(t, &LiquibaseResult{Run: 2, PreviouslyRun: 1, Total: 3, Output: output}, parseLiquibaseOutput(output))
+}
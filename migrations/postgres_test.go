@@ -0,0 +1,56 @@
+package migrations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// postgresContainer is a Postgres container providing its connection details.
+type postgresContainer struct {
+	testcontainers.Container
+}
+
+func (c postgresContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "postgres", "5432/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = "test"
+	details.Password = "test"
+	details.Database = "app"
+	details.Params.Set("sslmode", "disable")
+
+	return details, nil
+}
+
+func runPostgres(t *testing.T) postgresContainer {
+	t.Helper()
+
+	ctr, err := testcontainers.GenericContainer(context.Background(), testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:16-alpine",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "test",
+				"POSTGRES_PASSWORD": "test",
+				"POSTGRES_DB":       "app",
+			},
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	})
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+
+	return postgresContainer{Container: ctr}
+}
@@ -0,0 +1,4 @@
+--liquibase formatted sql
+
+--changeset testcontainers:seed-users
+INSERT INTO users (name) VALUES ('alice'), ('bob');
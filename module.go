@@ -0,0 +1,93 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ClientFunc creates the typed client of the service running in the container, e.g. a connection pool.
+type ClientFunc[T any] func(ctx context.Context, ctr Container) (T, error)
+
+// Module is a container exposing a typed client of its service, so the modules return a handle
+// with strongly-typed accessors instead of connection strings. The client is created on the first call
+// to Client, shared by the following calls, and closed when the container is terminated.
+type Module[T any] struct {
+	Container
+
+	connect ClientFunc[T]
+	close   func(client T) error
+
+	mtx       sync.Mutex
+	client    T
+	connected bool
+}
+
+// NewModule returns the module handle of the container, creating the client with connect
+// and closing it with close, which can be nil if the client doesn't need to be closed.
+func NewModule[T any](ctr Container, connect ClientFunc[T], close func(client T) error) *Module[T] {
+	return &Module[T]{
+		Container: ctr,
+		connect:   connect,
+		close:     close,
+	}
+}
+
+// Client returns the client of the service, creating it on the first call.
+// If the creation fails, the next call tries again.
+func (m *Module[T]) Client(ctx context.Context) (T, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.connected {
+		return m.client, nil
+	}
+
+	client, err := m.connect(ctx, m.Container)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("create client: %w", err)
+	}
+
+	m.client = client
+	m.connected = true
+
+	return client, nil
+}
+
+// Terminate closes the client, if it was created, then terminates the container with the options.
+func (m *Module[T]) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	var errs []error
+	if err := m.closeClient(); err != nil {
+		errs = append(errs, fmt.Errorf("close client: %w", err))
+	}
+
+	if err := m.Container.Terminate(ctx, opts...); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// closeClient closes the client, if it was created, so the next call to Client creates a new one.
+func (m *Module[T]) closeClient() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if !m.connected {
+		return nil
+	}
+
+	client := m.client
+
+	var zero T
+	m.client = zero
+	m.connected = false
+
+	if m.close == nil {
+		return nil
+	}
+
+	return m.close(client)
+}
@@ -0,0 +1,109 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// moduleTestContainer is a container recording its termination.
+type moduleTestContainer struct {
+	Container
+	terminated bool
+}
+
+func (c *moduleTestContainer) Terminate(context.Context, ...TerminateOption) error {
+	c.terminated = true
+	return nil
+}
+
+// moduleTestClient is a client recording if it was closed.
+type moduleTestClient struct {
+	closed bool
+}
+
+func TestModule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("client-is-shared", func(t *testing.T) {
+		ctr := &moduleTestContainer{}
+
+		var connects int
+		m := NewModule(ctr, func(_ context.Context, c Container) (*moduleTestClient, error) {
+			require.Same(t, ctr, c)
+			connects++
+			return &moduleTestClient{}, nil
+		}, func(client *moduleTestClient) error {
+			client.closed = true
+			return nil
+		})
+
+		client, err := m.Client(ctx)
+		require.NoError(t, err)
+
+		again, err := m.Client(ctx)
+		require.NoError(t, err)
+		require.Same(t, client, again)
+		require.Equal(t, 1, connects)
+
+		require.NoError(t, m.Terminate(ctx))
+		require.True(t, client.closed)
+		require.True(t, ctr.terminated)
+	})
+
+	t.Run("failed-connect-is-retried", func(t *testing.T) {
+		errConnect := errors.New("connection refused")
+
+		var connects int
+		m := NewModule(&moduleTestContainer{}, func(context.Context, Container) (*moduleTestClient, error) {
+			connects++
+			if connects == 1 {
+				return nil, errConnect
+			}
+			return &moduleTestClient{}, nil
+		}, nil)
+
+		_, err := m.Client(ctx)
+		require.ErrorIs(t, err, errConnect)
+
+		client, err := m.Client(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+		require.Equal(t, 2, connects)
+	})
+
+	t.Run("terminate-without-client", func(t *testing.T) {
+		ctr := &moduleTestContainer{}
+
+		m := NewModule(ctr, func(context.Context, Container) (*moduleTestClient, error) {
+			t.Fatal("the client must not be created")
+			return nil, nil
+		}, func(*moduleTestClient) error {
+			t.Fatal("the client must not be closed")
+			return nil
+		})
+
+		require.NoError(t, m.Terminate(ctx))
+		require.True(t, ctr.terminated)
+	})
+
+	t.Run("close-error", func(t *testing.T) {
+		errClose := errors.New("close failed")
+		ctr := &moduleTestContainer{}
+
+		m := NewModule(ctr, func(context.Context, Container) (*moduleTestClient, error) {
+			return &moduleTestClient{}, nil
+		}, func(*moduleTestClient) error {
+			return errClose
+		})
+
+		_, err := m.Client(ctx)
+		require.NoError(t, err)
+
+		err = m.Terminate(ctx)
+		require.ErrorIs(t, err, errClose)
+		require.True(t, ctr.terminated, "the container is terminated even if the client fails to close")
+	})
+}
@@ -57,7 +57,7 @@ func GenerateFiles(moduleDir string, moduleName string, funcMap template.FuncMap
 
 	tcModuleCtx := tcModule.(context.TestcontainersModule)
 	if tcModuleCtx.IsModule {
-		templates = append(templates, "examples_test.go")
+		templates = append(templates, "examples_test.go", "register.go")
 	}
 
 	for _, tmpl := range templates {
@@ -350,6 +350,7 @@ func TestGenerateModule(t *testing.T) {
 	assertExamplesTestContent(t, module, filepath.Join(generatedTemplatesDir, "examples_test.go"))
 	assertModuleTestContent(t, module, filepath.Join(generatedTemplatesDir, moduleNameLower+"_test.go"))
 	assertModuleContent(t, module, filepath.Join(generatedTemplatesDir, moduleNameLower+".go"))
+	assertRegisterContent(t, module, filepath.Join(generatedTemplatesDir, "register.go"))
 	assertGoModContent(t, module, originalConfig.Extra.LatestVersion, filepath.Join(generatedTemplatesDir, "go.mod"))
 	assertMakefileContent(t, module, filepath.Join(generatedTemplatesDir, "Makefile"))
 	assertMkdocsNavItems(t, module, originalConfig, tmpCtx)
@@ -433,6 +434,21 @@ func assertModuleContent(t *testing.T, module context.TestcontainersModule, exam
 	assert.Equal(t, "\treturn &"+containerName+"{Container: container}, nil", data[36])
 }
 
+// assert content of the file registering the module in the catalog
+func assertRegisterContent(t *testing.T, module context.TestcontainersModule, registerFile string) {
+	content, err := os.ReadFile(registerFile)
+	require.NoError(t, err)
+
+	lower := module.Lower()
+
+	data := sanitiseContent(content)
+	assert.Equal(t, "package "+lower, data[0])
+	assert.Equal(t, "import \"github.com/testcontainers/testcontainers-go/modules\"", data[2])
+	assert.Equal(t, "\t\tName:         \""+lower+"\",", data[6])
+	assert.Equal(t, "\t\tDefaultImage: \""+module.Image+"\",", data[7])
+	assert.Equal(t, "\t\tRun:          modules.Runner("+module.Entrypoint()+"),", data[8])
+}
+
 // assert content GitHub workflow for the module
 func assertModuleGithubWorkflowContent(t *testing.T, moduleWorkflowFile string) {
 	content, err := os.ReadFile(moduleWorkflowFile)
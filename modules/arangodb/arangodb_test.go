@@ -0,0 +1,66 @@
+package arangodb_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/arangodb"
+)
+
+const testImage = "arangodb:3.11"
+
+func TestArangoDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := arangodb.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	// connectionString {
+	connStr, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(connStr, "http://root:root@"))
+	require.Equal(t, "_system", container.Database())
+
+	resp, err := http.Get(connStr + "/_api/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestArangoDBWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	// withInitScripts {
+	container, err := arangodb.Run(ctx, testImage,
+		arangodb.WithUsername("app_user"),
+		arangodb.WithPassword("app_password"),
+		arangodb.WithDatabase("app"),
+		arangodb.WithInitScripts(filepath.Join("testdata", "init.js")),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(connStr, "http://app_user:app_password@"))
+
+	resp, err := http.Get(connStr + "/_db/app/_api/document/people/ada")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var doc struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	require.Equal(t, "Ada Lovelace", doc.Name)
+}
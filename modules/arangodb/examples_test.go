@@ -0,0 +1,41 @@
+package arangodb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/arangodb"
+)
+
+func ExampleRun() {
+	// runArangoDBContainer {
+	ctx := context.Background()
+
+	arangodbContainer, err := arangodb.Run(ctx, "arangodb:3.11",
+		arangodb.WithUsername("app_user"),
+		arangodb.WithPassword("app_password"),
+		arangodb.WithDatabase("app"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := arangodbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := arangodbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,85 @@
+package arangodb
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultUsername = "root"
+	defaultPassword = "root"
+	defaultDatabase = "_system"
+)
+
+type options struct {
+	username    string
+	password    string
+	database    string
+	initScripts []string
+}
+
+func defaultOptions() options {
+	return options{
+		username: defaultUsername,
+		password: defaultPassword,
+		database: defaultDatabase,
+	}
+}
+
+// rootPassword returns the password of the root user, which is the password of the user
+// only if the user is root.
+func (o options) rootPassword() string {
+	if o.username == defaultUsername {
+		return o.password
+	}
+
+	return defaultPassword
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the ArangoDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithUsername sets the user to be created once the container is ready, with read and write access to the database.
+// It is used in conjunction with WithPassword to set a user and its password.
+// If it is not specified, then the root user will be used.
+func WithUsername(username string) Option {
+	return func(o *options) {
+		if username == "" {
+			username = defaultUsername
+		}
+
+		o.username = username
+	}
+}
+
+// WithPassword sets the password of the user set with WithUsername, or the password of the root user
+// if no user is set. The default password is "root".
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithDatabase sets the database to be created once the container is ready.
+// If it is not specified, then the _system database will be used.
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithInitScripts sets the JavaScript files to be run, in order, with arangosh in the database
+// once the container is ready, using the credentials of the user.
+func WithInitScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.initScripts = append(o.initScripts, scripts...)
+	}
+}
@@ -0,0 +1,18 @@
+package arangodb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "arangodb",
+		DefaultImage: "arangodb:3.11",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithDatabase":    WithDatabase,
+			"WithInitScripts": WithInitScripts,
+			"WithPassword":    WithPassword,
+			"WithUsername":    WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
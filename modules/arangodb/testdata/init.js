@@ -0,0 +1,2 @@
+db._create("people");
+db.people.save({ _key: "ada", name: "Ada Lovelace" });
@@ -3,16 +3,23 @@ package artemis
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
 	defaultBrokerPort = "61616/tcp"
 	defaultHTTPPort   = "8161/tcp"
+
+	// artemisCmd runs the artemis CLI of the broker instance, connecting to the broker
+	// with the administrator credentials.
+	artemisCmd = `/var/lib/artemis-instance/bin/artemis "$@" --user "$ARTEMIS_USER" --password "$ARTEMIS_PASSWORD" --silent`
 )
 
 // Container represents the Artemis container type used in the module.
@@ -38,6 +45,17 @@ func (c *Container) BrokerEndpoint(ctx context.Context) (string, error) {
 	return c.PortEndpoint(ctx, nat.Port(defaultBrokerPort), "")
 }
 
+// AMQPURL returns the AMQP URL of the combined protocols endpoint, e.g. amqp://localhost:61616.
+func (c *Container) AMQPURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, nat.Port(defaultBrokerPort), "amqp")
+}
+
+// OpenWireURL returns the OpenWire URL of the combined protocols endpoint, e.g. tcp://localhost:61616,
+// to be used as the broker URL of an ActiveMQ connection factory.
+func (c *Container) OpenWireURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, nat.Port(defaultBrokerPort), "tcp")
+}
+
 // ConsoleURL returns the URL for the management console.
 func (c *Container) ConsoleURL(ctx context.Context) (string, error) {
 	host, err := c.PortEndpoint(ctx, nat.Port(defaultHTTPPort), "")
@@ -78,6 +96,71 @@ func WithExtraArgs(args string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithQueues creates the given anycast queues, each one in an address with the same name,
+// once the broker is ready. Messages sent to a queue are delivered to a single consumer.
+func WithQueues(queues ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		for _, queue := range queues {
+			withArtemisCommand(req, "queue", "create", "--name", queue, "--address", queue,
+				"--anycast", "--durable", "--auto-create-address", "--preserve-on-no-consumers")
+		}
+
+		return nil
+	}
+}
+
+// WithTopics creates the given multicast addresses once the broker is ready.
+// Messages sent to a topic are delivered to all its subscribers.
+func WithTopics(topics ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		for _, topic := range topics {
+			withArtemisCommand(req, "address", "create", "--name", topic, "--multicast")
+		}
+
+		return nil
+	}
+}
+
+// WithUser creates a user with the given password and roles once the broker is ready.
+// If no role is given, the user gets the amq role, which has all the permissions in the default
+// security settings of the broker, like the administrator.
+func WithUser(user, password string, roles ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if len(roles) == 0 {
+			roles = []string{"amq"}
+		}
+
+		withArtemisCommand(req, "user", "add", "--user-command-user", user,
+			"--user-command-password", password, "--role", strings.Join(roles, ","))
+
+		return nil
+	}
+}
+
+// withArtemisCommand adds a post readies hook running the artemis CLI with the given arguments,
+// failing if the command does not succeed.
+func withArtemisCommand(req *testcontainers.GenericContainerRequest, args ...string) {
+	cmd := append([]string{"/bin/sh", "-c", artemisCmd, "artemis"}, args...)
+
+	req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				code, r, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+				if err != nil {
+					return fmt.Errorf("artemis %s: %w", strings.Join(args[:2], " "), err)
+				}
+
+				if code != 0 {
+					output, _ := io.ReadAll(r)
+					return fmt.Errorf("artemis %s: exit code %d: %s", strings.Join(args[:2], " "), code, strings.TrimSpace(string(output)))
+				}
+
+				return nil
+			},
+		},
+	})
+}
+
 // Deprecated: use Run instead.
 // RunContainer creates an instance of the Artemis container type.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*Container, error) {
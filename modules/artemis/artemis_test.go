@@ -60,6 +60,40 @@ func TestArtemis(t *testing.T) {
 				expectQueue(t, container, "ArgsTestQueue")
 			},
 		},
+		{
+			name: "WithQueuesAndTopics",
+			opts: []testcontainers.ContainerCustomizer{
+				// withQueuesAndTopics {
+				artemis.WithQueues("orders", "invoices"),
+				artemis.WithTopics("events"),
+				// }
+			},
+			user: "artemis",
+			pass: "artemis",
+			hook: func(t *testing.T, container *artemis.Container) {
+				expectQueue(t, container, "orders")
+				expectQueue(t, container, "invoices")
+				expectAddress(t, container, "events")
+			},
+		},
+		{
+			name: "WithUser",
+			opts: []testcontainers.ContainerCustomizer{
+				// withUser {
+				artemis.WithUser("app", "secret"),
+				// }
+			},
+			user: "artemis",
+			pass: "artemis",
+			hook: func(t *testing.T, container *artemis.Container) {
+				host, err := container.BrokerEndpoint(context.Background())
+				require.NoError(t, err)
+
+				conn, err := stomp.Dial("tcp", host, stomp.ConnOpt.Login("app", "secret"))
+				require.NoError(t, err, "failed to connect as the new user")
+				require.NoError(t, conn.Disconnect())
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -115,6 +149,18 @@ func TestArtemis(t *testing.T) {
 				require.Equal(t, "test", string(msg.Body), "received unexpected message")
 			}
 
+			// amqpURL {
+			amqpURL, err := container.AMQPURL(ctx)
+			// }
+			require.NoError(t, err)
+			require.Equal(t, "amqp://"+host, amqpURL)
+
+			// openWireURL {
+			openWireURL, err := container.OpenWireURL(ctx)
+			// }
+			require.NoError(t, err)
+			require.Equal(t, "tcp://"+host, openWireURL)
+
 			if test.hook != nil {
 				test.hook(t, container)
 			}
@@ -125,16 +171,28 @@ func TestArtemis(t *testing.T) {
 func expectQueue(t *testing.T, container *artemis.Container, queueName string) {
 	t.Helper()
 
+	require.Containsf(t, readBrokerAttribute(t, container, "QueueNames"), queueName, "should contain queue")
+}
+
+func expectAddress(t *testing.T, container *artemis.Container, address string) {
+	t.Helper()
+
+	require.Containsf(t, readBrokerAttribute(t, container, "AddressNames"), address, "should contain address")
+}
+
+func readBrokerAttribute(t *testing.T, container *artemis.Container, attribute string) []string {
+	t.Helper()
+
 	u, err := container.ConsoleURL(context.Background())
 	require.NoError(t, err)
 
-	r, err := http.Get(u + `/jolokia/read/org.apache.activemq.artemis:broker="0.0.0.0"/QueueNames`)
-	require.NoError(t, err, "failed to request QueueNames")
+	r, err := http.Get(u + `/jolokia/read/org.apache.activemq.artemis:broker="0.0.0.0"/` + attribute)
+	require.NoError(t, err, "failed to request %s", attribute)
 	defer r.Body.Close()
 
 	var res struct{ Value []string }
 	err = json.NewDecoder(r.Body).Decode(&res)
-	require.NoError(t, err, "failed to decode QueueNames response")
+	require.NoError(t, err, "failed to decode %s response", attribute)
 
-	require.Containsf(t, res.Value, queueName, "should contain queue")
+	return res.Value
 }
@@ -0,0 +1,20 @@
+package artemis
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "artemis",
+		DefaultImage: "docker.io/apache/activemq-artemis:2.30.0-alpine",
+		ExposedPorts: []string{defaultBrokerPort, defaultHTTPPort},
+		Options: map[string]any{
+			"WithAnonymousLogin": WithAnonymousLogin,
+			"WithCredentials":    WithCredentials,
+			"WithExtraArgs":      WithExtraArgs,
+			"WithQueues":         WithQueues,
+			"WithTopics":         WithTopics,
+			"WithUser":           WithUser,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,15 @@
+package azurite
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "azurite",
+		DefaultImage: "mcr.microsoft.com/azure-storage/azurite:3.28.0",
+		ExposedPorts: []string{BlobPort, QueuePort, TablePort},
+		Options: map[string]any{
+			"WithInMemoryPersistence": WithInMemoryPersistence,
+		},
+		Run: modules.Runner(Run),
+	})
+}
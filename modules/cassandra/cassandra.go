@@ -2,6 +2,7 @@ package cassandra
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
@@ -9,11 +10,19 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
 	port = nat.Port("9042/tcp")
+
+	// cqlshrcPath is the path of the cqlsh config file of the root user, which holds
+	// the credentials used by cqlsh in the container when the password authentication is enabled.
+	cqlshrcPath = "/root/.cassandra/cqlshrc"
+
+	// enableAuthCmd enables the password authentication in the config file before starting Cassandra.
+	enableAuthCmd = `sed -i 's/AllowAllAuthenticator/PasswordAuthenticator/' /etc/cassandra/cassandra.yaml && exec docker-entrypoint.sh "$@"`
 )
 
 // CassandraContainer represents the Cassandra container type used in the module
@@ -107,12 +116,42 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	if settings.authEnabled() {
+		if len(genericContainerReq.Cmd) == 0 {
+			// overriding the entrypoint discards the command of the image
+			genericContainerReq.Cmd = []string{"cassandra", "-f"}
+		}
+		genericContainerReq.Entrypoint = []string{"/bin/sh", "-c", enableAuthCmd, "sh"}
+
+		// cqlsh uses the default superuser until the user is created
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(cqlshrc(defaultUsername, defaultPassword)),
+			ContainerFilePath: cqlshrcPath,
+			FileMode:          0o600,
+		})
+	}
+
+	// the user and the keyspace must be created before running the init scripts
+	genericContainerReq.LifecycleHooks = append([]testcontainers.ContainerLifecycleHooks{
+		{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return bootstrap(ctx, c, settings)
+				},
+			},
+		},
+	}, genericContainerReq.LifecycleHooks...)
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -120,3 +159,57 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 
 	return &CassandraContainer{Container: container}, nil
 }
+
+// bootstrap creates the superuser and the keyspace set with the options, using cqlsh in the container.
+func bootstrap(ctx context.Context, c testcontainers.Container, settings options) error {
+	if settings.authEnabled() {
+		username, password := settings.credentials()
+
+		stmt := fmt.Sprintf("CREATE ROLE IF NOT EXISTS %s WITH PASSWORD = %s AND SUPERUSER = true AND LOGIN = true", quote(username), quote(password))
+		if username == defaultUsername {
+			stmt = "ALTER ROLE cassandra WITH PASSWORD = " + quote(password)
+		}
+
+		if err := execCQL(ctx, c, stmt); err != nil {
+			return fmt.Errorf("create user %s: %w", username, err)
+		}
+
+		if err := c.CopyToContainer(ctx, []byte(cqlshrc(username, password)), cqlshrcPath, 0o600); err != nil {
+			return fmt.Errorf("copy cqlshrc: %w", err)
+		}
+	}
+
+	if settings.keyspace != "" {
+		stmt := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH REPLICATION = {'class': 'SimpleStrategy', 'replication_factor': 1}", settings.keyspace)
+		if err := execCQL(ctx, c, stmt); err != nil {
+			return fmt.Errorf("create keyspace %s: %w", settings.keyspace, err)
+		}
+	}
+
+	return nil
+}
+
+// execCQL executes the CQL statement using cqlsh in the container.
+func execCQL(ctx context.Context, c testcontainers.Container, stmt string) error {
+	code, r, err := c.Exec(ctx, []string{"cqlsh", "-e", stmt}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(r)
+		return fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// cqlshrc returns the content of the cqlsh config file with the given credentials.
+func cqlshrc(username string, password string) string {
+	return fmt.Sprintf("[authentication]\nusername = %s\npassword = %s\n", username, password)
+}
+
+// quote returns the CQL string literal of the value.
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -149,3 +149,46 @@ func TestCassandraWithInitScripts(t *testing.T) {
 		assert.Equal(t, Test{Id: 1, Name: "NAME"}, test)
 	})
 }
+
+func TestCassandraWithKeyspaceAndAuth(t *testing.T) {
+	ctx := context.Background()
+
+	// withKeyspaceAndAuth {
+	container, err := cassandra.Run(ctx, "cassandra:4.1.3",
+		cassandra.WithKeyspace("test_keyspace"),
+		cassandra.WithUsername("app_user"),
+		cassandra.WithPassword("app_password"),
+		cassandra.WithInitScripts(filepath.Join("testdata", "init.cql")),
+	)
+	// }
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connectionHost, err := container.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	t.Run("without-credentials", func(t *testing.T) {
+		cluster := gocql.NewCluster(connectionHost)
+		_, err := cluster.CreateSession()
+		require.Error(t, err)
+	})
+
+	t.Run("with-credentials", func(t *testing.T) {
+		cluster := gocql.NewCluster(connectionHost)
+		cluster.Keyspace = "test_keyspace"
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: "app_user", Password: "app_password"}
+
+		session, err := cluster.CreateSession()
+		require.NoError(t, err)
+		defer session.Close()
+
+		var test Test
+		err = session.Query("SELECT id, name FROM test_table WHERE id=1").Scan(&test.Id, &test.Name)
+		require.NoError(t, err)
+		assert.Equal(t, Test{Id: 1, Name: "NAME"}, test)
+	})
+}
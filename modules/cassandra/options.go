@@ -0,0 +1,73 @@
+package cassandra
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// defaultUsername and defaultPassword are the credentials of the default superuser,
+	// created when the password authentication is enabled.
+	defaultUsername = "cassandra"
+	defaultPassword = "cassandra"
+)
+
+type options struct {
+	keyspace string
+	username string
+	password string
+}
+
+// authEnabled returns true if the password authentication must be enabled.
+func (o options) authEnabled() bool {
+	return o.username != "" || o.password != ""
+}
+
+// credentials returns the credentials of the user, using the default superuser ones for those not set.
+func (o options) credentials() (string, string) {
+	username, password := o.username, o.password
+	if username == "" {
+		username = defaultUsername
+	}
+	if password == "" {
+		password = defaultPassword
+	}
+
+	return username, password
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Cassandra container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithKeyspace sets the keyspace to be created once the container is ready, before running the init scripts.
+// The keyspace uses the SimpleStrategy replication with a replication factor of 1.
+func WithKeyspace(keyspace string) Option {
+	return func(o *options) {
+		o.keyspace = keyspace
+	}
+}
+
+// WithUsername enables the password authentication, and sets the username of the superuser
+// to be created once the container is ready. It is used in conjunction with WithPassword.
+// If it is not specified, then the default cassandra superuser will be used.
+func WithUsername(username string) Option {
+	return func(o *options) {
+		o.username = username
+	}
+}
+
+// WithPassword enables the password authentication, and sets the password of the superuser
+// set with WithUsername. If it is not specified, then the default cassandra password will be used.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
@@ -0,0 +1,19 @@
+package cassandra
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "cassandra",
+		DefaultImage: "cassandra:4.1.3",
+		ExposedPorts: []string{string(port)},
+		Options: map[string]any{
+			"WithConfigFile":  WithConfigFile,
+			"WithInitScripts": WithInitScripts,
+			"WithKeyspace":    WithKeyspace,
+			"WithPassword":    WithPassword,
+			"WithUsername":    WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,158 @@
+package ceph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	rgwPort = nat.Port("8080/tcp")
+
+	// s3cmd creates the bucket in $0 in the RADOS Gateway, using the credentials of the demo user.
+	s3cmd = `s3cmd --no-ssl --host=127.0.0.1:8080 --host-bucket=127.0.0.1:8080 --access_key="$CEPH_DEMO_ACCESS_KEY" --secret_key="$CEPH_DEMO_SECRET_KEY" mb "s3://$0"`
+)
+
+// CephContainer represents the Ceph container type used in the module
+type CephContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// AccessKey returns the S3 access key of the demo user.
+func (c *CephContainer) AccessKey() string {
+	return c.settings.accessKey
+}
+
+// SecretKey returns the S3 secret key of the demo user.
+func (c *CephContainer) SecretKey() string {
+	return c.settings.secretKey
+}
+
+// SwiftUser returns the name of the Swift subuser of the demo user, whose key is the secret key.
+func (c *CephContainer) SwiftUser() string {
+	return c.settings.uid + ":swift"
+}
+
+// S3Endpoint returns the URL of the S3 API of the RADOS Gateway, e.g. http://localhost:8080,
+// obtaining the host and exposed port from the container.
+func (c *CephContainer) S3Endpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, rgwPort, "http")
+}
+
+// SwiftAuthURL returns the URL of the Swift v1 authentication endpoint of the RADOS Gateway,
+// e.g. http://localhost:8080/auth/v1.0, obtaining the host and exposed port from the container.
+func (c *CephContainer) SwiftAuthURL(ctx context.Context) (string, error) {
+	endpoint, err := c.S3Endpoint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/auth/v1.0", nil
+}
+
+// Run creates an instance of the Ceph container type, running a single node cluster with
+// the monitor, manager, OSD and RADOS Gateway daemons of the Ceph demo image.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*CephContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Cmd:   []string{"demo"},
+		Env: map[string]string{
+			"MON_IP":              "127.0.0.1",
+			"CEPH_PUBLIC_NETWORK": "0.0.0.0/0",
+			"DEMO_DAEMONS":        "mon,mgr,osd,rgw",
+			"RGW_FRONTEND_PORT":   rgwPort.Port(),
+		},
+		ExposedPorts: []string{string(rgwPort)},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("demo: SUCCESS").WithStartupTimeout(3*time.Minute),
+			wait.ForHTTP("/").WithPort(rgwPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	// the demo user is created by the demo image when the container starts
+	genericContainerReq.Env["CEPH_DEMO_UID"] = settings.uid
+	genericContainerReq.Env["CEPH_DEMO_ACCESS_KEY"] = settings.accessKey
+	genericContainerReq.Env["CEPH_DEMO_SECRET_KEY"] = settings.secretKey
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				return bootstrap(ctx, c, settings)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CephContainer{Container: container, settings: settings}, nil
+}
+
+// bootstrap creates the Swift subuser of the demo user, the users and the buckets.
+func bootstrap(ctx context.Context, c testcontainers.Container, settings options) error {
+	subuser := settings.uid + ":swift"
+	err := execCmd(ctx, c, "create subuser "+subuser,
+		"radosgw-admin", "subuser", "create", "--uid="+settings.uid, "--subuser="+subuser,
+		"--access=full", "--key-type=swift", "--secret="+settings.secretKey)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range settings.users {
+		err := execCmd(ctx, c, "create user "+u.UID,
+			"radosgw-admin", "user", "create", "--uid="+u.UID, "--display-name="+u.UID,
+			"--access-key="+u.AccessKey, "--secret="+u.SecretKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range settings.buckets {
+		if err := execCmd(ctx, c, "create bucket "+b, "sh", "-c", s3cmd, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execCmd runs the command in the container, returning an error with its output if it fails.
+func execCmd(ctx context.Context, c testcontainers.Container, desc string, cmd ...string) error {
+	code, r, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("%s: %w", desc, err)
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(r)
+		return fmt.Errorf("%s: exit code %d: %s", desc, code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package ceph_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules/ceph"
+)
+
+const testImage = "quay.io/ceph/demo:latest-quincy"
+
+func TestCeph(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := ceph.Run(ctx, testImage,
+		// withBucketsAndUsers {
+		ceph.WithCredentials("access", "secret"),
+		ceph.WithBuckets("orders", "invoices"),
+		ceph.WithUsers(ceph.User{UID: "reader", AccessKey: "reader-access", SecretKey: "reader-secret"}),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "access", ctr.AccessKey())
+	require.Equal(t, "secret", ctr.SecretKey())
+
+	t.Run("s3", func(t *testing.T) {
+		// s3Endpoint {
+		endpoint, err := ctr.S3Endpoint(ctx)
+		// }
+		require.NoError(t, err)
+
+		// anonymous requests list no buckets, but are answered by the RADOS Gateway
+		resp, err := http.Get(endpoint)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, string(body), "ListAllMyBucketsResult")
+	})
+
+	t.Run("swift", func(t *testing.T) {
+		// swiftAuthURL {
+		authURL, err := ctr.SwiftAuthURL(ctx)
+		// }
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Auth-User", ctr.SwiftUser())
+		req.Header.Set("X-Auth-Key", ctr.SecretKey())
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		// the buckets of the demo user are the containers of its Swift subuser
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, resp.Header.Get("X-Storage-Url"), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Auth-Token", resp.Header.Get("X-Auth-Token"))
+
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"invoices", "orders"}, strings.Fields(string(body)))
+	})
+
+	t.Run("users", func(t *testing.T) {
+		code, r, err := ctr.Exec(ctx, []string{"radosgw-admin", "user", "info", "--uid=reader"}, tcexec.Multiplexed())
+		require.NoError(t, err)
+
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Zero(t, code)
+		require.Contains(t, string(output), "reader-access")
+	})
+}
@@ -0,0 +1,39 @@
+package ceph_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/ceph"
+)
+
+func ExampleRun() {
+	// runCephContainer {
+	ctx := context.Background()
+
+	cephContainer, err := ceph.Run(ctx, "quay.io/ceph/demo:latest-quincy",
+		ceph.WithBuckets("test-bucket"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := cephContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := cephContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
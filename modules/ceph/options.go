@@ -0,0 +1,72 @@
+package ceph
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultUID       = "demo"
+	defaultAccessKey = "demo"
+	defaultSecretKey = "demo"
+)
+
+// User is a RADOS Gateway user, created once the container is ready.
+type User struct {
+	// UID is the identifier of the user.
+	UID string
+	// AccessKey is the S3 access key of the user.
+	AccessKey string
+	// SecretKey is the S3 secret key of the user.
+	SecretKey string
+}
+
+type options struct {
+	uid       string
+	accessKey string
+	secretKey string
+	buckets   []string
+	users     []User
+}
+
+func defaultOptions() options {
+	return options{
+		uid:       defaultUID,
+		accessKey: defaultAccessKey,
+		secretKey: defaultSecretKey,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Ceph container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithCredentials sets the S3 access and secret keys of the demo user, which is created
+// when the container starts. The default is demo:demo.
+func WithCredentials(accessKey, secretKey string) Option {
+	return func(o *options) {
+		o.accessKey = accessKey
+		o.secretKey = secretKey
+	}
+}
+
+// WithBuckets creates the given buckets, owned by the demo user, once the container is ready.
+func WithBuckets(buckets ...string) Option {
+	return func(o *options) {
+		o.buckets = append(o.buckets, buckets...)
+	}
+}
+
+// WithUsers creates the given users once the container is ready, in addition to the demo user.
+func WithUsers(users ...User) Option {
+	return func(o *options) {
+		o.users = append(o.users, users...)
+	}
+}
@@ -0,0 +1,17 @@
+package ceph
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "ceph",
+		DefaultImage: "quay.io/ceph/demo:latest-quincy",
+		ExposedPorts: []string{string(rgwPort)},
+		Options: map[string]any{
+			"WithBuckets":     WithBuckets,
+			"WithCredentials": WithCredentials,
+			"WithUsers":       WithUsers,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,12 @@
+package chroma
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "chroma",
+		DefaultImage: "chromadb/chroma:0.4.24",
+		ExposedPorts: []string{"8000/tcp"},
+		Run:          modules.Runner(Run),
+	})
+}
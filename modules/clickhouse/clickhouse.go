@@ -77,6 +77,21 @@ func (c *ClickHouseContainer) ConnectionString(ctx context.Context, args ...stri
 	return connectionString, nil
 }
 
+// ConnectionDetails returns the details to connect to the database of the container, using the native port,
+// implementing testcontainers.ConnectionSource.
+func (c *ClickHouseContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "clickhouse", nativePort)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.User
+	details.Password = c.Password
+	details.Database = c.DbName
+
+	return details, nil
+}
+
 // ZookeeperOptions arguments for zookeeper in clickhouse
 type ZookeeperOptions struct {
 	Host, Port string
@@ -0,0 +1,21 @@
+package clickhouse
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "clickhouse",
+		DefaultImage: "clickhouse/clickhouse-server:23.3.8.21-alpine",
+		ExposedPorts: []string{httpPort.Port(), nativePort.Port()},
+		Options: map[string]any{
+			"WithConfigFile":     WithConfigFile,
+			"WithDatabase":       WithDatabase,
+			"WithInitScripts":    WithInitScripts,
+			"WithPassword":       WithPassword,
+			"WithUsername":       WithUsername,
+			"WithYamlConfigFile": WithYamlConfigFile,
+			"WithZookeeper":      WithZookeeper,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -62,6 +62,31 @@ func (c *CockroachDBContainer) ConnectionString(ctx context.Context) (string, er
 	return connString(c.opts, host, port), nil
 }
 
+// ConnectionDetails returns the details to connect to the database of the container, including
+// the client TLS configuration if TLS is enabled, implementing testcontainers.ConnectionSource.
+func (c *CockroachDBContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "postgres", defaultSQLPort)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.opts.User
+	details.Password = c.opts.Password
+	details.Database = c.opts.Database
+	details.Params.Set("sslmode", "disable")
+
+	if c.opts.TLS != nil {
+		details.Params.Set("sslmode", "verify-full")
+
+		details.TLS, err = connTLS(c.opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return details, nil
+}
+
 // TLSConfig returns config necessary to connect to CockroachDB over TLS.
 func (c *CockroachDBContainer) TLSConfig() (*tls.Config, error) {
 	return connTLS(c.opts)
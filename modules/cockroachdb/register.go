@@ -0,0 +1,22 @@
+package cockroachdb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "cockroachdb",
+		DefaultImage: "cockroachdb/cockroach:latest-v23.1",
+		ExposedPorts: []string{
+			defaultSQLPort,
+			defaultAdminPort,
+		},
+		Options: map[string]any{
+			"WithDatabase":  WithDatabase,
+			"WithPassword":  WithPassword,
+			"WithStoreSize": WithStoreSize,
+			"WithTLS":       WithTLS,
+			"WithUser":      WithUser,
+		},
+		Run: modules.Runner(Run),
+	})
+}
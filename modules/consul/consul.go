@@ -1,8 +1,15 @@
 package consul
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -11,6 +18,9 @@ import (
 const (
 	defaultHttpApiPort = "8500"
 	defaultBrokerPort  = "8600"
+
+	// aclConfig enables the ACL system, denying the requests without a token.
+	aclConfig = `{"acl": {"enabled": true, "default_policy": "deny", "enable_token_persistence": true}}`
 )
 
 const (
@@ -21,6 +31,13 @@ const (
 // ConsulContainer represents the Consul container type used in the module.
 type ConsulContainer struct {
 	testcontainers.Container
+	aclToken string
+}
+
+// ACLToken returns the initial management token, formerly known as the master token,
+// created when bootstrapping the ACL system. It's empty if the ACL system is not enabled with WithACL.
+func (c *ConsulContainer) ACLToken() string {
+	return c.aclToken
 }
 
 // ApiEndpoint returns host:port for the HTTP API endpoint.
@@ -68,7 +85,8 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return Run(ctx, "docker.io/hashicorp/consul:1.15", opts...)
 }
 
-// Run creates an instance of the Consul container type
+// Run creates an instance of the Consul container type. It waits for the agent to elect a leader,
+// and then bootstraps the ACL system, stores the keys, and registers the services set with the options.
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*ConsulContainer, error) {
 	containerReq := testcontainers.GenericContainerRequest{
 		ContainerRequest: testcontainers.ContainerRequest{
@@ -81,22 +99,130 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 			Env: map[string]string{},
 			WaitingFor: wait.ForAll(
 				wait.ForLog("Consul agent running!"),
-				wait.ForListeningPort(defaultHttpApiPort+"/tcp"),
+				wait.ForHTTP("/v1/status/leader").
+					WithPort(defaultHttpApiPort+"/tcp").
+					WithResponseMatcher(hasLeader),
 			),
 		},
 		Started: true,
 	}
 
+	settings := defaultOptions()
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&containerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	if settings.acl {
+		containerReq.Files = append(containerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(aclConfig),
+			ContainerFilePath: "/consul/config/acl.json",
+			FileMode:          0o644,
+		})
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, containerReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ConsulContainer{Container: container}, nil
+	c := &ConsulContainer{Container: container}
+
+	if err := c.bootstrap(ctx, settings); err != nil {
+		// return the container so the caller can terminate it
+		return c, err
+	}
+
+	return c, nil
+}
+
+// hasLeader returns true if the response of the leader endpoint contains the address of the leader.
+// The endpoint returns an empty JSON string while there is no leader.
+func hasLeader(body io.Reader) bool {
+	var leader string
+	if err := json.NewDecoder(body).Decode(&leader); err != nil {
+		return false
+	}
+
+	return leader != ""
+}
+
+// bootstrap bootstraps the ACL system, stores the keys sorted by key, and registers the services,
+// using the HTTP API of the agent.
+func (c *ConsulContainer) bootstrap(ctx context.Context, settings options) error {
+	if settings.acl {
+		var token struct {
+			SecretID string
+		}
+		if err := c.apiRequest(ctx, http.MethodPut, "/v1/acl/bootstrap", nil, &token); err != nil {
+			return fmt.Errorf("bootstrap acl: %w", err)
+		}
+		c.aclToken = token.SecretID
+	}
+
+	keys := make([]string, 0, len(settings.kv))
+	for k := range settings.kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path := "/v1/kv/" + strings.TrimPrefix(k, "/")
+		if err := c.apiRequest(ctx, http.MethodPut, path, strings.NewReader(settings.kv[k]), nil); err != nil {
+			return fmt.Errorf("put key %s: %w", k, err)
+		}
+	}
+
+	for _, s := range settings.services {
+		body, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("marshal service %s: %w", s.Name, err)
+		}
+
+		if err := c.apiRequest(ctx, http.MethodPut, "/v1/agent/service/register", bytes.NewReader(body), nil); err != nil {
+			return fmt.Errorf("register service %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// apiRequest sends a request to the HTTP API of the agent, using the ACL token if any,
+// and decodes the JSON response into out if it's not nil.
+func (c *ConsulContainer) apiRequest(ctx context.Context, method string, path string, body io.Reader, out any) error {
+	endpoint, err := c.ApiEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	u := url.URL{Scheme: "http", Host: endpoint, Path: path}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+
+	if c.aclToken != "" {
+		req.Header.Set("X-Consul-Token", c.aclToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
 }
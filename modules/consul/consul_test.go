@@ -71,3 +71,53 @@ func TestConsul(t *testing.T) {
 		})
 	}
 }
+
+func TestConsul_Bootstrap(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := consul.Run(ctx, "docker.io/hashicorp/consul:1.15",
+		consul.WithACL(),
+		consul.WithKV(map[string]string{"config/feature": "enabled"}),
+		consul.WithServices(consul.Service{ID: "api-1", Name: "api", Tags: []string{"v1"}, Port: 8080}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx), "failed to terminate container") })
+
+	token := container.ACLToken()
+	require.NotEmpty(t, token)
+
+	host, err := container.ApiEndpoint(ctx)
+	require.NoError(t, err)
+
+	t.Run("without-token", func(t *testing.T) {
+		cfg := capi.DefaultConfig()
+		cfg.Address = host
+
+		client, err := capi.NewClient(cfg)
+		require.NoError(t, err)
+
+		pair, _, err := client.KV().Get("config/feature", nil)
+		require.NoError(t, err)
+		require.Nil(t, pair)
+	})
+
+	t.Run("with-token", func(t *testing.T) {
+		cfg := capi.DefaultConfig()
+		cfg.Address = host
+		cfg.Token = token
+
+		client, err := capi.NewClient(cfg)
+		require.NoError(t, err)
+
+		pair, _, err := client.KV().Get("config/feature", nil)
+		require.NoError(t, err)
+		require.NotNil(t, pair)
+		require.Equal(t, "enabled", string(pair.Value))
+
+		services, err := client.Agent().Services()
+		require.NoError(t, err)
+		require.Contains(t, services, "api-1")
+		require.Equal(t, "api", services["api-1"].Service)
+		require.Equal(t, 8080, services["api-1"].Port)
+	})
+}
@@ -0,0 +1,64 @@
+package consul
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Service is a service registered in the Consul agent once it's ready.
+// The fields follow the service definition of the Consul agent API.
+type Service struct {
+	ID      string            `json:"ID,omitempty"`
+	Name    string            `json:"Name"`
+	Tags    []string          `json:"Tags,omitempty"`
+	Address string            `json:"Address,omitempty"`
+	Port    int               `json:"Port,omitempty"`
+	Meta    map[string]string `json:"Meta,omitempty"`
+}
+
+type options struct {
+	acl      bool
+	kv       map[string]string
+	services []Service
+}
+
+func defaultOptions() options {
+	return options{
+		kv: map[string]string{},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Consul container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithACL enables the ACL system with a default deny policy, and bootstraps it once the agent is ready.
+// The token created by the bootstrap is available with the ACLToken method.
+func WithACL() Option {
+	return func(o *options) {
+		o.acl = true
+	}
+}
+
+// WithKV sets the keys and values to be stored in the KV store once the agent is ready.
+func WithKV(kv map[string]string) Option {
+	return func(o *options) {
+		for k, v := range kv {
+			o.kv[k] = v
+		}
+	}
+}
+
+// WithServices sets the services to be registered in the agent once it's ready.
+func WithServices(services ...Service) Option {
+	return func(o *options) {
+		o.services = append(o.services, services...)
+	}
+}
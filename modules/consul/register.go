@@ -0,0 +1,23 @@
+package consul
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "consul",
+		DefaultImage: "docker.io/hashicorp/consul:1.15",
+		ExposedPorts: []string{
+			defaultHttpApiPort + "/tcp",
+			defaultBrokerPort + "/tcp",
+			defaultBrokerPort + "/udp",
+		},
+		Options: map[string]any{
+			"WithACL":          WithACL,
+			"WithConfigFile":   WithConfigFile,
+			"WithConfigString": WithConfigString,
+			"WithKV":           WithKV,
+			"WithServices":     WithServices,
+		},
+		Run: modules.Runner(Run),
+	})
+}
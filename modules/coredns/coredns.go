@@ -0,0 +1,216 @@
+package coredns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// DNSPort is the UDP port of the DNS server.
+	DNSPort = "53/udp"
+	// DNSTCPPort is the TCP port of the DNS server.
+	DNSTCPPort = "53/tcp"
+
+	readyPort = "8181/tcp"
+
+	configDir = "/etc/coredns"
+	zonesDir  = configDir + "/zones"
+)
+
+// CoreDNSContainer represents the CoreDNS container type used in the module
+type CoreDNSContainer struct {
+	testcontainers.Container
+	mtx     sync.Mutex
+	serials map[string]uint32
+}
+
+// DNSAddress returns the host and the UDP port of the DNS server from the host, e.g. localhost:32768.
+func (c *CoreDNSContainer) DNSAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, DNSPort, "")
+}
+
+// Resolver returns a resolver querying the DNS server from the host, to check the zones in the tests.
+func (c *CoreDNSContainer) Resolver(ctx context.Context) (*net.Resolver, error) {
+	udpAddress, err := c.PortEndpoint(ctx, DNSPort, "")
+	if err != nil {
+		return nil, fmt.Errorf("udp endpoint: %w", err)
+	}
+
+	tcpAddress, err := c.PortEndpoint(ctx, DNSTCPPort, "")
+	if err != nil {
+		return nil, fmt.Errorf("tcp endpoint: %w", err)
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			var d net.Dialer
+			if strings.HasPrefix(network, "tcp") {
+				return d.DialContext(ctx, network, tcpAddress)
+			}
+			return d.DialContext(ctx, network, udpAddress)
+		},
+	}, nil
+}
+
+// UpdateZone replaces the records of a zone served by the container, e.g. to test the failover of a client
+// when the address of a service changes. CoreDNS reloads the zone within a second.
+func (c *CoreDNSContainer) UpdateZone(ctx context.Context, zone Zone) error {
+	origin := fqdn(zone.Origin)
+
+	c.mtx.Lock()
+	serial, ok := c.serials[origin]
+	if !ok {
+		c.mtx.Unlock()
+		return fmt.Errorf("zone %s not served", origin)
+	}
+	serial++
+	c.serials[origin] = serial
+	c.mtx.Unlock()
+
+	if err := c.CopyToContainer(ctx, zone.zoneFile(serial), zoneFilePath(origin), 0o644); err != nil {
+		return fmt.Errorf("copy zone %s: %w", origin, err)
+	}
+
+	return nil
+}
+
+// WithDNSFromContainer makes the container of the request use the DNS server of the given container,
+// e.g. a CoreDNS container, reached with its IP address, so both containers must share a network,
+// e.g. the default bridge network.
+func WithDNSFromContainer(dnsContainer testcontainers.Container) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		ip, err := dnsContainer.ContainerIP(context.Background())
+		if err != nil {
+			return fmt.Errorf("dns container ip: %w", err)
+		}
+		if ip == "" {
+			return fmt.Errorf("dns container %s has no ip", dnsContainer.GetContainerID())
+		}
+
+		modifier := req.HostConfigModifier
+		req.HostConfigModifier = func(hc *container.HostConfig) {
+			if modifier != nil {
+				modifier(hc)
+			}
+			hc.DNS = append([]string{ip}, hc.DNS...)
+		}
+
+		return nil
+	}
+}
+
+// Run creates an instance of the CoreDNS container type, serving the zones set with the options,
+// and forwarding the other queries to the upstream servers.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*CoreDNSContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{DNSPort, DNSTCPPort, readyPort},
+		Cmd:          []string{"-conf", configDir + "/Corefile"},
+		WaitingFor:   wait.ForHTTP("/ready").WithPort(readyPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	serials := map[string]uint32{}
+	origins := make([]string, 0, len(settings.zones)+len(settings.zoneFiles))
+
+	for _, zone := range settings.zones {
+		origin := fqdn(zone.Origin)
+		if _, ok := serials[origin]; ok {
+			return nil, fmt.Errorf("duplicate zone %s", origin)
+		}
+
+		serials[origin] = 1
+		origins = append(origins, origin)
+
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(zone.zoneFile(1)),
+			ContainerFilePath: zoneFilePath(origin),
+			FileMode:          0o644,
+		})
+	}
+
+	for origin, hostPath := range settings.zoneFiles {
+		if _, ok := serials[origin]; ok {
+			return nil, fmt.Errorf("duplicate zone %s", origin)
+		}
+
+		origins = append(origins, origin)
+
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      hostPath,
+			ContainerFilePath: zoneFilePath(origin),
+			FileMode:          0o644,
+		})
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            strings.NewReader(corefile(origins, settings.forwarders)),
+		ContainerFilePath: configDir + "/Corefile",
+		FileMode:          0o644,
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *CoreDNSContainer
+	if container != nil {
+		c = &CoreDNSContainer{Container: container, serials: serials}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// zoneFilePath returns the path of the zone file of the origin in the container.
+func zoneFilePath(origin string) string {
+	return path.Join(zonesDir, strings.TrimSuffix(origin, ".")+".db")
+}
+
+// corefile returns the configuration of CoreDNS, serving the zones from their files, reloaded every second,
+// and forwarding the other queries to the upstream servers.
+func corefile(origins []string, forwarders []string) string {
+	sorted := append([]string(nil), origins...)
+	sort.Strings(sorted)
+
+	var sb strings.Builder
+	for _, origin := range sorted {
+		fmt.Fprintf(&sb, "%s {\n", origin)
+		fmt.Fprintf(&sb, "    file %s {\n        reload 1s\n    }\n", zoneFilePath(origin))
+		sb.WriteString("    log\n    errors\n}\n\n")
+	}
+
+	sb.WriteString(". {\n")
+	if len(forwarders) > 0 {
+		fmt.Fprintf(&sb, "    forward . %s\n", strings.Join(forwarders, " "))
+	}
+	sb.WriteString("    ready :8181\n    log\n    errors\n}\n")
+
+	return sb.String()
+}
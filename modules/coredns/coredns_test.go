@@ -0,0 +1,95 @@
+package coredns_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/coredns"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "coredns/coredns:1.11.3"
+
+func TestCoreDNS(t *testing.T) {
+	ctx := context.Background()
+
+	// withZones {
+	ctr, err := coredns.Run(ctx, testImage,
+		coredns.WithZones(coredns.Zone{
+			Origin: "example.test",
+			Records: []coredns.Record{
+				coredns.A("api", "10.0.0.1"),
+				coredns.SRV("_http._tcp.api", 10, 5, 8080, "api.example.test."),
+			},
+		}),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// resolver {
+	resolver, err := ctr.Resolver(ctx)
+	require.NoError(t, err)
+
+	addrs, err := resolver.LookupHost(ctx, "api.example.test")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	_, srvs, err := resolver.LookupSRV(ctx, "http", "tcp", "api.example.test")
+	require.NoError(t, err)
+	require.Len(t, srvs, 1)
+	require.Equal(t, uint16(8080), srvs[0].Port)
+	require.Equal(t, "api.example.test.", srvs[0].Target)
+
+	t.Run("update-zone", func(t *testing.T) {
+		// updateZone {
+		err := ctr.UpdateZone(ctx, coredns.Zone{
+			Origin:  "example.test",
+			Records: []coredns.Record{coredns.A("api", "10.0.0.2")},
+		})
+		// }
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			addrs, err := resolver.LookupHost(ctx, "api.example.test")
+			return err == nil && len(addrs) == 1 && addrs[0] == "10.0.0.2"
+		}, 10*time.Second, 500*time.Millisecond)
+
+		require.Error(t, ctr.UpdateZone(ctx, coredns.Zone{Origin: "unknown.test"}))
+	})
+
+	t.Run("dns-from-container", func(t *testing.T) {
+		// withDNSFromContainer {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "alpine:3.20",
+				Cmd:        []string{"sleep", "infinity"},
+				WaitingFor: wait.ForExec([]string{"true"}),
+			},
+			Started: true,
+		}
+		require.NoError(t, coredns.WithDNSFromContainer(ctr).Customize(&req))
+
+		client, err := testcontainers.GenericContainer(ctx, req)
+		// }
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, client.Terminate(ctx)) })
+
+		require.Eventually(t, func() bool {
+			code, r, err := client.Exec(ctx, []string{"nslookup", "-type=a", "api.example.test"})
+			if err != nil || code != 0 {
+				return false
+			}
+
+			out, err := io.ReadAll(r)
+			return err == nil && strings.Contains(string(out), "10.0.0.")
+		}, 10*time.Second, 500*time.Millisecond)
+	})
+}
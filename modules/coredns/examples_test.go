@@ -0,0 +1,47 @@
+package coredns_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/coredns"
+)
+
+func ExampleRun() {
+	// runCoreDNSContainer {
+	ctx := context.Background()
+
+	corednsContainer, err := coredns.Run(ctx, "coredns/coredns:1.11.3",
+		coredns.WithZones(coredns.Zone{
+			Origin:  "example.test",
+			Records: []coredns.Record{coredns.A("api", "10.0.0.1")},
+		}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := corednsContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	resolver, err := corednsContainer.Resolver(ctx)
+	if err != nil {
+		log.Fatalf("failed to get resolver: %s", err) // nolint:gocritic
+	}
+
+	addrs, err := resolver.LookupHost(ctx, "api.example.test")
+	if err != nil {
+		log.Fatalf("failed to lookup host: %s", err)
+	}
+
+	fmt.Println(addrs)
+
+	// Output:
+	// [10.0.0.1]
+}
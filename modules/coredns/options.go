@@ -0,0 +1,53 @@
+package coredns
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	zones      []Zone
+	zoneFiles  map[string]string
+	forwarders []string
+}
+
+func defaultOptions() options {
+	return options{
+		zoneFiles:  map[string]string{},
+		forwarders: []string{"/etc/resolv.conf"},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the CoreDNS container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithZones serves the given zones, generating their zone files.
+func WithZones(zones ...Zone) Option {
+	return func(o *options) {
+		o.zones = append(o.zones, zones...)
+	}
+}
+
+// WithZoneFile serves the zone of the given origin, e.g. example.com, from the zone file on the host,
+// in the format of RFC 1035.
+func WithZoneFile(origin string, path string) Option {
+	return func(o *options) {
+		o.zoneFiles[fqdn(origin)] = path
+	}
+}
+
+// WithForwarders forwards the queries of the names outside the zones to the given upstream servers,
+// e.g. 8.8.8.8. The default is the resolvers of the container, from its /etc/resolv.conf file.
+func WithForwarders(upstreams ...string) Option {
+	return func(o *options) {
+		o.forwarders = upstreams
+	}
+}
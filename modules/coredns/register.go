@@ -0,0 +1,17 @@
+package coredns
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "coredns",
+		DefaultImage: "coredns/coredns:1.11.3",
+		ExposedPorts: []string{DNSPort, DNSTCPPort},
+		Options: map[string]any{
+			"WithForwarders": WithForwarders,
+			"WithZoneFile":   WithZoneFile,
+			"WithZones":      WithZones,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,104 @@
+package coredns
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultTTL = 60
+
+// Zone is a DNS zone served by CoreDNS, with its records.
+type Zone struct {
+	// Origin is the domain of the zone, e.g. example.com.
+	Origin string
+	// TTL is the default time to live of the records, in seconds. The default is 60 seconds.
+	TTL int
+	// Records are the records of the zone. The SOA and NS records are generated.
+	Records []Record
+}
+
+// Record is a resource record of a zone.
+type Record struct {
+	// Name is the name of the record, relative to the origin of the zone, e.g. www, or absolute
+	// with a trailing dot, e.g. www.example.com. The @ name is the origin of the zone.
+	Name string
+	// Type is the type of the record, e.g. A or SRV.
+	Type string
+	// TTL is the time to live of the record, in seconds. The default is the TTL of the zone.
+	TTL int
+	// Value is the data of the record, in the zone file format, e.g. 10 5 8080 api.example.com. for a SRV record.
+	Value string
+}
+
+// A returns an A record, resolving the name to the IPv4 address.
+func A(name string, ip string) Record {
+	return Record{Name: name, Type: "A", Value: ip}
+}
+
+// AAAA returns an AAAA record, resolving the name to the IPv6 address.
+func AAAA(name string, ip string) Record {
+	return Record{Name: name, Type: "AAAA", Value: ip}
+}
+
+// CNAME returns a CNAME record, making the name an alias of the target.
+func CNAME(name string, target string) Record {
+	return Record{Name: name, Type: "CNAME", Value: target}
+}
+
+// TXT returns a TXT record with the given text.
+func TXT(name string, text string) Record {
+	return Record{Name: name, Type: "TXT", Value: strconv.Quote(text)}
+}
+
+// MX returns a MX record, making the host a mail exchanger of the name, with the given preference.
+func MX(name string, preference int, host string) Record {
+	return Record{Name: name, Type: "MX", Value: fmt.Sprintf("%d %s", preference, host)}
+}
+
+// SRV returns a SRV record, e.g. for the _http._tcp name, locating the service on the port of the target.
+func SRV(name string, priority int, weight int, port int, target string) Record {
+	return Record{Name: name, Type: "SRV", Value: fmt.Sprintf("%d %d %d %s", priority, weight, port, target)}
+}
+
+// fqdn returns the name with a trailing dot.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	return name + "."
+}
+
+// zoneFile returns the zone in the file format of RFC 1035, with the given serial in its SOA record,
+// so CoreDNS reloads the zone when its serial is increased.
+func (z Zone) zoneFile(serial uint32) []byte {
+	origin := fqdn(z.Origin)
+
+	ttl := z.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(&buf, "$TTL %d\n", ttl)
+	fmt.Fprintf(&buf, "@ IN SOA ns.%s hostmaster.%s %d 7200 3600 1209600 %d\n", origin, origin, serial, ttl)
+	fmt.Fprintf(&buf, "@ IN NS ns.%s\n", origin)
+
+	for _, r := range z.Records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+
+		if r.TTL > 0 {
+			fmt.Fprintf(&buf, "%s %d IN %s %s\n", name, r.TTL, strings.ToUpper(r.Type), r.Value)
+		} else {
+			fmt.Fprintf(&buf, "%s IN %s %s\n", name, strings.ToUpper(r.Type), r.Value)
+		}
+	}
+
+	return buf.Bytes()
+}
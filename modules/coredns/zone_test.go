@@ -0,0 +1,66 @@
+package coredns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneFile(t *testing.T) {
+	zone := Zone{
+		Origin: "example.com",
+		Records: []Record{
+			A("api", "10.0.0.1"),
+			{Name: "db", Type: "a", TTL: 5, Value: "10.0.0.2"},
+			CNAME("www", "api"),
+			TXT("@", "v=spf1 -all"),
+			MX("", 10, "mail.example.com."),
+			SRV("_http._tcp", 10, 5, 8080, "api.example.com."),
+		},
+	}
+
+	require.Equal(t, `$ORIGIN example.com.
+$TTL 60
+@ IN SOA ns.example.com. hostmaster.example.com. 3 7200 3600 1209600 60
+@ IN NS ns.example.com.
+api IN A 10.0.0.1
+db 5 IN A 10.0.0.2
+www IN CNAME api
+@ IN TXT "v=spf1 -all"
+@ IN MX 10 mail.example.com.
+_http._tcp IN SRV 10 5 8080 api.example.com.
+`, string(zone.zoneFile(3)))
+}
+
+func TestCorefile(t *testing.T) {
+	require.Equal(t, `example.com. {
+    file /etc/coredns/zones/example.com.db {
+        reload 1s
+    }
+    log
+    errors
+}
+
+internal. {
+    file /etc/coredns/zones/internal.db {
+        reload 1s
+    }
+    log
+    errors
+}
+
+. {
+    forward . 8.8.8.8 1.1.1.1
+    ready :8181
+    log
+    errors
+}
+`, corefile([]string{"internal.", "example.com."}, []string{"8.8.8.8", "1.1.1.1"}))
+
+	require.Equal(t, `. {
+    ready :8181
+    log
+    errors
+}
+`, corefile(nil, nil))
+}
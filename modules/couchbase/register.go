@@ -0,0 +1,19 @@
+package couchbase
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "couchbase",
+		DefaultImage: "couchbase:6.5.1",
+		ExposedPorts: []string{MGMT_PORT + "/tcp", MGMT_SSL_PORT + "/tcp"},
+		Options: map[string]any{
+			"WithAdminCredentials": WithAdminCredentials,
+			"WithBuckets":          WithBuckets,
+			"WithIndexStorage":     WithIndexStorage,
+			"WithServiceAnalytics": WithServiceAnalytics,
+			"WithServiceEventing":  WithServiceEventing,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,71 @@
+package couchdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/couchdb"
+)
+
+const testImage = "couchdb:3.3"
+
+func TestCouchDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := couchdb.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	// connectionString {
+	connStr, err := container.ConnectionString(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(connStr, "http://admin:admin@"))
+
+	// the system databases are created
+	resp, err := http.Get(connStr + "/_users")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCouchDBWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	// withInitScripts {
+	container, err := couchdb.Run(ctx, testImage,
+		couchdb.WithUsername("app_user"),
+		couchdb.WithPassword("app_password"),
+		couchdb.WithDatabase("people"),
+		couchdb.WithInitScripts(filepath.Join("testdata", "people.json")),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(connStr, "http://app_user:app_password@"))
+
+	resp, err := http.Get(connStr + "/" + container.Database() + "/ada")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var doc struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	require.Equal(t, "Ada Lovelace", doc.Name)
+}
+
+func TestCouchDBInitScriptsWithoutDatabase(t *testing.T) {
+	_, err := couchdb.Run(context.Background(), testImage, couchdb.WithInitScripts(filepath.Join("testdata", "people.json")))
+	require.Error(t, err)
+}
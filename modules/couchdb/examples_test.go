@@ -0,0 +1,41 @@
+package couchdb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/couchdb"
+)
+
+func ExampleRun() {
+	// runCouchDBContainer {
+	ctx := context.Background()
+
+	couchdbContainer, err := couchdb.Run(ctx, "couchdb:3.3",
+		couchdb.WithUsername("app_user"),
+		couchdb.WithPassword("app_password"),
+		couchdb.WithDatabase("app"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := couchdbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := couchdbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,72 @@
+package couchdb
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultUsername = "admin"
+	defaultPassword = "admin"
+)
+
+type options struct {
+	username    string
+	password    string
+	database    string
+	initScripts []string
+}
+
+func defaultOptions() options {
+	return options{
+		username: defaultUsername,
+		password: defaultPassword,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the CouchDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithUsername sets the admin user to be created when the container starts.
+// It is used in conjunction with WithPassword to set a user and its password.
+// If it is not specified, then the admin user will be used.
+func WithUsername(username string) Option {
+	return func(o *options) {
+		if username == "" {
+			username = defaultUsername
+		}
+
+		o.username = username
+	}
+}
+
+// WithPassword sets the password of the admin user. The default password is "admin".
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithDatabase sets the database to be created once the container is ready.
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithInitScripts sets the JSON files with the documents to be stored, in order, in the database once the
+// container is ready. Each file contains either an array of documents, or an object with a "docs" array,
+// as accepted by the _bulk_docs endpoint. It needs a database set with WithDatabase.
+func WithInitScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.initScripts = append(o.initScripts, scripts...)
+	}
+}
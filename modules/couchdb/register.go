@@ -0,0 +1,18 @@
+package couchdb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "couchdb",
+		DefaultImage: "couchdb:3.3",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithDatabase":    WithDatabase,
+			"WithInitScripts": WithInitScripts,
+			"WithPassword":    WithPassword,
+			"WithUsername":    WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,97 @@
+package dapr
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const componentsPath = "/components"
+
+// Component is a Dapr component, loaded by the sidecar from its resources path.
+type Component struct {
+	// Name is the name of the component, used by the app to refer to it, e.g. statestore.
+	Name string
+	// Type is the type of the component, e.g. state.redis or pubsub.kafka.
+	Type string
+	// Version is the version of the component type. The default is v1.
+	Version string
+	// Metadata are the settings of the component.
+	Metadata map[string]string
+}
+
+// RedisStateStore returns a state store component backed by the Redis server at the given host and port,
+// e.g. redis:6379, as seen from the sidecar.
+func RedisStateStore(name string, address string) Component {
+	return Component{
+		Name: name,
+		Type: "state.redis",
+		Metadata: map[string]string{
+			"redisHost":     address,
+			"redisPassword": "",
+		},
+	}
+}
+
+// KafkaPubSub returns a pub/sub component backed by the Kafka brokers at the given hosts and ports,
+// e.g. kafka:9092, as seen from the sidecar. The consumer group is the app ID.
+func KafkaPubSub(name string, brokers ...string) Component {
+	return Component{
+		Name: name,
+		Type: "pubsub.kafka",
+		Metadata: map[string]string{
+			"brokers":       strings.Join(brokers, ","),
+			"authType":      "none",
+			"consumerGroup": "{appID}",
+		},
+	}
+}
+
+type componentMetadata struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type componentSpec struct {
+	Type     string              `yaml:"type"`
+	Version  string              `yaml:"version"`
+	Metadata []componentMetadata `yaml:"metadata,omitempty"`
+}
+
+type componentManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   map[string]string `yaml:"metadata"`
+	Spec       componentSpec     `yaml:"spec"`
+}
+
+// manifest returns the YAML manifest of the component.
+func (c Component) manifest() ([]byte, error) {
+	version := c.Version
+	if version == "" {
+		version = "v1"
+	}
+
+	keys := make([]string, 0, len(c.Metadata))
+	for k := range c.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	metadata := make([]componentMetadata, 0, len(keys))
+	for _, k := range keys {
+		metadata = append(metadata, componentMetadata{Name: k, Value: c.Metadata[k]})
+	}
+
+	return yaml.Marshal(componentManifest{
+		APIVersion: "dapr.io/v1alpha1",
+		Kind:       "Component",
+		Metadata:   map[string]string{"name": c.Name},
+		Spec: componentSpec{
+			Type:     c.Type,
+			Version:  version,
+			Metadata: metadata,
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package dapr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentManifest(t *testing.T) {
+	manifest, err := RedisStateStore("statestore", "redis:6379").manifest()
+	require.NoError(t, err)
+
+	expected := `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+    name: statestore
+spec:
+    type: state.redis
+    version: v1
+    metadata:
+        - name: redisHost
+          value: redis:6379
+        - name: redisPassword
+          value: ""
+`
+	require.Equal(t, expected, string(manifest))
+}
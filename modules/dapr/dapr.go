@@ -0,0 +1,130 @@
+package dapr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HTTPPort is the port of the HTTP API of the sidecar, to be exposed by the app container
+	// when the sidecar is attached to it.
+	HTTPPort = "3500/tcp"
+	// GRPCPort is the port of the gRPC API of the sidecar, to be exposed by the app container
+	// when the sidecar is attached to it.
+	GRPCPort = "50001/tcp"
+)
+
+// DaprContainer represents the Dapr sidecar container type used in the module
+type DaprContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// AppID returns the ID of the app the sidecar runs for.
+func (c *DaprContainer) AppID() string {
+	return c.settings.appID
+}
+
+// HTTPEndpoint returns the URL of the HTTP API of the sidecar, e.g. http://localhost:3500.
+// If the sidecar is attached to an app container, it uses the port exposed by the app container.
+func (c *DaprContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	return c.portsContainer().PortEndpoint(ctx, HTTPPort, "http")
+}
+
+// GRPCEndpoint returns the host and port of the gRPC API of the sidecar, e.g. localhost:50001.
+// If the sidecar is attached to an app container, it uses the port exposed by the app container.
+func (c *DaprContainer) GRPCEndpoint(ctx context.Context) (string, error) {
+	return c.portsContainer().PortEndpoint(ctx, GRPCPort, "")
+}
+
+// portsContainer returns the container publishing the ports of the sidecar.
+func (c *DaprContainer) portsContainer() testcontainers.Container {
+	if c.settings.appContainer != nil {
+		return c.settings.appContainer
+	}
+
+	return c.Container
+}
+
+// Run creates an instance of the Dapr sidecar container type, loading the components set with the options.
+// If an app container is set with the WithAppContainer option, the sidecar shares its network namespace.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*DaprContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HTTPPort, GRPCPort},
+		WaitingFor:   wait.ForLog("dapr initialized. Status: Running"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := []string{
+		"./daprd",
+		"--app-id", settings.appID,
+		"--dapr-http-port", "3500",
+		"--dapr-grpc-port", "50001",
+	}
+
+	if len(settings.components) > 0 {
+		cmd = append(cmd, "--resources-path", componentsPath)
+	}
+
+	for _, component := range settings.components {
+		manifest, err := component.manifest()
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", component.Name, err)
+		}
+
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(manifest),
+			ContainerFilePath: componentsPath + "/" + component.Name + ".yaml",
+			FileMode:          0o644,
+		})
+	}
+
+	if settings.appContainer != nil {
+		cmd = append(cmd, "--app-port", strconv.Itoa(settings.appPort))
+
+		// the sidecar shares the network namespace of the app, so it can't publish ports nor join networks
+		genericContainerReq.ExposedPorts = nil
+		genericContainerReq.Networks = nil
+		genericContainerReq.NetworkAliases = nil
+
+		appContainerID := settings.appContainer.GetContainerID()
+		modifier := genericContainerReq.HostConfigModifier
+		genericContainerReq.HostConfigModifier = func(hc *container.HostConfig) {
+			if modifier != nil {
+				modifier(hc)
+			}
+			hc.NetworkMode = container.NetworkMode("container:" + appContainerID)
+		}
+	}
+
+	genericContainerReq.Cmd = append(cmd, genericContainerReq.Cmd...)
+
+	ctr, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DaprContainer{Container: ctr, settings: settings}, nil
+}
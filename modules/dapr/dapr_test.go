@@ -0,0 +1,104 @@
+package dapr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/dapr"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "daprio/daprd:1.14.4"
+
+func TestDapr_stateStore(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, nw.Remove(ctx)) })
+
+	redis, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          "redis:7-alpine",
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"redis"}},
+			WaitingFor:     wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, redis.Terminate(ctx)) })
+
+	ctr, err := dapr.Run(ctx, testImage,
+		network.WithNetwork([]string{"dapr"}, nw),
+		// withComponents {
+		dapr.WithComponents(dapr.RedisStateStore("statestore", "redis:6379")),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// httpEndpoint {
+	endpoint, err := ctr.HTTPEndpoint(ctx)
+	// }
+	require.NoError(t, err)
+
+	resp, err := http.Post(endpoint+"/v1.0/state/statestore", "application/json", strings.NewReader(`[{"key":"order","value":"42"}]`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = http.Get(endpoint + "/v1.0/state/statestore/order")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `"42"`, string(body))
+}
+
+func TestDapr_appContainer(t *testing.T) {
+	ctx := context.Background()
+
+	// appContainer {
+	app, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "nginx:alpine",
+			// the ports of the sidecar are exposed by the app container
+			ExposedPorts: []string{"80/tcp", dapr.HTTPPort, dapr.GRPCPort},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, app.Terminate(ctx)) })
+
+	ctr, err := dapr.Run(ctx, testImage,
+		dapr.WithAppID("web"),
+		dapr.WithAppContainer(app, 80),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	endpoint, err := ctr.HTTPEndpoint(ctx)
+	require.NoError(t, err)
+
+	// the sidecar invokes the app on localhost
+	resp, err := http.Get(endpoint + "/v1.0/invoke/web/method/index.html")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "Welcome to nginx!")
+}
@@ -0,0 +1,37 @@
+package dapr_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/dapr"
+)
+
+func ExampleRun() {
+	// runDaprContainer {
+	ctx := context.Background()
+
+	daprContainer, err := dapr.Run(ctx, "daprio/daprd:1.14.4", dapr.WithAppID("orders"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := daprContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := daprContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,56 @@
+package dapr
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const defaultAppID = "app"
+
+type options struct {
+	appID        string
+	appContainer testcontainers.Container
+	appPort      int
+	components   []Component
+}
+
+func defaultOptions() options {
+	return options{
+		appID: defaultAppID,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Dapr container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithAppID sets the ID of the app the sidecar runs for. The default is app.
+func WithAppID(id string) Option {
+	return func(o *options) {
+		o.appID = id
+	}
+}
+
+// WithAppContainer attaches the sidecar to the network namespace of the given app container,
+// listening on the given port. The app reaches the sidecar on localhost, and the ports of the
+// sidecar must be exposed by the app container, as the sidecar can't publish ports itself.
+func WithAppContainer(ctr testcontainers.Container, appPort int) Option {
+	return func(o *options) {
+		o.appContainer = ctr
+		o.appPort = appPort
+	}
+}
+
+// WithComponents loads the given components in the sidecar.
+func WithComponents(components ...Component) Option {
+	return func(o *options) {
+		o.components = append(o.components, components...)
+	}
+}
@@ -0,0 +1,17 @@
+package dapr
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "dapr",
+		DefaultImage: "daprio/daprd:1.14.4",
+		ExposedPorts: []string{HTTPPort, GRPCPort},
+		Options: map[string]any{
+			"WithAppContainer": WithAppContainer,
+			"WithAppID":        WithAppID,
+			"WithComponents":   WithComponents,
+		},
+		Run: modules.Runner(Run),
+	})
+}
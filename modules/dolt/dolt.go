@@ -191,6 +191,22 @@ func (c *DoltContainer) ConnectionString(ctx context.Context, args ...string) (s
 	return connectionString, nil
 }
 
+// ConnectionDetails returns the details to connect to the database of the container, with the DSN
+// in the format of the go-sql-driver/mysql driver, implementing testcontainers.ConnectionSource.
+func (c *DoltContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "mysql", "3306/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.username
+	details.Password = c.password
+	details.Database = c.database
+	details.NativeDSN = fmt.Sprintf("%s:%s@tcp(%s)/%s", c.username, c.password, details.Address(), c.database)
+
+	return details, nil
+}
+
 func WithUsername(username string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) error {
 		req.Env["DOLT_USER"] = username
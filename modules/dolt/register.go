@@ -0,0 +1,23 @@
+package dolt
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "dolt",
+		DefaultImage: "dolthub/dolt-sql-server:1.32.4",
+		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":         WithConfigFile,
+			"WithCredsFile":          WithCredsFile,
+			"WithDatabase":           WithDatabase,
+			"WithDefaultCredentials": WithDefaultCredentials,
+			"WithDoltCloneRemoteUrl": WithDoltCloneRemoteUrl,
+			"WithDoltCredsPublicKey": WithDoltCredsPublicKey,
+			"WithPassword":           WithPassword,
+			"WithScripts":            WithScripts,
+			"WithUsername":           WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -283,3 +283,49 @@ func configureHTTPClient(esContainer *elasticsearch.ElasticsearchContainer) *htt
 	// }
 	return client
 }
+
+func TestElasticsearchWithSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// withSeed {
+	container, err := elasticsearch.Run(ctx, baseImage8,
+		testcontainers.WithSeed(
+			elasticsearch.JSONSeeder("products", []byte(`[{"sku": "A-1", "price": 10}, {"sku": "B-2", "price": 20}]`)),
+		),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	httpClient := configureHTTPClient(container)
+
+	req, err := http.NewRequest("GET", container.Settings.Address+"/products/_count", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(container.Settings.Username, container.Settings.Password)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var countResp struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&countResp); err != nil {
+		t.Fatal(err)
+	}
+
+	if countResp.Count != 2 {
+		t.Fatalf("expected 2 seeded documents, got %d", countResp.Count)
+	}
+}
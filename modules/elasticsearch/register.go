@@ -0,0 +1,18 @@
+package elasticsearch
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "elasticsearch",
+		DefaultImage: "docker.elastic.co/elasticsearch/elasticsearch:7.9.2",
+		ExposedPorts: []string{
+			defaultHTTPPort + "/tcp",
+			defaultTCPPort + "/tcp",
+		},
+		Options: map[string]any{
+			"WithPassword": WithPassword,
+		},
+		Run: modules.Runner(Run),
+	})
+}
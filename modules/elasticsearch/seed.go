@@ -0,0 +1,100 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// seedFile is the path where the bulk requests are copied in the container before being sent to the server.
+const seedFile = "/tmp/testcontainers-seed.ndjson"
+
+// bulkScript sends the bulk requests to the server with curl, from inside the container. It uses HTTPS with
+// the certificate generated by the server if any, and the password of the elastic user if security is enabled.
+const bulkScript = `CA=/usr/share/elasticsearch/config/certs/http_ca.crt
+URL=http://localhost:9200
+if [ -f "$CA" ]; then URL=https://localhost:9200; set -- --cacert "$CA"; fi
+if [ -n "$ELASTIC_PASSWORD" ]; then set -- "$@" -u "elastic:$ELASTIC_PASSWORD"; fi
+curl -sS "$@" -H 'Content-Type: application/x-ndjson' --data-binary @` + seedFile + ` "$URL/_bulk?refresh=true"`
+
+// BulkSeeder returns a seeder sending the bulk requests to the server, to be used with the
+// testcontainers.WithSeed option. The requests use the newline delimited JSON format of the Bulk API,
+// and the indexed documents are searchable once the seeder is done.
+func BulkSeeder(ndjson []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		return bulk(ctx, c, ndjson)
+	})
+}
+
+// JSONSeeder returns a seeder indexing the documents of the JSON array into the index, to be used with the
+// testcontainers.WithSeed option. The documents are searchable once the seeder is done.
+func JSONSeeder(index string, documents []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		var docs []json.RawMessage
+		if err := json.Unmarshal(documents, &docs); err != nil {
+			return fmt.Errorf("unmarshal JSON documents: %w", err)
+		}
+
+		action, err := json.Marshal(map[string]any{"index": map[string]string{"_index": index}})
+		if err != nil {
+			return err
+		}
+
+		ndjson := &bytes.Buffer{}
+		for _, doc := range docs {
+			compact := &bytes.Buffer{}
+			if err := json.Compact(compact, doc); err != nil {
+				return fmt.Errorf("compact JSON document: %w", err)
+			}
+
+			ndjson.Write(action)
+			ndjson.WriteByte('\n')
+			ndjson.Write(compact.Bytes())
+			ndjson.WriteByte('\n')
+		}
+
+		return bulk(ctx, c, ndjson.Bytes())
+	})
+}
+
+// bulk sends the bulk requests to the server, returning an error with the response
+// if the request or any of its items fails.
+func bulk(ctx context.Context, c testcontainers.Container, ndjson []byte) error {
+	if err := c.CopyToContainer(ctx, ndjson, seedFile, 0o644); err != nil {
+		return fmt.Errorf("copy bulk requests: %w", err)
+	}
+
+	exitCode, reader, err := c.Exec(ctx, []string{"sh", "-c", bulkScript}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("curl: exit code %d: %s", exitCode, strings.TrimSpace(string(out)))
+	}
+
+	var resp struct {
+		Errors bool            `json:"errors"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return fmt.Errorf("unmarshal bulk response: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	if resp.Errors || resp.Error != nil {
+		return fmt.Errorf("bulk request failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
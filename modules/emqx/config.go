@@ -0,0 +1,87 @@
+package emqx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	etcDir     = "/opt/emqx/etc"
+	usersFile  = etcDir + "/users.csv"
+	aclFile    = etcDir + "/acl.conf"
+	certsDir   = etcDir + "/certs/testcontainers"
+	caFile     = certsDir + "/ca.crt"
+	certFile   = certsDir + "/server.crt"
+	keyFile    = certsDir + "/server.key"
+	envPrefix  = "EMQX_"
+	authPrefix = envPrefix + "AUTHENTICATION__1__"
+	sslPrefix  = envPrefix + "LISTENERS__SSL__DEFAULT__SSL_OPTIONS__"
+)
+
+// env returns the environment variables configuring EMQX: the authentication of the users with the built-in database,
+// the authorization of the ACL rules, and the certificates of the TLS listener.
+func (o options) env() map[string]string {
+	env := map[string]string{}
+
+	if len(o.users) > 0 {
+		env[authPrefix+"MECHANISM"] = "password_based"
+		env[authPrefix+"BACKEND"] = "built_in_database"
+		env[authPrefix+"USER_ID_TYPE"] = "username"
+		env[authPrefix+"BOOTSTRAP_FILE"] = usersFile
+		env[authPrefix+"BOOTSTRAP_TYPE"] = "plain"
+	}
+
+	if len(o.acl) > 0 {
+		env[envPrefix+"AUTHORIZATION__NO_MATCH"] = "deny"
+	}
+
+	if o.tls {
+		env[sslPrefix+"CACERTFILE"] = caFile
+		env[sslPrefix+"CERTFILE"] = certFile
+		env[sslPrefix+"KEYFILE"] = keyFile
+	}
+
+	return env
+}
+
+// usersCSV returns the bootstrap file of the users of the built-in database, with plain text passwords.
+func (o options) usersCSV() []byte {
+	usernames := make([]string, 0, len(o.users))
+	for username := range o.users {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	var sb strings.Builder
+	sb.WriteString("user_id,password,is_superuser\n")
+	for _, username := range usernames {
+		fmt.Fprintf(&sb, "%s,%s,false\n", username, o.users[username])
+	}
+
+	return []byte(sb.String())
+}
+
+// aclConfig returns the ACL file of the rules, in the Erlang terms of the file authorizer of EMQX.
+func (o options) aclConfig() []byte {
+	var sb strings.Builder
+	for _, rule := range o.acl {
+		who := "all"
+		if rule.Username != "" {
+			who = "{username, " + strconv.Quote(rule.Username) + "}"
+		}
+
+		action := "all"
+		switch rule.Access {
+		case AccessRead:
+			action = "subscribe"
+		case AccessWrite:
+			action = "publish"
+		}
+
+		fmt.Fprintf(&sb, "{allow, %s, %s, [%s]}.\n", who, action, strconv.Quote(rule.Topic))
+	}
+
+	return []byte(sb.String())
+}
@@ -0,0 +1,50 @@
+package emqx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv(t *testing.T) {
+	require.Empty(t, defaultOptions().env())
+
+	o := defaultOptions()
+	WithUser("alice", "secret")(&o)
+	WithACL(ACLRule{Username: "alice", Topic: "sensors/#", Access: AccessRead})(&o)
+	WithTLS()(&o)
+
+	require.Equal(t, map[string]string{
+		"EMQX_AUTHENTICATION__1__MECHANISM":                     "password_based",
+		"EMQX_AUTHENTICATION__1__BACKEND":                       "built_in_database",
+		"EMQX_AUTHENTICATION__1__USER_ID_TYPE":                  "username",
+		"EMQX_AUTHENTICATION__1__BOOTSTRAP_FILE":                "/opt/emqx/etc/users.csv",
+		"EMQX_AUTHENTICATION__1__BOOTSTRAP_TYPE":                "plain",
+		"EMQX_AUTHORIZATION__NO_MATCH":                          "deny",
+		"EMQX_LISTENERS__SSL__DEFAULT__SSL_OPTIONS__CACERTFILE": "/opt/emqx/etc/certs/testcontainers/ca.crt",
+		"EMQX_LISTENERS__SSL__DEFAULT__SSL_OPTIONS__CERTFILE":   "/opt/emqx/etc/certs/testcontainers/server.crt",
+		"EMQX_LISTENERS__SSL__DEFAULT__SSL_OPTIONS__KEYFILE":    "/opt/emqx/etc/certs/testcontainers/server.key",
+	}, o.env())
+}
+
+func TestUsersCSV(t *testing.T) {
+	o := defaultOptions()
+	WithUser("bob", "pass")(&o)
+	WithUser("alice", "secret")(&o)
+
+	require.Equal(t, "user_id,password,is_superuser\nalice,secret,false\nbob,pass,false\n", string(o.usersCSV()))
+}
+
+func TestACLConfig(t *testing.T) {
+	o := defaultOptions()
+	WithACL(
+		ACLRule{Username: "alice", Topic: "sensors/#", Access: AccessWrite},
+		ACLRule{Username: "bob", Topic: "alerts/#", Access: AccessRead},
+		ACLRule{Topic: "clients/${clientid}/#", Access: AccessReadWrite},
+	)(&o)
+
+	require.Equal(t, `{allow, {username, "alice"}, publish, ["sensors/#"]}.
+{allow, {username, "bob"}, subscribe, ["alerts/#"]}.
+{allow, all, all, ["clients/${clientid}/#"]}.
+`, string(o.aclConfig()))
+}
@@ -0,0 +1,182 @@
+package emqx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mdelapenya/tlscert"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// MQTTPort is the port of the MQTT listener.
+	MQTTPort = "1883/tcp"
+	// MQTTTLSPort is the port of the MQTT listener over TLS.
+	MQTTTLSPort = "8883/tcp"
+	// DashboardPort is the port of the dashboard and of the REST API.
+	DashboardPort = "18083/tcp"
+)
+
+// EMQXContainer represents the EMQX container type used in the module
+type EMQXContainer struct {
+	testcontainers.Container
+	caCert *tlscert.Certificate
+}
+
+// BrokerURL returns the URL of the MQTT listener from the host, e.g. tcp://localhost:32768.
+func (c *EMQXContainer) BrokerURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, MQTTPort, "tcp")
+}
+
+// TLSBrokerURL returns the URL of the MQTT listener over TLS from the host, e.g. tls://localhost:32769.
+// It returns an error if the container was not started with WithTLS.
+func (c *EMQXContainer) TLSBrokerURL(ctx context.Context) (string, error) {
+	if c.caCert == nil {
+		return "", fmt.Errorf("tls listener not enabled, use WithTLS")
+	}
+
+	return c.PortEndpoint(ctx, MQTTTLSPort, "tls")
+}
+
+// TLSConfig returns the TLS config to connect to the MQTT listener over TLS, trusting the certificate authority
+// of the broker, or nil if the container was not started with WithTLS.
+func (c *EMQXContainer) TLSConfig() *tls.Config {
+	if c.caCert == nil {
+		return nil
+	}
+
+	return tlsConfig(c.caCert)
+}
+
+// tlsConfig returns the TLS config trusting the certificate authority EMQX is started with,
+// replacing the example certificates of the image, see WithTLS.
+func tlsConfig(caCert *tlscert.Certificate) *tls.Config {
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert.Cert)
+
+	return &tls.Config{
+		RootCAs:    caPool,
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// DashboardURL returns the URL of the dashboard and of the REST API, e.g. http://localhost:32770.
+// The default credentials of the dashboard are admin and public.
+func (c *EMQXContainer) DashboardURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, DashboardPort, "http")
+}
+
+// Run creates an instance of the EMQX container type, with the users, the ACL rules and the TLS certificates
+// set with the options. It waits for the broker to complete the MQTT handshake, with the credentials of a user if any.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*EMQXContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{MQTTPort, MQTTTLSPort, DashboardPort},
+		Env:          map[string]string{},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range settings.env() {
+		if _, ok := genericContainerReq.Env[k]; !ok {
+			genericContainerReq.Env[k] = v
+		}
+	}
+
+	mqttWait := wait.ForMQTT(MQTTPort).WithStartupTimeout(2 * time.Minute)
+
+	if len(settings.users) > 0 {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader: bytes.NewReader(settings.usersCSV()), ContainerFilePath: usersFile, FileMode: 0o644,
+		})
+
+		usernames := make([]string, 0, len(settings.users))
+		for username := range settings.users {
+			usernames = append(usernames, username)
+		}
+		sort.Strings(usernames)
+		mqttWait = mqttWait.WithCredentials(usernames[0], settings.users[usernames[0]])
+	}
+
+	if len(settings.acl) > 0 {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader: bytes.NewReader(settings.aclConfig()), ContainerFilePath: aclFile, FileMode: 0o644,
+		})
+	}
+
+	var caCert *tlscert.Certificate
+	if settings.tls {
+		caCert = tlscert.SelfSignedFromRequest(tlscert.Request{
+			Name:              "ca",
+			SubjectCommonName: "testcontainers-emqx-ca",
+			Host:              "localhost,127.0.0.1",
+			IsCA:              true,
+			ValidFor:          24 * time.Hour,
+		})
+		if caCert == nil {
+			return nil, errors.New("generate CA certificate")
+		}
+
+		serverCert := tlscert.SelfSignedFromRequest(tlscert.Request{
+			Name:              "server",
+			SubjectCommonName: "testcontainers-emqx",
+			Host:              "localhost,127.0.0.1",
+			ValidFor:          24 * time.Hour,
+			Parent:            caCert,
+		})
+		if serverCert == nil {
+			return nil, errors.New("generate server certificate")
+		}
+
+		// the files are read by the emqx user of the image
+		genericContainerReq.Files = append(genericContainerReq.Files,
+			testcontainers.ContainerFile{Reader: bytes.NewReader(caCert.Bytes), ContainerFilePath: caFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(serverCert.Bytes), ContainerFilePath: certFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(serverCert.KeyBytes), ContainerFilePath: keyFile, FileMode: 0o644},
+		)
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		strategies := []wait.Strategy{mqttWait}
+		if caCert != nil {
+			tlsWait := *mqttWait
+			tlsWait.Port = MQTTTLSPort
+			strategies = append(strategies, tlsWait.WithTLS(tlsConfig(caCert)))
+		}
+		genericContainerReq.WaitingFor = wait.ForAll(strategies...)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *EMQXContainer
+	if container != nil {
+		c = &EMQXContainer{Container: container, caCert: caCert}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
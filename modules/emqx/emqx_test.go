@@ -0,0 +1,93 @@
+package emqx_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/emqx"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "emqx/emqx:5.8.0"
+
+// handshake makes the MQTT handshake with the broker on the given port, with the credentials and the TLS config if any.
+func handshake(ctr *emqx.EMQXContainer, port nat.Port, username string, password string, tlsConfig *tls.Config) error {
+	strategy := wait.ForMQTT(port).WithStartupTimeout(2 * time.Second)
+	if username != "" {
+		strategy = strategy.WithCredentials(username, password)
+	}
+	if tlsConfig != nil {
+		strategy = strategy.WithTLS(tlsConfig)
+	}
+
+	return strategy.WaitUntilReady(context.Background(), ctr)
+}
+
+func TestEMQX(t *testing.T) {
+	ctx := context.Background()
+
+	// withUserACLAndTLS {
+	ctr, err := emqx.Run(ctx, testImage,
+		emqx.WithUser("alice", "secret"),
+		emqx.WithACL(emqx.ACLRule{Username: "alice", Topic: "sensors/#", Access: emqx.AccessReadWrite}),
+		emqx.WithTLS(),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// brokerURL {
+	brokerURL, err := ctr.BrokerURL(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(brokerURL, "tcp://"))
+
+	require.NoError(t, handshake(ctr, emqx.MQTTPort, "alice", "secret", nil))
+	require.Error(t, handshake(ctr, emqx.MQTTPort, "alice", "wrong", nil))
+
+	// tlsBrokerURL {
+	tlsBrokerURL, err := ctr.TLSBrokerURL(ctx)
+	require.NoError(t, err)
+
+	tlsConfig := ctr.TLSConfig()
+	// }
+	require.True(t, strings.HasPrefix(tlsBrokerURL, "tls://"))
+	require.NoError(t, handshake(ctr, emqx.MQTTTLSPort, "alice", "secret", tlsConfig))
+}
+
+func TestEMQX_anonymous(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := emqx.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.NoError(t, handshake(ctr, emqx.MQTTPort, "", "", nil))
+
+	_, err = ctr.TLSBrokerURL(ctx)
+	require.Error(t, err)
+	require.Nil(t, ctr.TLSConfig())
+}
+
+func TestEMQX_dashboard(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := emqx.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	dashboardURL, err := ctr.DashboardURL(ctx)
+	require.NoError(t, err)
+
+	resp, err := http.Get(dashboardURL + "/api/v5/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,39 @@
+package emqx_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/emqx"
+)
+
+func ExampleRun() {
+	// runEMQXContainer {
+	ctx := context.Background()
+
+	emqxContainer, err := emqx.Run(ctx, "emqx/emqx:5.8.0",
+		emqx.WithUser("alice", "secret"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := emqxContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := emqxContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,17 @@
+package emqx
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "emqx",
+		DefaultImage: "emqx/emqx:5.8.0",
+		ExposedPorts: []string{MQTTPort, MQTTTLSPort, DashboardPort},
+		Options: map[string]any{
+			"WithACL":  WithACL,
+			"WithTLS":  WithTLS,
+			"WithUser": WithUser,
+		},
+		Run: modules.Runner(Run),
+	})
+}
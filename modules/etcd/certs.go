@@ -0,0 +1,66 @@
+package etcd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mdelapenya/tlscert"
+)
+
+// certificates holds the PEM encoded CA and node certificates of the cluster.
+// The node certificate is used both to serve clients and to authenticate the members
+// of the cluster between them.
+type certificates struct {
+	caCert   []byte
+	nodeCert []byte
+	nodeKey  []byte
+	caPool   *x509.CertPool
+}
+
+// newCertificates generates a self-signed CA, and a node certificate signed by it, valid for the given hosts.
+func newCertificates(hosts ...string) (*certificates, error) {
+	caCert := tlscert.SelfSignedFromRequest(tlscert.Request{
+		Name:              "ca",
+		SubjectCommonName: "testcontainers-etcd-ca",
+		Host:              strings.Join(hosts, ","),
+		IsCA:              true,
+		ValidFor:          24 * time.Hour,
+	})
+	if caCert == nil {
+		return nil, errors.New("generate CA certificate")
+	}
+
+	nodeCert := tlscert.SelfSignedFromRequest(tlscert.Request{
+		Name:              "node",
+		SubjectCommonName: "testcontainers-etcd",
+		Host:              strings.Join(hosts, ","),
+		ValidFor:          24 * time.Hour,
+		Parent:            caCert,
+	})
+	if nodeCert == nil {
+		return nil, errors.New("generate node certificate")
+	}
+
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert.Cert)
+
+	return &certificates{
+		caCert:   caCert.Bytes,
+		nodeCert: nodeCert.Bytes,
+		nodeKey:  nodeCert.KeyBytes,
+		caPool:   caPool,
+	}, nil
+}
+
+// tlsConfig returns the TLS config to connect to the client API of the cluster from the host.
+// All the members share the node certificate, so it's verified the same way whichever member is reached.
+func (c *certificates) tlsConfig() *tls.Config {
+	return &tls.Config{
+		RootCAs:    c.caPool,
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	}
+}
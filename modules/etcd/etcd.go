@@ -0,0 +1,310 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	clientPort = "2379/tcp"
+	peerPort   = "2380/tcp"
+
+	defaultNodeName     = "default"
+	defaultClusterToken = "testcontainers-etcd"
+
+	dataDir  = "/etcd-data"
+	certsDir = "/etc/etcd/certs"
+	caFile   = certsDir + "/ca.crt"
+	certFile = certsDir + "/server.crt"
+	keyFile  = certsDir + "/server.key"
+)
+
+// EtcdContainer represents the etcd container type used in the module.
+// When the etcd cluster is formed by more than one node, it represents the first node of the cluster.
+type EtcdContainer struct {
+	testcontainers.Container
+	childNodes []*EtcdContainer
+	network    *testcontainers.DockerNetwork
+	settings   options
+	certs      *certificates
+}
+
+// Run creates an instance of the etcd container type. When more than one node is configured with WithNodes,
+// the members of the cluster are started concurrently, attached to a new network, and the first one is returned.
+// The keys set with WithKeys are stored once all the members are ready.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*EtcdContainer, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
+	c := &EtcdContainer{settings: settings}
+
+	if settings.clientTLS || settings.peerTLS {
+		certs, err := newCertificates(append([]string{"localhost", "127.0.0.1"}, settings.nodes...)...)
+		if err != nil {
+			return nil, fmt.Errorf("new certificates: %w", err)
+		}
+		c.certs = certs
+	}
+
+	if settings.clustered() {
+		nw, err := network.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("new network: %w", err)
+		}
+		c.network = nw
+	}
+
+	nodes := make([]testcontainers.Container, len(settings.nodes))
+	errs := make([]error, len(settings.nodes))
+
+	// the members of the cluster must be started at the same time, as none of them
+	// is ready to serve client requests until there is a quorum.
+	var wg sync.WaitGroup
+	for i, name := range settings.nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nodes[i], errs[i] = c.runNode(ctx, img, name, opts...)
+		}()
+	}
+	wg.Wait()
+
+	for i, node := range nodes {
+		if i == 0 {
+			c.Container = node
+			continue
+		}
+
+		if node != nil {
+			c.childNodes = append(c.childNodes, &EtcdContainer{Container: node, settings: settings, certs: c.certs})
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	if err := c.putKeys(ctx); err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	return c, nil
+}
+
+// runNode starts the member of the cluster with the given name.
+func (c *EtcdContainer) runNode(ctx context.Context, img string, name string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        img,
+			ExposedPorts: []string{clientPort, peerPort},
+			Cmd:          c.nodeCmd(name),
+			WaitingFor:   wait.ForLog("ready to serve client requests"),
+		},
+		Started: true,
+	}
+
+	if c.network != nil {
+		if err := network.WithNetwork([]string{name}, c.network).Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.certs != nil {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{Reader: bytes.NewReader(c.certs.caCert), ContainerFilePath: caFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(c.certs.nodeCert), ContainerFilePath: certFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(c.certs.nodeKey), ContainerFilePath: keyFile, FileMode: 0o600},
+		)
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	ctr, err := testcontainers.GenericContainer(ctx, req)
+	if err != nil {
+		// return the container, if any, so it can be terminated
+		return ctr, fmt.Errorf("run etcd node %s: %w", name, err)
+	}
+
+	return ctr, nil
+}
+
+// nodeCmd returns the etcd command for the member of the cluster with the given name.
+func (c *EtcdContainer) nodeCmd(name string) []string {
+	clientScheme, peerScheme := c.schemes()
+
+	// a single node advertises itself on localhost, while the members of a cluster
+	// reach each other using their aliases in the cluster network.
+	peerHost := "localhost"
+	if c.settings.clustered() {
+		peerHost = name
+	}
+
+	initialCluster := make([]string, len(c.settings.nodes))
+	for i, n := range c.settings.nodes {
+		host := "localhost"
+		if c.settings.clustered() {
+			host = n
+		}
+		initialCluster[i] = n + "=" + peerScheme + "://" + net.JoinHostPort(host, "2380")
+	}
+
+	cmd := []string{
+		"etcd",
+		"--name=" + name,
+		"--data-dir=" + dataDir,
+		"--listen-client-urls=" + clientScheme + "://0.0.0.0:2379",
+		"--advertise-client-urls=" + clientScheme + "://" + net.JoinHostPort(peerHost, "2379"),
+		"--listen-peer-urls=" + peerScheme + "://0.0.0.0:2380",
+		"--initial-advertise-peer-urls=" + peerScheme + "://" + net.JoinHostPort(peerHost, "2380"),
+		"--initial-cluster=" + strings.Join(initialCluster, ","),
+		"--initial-cluster-token=" + c.settings.clusterToken,
+		"--initial-cluster-state=new",
+	}
+
+	if c.settings.clientTLS {
+		cmd = append(cmd, "--cert-file="+certFile, "--key-file="+keyFile)
+	}
+
+	if c.settings.peerTLS {
+		cmd = append(cmd,
+			"--peer-cert-file="+certFile,
+			"--peer-key-file="+keyFile,
+			"--peer-trusted-ca-file="+caFile,
+			"--peer-client-cert-auth",
+		)
+	}
+
+	return append(cmd, c.settings.additionalArgs...)
+}
+
+// schemes returns the URL schemes of the client and peer endpoints.
+func (c *EtcdContainer) schemes() (string, string) {
+	clientScheme, peerScheme := "http", "http"
+	if c.settings.clientTLS {
+		clientScheme = "https"
+	}
+	if c.settings.peerTLS {
+		peerScheme = "https"
+	}
+
+	return clientScheme, peerScheme
+}
+
+// putKeys stores the keys set with WithKeys, using etcdctl in the container, sorted by key.
+func (c *EtcdContainer) putKeys(ctx context.Context) error {
+	keys := make([]string, 0, len(c.settings.keys))
+	for k := range c.settings.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clientScheme, _ := c.schemes()
+	for _, k := range keys {
+		cmd := []string{"etcdctl", "--endpoints=" + clientScheme + "://localhost:2379"}
+		if c.settings.clientTLS {
+			cmd = append(cmd, "--cacert="+caFile)
+		}
+		// the double dash allows keys and values starting with a dash
+		cmd = append(cmd, "put", "--", k, c.settings.keys[k])
+
+		code, r, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("put key %s: %w", k, err)
+		}
+
+		if code != 0 {
+			output, _ := io.ReadAll(r)
+			return fmt.Errorf("put key %s: exit code %d: %s", k, code, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+// ClientEndpoint returns the endpoint to reach the client API of the node from the host,
+// e.g. "http://localhost:32768".
+func (c *EtcdContainer) ClientEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, clientPort)
+	if err != nil {
+		return "", err
+	}
+
+	clientScheme, _ := c.schemes()
+
+	return clientScheme + "://" + net.JoinHostPort(host, port.Port()), nil
+}
+
+// ClientEndpoints returns the endpoints to reach the client API of all the members of the cluster from the host.
+func (c *EtcdContainer) ClientEndpoints(ctx context.Context) ([]string, error) {
+	members := c.Members()
+
+	endpoints := make([]string, len(members))
+	for i, m := range members {
+		endpoint, err := m.ClientEndpoint(ctx)
+		if err != nil {
+			return nil, err
+		}
+		endpoints[i] = endpoint
+	}
+
+	return endpoints, nil
+}
+
+// Members returns all the members of the cluster, being the first one the container itself.
+func (c *EtcdContainer) Members() []*EtcdContainer {
+	return append([]*EtcdContainer{c}, c.childNodes...)
+}
+
+// TLSConfig returns the TLS config to connect to the client API of the cluster,
+// or nil if the client API is not served over TLS.
+func (c *EtcdContainer) TLSConfig() *tls.Config {
+	if !c.settings.clientTLS || c.certs == nil {
+		return nil
+	}
+
+	return c.certs.tlsConfig()
+}
+
+// Terminate terminates all the members of the cluster, and removes the cluster network.
+func (c *EtcdContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	var errs []error
+	for _, child := range c.childNodes {
+		errs = append(errs, child.Terminate(ctx, opts...))
+	}
+
+	if c.Container != nil {
+		errs = append(errs, c.Container.Terminate(ctx, opts...))
+	}
+
+	if c.network != nil {
+		errs = append(errs, c.network.Remove(ctx))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package etcd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const testImage = "gcr.io/etcd-development/etcd:v3.5.14"
+
+func TestNodeCmd(t *testing.T) {
+	t.Run("single-node", func(t *testing.T) {
+		c := &EtcdContainer{settings: defaultOptions()}
+
+		cmd := c.nodeCmd(defaultNodeName)
+		require.Contains(t, cmd, "--initial-cluster=default=http://localhost:2380")
+		require.Contains(t, cmd, "--advertise-client-urls=http://localhost:2379")
+		require.NotContains(t, cmd, "--cert-file="+certFile)
+	})
+
+	t.Run("cluster", func(t *testing.T) {
+		settings := defaultOptions()
+		WithNodes("etcd-1", "etcd-2", "etcd-3")(&settings)
+		WithPeerTLS()(&settings)
+		c := &EtcdContainer{settings: settings}
+
+		cmd := c.nodeCmd("etcd-2")
+		require.Contains(t, cmd, "--name=etcd-2")
+		require.Contains(t, cmd, "--initial-cluster=etcd-1=https://etcd-1:2380,etcd-2=https://etcd-2:2380,etcd-3=https://etcd-3:2380")
+		require.Contains(t, cmd, "--initial-advertise-peer-urls=https://etcd-2:2380")
+		require.Contains(t, cmd, "--advertise-client-urls=http://etcd-2:2379")
+		require.Contains(t, cmd, "--peer-client-cert-auth")
+	})
+}
+
+func TestNewCertificates(t *testing.T) {
+	certs, err := newCertificates("localhost", "127.0.0.1", "etcd-1")
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certs.nodeCert)
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, []string{"localhost", "etcd-1"}, cert.DNSNames)
+	require.Len(t, cert.IPAddresses, 1)
+
+	for _, host := range []string{"localhost", "etcd-1"} {
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName:   host,
+			Roots:     certs.caPool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestEtcd(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := Run(ctx, testImage, WithKeys(map[string]string{"foo": "bar", "-dash": "value"}))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	endpoints, err := ctr.ClientEndpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	require.True(t, strings.HasPrefix(endpoints[0], "http://"))
+	require.Nil(t, ctr.TLSConfig())
+
+	require.Equal(t, "bar", get(t, ctr, "foo"))
+	require.Equal(t, "value", get(t, ctr, "-dash"))
+}
+
+func TestEtcd_Cluster(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := Run(ctx, testImage,
+		WithNodes("etcd-1", "etcd-2", "etcd-3"),
+		WithClientTLS(),
+		WithPeerTLS(),
+		WithKeys(map[string]string{"foo": "bar"}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Len(t, ctr.Members(), 3)
+	require.NotNil(t, ctr.TLSConfig())
+
+	endpoints, err := ctr.ClientEndpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 3)
+	for _, e := range endpoints {
+		require.True(t, strings.HasPrefix(e, "https://"))
+	}
+
+	// the key is replicated to all the members of the cluster
+	for _, m := range ctr.Members() {
+		require.Equal(t, "bar", get(t, m, "foo"))
+	}
+}
+
+// get reads the value of the key in the node, using etcdctl in the container.
+func get(t *testing.T, ctr *EtcdContainer, key string) string {
+	t.Helper()
+
+	clientScheme, _ := ctr.schemes()
+	cmd := []string{"etcdctl", "--endpoints=" + clientScheme + "://localhost:2379", "--print-value-only"}
+	if ctr.settings.clientTLS {
+		cmd = append(cmd, "--cacert="+caFile)
+	}
+	cmd = append(cmd, "get", "--", key)
+
+	code, r, err := ctr.Exec(context.Background(), cmd, tcexec.Multiplexed())
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,73 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/etcd"
+)
+
+func ExampleRun() {
+	// runEtcdContainer {
+	ctx := context.Background()
+
+	etcdContainer, err := etcd.Run(ctx, "gcr.io/etcd-development/etcd:v3.5.14",
+		etcd.WithKeys(map[string]string{"config/feature": "enabled"}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := etcdContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := etcdContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
+
+func ExampleRun_cluster() {
+	// runEtcdCluster {
+	ctx := context.Background()
+
+	etcdContainer, err := etcd.Run(ctx, "gcr.io/etcd-development/etcd:v3.5.14",
+		etcd.WithNodes("etcd-1", "etcd-2", "etcd-3"),
+		etcd.WithClientTLS(),
+		etcd.WithPeerTLS(),
+	)
+	if err != nil {
+		log.Fatalf("failed to start cluster: %s", err)
+	}
+
+	// Clean up the members of the cluster and its network
+	defer func() {
+		if err := etcdContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate cluster: %s", err)
+		}
+	}()
+
+	endpoints, err := etcdContainer.ClientEndpoints(ctx)
+	if err != nil {
+		log.Fatalf("failed to get client endpoints: %s", err) // nolint:gocritic
+	}
+
+	// connect using the endpoints and etcdContainer.TLSConfig()
+	// }
+
+	fmt.Println(len(endpoints))
+
+	// Output:
+	// 3
+}
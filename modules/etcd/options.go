@@ -0,0 +1,90 @@
+package etcd
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	nodes          []string
+	clusterToken   string
+	clientTLS      bool
+	peerTLS        bool
+	keys           map[string]string
+	additionalArgs []string
+}
+
+func defaultOptions() options {
+	return options{
+		nodes:        []string{defaultNodeName},
+		clusterToken: defaultClusterToken,
+		keys:         map[string]string{},
+	}
+}
+
+// clustered returns true if the etcd cluster is formed by more than one node.
+func (o options) clustered() bool {
+	return len(o.nodes) > 1
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the etcd container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithNodes sets the names of the members of the etcd cluster, which are also used as their aliases
+// in the cluster network. At least two nodes are needed to form a cluster: the container returned by Run
+// is the first node, and the rest of them are available with the Members method.
+func WithNodes(node1 string, node2 string, nodes ...string) Option {
+	return func(o *options) {
+		o.nodes = append([]string{node1, node2}, nodes...)
+	}
+}
+
+// WithClusterToken sets the initial cluster token of the etcd cluster.
+func WithClusterToken(token string) Option {
+	return func(o *options) {
+		o.clusterToken = token
+	}
+}
+
+// WithClientTLS serves the client API over TLS, using certificates generated for the container.
+// The TLS config to connect to the cluster is available with the TLSConfig method.
+// The TLS config pins its ServerName to localhost: when the Host of the container is a remote
+// Docker host, the name verified is not the one of the host reached.
+func WithClientTLS() Option {
+	return func(o *options) {
+		o.clientTLS = true
+	}
+}
+
+// WithPeerTLS secures the communication between the members of the cluster with mutual TLS,
+// using certificates generated for the cluster.
+func WithPeerTLS() Option {
+	return func(o *options) {
+		o.peerTLS = true
+	}
+}
+
+// WithKeys sets the keys and values to be stored in etcd once the cluster is ready.
+func WithKeys(keys map[string]string) Option {
+	return func(o *options) {
+		for k, v := range keys {
+			o.keys[k] = v
+		}
+	}
+}
+
+// WithAdditionalArgs appends command line arguments to the etcd command of all the nodes,
+// e.g. "--auto-compaction-retention=1".
+func WithAdditionalArgs(args ...string) Option {
+	return func(o *options) {
+		o.additionalArgs = append(o.additionalArgs, args...)
+	}
+}
@@ -0,0 +1,20 @@
+package etcd
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "etcd",
+		DefaultImage: "gcr.io/etcd-development/etcd:v3.5.14",
+		ExposedPorts: []string{clientPort, peerPort},
+		Options: map[string]any{
+			"WithAdditionalArgs": WithAdditionalArgs,
+			"WithClientTLS":      WithClientTLS,
+			"WithClusterToken":   WithClusterToken,
+			"WithKeys":           WithKeys,
+			"WithNodes":          WithNodes,
+			"WithPeerTLS":        WithPeerTLS,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,39 @@
+package fakegcs_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/fakegcs"
+)
+
+func ExampleRun() {
+	// runFakeGCSContainer {
+	ctx := context.Background()
+
+	fakegcsContainer, err := fakegcs.Run(ctx, "fsouza/fake-gcs-server:1.50.2",
+		fakegcs.WithBuckets("uploads"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := fakegcsContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := fakegcsContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,230 @@
+// Package fakegcs is the module of fake-gcs-server, the emulator of the Google Cloud Storage API,
+// seeded with buckets and objects.
+package fakegcs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+	"google.golang.org/api/option"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/fakes"
+	"github.com/testcontainers/testcontainers-go/modules/fakes/internal/api"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HTTPPort is the port of the API.
+	HTTPPort = "4443/tcp"
+
+	// DefaultPublicHost is the default host of the public and signed URLs of the objects.
+	DefaultPublicHost = "storage.googleapis.com"
+
+	// project is the project of the buckets, which the emulator ignores.
+	project = "test"
+)
+
+// FakeGCSContainer represents the fake-gcs-server container type used in the module
+type FakeGCSContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// APIEndpoint returns the endpoint of the API from the host, e.g. http://localhost:32768.
+func (c *FakeGCSContainer) APIEndpoint(ctx context.Context) (fakes.Endpoint, error) {
+	return fakes.HostEndpoint(ctx, c, HTTPPort, "http")
+}
+
+// ClientOptions returns the options of the Cloud Storage clients of the tests, pointing them at the emulator
+// without authentication, e.g. storage.NewClient(ctx, opts...).
+func (c *FakeGCSContainer) ClientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []option.ClientOption{
+		option.WithEndpoint(endpoint.URL() + "/storage/v1/"),
+		option.WithoutAuthentication(),
+	}, nil
+}
+
+// PublicHTTPClient returns an HTTP client sending the requests of the public and the signed URLs of the objects,
+// e.g. https://storage.googleapis.com/bucket/object?X-Goog-Signature=..., to the emulator, so the tests use the URLs
+// signed by the code under test as is. The signatures are not checked by the emulator.
+func (c *FakeGCSContainer) PublicHTTPClient(ctx context.Context) (*http.Client, error) {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &publicHostTransport{publicHost: c.settings.publicHost, endpoint: endpoint, base: http.DefaultTransport},
+	}, nil
+}
+
+// publicHostTransport sends the requests of the public host to the endpoint of the emulator,
+// keeping their Host header, which routes them to the objects.
+type publicHostTransport struct {
+	publicHost string
+	endpoint   fakes.Endpoint
+	base       http.RoundTripper
+}
+
+// RoundTrip rewrites the URL of the request if its host is the public host, or a subdomain of it
+// in the virtual hosted style, e.g. bucket.storage.googleapis.com, then sends it.
+func (t *publicHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.matches(req.URL.Hostname()) {
+		return t.base.RoundTrip(req)
+	}
+
+	rewritten := req.Clone(req.Context())
+	rewritten.Host = req.URL.Host
+	rewritten.URL.Scheme = t.endpoint.Scheme
+	rewritten.URL.Host = t.endpoint.Address()
+
+	return t.base.RoundTrip(rewritten)
+}
+
+// matches returns true if the host is the public host, ignoring its port, or one of its subdomains.
+func (t *publicHostTransport) matches(host string) bool {
+	publicHost := t.publicHost
+	if h, _, err := net.SplitHostPort(publicHost); err == nil {
+		publicHost = h
+	}
+
+	return host == publicHost || strings.HasSuffix(host, "."+publicHost)
+}
+
+// Run creates an instance of the fake-gcs-server container type, creating the buckets and uploading the objects
+// set with the options once the server is ready.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*FakeGCSContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HTTPPort},
+		WaitingFor:   wait.ForHTTP("/storage/v1/b").WithPort(HTTPPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	genericContainerReq.Cmd = append([]string{
+		"-scheme", "http",
+		"-port", nat.Port(HTTPPort).Port(),
+		"-public-host", settings.publicHost,
+	}, genericContainerReq.Cmd...)
+
+	c := &FakeGCSContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container != nil {
+		c.Container = container
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// bootstrap sets the external URL of the emulator, used in the locations of the resumable uploads,
+// creates the buckets, and uploads the objects.
+func (c *FakeGCSContainer) bootstrap(ctx context.Context) error {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := testcontainers.HTTPClient(c, HTTPPort)
+
+	if err := api.Do(ctx, client, http.MethodPut, "/_internal/config", map[string]string{"externalUrl": endpoint.URL()}, nil); err != nil {
+		return fmt.Errorf("set external url: %w", err)
+	}
+
+	created := map[string]bool{}
+	buckets := append([]string{}, c.settings.buckets...)
+	for _, s := range c.settings.seeds {
+		buckets = append(buckets, s.bucket)
+	}
+
+	for _, bucket := range buckets {
+		if created[bucket] {
+			continue
+		}
+		if err := api.Do(ctx, client, http.MethodPost, "/storage/v1/b?project="+project, map[string]string{"name": bucket}, nil); err != nil {
+			return fmt.Errorf("create bucket %s: %w", bucket, err)
+		}
+		created[bucket] = true
+	}
+
+	for _, s := range c.settings.seeds {
+		if err := c.upload(ctx, client, s); err != nil {
+			return fmt.Errorf("upload objects of bucket %s: %w", s.bucket, err)
+		}
+	}
+
+	return nil
+}
+
+// upload uploads the files of the file system of the seed as the objects of its bucket.
+func (c *FakeGCSContainer) upload(ctx context.Context, client *http.Client, s seed) error {
+	return fs.WalkDir(s.fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(s.fsys, path)
+		if err != nil {
+			return err
+		}
+
+		target := "/upload/storage/v1/b/" + url.PathEscape(s.bucket) + "/o?uploadType=media&name=" + url.QueryEscape(path)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("upload %s: %w", path, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("upload %s: unexpected status code %d", path, resp.StatusCode)
+		}
+
+		return nil
+	})
+}
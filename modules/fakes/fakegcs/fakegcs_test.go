@@ -0,0 +1,75 @@
+package fakegcs_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/fakegcs"
+)
+
+const testImage = "fsouza/fake-gcs-server:1.50.2"
+
+func TestFakeGCS(t *testing.T) {
+	ctx := context.Background()
+
+	// withObjects {
+	ctr, err := fakegcs.Run(ctx, testImage,
+		fakegcs.WithBuckets("uploads"),
+		fakegcs.WithObjects("assets", fstest.MapFS{
+			"images/logo.png": {Data: []byte("png")},
+			"robots.txt":      {Data: []byte("User-agent: *")},
+		}),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// clientOptions {
+	opts, err := ctr.ClientOptions(ctx)
+	require.NoError(t, err)
+
+	svc, err := storage.NewService(ctx, opts...)
+	// }
+	require.NoError(t, err)
+
+	_, err = svc.Buckets.Get("uploads").Context(ctx).Do()
+	require.NoError(t, err)
+
+	objects, err := svc.Objects.List("assets").Context(ctx).Do()
+	require.NoError(t, err)
+
+	var names []string
+	for _, o := range objects.Items {
+		names = append(names, o.Name)
+	}
+	require.ElementsMatch(t, []string{"images/logo.png", "robots.txt"}, names)
+
+	resp, err := svc.Objects.Get("assets", "robots.txt").Context(ctx).Download()
+	require.NoError(t, err)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "User-agent: *", string(data))
+
+	// publicHTTPClient {
+	httpClient, err := ctr.PublicHTTPClient(ctx)
+	require.NoError(t, err)
+
+	// e.g. a URL signed by the code under test
+	signedURL := "https://storage.googleapis.com/assets/robots.txt?X-Goog-Algorithm=GOOG4-RSA-SHA256&X-Goog-Signature=abc"
+	resp, err = httpClient.Get(signedURL)
+	// }
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "User-agent: *", string(data))
+}
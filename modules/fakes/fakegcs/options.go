@@ -0,0 +1,60 @@
+package fakegcs
+
+import (
+	"io/fs"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// seed is a file system whose files are uploaded as the objects of a bucket.
+type seed struct {
+	bucket string
+	fsys   fs.FS
+}
+
+type options struct {
+	buckets    []string
+	seeds      []seed
+	publicHost string
+}
+
+func defaultOptions() options {
+	return options{
+		publicHost: DefaultPublicHost,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the fake-gcs-server container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithBuckets creates the given empty buckets once the server is ready.
+func WithBuckets(buckets ...string) Option {
+	return func(o *options) {
+		o.buckets = append(o.buckets, buckets...)
+	}
+}
+
+// WithObjects creates the bucket, if needed, and uploads the files of the file system as its objects once the server
+// is ready, named after their slash-separated paths in the file system, e.g. the files of an embed.FS or of a fstest.MapFS.
+func WithObjects(bucket string, fsys fs.FS) Option {
+	return func(o *options) {
+		o.seeds = append(o.seeds, seed{bucket: bucket, fsys: fsys})
+	}
+}
+
+// WithPublicHost sets the host of the public and signed URLs of the objects served by the server,
+// storage.googleapis.com by default, e.g. to match the URLs signed with a custom endpoint.
+func WithPublicHost(host string) Option {
+	return func(o *options) {
+		o.publicHost = host
+	}
+}
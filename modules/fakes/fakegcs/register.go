@@ -0,0 +1,17 @@
+package fakegcs
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "fakes/fakegcs",
+		DefaultImage: "fsouza/fake-gcs-server:1.50.2",
+		ExposedPorts: []string{HTTPPort},
+		Options: map[string]any{
+			"WithBuckets":    WithBuckets,
+			"WithObjects":    WithObjects,
+			"WithPublicHost": WithPublicHost,
+		},
+		Run: modules.Runner(Run),
+	})
+}
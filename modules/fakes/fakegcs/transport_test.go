@@ -0,0 +1,52 @@
+package fakegcs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes"
+)
+
+type recordingTransport struct {
+	req *http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestPublicHostTransport(t *testing.T) {
+	base := &recordingTransport{}
+	transport := &publicHostTransport{
+		publicHost: DefaultPublicHost,
+		endpoint:   fakes.Endpoint{Scheme: "http", Host: "localhost", Port: "32768"},
+		base:       base,
+	}
+
+	send := func(rawURL string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		require.NoError(t, err)
+		return base.req
+	}
+
+	req := send("https://storage.googleapis.com/uploads/report.csv?X-Goog-Signature=abc")
+	require.Equal(t, "http://localhost:32768/uploads/report.csv?X-Goog-Signature=abc", req.URL.String())
+	require.Equal(t, "storage.googleapis.com", req.Host)
+
+	req = send("https://uploads.storage.googleapis.com/report.csv")
+	require.Equal(t, "http://localhost:32768/report.csv", req.URL.String())
+	require.Equal(t, "uploads.storage.googleapis.com", req.Host)
+
+	req = send("https://example.com/report.csv")
+	require.Equal(t, "https://example.com/report.csv", req.URL.String())
+
+	transport.publicHost = "cdn.test:8080"
+	req = send("http://cdn.test:8080/uploads/report.csv")
+	require.Equal(t, "http://localhost:32768/uploads/report.csv", req.URL.String())
+	require.Equal(t, "cdn.test:8080", req.Host)
+}
@@ -0,0 +1,54 @@
+// Package fakes groups the modules of the fakes of third-party APIs, e.g. the payment or the mail APIs, in subpackages,
+// so the tests of the integrations with SaaS APIs run against local containers:
+//
+//   - stripemock: stripe-mock, the stateless mock of the Stripe API.
+//   - localstripe: localstripe, a stateful fake of the Stripe API, with webhooks.
+//   - smtp4dev: smtp4dev, a fake SMTP server with an API to read the received messages.
+//   - fakegcs: fake-gcs-server, the emulator of the Google Cloud Storage API.
+//
+// The containers of the fakes return the endpoints of their APIs as an Endpoint, and the configuration
+// of their clients, e.g. as a typed Config.
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Endpoint is the endpoint of an API of a fake.
+type Endpoint struct {
+	// Scheme is the scheme of the API, e.g. http or smtp.
+	Scheme string
+	Host   string
+	Port   string
+}
+
+// Address returns the host and the port of the endpoint, e.g. localhost:32768.
+func (e Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, e.Port)
+}
+
+// URL returns the URL of the endpoint, e.g. http://localhost:32768.
+func (e Endpoint) URL() string {
+	return e.Scheme + "://" + e.Address()
+}
+
+// HostEndpoint returns the endpoint of the given port of the container from the host, with the given scheme.
+func HostEndpoint(ctx context.Context, ctr testcontainers.Container, port nat.Port, scheme string) (Endpoint, error) {
+	host, err := ctr.Host(ctx)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("host: %w", err)
+	}
+
+	mappedPort, err := ctr.MappedPort(ctx, port)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("mapped port %s: %w", port, err)
+	}
+
+	return Endpoint{Scheme: scheme, Host: host, Port: mappedPort.Port()}, nil
+}
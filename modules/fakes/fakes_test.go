@@ -0,0 +1,17 @@
+package fakes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoint(t *testing.T) {
+	e := Endpoint{Scheme: "http", Host: "localhost", Port: "32768"}
+	require.Equal(t, "localhost:32768", e.Address())
+	require.Equal(t, "http://localhost:32768", e.URL())
+
+	e = Endpoint{Scheme: "smtp", Host: "::1", Port: "25"}
+	require.Equal(t, "[::1]:25", e.Address())
+	require.Equal(t, "smtp://[::1]:25", e.URL())
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/json":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.JSONEq(t, `{"name":"alice"}`, string(body))
+			_, _ = w.Write([]byte(`{"id":"cus_1"}`))
+		case "/form":
+			assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+			assert.Equal(t, "name=alice", string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	var out struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, Do(ctx, srv.Client(), http.MethodPost, srv.URL+"/json", map[string]string{"name": "alice"}, &out))
+	require.Equal(t, "cus_1", out.ID)
+
+	require.NoError(t, Do(ctx, srv.Client(), http.MethodPost, srv.URL+"/form", url.Values{"name": {"alice"}}, nil))
+
+	err := Do(ctx, srv.Client(), http.MethodGet, srv.URL+"/missing", nil, nil)
+	require.EqualError(t, err, "unexpected status code 404: not found")
+}
@@ -0,0 +1,37 @@
+package localstripe_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/localstripe"
+)
+
+func ExampleRun() {
+	// runLocalStripeContainer {
+	ctx := context.Background()
+
+	localstripeContainer, err := localstripe.Run(ctx, "adrienverge/localstripe:latest")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := localstripeContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := localstripeContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
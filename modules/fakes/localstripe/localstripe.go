@@ -0,0 +1,115 @@
+// Package localstripe is the module of localstripe, a fake of the Stripe API keeping the objects in memory,
+// e.g. the customers, the payment intents and the subscriptions, and sending the webhooks of their events.
+package localstripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/fakes"
+	"github.com/testcontainers/testcontainers-go/modules/fakes/internal/api"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HTTPPort is the port of the API.
+	HTTPPort = "8420/tcp"
+
+	// APIKey is a secret key accepted by localstripe, which accepts any test key.
+	APIKey = "sk_test_testcontainers"
+)
+
+// Config is the configuration of the Stripe clients of the tests.
+type Config struct {
+	// APIBase is the base URL of the API, e.g. http://localhost:32768, to be set as the URL of the API backend
+	// of the Stripe SDKs, e.g. stripe.BackendConfig.URL in stripe-go.
+	APIBase string
+	// APIKey is the secret key of the clients.
+	APIKey string
+}
+
+// Webhook is an endpoint receiving the events of the objects, signed with its secret.
+type Webhook struct {
+	// URL is the URL of the endpoint, reachable from the container, e.g. http://host.docker.internal:8080/webhooks.
+	URL string `json:"url"`
+	// Secret is the signing secret of the endpoint, e.g. whsec_test.
+	Secret string `json:"secret"`
+	// Events are the types of the events sent to the endpoint, e.g. invoice.paid. All the events are sent without events.
+	Events []string `json:"events,omitempty"`
+}
+
+// LocalStripeContainer represents the localstripe container type used in the module
+type LocalStripeContainer struct {
+	testcontainers.Container
+}
+
+// APIEndpoint returns the endpoint of the API from the host, e.g. http://localhost:32768.
+func (c *LocalStripeContainer) APIEndpoint(ctx context.Context) (fakes.Endpoint, error) {
+	return fakes.HostEndpoint(ctx, c, HTTPPort, "http")
+}
+
+// Config returns the configuration of the Stripe clients of the tests.
+func (c *LocalStripeContainer) Config(ctx context.Context) (Config, error) {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{APIBase: endpoint.URL(), APIKey: APIKey}, nil
+}
+
+// RegisterWebhook registers the webhook with the given ID, replacing the webhook with the same ID if any.
+func (c *LocalStripeContainer) RegisterWebhook(ctx context.Context, id string, webhook Webhook) error {
+	path := "/_config/webhooks/" + url.PathEscape(id)
+	if err := api.Do(ctx, testcontainers.HTTPClient(c, HTTPPort), http.MethodPost, path, webhook, nil); err != nil {
+		return fmt.Errorf("register webhook %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// Flush deletes all the objects, e.g. between the tests.
+func (c *LocalStripeContainer) Flush(ctx context.Context) error {
+	if err := api.Do(ctx, testcontainers.HTTPClient(c, HTTPPort), http.MethodDelete, "/_config/data", nil, nil); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+// Run creates an instance of the localstripe container type
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*LocalStripeContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HTTPPort},
+		WaitingFor: wait.ForHTTP("/v1/customers").
+			WithPort(HTTPPort).
+			WithBasicAuth(APIKey, ""),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *LocalStripeContainer
+	if container != nil {
+		c = &LocalStripeContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,78 @@
+package localstripe_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/localstripe"
+)
+
+// request sends a request to the API, with the secret key of the config, returning the status code
+// and decoding the response into out.
+func request(t *testing.T, cfg localstripe.Config, method string, path string, form url.Values, out any) int {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, cfg.APIBase+path, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(cfg.APIKey, "")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func TestLocalStripe(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := localstripe.Run(ctx, "adrienverge/localstripe:latest")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	cfg, err := ctr.Config(ctx)
+	require.NoError(t, err)
+
+	var customer struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+	status := request(t, cfg, http.MethodPost, "/v1/customers", url.Values{"email": {"alice@example.com"}}, &customer)
+	require.Equal(t, http.StatusOK, status)
+
+	// the customer is kept, unlike with stripe-mock
+	var retrieved struct {
+		Email string `json:"email"`
+	}
+	status = request(t, cfg, http.MethodGet, "/v1/customers/"+customer.ID, nil, &retrieved)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "alice@example.com", retrieved.Email)
+
+	// registerWebhook {
+	err = ctr.RegisterWebhook(ctx, "orders", localstripe.Webhook{
+		URL:    "http://host.docker.internal:8080/webhooks",
+		Secret: "whsec_test",
+		Events: []string{"customer.created"},
+	})
+	// }
+	require.NoError(t, err)
+
+	// flush {
+	err = ctr.Flush(ctx)
+	// }
+	require.NoError(t, err)
+
+	status = request(t, cfg, http.MethodGet, "/v1/customers/"+customer.ID, nil, nil)
+	require.Equal(t, http.StatusNotFound, status)
+}
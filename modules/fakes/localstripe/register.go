@@ -0,0 +1,12 @@
+package localstripe
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "fakes/localstripe",
+		DefaultImage: "adrienverge/localstripe:latest",
+		ExposedPorts: []string{HTTPPort},
+		Run:          modules.Runner(Run),
+	})
+}
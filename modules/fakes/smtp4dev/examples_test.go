@@ -0,0 +1,37 @@
+package smtp4dev_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/smtp4dev"
+)
+
+func ExampleRun() {
+	// runSmtp4devContainer {
+	ctx := context.Background()
+
+	smtp4devContainer, err := smtp4dev.Run(ctx, "rnwood/smtp4dev:3.6.1")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := smtp4devContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := smtp4devContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
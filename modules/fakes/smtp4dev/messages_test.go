@@ -0,0 +1,34 @@
+package smtp4dev
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessages(t *testing.T) {
+	message := `{"id":"8d6c1f0e","from":"orders@example.com","to":["alice@example.com"],"subject":"Order confirmed","receivedDate":"2024-06-01T10:00:00Z","isUnread":true}`
+
+	expected := []Message{{ID: "8d6c1f0e", From: "orders@example.com", To: []string{"alice@example.com"}, Subject: "Order confirmed"}}
+
+	messages, err := parseMessages(json.RawMessage("[" + message + "]"))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, 2024, messages[0].ReceivedDate.Year())
+	messages[0].ReceivedDate = expected[0].ReceivedDate
+	require.Equal(t, expected, messages)
+
+	messages, err = parseMessages(json.RawMessage(`{"currentPage":1,"pageCount":1,"results":[` + message + `]}`))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	messages[0].ReceivedDate = expected[0].ReceivedDate
+	require.Equal(t, expected, messages)
+
+	messages, err = parseMessages(json.RawMessage(`{"results":null}`))
+	require.NoError(t, err)
+	require.Empty(t, messages)
+
+	_, err = parseMessages(json.RawMessage(`"unexpected"`))
+	require.ErrorContains(t, err, "decode messages")
+}
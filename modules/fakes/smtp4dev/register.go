@@ -0,0 +1,12 @@
+package smtp4dev
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "fakes/smtp4dev",
+		DefaultImage: "rnwood/smtp4dev:3.6.1",
+		ExposedPorts: []string{SMTPPort, IMAPPort, HTTPPort},
+		Run:          modules.Runner(Run),
+	})
+}
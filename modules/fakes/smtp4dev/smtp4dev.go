@@ -0,0 +1,174 @@
+// Package smtp4dev is the module of smtp4dev, a fake SMTP server keeping the received messages,
+// which the tests read with its API, instead of delivering them.
+package smtp4dev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/fakes"
+	"github.com/testcontainers/testcontainers-go/modules/fakes/internal/api"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// SMTPPort is the port of the SMTP server.
+	SMTPPort = "25/tcp"
+	// IMAPPort is the port of the IMAP server, serving the received messages.
+	IMAPPort = "143/tcp"
+	// HTTPPort is the port of the web interface and of the API.
+	HTTPPort = "80/tcp"
+)
+
+// Config is the configuration of the SMTP clients of the tests.
+type Config struct {
+	// Host is the host of the SMTP server, e.g. localhost.
+	Host string
+	// Port is the port of the SMTP server, without TLS nor authentication.
+	Port int
+}
+
+// Message is the summary of a received message.
+type Message struct {
+	ID           string    `json:"id"`
+	From         string    `json:"from"`
+	To           []string  `json:"to"`
+	Subject      string    `json:"subject"`
+	ReceivedDate time.Time `json:"receivedDate"`
+}
+
+// Smtp4devContainer represents the smtp4dev container type used in the module
+type Smtp4devContainer struct {
+	testcontainers.Container
+}
+
+// SMTPEndpoint returns the endpoint of the SMTP server from the host, e.g. smtp://localhost:32768.
+func (c *Smtp4devContainer) SMTPEndpoint(ctx context.Context) (fakes.Endpoint, error) {
+	return fakes.HostEndpoint(ctx, c, SMTPPort, "smtp")
+}
+
+// WebEndpoint returns the endpoint of the web interface and of the API from the host, e.g. http://localhost:32769.
+func (c *Smtp4devContainer) WebEndpoint(ctx context.Context) (fakes.Endpoint, error) {
+	return fakes.HostEndpoint(ctx, c, HTTPPort, "http")
+}
+
+// Config returns the configuration of the SMTP clients of the tests.
+func (c *Smtp4devContainer) Config(ctx context.Context) (Config, error) {
+	endpoint, err := c.SMTPEndpoint(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
+	port, err := strconv.Atoi(endpoint.Port)
+	if err != nil {
+		return Config{}, fmt.Errorf("parse port: %w", err)
+	}
+
+	return Config{Host: endpoint.Host, Port: port}, nil
+}
+
+// Messages returns the summaries of the received messages, the most recent first.
+func (c *Smtp4devContainer) Messages(ctx context.Context) ([]Message, error) {
+	var raw json.RawMessage
+	if err := api.Do(ctx, testcontainers.HTTPClient(c, HTTPPort), http.MethodGet, "/api/messages?pageSize=1000", nil, &raw); err != nil {
+		return nil, fmt.Errorf("list messages: %w", err)
+	}
+
+	return parseMessages(raw)
+}
+
+// PlainText returns the plain text body of the message with the given ID.
+func (c *Smtp4devContainer) PlainText(ctx context.Context, id string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/api/messages/"+url.PathEscape(id)+"/plaintext", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := testcontainers.HTTPClient(c, HTTPPort).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get message %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read message %s: %w", id, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get message %s: unexpected status code %d: %s", id, resp.StatusCode, body)
+	}
+
+	return string(body), nil
+}
+
+// DeleteMessages deletes all the received messages, e.g. between the tests.
+func (c *Smtp4devContainer) DeleteMessages(ctx context.Context) error {
+	if err := api.Do(ctx, testcontainers.HTTPClient(c, HTTPPort), http.MethodDelete, "/api/messages/*", nil, nil); err != nil {
+		return fmt.Errorf("delete messages: %w", err)
+	}
+
+	return nil
+}
+
+// parseMessages parses the response of the list of the messages, which is paged since smtp4dev 3.2.
+func parseMessages(raw json.RawMessage) ([]Message, error) {
+	messages := []Message{}
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		return messages, nil
+	}
+
+	var page struct {
+		Results []Message `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, fmt.Errorf("decode messages: %w", err)
+	}
+	if page.Results == nil {
+		return []Message{}, nil
+	}
+
+	return page.Results, nil
+}
+
+// Run creates an instance of the smtp4dev container type
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*Smtp4devContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{SMTPPort, IMAPPort, HTTPPort},
+		WaitingFor: wait.ForAll(
+			wait.ForHTTP("/api/messages").WithPort(HTTPPort),
+			wait.ForListeningPort(SMTPPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *Smtp4devContainer
+	if container != nil {
+		c = &Smtp4devContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,53 @@
+package smtp4dev_test
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/smtp4dev"
+)
+
+func TestSmtp4dev(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := smtp4dev.Run(ctx, "rnwood/smtp4dev:3.6.1")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// config {
+	cfg, err := ctr.Config(ctx)
+	// }
+	require.NoError(t, err)
+
+	msg := "From: orders@example.com\r\nTo: alice@example.com\r\nSubject: Order confirmed\r\n\r\nYour order is confirmed.\r\n"
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	require.NoError(t, smtp.SendMail(addr, nil, "orders@example.com", []string{"alice@example.com"}, []byte(msg)))
+
+	// messages {
+	var messages []smtp4dev.Message
+	require.Eventually(t, func() bool {
+		messages, err = ctr.Messages(ctx)
+		return err == nil && len(messages) == 1
+	}, 10*time.Second, 200*time.Millisecond)
+	// }
+
+	require.Equal(t, "Order confirmed", messages[0].Subject)
+	require.Equal(t, []string{"alice@example.com"}, messages[0].To)
+
+	text, err := ctr.PlainText(ctx, messages[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "Your order is confirmed.", strings.TrimSpace(text))
+
+	require.NoError(t, ctr.DeleteMessages(ctx))
+
+	messages, err = ctr.Messages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
@@ -0,0 +1,37 @@
+package stripemock_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/stripemock"
+)
+
+func ExampleRun() {
+	// runStripeMockContainer {
+	ctx := context.Background()
+
+	stripemockContainer, err := stripemock.Run(ctx, "stripe/stripe-mock:v0.188.0")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := stripemockContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := stripemockContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,12 @@
+package stripemock
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "fakes/stripemock",
+		DefaultImage: "stripe/stripe-mock:v0.188.0",
+		ExposedPorts: []string{HTTPPort, HTTPSPort},
+		Run:          modules.Runner(Run),
+	})
+}
@@ -0,0 +1,85 @@
+// Package stripemock is the module of stripe-mock, the mock of the Stripe API, answering the requests
+// with the fixtures of the OpenAPI specification of the API, without state.
+package stripemock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/fakes"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HTTPPort is the port of the API over HTTP.
+	HTTPPort = "12111/tcp"
+	// HTTPSPort is the port of the API over HTTPS, with a self-signed certificate.
+	HTTPSPort = "12112/tcp"
+
+	// APIKey is a secret key accepted by stripe-mock, which accepts any test key.
+	APIKey = "sk_test_testcontainers"
+)
+
+// Config is the configuration of the Stripe clients of the tests.
+type Config struct {
+	// APIBase is the base URL of the API, e.g. http://localhost:32768, to be set as the URL of the API backend
+	// of the Stripe SDKs, e.g. stripe.BackendConfig.URL in stripe-go.
+	APIBase string
+	// APIKey is the secret key of the clients.
+	APIKey string
+}
+
+// StripeMockContainer represents the stripe-mock container type used in the module
+type StripeMockContainer struct {
+	testcontainers.Container
+}
+
+// APIEndpoint returns the endpoint of the API over HTTP from the host, e.g. http://localhost:32768.
+func (c *StripeMockContainer) APIEndpoint(ctx context.Context) (fakes.Endpoint, error) {
+	return fakes.HostEndpoint(ctx, c, HTTPPort, "http")
+}
+
+// Config returns the configuration of the Stripe clients of the tests.
+func (c *StripeMockContainer) Config(ctx context.Context) (Config, error) {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{APIBase: endpoint.URL(), APIKey: APIKey}, nil
+}
+
+// Run creates an instance of the stripe-mock container type
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*StripeMockContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HTTPPort, HTTPSPort},
+		WaitingFor: wait.ForHTTP("/v1/customers").
+			WithPort(HTTPPort).
+			WithHeaders(map[string]string{"Authorization": "Bearer " + APIKey}),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *StripeMockContainer
+	if container != nil {
+		c = &StripeMockContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,47 @@
+package stripemock_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/fakes/stripemock"
+)
+
+func TestStripeMock(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := stripemock.Run(ctx, "stripe/stripe-mock:v0.188.0")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// config {
+	cfg, err := ctr.Config(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(cfg.APIBase, "http://"))
+
+	form := url.Values{"email": {"alice@example.com"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.APIBase+"/v1/customers", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(cfg.APIKey, "")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var customer struct {
+		Object string `json:"object"`
+		Email  string `json:"email"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&customer))
+	require.Equal(t, "customer", customer.Object)
+	require.Equal(t, "alice@example.com", customer.Email)
+}
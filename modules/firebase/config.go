@@ -0,0 +1,189 @@
+package firebase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Emulator is an emulator of the Firebase emulator suite.
+type Emulator string
+
+// The emulators supported by the module.
+const (
+	EmulatorAuth      Emulator = "auth"
+	EmulatorFirestore Emulator = "firestore"
+	EmulatorFunctions Emulator = "functions"
+)
+
+const (
+	// HubPort is the port of the hub of the emulators, listing the running emulators.
+	HubPort = "4400/tcp"
+
+	// sourceDir is the directory the project is copied to, and workDir the directory the emulators run in,
+	// as the user of the image doesn't own the copied files, while the CLI writes its logs and the functions
+	// their dependencies next to them.
+	sourceDir = "/srv/firebase"
+	workDir   = "/tmp/firebase"
+)
+
+// ports are the ports of the emulators in the container.
+var ports = map[Emulator]int{
+	EmulatorAuth:      9099,
+	EmulatorFirestore: 8080,
+	EmulatorFunctions: 5001,
+}
+
+// Port returns the port of the emulator in the container, e.g. 9099/tcp for the auth emulator.
+func (e Emulator) Port() string {
+	return strconv.Itoa(ports[e]) + "/tcp"
+}
+
+// projectIDPattern matches the IDs of the Firebase projects.
+var projectIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+// Config is the firebase.json configuration of the project of the emulators, generated from the options.
+// It can be modified with the WithConfig option before the container is created.
+type Config struct {
+	Emulators EmulatorsConfig  `json:"emulators"`
+	Firestore *FirestoreConfig `json:"firestore,omitempty"`
+	Functions []FunctionConfig `json:"functions,omitempty"`
+}
+
+// EmulatorsConfig is the configuration of the emulators.
+type EmulatorsConfig struct {
+	SingleProjectMode bool            `json:"singleProjectMode"`
+	Hub               EndpointConfig  `json:"hub"`
+	UI                UIConfig        `json:"ui"`
+	Auth              *EndpointConfig `json:"auth,omitempty"`
+	Firestore         *EndpointConfig `json:"firestore,omitempty"`
+	Functions         *EndpointConfig `json:"functions,omitempty"`
+}
+
+// EndpointConfig is the host and the port an emulator listens on.
+type EndpointConfig struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// UIConfig is the configuration of the emulator UI, disabled by the module.
+type UIConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// FirestoreConfig is the configuration of Firestore, its security rules and its indexes.
+type FirestoreConfig struct {
+	Rules   string `json:"rules,omitempty"`
+	Indexes string `json:"indexes,omitempty"`
+}
+
+// FunctionConfig is the configuration of a codebase of Cloud Functions.
+type FunctionConfig struct {
+	Source   string `json:"source"`
+	Codebase string `json:"codebase,omitempty"`
+}
+
+// config returns the configuration of the emulators of the options.
+func (o options) config() Config {
+	cfg := Config{
+		Emulators: EmulatorsConfig{
+			SingleProjectMode: true,
+			Hub:               EndpointConfig{Host: "0.0.0.0", Port: 4400},
+		},
+	}
+
+	for _, e := range o.emulators() {
+		endpoint := &EndpointConfig{Host: "0.0.0.0", Port: ports[e]}
+		switch e {
+		case EmulatorAuth:
+			cfg.Emulators.Auth = endpoint
+		case EmulatorFirestore:
+			cfg.Emulators.Firestore = endpoint
+			cfg.Firestore = &FirestoreConfig{}
+			if o.firestoreRules != "" {
+				cfg.Firestore.Rules = "firestore.rules"
+			}
+		case EmulatorFunctions:
+			cfg.Emulators.Functions = endpoint
+			cfg.Functions = []FunctionConfig{{Source: "functions"}}
+		}
+	}
+
+	for _, modify := range o.configModifiers {
+		modify(&cfg)
+	}
+
+	return cfg
+}
+
+// configJSON returns the firebase.json file of the configuration.
+func configJSON(cfg Config) ([]byte, error) {
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// emulators returns the emulators of the configuration, sorted by name.
+func (cfg Config) emulators() []Emulator {
+	var emulators []Emulator
+	if cfg.Emulators.Auth != nil {
+		emulators = append(emulators, EmulatorAuth)
+	}
+	if cfg.Emulators.Firestore != nil {
+		emulators = append(emulators, EmulatorFirestore)
+	}
+	if cfg.Emulators.Functions != nil {
+		emulators = append(emulators, EmulatorFunctions)
+	}
+
+	return emulators
+}
+
+// emulators returns the emulators enabled by the options, auth and firestore by default, sorted by name.
+func (o options) emulators() []Emulator {
+	if len(o.enabled) == 0 {
+		return []Emulator{EmulatorAuth, EmulatorFirestore}
+	}
+
+	emulators := make([]Emulator, 0, len(o.enabled))
+	for e := range o.enabled {
+		emulators = append(emulators, e)
+	}
+	sort.Slice(emulators, func(i, j int) bool { return emulators[i] < emulators[j] })
+
+	return emulators
+}
+
+// validate checks the project ID and that the configuration runs at least one emulator.
+func (cfg Config) validate(projectID string) error {
+	if !projectIDPattern.MatchString(projectID) {
+		return fmt.Errorf("invalid project id %q", projectID)
+	}
+
+	if len(cfg.emulators()) == 0 {
+		return errors.New("no emulator")
+	}
+
+	return nil
+}
+
+// startScript returns the command starting the emulators of the project, installing the dependencies
+// of the functions first, if any.
+func startScript(projectID string, emulators []Emulator) string {
+	only := make([]string, 0, len(emulators))
+	for _, e := range emulators {
+		only = append(only, string(e))
+	}
+
+	return fmt.Sprintf(`set -e
+mkdir -p %[1]s
+cp -R %[2]s/. %[1]s
+cd %[1]s
+if [ -f functions/package.json ]; then
+  (cd functions && npm install --no-audit --no-fund)
+fi
+exec firebase emulators:start --project %[3]s --only %[4]s
+`, workDir, sourceDir, projectID, strings.Join(only, ","))
+}
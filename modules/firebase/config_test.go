@@ -0,0 +1,79 @@
+package firebase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cfg := defaultOptions().config()
+
+		data, err := configJSON(cfg)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"emulators": {
+				"singleProjectMode": true,
+				"hub": {"host": "0.0.0.0", "port": 4400},
+				"ui": {"enabled": false},
+				"auth": {"host": "0.0.0.0", "port": 9099},
+				"firestore": {"host": "0.0.0.0", "port": 8080}
+			},
+			"firestore": {}
+		}`, string(data))
+		require.Equal(t, []Emulator{EmulatorAuth, EmulatorFirestore}, cfg.emulators())
+	})
+
+	t.Run("functions-rules", func(t *testing.T) {
+		o := defaultOptions()
+		WithFunctions("testdata/functions")(&o)
+		WithFirestoreRules("testdata/firestore.rules")(&o)
+		cfg := o.config()
+
+		data, err := configJSON(cfg)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"emulators": {
+				"singleProjectMode": true,
+				"hub": {"host": "0.0.0.0", "port": 4400},
+				"ui": {"enabled": false},
+				"firestore": {"host": "0.0.0.0", "port": 8080},
+				"functions": {"host": "0.0.0.0", "port": 5001}
+			},
+			"firestore": {"rules": "firestore.rules"},
+			"functions": [{"source": "functions"}]
+		}`, string(data))
+		require.Equal(t, []Emulator{EmulatorFirestore, EmulatorFunctions}, cfg.emulators())
+	})
+
+	t.Run("modifier", func(t *testing.T) {
+		o := defaultOptions()
+		WithAuth()(&o)
+		WithConfig(func(cfg *Config) {
+			cfg.Emulators.Auth.Port = 9199
+			cfg.Emulators.UI.Enabled = true
+		})(&o)
+		cfg := o.config()
+
+		require.Equal(t, &EndpointConfig{Host: "0.0.0.0", Port: 9199}, cfg.Emulators.Auth)
+		require.True(t, cfg.Emulators.UI.Enabled)
+		require.Nil(t, cfg.Firestore)
+	})
+}
+
+func TestConfig_validate(t *testing.T) {
+	cfg := defaultOptions().config()
+	require.NoError(t, cfg.validate(DefaultProjectID))
+	require.ErrorContains(t, cfg.validate("Demo_Project"), "invalid project id")
+
+	require.ErrorContains(t, Config{}.validate(DefaultProjectID), "no emulator")
+}
+
+func TestStartScript(t *testing.T) {
+	script := startScript("demo-app", []Emulator{EmulatorAuth, EmulatorFunctions})
+
+	require.Contains(t, script, "cp -R /srv/firebase/. /tmp/firebase\n")
+	require.Contains(t, script, "(cd functions && npm install --no-audit --no-fund)")
+	require.Contains(t, script, "exec firebase emulators:start --project demo-app --only auth,functions\n")
+}
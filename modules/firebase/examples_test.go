@@ -0,0 +1,48 @@
+package firebase_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/firebase"
+)
+
+func ExampleRun() {
+	// runFirebaseContainer {
+	ctx := context.Background()
+
+	firebaseContainer, err := firebase.Run(ctx, "andreysenov/firebase-tools:13.16.0-node-20",
+		firebase.WithAuth(),
+		firebase.WithFirestore(),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := firebaseContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	// firebaseEnv {
+	env, err := firebaseContainer.Env(ctx)
+	if err != nil {
+		log.Fatalf("failed to get environment: %s", err) // nolint:gocritic
+	}
+	// e.g. set the variables with os.Setenv before creating the Firebase Admin SDK app
+	// }
+
+	state, err := firebaseContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err)
+	}
+
+	fmt.Println(state.Running, env["GCLOUD_PROJECT"])
+
+	// Output:
+	// true demo-testcontainers
+}
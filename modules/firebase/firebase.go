@@ -0,0 +1,193 @@
+package firebase
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// FirebaseContainer represents the Firebase container type used in the module. It runs the emulators
+// of the Firebase emulator suite, to test the backends of the mobile and web applications without
+// a real Firebase project.
+type FirebaseContainer struct {
+	testcontainers.Container
+	projectID string
+	emulators []Emulator
+}
+
+// ProjectID returns the ID of the project of the emulators.
+func (c *FirebaseContainer) ProjectID() string {
+	return c.projectID
+}
+
+// Emulators returns the emulators running in the container, sorted by name.
+func (c *FirebaseContainer) Emulators() []Emulator {
+	return slices.Clone(c.emulators)
+}
+
+// EmulatorHost returns the host and the mapped port of the emulator, e.g. localhost:32768,
+// to be used as the address of the emulator in the SDKs.
+func (c *FirebaseContainer) EmulatorHost(ctx context.Context, emulator Emulator) (string, error) {
+	if !slices.Contains(c.emulators, emulator) {
+		return "", fmt.Errorf("emulator %s not running", emulator)
+	}
+
+	return c.hostPort(ctx, emulator.Port())
+}
+
+// FunctionURL returns the URL of the HTTP function of the functions emulator, deployed in the region,
+// e.g. http://localhost:32768/demo-testcontainers/us-central1/hello.
+func (c *FirebaseContainer) FunctionURL(ctx context.Context, region string, name string) (string, error) {
+	host, err := c.EmulatorHost(ctx, EmulatorFunctions)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + host + "/" + c.projectID + "/" + region + "/" + name, nil
+}
+
+// Env returns the environment variables making the Firebase Admin SDKs, and the Google Cloud client
+// libraries, use the emulators of the container instead of the real services, e.g. FIRESTORE_EMULATOR_HOST.
+func (c *FirebaseContainer) Env(ctx context.Context) (map[string]string, error) {
+	hub, err := c.hostPort(ctx, HubPort)
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{
+		"GCLOUD_PROJECT":        c.projectID,
+		"FIREBASE_EMULATOR_HUB": hub,
+	}
+
+	// the functions are called with their URL, see FunctionURL
+	for _, e := range c.emulators {
+		if e == EmulatorFunctions {
+			continue
+		}
+
+		host, err := c.EmulatorHost(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+
+		switch e {
+		case EmulatorAuth:
+			env["FIREBASE_AUTH_EMULATOR_HOST"] = host
+		case EmulatorFirestore:
+			env["FIRESTORE_EMULATOR_HOST"] = host
+		}
+	}
+
+	return env, nil
+}
+
+// hostPort returns the host and the mapped port of the port of the container.
+func (c *FirebaseContainer) hostPort(ctx context.Context, port string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("host: %w", err)
+	}
+
+	mapped, err := c.MappedPort(ctx, nat.Port(port))
+	if err != nil {
+		return "", fmt.Errorf("mapped port %s: %w", port, err)
+	}
+
+	return net.JoinHostPort(host, mapped.Port()), nil
+}
+
+// Run creates an instance of the Firebase container type. The image must provide the Firebase CLI,
+// e.g. andreysenov/firebase-tools. It runs the auth and firestore emulators by default.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*FirebaseContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HubPort},
+		Entrypoint:   []string{"sh", "-c"},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := settings.config()
+	if err := cfg.validate(settings.projectID); err != nil {
+		return nil, err
+	}
+
+	data, err := configJSON(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("firebase.json: %w", err)
+	}
+
+	if err := testcontainers.WithFileContent(sourceDir+"/firebase.json", data, 0o644)(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
+	if settings.firestoreRules != "" {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      settings.firestoreRules,
+			ContainerFilePath: sourceDir + "/firestore.rules",
+			FileMode:          0o644,
+		})
+	}
+
+	if settings.functions != "" {
+		if err := testcontainers.WithFS(sourceDir+"/functions", os.DirFS(settings.functions))(&genericContainerReq); err != nil {
+			return nil, fmt.Errorf("functions: %w", err)
+		}
+	}
+
+	emulators := cfg.emulators()
+	genericContainerReq.Cmd = []string{startScript(settings.projectID, emulators)}
+
+	strategies := []wait.Strategy{
+		wait.ForLog("All emulators ready!"),
+		wait.ForHTTP("/emulators").WithPort(HubPort),
+	}
+	for _, e := range emulators {
+		genericContainerReq.ExposedPorts = append(genericContainerReq.ExposedPorts, e.Port())
+
+		switch e {
+		case EmulatorAuth, EmulatorFirestore:
+			strategies = append(strategies, wait.ForHTTP("/").WithPort(nat.Port(e.Port())))
+		default:
+			strategies = append(strategies, wait.ForListeningPort(nat.Port(e.Port())))
+		}
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		// installing the dependencies of the functions can take a while
+		genericContainerReq.WaitingFor = wait.ForAll(strategies...).WithStartupTimeoutDefault(5 * time.Minute)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *FirebaseContainer
+	if container != nil {
+		c = &FirebaseContainer{Container: container, projectID: settings.projectID, emulators: emulators}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
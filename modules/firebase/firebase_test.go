@@ -0,0 +1,139 @@
+package firebase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/firebase"
+)
+
+const image = "andreysenov/firebase-tools:13.16.0-node-20"
+
+// post sends the JSON body to the URL, returning the decoded JSON response.
+func post(t *testing.T, url string, body any) map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(respBody))
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(respBody, &out))
+
+	return out
+}
+
+func TestFirebase(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := firebase.Run(ctx, image, firebase.WithProjectID("demo-mobile"))
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "demo-mobile", ctr.ProjectID())
+	require.Equal(t, []firebase.Emulator{firebase.EmulatorAuth, firebase.EmulatorFirestore}, ctr.Emulators())
+
+	t.Run("auth", func(t *testing.T) {
+		host, err := ctr.EmulatorHost(ctx, firebase.EmulatorAuth)
+		require.NoError(t, err)
+
+		user := post(t, "http://"+host+"/identitytoolkit.googleapis.com/v1/accounts:signUp?key=fake-api-key", map[string]any{
+			"email":             "alice@example.com",
+			"password":          "s3cr3t!",
+			"returnSecureToken": true,
+		})
+		require.NotEmpty(t, user["localId"])
+		require.NotEmpty(t, user["idToken"])
+	})
+
+	t.Run("firestore", func(t *testing.T) {
+		host, err := ctr.EmulatorHost(ctx, firebase.EmulatorFirestore)
+		require.NoError(t, err)
+
+		documents := "http://" + host + "/v1/projects/demo-mobile/databases/(default)/documents/users"
+		doc := post(t, documents+"?documentId=alice", map[string]any{
+			"fields": map[string]any{"name": map[string]any{"stringValue": "Alice"}},
+		})
+		require.Contains(t, doc["name"], "/documents/users/alice")
+
+		resp, err := http.Get(documents + "/alice")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		env, err := ctr.Env(ctx)
+		require.NoError(t, err)
+
+		auth, err := ctr.EmulatorHost(ctx, firebase.EmulatorAuth)
+		require.NoError(t, err)
+
+		require.Equal(t, auth, env["FIREBASE_AUTH_EMULATOR_HOST"])
+		require.NotEmpty(t, env["FIRESTORE_EMULATOR_HOST"])
+		require.NotEmpty(t, env["FIREBASE_EMULATOR_HUB"])
+		require.Equal(t, "demo-mobile", env["GCLOUD_PROJECT"])
+	})
+
+	t.Run("not-running", func(t *testing.T) {
+		_, err := ctr.FunctionURL(ctx, "us-central1", "hello")
+		require.ErrorContains(t, err, "emulator functions not running")
+	})
+}
+
+func TestFirebase_functions(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := firebase.Run(ctx, image,
+		firebase.WithFunctions("testdata/functions"),
+		firebase.WithFirestoreRules("testdata/firestore.rules"),
+	)
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	url, err := ctr.FunctionURL(ctx, "us-central1", "hello")
+	require.NoError(t, err)
+
+	resp, err := http.Get(url + "?name=tests")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "Hello, tests!", string(body))
+
+	// the rules only allow the public collection
+	host, err := ctr.EmulatorHost(ctx, firebase.EmulatorFirestore)
+	require.NoError(t, err)
+
+	denied, err := http.Get("http://" + host + "/v1/projects/" + firebase.DefaultProjectID + "/databases/(default)/documents/private/doc")
+	require.NoError(t, err)
+	defer denied.Body.Close()
+	require.Equal(t, http.StatusForbidden, denied.StatusCode)
+}
+
+func TestRun_invalidProjectID(t *testing.T) {
+	_, err := firebase.Run(context.Background(), image, firebase.WithProjectID("Not a project"))
+	require.ErrorContains(t, err, "invalid project id")
+}
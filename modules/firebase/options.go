@@ -0,0 +1,83 @@
+package firebase
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// DefaultProjectID is the ID of the project of the emulators. The IDs starting with demo- are
+// demo projects, which the emulators run without credentials nor access to the real services.
+const DefaultProjectID = "demo-testcontainers"
+
+type options struct {
+	projectID       string
+	enabled         map[Emulator]bool
+	functions       string
+	firestoreRules  string
+	configModifiers []func(*Config)
+}
+
+func defaultOptions() options {
+	return options{
+		projectID: DefaultProjectID,
+		enabled:   map[Emulator]bool{},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Firebase container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithProjectID sets the ID of the project of the emulators, DefaultProjectID by default.
+func WithProjectID(id string) Option {
+	return func(o *options) {
+		o.projectID = id
+	}
+}
+
+// WithAuth runs the auth emulator. Without any of WithAuth, WithFirestore and WithFunctions,
+// the auth and firestore emulators run.
+func WithAuth() Option {
+	return func(o *options) {
+		o.enabled[EmulatorAuth] = true
+	}
+}
+
+// WithFirestore runs the firestore emulator, allowing all the requests.
+func WithFirestore() Option {
+	return func(o *options) {
+		o.enabled[EmulatorFirestore] = true
+	}
+}
+
+// WithFirestoreRules runs the firestore emulator with the security rules of the file, e.g. firestore.rules.
+func WithFirestoreRules(path string) Option {
+	return func(o *options) {
+		o.enabled[EmulatorFirestore] = true
+		o.firestoreRules = path
+	}
+}
+
+// WithFunctions runs the functions emulator with the Cloud Functions of the directory, which contains
+// their package.json. Their dependencies are installed when the container starts.
+func WithFunctions(dir string) Option {
+	return func(o *options) {
+		o.enabled[EmulatorFunctions] = true
+		o.functions = dir
+	}
+}
+
+// WithConfig modifies the firebase.json configuration generated from the options, e.g. to set
+// the codebase of the functions or to enable the UI.
+func WithConfig(modifier func(cfg *Config)) Option {
+	return func(o *options) {
+		o.configModifiers = append(o.configModifiers, modifier)
+	}
+}
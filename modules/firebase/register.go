@@ -0,0 +1,20 @@
+package firebase
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "firebase",
+		DefaultImage: "andreysenov/firebase-tools:13.16.0-node-20",
+		ExposedPorts: []string{HubPort, EmulatorAuth.Port(), EmulatorFirestore.Port()},
+		Options: map[string]any{
+			"WithAuth":           WithAuth,
+			"WithConfig":         WithConfig,
+			"WithFirestore":      WithFirestore,
+			"WithFirestoreRules": WithFirestoreRules,
+			"WithFunctions":      WithFunctions,
+			"WithProjectID":      WithProjectID,
+		},
+		Run: modules.Runner(Run),
+	})
+}
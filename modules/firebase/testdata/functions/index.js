@@ -0,0 +1,5 @@
+const { onRequest } = require("firebase-functions/v2/https");
+
+exports.hello = onRequest((req, res) => {
+  res.send(`Hello, ${req.query.name || "world"}!`);
+});
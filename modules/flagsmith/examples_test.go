@@ -0,0 +1,39 @@
+package flagsmith_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/flagsmith"
+)
+
+func ExampleRun() {
+	// runFlagsmithContainer {
+	ctx := context.Background()
+
+	flagsmithContainer, err := flagsmith.Run(ctx, "flagsmith/flagsmith:2.130",
+		flagsmith.WithFlags(flagsmith.Flag{Name: "new_checkout", Enabled: true}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container and its database
+	defer func() {
+		if err := flagsmithContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := flagsmithContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
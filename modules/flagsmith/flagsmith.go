@@ -0,0 +1,261 @@
+package flagsmith
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "8000/tcp"
+
+	postgresAlias = "flagsmith-db"
+	databaseURL   = "postgresql://flagsmith:flagsmith@" + postgresAlias + ":5432/flagsmith"
+)
+
+// FlagsmithContainer represents the Flagsmith container type used in the module.
+// The Postgres container used as its database is terminated with it.
+type FlagsmithContainer struct {
+	testcontainers.Container
+	postgres testcontainers.Container
+	network  *testcontainers.DockerNetwork
+	settings options
+	apiToken string
+	sdkKey   string
+}
+
+// APIToken returns the token of the user signed up when the container started,
+// to be sent in the Authorization header of the requests to the admin API, as "Token <token>".
+func (c *FlagsmithContainer) APIToken() string {
+	return c.apiToken
+}
+
+// SDKKey returns the key of the environment of the project, to be used as the environment key of the SDKs.
+func (c *FlagsmithContainer) SDKKey() string {
+	return c.sdkKey
+}
+
+// URL returns the URL of the server, e.g. http://localhost:8000.
+func (c *FlagsmithContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// APIURL returns the URL of the API of the server, e.g. http://localhost:8000/api/v1/,
+// to be used as the API URL of the SDKs.
+func (c *FlagsmithContainer) APIURL(ctx context.Context) (string, error) {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/api/v1/", nil
+}
+
+// Terminate terminates the Flagsmith container, its Postgres container and their network.
+func (c *FlagsmithContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	var errs []error
+	if c.Container != nil {
+		errs = append(errs, c.Container.Terminate(ctx, opts...))
+	}
+
+	if c.postgres != nil {
+		errs = append(errs, c.postgres.Terminate(ctx, opts...))
+	}
+
+	if c.network != nil {
+		errs = append(errs, c.network.Remove(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run creates an instance of the Flagsmith container type. It starts a Postgres container as its database,
+// both attached to a new network. Once the server is ready, it signs up a user, and creates an organisation,
+// the project with its environment, and the flags set with the options.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*FlagsmithContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"DATABASE_URL":         databaseURL,
+			"DJANGO_ALLOWED_HOSTS": "*",
+		},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor:   wait.ForHTTP("/health/").WithPort(defaultHTTPPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
+	c := &FlagsmithContainer{settings: settings}
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new network: %w", err)
+	}
+	c.network = nw
+
+	c.postgres, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: settings.postgresImage,
+			Env: map[string]string{
+				"POSTGRES_USER":     "flagsmith",
+				"POSTGRES_PASSWORD": "flagsmith",
+				"POSTGRES_DB":       "flagsmith",
+			},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {postgresAlias}},
+			WaitingFor:     wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("run postgres: %w", err), c.Terminate(ctx))
+	}
+
+	if err := network.WithNetwork([]string{"flagsmith"}, nw).Customize(&genericContainerReq); err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, errors.Join(err, c.Terminate(ctx))
+		}
+	}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	c.Container = container
+	if err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	return c, nil
+}
+
+// bootstrap signs up the user, and creates the organisation, the project with its environment,
+// and the flags, using the admin API.
+func (c *FlagsmithContainer) bootstrap(ctx context.Context) error {
+	s := c.settings
+
+	signUp := map[string]string{
+		"email":       s.email,
+		"password":    s.password,
+		"re_password": s.password,
+		"first_name":  "Testcontainers",
+		"last_name":   "Admin",
+	}
+	if err := c.apiRequest(ctx, "auth/users/", signUp, nil); err != nil {
+		return fmt.Errorf("sign up %s: %w", s.email, err)
+	}
+
+	var token struct {
+		Key string `json:"key"`
+	}
+	if err := c.apiRequest(ctx, "auth/login/", map[string]string{"email": s.email, "password": s.password}, &token); err != nil {
+		return fmt.Errorf("log in %s: %w", s.email, err)
+	}
+	c.apiToken = token.Key
+
+	var organisation struct {
+		ID int `json:"id"`
+	}
+	if err := c.apiRequest(ctx, "organisations/", map[string]string{"name": s.project}, &organisation); err != nil {
+		return fmt.Errorf("create organisation %s: %w", s.project, err)
+	}
+
+	var project struct {
+		ID int `json:"id"`
+	}
+	if err := c.apiRequest(ctx, "projects/", map[string]any{"name": s.project, "organisation": organisation.ID}, &project); err != nil {
+		return fmt.Errorf("create project %s: %w", s.project, err)
+	}
+
+	var environment struct {
+		APIKey string `json:"api_key"`
+	}
+	if err := c.apiRequest(ctx, "environments/", map[string]any{"name": s.environment, "project": project.ID}, &environment); err != nil {
+		return fmt.Errorf("create environment %s: %w", s.environment, err)
+	}
+	c.sdkKey = environment.APIKey
+
+	featuresPath := "projects/" + strconv.Itoa(project.ID) + "/features/"
+	for _, f := range s.flags {
+		body := map[string]any{"name": f.Name, "default_enabled": f.Enabled}
+		if f.Value != "" {
+			body["initial_value"] = f.Value
+		}
+
+		if err := c.apiRequest(ctx, featuresPath, body, nil); err != nil {
+			return fmt.Errorf("create flag %s: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// apiRequest posts the JSON body to the given path of the API, using the token of the user if any,
+// and decodes the JSON response into out if it's not nil.
+func (c *FlagsmithContainer) apiRequest(ctx context.Context, path string, body any, out any) error {
+	apiURL, err := c.APIURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Token "+c.apiToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
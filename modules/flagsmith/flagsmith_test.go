@@ -0,0 +1,66 @@
+package flagsmith_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/flagsmith"
+)
+
+const testImage = "flagsmith/flagsmith:2.130"
+
+func TestFlagsmith(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := flagsmith.Run(ctx, testImage,
+		// withFlags {
+		flagsmith.WithFlags(
+			flagsmith.Flag{Name: "new_checkout", Enabled: true},
+			flagsmith.Flag{Name: "banner_text", Value: "Hello from Testcontainers"},
+		),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// apiURL {
+	apiURL, err := ctr.APIURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	// the flags API returns the flags of the environment of the SDK key
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"flags/", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Environment-Key", ctr.SDKKey())
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result []struct {
+		Feature struct {
+			Name string `json:"name"`
+		} `json:"feature"`
+		Enabled bool `json:"enabled"`
+		Value   any  `json:"feature_state_value"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result, 2)
+
+	for _, f := range result {
+		switch f.Feature.Name {
+		case "new_checkout":
+			require.True(t, f.Enabled)
+		case "banner_text":
+			require.False(t, f.Enabled)
+			require.Equal(t, "Hello from Testcontainers", f.Value)
+		default:
+			t.Fatalf("unexpected flag %s", f.Feature.Name)
+		}
+	}
+}
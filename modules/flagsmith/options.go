@@ -0,0 +1,87 @@
+package flagsmith
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultPostgresImage = "docker.io/postgres:16-alpine"
+	defaultEmail         = "admin@testcontainers.org"
+	defaultPassword      = "Testcontainers-Passw0rd!"
+	defaultProject       = "testcontainers"
+	defaultEnvironment   = "development"
+)
+
+// Flag is a feature flag created in the project once the container is ready.
+type Flag struct {
+	// Name is the name of the flag.
+	Name string
+	// Enabled is the default state of the flag in the environments of the project.
+	Enabled bool
+	// Value is the default remote config value of the flag.
+	Value string
+}
+
+type options struct {
+	postgresImage string
+	email         string
+	password      string
+	project       string
+	environment   string
+	flags         []Flag
+}
+
+func defaultOptions() options {
+	return options{
+		postgresImage: defaultPostgresImage,
+		email:         defaultEmail,
+		password:      defaultPassword,
+		project:       defaultProject,
+		environment:   defaultEnvironment,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Flagsmith container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPostgresImage sets the image of the Postgres container used as the database of Flagsmith.
+// The default is docker.io/postgres:16-alpine.
+func WithPostgresImage(img string) Option {
+	return func(o *options) {
+		o.postgresImage = img
+	}
+}
+
+// WithCredentials sets the email and password of the user signed up once the container is ready,
+// which owns the organisation and the project.
+func WithCredentials(email, password string) Option {
+	return func(o *options) {
+		o.email = email
+		o.password = password
+	}
+}
+
+// WithProject sets the names of the project and its environment created once the container is ready.
+// The defaults are testcontainers and development.
+func WithProject(project, environment string) Option {
+	return func(o *options) {
+		o.project = project
+		o.environment = environment
+	}
+}
+
+// WithFlags creates the given feature flags in the project once the container is ready.
+func WithFlags(flags ...Flag) Option {
+	return func(o *options) {
+		o.flags = append(o.flags, flags...)
+	}
+}
@@ -0,0 +1,18 @@
+package flagsmith
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "flagsmith",
+		DefaultImage: "flagsmith/flagsmith:2.130",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithCredentials":   WithCredentials,
+			"WithFlags":         WithFlags,
+			"WithPostgresImage": WithPostgresImage,
+			"WithProject":       WithProject,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,15 @@
+package grafanalgtm
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "grafana-lgtm",
+		DefaultImage: "grafana/otel-lgtm:0.6.0",
+		ExposedPorts: []string{GrafanaPort, LokiPort, TempoPort, OtlpGrpcPort, OtlpHttpPort, PrometheusPort},
+		Options: map[string]any{
+			"WithAdminCredentials": WithAdminCredentials,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,217 @@
+package grpcreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// reflectionMethods are the methods of the v1 and v1alpha reflection services, in order of preference.
+// Both versions use the same messages on the wire, so the v1 messages are used for both.
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+var reflectionStreamDesc = &grpc.StreamDesc{
+	StreamName:    "ServerReflectionInfo",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// Service is a gRPC service exposed by a server.
+type Service struct {
+	// Name is the fully qualified name of the service, e.g. grpc.health.v1.Health.
+	Name string
+	// Methods are the names of the methods of the service, e.g. Check.
+	Methods []string
+}
+
+// ListServices connects to the gRPC server listening on the given port of the container,
+// and lists its services and their methods using the reflection service, either v1 or v1alpha.
+// The connection uses insecure credentials, unless the options set others.
+func ListServices(ctx context.Context, target wait.StrategyTarget, port nat.Port, opts ...grpc.DialOption) ([]Service, error) {
+	address, err := endpoint(ctx, target, port)
+	if err != nil {
+		return nil, err
+	}
+
+	return listServices(ctx, address, opts...)
+}
+
+// CheckServices connects to the gRPC server listening on the given port of the container,
+// and returns an error listing the expected services or methods it does not expose.
+// The expected entries are fully qualified service names, e.g. grpc.health.v1.Health,
+// or methods in the service/method format, e.g. grpc.health.v1.Health/Check.
+func CheckServices(ctx context.Context, target wait.StrategyTarget, port nat.Port, expected []string, opts ...grpc.DialOption) error {
+	services, err := ListServices(ctx, target, port, opts...)
+	if err != nil {
+		return err
+	}
+
+	return checkServices(services, expected)
+}
+
+// endpoint returns the host and mapped port of the given port of the container.
+func endpoint(ctx context.Context, target wait.StrategyTarget, port nat.Port) (string, error) {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := target.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
+
+// checkServices returns an error listing the expected services or methods missing from the services.
+func checkServices(services []Service, expected []string) error {
+	methods := make(map[string]bool)
+	for _, s := range services {
+		methods[s.Name] = true
+		for _, m := range s.Methods {
+			methods[s.Name+"/"+m] = true
+		}
+	}
+
+	var missing []string
+	for _, e := range expected {
+		if !methods[e] {
+			missing = append(missing, e)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing gRPC services or methods: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// listServices lists the services of the gRPC server at the given address, trying the
+// reflection service versions in order of preference.
+func listServices(ctx context.Context, address string, opts ...grpc.DialOption) ([]Service, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new client: %w", err)
+	}
+	defer conn.Close()
+
+	for _, method := range reflectionMethods {
+		services, err := reflectServices(ctx, conn, method)
+		if status.Code(err) == codes.Unimplemented {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reflection: %w", err)
+		}
+
+		return services, nil
+	}
+
+	return nil, errors.New("reflection: service not implemented by the server")
+}
+
+// reflectServices lists the services and their methods using the given method of the reflection service.
+func reflectServices(ctx context.Context, conn *grpc.ClientConn, method string) ([]Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, reflectionStreamDesc, method)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := resp.GetListServicesResponse().GetService()
+	services := make([]Service, 0, len(names))
+	for _, name := range names {
+		methods, err := serviceMethods(stream, name.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, Service{Name: name.GetName(), Methods: methods})
+	}
+
+	return services, stream.CloseSend()
+}
+
+// serviceMethods returns the names of the methods of the service, from the descriptor of the file defining it.
+func serviceMethods(stream grpc.ClientStream, service string) ([]string, error) {
+	resp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("unmarshal file descriptor: %w", err)
+		}
+
+		for _, sd := range file.GetService() {
+			name := sd.GetName()
+			if pkg := file.GetPackage(); pkg != "" {
+				name = pkg + "." + name
+			}
+			if name != service {
+				continue
+			}
+
+			methods := make([]string, 0, len(sd.GetMethod()))
+			for _, m := range sd.GetMethod() {
+				methods = append(methods, m.GetName())
+			}
+
+			return methods, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no descriptor for service %s", service)
+}
+
+// reflectionRequest sends the request on the reflection stream and returns its response,
+// converting the error responses into gRPC status errors.
+func reflectionRequest(stream grpc.ClientStream, req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+
+	resp := &rpb.ServerReflectionResponse{}
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+	}
+
+	return resp, nil
+}
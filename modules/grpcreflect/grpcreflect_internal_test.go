@@ -0,0 +1,42 @@
+package grpcreflect
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestListServices(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	services, err := listServices(context.Background(), lis.Addr().String())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	require.Contains(t, names, "grpc.health.v1.Health")
+
+	require.NoError(t, checkServices(services, []string{"grpc.health.v1.Health", "grpc.health.v1.Health/Check"}))
+	require.EqualError(t,
+		checkServices(services, []string{"grpc.health.v1.Health/Check", "grpc.health.v1.Health/Ping", "acme.Orders"}),
+		"missing gRPC services or methods: grpc.health.v1.Health/Ping, acme.Orders",
+	)
+}
@@ -0,0 +1,47 @@
+package grpcreflect_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/grpcreflect"
+)
+
+func TestForServices(t *testing.T) {
+	ctx := context.Background()
+
+	// waitForServices {
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "moul/grpcbin:latest",
+			ExposedPorts: []string{"9000/tcp"},
+			WaitingFor:   grpcreflect.ForServices("9000/tcp", "hello.HelloService/SayHello", "grpcbin.GRPCBin"),
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// checkServices {
+	err = grpcreflect.CheckServices(ctx, ctr, "9000/tcp", []string{"hello.HelloService/SayHello"})
+	// }
+	require.NoError(t, err)
+
+	err = grpcreflect.CheckServices(ctx, ctr, "9000/tcp", []string{"hello.HelloService/SayGoodbye"})
+	require.EqualError(t, err, "missing gRPC services or methods: hello.HelloService/SayGoodbye")
+
+	// listServices {
+	services, err := grpcreflect.ListServices(ctx, ctr, "9000/tcp")
+	// }
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	require.Contains(t, names, "hello.HelloService")
+}
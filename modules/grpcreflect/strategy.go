@@ -0,0 +1,97 @@
+package grpcreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"google.golang.org/grpc"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Compiler check to ensure that ServicesStrategy implements the wait.Strategy interface.
+var (
+	_ wait.Strategy        = (*ServicesStrategy)(nil)
+	_ wait.StrategyTimeout = (*ServicesStrategy)(nil)
+)
+
+// ServicesStrategy waits until the gRPC server listening on the given port exposes
+// the expected services and methods through its reflection service.
+type ServicesStrategy struct {
+	port         nat.Port
+	expected     []string
+	dialOptions  []grpc.DialOption
+	timeout      *time.Duration
+	pollInterval time.Duration
+}
+
+// ForServices returns a strategy waiting until the gRPC server listening on the given port
+// exposes the expected services or methods, in the format of the CheckServices function.
+// Without expected services, it waits until the reflection service answers.
+func ForServices(port nat.Port, expected ...string) *ServicesStrategy {
+	return &ServicesStrategy{
+		port:         port,
+		expected:     expected,
+		pollInterval: 100 * time.Millisecond,
+	}
+}
+
+// WithDialOptions sets the options used to connect to the server, e.g. its transport credentials.
+// The connection uses insecure credentials by default.
+func (s *ServicesStrategy) WithDialOptions(opts ...grpc.DialOption) *ServicesStrategy {
+	s.dialOptions = append(s.dialOptions, opts...)
+	return s
+}
+
+// WithStartupTimeout can be used to change the default startup timeout of 60 seconds.
+func (s *ServicesStrategy) WithStartupTimeout(timeout time.Duration) *ServicesStrategy {
+	s.timeout = &timeout
+	return s
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds.
+func (s *ServicesStrategy) WithPollInterval(pollInterval time.Duration) *ServicesStrategy {
+	s.pollInterval = pollInterval
+	return s
+}
+
+// Timeout returns the startup timeout of the strategy, if any.
+func (s *ServicesStrategy) Timeout() *time.Duration {
+	return s.timeout
+}
+
+// WaitUntilReady implements the wait.Strategy interface.
+func (s *ServicesStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	timeout := 60 * time.Second
+	if s.timeout != nil {
+		timeout = *s.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gRPC services: %w", errors.Join(ctx.Err(), lastErr))
+		case <-time.After(s.pollInterval):
+		}
+
+		state, err := target.State(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !state.Running {
+			return fmt.Errorf("container is not running: %s", state.Status)
+		}
+
+		if lastErr = CheckServices(ctx, target, s.port, s.expected, s.dialOptions...); lastErr == nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package hasura_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/hasura"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func ExampleRun() {
+	// runHasuraContainer {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to create network: %s", err)
+	}
+
+	pgContainer, err := postgres.Run(ctx, "postgres:16-alpine",
+		network.WithNetwork([]string{"db"}, nw),
+		testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").WithOccurrence(2)),
+	)
+	if err != nil {
+		log.Fatalf("failed to start postgres container: %s", err)
+	}
+
+	hasuraContainer, err := hasura.Run(ctx, "hasura/graphql-engine:v2.40.0",
+		hasura.WithPostgres(pgContainer),
+		hasura.WithAdminSecret("s3cr3t"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the containers and the network
+	defer func() {
+		if err := hasuraContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+		if err := pgContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate postgres container: %s", err)
+		}
+		if err := nw.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+	// }
+
+	state, err := hasuraContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running, hasuraContainer.AdminSecret())
+
+	// Output:
+	// true s3cr3t
+}
@@ -0,0 +1,272 @@
+package hasura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "8080/tcp"
+
+	// postgresPort is the port of Postgres in its container.
+	postgresPort = "5432"
+)
+
+// HasuraContainer represents the Hasura GraphQL engine container type used in the module. It serves
+// the GraphQL API of the database of a Postgres container, which isn't terminated with it.
+type HasuraContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// AdminSecret returns the admin secret of the GraphQL engine, to be sent in the X-Hasura-Admin-Secret header.
+func (c *HasuraContainer) AdminSecret() string {
+	return c.settings.adminSecret
+}
+
+// URL returns the URL of the GraphQL engine, e.g. http://localhost:8080.
+func (c *HasuraContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// GraphQLEndpoint returns the URL of the GraphQL API, e.g. http://localhost:8080/v1/graphql.
+func (c *HasuraContainer) GraphQLEndpoint(ctx context.Context) (string, error) {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/v1/graphql", nil
+}
+
+// Query sends the GraphQL query, or mutation, with the admin secret, decoding the data of the response into out,
+// if not nil. It returns an error if the response contains errors.
+func (c *HasuraContainer) Query(ctx context.Context, query string, variables map[string]any, out any) error {
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.request(ctx, "/v1/graphql", map[string]any{"query": query, "variables": variables}, &resp); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return fmt.Errorf("query: %s", strings.Join(msgs, "; "))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("decode data: %w", err)
+	}
+
+	return nil
+}
+
+// RunSQL runs the SQL statements in the database, with the run_sql API.
+func (c *HasuraContainer) RunSQL(ctx context.Context, sql string) error {
+	body := map[string]any{
+		"type": "run_sql",
+		"args": map[string]any{"source": "default", "sql": sql},
+	}
+	if err := c.request(ctx, "/v2/query", body, nil); err != nil {
+		return fmt.Errorf("run sql: %w", err)
+	}
+
+	return nil
+}
+
+// Metadata calls the metadata API with the type and the arguments of the operation, e.g. pg_track_table,
+// decoding the response into out, if not nil.
+func (c *HasuraContainer) Metadata(ctx context.Context, operation string, args any, out any) error {
+	if err := c.request(ctx, "/v1/metadata", map[string]any{"type": operation, "args": args}, out); err != nil {
+		return fmt.Errorf("metadata %s: %w", operation, err)
+	}
+
+	return nil
+}
+
+// TrackTable tracks the table of the database, e.g. users or public.orders, exposing it in the GraphQL API.
+func (c *HasuraContainer) TrackTable(ctx context.Context, table string) error {
+	schema, name := "public", table
+	if s, n, ok := strings.Cut(table, "."); ok {
+		schema, name = s, n
+	}
+
+	return c.Metadata(ctx, "pg_track_table", map[string]any{
+		"source": "default",
+		"table":  map[string]string{"schema": schema, "name": name},
+	}, nil)
+}
+
+// Run creates an instance of the Hasura GraphQL engine container type, serving the database of the Postgres
+// container set with WithPostgres. Once the GraphQL engine is ready, it applies the migrations and the metadata,
+// and tracks the tables, set with the options.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*HasuraContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"HASURA_GRAPHQL_ENABLE_CONSOLE": "false",
+			"HASURA_GRAPHQL_DEV_MODE":       "true",
+		},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor:   wait.ForHTTP("/healthz").WithPort(defaultHTTPPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.postgres == nil {
+		return nil, errors.New("no postgres container, see WithPostgres")
+	}
+
+	network, host, err := postgresHost(ctx, settings.postgres)
+	if err != nil {
+		return nil, fmt.Errorf("postgres host: %w", err)
+	}
+	if network != "" && !slices.Contains(genericContainerReq.Networks, network) {
+		genericContainerReq.Networks = append(genericContainerReq.Networks, network)
+	}
+
+	details, err := settings.postgres.ConnectionDetails(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres connection details: %w", err)
+	}
+
+	databaseURL := databaseURL(details, host)
+	genericContainerReq.Env["HASURA_GRAPHQL_DATABASE_URL"] = databaseURL
+	genericContainerReq.Env["HASURA_GRAPHQL_METADATA_DATABASE_URL"] = databaseURL
+	genericContainerReq.Env["HASURA_GRAPHQL_ADMIN_SECRET"] = settings.adminSecret
+
+	c := &HasuraContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, fmt.Errorf("generic container: %w", err)
+	}
+
+	c.Container = container
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// bootstrap applies the migrations and the metadata, and tracks the tables, set with the options.
+func (c *HasuraContainer) bootstrap(ctx context.Context) error {
+	s := c.settings
+
+	if s.migrations != "" {
+		files, err := migrationFiles(s.migrations)
+		if err != nil {
+			return fmt.Errorf("migrations: %w", err)
+		}
+
+		for _, file := range files {
+			sql, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("read migration: %w", err)
+			}
+
+			if err := c.RunSQL(ctx, string(sql)); err != nil {
+				return fmt.Errorf("migration %s: %w", file, err)
+			}
+		}
+	}
+
+	if s.metadata != "" {
+		metadata, err := os.ReadFile(s.metadata)
+		if err != nil {
+			return fmt.Errorf("read metadata: %w", err)
+		}
+
+		if err := c.Metadata(ctx, "replace_metadata", json.RawMessage(metadata), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range s.trackedTables {
+		if err := c.TrackTable(ctx, table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// request sends the JSON body to the API, with the admin secret, decoding the JSON response into out, if not nil.
+func (c *HasuraContainer) request(ctx context.Context, path string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Hasura-Admin-Secret", c.settings.adminSecret)
+
+	resp, err := testcontainers.HTTPClient(c, defaultHTTPPort).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
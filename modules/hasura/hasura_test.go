@@ -0,0 +1,124 @@
+package hasura_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/hasura"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const image = "hasura/graphql-engine:v2.40.0"
+
+// runPostgres runs a Postgres container attached to a new network, with the db alias.
+func runPostgres(t *testing.T) *postgres.PostgresContainer {
+	t.Helper()
+	ctx := context.Background()
+
+	nw, err := network.New(ctx, network.WithCleanup(t))
+	require.NoError(t, err)
+
+	pg, err := postgres.Run(ctx, "postgres:16-alpine",
+		postgres.WithDatabase("app"),
+		network.WithNetwork([]string{"db"}, nw),
+		testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").WithOccurrence(2)),
+	)
+	t.Cleanup(func() {
+		if pg != nil {
+			require.NoError(t, pg.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	return pg
+}
+
+func TestHasura(t *testing.T) {
+	ctx := context.Background()
+	pg := runPostgres(t)
+
+	// withMigrations {
+	ctr, err := hasura.Run(ctx, image,
+		hasura.WithPostgres(pg),
+		hasura.WithAdminSecret("s3cr3t"),
+		hasura.WithMigrations("testdata/migrations"),
+		hasura.WithTrackedTables("users"),
+	)
+	// }
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t", ctr.AdminSecret())
+
+	endpoint, err := ctr.GraphQLEndpoint(ctx)
+	require.NoError(t, err)
+	require.Contains(t, endpoint, "/v1/graphql")
+
+	var data struct {
+		Users []struct {
+			Name string `json:"name"`
+		} `json:"users"`
+	}
+	require.NoError(t, ctr.Query(ctx, `query { users(order_by: {id: asc}) { name } }`, nil, &data))
+	require.Len(t, data.Users, 2)
+	require.Equal(t, "alice", data.Users[0].Name)
+
+	t.Run("mutation", func(t *testing.T) {
+		var inserted struct {
+			InsertUsersOne struct {
+				ID int `json:"id"`
+			} `json:"insert_users_one"`
+		}
+		err := ctr.Query(ctx, `mutation($name: String!) { insert_users_one(object: {name: $name}) { id } }`,
+			map[string]any{"name": "carol"}, &inserted)
+		require.NoError(t, err)
+		require.Equal(t, 3, inserted.InsertUsersOne.ID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := ctr.Query(ctx, `query { orders { id } }`, nil, nil)
+		require.ErrorContains(t, err, "orders")
+	})
+}
+
+func TestHasura_metadata(t *testing.T) {
+	ctx := context.Background()
+	pg := runPostgres(t)
+
+	ctr, err := hasura.Run(ctx, image,
+		hasura.WithPostgres(pg),
+		hasura.WithMigrations("testdata/migrations"),
+		hasura.WithMetadata("testdata/metadata.json"),
+	)
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ctr.RunSQL(ctx, `CREATE TABLE orders (id SERIAL PRIMARY KEY)`))
+	require.NoError(t, ctr.TrackTable(ctx, "public.orders"))
+
+	var data struct {
+		Users  []map[string]any `json:"users"`
+		Orders []map[string]any `json:"orders"`
+	}
+	require.NoError(t, ctr.Query(ctx, `query { users { id } orders { id } }`, nil, &data))
+	require.Len(t, data.Users, 2)
+	require.Empty(t, data.Orders)
+}
+
+func TestRun_noPostgres(t *testing.T) {
+	_, err := hasura.Run(context.Background(), image)
+	require.ErrorContains(t, err, "no postgres container")
+}
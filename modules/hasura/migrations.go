@@ -0,0 +1,66 @@
+package hasura
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationFiles returns the SQL files of the migrations of the directory, in the order they are applied:
+// the up.sql files of the migrations of the Hasura CLI, sorted by version, or else the .sql files of the directory,
+// sorted by name.
+func migrationFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type migration struct {
+		version int64
+		file    string
+	}
+
+	var migrations []migration
+	var files []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if !entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), ".sql") {
+				files = append(files, path)
+			}
+			continue
+		}
+
+		up := filepath.Join(path, "up.sql")
+		if _, err := os.Stat(up); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid migration version: " + entry.Name())
+		}
+		migrations = append(migrations, migration{version: version, file: up})
+	}
+
+	if len(migrations) == 0 {
+		sort.Strings(files)
+		return files, nil
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	files = make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		files = append(files, m.file)
+	}
+
+	return files, nil
+}
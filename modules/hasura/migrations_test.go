@@ -0,0 +1,45 @@
+package hasura
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationFiles(t *testing.T) {
+	t.Run("hasura-cli", func(t *testing.T) {
+		files, err := migrationFiles("testdata/migrations")
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join("testdata", "migrations", "1700000000000_init", "up.sql"),
+			filepath.Join("testdata", "migrations", "1700000000001_seed", "up.sql"),
+		}, files)
+	})
+
+	t.Run("sql-files", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"02_seed.sql", "01_schema.sql", "README.md"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+		}
+
+		files, err := migrationFiles(dir)
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(dir, "01_schema.sql"), filepath.Join(dir, "02_seed.sql")}, files)
+	})
+
+	t.Run("invalid-version", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "init"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "init", "up.sql"), nil, 0o644))
+
+		_, err := migrationFiles(dir)
+		require.ErrorContains(t, err, "invalid migration version: init")
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		_, err := migrationFiles("testdata/missing")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
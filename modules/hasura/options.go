@@ -0,0 +1,82 @@
+package hasura
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// DefaultAdminSecret is the admin secret of the GraphQL engine, sent in the X-Hasura-Admin-Secret header.
+const DefaultAdminSecret = "testcontainers"
+
+// PostgresContainer is a Postgres container, e.g. of the postgres module, providing its connection details.
+type PostgresContainer interface {
+	testcontainers.Container
+	testcontainers.ConnectionSource
+}
+
+type options struct {
+	postgres      PostgresContainer
+	adminSecret   string
+	migrations    string
+	metadata      string
+	trackedTables []string
+}
+
+func defaultOptions() options {
+	return options{
+		adminSecret: DefaultAdminSecret,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Hasura container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPostgres sets the Postgres container of the database of the GraphQL engine, e.g. of the postgres module,
+// which also stores its metadata. It's required. The GraphQL engine is attached to the network of the Postgres
+// container, and connects to its network alias, or to its IP address on the default bridge network.
+func WithPostgres(pg PostgresContainer) Option {
+	return func(o *options) {
+		o.postgres = pg
+	}
+}
+
+// WithAdminSecret sets the admin secret of the GraphQL engine, DefaultAdminSecret by default.
+func WithAdminSecret(secret string) Option {
+	return func(o *options) {
+		o.adminSecret = secret
+	}
+}
+
+// WithMigrations applies the SQL migrations of the directory to the database when the GraphQL engine is ready,
+// before the metadata. The directory is either a migrations directory of the Hasura CLI, with the up.sql file
+// of each migration in a directory named after its version, e.g. 1700000000000_init/up.sql, or a directory
+// of .sql files, applied in the order of their names.
+func WithMigrations(dir string) Option {
+	return func(o *options) {
+		o.migrations = dir
+	}
+}
+
+// WithMetadata replaces the metadata of the GraphQL engine with the metadata of the JSON file, as exported
+// by the export_metadata API or the hasura metadata export --output json command.
+func WithMetadata(path string) Option {
+	return func(o *options) {
+		o.metadata = path
+	}
+}
+
+// WithTrackedTables tracks the tables of the database, e.g. users or public.orders, exposing them in the GraphQL API.
+// They are tracked after the migrations and the metadata are applied.
+func WithTrackedTables(tables ...string) Option {
+	return func(o *options) {
+		o.trackedTables = append(o.trackedTables, tables...)
+	}
+}
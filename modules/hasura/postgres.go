@@ -0,0 +1,50 @@
+package hasura
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// postgresHost returns the network the GraphQL engine must be attached to, and the host of the Postgres container
+// on this network: its alias on its first network having one, or its IP address on the default bridge network,
+// without network to attach to.
+func postgresHost(ctx context.Context, pg testcontainers.Container) (string, string, error) {
+	networks, err := pg.Networks(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	aliases, err := pg.NetworkAliases(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, network := range networks {
+		if len(aliases[network]) > 0 {
+			return network, aliases[network][0], nil
+		}
+	}
+
+	ip, err := pg.ContainerIP(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	return "", ip, nil
+}
+
+// databaseURL returns the URL of the database of the connection details, on the host in the network of the containers.
+func databaseURL(details *testcontainers.ConnectionDetails, host string) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(details.Username, details.Password),
+		Host:     net.JoinHostPort(host, postgresPort),
+		Path:     "/" + details.Database,
+		RawQuery: details.Params.Encode(),
+	}
+
+	return u.String()
+}
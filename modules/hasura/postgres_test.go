@@ -0,0 +1,69 @@
+package hasura
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// fakePostgresContainer is a Postgres container with the given networks and IP address.
+type fakePostgresContainer struct {
+	testcontainers.Container
+	networks []string
+	aliases  map[string][]string
+	ip       string
+}
+
+func (c fakePostgresContainer) Networks(context.Context) ([]string, error) {
+	return c.networks, nil
+}
+
+func (c fakePostgresContainer) NetworkAliases(context.Context) (map[string][]string, error) {
+	return c.aliases, nil
+}
+
+func (c fakePostgresContainer) ContainerIP(context.Context) (string, error) {
+	return c.ip, nil
+}
+
+func TestPostgresHost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("alias", func(t *testing.T) {
+		network, host, err := postgresHost(ctx, fakePostgresContainer{
+			networks: []string{"bridge", "backend"},
+			aliases:  map[string][]string{"backend": {"db", "postgres"}},
+			ip:       "172.17.0.2",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "backend", network)
+		require.Equal(t, "db", host)
+	})
+
+	t.Run("bridge", func(t *testing.T) {
+		network, host, err := postgresHost(ctx, fakePostgresContainer{
+			networks: []string{"bridge"},
+			ip:       "172.17.0.2",
+		})
+		require.NoError(t, err)
+		require.Empty(t, network)
+		require.Equal(t, "172.17.0.2", host)
+	})
+}
+
+func TestDatabaseURL(t *testing.T) {
+	details := &testcontainers.ConnectionDetails{
+		Host:     "localhost",
+		Port:     "32768",
+		Username: "hasura",
+		Password: "p@ss",
+		Database: "app",
+		Params:   url.Values{"sslmode": {"disable"}},
+	}
+
+	require.Equal(t, "postgres://hasura:p%40ss@db:5432/app?sslmode=disable", databaseURL(details, "db"))
+}
@@ -0,0 +1,19 @@
+package hasura
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "hasura",
+		DefaultImage: "hasura/graphql-engine:v2.40.0",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithAdminSecret":   WithAdminSecret,
+			"WithMetadata":      WithMetadata,
+			"WithMigrations":    WithMigrations,
+			"WithPostgres":      WithPostgres,
+			"WithTrackedTables": WithTrackedTables,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1 @@
+INSERT INTO users (name) VALUES ('alice'), ('bob');
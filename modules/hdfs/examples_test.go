@@ -0,0 +1,37 @@
+package hdfs_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/hdfs"
+)
+
+func ExampleRun() {
+	// runHDFSContainer {
+	ctx := context.Background()
+
+	hdfsContainer, err := hdfs.Run(ctx, "apache/hadoop:3.4.1")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := hdfsContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := hdfsContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,143 @@
+package hdfs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// NameNodePort is the RPC port of the namenode, the port of the fs.defaultFS URI.
+	NameNodePort = "8020/tcp"
+	// NameNodeHTTPPort is the port of the web UI and of the WebHDFS API of the namenode.
+	NameNodeHTTPPort = "9870/tcp"
+	// DataNodePort is the data transfer port of the datanode.
+	DataNodePort = "9866/tcp"
+	// DataNodeHTTPPort is the HTTP port of the datanode, where WebHDFS redirects the reads and the writes.
+	DataNodeHTTPPort = "9864/tcp"
+
+	configDir = "/opt/hadoop/etc/hadoop"
+)
+
+// liveDataNode matches the state of the namesystem of the namenode once the datanode registered.
+var liveDataNode = regexp.MustCompile(`"NumLiveDataNodes"\s*:\s*1\b`)
+
+// HDFSContainer represents the HDFS container type used in the module, running a namenode and a datanode
+// in a single container.
+type HDFSContainer struct {
+	testcontainers.Container
+}
+
+// DefaultFS returns the fs.defaultFS URI of the file system from the host, e.g. hdfs://localhost:32768.
+// The clients reach the datanode with the IP address of the container, which is reachable from the host on Linux only.
+func (c *HDFSContainer) DefaultFS(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, NameNodePort, "hdfs")
+}
+
+// ContainerDefaultFS returns the fs.defaultFS URI of the file system from other containers, with the IP address
+// of the container, e.g. hdfs://172.17.0.2:8020, so the containers must share a network.
+func (c *HDFSContainer) ContainerDefaultFS(ctx context.Context) (string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container ip: %w", err)
+	}
+
+	return "hdfs://" + ip + ":" + strings.TrimSuffix(NameNodePort, "/tcp"), nil
+}
+
+// WebHDFSURL returns the base URL of the WebHDFS REST API of the namenode, e.g. http://localhost:32769/webhdfs/v1.
+func (c *HDFSContainer) WebHDFSURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, NameNodeHTTPPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/webhdfs/v1", nil
+}
+
+// DFS runs the hdfs dfs command with the given arguments in the container, e.g. -mkdir -p /warehouse,
+// returning its output, or an error if it fails.
+func (c *HDFSContainer) DFS(ctx context.Context, args ...string) (string, error) {
+	code, r, err := c.Exec(ctx, append([]string{"hdfs", "dfs"}, args...), tcexec.Multiplexed())
+	if err != nil {
+		return "", fmt.Errorf("exec hdfs dfs: %w", err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("read output: %w", err)
+	}
+
+	if code != 0 {
+		return "", fmt.Errorf("hdfs dfs %s: exit code %d: %s", strings.Join(args, " "), code, strings.TrimSpace(string(out)))
+	}
+
+	return string(out), nil
+}
+
+// Run creates an instance of the HDFS container type, formatting the file system, then running a namenode and a datanode.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*HDFSContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{NameNodePort, NameNodeHTTPPort, DataNodePort, DataNodeHTTPPort},
+		Cmd: []string{
+			"sh", "-c",
+			"hdfs namenode -format -force && (hdfs namenode &) && exec hdfs datanode",
+		},
+		WaitingFor: wait.ForHTTP("/jmx?qry=Hadoop:service=NameNode,name=FSNamesystemState").
+			WithPort(NameNodeHTTPPort).
+			WithResponseMatcher(func(body io.Reader) bool {
+				data, err := io.ReadAll(body)
+				return err == nil && liveDataNode.Match(data)
+			}).
+			WithStartupTimeout(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files,
+		testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(siteXML(settings.coreSite)),
+			ContainerFilePath: configDir + "/core-site.xml",
+			FileMode:          0o644,
+		},
+		testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(siteXML(settings.hdfsSite)),
+			ContainerFilePath: configDir + "/hdfs-site.xml",
+			FileMode:          0o644,
+		},
+	)
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *HDFSContainer
+	if container != nil {
+		c = &HDFSContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,61 @@
+package hdfs_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/hdfs"
+)
+
+const testImage = "apache/hadoop:3.4.1"
+
+func TestHDFS(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := hdfs.Run(ctx, testImage, hdfs.WithHDFSSiteProperty("dfs.blocksize", "1m"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// defaultFS {
+	defaultFS, err := ctr.DefaultFS(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(defaultFS, "hdfs://"))
+
+	containerDefaultFS, err := ctr.ContainerDefaultFS(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(containerDefaultFS, ":8020"))
+
+	t.Run("dfs", func(t *testing.T) {
+		// dfs {
+		_, err := ctr.DFS(ctx, "-mkdir", "-p", "/warehouse/sales")
+		require.NoError(t, err)
+
+		out, err := ctr.DFS(ctx, "-ls", "/warehouse")
+		// }
+		require.NoError(t, err)
+		require.Contains(t, out, "/warehouse/sales")
+
+		_, err = ctr.DFS(ctx, "-ls", "/missing")
+		require.Error(t, err)
+	})
+
+	t.Run("webhdfs", func(t *testing.T) {
+		webHDFS, err := ctr.WebHDFSURL(ctx)
+		require.NoError(t, err)
+
+		resp, err := http.Get(webHDFS + "/warehouse?op=LISTSTATUS")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, string(body), `"pathSuffix":"sales"`)
+	})
+}
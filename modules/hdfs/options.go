@@ -0,0 +1,52 @@
+package hdfs
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	coreSite map[string]string
+	hdfsSite map[string]string
+}
+
+func defaultOptions() options {
+	return options{
+		coreSite: map[string]string{
+			"fs.defaultFS": "hdfs://localhost:8020",
+		},
+		hdfsSite: map[string]string{
+			"dfs.replication":                                      "1",
+			"dfs.permissions.enabled":                              "false",
+			"dfs.namenode.rpc-bind-host":                           "0.0.0.0",
+			"dfs.namenode.http-bind-host":                          "0.0.0.0",
+			"dfs.namenode.datanode.registration.ip-hostname-check": "false",
+		},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the HDFS container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithCoreSiteProperty sets a property of the core-site.xml configuration file, e.g. hadoop.proxyuser.hive.hosts.
+func WithCoreSiteProperty(name string, value string) Option {
+	return func(o *options) {
+		o.coreSite[name] = value
+	}
+}
+
+// WithHDFSSiteProperty sets a property of the hdfs-site.xml configuration file, e.g. dfs.blocksize.
+// The replication is 1 and the permissions are disabled by default.
+func WithHDFSSiteProperty(name string, value string) Option {
+	return func(o *options) {
+		o.hdfsSite[name] = value
+	}
+}
@@ -0,0 +1,16 @@
+package hdfs
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "hdfs",
+		DefaultImage: "apache/hadoop:3.4.1",
+		ExposedPorts: []string{NameNodePort, NameNodeHTTPPort, DataNodePort, DataNodeHTTPPort},
+		Options: map[string]any{
+			"WithCoreSiteProperty": WithCoreSiteProperty,
+			"WithHDFSSiteProperty": WithHDFSSiteProperty,
+		},
+		Run: modules.Runner(Run),
+	})
+}
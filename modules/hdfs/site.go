@@ -0,0 +1,30 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+)
+
+// siteXML returns the Hadoop configuration file, e.g. core-site.xml, with the given properties, sorted by name.
+func siteXML(properties map[string]string) []byte {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<configuration>\n")
+	for _, name := range names {
+		buf.WriteString("  <property>\n    <name>")
+		_ = xml.EscapeText(&buf, []byte(name))
+		buf.WriteString("</name>\n    <value>")
+		_ = xml.EscapeText(&buf, []byte(properties[name]))
+		buf.WriteString("</value>\n  </property>\n")
+	}
+	buf.WriteString("</configuration>\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,25 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSiteXML(t *testing.T) {
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<configuration>
+  <property>
+    <name>dfs.blocksize</name>
+    <value>1m</value>
+  </property>
+  <property>
+    <name>hadoop.proxyuser.hive.hosts</name>
+    <value>*&amp;</value>
+  </property>
+</configuration>
+`, string(siteXML(map[string]string{
+		"hadoop.proxyuser.hive.hosts": "*&",
+		"dfs.blocksize":               "1m",
+	})))
+}
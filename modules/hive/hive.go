@@ -0,0 +1,124 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// MetastorePort is the Thrift port of the Hive metastore.
+const MetastorePort = "9083/tcp"
+
+// HiveContainer represents the Hive metastore container type used in the module.
+type HiveContainer struct {
+	testcontainers.Container
+}
+
+// ThriftURI returns the Thrift URI of the metastore from the host, e.g. thrift://localhost:32768,
+// the hive.metastore.uris property of the clients.
+func (c *HiveContainer) ThriftURI(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, MetastorePort, "thrift")
+}
+
+// ContainerThriftURI returns the Thrift URI of the metastore from other containers, with the IP address
+// of the container, e.g. thrift://172.17.0.3:9083, so the containers must share a network.
+func (c *HiveContainer) ContainerThriftURI(ctx context.Context) (string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container ip: %w", err)
+	}
+
+	return "thrift://" + ip + ":" + strings.TrimSuffix(MetastorePort, "/tcp"), nil
+}
+
+// Run creates an instance of the Hive metastore container type, initializing its schema in the embedded Derby database,
+// or in the database of a Postgres container, see WithPostgres.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*HiveContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{MetastorePort},
+		Env: map[string]string{
+			"SERVICE_NAME": "metastore",
+		},
+		WaitingFor: wait.ForListeningPort(MetastorePort).WithStartupTimeout(3 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	properties := map[string]string{}
+	if settings.warehouseDir != "" {
+		properties["hive.metastore.warehouse.dir"] = settings.warehouseDir
+	}
+
+	if settings.postgres != nil {
+		pgProperties, err := settings.postgresProperties(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: %w", err)
+		}
+		for name, value := range pgProperties {
+			properties[name] = value
+		}
+
+		genericContainerReq.Env["DB_DRIVER"] = "postgres"
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      settings.postgresDriver,
+			ContainerFilePath: "/opt/hive/lib/postgres.jar",
+			FileMode:          0o644,
+		})
+	}
+
+	for name, value := range settings.properties {
+		properties[name] = value
+	}
+
+	if len(properties) > 0 {
+		genericContainerReq.Env["SERVICE_OPTS"] = serviceOpts(properties)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *HiveContainer
+	if container != nil {
+		c = &HiveContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// serviceOpts returns the Java system properties setting the properties of the metastore, sorted by name,
+// e.g. -Dhive.metastore.warehouse.dir=/warehouse.
+func serviceOpts(properties map[string]string) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	opts := make([]string, 0, len(names))
+	for _, name := range names {
+		opts = append(opts, "-D"+name+"="+properties[name])
+	}
+
+	return strings.Join(opts, " ")
+}
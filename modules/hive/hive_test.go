@@ -0,0 +1,41 @@
+package hive_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/hive"
+)
+
+const testImage = "apache/hive:4.0.0"
+
+func TestHive(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := hive.Run(ctx, testImage, hive.WithWarehouseDir("/opt/hive/data/warehouse"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// thriftURI {
+	uri, err := ctr.ThriftURI(ctx)
+	// }
+	require.NoError(t, err)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "thrift", u.Scheme)
+
+	// the metastore accepts the connections of the clients
+	conn, err := net.Dial("tcp", u.Host)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	containerURI, err := ctr.ContainerThriftURI(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(containerURI, ":9083"))
+}
@@ -0,0 +1,84 @@
+package hive
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// PostgresContainer is a Postgres container, e.g. of the postgres module, providing its connection details.
+type PostgresContainer interface {
+	testcontainers.Container
+	testcontainers.ConnectionSource
+}
+
+type options struct {
+	postgres       PostgresContainer
+	postgresDriver string
+	warehouseDir   string
+	properties     map[string]string
+}
+
+func defaultOptions() options {
+	return options{
+		properties: map[string]string{},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Hive metastore container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPostgres stores the metastore in the database of the given Postgres container, e.g. of the postgres module,
+// instead of the embedded Derby database. The containers must share a network, as the metastore connects to the
+// IP address of the Postgres container. The image of Hive doesn't provide the JDBC driver of Postgres,
+// so the path of its jar file on the host must be given, e.g. postgresql-42.7.4.jar.
+func WithPostgres(pg PostgresContainer, driverJar string) Option {
+	return func(o *options) {
+		o.postgres = pg
+		o.postgresDriver = driverJar
+	}
+}
+
+// WithWarehouseDir sets the location of the warehouse of the managed tables, e.g. a directory of the file system
+// of a HDFS container, such as hdfs://172.17.0.2:8020/warehouse.
+func WithWarehouseDir(dir string) Option {
+	return func(o *options) {
+		o.warehouseDir = dir
+	}
+}
+
+// WithProperty sets a property of the metastore, e.g. metastore.client.capability.check.
+func WithProperty(name string, value string) Option {
+	return func(o *options) {
+		o.properties[name] = value
+	}
+}
+
+// postgresProperties returns the properties connecting the metastore to the Postgres container.
+func (o options) postgresProperties(ctx context.Context) (map[string]string, error) {
+	details, err := o.postgres.ConnectionDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ip, err := o.postgres.ContainerIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"javax.jdo.option.ConnectionDriverName": "org.postgresql.Driver",
+		"javax.jdo.option.ConnectionURL":        "jdbc:postgresql://" + ip + ":5432/" + details.Database,
+		"javax.jdo.option.ConnectionUserName":   details.Username,
+		"javax.jdo.option.ConnectionPassword":   details.Password,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package hive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// fakePostgresContainer is a Postgres container with the given IP address and connection details.
+type fakePostgresContainer struct {
+	testcontainers.Container
+	ip      string
+	details testcontainers.ConnectionDetails
+}
+
+func (c fakePostgresContainer) ContainerIP(context.Context) (string, error) {
+	return c.ip, nil
+}
+
+func (c fakePostgresContainer) ConnectionDetails(context.Context) (*testcontainers.ConnectionDetails, error) {
+	return &c.details, nil
+}
+
+func TestPostgresProperties(t *testing.T) {
+	o := defaultOptions()
+	WithPostgres(fakePostgresContainer{
+		ip: "172.17.0.2",
+		details: testcontainers.ConnectionDetails{
+			Host:     "localhost",
+			Port:     "32768",
+			Username: "hive",
+			Password: "secret",
+			Database: "metastore",
+		},
+	}, "postgresql.jar")(&o)
+
+	properties, err := o.postgresProperties(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"javax.jdo.option.ConnectionDriverName": "org.postgresql.Driver",
+		"javax.jdo.option.ConnectionURL":        "jdbc:postgresql://172.17.0.2:5432/metastore",
+		"javax.jdo.option.ConnectionUserName":   "hive",
+		"javax.jdo.option.ConnectionPassword":   "secret",
+	}, properties)
+}
+
+func TestServiceOpts(t *testing.T) {
+	require.Equal(t,
+		"-Dhive.metastore.warehouse.dir=hdfs://172.17.0.2:8020/warehouse -Dmetastore.client.capability.check=false",
+		serviceOpts(map[string]string{
+			"metastore.client.capability.check": "false",
+			"hive.metastore.warehouse.dir":      "hdfs://172.17.0.2:8020/warehouse",
+		}),
+	)
+}
@@ -0,0 +1,17 @@
+package hive
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "hive",
+		DefaultImage: "apache/hive:4.0.0",
+		ExposedPorts: []string{MetastorePort},
+		Options: map[string]any{
+			"WithPostgres":     WithPostgres,
+			"WithProperty":     WithProperty,
+			"WithWarehouseDir": WithWarehouseDir,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,39 @@
+package hoverfly_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/hoverfly"
+)
+
+func ExampleRun() {
+	// runHoverflyContainer {
+	ctx := context.Background()
+
+	hoverflyContainer, err := hoverfly.Run(ctx, "spectolabs/hoverfly:v1.10.5",
+		hoverfly.WithMode(hoverfly.ModeCapture),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := hoverflyContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := hoverflyContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running, hoverflyContainer.Mode())
+
+	// Output:
+	// true capture
+}
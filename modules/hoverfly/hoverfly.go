@@ -0,0 +1,270 @@
+package hoverfly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultProxyPort = "8500/tcp"
+	defaultAdminPort = "8888/tcp"
+)
+
+// HoverflyContainer represents the Hoverfly container type used in the module. Hoverfly is a proxy
+// recording the traffic to the real APIs, and replaying it in the later runs, so the tests of the integrations
+// with third-party APIs don't depend on them. The container under test uses it as its HTTP proxy, see ProxyEnv.
+type HoverflyContainer struct {
+	testcontainers.Container
+	settings options
+	mode     Mode
+}
+
+// Mode returns the mode of Hoverfly set when the container started.
+func (c *HoverflyContainer) Mode() Mode {
+	return c.mode
+}
+
+// ProxyURL returns the URL of the proxy from the host, e.g. http://localhost:8500,
+// to be used as the proxy of the HTTP clients of the tests.
+func (c *HoverflyContainer) ProxyURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultProxyPort, "http")
+}
+
+// AdminURL returns the URL of the admin API, e.g. http://localhost:8888.
+func (c *HoverflyContainer) AdminURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultAdminPort, "http")
+}
+
+// ProxyEnv returns the environment variables making the HTTP clients of a container use the proxy,
+// without changing their code, e.g. with testcontainers.WithEnv. The proxy is reached with the IP address
+// of the Hoverfly container, on the default bridge network. The HTTPS requests are intercepted too,
+// which requires the container to trust the certificate authority of Hoverfly.
+func (c *HoverflyContainer) ProxyEnv(ctx context.Context) (map[string]string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container ip: %w", err)
+	}
+
+	proxy := "http://" + ip + ":" + strings.TrimSuffix(defaultProxyPort, "/tcp")
+
+	return map[string]string{
+		"HTTP_PROXY":  proxy,
+		"HTTPS_PROXY": proxy,
+		"http_proxy":  proxy,
+		"https_proxy": proxy,
+	}, nil
+}
+
+// SetMode changes the mode of Hoverfly.
+func (c *HoverflyContainer) SetMode(ctx context.Context, mode Mode) error {
+	body := map[string]any{"mode": mode}
+	if mode == ModeCapture && len(c.settings.capturedHeaders) > 0 {
+		body["arguments"] = map[string]any{"headersWhitelist": c.settings.capturedHeaders}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.adminRequest(ctx, http.MethodPut, "/api/v2/hoverfly/mode", data); err != nil {
+		return fmt.Errorf("set mode %s: %w", mode, err)
+	}
+
+	c.mode = mode
+
+	return nil
+}
+
+// Export returns the recorded traffic, in the simulation format of Hoverfly.
+func (c *HoverflyContainer) Export(ctx context.Context) ([]byte, error) {
+	data, err := c.adminRequest(ctx, http.MethodGet, "/api/v2/simulation", nil)
+	if err != nil {
+		return nil, fmt.Errorf("export simulation: %w", err)
+	}
+
+	return data, nil
+}
+
+// Import replaces the recorded traffic with the given simulation, in the format of Hoverfly.
+func (c *HoverflyContainer) Import(ctx context.Context, simulation []byte) error {
+	if _, err := c.adminRequest(ctx, http.MethodPut, "/api/v2/simulation", simulation); err != nil {
+		return fmt.Errorf("import simulation: %w", err)
+	}
+
+	return nil
+}
+
+// SaveCassette writes the recorded traffic to the cassette set with WithCassette.
+func (c *HoverflyContainer) SaveCassette(ctx context.Context) error {
+	if c.settings.cassette == "" {
+		return errors.New("no cassette")
+	}
+
+	data, err := c.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.settings.cassette, data, 0o644); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Terminate saves the recorded traffic to the cassette, if it's recorded in the capture mode,
+// then terminates the container.
+func (c *HoverflyContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	if c.Container == nil {
+		return nil
+	}
+
+	var errs []error
+	if c.settings.cassette != "" && c.mode == ModeCapture {
+		errs = append(errs, c.SaveCassette(ctx))
+	}
+
+	return errors.Join(append(errs, c.Container.Terminate(ctx, opts...))...)
+}
+
+// Run creates an instance of the Hoverfly container type. With a cassette, it replays the traffic
+// recorded in the cassette, or records it if the cassette doesn't exist, see WithCassette.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*HoverflyContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{defaultProxyPort, defaultAdminPort},
+		WaitingFor: wait.ForAll(
+			wait.ForHTTP("/api/v2/hoverfly/mode").WithPort(defaultAdminPort),
+			wait.ForListeningPort(defaultProxyPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	simulation, mode, err := settings.cassetteMode()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &HoverflyContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+
+				if simulation != nil {
+					if err := c.Import(ctx, simulation); err != nil {
+						return err
+					}
+				}
+
+				return c.SetMode(ctx, mode)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, fmt.Errorf("generic container: %w", err)
+	}
+
+	c.Container = container
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// cassetteMode returns the simulation of the cassette, if it exists, and the mode of Hoverfly:
+// the mode of the options, or the simulate mode if the cassette exists, or the capture mode if it doesn't.
+func (o options) cassetteMode() ([]byte, Mode, error) {
+	mode := o.mode
+
+	if o.cassette == "" {
+		if mode == "" {
+			mode = ModeSimulate
+		}
+		return nil, mode, nil
+	}
+
+	simulation, err := os.ReadFile(o.cassette)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if mode == "" {
+			mode = ModeCapture
+		}
+		return nil, mode, nil
+	case err != nil:
+		return nil, "", fmt.Errorf("read cassette: %w", err)
+	}
+
+	if mode == "" {
+		mode = ModeSimulate
+	}
+
+	return simulation, mode, nil
+}
+
+// adminRequest sends the body, if any, to the admin API, returning the body of the response.
+func (c *HoverflyContainer) adminRequest(ctx context.Context, method string, path string, body []byte) ([]byte, error) {
+	adminURL, err := c.AdminURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, adminURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
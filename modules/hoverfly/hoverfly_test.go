@@ -0,0 +1,106 @@
+package hoverfly_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/hoverfly"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "spectolabs/hoverfly:v1.10.5"
+
+// get sends a GET request to the target through the proxy.
+func get(ctx context.Context, t *testing.T, ctr *hoverfly.HoverflyContainer, target string) (int, string) {
+	t.Helper()
+
+	proxyURL, err := ctr.ProxyURL(ctx)
+	require.NoError(t, err)
+
+	proxy, err := url.Parse(proxyURL)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxy)}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestHoverfly_cassette(t *testing.T) {
+	ctx := context.Background()
+
+	// the real API, only reachable while the traffic is recorded
+	api, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForHTTP("/").WithPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	apiIP, err := api.ContainerIP(ctx)
+	require.NoError(t, err)
+	target := "http://" + apiIP + "/"
+
+	// withCassette {
+	cassette := filepath.Join(t.TempDir(), "nginx.json")
+
+	recorder, err := hoverfly.Run(ctx, testImage, hoverfly.WithCassette(cassette))
+	// }
+	require.NoError(t, err)
+	require.Equal(t, hoverfly.ModeCapture, recorder.Mode())
+
+	status, recorded := get(ctx, t, recorder, target)
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, recorded, "Welcome to nginx!")
+
+	// the cassette is written when the container is terminated
+	require.NoError(t, recorder.Terminate(ctx))
+	require.NoError(t, api.Terminate(ctx))
+	require.FileExists(t, cassette)
+
+	replayer, err := hoverfly.Run(ctx, testImage, hoverfly.WithCassette(cassette))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, replayer.Terminate(ctx)) })
+	require.Equal(t, hoverfly.ModeSimulate, replayer.Mode())
+
+	status, replayed := get(ctx, t, replayer, target)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, recorded, replayed)
+}
+
+func TestHoverfly_proxyEnv(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := hoverfly.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	ip, err := ctr.ContainerIP(ctx)
+	require.NoError(t, err)
+
+	// proxyEnv {
+	env, err := ctr.ProxyEnv(ctx)
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "http://"+ip+":8500", env["HTTP_PROXY"])
+	require.Equal(t, "http://"+ip+":8500", env["https_proxy"])
+}
@@ -0,0 +1,65 @@
+package hoverfly
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Mode is the mode of Hoverfly, defining what the proxy does with the requests.
+type Mode string
+
+const (
+	// ModeCapture forwards the requests to the real APIs, recording the requests and their responses.
+	ModeCapture Mode = "capture"
+	// ModeSimulate answers the requests with the recorded responses, without reaching the real APIs.
+	ModeSimulate Mode = "simulate"
+	// ModeSpy answers the requests with the recorded responses, forwarding the other requests to the real APIs.
+	ModeSpy Mode = "spy"
+)
+
+type options struct {
+	cassette        string
+	mode            Mode
+	capturedHeaders []string
+}
+
+func defaultOptions() options {
+	return options{}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Hoverfly container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithCassette sets the file of the recorded traffic, in the simulation format of Hoverfly. If the file exists,
+// its responses are replayed, in the simulate mode. Otherwise, the traffic is recorded, in the capture mode,
+// and written to the file when the container is terminated, so the later runs replay it. Delete the file
+// to record the traffic again.
+func WithCassette(path string) Option {
+	return func(o *options) {
+		o.cassette = path
+	}
+}
+
+// WithMode sets the mode of Hoverfly, overriding the mode defined by the cassette. The default mode,
+// without cassette, is the simulate mode.
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithCapturedHeaders records the given request headers in the capture mode, so they are matched
+// when the responses are replayed. Use "*" to record all the headers. By default, the headers are not recorded.
+func WithCapturedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.capturedHeaders = append(o.capturedHeaders, headers...)
+	}
+}
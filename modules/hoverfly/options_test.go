@@ -0,0 +1,53 @@
+package hoverfly
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassetteMode(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	t.Run("no-cassette", func(t *testing.T) {
+		simulation, mode, err := defaultOptions().cassetteMode()
+		require.NoError(t, err)
+		require.Nil(t, simulation)
+		require.Equal(t, ModeSimulate, mode)
+	})
+
+	t.Run("record", func(t *testing.T) {
+		o := defaultOptions()
+		WithCassette(cassette)(&o)
+
+		simulation, mode, err := o.cassetteMode()
+		require.NoError(t, err)
+		require.Nil(t, simulation)
+		require.Equal(t, ModeCapture, mode)
+	})
+
+	require.NoError(t, os.WriteFile(cassette, []byte(`{"data":{}}`), 0o644))
+
+	t.Run("replay", func(t *testing.T) {
+		o := defaultOptions()
+		WithCassette(cassette)(&o)
+
+		simulation, mode, err := o.cassetteMode()
+		require.NoError(t, err)
+		require.Equal(t, `{"data":{}}`, string(simulation))
+		require.Equal(t, ModeSimulate, mode)
+	})
+
+	t.Run("mode", func(t *testing.T) {
+		o := defaultOptions()
+		WithCassette(cassette)(&o)
+		WithMode(ModeSpy)(&o)
+
+		simulation, mode, err := o.cassetteMode()
+		require.NoError(t, err)
+		require.NotNil(t, simulation)
+		require.Equal(t, ModeSpy, mode)
+	})
+}
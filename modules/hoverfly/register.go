@@ -0,0 +1,17 @@
+package hoverfly
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "hoverfly",
+		DefaultImage: "spectolabs/hoverfly:v1.10.5",
+		ExposedPorts: []string{defaultProxyPort, defaultAdminPort},
+		Options: map[string]any{
+			"WithCapturedHeaders": WithCapturedHeaders,
+			"WithCassette":        WithCassette,
+			"WithMode":            WithMode,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,41 @@
+package ibmmq_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/ibmmq"
+)
+
+func ExampleRun() {
+	// runIBMMQContainer {
+	ctx := context.Background()
+
+	ibmmqContainer, err := ibmmq.Run(ctx, "icr.io/ibm-messaging/mq:9.4.0.0-r3",
+		ibmmq.WithQueues("ORDERS"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := ibmmqContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := ibmmqContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+	fmt.Println(ibmmqContainer.QueueManager(), ibmmqContainer.Channel())
+
+	// Output:
+	// true
+	// QM1 DEV.APP.SVRCONN
+}
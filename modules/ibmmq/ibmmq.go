@@ -0,0 +1,162 @@
+package ibmmq
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	listenerPort = nat.Port("1414/tcp")
+	webPort      = nat.Port("9443/tcp")
+
+	appUser     = "app"
+	appChannel  = "DEV.APP.SVRCONN"
+	mqscDir     = "/etc/mqm/"
+	mqscObjects = mqscDir + "20-testcontainers.mqsc"
+)
+
+// IBMMQContainer represents the IBM MQ container type used in the module
+type IBMMQContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// QueueManager returns the name of the queue manager.
+func (c *IBMMQContainer) QueueManager() string {
+	return c.settings.queueManager
+}
+
+// Channel returns the name of the server connection channel used by the applications.
+func (c *IBMMQContainer) Channel() string {
+	return appChannel
+}
+
+// Username returns the name of the user used by the applications.
+func (c *IBMMQContainer) Username() string {
+	return appUser
+}
+
+// Password returns the password of the user used by the applications.
+func (c *IBMMQContainer) Password() string {
+	return c.settings.appPassword
+}
+
+// ConnectionHost returns the host and port of the listener of the queue manager,
+// obtaining the host and exposed port from the container.
+func (c *IBMMQContainer) ConnectionHost(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, listenerPort, "")
+}
+
+// ConnectionName returns the host and port of the listener of the queue manager in the
+// host(port) format, used as the connection name by the MQ clients, e.g. as the
+// connectionNameList of a JMS connection factory.
+func (c *IBMMQContainer) ConnectionName(ctx context.Context) (string, error) {
+	hostPort, err := c.ConnectionHost(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s(%s)", host, port), nil
+}
+
+// ConsoleURL returns the URL of the web console, which is served over HTTPS
+// with a self-signed certificate.
+func (c *IBMMQContainer) ConsoleURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, webPort, "https")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/ibmmq/console", nil
+}
+
+// Run creates an instance of the IBM MQ container type, running a queue manager with the
+// developer configuration, and accepting the license of the image.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*IBMMQContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"LICENSE": "accept",
+		},
+		ExposedPorts: []string{string(listenerPort), string(webPort)},
+		WaitingFor: wait.ForAll(
+			// the listener has started
+			wait.ForLog("AMQ5026I"),
+			wait.ForListeningPort(listenerPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	genericContainerReq.Env["MQ_QMGR_NAME"] = settings.queueManager
+	genericContainerReq.Env["MQ_APP_PASSWORD"] = settings.appPassword
+	genericContainerReq.Env["MQ_ADMIN_PASSWORD"] = settings.adminPassword
+
+	// the scripts in /etc/mqm are run in lexical order when the queue manager starts,
+	// after the developer configuration in 10-dev.mqsc.
+	if mqsc := objectsMQSC(settings.queues, settings.topics); mqsc != "" {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(mqsc),
+			ContainerFilePath: mqscObjects,
+			FileMode:          0o644,
+		})
+	}
+
+	for i, script := range settings.initScripts {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      script,
+			ContainerFilePath: fmt.Sprintf("%s%d-%s", mqscDir, 30+i, filepath.Base(script)),
+			FileMode:          0o644,
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IBMMQContainer{Container: container, settings: settings}, nil
+}
+
+// objectsMQSC returns the MQSC commands defining the given queues and topics,
+// and granting the app user access to them.
+func objectsMQSC(queues []string, topics []string) string {
+	var sb strings.Builder
+	for _, q := range queues {
+		fmt.Fprintf(&sb, "DEFINE QLOCAL('%s') REPLACE\n", q)
+		fmt.Fprintf(&sb, "SET AUTHREC PROFILE('%s') PRINCIPAL('%s') OBJTYPE(QUEUE) AUTHADD(BROWSE,GET,INQ,PUT)\n", q, appUser)
+	}
+
+	for _, t := range topics {
+		fmt.Fprintf(&sb, "DEFINE TOPIC('%s') TOPICSTR('%s') REPLACE\n", t, t)
+		fmt.Fprintf(&sb, "SET AUTHREC PROFILE('%s') PRINCIPAL('%s') OBJTYPE(TOPIC) AUTHADD(PUB,SUB)\n", t, appUser)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package ibmmq_test
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules/ibmmq"
+)
+
+const testImage = "icr.io/ibm-messaging/mq:9.4.0.0-r3"
+
+func TestIBMMQ(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := ibmmq.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "QM1", ctr.QueueManager())
+	require.Equal(t, "DEV.APP.SVRCONN", ctr.Channel())
+	require.Equal(t, "app", ctr.Username())
+	require.Equal(t, "passw0rd", ctr.Password())
+
+	// connectionName {
+	connName, err := ctr.ConnectionName(ctx)
+	// }
+	require.NoError(t, err)
+
+	host, err := ctr.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	h, p, ok := strings.Cut(host, ":")
+	require.True(t, ok)
+	require.Equal(t, h+"("+p+")", connName)
+
+	// the developer configuration defines the DEV queues
+	requireObject(t, ctr, "DISPLAY QLOCAL('DEV.QUEUE.1')")
+}
+
+func TestIBMMQWithQueuesAndTopics(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := ibmmq.Run(ctx, testImage,
+		// withQueuesAndTopics {
+		ibmmq.WithQueueManager("QM2"),
+		ibmmq.WithAppPassword("secret"),
+		ibmmq.WithQueues("ORDERS", "INVOICES"),
+		ibmmq.WithTopics("EVENTS"),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "QM2", ctr.QueueManager())
+	require.Equal(t, "secret", ctr.Password())
+
+	requireObject(t, ctr, "DISPLAY QLOCAL('ORDERS')")
+	requireObject(t, ctr, "DISPLAY QLOCAL('INVOICES')")
+	requireObject(t, ctr, "DISPLAY TOPIC('EVENTS')")
+	requireObject(t, ctr, "DISPLAY AUTHREC PROFILE('ORDERS') OBJTYPE(QUEUE) PRINCIPAL('app')")
+}
+
+func TestIBMMQWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := ibmmq.Run(ctx, testImage,
+		// withInitScripts {
+		ibmmq.WithInitScripts(filepath.Join("testdata", "init.mqsc")),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	requireObject(t, ctr, "DISPLAY QLOCAL('DEV.INIT.QUEUE')")
+}
+
+// requireObject runs the given MQSC command with runmqsc, requiring it to succeed.
+func requireObject(t *testing.T, ctr *ibmmq.IBMMQContainer, mqsc string) {
+	t.Helper()
+
+	cmd := []string{"sh", "-c", `echo "$0" | runmqsc "$1"`, mqsc, ctr.QueueManager()}
+
+	code, r, err := ctr.Exec(context.Background(), cmd, tcexec.Multiplexed())
+	require.NoError(t, err)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Zerof(t, code, "runmqsc failed: %s", output)
+}
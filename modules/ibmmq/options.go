@@ -0,0 +1,87 @@
+package ibmmq
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultQueueManager  = "QM1"
+	defaultAppPassword   = "passw0rd"
+	defaultAdminPassword = "passw0rd"
+)
+
+type options struct {
+	queueManager  string
+	appPassword   string
+	adminPassword string
+	queues        []string
+	topics        []string
+	initScripts   []string
+}
+
+func defaultOptions() options {
+	return options{
+		queueManager:  defaultQueueManager,
+		appPassword:   defaultAppPassword,
+		adminPassword: defaultAdminPassword,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the IBM MQ container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithQueueManager sets the name of the queue manager. The default is QM1.
+func WithQueueManager(name string) Option {
+	return func(o *options) {
+		o.queueManager = name
+	}
+}
+
+// WithAppPassword sets the password of the app user, which connects to the queue manager
+// through the DEV.APP.SVRCONN channel. The default is passw0rd.
+func WithAppPassword(password string) Option {
+	return func(o *options) {
+		o.appPassword = password
+	}
+}
+
+// WithAdminPassword sets the password of the admin user, which connects to the queue manager
+// through the DEV.ADMIN.SVRCONN channel, and to the web console. The default is passw0rd.
+func WithAdminPassword(password string) Option {
+	return func(o *options) {
+		o.adminPassword = password
+	}
+}
+
+// WithQueues creates the given local queues when the queue manager starts,
+// granting the app user access to them.
+func WithQueues(queues ...string) Option {
+	return func(o *options) {
+		o.queues = append(o.queues, queues...)
+	}
+}
+
+// WithTopics creates the given topics when the queue manager starts, using the name
+// of each topic as its topic string, and granting the app user access to them.
+func WithTopics(topics ...string) Option {
+	return func(o *options) {
+		o.topics = append(o.topics, topics...)
+	}
+}
+
+// WithInitScripts sets the MQSC scripts to be run, in order, when the queue manager starts,
+// after the developer configuration and the queues and topics set with the options.
+func WithInitScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.initScripts = append(o.initScripts, scripts...)
+	}
+}
@@ -0,0 +1,20 @@
+package ibmmq
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "ibmmq",
+		DefaultImage: "icr.io/ibm-messaging/mq:9.4.0.0-r3",
+		ExposedPorts: []string{string(listenerPort), string(webPort)},
+		Options: map[string]any{
+			"WithAdminPassword": WithAdminPassword,
+			"WithAppPassword":   WithAppPassword,
+			"WithInitScripts":   WithInitScripts,
+			"WithQueueManager":  WithQueueManager,
+			"WithQueues":        WithQueues,
+			"WithTopics":        WithTopics,
+		},
+		Run: modules.Runner(Run),
+	})
+}
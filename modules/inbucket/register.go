@@ -0,0 +1,12 @@
+package inbucket
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "inbucket",
+		DefaultImage: "inbucket/inbucket:sha-2d409bb",
+		ExposedPorts: []string{"2500/tcp", "9000/tcp", "1100/tcp"},
+		Run:          modules.Runner(Run),
+	})
+}
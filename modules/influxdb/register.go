@@ -0,0 +1,19 @@
+package influxdb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "influxdb",
+		DefaultImage: "influxdb:1.8",
+		ExposedPorts: []string{"8086/tcp", "8088/tcp"},
+		Options: map[string]any{
+			"WithConfigFile": WithConfigFile,
+			"WithDatabase":   WithDatabase,
+			"WithInitDb":     WithInitDb,
+			"WithPassword":   WithPassword,
+			"WithUsername":   WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
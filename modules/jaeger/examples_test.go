@@ -0,0 +1,37 @@
+package jaeger_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/jaeger"
+)
+
+func ExampleRun() {
+	// runJaegerContainer {
+	ctx := context.Background()
+
+	jaegerContainer, err := jaeger.Run(ctx, "jaegertracing/all-in-one:1.60")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := jaegerContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := jaegerContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
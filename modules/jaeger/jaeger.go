@@ -0,0 +1,274 @@
+package jaeger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// QueryPort is the port of the query API and of the UI.
+	QueryPort = "16686/tcp"
+	// OTLPGRPCPort is the port of the OTLP receiver over gRPC.
+	OTLPGRPCPort = "4317/tcp"
+	// OTLPHTTPPort is the port of the OTLP receiver over HTTP.
+	OTLPHTTPPort = "4318/tcp"
+
+	adminPort = "14269/tcp"
+)
+
+// JaegerContainer represents the Jaeger container type used in the module. It runs the all-in-one
+// distribution of Jaeger, receiving the traces with OTLP and storing them in memory.
+type JaegerContainer struct {
+	testcontainers.Container
+}
+
+// TraceQuery is the query of the traces of a service.
+type TraceQuery struct {
+	// Service is the name of the service, required.
+	Service string
+	// Operation is the name of the operation of a span of the traces, if any.
+	Operation string
+	// Tags are the tags of a span of the traces, if any, e.g. http.status_code=500.
+	Tags map[string]string
+	// Lookback is the duration to look back from now, an hour by default.
+	Lookback time.Duration
+	// Limit is the maximum number of traces, 20 by default.
+	Limit int
+}
+
+// values returns the query parameters of the query API.
+func (q TraceQuery) values() (url.Values, error) {
+	if q.Service == "" {
+		return nil, errors.New("no service")
+	}
+
+	lookback := q.Lookback
+	if lookback == 0 {
+		lookback = time.Hour
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	end := time.Now()
+	v := url.Values{
+		"service": {q.Service},
+		"start":   {strconv.FormatInt(end.Add(-lookback).UnixMicro(), 10)},
+		"end":     {strconv.FormatInt(end.UnixMicro(), 10)},
+		"limit":   {strconv.Itoa(limit)},
+	}
+	if q.Operation != "" {
+		v.Set("operation", q.Operation)
+	}
+	if len(q.Tags) > 0 {
+		tags, err := json.Marshal(q.Tags)
+		if err != nil {
+			return nil, err
+		}
+		v.Set("tags", string(tags))
+	}
+
+	return v, nil
+}
+
+// QueryURL returns the URL of the query API and of the UI, e.g. http://localhost:16686.
+func (c *JaegerContainer) QueryURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, QueryPort, "http")
+}
+
+// OTLPGRPCEndpoint returns the address of the OTLP receiver over gRPC, e.g. localhost:4317.
+func (c *JaegerContainer) OTLPGRPCEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("host: %w", err)
+	}
+
+	port, err := c.MappedPort(ctx, OTLPGRPCPort)
+	if err != nil {
+		return "", fmt.Errorf("mapped port: %w", err)
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// OTLPHTTPEndpoint returns the URL of the OTLP receiver over HTTP, e.g. http://localhost:4318.
+func (c *JaegerContainer) OTLPHTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, OTLPHTTPPort, "http")
+}
+
+// OTLPEnv returns the environment variables making the OpenTelemetry SDKs of a container export their traces
+// to Jaeger over HTTP, e.g. with testcontainers.WithEnv. Jaeger is reached with its IP address, on the default
+// bridge network.
+func (c *JaegerContainer) OTLPEnv(ctx context.Context) (map[string]string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container ip: %w", err)
+	}
+
+	return map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://" + net.JoinHostPort(ip, strings.TrimSuffix(OTLPHTTPPort, "/tcp")),
+		"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+		"OTEL_TRACES_EXPORTER":        "otlp",
+	}, nil
+}
+
+// Services returns the names of the services which sent traces.
+func (c *JaegerContainer) Services(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Data []string `json:"data"`
+	}
+	if err := c.query(ctx, "/api/services", nil, &resp); err != nil {
+		return nil, fmt.Errorf("services: %w", err)
+	}
+
+	return resp.Data, nil
+}
+
+// Operations returns the names of the operations of the spans of the service.
+func (c *JaegerContainer) Operations(ctx context.Context, service string) ([]string, error) {
+	var resp struct {
+		Data []string `json:"data"`
+	}
+	if err := c.query(ctx, "/api/services/"+url.PathEscape(service)+"/operations", nil, &resp); err != nil {
+		return nil, fmt.Errorf("operations of %s: %w", service, err)
+	}
+
+	return resp.Data, nil
+}
+
+// FindTraces returns the traces matching the query, the most recent first.
+func (c *JaegerContainer) FindTraces(ctx context.Context, q TraceQuery) ([]Trace, error) {
+	values, err := q.values()
+	if err != nil {
+		return nil, fmt.Errorf("find traces: %w", err)
+	}
+
+	var resp apiTraces
+	if err := c.query(ctx, "/api/traces", values, &resp); err != nil {
+		return nil, fmt.Errorf("find traces: %w", err)
+	}
+
+	traces := make([]Trace, 0, len(resp.Data))
+	for _, t := range resp.Data {
+		traces = append(traces, t.trace())
+	}
+
+	return traces, nil
+}
+
+// Trace returns the trace of the ID, in hexadecimal.
+func (c *JaegerContainer) Trace(ctx context.Context, traceID string) (Trace, error) {
+	var resp apiTraces
+	if err := c.query(ctx, "/api/traces/"+url.PathEscape(traceID), nil, &resp); err != nil {
+		return Trace{}, fmt.Errorf("trace %s: %w", traceID, err)
+	}
+
+	if len(resp.Data) == 0 {
+		return Trace{}, fmt.Errorf("trace %s not found", traceID)
+	}
+
+	return resp.Data[0].trace(), nil
+}
+
+// WaitForTrace waits until a trace matches the query, returning the most recent one, or until the context is done.
+// The traces are queried as soon as they are received, as the spans of a trace may arrive in several batches.
+func (c *JaegerContainer) WaitForTrace(ctx context.Context, q TraceQuery) (Trace, error) {
+	for {
+		traces, err := c.FindTraces(ctx, q)
+		if err != nil {
+			return Trace{}, err
+		}
+
+		if len(traces) > 0 {
+			return traces[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Trace{}, fmt.Errorf("wait for trace of %s: %w", q.Service, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// query sends a GET request to the query API, decoding the JSON response into out.
+func (c *JaegerContainer) query(ctx context.Context, path string, values url.Values, out any) error {
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := testcontainers.HTTPClient(c, QueryPort).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// Run creates an instance of the Jaeger container type, with the all-in-one distribution of Jaeger,
+// e.g. jaegertracing/all-in-one.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*JaegerContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"COLLECTOR_OTLP_ENABLED": "true",
+		},
+		ExposedPorts: []string{QueryPort, OTLPGRPCPort, OTLPHTTPPort, adminPort},
+		WaitingFor: wait.ForAll(
+			wait.ForHTTP("/").WithPort(adminPort),
+			wait.ForListeningPort(OTLPGRPCPort),
+			wait.ForListeningPort(OTLPHTTPPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *JaegerContainer
+	if container != nil {
+		c = &JaegerContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,90 @@
+package jaeger_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/jaeger"
+)
+
+// traces are two spans of a trace of the checkout service, in the OTLP JSON encoding.
+const traces = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+"scopeSpans":[{"scope":{"name":"tests"},"spans":[
+{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174","name":"GET /cart","kind":2,
+"startTimeUnixNano":"%d","endTimeUnixNano":"%d","attributes":[{"key":"http.status_code","value":{"intValue":"200"}}]},
+{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b175","parentSpanId":"eee19b7ec3c1b174","name":"SELECT carts","kind":3,
+"startTimeUnixNano":"%d","endTimeUnixNano":"%d"}]}]}]}`
+
+func TestJaeger(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := jaeger.Run(ctx, "jaegertracing/all-in-one:1.60")
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	endpoint, err := ctr.OTLPHTTPEndpoint(ctx)
+	require.NoError(t, err)
+
+	now := time.Now().UnixNano()
+	payload := fillTimes(traces, now)
+
+	resp, err := http.Post(endpoint+"/v1/traces", "application/json", strings.NewReader(payload))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// waitForTrace {
+	trace, err := ctr.WaitForTrace(waitCtx, jaeger.TraceQuery{Service: "checkout", Operation: "GET /cart"})
+	require.NoError(t, err)
+	// }
+	require.Equal(t, "5b8efff798038103d269b633813fc60c", trace.TraceID)
+	require.Len(t, trace.Spans, 2)
+
+	root, ok := trace.RootSpan()
+	require.True(t, ok)
+	require.Equal(t, "GET /cart", root.OperationName)
+
+	t.Run("services", func(t *testing.T) {
+		services, err := ctr.Services(ctx)
+		require.NoError(t, err)
+		require.Contains(t, services, "checkout")
+
+		operations, err := ctr.Operations(ctx, "checkout")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"GET /cart", "SELECT carts"}, operations)
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		trace, err := ctr.Trace(ctx, "5b8efff798038103d269b633813fc60c")
+		require.NoError(t, err)
+		require.Len(t, trace.SpansOf("SELECT carts"), 1)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		traces, err := ctr.FindTraces(ctx, jaeger.TraceQuery{Service: "checkout", Tags: map[string]string{"http.status_code": "500"}})
+		require.NoError(t, err)
+		require.Empty(t, traces)
+	})
+}
+
+// fillTimes sets the start and end times of the spans of the payload, relative to now.
+func fillTimes(payload string, now int64) string {
+	for _, offset := range []int64{0, 250, 100, 200} {
+		payload = strings.Replace(payload, "%d", strconv.FormatInt(now+offset*int64(time.Millisecond), 10), 1)
+	}
+
+	return payload
+}
@@ -0,0 +1,12 @@
+package jaeger
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "jaeger",
+		DefaultImage: "jaegertracing/all-in-one:1.60",
+		ExposedPorts: []string{QueryPort, OTLPGRPCPort, OTLPHTTPPort, adminPort},
+		Run:          modules.Runner(Run),
+	})
+}
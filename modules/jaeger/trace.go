@@ -0,0 +1,122 @@
+package jaeger
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Trace is a trace found by the query API of Jaeger.
+type Trace struct {
+	TraceID string
+	Spans   []Span
+}
+
+// Span is a span of a trace found by the query API.
+type Span struct {
+	TraceID       string
+	SpanID        string
+	ParentSpanID  string
+	OperationName string
+	ServiceName   string
+	StartTime     time.Time
+	Duration      time.Duration
+	Tags          map[string]any
+}
+
+// RootSpan returns the span of the trace without parent, if any.
+func (t Trace) RootSpan() (Span, bool) {
+	for _, s := range t.Spans {
+		if s.ParentSpanID == "" {
+			return s, true
+		}
+	}
+
+	return Span{}, false
+}
+
+// SpansOf returns the spans of the operation of the trace.
+func (t Trace) SpansOf(operation string) []Span {
+	var spans []Span
+	for _, s := range t.Spans {
+		if s.OperationName == operation {
+			spans = append(spans, s)
+		}
+	}
+
+	return spans
+}
+
+// The types of the JSON responses of the query API.
+type (
+	apiTraces struct {
+		Data   []apiTrace `json:"data"`
+		Errors []struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"errors"`
+	}
+
+	apiTrace struct {
+		TraceID   string                `json:"traceID"`
+		Spans     []apiSpan             `json:"spans"`
+		Processes map[string]apiProcess `json:"processes"`
+	}
+
+	apiSpan struct {
+		TraceID       string `json:"traceID"`
+		SpanID        string `json:"spanID"`
+		OperationName string `json:"operationName"`
+		References    []struct {
+			RefType string `json:"refType"`
+			SpanID  string `json:"spanID"`
+		} `json:"references"`
+		// StartTime and Duration are in microseconds.
+		StartTime int64    `json:"startTime"`
+		Duration  int64    `json:"duration"`
+		Tags      []apiTag `json:"tags"`
+		ProcessID string   `json:"processID"`
+	}
+
+	apiProcess struct {
+		ServiceName string `json:"serviceName"`
+	}
+
+	apiTag struct {
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+)
+
+// trace returns the trace of the response of the query API.
+func (t apiTrace) trace() Trace {
+	trace := Trace{TraceID: t.TraceID, Spans: make([]Span, 0, len(t.Spans))}
+	for _, s := range t.Spans {
+		span := Span{
+			TraceID:       s.TraceID,
+			SpanID:        s.SpanID,
+			OperationName: s.OperationName,
+			ServiceName:   t.Processes[s.ProcessID].ServiceName,
+			StartTime:     time.UnixMicro(s.StartTime),
+			Duration:      time.Duration(s.Duration) * time.Microsecond,
+			Tags:          make(map[string]any, len(s.Tags)),
+		}
+
+		for _, ref := range s.References {
+			if ref.RefType == "CHILD_OF" {
+				span.ParentSpanID = ref.SpanID
+				break
+			}
+		}
+
+		for _, tag := range s.Tags {
+			var v any
+			if err := json.Unmarshal(tag.Value, &v); err == nil {
+				span.Tags[tag.Key] = v
+			}
+		}
+
+		trace.Spans = append(trace.Spans, span)
+	}
+
+	return trace
+}
@@ -0,0 +1,72 @@
+package jaeger
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITrace_trace(t *testing.T) {
+	data, err := os.ReadFile("testdata/traces.json")
+	require.NoError(t, err)
+
+	var resp apiTraces
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.Len(t, resp.Data, 1)
+
+	trace := resp.Data[0].trace()
+	require.Equal(t, "5b8efff798038103d269b633813fc60c", trace.TraceID)
+	require.Len(t, trace.Spans, 2)
+
+	require.Equal(t, Span{
+		TraceID:       "5b8efff798038103d269b633813fc60c",
+		SpanID:        "eee19b7ec3c1b175",
+		ParentSpanID:  "eee19b7ec3c1b174",
+		OperationName: "SELECT carts",
+		ServiceName:   "carts",
+		StartTime:     time.UnixMicro(1700000000100000),
+		Duration:      100 * time.Millisecond,
+		Tags:          map[string]any{"db.system": "postgresql", "error": true},
+	}, trace.Spans[0])
+
+	root, ok := trace.RootSpan()
+	require.True(t, ok)
+	require.Equal(t, "GET /cart", root.OperationName)
+	require.Equal(t, "checkout", root.ServiceName)
+	require.Equal(t, 200.0, root.Tags["http.status_code"])
+
+	require.Len(t, trace.SpansOf("SELECT carts"), 1)
+	require.Empty(t, trace.SpansOf("POST /orders"))
+}
+
+func TestTraceQuery_values(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		v, err := TraceQuery{Service: "checkout"}.values()
+		require.NoError(t, err)
+		require.Equal(t, "checkout", v.Get("service"))
+		require.Equal(t, "20", v.Get("limit"))
+		require.False(t, v.Has("operation"))
+		require.False(t, v.Has("tags"))
+	})
+
+	t.Run("operation-tags", func(t *testing.T) {
+		v, err := TraceQuery{
+			Service:   "checkout",
+			Operation: "GET /cart",
+			Tags:      map[string]string{"http.status_code": "500"},
+			Limit:     5,
+		}.values()
+		require.NoError(t, err)
+		require.Equal(t, "GET /cart", v.Get("operation"))
+		require.Equal(t, `{"http.status_code":"500"}`, v.Get("tags"))
+		require.Equal(t, "5", v.Get("limit"))
+	})
+
+	t.Run("no-service", func(t *testing.T) {
+		_, err := TraceQuery{Operation: "GET /cart"}.values()
+		require.EqualError(t, err, "no service")
+	})
+}
@@ -0,0 +1,18 @@
+package k3s
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "k3s",
+		DefaultImage: "docker.io/rancher/k3s:v1.27.1-k3s1",
+		ExposedPorts: []string{
+			defaultKubeSecurePort,
+			defaultRancherWebhookPort,
+		},
+		Options: map[string]any{
+			"WithManifest": WithManifest,
+		},
+		Run: modules.Runner(Run),
+	})
+}
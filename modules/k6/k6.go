@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types/mount"
@@ -130,6 +132,34 @@ func SetEnvVar(variable string, value string) testcontainers.CustomizeRequestOpt
 	}
 }
 
+// TargetEnvVar is the environment variable of the test script set to the URL of the target, e.g. __ENV.TARGET_URL.
+const TargetEnvVar = "TARGET_URL"
+
+// WithTarget sets the URL of the target of the test script, e.g. http://172.17.0.3:8080,
+// in the TARGET_URL environment variable of the script.
+func WithTarget(targetURL string) testcontainers.CustomizeRequestOption {
+	return SetEnvVar(TargetEnvVar, targetURL)
+}
+
+// WithTargetContainer sets the URL of the port of the container, e.g. http://172.17.0.3:8080, as the target of the test script,
+// in the TARGET_URL environment variable of the script. The container is reached with its IP address, on the default bridge network.
+func WithTargetContainer(target testcontainers.Container, port string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		ip, err := target.ContainerIP(context.Background())
+		if err != nil {
+			return fmt.Errorf("target container ip: %w", err)
+		}
+
+		return WithTarget("http://" + net.JoinHostPort(ip, strings.TrimSuffix(port, "/tcp")))(req)
+	}
+}
+
+// WithSummaryTrendStats sets the values of the trends in the summary of the run, e.g. avg, p(95) and p(99).
+// The summary reports avg, min, med, max, p(90) and p(95) by default.
+func WithSummaryTrendStats(stats ...string) testcontainers.CustomizeRequestOption {
+	return WithCmdOptions("--summary-trend-stats=" + strings.Join(stats, ","))
+}
+
 // WithCache sets a volume as a cache for building the k6 binary
 // If a volume name is provided in the TC_K6_BUILD_CACHE, this volume is used and it will
 // persist across test sessions.
@@ -160,6 +190,23 @@ func WithCache() testcontainers.CustomizeRequestOption {
 	}
 }
 
+// Summary returns the summary of the test run, with its metrics and the results of their thresholds,
+// e.g. to assert the p(95) of the duration of the requests. k6 exits with the code 99 when a threshold fails.
+func (c *K6Container) Summary(ctx context.Context) (Summary, error) {
+	r, err := c.CopyFileFromContainer(ctx, summaryPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("copy summary: %w", err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Summary{}, fmt.Errorf("read summary: %w", err)
+	}
+
+	return parseSummary(data)
+}
+
 // Deprecated: use Run instead
 // RunContainer creates an instance of the K6 container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*K6Container, error) {
@@ -170,7 +217,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*K6Container, error) {
 	req := testcontainers.ContainerRequest{
 		Image:      img,
-		Cmd:        []string{"run"},
+		Cmd:        []string{"run", "--summary-export=" + summaryPath},
 		WaitingFor: wait.ForExit(),
 	}
 
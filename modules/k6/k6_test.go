@@ -6,9 +6,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/k6"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 func TestK6(t *testing.T) {
@@ -84,3 +86,65 @@ func TestK6(t *testing.T) {
 		})
 	}
 }
+
+func TestK6Summary(t *testing.T) {
+	ctx := context.Background()
+
+	httpbin, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "kennethreitz/httpbin",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForExposedPort(),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := httpbin.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	absPath, err := filepath.Abs(filepath.Join("scripts", "thresholds.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// runK6Summary {
+	container, err := k6.Run(ctx, "szkiba/k6x:v0.3.1",
+		k6.WithCache(),
+		k6.WithTestScript(absPath),
+		k6.WithTargetContainer(httpbin, "80/tcp"),
+		k6.WithSummaryTrendStats("avg", "p(95)", "p(99)"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	summary, err := container.Summary(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if failed := summary.FailedThresholds(); len(failed) > 0 {
+		t.Fatalf("failed thresholds: %v", failed)
+	}
+
+	duration, _ := summary.Metric("http_req_duration")
+	p95, _ := duration.Duration("p(95)")
+	// }
+	if p95 <= 0 || p95 > 500*time.Millisecond {
+		t.Fatalf("unexpected p(95) of the requests: %s", p95)
+	}
+
+	if _, ok := duration.Value("p(99)"); !ok {
+		t.Fatal("expected the p(99) trend stat")
+	}
+}
@@ -0,0 +1,20 @@
+package k6
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "k6",
+		DefaultImage: "szkiba/k6x:v0.3.1",
+		Options: map[string]any{
+			"WithCache":             WithCache,
+			"WithCmdOptions":        WithCmdOptions,
+			"WithRemoteTestScript":  WithRemoteTestScript,
+			"WithSummaryTrendStats": WithSummaryTrendStats,
+			"WithTarget":            WithTarget,
+			"WithTestScript":        WithTestScript,
+			"WithTestScriptReader":  WithTestScriptReader,
+		},
+		Run: modules.Runner(Run),
+	})
+}
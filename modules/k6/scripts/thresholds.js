@@ -0,0 +1,19 @@
+import { check } from 'k6';
+import http from 'k6/http';
+
+export const options = {
+  vus: 2,
+  duration: '3s',
+  thresholds: {
+    http_req_duration: ['p(95)<500'],
+    checks: ['rate>0.99'],
+  },
+};
+
+export default function () {
+  const res = http.get(`${__ENV.TARGET_URL}/status/200`);
+
+  check(res, {
+    'is status 200': (r) => r.status === 200,
+  });
+}
@@ -0,0 +1,113 @@
+package k6
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// summaryPath is the path of the summary of the test run, exported by k6 in the container.
+const summaryPath = "/home/k6x/summary.json"
+
+// Summary is the summary of a test run, with the metrics of the run and the results of their thresholds.
+type Summary struct {
+	// Metrics are the metrics of the run, by name, e.g. http_req_duration or http_req_duration{expected_response:true}.
+	Metrics map[string]Metric
+}
+
+// Metric is a metric of a test run.
+type Metric struct {
+	// Values are the values of the metric, by name, which depend on the type of the metric,
+	// e.g. avg, min, med, max, p(90) and p(95) for a trend, count and rate for a counter, or passes, fails and value for a rate.
+	Values map[string]float64
+	// Thresholds are the thresholds of the metric, sorted by expression.
+	Thresholds []Threshold
+}
+
+// Threshold is the result of a threshold of a metric.
+type Threshold struct {
+	// Expression is the expression of the threshold, e.g. p(95)<500.
+	Expression string
+	// OK reports whether the threshold passed.
+	OK bool
+}
+
+// Value returns the value of the metric of the given name, e.g. p(95), if any.
+func (m Metric) Value(name string) (float64, bool) {
+	v, ok := m.Values[name]
+	return v, ok
+}
+
+// Duration returns the value of a trend of durations, e.g. p(95) of http_req_duration, which k6 reports in milliseconds.
+func (m Metric) Duration(name string) (time.Duration, bool) {
+	v, ok := m.Values[name]
+	if !ok {
+		return 0, false
+	}
+
+	return time.Duration(v * float64(time.Millisecond)), true
+}
+
+// Metric returns the metric of the given name, if any.
+func (s Summary) Metric(name string) (Metric, bool) {
+	m, ok := s.Metrics[name]
+	return m, ok
+}
+
+// FailedThresholds returns the thresholds which failed, as <metric>: <expression>, e.g. http_req_duration: p(95)<500,
+// sorted. The run passed all its thresholds if there are none.
+func (s Summary) FailedThresholds() []string {
+	var failed []string
+	for name, m := range s.Metrics {
+		for _, t := range m.Thresholds {
+			if !t.OK {
+				failed = append(failed, name+": "+t.Expression)
+			}
+		}
+	}
+	sort.Strings(failed)
+
+	return failed
+}
+
+// parseSummary parses the summary exported by k6 with --summary-export, where the values of the metrics
+// are next to their thresholds, which map the expressions to whether they failed.
+func parseSummary(data []byte) (Summary, error) {
+	var raw struct {
+		Metrics map[string]map[string]json.RawMessage `json:"metrics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Summary{}, fmt.Errorf("unmarshal summary: %w", err)
+	}
+
+	s := Summary{Metrics: make(map[string]Metric, len(raw.Metrics))}
+	for name, fields := range raw.Metrics {
+		m := Metric{Values: map[string]float64{}}
+		for k, v := range fields {
+			if k == "thresholds" {
+				var thresholds map[string]bool
+				if err := json.Unmarshal(v, &thresholds); err != nil {
+					return Summary{}, fmt.Errorf("unmarshal thresholds of %s: %w", name, err)
+				}
+
+				for expr, failed := range thresholds {
+					m.Thresholds = append(m.Thresholds, Threshold{Expression: expr, OK: !failed})
+				}
+				sort.Slice(m.Thresholds, func(i, j int) bool {
+					return m.Thresholds[i].Expression < m.Thresholds[j].Expression
+				})
+				continue
+			}
+
+			// the values of some metrics are not numbers, e.g. the null values of an empty trend
+			var f *float64
+			if err := json.Unmarshal(v, &f); err == nil && f != nil {
+				m.Values[k] = *f
+			}
+		}
+		s.Metrics[name] = m
+	}
+
+	return s, nil
+}
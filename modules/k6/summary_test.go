@@ -0,0 +1,55 @@
+package k6
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSummary(t *testing.T) {
+	data, err := os.ReadFile("testdata/summary.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := parseSummary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duration, ok := s.Metric("http_req_duration")
+	if !ok {
+		t.Fatal("expected http_req_duration metric")
+	}
+
+	p95, ok := duration.Duration("p(95)")
+	if !ok || p95 != 5250*time.Microsecond {
+		t.Fatalf("expected p(95) of 5.25ms, got %s", p95)
+	}
+
+	expected := []Threshold{{Expression: "p(95)<500", OK: true}, {Expression: "p(99)<5", OK: false}}
+	if !reflect.DeepEqual(expected, duration.Thresholds) {
+		t.Fatalf("expected thresholds %v, got %v", expected, duration.Thresholds)
+	}
+
+	reqs, _ := s.Metric("http_reqs")
+	if count, ok := reqs.Value("count"); !ok || count != 120 {
+		t.Fatalf("expected 120 requests, got %v", count)
+	}
+
+	iteration, _ := s.Metric("iteration_duration")
+	if _, ok := iteration.Value("avg"); ok {
+		t.Fatal("expected no avg for a null value")
+	}
+
+	if failed := s.FailedThresholds(); !reflect.DeepEqual([]string{"http_req_duration: p(99)<5"}, failed) {
+		t.Fatalf("unexpected failed thresholds %v", failed)
+	}
+}
+
+func TestParseSummary_invalid(t *testing.T) {
+	if _, err := parseSummary([]byte(`{"metrics":{"checks":{"thresholds":[]}}}`)); err == nil {
+		t.Fatal("expected an error for invalid thresholds")
+	}
+}
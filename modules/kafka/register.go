@@ -0,0 +1,15 @@
+package kafka
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "kafka",
+		DefaultImage: "confluentinc/confluent-local:7.5.0",
+		ExposedPorts: []string{string(publicPort)},
+		Options: map[string]any{
+			"WithClusterID": WithClusterID,
+		},
+		Run: modules.Runner(Run),
+	})
+}
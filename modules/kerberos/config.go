@@ -0,0 +1,111 @@
+package kerberos
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	krb5ConfPath   = "/etc/krb5.conf"
+	kdcConfPath    = "/etc/krb5kdc/kdc.conf"
+	entrypointPath = "/usr/local/bin/kdc-entrypoint.sh"
+	databaseDir    = "/var/lib/krb5kdc"
+	keytabsDir     = "/var/lib/krb5kdc/keytabs"
+	masterPassword = "master"
+)
+
+// safeValue matches the realms, the principal names and the passwords which can be passed to kadmin.local
+// in the queries of the entrypoint script without quoting.
+var safeValue = regexp.MustCompile(`^[A-Za-z0-9._/+=:,-]+$`)
+
+// krb5Conf returns the krb5.conf client configuration of the realm, with the given address of the KDC,
+// e.g. localhost:32768. The clients use TCP, as the UDP port of the KDC is mapped to another port.
+func krb5Conf(realm string, kdcAddress string) []byte {
+	return []byte(fmt.Sprintf(`[libdefaults]
+    default_realm = %[1]s
+    dns_lookup_realm = false
+    dns_lookup_kdc = false
+    rdns = false
+    udp_preference_limit = 1
+    ticket_lifetime = 24h
+    forwardable = true
+
+[realms]
+    %[1]s = {
+        kdc = %[2]s
+        admin_server = %[2]s
+    }
+
+[domain_realm]
+    .%[3]s = %[1]s
+    %[3]s = %[1]s
+`, realm, kdcAddress, strings.ToLower(realm)))
+}
+
+// kdcConf returns the configuration of the KDC of the realm, logging to the standard error.
+func kdcConf(realm string) []byte {
+	return []byte(fmt.Sprintf(`[kdcdefaults]
+    kdc_ports = 88
+    kdc_tcp_ports = 88
+
+[realms]
+    %[1]s = {
+        database_name = %[2]s/principal
+        key_stash_file = %[2]s/.k5.%[1]s
+        acl_file = %[2]s/kadm5.acl
+    }
+
+[logging]
+    kdc = STDERR
+`, realm, databaseDir))
+}
+
+// entrypoint returns the script creating the realm and its principals, exporting their keytabs,
+// then running the KDC. The krb5 packages are installed if the image doesn't provide them.
+// The realm and the principals must be validated.
+func entrypoint(realm string, principals []Principal) []byte {
+	var sb strings.Builder
+
+	sb.WriteString("#!/bin/sh\nset -e\n")
+	sb.WriteString("command -v krb5kdc >/dev/null || apk add --no-cache krb5-server krb5\n")
+	fmt.Fprintf(&sb, "mkdir -p %s\n", keytabsDir)
+	fmt.Fprintf(&sb, "kdb5_util create -s -r %s -P %s\n", realm, masterPassword)
+
+	for _, p := range principals {
+		name := p.Name + "@" + realm
+		if p.Password != "" {
+			fmt.Fprintf(&sb, "kadmin.local -q \"addprinc -pw %s %s\"\n", p.Password, name)
+		} else {
+			fmt.Fprintf(&sb, "kadmin.local -q \"addprinc -randkey %s\"\n", name)
+		}
+		fmt.Fprintf(&sb, "kadmin.local -q \"ktadd -norandkey -k %s %s\"\n", keytabPath(p.Name), name)
+	}
+
+	sb.WriteString("exec krb5kdc -n\n")
+
+	return []byte(sb.String())
+}
+
+// keytabPath returns the path of the keytab of the principal in the container.
+func keytabPath(principal string) string {
+	return keytabsDir + "/" + strings.ReplaceAll(principal, "/", "_") + ".keytab"
+}
+
+// validate returns an error if the realm, or the name or the password of a principal, can't be passed to kadmin.local.
+func (o options) validate() error {
+	if !safeValue.MatchString(o.realm) {
+		return fmt.Errorf("invalid realm %q", o.realm)
+	}
+
+	for _, p := range o.principals {
+		if !safeValue.MatchString(p.Name) {
+			return fmt.Errorf("invalid principal name %q", p.Name)
+		}
+		if p.Password != "" && !safeValue.MatchString(p.Password) {
+			return fmt.Errorf("invalid password of principal %s: only letters, digits and ._/+=:,- are allowed", p.Name)
+		}
+	}
+
+	return nil
+}
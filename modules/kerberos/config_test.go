@@ -0,0 +1,47 @@
+package kerberos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKrb5Conf(t *testing.T) {
+	conf := string(krb5Conf("EXAMPLE.COM", "localhost:32768"))
+
+	require.Contains(t, conf, "default_realm = EXAMPLE.COM")
+	require.Contains(t, conf, "udp_preference_limit = 1")
+	require.Contains(t, conf, "kdc = localhost:32768")
+	require.Contains(t, conf, ".example.com = EXAMPLE.COM")
+}
+
+func TestEntrypoint(t *testing.T) {
+	script := string(entrypoint("EXAMPLE.COM", []Principal{
+		{Name: "alice", Password: "secret"},
+		{Name: "HTTP/app.example.com"},
+	}))
+
+	require.Contains(t, script, `kadmin.local -q "addprinc -pw secret alice@EXAMPLE.COM"`)
+	require.Contains(t, script, `kadmin.local -q "ktadd -norandkey -k /var/lib/krb5kdc/keytabs/alice.keytab alice@EXAMPLE.COM"`)
+	require.Contains(t, script, `kadmin.local -q "addprinc -randkey HTTP/app.example.com@EXAMPLE.COM"`)
+	require.Contains(t, script, `kadmin.local -q "ktadd -norandkey -k /var/lib/krb5kdc/keytabs/HTTP_app.example.com.keytab HTTP/app.example.com@EXAMPLE.COM"`)
+	require.Contains(t, script, "exec krb5kdc -n\n")
+}
+
+func TestValidate(t *testing.T) {
+	o := defaultOptions()
+	WithPrincipals(Principal{Name: "kafka/broker.example.com", Password: "s3cr3t-pass"})(&o)
+	require.NoError(t, o.validate())
+
+	o = defaultOptions()
+	WithPrincipals(Principal{Name: "alice", Password: `pa"ss`})(&o)
+	require.Error(t, o.validate())
+
+	o = defaultOptions()
+	WithPrincipals(Principal{Name: "alice bob"})(&o)
+	require.Error(t, o.validate())
+
+	o = defaultOptions()
+	WithRealm("example com")(&o)
+	require.Error(t, o.validate())
+}
@@ -0,0 +1,40 @@
+package kerberos_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/kerberos"
+)
+
+func ExampleRun() {
+	// runKerberosContainer {
+	ctx := context.Background()
+
+	kerberosContainer, err := kerberos.Run(ctx, "alpine:3.20",
+		kerberos.WithRealm("EXAMPLE.COM"),
+		kerberos.WithPrincipals(kerberos.Principal{Name: "kafka/broker.example.com"}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := kerberosContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := kerberosContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running, kerberosContainer.Realm())
+
+	// Output:
+	// true EXAMPLE.COM
+}
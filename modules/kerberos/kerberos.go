@@ -0,0 +1,144 @@
+package kerberos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// KDCPort is the TCP port of the KDC.
+	KDCPort = "88/tcp"
+	// KDCUDPPort is the UDP port of the KDC.
+	KDCUDPPort = "88/udp"
+)
+
+// KerberosContainer represents the Kerberos KDC container type used in the module, running the MIT krb5 KDC
+// of a realm, with its principals and their keytabs.
+type KerberosContainer struct {
+	testcontainers.Container
+	realm string
+}
+
+// Realm returns the name of the realm, e.g. EXAMPLE.COM.
+func (c *KerberosContainer) Realm() string {
+	return c.realm
+}
+
+// KDCAddress returns the host and the TCP port of the KDC from the host, e.g. localhost:32768.
+func (c *KerberosContainer) KDCAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, KDCPort, "")
+}
+
+// Krb5Conf returns the krb5.conf configuration of the clients running on the host, with the address
+// of the KDC from the host, e.g. to be referenced by the KRB5_CONFIG environment variable.
+func (c *KerberosContainer) Krb5Conf(ctx context.Context) ([]byte, error) {
+	address, err := c.KDCAddress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("kdc address: %w", err)
+	}
+
+	return krb5Conf(c.realm, address), nil
+}
+
+// ContainerKrb5Conf returns the krb5.conf configuration of the clients running in other containers,
+// with the IP address of the KDC container, so the containers must share a network.
+func (c *KerberosContainer) ContainerKrb5Conf(ctx context.Context) ([]byte, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container ip: %w", err)
+	}
+
+	return krb5Conf(c.realm, net.JoinHostPort(ip, strings.TrimSuffix(KDCPort, "/tcp"))), nil
+}
+
+// Keytab returns the keytab of the principal, created with the WithPrincipals option,
+// e.g. to be copied into the container of a service authenticating its clients with SPNEGO.
+func (c *KerberosContainer) Keytab(ctx context.Context, principal string) ([]byte, error) {
+	r, err := c.CopyFileFromContainer(ctx, keytabPath(principal))
+	if err != nil {
+		return nil, fmt.Errorf("copy keytab of %s: %w", principal, err)
+	}
+	defer r.Close()
+
+	keytab, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read keytab of %s: %w", principal, err)
+	}
+
+	return keytab, nil
+}
+
+// Run creates an instance of the Kerberos container type, creating the realm and the principals set with the options.
+// The image must be based on Alpine: the krb5 packages are installed when the container starts if it doesn't provide them.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*KerberosContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{KDCPort, KDCUDPPort},
+		Env: map[string]string{
+			"KRB5_CONFIG":      krb5ConfPath,
+			"KRB5_KDC_PROFILE": kdcConfPath,
+		},
+		Entrypoint: []string{"/bin/sh", entrypointPath},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("commencing operation"),
+			wait.ForListeningPort(KDCPort),
+		).WithStartupTimeoutDefault(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files,
+		testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(entrypoint(settings.realm, settings.principals)),
+			ContainerFilePath: entrypointPath,
+			FileMode:          0o755,
+		},
+		testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(kdcConf(settings.realm)),
+			ContainerFilePath: kdcConfPath,
+			FileMode:          0o644,
+		},
+		testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(krb5Conf(settings.realm, "localhost:88")),
+			ContainerFilePath: krb5ConfPath,
+			FileMode:          0o644,
+		},
+	)
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *KerberosContainer
+	if container != nil {
+		c = &KerberosContainer{Container: container, realm: settings.realm}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
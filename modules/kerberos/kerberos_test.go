@@ -0,0 +1,76 @@
+package kerberos_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/kerberos"
+)
+
+const testImage = "alpine:3.20"
+
+func TestKerberos(t *testing.T) {
+	ctx := context.Background()
+
+	// withPrincipals {
+	ctr, err := kerberos.Run(ctx, testImage,
+		kerberos.WithRealm("EXAMPLE.COM"),
+		kerberos.WithPrincipals(
+			kerberos.Principal{Name: "alice", Password: "secret"},
+			kerberos.Principal{Name: "HTTP/app.example.com"},
+		),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "EXAMPLE.COM", ctr.Realm())
+
+	t.Run("keytab", func(t *testing.T) {
+		// keytab {
+		keytab, err := ctr.Keytab(ctx, "HTTP/app.example.com")
+		// }
+		require.NoError(t, err)
+		require.NotEmpty(t, keytab)
+
+		// authenticate with the keytab, with the clients of the container
+		require.NoError(t, ctr.CopyToContainer(ctx, keytab, "/tmp/http.keytab", 0o600))
+
+		code, r, err := ctr.Exec(ctx, []string{"kinit", "-kt", "/tmp/http.keytab", "HTTP/app.example.com@EXAMPLE.COM"})
+		require.NoError(t, err)
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Zero(t, code, string(out))
+	})
+
+	t.Run("ticket", func(t *testing.T) {
+		code, r, err := ctr.Exec(ctx, []string{"sh", "-c", "kinit -kt /var/lib/krb5kdc/keytabs/alice.keytab alice && klist"})
+		require.NoError(t, err)
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Zero(t, code, string(out))
+		require.Contains(t, string(out), "krbtgt/EXAMPLE.COM@EXAMPLE.COM")
+	})
+
+	t.Run("krb5-conf", func(t *testing.T) {
+		// krb5Conf {
+		conf, err := ctr.Krb5Conf(ctx)
+		// }
+		require.NoError(t, err)
+
+		address, err := ctr.KDCAddress(ctx)
+		require.NoError(t, err)
+		require.True(t, bytes.Contains(conf, []byte("kdc = "+address)))
+
+		ip, err := ctr.ContainerIP(ctx)
+		require.NoError(t, err)
+
+		conf, err = ctr.ContainerKrb5Conf(ctx)
+		require.NoError(t, err)
+		require.True(t, bytes.Contains(conf, []byte("kdc = "+ip+":88")))
+	})
+}
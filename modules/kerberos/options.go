@@ -0,0 +1,56 @@
+package kerberos
+
+import (
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const defaultRealm = "EXAMPLE.COM"
+
+// Principal is a principal of the realm, e.g. a user or a service.
+type Principal struct {
+	// Name is the name of the principal, without the realm, e.g. alice or HTTP/app.example.com.
+	Name string
+	// Password is the password of the principal. Without password, the principal has a random key,
+	// and authenticates with its keytab.
+	Password string
+}
+
+type options struct {
+	realm      string
+	principals []Principal
+}
+
+func defaultOptions() options {
+	return options{
+		realm: defaultRealm,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Kerberos container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithRealm sets the name of the realm, in upper case by convention. The default is EXAMPLE.COM.
+func WithRealm(realm string) Option {
+	return func(o *options) {
+		o.realm = strings.ToUpper(realm)
+	}
+}
+
+// WithPrincipals creates the given principals in the realm, and exports their keys into keytabs,
+// see KerberosContainer.Keytab.
+func WithPrincipals(principals ...Principal) Option {
+	return func(o *options) {
+		o.principals = append(o.principals, principals...)
+	}
+}
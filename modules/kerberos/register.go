@@ -0,0 +1,16 @@
+package kerberos
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "kerberos",
+		DefaultImage: "alpine:3.20",
+		ExposedPorts: []string{KDCPort, KDCUDPPort},
+		Options: map[string]any{
+			"WithPrincipals": WithPrincipals,
+			"WithRealm":      WithRealm,
+		},
+		Run: modules.Runner(Run),
+	})
+}
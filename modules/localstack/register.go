@@ -0,0 +1,16 @@
+package localstack
+
+import (
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go/modules"
+)
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "localstack",
+		DefaultImage: "localstack/localstack:1.4.0",
+		ExposedPorts: []string{fmt.Sprintf("%d/tcp", defaultPort)},
+		Run:          modules.Runner(Run),
+	})
+}
@@ -0,0 +1,87 @@
+package loki
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogEntry is a log line stored by Loki, with the labels of its stream.
+type LogEntry struct {
+	Time   time.Time
+	Line   string
+	Labels map[string]string
+}
+
+// The types of the JSON requests and responses of the HTTP API.
+type (
+	apiPush struct {
+		Streams []apiStream `json:"streams"`
+	}
+
+	apiStream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+
+	apiQueryRange struct {
+		Data struct {
+			ResultType string      `json:"resultType"`
+			Result     []apiStream `json:"result"`
+		} `json:"data"`
+	}
+
+	apiLabels struct {
+		Data []string `json:"data"`
+	}
+)
+
+// pushRequest returns the push request of the lines, in a stream of the labels, all at the time.
+// The lines of a stream must have increasing times, so they are one nanosecond apart.
+func pushRequest(labels map[string]string, t time.Time, lines []string) apiPush {
+	stream := apiStream{Stream: labels, Values: make([][2]string, 0, len(lines))}
+	ns := t.UnixNano()
+	for i, line := range lines {
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(ns+int64(i), 10), line})
+	}
+
+	return apiPush{Streams: []apiStream{stream}}
+}
+
+// entries returns the log entries of the streams of the response, sorted by time.
+func (r apiQueryRange) entries() ([]LogEntry, error) {
+	if r.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("unexpected result type %q: the query must select log lines", r.Data.ResultType)
+	}
+
+	var entries []LogEntry
+	for _, s := range r.Data.Result {
+		for _, v := range s.Values {
+			ns, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse time %q: %w", v[0], err)
+			}
+
+			entries = append(entries, LogEntry{Time: time.Unix(0, ns), Line: v[1], Labels: s.Stream})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	return entries, nil
+}
+
+// selector returns the LogQL stream selector matching the labels, e.g. {app="checkout", env="test"}.
+func selector(labels map[string]string) string {
+	matchers := make([]string, 0, len(labels))
+	for k, v := range labels {
+		matchers = append(matchers, k+"="+strconv.Quote(v))
+	}
+	sort.Strings(matchers)
+
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
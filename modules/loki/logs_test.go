@@ -0,0 +1,56 @@
+package loki
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRequest(t *testing.T) {
+	req := pushRequest(map[string]string{"app": "checkout"}, time.Unix(0, 1700000000000000000), []string{"cart loaded", "payment started"})
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"streams":[{"stream":{"app":"checkout"},"values":[
+		["1700000000000000000","cart loaded"],
+		["1700000000000000001","payment started"]
+	]}]}`, string(data))
+}
+
+func TestAPIQueryRange_entries(t *testing.T) {
+	t.Run("streams", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/query_range.json")
+		require.NoError(t, err)
+
+		var resp apiQueryRange
+		require.NoError(t, json.Unmarshal(data, &resp))
+
+		entries, err := resp.entries()
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		require.Equal(t, LogEntry{
+			Time:   time.Unix(0, 1700000000000000000),
+			Line:   "cart loaded",
+			Labels: map[string]string{"app": "checkout", "level": "info"},
+		}, entries[0])
+		require.Equal(t, "payment started", entries[1].Line)
+		require.Equal(t, "payment declined", entries[2].Line)
+		require.Equal(t, "error", entries[2].Labels["level"])
+	})
+
+	t.Run("matrix", func(t *testing.T) {
+		var resp apiQueryRange
+		require.NoError(t, json.Unmarshal([]byte(`{"data":{"resultType":"matrix","result":[]}}`), &resp))
+
+		_, err := resp.entries()
+		require.ErrorContains(t, err, "must select log lines")
+	})
+}
+
+func TestSelector(t *testing.T) {
+	require.Equal(t, `{app="checkout", level="say \"hi\""}`, selector(map[string]string{"level": `say "hi"`, "app": "checkout"}))
+}
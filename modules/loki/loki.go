@@ -0,0 +1,196 @@
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// HTTPPort is the port of the HTTP API of Loki, to push and to query the logs.
+const HTTPPort = "3100/tcp"
+
+// LokiContainer represents the Loki container type used in the module. It runs Loki in its single binary mode,
+// with the configuration of the image, storing the logs on the file system of the container.
+type LokiContainer struct {
+	testcontainers.Container
+}
+
+// URL returns the URL of the HTTP API of Loki, e.g. http://localhost:3100, e.g. to be used
+// as the URL of a Loki data source of Grafana.
+func (c *LokiContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, HTTPPort, "http")
+}
+
+// PushURL returns the URL of the push API of Loki, e.g. http://localhost:3100/loki/api/v1/push,
+// to be used by the Loki clients of the logging libraries, e.g. Promtail.
+func (c *LokiContainer) PushURL(ctx context.Context) (string, error) {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/loki/api/v1/push", nil
+}
+
+// Push stores the lines in the stream of the labels, e.g. {"app": "checkout"}, at the current time.
+func (c *LokiContainer) Push(ctx context.Context, labels map[string]string, lines ...string) error {
+	body, err := json.Marshal(pushRequest(labels, time.Now(), lines))
+	if err != nil {
+		return fmt.Errorf("marshal push request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/loki/api/v1/push", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := c.do(req, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns the log lines of the last hour selected by the LogQL query, e.g. {app="checkout"} |= "error",
+// sorted by time.
+func (c *LokiContainer) Query(ctx context.Context, logQL string) ([]LogEntry, error) {
+	end := time.Now()
+	values := url.Values{
+		"query":     {logQL},
+		"start":     {strconv.FormatInt(end.Add(-time.Hour).UnixNano(), 10)},
+		"end":       {strconv.FormatInt(end.Add(time.Second).UnixNano(), 10)},
+		"direction": {"forward"},
+		"limit":     {"5000"},
+	}
+
+	var resp apiQueryRange
+	if err := c.get(ctx, "/loki/api/v1/query_range?"+values.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("query %s: %w", logQL, err)
+	}
+
+	return resp.entries()
+}
+
+// QueryByLabels returns the log lines of the last hour in the streams matching all the labels, sorted by time.
+func (c *LokiContainer) QueryByLabels(ctx context.Context, labels map[string]string) ([]LogEntry, error) {
+	return c.Query(ctx, selector(labels))
+}
+
+// Labels returns the names of the labels of the logs of the last hour.
+func (c *LokiContainer) Labels(ctx context.Context) ([]string, error) {
+	var resp apiLabels
+	if err := c.get(ctx, "/loki/api/v1/labels", &resp); err != nil {
+		return nil, fmt.Errorf("labels: %w", err)
+	}
+
+	return resp.Data, nil
+}
+
+// LabelValues returns the values of the label in the logs of the last hour.
+func (c *LokiContainer) LabelValues(ctx context.Context, label string) ([]string, error) {
+	var resp apiLabels
+	if err := c.get(ctx, "/loki/api/v1/label/"+url.PathEscape(label)+"/values", &resp); err != nil {
+		return nil, fmt.Errorf("values of label %s: %w", label, err)
+	}
+
+	return resp.Data, nil
+}
+
+// WaitForLogs waits until the LogQL query selects at least n log lines, returning them,
+// or until the context is done.
+func (c *LokiContainer) WaitForLogs(ctx context.Context, logQL string, n int) ([]LogEntry, error) {
+	for {
+		entries, err := c.Query(ctx, logQL)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(entries) >= n {
+			return entries, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return entries, fmt.Errorf("wait for %d log lines of %s, got %d: %w", n, logQL, len(entries), ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// get sends a GET request to the HTTP API, decoding the JSON response into out.
+func (c *LokiContainer) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	return c.do(req, http.StatusOK, out)
+}
+
+// do sends the request to the HTTP API, expecting the status code, and decodes the JSON response into out, if not nil.
+func (c *LokiContainer) do(req *http.Request, status int, out any) error {
+	resp, err := testcontainers.HTTPClient(c, HTTPPort).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != status {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// Run creates an instance of the Loki container type, with the configuration of the image.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*LokiContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{HTTPPort},
+		// the ingester is ready 15 seconds after it started
+		WaitingFor: wait.ForHTTP("/ready").WithPort(HTTPPort).WithStartupTimeout(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *LokiContainer
+	if container != nil {
+		c = &LokiContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
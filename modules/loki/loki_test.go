@@ -0,0 +1,66 @@
+package loki_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/loki"
+)
+
+func TestLoki(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := loki.Run(ctx, "grafana/loki:3.1.0")
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	// pushLogs {
+	err = ctr.Push(ctx, map[string]string{"app": "checkout", "level": "info"}, "cart loaded", "payment started")
+	require.NoError(t, err)
+	err = ctr.Push(ctx, map[string]string{"app": "checkout", "level": "error"}, "payment declined")
+	require.NoError(t, err)
+	// }
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// waitForLogs {
+	entries, err := ctr.WaitForLogs(waitCtx, `{app="checkout"}`, 3)
+	require.NoError(t, err)
+	// }
+	require.Len(t, entries, 3)
+	require.Equal(t, "cart loaded", entries[0].Line)
+
+	t.Run("filter", func(t *testing.T) {
+		entries, err := ctr.Query(ctx, `{app="checkout"} |= "payment"`)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		entries, err := ctr.QueryByLabels(ctx, map[string]string{"app": "checkout", "level": "error"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, "payment declined", entries[0].Line)
+
+		labels, err := ctr.Labels(ctx)
+		require.NoError(t, err)
+		require.Subset(t, labels, []string{"app", "level"})
+
+		values, err := ctr.LabelValues(ctx, "level")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"error", "info"}, values)
+	})
+
+	t.Run("metric-query", func(t *testing.T) {
+		_, err := ctr.Query(ctx, `count_over_time({app="checkout"}[1m])`)
+		require.ErrorContains(t, err, "must select log lines")
+	})
+}
@@ -0,0 +1,12 @@
+package loki
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "loki",
+		DefaultImage: "grafana/loki:3.1.0",
+		ExposedPorts: []string{HTTPPort},
+		Run:          modules.Runner(Run),
+	})
+}
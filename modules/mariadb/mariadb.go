@@ -81,6 +81,21 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithGeneratedCredentials sets a random username and password, see [testcontainers.GenerateCredentials].
+func WithGeneratedCredentials() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		creds, err := testcontainers.GenerateCredentials()
+		if err != nil {
+			return fmt.Errorf("generate credentials: %w", err)
+		}
+
+		req.Env["MARIADB_USER"] = creds.Username
+		req.Env["MARIADB_PASSWORD"] = creds.Password
+
+		return testcontainers.WithSensitiveValues(creds.Password)(req)
+	}
+}
+
 func WithDatabase(database string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) error {
 		req.Env["MARIADB_DATABASE"] = database
@@ -209,3 +224,25 @@ func (c *MariaDBContainer) ConnectionString(ctx context.Context, args ...string)
 	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s%s", c.username, c.password, host, containerPort.Port(), c.database, extraArgs)
 	return connectionString, nil
 }
+
+// ConnectionDetails returns the details to connect to the database of the container, with the DSN
+// in the format of the go-sql-driver/mysql driver, implementing testcontainers.ConnectionSource.
+func (c *MariaDBContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "mysql", "3306/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.username
+	details.Password = c.password
+	details.Database = c.database
+	details.NativeDSN = fmt.Sprintf("%s:%s@tcp(%s)/%s", c.username, c.password, details.Address(), c.database)
+
+	return details, nil
+}
+
+// Credentials returns the username and the password of the user of the container,
+// e.g. the ones generated by WithGeneratedCredentials.
+func (c *MariaDBContainer) Credentials() testcontainers.Credentials {
+	return testcontainers.Credentials{Username: c.username, Password: c.password}
+}
@@ -0,0 +1,21 @@
+package mariadb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mariadb",
+		DefaultImage: "mariadb:11.0.3",
+		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":           WithConfigFile,
+			"WithDatabase":             WithDatabase,
+			"WithDefaultCredentials":   WithDefaultCredentials,
+			"WithGeneratedCredentials": WithGeneratedCredentials,
+			"WithPassword":             WithPassword,
+			"WithScripts":              WithScripts,
+			"WithUsername":             WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,39 @@
+package meilisearch_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/meilisearch"
+)
+
+func ExampleRun() {
+	// runMeilisearchContainer {
+	ctx := context.Background()
+
+	meilisearchContainer, err := meilisearch.Run(ctx, "getmeili/meilisearch:v1.9",
+		meilisearch.WithMasterKey("a-master-key-of-16-bytes"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := meilisearchContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := meilisearchContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
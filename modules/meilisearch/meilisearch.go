@@ -0,0 +1,214 @@
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "7700/tcp"
+)
+
+// MeilisearchContainer represents the Meilisearch container type used in the module
+type MeilisearchContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// MasterKey returns the master key of the server set with WithMasterKey.
+func (c *MeilisearchContainer) MasterKey() string {
+	return c.settings.masterKey
+}
+
+// Address returns the URL of the API of the server, e.g. http://localhost:7700,
+// to be used as the host of the Meilisearch clients, with the master key.
+func (c *MeilisearchContainer) Address(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// Run creates an instance of the Meilisearch container type, in the development environment.
+// Once the container is ready, it creates the indexes set with the options.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*MeilisearchContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"MEILI_ENV":          "development",
+			"MEILI_NO_ANALYTICS": "true",
+		},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor: wait.ForHTTP("/health").
+			WithPort(defaultHTTPPort).
+			WithResponseMatcher(isAvailable),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	var settings options
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.masterKey != "" {
+		genericContainerReq.Env["MEILI_MASTER_KEY"] = settings.masterKey
+	}
+
+	c := &MeilisearchContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+	c.Container = container
+
+	return c, nil
+}
+
+// isAvailable returns true if the health endpoint reports that the server is available.
+func isAvailable(body io.Reader) bool {
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(body).Decode(&health); err != nil {
+		return false
+	}
+
+	return health.Status == "available"
+}
+
+// bootstrap creates the indexes, updates their settings and adds their documents.
+// Each of these operations is an asynchronous task, which is awaited before the next one.
+func (c *MeilisearchContainer) bootstrap(ctx context.Context) error {
+	for _, idx := range c.settings.indexes {
+		index := map[string]string{"uid": idx.UID}
+		if idx.PrimaryKey != "" {
+			index["primaryKey"] = idx.PrimaryKey
+		}
+
+		if err := c.runTask(ctx, http.MethodPost, "/indexes", index); err != nil {
+			return fmt.Errorf("create index %s: %w", idx.UID, err)
+		}
+
+		path := "/indexes/" + url.PathEscape(idx.UID)
+		if idx.Settings != nil {
+			if err := c.runTask(ctx, http.MethodPatch, path+"/settings", idx.Settings); err != nil {
+				return fmt.Errorf("update settings of index %s: %w", idx.UID, err)
+			}
+		}
+
+		if len(idx.Documents) > 0 {
+			if err := c.runTask(ctx, http.MethodPost, path+"/documents", idx.Documents); err != nil {
+				return fmt.Errorf("add documents to index %s: %w", idx.UID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runTask sends the JSON body to the API of the server, and polls the enqueued task
+// until it succeeds or fails.
+func (c *MeilisearchContainer) runTask(ctx context.Context, method string, path string, body any) error {
+	var task struct {
+		TaskUID int64 `json:"taskUid"`
+	}
+	if err := c.apiRequest(ctx, method, path, body, &task); err != nil {
+		return err
+	}
+
+	for {
+		var status struct {
+			Status string `json:"status"`
+			Error  *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := c.apiRequest(ctx, http.MethodGet, "/tasks/"+strconv.FormatInt(task.TaskUID, 10), nil, &status); err != nil {
+			return err
+		}
+
+		switch status.Status {
+		case "succeeded":
+			return nil
+		case "failed", "canceled":
+			if status.Error != nil {
+				return errors.New(status.Error.Message)
+			}
+			return fmt.Errorf("task %d %s", task.TaskUID, status.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// apiRequest sends the JSON body, if any, to the API of the server with the master key, if any,
+// and decodes the JSON response into out.
+func (c *MeilisearchContainer) apiRequest(ctx context.Context, method string, path string, body any, out any) error {
+	address, err := c.Address(ctx)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, address+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.settings.masterKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.settings.masterKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
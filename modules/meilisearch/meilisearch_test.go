@@ -0,0 +1,85 @@
+package meilisearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/meilisearch"
+)
+
+const testImage = "getmeili/meilisearch:v1.9"
+
+type movie struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Year  int    `json:"year"`
+}
+
+func TestMeilisearch(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := meilisearch.Run(ctx, testImage,
+		// withIndexes {
+		meilisearch.WithMasterKey("a-master-key-of-16-bytes"),
+		meilisearch.WithIndexes(meilisearch.Index{
+			UID:        "movies",
+			PrimaryKey: "id",
+			Settings: &meilisearch.Settings{
+				FilterableAttributes: []string{"year"},
+			},
+			Documents: []any{
+				movie{ID: 1, Title: "Alien", Year: 1979},
+				movie{ID: 2, Title: "Aliens", Year: 1986},
+			},
+		}),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "a-master-key-of-16-bytes", ctr.MasterKey())
+
+	// address {
+	address, err := ctr.Address(ctx)
+	// }
+	require.NoError(t, err)
+
+	body := strings.NewReader(`{"q": "alien", "filter": "year > 1980"}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/indexes/movies/search", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+ctr.MasterKey())
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Hits []movie `json:"hits"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, []movie{{ID: 2, Title: "Aliens", Year: 1986}}, result.Hits)
+}
+
+func TestMeilisearchWithoutMasterKey(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := meilisearch.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	address, err := ctr.Address(ctx)
+	require.NoError(t, err)
+
+	// the API is not protected
+	resp, err := http.Get(address + "/indexes")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
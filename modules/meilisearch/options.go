@@ -0,0 +1,56 @@
+package meilisearch
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Settings are the settings of an index. The fields follow the settings of the Meilisearch API.
+type Settings struct {
+	SearchableAttributes []string `json:"searchableAttributes,omitempty"`
+	FilterableAttributes []string `json:"filterableAttributes,omitempty"`
+	SortableAttributes   []string `json:"sortableAttributes,omitempty"`
+	DisplayedAttributes  []string `json:"displayedAttributes,omitempty"`
+}
+
+// Index is an index created once the container is ready.
+type Index struct {
+	UID        string
+	PrimaryKey string
+	// Settings are updated once the index is created, if not nil.
+	Settings *Settings
+	// Documents are added to the index once its settings are updated.
+	Documents []any
+}
+
+type options struct {
+	masterKey string
+	indexes   []Index
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Meilisearch container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithMasterKey sets the master key of the server, protecting its API. If it is not specified,
+// then the API is not protected.
+func WithMasterKey(masterKey string) Option {
+	return func(o *options) {
+		o.masterKey = masterKey
+	}
+}
+
+// WithIndexes creates the given indexes, in order, once the container is ready,
+// updating their settings and adding their documents.
+func WithIndexes(indexes ...Index) Option {
+	return func(o *options) {
+		o.indexes = append(o.indexes, indexes...)
+	}
+}
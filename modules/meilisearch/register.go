@@ -0,0 +1,16 @@
+package meilisearch
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "meilisearch",
+		DefaultImage: "getmeili/meilisearch:v1.9",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithIndexes":   WithIndexes,
+			"WithMasterKey": WithMasterKey,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -25,6 +27,7 @@ const (
 // MilvusContainer represents the Milvus container type used in the module
 type MilvusContainer struct {
 	testcontainers.Container
+	settings options
 }
 
 // ConnectionString returns the connection string for the milvus container, using the default 19530 port, and
@@ -41,13 +44,25 @@ func (c *MilvusContainer) ConnectionString(ctx context.Context) (string, error)
 	return fmt.Sprintf("%s:%s", host, port.Port()), nil
 }
 
+// HTTPEndpoint returns the URL of the RESTful API of the milvus container, e.g. http://localhost:19530,
+// which is served on the same port as the gRPC API.
+func (c *MilvusContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	connStr, err := c.ConnectionString(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + connStr, nil
+}
+
 // Deprecated: use Run instead
 // RunContainer creates an instance of the Milvus container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MilvusContainer, error) {
 	return Run(ctx, "milvusdb/milvus:v2.3.9", opts...)
 }
 
-// Run creates an instance of the Milvus container type
+// Run creates an instance of the Milvus container type. Once the container is ready,
+// it creates the collections set with the options.
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*MilvusContainer, error) {
 	config, err := renderEmbedEtcdConfig(defaultClientPort)
 	if err != nil {
@@ -78,18 +93,93 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	c := &MilvusContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.createCollections(ctx)
+			},
+		},
+	})
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
+	c.Container = container
+
+	return c, nil
+}
+
+// createCollections creates the collections using the quick setup of the RESTful API,
+// which also indexes and loads them.
+func (c *MilvusContainer) createCollections(ctx context.Context) error {
+	if len(c.settings.collections) == 0 {
+		return nil
+	}
+
+	endpoint, err := c.HTTPEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range c.settings.collections {
+		metricType := col.MetricType
+		if metricType == "" {
+			metricType = "L2"
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"collectionName": col.Name,
+			"dimension":      col.Dimension,
+			"metricType":     metricType,
+			"primaryField":   "id",
+			"vectorField":    "vector",
+		})
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v1/vector/collections/create", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("create collection %s: %w", col.Name, err)
+		}
+
+		// the API answers with a 200 status code, and the result in the code of the body
+		var result struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("create collection %s: %w", col.Name, err)
+		}
+
+		if result.Code != http.StatusOK {
+			return fmt.Errorf("create collection %s: code %d: %s", col.Name, result.Code, result.Message)
+		}
+	}
 
-	return &MilvusContainer{Container: container}, nil
+	return nil
 }
 
 type embedEtcdConfigTplParams struct {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go/modules/milvus"
@@ -39,3 +40,49 @@ func TestMilvus(t *testing.T) {
 		tt.Logf("Milvus version: %s", v)
 	})
 }
+
+func TestMilvusWithCollections(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := milvus.Run(ctx, "milvusdb/milvus:v2.3.9",
+		// withCollections {
+		milvus.WithCollections(
+			milvus.Collection{Name: "documents", Dimension: 384},
+			milvus.Collection{Name: "images", Dimension: 512, MetricType: "IP"},
+		),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	connectionStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	milvusClient, err := client.NewGrpcClient(ctx, connectionStr)
+	require.NoError(t, err)
+	defer milvusClient.Close()
+
+	for name, dim := range map[string]string{"documents": "384", "images": "512"} {
+		collection, err := milvusClient.DescribeCollection(ctx, name)
+		require.NoError(t, err)
+
+		loadState, err := milvusClient.GetLoadState(ctx, name, nil)
+		require.NoError(t, err)
+		require.Equal(t, entity.LoadStateLoaded, loadState)
+
+		var vector *entity.Field
+		for _, f := range collection.Schema.Fields {
+			if f.Name == "vector" {
+				vector = f
+			}
+		}
+		require.NotNil(t, vector)
+		require.Equal(t, dim, vector.TypeParams[entity.TypeParamDim])
+	}
+
+	// httpEndpoint {
+	endpoint, err := container.HTTPEndpoint(ctx)
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "http://"+connectionStr, endpoint)
+}
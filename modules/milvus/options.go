@@ -0,0 +1,41 @@
+package milvus
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Collection is a collection created once the container is ready, with an int64 primary key
+// field named id, and a float vector field named vector.
+type Collection struct {
+	// Name is the name of the collection.
+	Name string
+	// Dimension is the number of dimensions of the vectors.
+	Dimension int
+	// MetricType is the metric used to compare the vectors: L2, IP or COSINE.
+	// If it is not specified, then L2 will be used.
+	MetricType string
+}
+
+type options struct {
+	collections []Collection
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Milvus container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithCollections creates the given collections in the default database once the container is ready.
+// The collections are indexed and loaded, ready to be searched.
+func WithCollections(collections ...Collection) Option {
+	return func(o *options) {
+		o.collections = append(o.collections, collections...)
+	}
+}
@@ -0,0 +1,15 @@
+package milvus
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "milvus",
+		DefaultImage: "milvusdb/milvus:v2.3.9",
+		ExposedPorts: []string{"19530/tcp", "9091/tcp", "2379/tcp"},
+		Options: map[string]any{
+			"WithCollections": WithCollections,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,16 @@
+package minio
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "minio",
+		DefaultImage: "docker.io/minio/minio:RELEASE.2024-01-16T16-07-38Z",
+		ExposedPorts: []string{"9000/tcp"},
+		Options: map[string]any{
+			"WithPassword": WithPassword,
+			"WithUsername": WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,12 @@
+package mockserver
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mockserver",
+		DefaultImage: "mockserver/mockserver:5.15.0",
+		ExposedPorts: []string{"1080/tcp"},
+		Run:          modules.Runner(Run),
+	})
+}
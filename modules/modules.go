@@ -0,0 +1,132 @@
+// Package modules is the catalog of the modules of Testcontainers for Go, where each module registers its metadata
+// when its package is imported, so the tools can discover the modules and run them by name, e.g. the test fixtures
+// defined in YAML files:
+//
+//	import (
+//		"github.com/testcontainers/testcontainers-go/modules"
+//		_ "github.com/testcontainers/testcontainers-go/modules/postgres"
+//	)
+//
+//	ctr, err := modules.Run(ctx, "postgres")
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ErrModuleNotFound is returned when no module is registered with the given name,
+// usually because its package is not imported.
+var ErrModuleNotFound = errors.New("module not found")
+
+var (
+	mtx     sync.RWMutex
+	catalog = make(map[string]Module)
+)
+
+// RunFunc creates and starts the container of a module, with the given image and options.
+type RunFunc func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error)
+
+// Module is the metadata of a module, registered in the catalog.
+type Module struct {
+	// Name is the name of the module, e.g. postgres, which is the name of its package directory.
+	Name string
+	// DefaultImage is the Docker image the module is documented and tested with.
+	DefaultImage string
+	// ExposedPorts are the ports exposed by the container of the module, e.g. 5432/tcp.
+	ExposedPorts []string
+	// Options are the functions returning the options of the module, by name, e.g. WithDatabase,
+	// so the tools can call them with reflection.
+	Options map[string]any
+	// Run creates and starts the container of the module.
+	Run RunFunc
+}
+
+// OptionNames returns the sorted names of the options of the module.
+func (m Module) OptionNames() []string {
+	names := make([]string, 0, len(m.Options))
+	for name := range m.Options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Runner adapts the Run function of a module, returning its container type, to a RunFunc.
+func Runner[T testcontainers.Container](run func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (T, error)) RunFunc {
+	return func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		ctr, err := run(ctx, img, opts...)
+		if err != nil {
+			// don't return a typed nil pointer as a non-nil container
+			return nil, err
+		}
+
+		return ctr, nil
+	}
+}
+
+// Register adds the module to the catalog. It's called by the init function of the module packages,
+// and panics if the module has no name or no Run function, or if a module with the same name is already registered.
+func Register(m Module) {
+	if m.Name == "" {
+		panic("modules: Register module without a name")
+	}
+
+	if m.Run == nil {
+		panic("modules: Register module " + m.Name + " without a Run function")
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := catalog[m.Name]; ok {
+		panic("modules: Register called twice for module " + m.Name)
+	}
+
+	catalog[m.Name] = m
+}
+
+// List returns the registered modules, sorted by name.
+func List() []Module {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	list := make([]Module, 0, len(catalog))
+	for _, m := range catalog {
+		list = append(list, m)
+	}
+
+	slices.SortFunc(list, func(a, b Module) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return list
+}
+
+// Lookup returns the module registered with the given name, if any.
+func Lookup(name string) (Module, bool) {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	m, ok := catalog[name]
+	return m, ok
+}
+
+// Run creates and starts the container of the module registered with the given name, using its default image.
+// Use testcontainers.WithImage to override it. It returns an error wrapping ErrModuleNotFound if the module
+// is not registered.
+func Run(ctx context.Context, name string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	m, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, import its package to register it", ErrModuleNotFound, name)
+	}
+
+	return m.Run(ctx, m.DefaultImage, opts...)
+}
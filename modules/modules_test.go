@@ -0,0 +1,100 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// fakeContainer is the container type of the fake modules.
+type fakeContainer struct {
+	testcontainers.Container
+	image string
+}
+
+var errRun = errors.New("run failed")
+
+func runFake(_ context.Context, img string, _ ...testcontainers.ContainerCustomizer) (*fakeContainer, error) {
+	if img == "" {
+		return nil, errRun
+	}
+	return &fakeContainer{image: img}, nil
+}
+
+func withFake(string) testcontainers.CustomizeRequestOption {
+	return func(*testcontainers.GenericContainerRequest) error {
+		return nil
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(Module{
+		Name:         "test-b",
+		DefaultImage: "b:1",
+		ExposedPorts: []string{"8080/tcp"},
+		Options:      map[string]any{"WithZ": withFake, "WithA": withFake},
+		Run:          Runner(runFake),
+	})
+	Register(Module{
+		Name:         "test-a",
+		DefaultImage: "a:1",
+		Run:          Runner(runFake),
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		m, ok := Lookup("test-b")
+		require.True(t, ok)
+		require.Equal(t, "b:1", m.DefaultImage)
+		require.Equal(t, []string{"8080/tcp"}, m.ExposedPorts)
+		require.Equal(t, []string{"WithA", "WithZ"}, m.OptionNames())
+
+		_, ok = Lookup("test-unknown")
+		require.False(t, ok)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var names []string
+		for _, m := range List() {
+			names = append(names, m.Name)
+		}
+		require.Subset(t, names, []string{"test-a", "test-b"})
+		require.IsIncreasing(t, names)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		ctr, err := Run(context.Background(), "test-a")
+		require.NoError(t, err)
+		require.Equal(t, "a:1", ctr.(*fakeContainer).image)
+
+		_, err = Run(context.Background(), "test-unknown")
+		require.ErrorIs(t, err, ErrModuleNotFound)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		require.Panics(t, func() {
+			Register(Module{Name: "test-a", Run: Runner(runFake)})
+		})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Panics(t, func() {
+			Register(Module{Run: Runner(runFake)})
+		})
+		require.Panics(t, func() {
+			Register(Module{Name: "test-no-run"})
+		})
+	})
+}
+
+func TestRunner(t *testing.T) {
+	run := Runner(runFake)
+
+	ctr, err := run(context.Background(), "")
+	require.ErrorIs(t, err, errRun)
+	// the nil *fakeContainer is not returned as a non-nil container
+	require.Nil(t, ctr)
+}
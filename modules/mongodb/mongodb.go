@@ -82,6 +82,21 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithGeneratedCredentials sets random root credentials, enabling the authentication, see [testcontainers.GenerateCredentials].
+func WithGeneratedCredentials() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		creds, err := testcontainers.GenerateCredentials()
+		if err != nil {
+			return fmt.Errorf("generate credentials: %w", err)
+		}
+
+		req.Env["MONGO_INITDB_ROOT_USERNAME"] = creds.Username
+		req.Env["MONGO_INITDB_ROOT_PASSWORD"] = creds.Password
+
+		return testcontainers.WithSensitiveValues(creds.Password)(req)
+	}
+}
+
 // WithReplicaSet configures the container to run a single-node MongoDB replica set named "rs".
 // It will wait until the replica set is ready.
 func WithReplicaSet(replSetName string) testcontainers.CustomizeRequestOption {
@@ -122,6 +137,28 @@ func (c *MongoDBContainer) ConnectionString(ctx context.Context) (string, error)
 	return c.Endpoint(ctx, "mongodb")
 }
 
+// ConnectionDetails returns the details to connect to the database of the container,
+// implementing testcontainers.ConnectionSource.
+func (c *MongoDBContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "mongodb", "27017/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	if c.username != "" && c.password != "" {
+		details.Username = c.username
+		details.Password = c.password
+	}
+
+	return details, nil
+}
+
+// Credentials returns the username and the password of the user of the container,
+// e.g. the ones generated by WithGeneratedCredentials.
+func (c *MongoDBContainer) Credentials() testcontainers.Credentials {
+	return testcontainers.Credentials{Username: c.username, Password: c.password}
+}
+
 // eval builds an mongosh|mongo eval command.
 func eval(command string, args ...any) []string {
 	command = "\"" + fmt.Sprintf(command, args...) + "\""
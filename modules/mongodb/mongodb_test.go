@@ -91,3 +91,48 @@ func TestMongoDB(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDBWithSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// withSeed {
+	mongodbContainer, err := mongodb.Run(ctx, "mongo:6",
+		mongodb.WithUsername("root"),
+		mongodb.WithPassword("password"),
+		testcontainers.WithSeed(
+			mongodb.JSONSeeder("shop", "products", []byte(`[{"sku": "A-1", "price": 10}, {"sku": "B-2", "price": 20}]`)),
+		),
+	)
+	// }
+	if err != nil {
+		t.Fatalf("failed to start container: %s", err)
+	}
+
+	defer func() {
+		if err := mongodbContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+
+	endpoint, err := mongodbContainer.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %s", err)
+	}
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(endpoint))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %s", err)
+	}
+	defer func() {
+		_ = mongoClient.Disconnect(ctx)
+	}()
+
+	count, err := mongoClient.Database("shop").Collection("products").CountDocuments(ctx, map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to count documents: %s", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 seeded documents, got %d", count)
+	}
+}
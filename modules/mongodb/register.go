@@ -0,0 +1,18 @@
+package mongodb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mongodb",
+		DefaultImage: "mongo:6",
+		ExposedPorts: []string{"27017/tcp"},
+		Options: map[string]any{
+			"WithGeneratedCredentials": WithGeneratedCredentials,
+			"WithPassword":             WithPassword,
+			"WithReplicaSet":           WithReplicaSet,
+			"WithUsername":             WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// seedFile is the path where the JSON documents are copied in the container before being imported.
+const seedFile = "/tmp/testcontainers-seed.json"
+
+// mongoimportScript runs mongoimport, authenticating as the root user of the container if any.
+const mongoimportScript = `mongoimport --quiet ${MONGO_INITDB_ROOT_USERNAME:+--username "$MONGO_INITDB_ROOT_USERNAME" --password "$MONGO_INITDB_ROOT_PASSWORD" --authenticationDatabase admin} "$@"`
+
+// JSONSeeder returns a seeder importing the documents of the JSON array into the collection of the database,
+// to be used with the testcontainers.WithSeed option. The documents use the Extended JSON format, and are
+// imported with mongoimport, which is available in the official mongo images.
+func JSONSeeder(database string, collection string, documents []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		if err := c.CopyToContainer(ctx, documents, seedFile, 0o644); err != nil {
+			return fmt.Errorf("copy JSON documents: %w", err)
+		}
+
+		cmd := []string{
+			"sh", "-c", mongoimportScript, "mongoimport",
+			"--db", database, "--collection", collection, "--jsonArray", "--file", seedFile,
+		}
+
+		exitCode, reader, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+		if err != nil {
+			return err
+		}
+
+		if exitCode != 0 {
+			out, _ := io.ReadAll(reader)
+			return fmt.Errorf("mongoimport: exit code %d: %s", exitCode, strings.TrimSpace(string(out)))
+		}
+
+		return nil
+	})
+}
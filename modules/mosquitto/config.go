@@ -0,0 +1,86 @@
+package mosquitto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	configDir    = "/mosquitto/config"
+	configFile   = configDir + "/mosquitto.conf"
+	passwordFile = configDir + "/passwd"
+	aclFile      = configDir + "/acl"
+	caFile       = configDir + "/ca.crt"
+	certFile     = configDir + "/server.crt"
+	keyFile      = configDir + "/server.key"
+)
+
+// config returns the configuration of Mosquitto, with a listener on the 1883 port, and on the 8883 port with TLS.
+func (o options) config() []byte {
+	var sb strings.Builder
+
+	sb.WriteString("persistence false\nlog_dest stdout\n\n")
+
+	fmt.Fprintf(&sb, "allow_anonymous %t\n", len(o.users) == 0)
+	if len(o.users) > 0 {
+		fmt.Fprintf(&sb, "password_file %s\n", passwordFile)
+	}
+	if len(o.acl) > 0 {
+		fmt.Fprintf(&sb, "acl_file %s\n", aclFile)
+	}
+
+	sb.WriteString("\nlistener 1883\n")
+
+	if o.tls {
+		sb.WriteString("\nlistener 8883\n")
+		fmt.Fprintf(&sb, "cafile %s\ncertfile %s\nkeyfile %s\n", caFile, certFile, keyFile)
+	}
+
+	return []byte(sb.String())
+}
+
+// passwords returns the password file of the users, with plain text passwords, hashed by mosquitto_passwd
+// when the container starts.
+func (o options) passwords() []byte {
+	var sb strings.Builder
+	for _, username := range sortedKeys(o.users) {
+		fmt.Fprintf(&sb, "%s:%s\n", username, o.users[username])
+	}
+
+	return []byte(sb.String())
+}
+
+// aclConfig returns the ACL file of the rules: the rules of all the clients are patterns,
+// then the rules of each user follow their user line.
+func (o options) aclConfig() []byte {
+	var sb strings.Builder
+
+	users := map[string][]ACLRule{}
+	for _, rule := range o.acl {
+		if rule.Username == "" {
+			fmt.Fprintf(&sb, "pattern %s %s\n", rule.Access, rule.Topic)
+			continue
+		}
+		users[rule.Username] = append(users[rule.Username], rule)
+	}
+
+	for _, username := range sortedKeys(users) {
+		fmt.Fprintf(&sb, "\nuser %s\n", username)
+		for _, rule := range users[username] {
+			fmt.Fprintf(&sb, "topic %s %s\n", rule.Access, rule.Topic)
+		}
+	}
+
+	return []byte(sb.String())
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,65 @@
+package mosquitto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	require.Equal(t, `persistence false
+log_dest stdout
+
+allow_anonymous true
+
+listener 1883
+`, string(defaultOptions().config()))
+
+	o := defaultOptions()
+	WithUser("alice", "secret")(&o)
+	WithACL(ACLRule{Username: "alice", Topic: "sensors/#", Access: AccessReadWrite})(&o)
+	WithTLS()(&o)
+
+	require.Equal(t, `persistence false
+log_dest stdout
+
+allow_anonymous false
+password_file /mosquitto/config/passwd
+acl_file /mosquitto/config/acl
+
+listener 1883
+
+listener 8883
+cafile /mosquitto/config/ca.crt
+certfile /mosquitto/config/server.crt
+keyfile /mosquitto/config/server.key
+`, string(o.config()))
+}
+
+func TestPasswords(t *testing.T) {
+	o := defaultOptions()
+	WithUser("bob", "pass")(&o)
+	WithUser("alice", "secret")(&o)
+
+	require.Equal(t, "alice:secret\nbob:pass\n", string(o.passwords()))
+}
+
+func TestACLConfig(t *testing.T) {
+	o := defaultOptions()
+	WithACL(
+		ACLRule{Username: "bob", Topic: "alerts/#", Access: AccessRead},
+		ACLRule{Topic: "clients/%c/#", Access: AccessReadWrite},
+		ACLRule{Username: "alice", Topic: "sensors/#", Access: AccessWrite},
+		ACLRule{Username: "bob", Topic: "sensors/#", Access: AccessRead},
+	)(&o)
+
+	require.Equal(t, `pattern readwrite clients/%c/#
+
+user alice
+topic write sensors/#
+
+user bob
+topic read alerts/#
+topic read sensors/#
+`, string(o.aclConfig()))
+}
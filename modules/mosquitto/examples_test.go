@@ -0,0 +1,39 @@
+package mosquitto_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/mosquitto"
+)
+
+func ExampleRun() {
+	// runMosquittoContainer {
+	ctx := context.Background()
+
+	mosquittoContainer, err := mosquitto.Run(ctx, "eclipse-mosquitto:2.0.18",
+		mosquitto.WithUser("alice", "secret"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := mosquittoContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := mosquittoContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
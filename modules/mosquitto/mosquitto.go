@@ -0,0 +1,176 @@
+package mosquitto
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mdelapenya/tlscert"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// MQTTPort is the port of the MQTT listener.
+	MQTTPort = "1883/tcp"
+	// MQTTTLSPort is the port of the MQTT listener over TLS, see WithTLS.
+	MQTTTLSPort = "8883/tcp"
+)
+
+// MosquittoContainer represents the Mosquitto container type used in the module
+type MosquittoContainer struct {
+	testcontainers.Container
+	caCert *tlscert.Certificate
+}
+
+// BrokerURL returns the URL of the MQTT listener from the host, e.g. tcp://localhost:32768.
+func (c *MosquittoContainer) BrokerURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, MQTTPort, "tcp")
+}
+
+// TLSBrokerURL returns the URL of the MQTT listener over TLS from the host, e.g. tls://localhost:32769.
+// It returns an error if the container was not started with WithTLS.
+func (c *MosquittoContainer) TLSBrokerURL(ctx context.Context) (string, error) {
+	if c.caCert == nil {
+		return "", fmt.Errorf("tls listener not enabled, use WithTLS")
+	}
+
+	return c.PortEndpoint(ctx, MQTTTLSPort, "tls")
+}
+
+// TLSConfig returns the TLS config to connect to the MQTT listener over TLS, trusting the certificate authority
+// of the broker, or nil if the container was not started with WithTLS.
+func (c *MosquittoContainer) TLSConfig() *tls.Config {
+	if c.caCert == nil {
+		return nil
+	}
+
+	return tlsConfig(c.caCert)
+}
+
+// tlsConfig returns the TLS config trusting the certificate authority generated for the broker,
+// used by the TLSConfig method and by the wait strategy of the TLS listener, see WithTLS.
+func tlsConfig(caCert *tlscert.Certificate) *tls.Config {
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert.Cert)
+
+	return &tls.Config{
+		RootCAs:    caPool,
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// Run creates an instance of the Mosquitto container type, with the users, the ACL rules and the TLS listener
+// set with the options. It waits for the broker to complete the MQTT handshake, with the credentials of a user if any.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*MosquittoContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{MQTTPort},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	files := []testcontainers.ContainerFile{
+		{Reader: bytes.NewReader(settings.config()), ContainerFilePath: configFile, FileMode: 0o644},
+	}
+
+	// the files are owned by root when copied, and Mosquitto reads them as the mosquitto user
+	script := []string{"chown -R mosquitto:mosquitto " + configDir}
+
+	mqttWait := wait.ForMQTT(MQTTPort)
+
+	if len(settings.users) > 0 {
+		files = append(files, testcontainers.ContainerFile{
+			Reader: bytes.NewReader(settings.passwords()), ContainerFilePath: passwordFile, FileMode: 0o600,
+		})
+		script = append(script, "mosquitto_passwd -U "+passwordFile)
+
+		username := sortedKeys(settings.users)[0]
+		mqttWait = mqttWait.WithCredentials(username, settings.users[username])
+	}
+
+	if len(settings.acl) > 0 {
+		files = append(files, testcontainers.ContainerFile{
+			Reader: bytes.NewReader(settings.aclConfig()), ContainerFilePath: aclFile, FileMode: 0o600,
+		})
+	}
+
+	var caCert *tlscert.Certificate
+	if settings.tls {
+		caCert = tlscert.SelfSignedFromRequest(tlscert.Request{
+			Name:              "ca",
+			SubjectCommonName: "testcontainers-mosquitto-ca",
+			Host:              "localhost,127.0.0.1",
+			IsCA:              true,
+			ValidFor:          24 * time.Hour,
+		})
+		if caCert == nil {
+			return nil, errors.New("generate CA certificate")
+		}
+
+		serverCert := tlscert.SelfSignedFromRequest(tlscert.Request{
+			Name:              "server",
+			SubjectCommonName: "testcontainers-mosquitto",
+			Host:              "localhost,127.0.0.1",
+			ValidFor:          24 * time.Hour,
+			Parent:            caCert,
+		})
+		if serverCert == nil {
+			return nil, errors.New("generate server certificate")
+		}
+
+		files = append(files,
+			testcontainers.ContainerFile{Reader: bytes.NewReader(caCert.Bytes), ContainerFilePath: caFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(serverCert.Bytes), ContainerFilePath: certFile, FileMode: 0o644},
+			testcontainers.ContainerFile{Reader: bytes.NewReader(serverCert.KeyBytes), ContainerFilePath: keyFile, FileMode: 0o600},
+		)
+		genericContainerReq.ExposedPorts = append(genericContainerReq.ExposedPorts, MQTTTLSPort)
+	}
+
+	script = append(script, "exec mosquitto -c "+configFile)
+
+	genericContainerReq.Files = append(genericContainerReq.Files, files...)
+	genericContainerReq.Cmd = []string{"sh", "-c", strings.Join(script, " && ")}
+
+	if genericContainerReq.WaitingFor == nil {
+		strategies := []wait.Strategy{mqttWait}
+		if caCert != nil {
+			tlsWait := *mqttWait
+			tlsWait.Port = MQTTTLSPort
+			strategies = append(strategies, tlsWait.WithTLS(tlsConfig(caCert)))
+		}
+		genericContainerReq.WaitingFor = wait.ForAll(strategies...)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *MosquittoContainer
+	if container != nil {
+		c = &MosquittoContainer{Container: container, caCert: caCert}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,76 @@
+package mosquitto_test
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/mosquitto"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "eclipse-mosquitto:2.0.18"
+
+// handshake makes the MQTT handshake with the broker on the given port, with the credentials and the TLS config if any.
+func handshake(ctr *mosquitto.MosquittoContainer, port nat.Port, username string, password string, tlsConfig *tls.Config) error {
+	strategy := wait.ForMQTT(port).WithStartupTimeout(2 * time.Second)
+	if username != "" {
+		strategy = strategy.WithCredentials(username, password)
+	}
+	if tlsConfig != nil {
+		strategy = strategy.WithTLS(tlsConfig)
+	}
+
+	return strategy.WaitUntilReady(context.Background(), ctr)
+}
+
+func TestMosquitto(t *testing.T) {
+	ctx := context.Background()
+
+	// withUserACLAndTLS {
+	ctr, err := mosquitto.Run(ctx, testImage,
+		mosquitto.WithUser("alice", "secret"),
+		mosquitto.WithACL(mosquitto.ACLRule{Username: "alice", Topic: "sensors/#", Access: mosquitto.AccessReadWrite}),
+		mosquitto.WithTLS(),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// brokerURL {
+	brokerURL, err := ctr.BrokerURL(ctx)
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(brokerURL, "tcp://"))
+
+	require.NoError(t, handshake(ctr, mosquitto.MQTTPort, "alice", "secret", nil))
+	require.Error(t, handshake(ctr, mosquitto.MQTTPort, "alice", "wrong", nil))
+
+	// tlsBrokerURL {
+	tlsBrokerURL, err := ctr.TLSBrokerURL(ctx)
+	require.NoError(t, err)
+
+	tlsConfig := ctr.TLSConfig()
+	// }
+	require.True(t, strings.HasPrefix(tlsBrokerURL, "tls://"))
+	require.NoError(t, handshake(ctr, mosquitto.MQTTTLSPort, "alice", "secret", tlsConfig))
+}
+
+func TestMosquitto_anonymous(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := mosquitto.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.NoError(t, handshake(ctr, mosquitto.MQTTPort, "", "", nil))
+
+	_, err = ctr.TLSBrokerURL(ctx)
+	require.Error(t, err)
+	require.Nil(t, ctr.TLSConfig())
+}
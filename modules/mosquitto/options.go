@@ -0,0 +1,76 @@
+package mosquitto
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Access is the access of the clients to the topics matching an ACL rule.
+type Access string
+
+const (
+	// AccessRead allows the clients to subscribe to the topics.
+	AccessRead Access = "read"
+	// AccessWrite allows the clients to publish to the topics.
+	AccessWrite Access = "write"
+	// AccessReadWrite allows the clients to subscribe and to publish to the topics.
+	AccessReadWrite Access = "readwrite"
+)
+
+// ACLRule grants an access to the topics matching a filter, e.g. sensors/#, to a user or to all the clients.
+type ACLRule struct {
+	// Username is the user the rule applies to. Without username, the rule applies to all the clients.
+	Username string
+	// Topic is the topic filter, with the + and # wildcards, and the %u and %c patterns of Mosquitto,
+	// replaced by the username and the client ID, in the rules of all the clients.
+	Topic  string
+	Access Access
+}
+
+type options struct {
+	users map[string]string
+	acl   []ACLRule
+	tls   bool
+}
+
+func defaultOptions() options {
+	return options{
+		users: map[string]string{},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Mosquitto container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithUser creates a user with the given password. The anonymous clients are refused once a user is created.
+func WithUser(username string, password string) Option {
+	return func(o *options) {
+		o.users[username] = password
+	}
+}
+
+// WithACL restricts the access to the topics to the given rules: the clients can only subscribe and publish
+// to the topics granted by the rules.
+func WithACL(rules ...ACLRule) Option {
+	return func(o *options) {
+		o.acl = append(o.acl, rules...)
+	}
+}
+
+// WithTLS adds a TLS listener on the 8883 port, using certificates generated for the container.
+// The TLS config to connect to the listener is available with the TLSConfig method.
+// The certificate is only valid for localhost and 127.0.0.1, and the TLS config pins its ServerName to localhost:
+// when the Host of the container is a remote Docker host, the name verified is not the one of the host reached.
+func WithTLS() Option {
+	return func(o *options) {
+		o.tls = true
+	}
+}
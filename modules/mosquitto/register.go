@@ -0,0 +1,17 @@
+package mosquitto
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mosquitto",
+		DefaultImage: "eclipse-mosquitto:2.0.18",
+		ExposedPorts: []string{MQTTPort},
+		Options: map[string]any{
+			"WithACL":  WithACL,
+			"WithTLS":  WithTLS,
+			"WithUser": WithUser,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -97,3 +97,17 @@ func (c *MSSQLServerContainer) ConnectionString(ctx context.Context, args ...str
 
 	return connStr, nil
 }
+
+// ConnectionDetails returns the details to connect to the server of the container,
+// implementing testcontainers.ConnectionSource.
+func (c *MSSQLServerContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "sqlserver", defaultPort)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.username
+	details.Password = c.password
+
+	return details, nil
+}
@@ -0,0 +1,16 @@
+package mssql
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mssql",
+		DefaultImage: "mcr.microsoft.com/mssql/server:2022-CU10-ubuntu-22.04",
+		ExposedPorts: []string{defaultPort},
+		Options: map[string]any{
+			"WithAcceptEULA": WithAcceptEULA,
+			"WithPassword":   WithPassword,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -9,6 +9,7 @@ import (
 	// Import mysql into the scope of this package (required)
 	_ "github.com/go-sql-driver/mysql"
 
+	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
 )
 
@@ -58,6 +59,43 @@ func TestMySQL(t *testing.T) {
 	}
 }
 
+func TestMySQLConnectionDetails(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := mysql.Run(ctx, "mysql:8.0.36")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	var source testcontainers.ConnectionSource = container
+
+	details, err := source.ConnectionDetails(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details.Scheme != "mysql" {
+		t.Errorf("expected the mysql scheme, got %s", details.Scheme)
+	}
+
+	// the URL is not accepted by the driver, which uses the native DSN
+	db, err := sql.Open("mysql", details.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err = db.Ping(); err != nil {
+		t.Errorf("error pinging db: %+v\n", err)
+	}
+}
+
 func TestMySQLWithNonRootUserAndEmptyPassword(t *testing.T) {
 	ctx := context.Background()
 
@@ -156,3 +194,43 @@ func TestMySQLWithScripts(t *testing.T) {
 		t.Fatal("The expected record was not found in the database.")
 	}
 }
+
+func TestMySQLWithSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// withSeed {
+	container, err := mysql.Run(ctx,
+		"mysql:8.0.36",
+		testcontainers.WithSeed(
+			mysql.SQLSeeder([]byte("CREATE TABLE users (id int PRIMARY KEY, name varchar(255) NOT NULL);")),
+			mysql.CSVSeeder("users", []byte("id,name\n1,alice\n2,\"bob, jr\"\n")),
+		),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, _ := container.ConnectionString(ctx)
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = 2").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "bob, jr" {
+		t.Fatalf("expected the seeded record, got %q", name)
+	}
+}
@@ -0,0 +1,21 @@
+package mysql
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "mysql",
+		DefaultImage: "mysql:8.0.36",
+		ExposedPorts: []string{"3306/tcp", "33060/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":           WithConfigFile,
+			"WithDatabase":             WithDatabase,
+			"WithDefaultCredentials":   WithDefaultCredentials,
+			"WithGeneratedCredentials": WithGeneratedCredentials,
+			"WithPassword":             WithPassword,
+			"WithScripts":              WithScripts,
+			"WithUsername":             WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	// seedFile is the path where the SQL scripts are copied in the container before being run.
+	seedFile = "/tmp/testcontainers-seed.sql"
+	// seedCSVFile is the path where the CSV files are copied in the container before being loaded.
+	// It's in the directory the server is allowed to read files from, see the secure_file_priv variable.
+	seedCSVFile = "/var/lib/mysql-files/testcontainers-seed.csv"
+)
+
+// mysqlScript runs the mysql client as the root user, in the database of the container.
+const mysqlScript = `MYSQL_PWD="$MYSQL_ROOT_PASSWORD" mysql -uroot ${MYSQL_DATABASE:+--database="$MYSQL_DATABASE"} "$@"`
+
+// SQLSeeder returns a seeder running the SQL script with the mysql client, as the root user and in the database
+// of the container, to be used with the testcontainers.WithSeed option.
+func SQLSeeder(script []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		if err := c.CopyToContainer(ctx, script, seedFile, 0o644); err != nil {
+			return fmt.Errorf("copy SQL script: %w", err)
+		}
+
+		return mysqlExec(ctx, c, "source "+seedFile)
+	})
+}
+
+// CSVSeeder returns a seeder loading the rows of the CSV content into the table, to be used with the
+// testcontainers.WithSeed option. The first row is the header, with the names of the columns of the table.
+func CSVSeeder(table string, content []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		if err != nil {
+			return fmt.Errorf("read CSV: %w", err)
+		}
+
+		if len(records) == 0 {
+			return errors.New("read CSV: missing header")
+		}
+
+		// rewrite the records to normalize the quoting and line endings
+		buf := &bytes.Buffer{}
+		if err := csv.NewWriter(buf).WriteAll(records); err != nil {
+			return fmt.Errorf("write CSV: %w", err)
+		}
+
+		if err := c.CopyToContainer(ctx, buf.Bytes(), seedCSVFile, 0o644); err != nil {
+			return fmt.Errorf("copy CSV file: %w", err)
+		}
+
+		stmt := fmt.Sprintf(`LOAD DATA INFILE '%s' INTO TABLE %s FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' ESCAPED BY '' LINES TERMINATED BY '\n' IGNORE 1 LINES (%s)`,
+			seedCSVFile, table, strings.Join(records[0], ", "))
+
+		return mysqlExec(ctx, c, stmt)
+	})
+}
+
+// mysqlExec runs the statement with the mysql client, returning an error with its output if it fails.
+func mysqlExec(ctx context.Context, c testcontainers.Container, stmt string) error {
+	exitCode, reader, err := c.Exec(ctx, []string{"sh", "-c", mysqlScript, "mysql", "-e", stmt}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		out, _ := io.ReadAll(reader)
+		return fmt.Errorf("mysql: exit code %d: %s", exitCode, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package nats
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "nats",
+		DefaultImage: "nats:2.9",
+		ExposedPorts: []string{defaultClientPort, defaultRoutingPort, defaultMonitoringPort},
+		Options: map[string]any{
+			"WithArgument": WithArgument,
+			"WithPassword": WithPassword,
+			"WithUsername": WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
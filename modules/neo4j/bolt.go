@@ -0,0 +1,128 @@
+package neo4j
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// boltPreamble is the magic number sent by a client to start the Bolt handshake.
+var boltPreamble = []byte{0x60, 0x60, 0xb0, 0x17}
+
+// boltVersions are the Bolt protocol versions proposed in the handshake, in order of preference:
+// 5.0, 4.4, 4.0 and 3.0, in the [reserved, reserved, minor, major] format.
+var boltVersions = []byte{
+	0x00, 0x00, 0x00, 0x05,
+	0x00, 0x00, 0x04, 0x04,
+	0x00, 0x00, 0x00, 0x04,
+	0x00, 0x00, 0x00, 0x03,
+}
+
+// Compiler check to ensure that boltStrategy implements the wait.Strategy interface.
+var (
+	_ wait.Strategy        = (*boltStrategy)(nil)
+	_ wait.StrategyTimeout = (*boltStrategy)(nil)
+)
+
+// boltStrategy waits until the server completes the Bolt handshake on the given port,
+// which means that it's ready to accept connections from the drivers.
+type boltStrategy struct {
+	port         nat.Port
+	timeout      *time.Duration
+	pollInterval time.Duration
+}
+
+func forBoltHandshake(port nat.Port) *boltStrategy {
+	return &boltStrategy{
+		port:         port,
+		pollInterval: 100 * time.Millisecond,
+	}
+}
+
+// Timeout returns the startup timeout of the strategy, if any.
+func (s *boltStrategy) Timeout() *time.Duration {
+	return s.timeout
+}
+
+// WaitUntilReady implements the wait.Strategy interface.
+func (s *boltStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	timeout := 60 * time.Second
+	if s.timeout != nil {
+		timeout = *s.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("bolt handshake: %w", errors.Join(ctx.Err(), lastErr))
+		case <-time.After(s.pollInterval):
+		}
+
+		state, err := target.State(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !state.Running {
+			return fmt.Errorf("container is not running: %s", state.Status)
+		}
+
+		host, err := target.Host(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		port, err := target.MappedPort(ctx, s.port)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if lastErr = boltHandshake(ctx, net.JoinHostPort(host, port.Port())); lastErr == nil {
+			return nil
+		}
+	}
+}
+
+// boltHandshake performs the Bolt handshake with the server at the given address,
+// returning an error if the connection fails or the server does not agree on any of the proposed versions.
+func boltHandshake(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write(append(append([]byte{}, boltPreamble...), boltVersions...)); err != nil {
+		return fmt.Errorf("write handshake: %w", err)
+	}
+
+	version := make([]byte, 4)
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return fmt.Errorf("read handshake: %w", err)
+	}
+
+	if bytes.Equal(version, []byte{0, 0, 0, 0}) {
+		return errors.New("no supported bolt version")
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package neo4j
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestBoltHandshake(t *testing.T) {
+	// serve accepts a single connection, reads the handshake and replies with the given version.
+	serve := func(t *testing.T, version []byte) string {
+		t.Helper()
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { ln.Close() })
+
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			handshake := make([]byte, 20)
+			if _, err := io.ReadFull(conn, handshake); err != nil {
+				return
+			}
+			conn.Write(version) //nolint:errcheck // the client reports the error
+		}()
+
+		return ln.Addr().String()
+	}
+
+	t.Run("agreed-version", func(t *testing.T) {
+		if err := boltHandshake(context.Background(), serve(t, []byte{0, 0, 4, 4})); err != nil {
+			t.Fatalf("expected the handshake to succeed but did not: %s", err)
+		}
+	})
+
+	t.Run("no-supported-version", func(t *testing.T) {
+		err := boltHandshake(context.Background(), serve(t, []byte{0, 0, 0, 0}))
+		if err == nil || err.Error() != "no supported bolt version" {
+			t.Fatalf("expected no supported bolt version error but got: %v", err)
+		}
+	})
+
+	t.Run("connection-closed", func(t *testing.T) {
+		if err := boltHandshake(context.Background(), serve(t, nil)); err == nil {
+			t.Fatal("expected the handshake to fail but did not")
+		}
+	})
+}
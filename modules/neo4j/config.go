@@ -1,11 +1,16 @@
 package neo4j
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 )
 
 type LabsPlugin string
@@ -61,6 +66,112 @@ func WithLabsPlugin(plugins ...LabsPlugin) testcontainers.CustomizeRequestOption
 	}
 }
 
+// pluginProcedures are the procedures namespaces of the plugins, allowed to run unrestricted when they are installed.
+var pluginProcedures = map[LabsPlugin]string{
+	Apoc:             "apoc.*",
+	ApocCore:         "apoc.*",
+	Bloom:            "bloom.*",
+	GraphDataScience: "gds.*",
+	NeoSemantics:     "n10s.*",
+}
+
+// WithPlugins installs one or more plugins when the server starts, e.g. WithPlugins("apoc", "graph-data-science").
+// The version of each plugin is resolved by the Neo4j image to match the Neo4j version, and the plugins directory is
+// mounted from a volume named after the image and the plugins, so they are only downloaded once. The procedures of
+// the plugins are allowed to run unrestricted.
+// There might be plugins not supported by your selected version of Neo4j.
+func WithPlugins(plugins ...LabsPlugin) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if len(plugins) == 0 {
+			return nil
+		}
+
+		rawPluginValues := make([]string, len(plugins))
+		var procedures []string
+		seen := map[string]bool{}
+		for i, plugin := range plugins {
+			rawPluginValues[i] = string(plugin)
+
+			if p, ok := pluginProcedures[plugin]; ok && !seen[p] {
+				seen[p] = true
+				procedures = append(procedures, p)
+			}
+		}
+
+		req.Env["NEO4J_PLUGINS"] = fmt.Sprintf(`["%s"]`, strings.Join(rawPluginValues, `","`))
+
+		if len(procedures) > 0 {
+			if err := addSetting(req, "dbms.security.procedures.unrestricted", strings.Join(procedures, ",")); err != nil {
+				return err
+			}
+		}
+
+		hash := sha256.Sum256([]byte(req.Image + "|" + strings.Join(rawPluginValues, ",")))
+		volumeName := "testcontainers-neo4j-plugins-" + hex.EncodeToString(hash[:])[:12]
+		req.Mounts = append(req.Mounts, testcontainers.VolumeMount(volumeName, "/plugins"))
+
+		return nil
+	}
+}
+
+// WithCypherInit sets the Cypher statements to be run, in order, with cypher-shell once the container is ready,
+// e.g. to create constraints or seed data. The statements use the credentials set with WithAdminPassword.
+func WithCypherInit(statements ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					// the credentials are resolved once all the options have been applied
+					return runCypher(ctx, c, req.Env["NEO4J_AUTH"], statements...)
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// WithHeapSize sets both the initial and the maximum size of the heap, e.g. "512m".
+func WithHeapSize(size string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if err := addSetting(req, "dbms.memory.heap.initial_size", size); err != nil {
+			return err
+		}
+
+		return addSetting(req, "dbms.memory.heap.max_size", size)
+	}
+}
+
+// WithPageCacheSize sets the size of the page cache, e.g. "256m".
+func WithPageCacheSize(size string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		return addSetting(req, "dbms.memory.pagecache.size", size)
+	}
+}
+
+// runCypher runs the statements with cypher-shell in the container, using the credentials
+// of the NEO4J_AUTH environment variable, if any.
+func runCypher(ctx context.Context, c testcontainers.Container, auth string, statements ...string) error {
+	cmd := []string{"cypher-shell"}
+	if user, password, ok := strings.Cut(auth, "/"); ok {
+		cmd = append(cmd, "-u", user, "-p", password)
+	}
+
+	for _, stmt := range statements {
+		code, r, err := c.Exec(ctx, append(cmd, stmt), tcexec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("run cypher %q: %w", stmt, err)
+		}
+
+		if code != 0 {
+			output, _ := io.ReadAll(r)
+			return fmt.Errorf("run cypher %q: exit code %d: %s", stmt, code, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
 // WithNeo4jSetting adds Neo4j a single configuration setting to the container.
 // The setting can be added as in the official Neo4j configuration, the function automatically translates the setting
 // name (e.g. dbms.tx_log.rotation.size) into the format required by the Neo4j container.
@@ -50,6 +50,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 // Run creates an instance of the Neo4j container type
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*Neo4jContainer, error) {
 	httpPort, _ := nat.NewPort("tcp", defaultHttpPort)
+	boltPort, _ := nat.NewPort("tcp", defaultBoltPort)
 	request := testcontainers.ContainerRequest{
 		Image: img,
 		Env: map[string]string{
@@ -62,7 +63,8 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		},
 		WaitingFor: &wait.MultiStrategy{
 			Strategies: []wait.Strategy{
-				wait.NewLogStrategy("Bolt enabled on"),
+				// the drivers can connect once the server completes the Bolt handshake
+				forBoltHandshake(boltPort),
 				&wait.HTTPStrategy{
 					Port:              httpPort,
 					StatusCodeMatcher: isHttpOk(),
@@ -171,6 +171,54 @@ func TestNeo4jWithWrongSettings(outer *testing.T) {
 	})
 }
 
+func TestNeo4jWithPluginsAndCypherInit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// withPlugins {
+	container, err := neo4j.Run(ctx,
+		"neo4j:5.20",
+		neo4j.WithAdminPassword(testPassword),
+		neo4j.WithPlugins("apoc"),
+		neo4j.WithHeapSize("512m"),
+		neo4j.WithPageCacheSize("128m"),
+		neo4j.WithCypherInit(
+			"CREATE CONSTRAINT person_name IF NOT EXISTS FOR (p:Person) REQUIRE p.name IS UNIQUE",
+			"CREATE (:Person {name: 'Ada'})",
+		),
+	)
+	// }
+	if err != nil {
+		t.Fatalf("expected container to successfully initialize but did not: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	driver := createDriver(t, ctx, container)
+
+	result, err := neo.ExecuteQuery(ctx, driver,
+		"MATCH (p:Person) RETURN apoc.text.join(collect(p.name), ',') AS names", nil,
+		neo.EagerResultTransformer)
+	if err != nil {
+		t.Fatalf("expected query to successfully run but did not: %s", err)
+	}
+	if value, _ := result.Records[0].Get("names"); value != "Ada" {
+		t.Fatalf("did not get the person created by the init statements: %s", value)
+	}
+
+	env := getContainerEnv(t, ctx, container)
+	if !strings.Contains(env, "NEO4J_dbms_security_procedures_unrestricted=apoc.*") {
+		t.Fatal("expected the APOC procedures to be unrestricted but were not")
+	}
+	if !strings.Contains(env, "NEO4J_dbms_memory_heap_max__size=512m") {
+		t.Fatal("expected the heap size to be set but was not")
+	}
+}
+
 func setupNeo4j(ctx context.Context, t *testing.T) *neo4j.Neo4jContainer {
 	container, err := neo4j.Run(ctx,
 		"neo4j:4.4",
@@ -0,0 +1,34 @@
+package neo4j
+
+import (
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go/modules"
+)
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "neo4j",
+		DefaultImage: "neo4j:4.4",
+		ExposedPorts: []string{
+			fmt.Sprintf("%s/tcp", defaultBoltPort),
+			fmt.Sprintf("%s/tcp", defaultHttpPort),
+			fmt.Sprintf("%s/tcp", defaultHttpsPort),
+		},
+		Options: map[string]any{
+			"WithAcceptCommercialLicenseAgreement": WithAcceptCommercialLicenseAgreement,
+			"WithAcceptEvaluationLicenseAgreement": WithAcceptEvaluationLicenseAgreement,
+			"WithAdminPassword":                    WithAdminPassword,
+			"WithCypherInit":                       WithCypherInit,
+			"WithHeapSize":                         WithHeapSize,
+			"WithLabsPlugin":                       WithLabsPlugin,
+			"WithLogger":                           WithLogger,
+			"WithNeo4jSetting":                     WithNeo4jSetting,
+			"WithNeo4jSettings":                    WithNeo4jSettings,
+			"WithPageCacheSize":                    WithPageCacheSize,
+			"WithPlugins":                          WithPlugins,
+			"WithoutAuthentication":                WithoutAuthentication,
+		},
+		Run: modules.Runner(Run),
+	})
+}
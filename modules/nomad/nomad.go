@@ -0,0 +1,212 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "4646/tcp"
+)
+
+// Allocation is an allocation of a job, as returned by the HTTP API of the agent.
+type Allocation struct {
+	ID            string
+	JobID         string
+	TaskGroup     string
+	ClientStatus  string
+	DesiredStatus string
+}
+
+// NomadContainer represents the Nomad container type used in the module
+type NomadContainer struct {
+	testcontainers.Container
+}
+
+// HTTPAddress returns the address of the HTTP API of the agent, e.g. http://localhost:4646,
+// to be used as the address of the Nomad API client.
+func (c *NomadContainer) HTTPAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// RegisterJob parses the given job specification in HCL, and registers the job,
+// returning its ID. The allocations of the job are available with the Allocations method.
+func (c *NomadContainer) RegisterJob(ctx context.Context, hcl string) (string, error) {
+	var job map[string]any
+	if err := c.apiRequest(ctx, http.MethodPost, "/v1/jobs/parse", map[string]any{"JobHCL": hcl, "Canonicalize": true}, &job); err != nil {
+		return "", fmt.Errorf("parse job: %w", err)
+	}
+
+	id, _ := job["ID"].(string)
+	if err := c.apiRequest(ctx, http.MethodPost, "/v1/jobs", map[string]any{"Job": job}, nil); err != nil {
+		return "", fmt.Errorf("register job %s: %w", id, err)
+	}
+
+	return id, nil
+}
+
+// Allocations returns the allocations of the given job.
+func (c *NomadContainer) Allocations(ctx context.Context, jobID string) ([]Allocation, error) {
+	var allocs []Allocation
+	if err := c.apiRequest(ctx, http.MethodGet, "/v1/job/"+url.PathEscape(jobID)+"/allocations", nil, &allocs); err != nil {
+		return nil, fmt.Errorf("list allocations of job %s: %w", jobID, err)
+	}
+
+	return allocs, nil
+}
+
+// WaitForAllocations polls the allocations of the given job until all of them have the given
+// client status, e.g. running or complete, returning an error if any of them fails,
+// or if the context is done first.
+func (c *NomadContainer) WaitForAllocations(ctx context.Context, jobID string, status string) error {
+	for {
+		allocs, err := c.Allocations(ctx, jobID)
+		if err != nil {
+			return err
+		}
+
+		ready := len(allocs) > 0
+		for _, a := range allocs {
+			if a.ClientStatus == "failed" && status != "failed" {
+				return fmt.Errorf("allocation %s of job %s failed", a.ID, jobID)
+			}
+			ready = ready && a.ClientStatus == status
+		}
+
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for allocations of job %s: %w", jobID, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// WithConsul sets the address of the Consul agent used by Nomad to register the services
+// of the jobs, e.g. the network alias and port of a Consul container in the same network.
+func WithConsul(address string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Cmd = append(req.Cmd, "-consul-address="+address)
+
+		return nil
+	}
+}
+
+// WithConfigFile takes in a path to an HCL file with the configuration of the agent,
+// which is merged with the configuration of the dev mode.
+func WithConfigFile(configPath string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		cf := testcontainers.ContainerFile{
+			HostFilePath:      configPath,
+			ContainerFilePath: "/etc/nomad.d/agent.hcl",
+			FileMode:          0o644,
+		}
+		req.Files = append(req.Files, cf)
+		req.Cmd = append(req.Cmd, "-config="+cf.ContainerFilePath)
+
+		return nil
+	}
+}
+
+// Run creates an instance of the Nomad container type, running an agent in dev mode,
+// which is both a server and a client able to run raw_exec tasks. It waits for the server
+// to elect a leader and for the client to register.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*NomadContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		Cmd:          []string{"agent", "-dev", "-bind=0.0.0.0"},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("node registration complete"),
+			wait.ForHTTP("/v1/status/leader").
+				WithPort(defaultHTTPPort).
+				WithResponseMatcher(hasLeader),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NomadContainer{Container: container}, nil
+}
+
+// hasLeader returns true if the response of the leader endpoint contains the address of the leader.
+// The endpoint returns an empty JSON string while there is no leader.
+func hasLeader(body io.Reader) bool {
+	var leader string
+	if err := json.NewDecoder(body).Decode(&leader); err != nil {
+		return false
+	}
+
+	return leader != ""
+}
+
+// apiRequest sends the JSON body, if any, to the HTTP API of the agent,
+// and decodes the JSON response into out if it's not nil.
+func (c *NomadContainer) apiRequest(ctx context.Context, method string, path string, body any, out any) error {
+	address, err := c.HTTPAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, address+path, r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
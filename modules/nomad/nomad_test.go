@@ -0,0 +1,123 @@
+package nomad_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/nomad"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const testImage = "hashicorp/nomad:1.8"
+
+// sleepJob {
+const sleepJob = `
+job "sleep" {
+  datacenters = ["dc1"]
+  type        = "service"
+
+  group "sleep" {
+    task "sleep" {
+      driver = "raw_exec"
+
+      config {
+        command = "/bin/sleep"
+        args    = ["300"]
+      }
+    }
+  }
+}
+`
+
+// }
+
+func TestNomad(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := nomad.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// httpAddress {
+	address, err := ctr.HTTPAddress(ctx)
+	// }
+	require.NoError(t, err)
+
+	resp, err := http.Get(address + "/v1/agent/self")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// registerJob {
+	jobID, err := ctr.RegisterJob(ctx, sleepJob)
+	require.NoError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	err = ctr.WaitForAllocations(waitCtx, jobID, "running")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "sleep", jobID)
+
+	allocs, err := ctr.Allocations(ctx, jobID)
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	require.Equal(t, "sleep", allocs[0].TaskGroup)
+}
+
+func TestNomadWithConsul(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, nw.Remove(ctx)) })
+
+	consul, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          "hashicorp/consul:1.15",
+			ExposedPorts:   []string{"8500/tcp"},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {"consul"}},
+			WaitingFor:     wait.ForHTTP("/v1/status/leader").WithPort("8500/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, consul.Terminate(ctx)) })
+
+	ctr, err := nomad.Run(ctx, testImage,
+		// withConsul {
+		network.WithNetwork([]string{"nomad"}, nw),
+		nomad.WithConsul("consul:8500"),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	endpoint, err := consul.PortEndpoint(ctx, "8500/tcp", "http")
+	require.NoError(t, err)
+
+	// the agent registers its own services in Consul
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(endpoint + "/v1/catalog/service/nomad")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		var services []json.RawMessage
+		if err := json.NewDecoder(resp.Body).Decode(&services); err != nil {
+			return false
+		}
+
+		return len(services) > 0
+	}, 30*time.Second, time.Second)
+}
@@ -0,0 +1,16 @@
+package nomad
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "nomad",
+		DefaultImage: "hashicorp/nomad:1.8",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithConfigFile": WithConfigFile,
+			"WithConsul":     WithConsul,
+		},
+		Run: modules.Runner(Run),
+	})
+}
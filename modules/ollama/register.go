@@ -0,0 +1,12 @@
+package ollama
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "ollama",
+		DefaultImage: "ollama/ollama:0.1.25",
+		ExposedPorts: []string{"11434/tcp"},
+		Run:          modules.Runner(Run),
+	})
+}
@@ -1,10 +1,14 @@
 package openfga
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -14,6 +18,44 @@ import (
 // OpenFGAContainer represents the OpenFGA container type used in the module
 type OpenFGAContainer struct {
 	testcontainers.Container
+	settings             options
+	storeID              string
+	authorizationModelID string
+}
+
+// StoreID returns the ID of the store created with WithStore.
+func (c *OpenFGAContainer) StoreID() string {
+	return c.storeID
+}
+
+// AuthorizationModelID returns the ID of the authorization model written with
+// WithAuthorizationModel or WithAuthorizationModelFile.
+func (c *OpenFGAContainer) AuthorizationModelID() string {
+	return c.authorizationModelID
+}
+
+// Check returns whether the user has the relation with the object in the store created with WithStore,
+// using the authorization model written with the options, if any.
+func (c *OpenFGAContainer) Check(ctx context.Context, user string, relation string, object string) (bool, error) {
+	if c.storeID == "" {
+		return false, errors.New("no store created with WithStore")
+	}
+
+	body := map[string]any{
+		"tuple_key": Tuple{User: user, Relation: relation, Object: object},
+	}
+	if c.authorizationModelID != "" {
+		body["authorization_model_id"] = c.authorizationModelID
+	}
+
+	var result struct {
+		Allowed bool `json:"allowed"`
+	}
+	if err := c.apiRequest(ctx, "/stores/"+url.PathEscape(c.storeID)+"/check", body, &result); err != nil {
+		return false, fmt.Errorf("check: %w", err)
+	}
+
+	return result.Allowed, nil
 }
 
 // GrpcEndpoint returns the gRPC endpoint for the OpenFGA container,
@@ -45,11 +87,13 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return Run(ctx, "openfga/openfga:v1.5.0", opts...)
 }
 
-// Run creates an instance of the OpenFGA container type
+// Run creates an instance of the OpenFGA container type. Once the container is ready,
+// it creates the store, and writes the authorization model and the tuples set with the options.
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*OpenFGAContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        img,
 		Cmd:          []string{"run"},
+		Env:          map[string]string{},
 		ExposedPorts: []string{"3000/tcp", "8080/tcp", "8081/tcp"},
 		WaitingFor: wait.ForAll(
 			wait.ForHTTP("/healthz").WithPort("8080/tcp").WithResponseMatcher(func(r io.Reader) bool {
@@ -71,16 +115,128 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, fmt.Errorf("customize: %w", err)
 		}
 	}
 
+	if settings.presharedKey != "" {
+		genericContainerReq.Env["OPENFGA_AUTHN_METHOD"] = "preshared"
+		genericContainerReq.Env["OPENFGA_AUTHN_PRESHARED_KEYS"] = settings.presharedKey
+	}
+
+	model, err := settings.authorizationModel()
+	if err != nil {
+		return nil, fmt.Errorf("read authorization model: %w", err)
+	}
+
+	if settings.store == "" && (model != nil || len(settings.tuples) > 0) {
+		return nil, errors.New("authorization model and tuples need a store set with WithStore")
+	}
+
+	c := &OpenFGAContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx, model)
+			},
+		},
+	})
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
+	c.Container = container
+
+	return c, nil
+}
+
+// bootstrap creates the store, and writes the authorization model and the tuples, using the HTTP API.
+func (c *OpenFGAContainer) bootstrap(ctx context.Context, model []byte) error {
+	if c.settings.store == "" {
+		return nil
+	}
+
+	var store struct {
+		ID string `json:"id"`
+	}
+	if err := c.apiRequest(ctx, "/stores", map[string]string{"name": c.settings.store}, &store); err != nil {
+		return fmt.Errorf("create store %s: %w", c.settings.store, err)
+	}
+	c.storeID = store.ID
+
+	storePath := "/stores/" + url.PathEscape(c.storeID)
+
+	if model != nil {
+		var result struct {
+			AuthorizationModelID string `json:"authorization_model_id"`
+		}
+		if err := c.apiRequest(ctx, storePath+"/authorization-models", json.RawMessage(model), &result); err != nil {
+			return fmt.Errorf("write authorization model: %w", err)
+		}
+		c.authorizationModelID = result.AuthorizationModelID
+	}
+
+	if len(c.settings.tuples) > 0 {
+		body := map[string]any{
+			"writes": map[string]any{"tuple_keys": c.settings.tuples},
+		}
+		if c.authorizationModelID != "" {
+			body["authorization_model_id"] = c.authorizationModelID
+		}
+
+		if err := c.apiRequest(ctx, storePath+"/write", body, nil); err != nil {
+			return fmt.Errorf("write tuples: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// apiRequest posts the JSON body to the HTTP API, using the preshared key if any,
+// and decodes the JSON response into out if it's not nil.
+func (c *OpenFGAContainer) apiRequest(ctx context.Context, path string, body any, out any) error {
+	endpoint, err := c.HttpEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.settings.presharedKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.settings.presharedKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
 
-	return &OpenFGAContainer{Container: container}, nil
+	return json.NewDecoder(resp.Body).Decode(out)
 }
@@ -2,6 +2,7 @@ package openfga_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/testcontainers/testcontainers-go/modules/openfga"
@@ -24,3 +25,58 @@ func TestOpenFGA(t *testing.T) {
 
 	// perform assertions
 }
+
+func TestOpenFGAWithStore(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := openfga.Run(ctx, "openfga/openfga:v1.5.0",
+		// withStore {
+		openfga.WithPresharedKey("openfga-secret"),
+		openfga.WithStore("documents"),
+		openfga.WithAuthorizationModelFile(filepath.Join("testdata", "authorization_model.json")),
+		openfga.WithTuples(openfga.Tuple{User: "user:alice", Relation: "reader", Object: "document:roadmap"}),
+		// }
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if container.StoreID() == "" || container.AuthorizationModelID() == "" {
+		t.Fatalf("unexpected store %q and authorization model %q", container.StoreID(), container.AuthorizationModelID())
+	}
+
+	for _, tc := range []struct {
+		user    string
+		allowed bool
+	}{
+		{user: "user:alice", allowed: true},
+		{user: "user:bob", allowed: false},
+	} {
+		// check {
+		allowed, err := container.Check(ctx, tc.user, "reader", "document:roadmap")
+		// }
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if allowed != tc.allowed {
+			t.Fatalf("unexpected check result for %s: %t", tc.user, allowed)
+		}
+	}
+}
+
+func TestOpenFGAWithoutStore(t *testing.T) {
+	_, err := openfga.Run(context.Background(), "openfga/openfga:v1.5.0",
+		openfga.WithTuples(openfga.Tuple{User: "user:alice", Relation: "reader", Object: "document:roadmap"}),
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,86 @@
+package openfga
+
+import (
+	"os"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Tuple is a relationship tuple written to the store once the container is ready.
+type Tuple struct {
+	User     string `json:"user"`
+	Relation string `json:"relation"`
+	Object   string `json:"object"`
+}
+
+type options struct {
+	presharedKey string
+	store        string
+	model        []byte
+	modelFile    string
+	tuples       []Tuple
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the OpenFGA container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPresharedKey enables the preshared key authentication method, with the given key.
+// The requests of the module are authenticated with it.
+func WithPresharedKey(key string) Option {
+	return func(o *options) {
+		o.presharedKey = key
+	}
+}
+
+// WithStore creates a store with the given name once the container is ready.
+// Its ID is available with the StoreID method.
+func WithStore(name string) Option {
+	return func(o *options) {
+		o.store = name
+	}
+}
+
+// WithAuthorizationModel writes the given authorization model, in the JSON format of the API,
+// to the store once it's created. Its ID is available with the AuthorizationModelID method.
+// It needs a store set with WithStore.
+func WithAuthorizationModel(model []byte) Option {
+	return func(o *options) {
+		o.model = model
+		o.modelFile = ""
+	}
+}
+
+// WithAuthorizationModelFile writes the authorization model in the given JSON file
+// to the store once it's created. It needs a store set with WithStore.
+func WithAuthorizationModelFile(path string) Option {
+	return func(o *options) {
+		o.model = nil
+		o.modelFile = path
+	}
+}
+
+// WithTuples writes the given relationship tuples to the store once the authorization model is written.
+// It needs a store set with WithStore and an authorization model.
+func WithTuples(tuples ...Tuple) Option {
+	return func(o *options) {
+		o.tuples = append(o.tuples, tuples...)
+	}
+}
+
+// authorizationModel returns the authorization model set with the options, if any.
+func (o options) authorizationModel() ([]byte, error) {
+	if o.modelFile != "" {
+		return os.ReadFile(o.modelFile)
+	}
+
+	return o.model, nil
+}
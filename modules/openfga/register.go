@@ -0,0 +1,19 @@
+package openfga
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "openfga",
+		DefaultImage: "openfga/openfga:v1.5.0",
+		ExposedPorts: []string{"3000/tcp", "8080/tcp", "8081/tcp"},
+		Options: map[string]any{
+			"WithAuthorizationModel":     WithAuthorizationModel,
+			"WithAuthorizationModelFile": WithAuthorizationModelFile,
+			"WithPresharedKey":           WithPresharedKey,
+			"WithStore":                  WithStore,
+			"WithTuples":                 WithTuples,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,18 @@
+package openldap
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "openldap",
+		DefaultImage: "bitnami/openldap:2.6.6",
+		ExposedPorts: []string{"1389/tcp"},
+		Options: map[string]any{
+			"WithAdminPassword": WithAdminPassword,
+			"WithAdminUsername": WithAdminUsername,
+			"WithInitialLdif":   WithInitialLdif,
+			"WithRoot":          WithRoot,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,16 @@
+package opensearch
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "opensearch",
+		DefaultImage: "opensearchproject/opensearch:2.11.1",
+		ExposedPorts: []string{defaultHTTPPort, "9600/tcp"},
+		Options: map[string]any{
+			"WithPassword": WithPassword,
+			"WithUsername": WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
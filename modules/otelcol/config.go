@@ -0,0 +1,187 @@
+package otelcol
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	// OTLPGRPCPort is the port of the OTLP receiver over gRPC.
+	OTLPGRPCPort = "4317/tcp"
+	// OTLPHTTPPort is the port of the OTLP receiver over HTTP.
+	OTLPHTTPPort = "4318/tcp"
+
+	healthCheckPort = "13133/tcp"
+
+	configPath = "/etc/otelcol-contrib/config.yaml"
+	sinkDir    = "/tmp/otelcol"
+)
+
+// Signal is a type of telemetry, whose name prefixes the names of the pipelines, e.g. traces/backend.
+type Signal string
+
+// The signals of the pipelines.
+const (
+	SignalTraces  Signal = "traces"
+	SignalMetrics Signal = "metrics"
+	SignalLogs    Signal = "logs"
+)
+
+// sinkExporter returns the name of the exporter of the sink of the signal.
+func (s Signal) sinkExporter() string {
+	return "file/testcontainers-" + string(s)
+}
+
+// sinkPath returns the path of the file of the sink of the signal in the container.
+func (s Signal) sinkPath() string {
+	return sinkDir + "/" + string(s) + ".jsonl"
+}
+
+// Config is the configuration of the collector, built from the options, and written as the configuration file
+// of the collector. The components are configured with any value encoded as the YAML of the collector, e.g. a map
+// or one of the structs of the module, such as OTLPExporter.
+type Config struct {
+	Receivers  map[string]any `json:"receivers,omitempty"`
+	Processors map[string]any `json:"processors,omitempty"`
+	Exporters  map[string]any `json:"exporters,omitempty"`
+	Connectors map[string]any `json:"connectors,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+	Service    Service        `json:"service"`
+}
+
+// Service is the service section of the configuration, enabling the extensions and the pipelines.
+type Service struct {
+	Extensions []string            `json:"extensions,omitempty"`
+	Pipelines  map[string]Pipeline `json:"pipelines"`
+}
+
+// Pipeline is a pipeline of the collector, receiving, processing and exporting a signal.
+type Pipeline struct {
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors,omitempty"`
+	Exporters  []string `json:"exporters"`
+}
+
+// OTLPReceiver is the configuration of the otlp receiver.
+type OTLPReceiver struct {
+	Protocols OTLPProtocols `json:"protocols"`
+}
+
+// OTLPProtocols are the protocols of the otlp receiver.
+type OTLPProtocols struct {
+	GRPC *Endpoint `json:"grpc,omitempty"`
+	HTTP *Endpoint `json:"http,omitempty"`
+}
+
+// Endpoint is the address a receiver listens on, e.g. 0.0.0.0:4317.
+type Endpoint struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// OTLPExporter is the configuration of the otlp and otlphttp exporters, forwarding the telemetry to another backend.
+type OTLPExporter struct {
+	Endpoint string            `json:"endpoint"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	TLS      *TLSConfig        `json:"tls,omitempty"`
+}
+
+// TLSConfig is the TLS configuration of an exporter.
+type TLSConfig struct {
+	Insecure bool `json:"insecure"`
+}
+
+// BatchProcessor is the configuration of the batch processor.
+type BatchProcessor struct {
+	Timeout       string `json:"timeout,omitempty"`
+	SendBatchSize int    `json:"send_batch_size,omitempty"`
+}
+
+// fileExporter is the configuration of the file exporter of the sinks.
+type fileExporter struct {
+	Path string `json:"path"`
+}
+
+// defaultConfig returns the configuration receiving the three signals with the otlp receiver.
+func defaultConfig() Config {
+	return Config{
+		Receivers: map[string]any{
+			"otlp": OTLPReceiver{Protocols: OTLPProtocols{
+				GRPC: &Endpoint{Endpoint: "0.0.0.0:4317"},
+				HTTP: &Endpoint{Endpoint: "0.0.0.0:4318"},
+			}},
+		},
+		Processors: map[string]any{},
+		Exporters:  map[string]any{},
+		Connectors: map[string]any{},
+		Extensions: map[string]any{},
+		Service: Service{
+			Pipelines: map[string]Pipeline{
+				string(SignalTraces):  {Receivers: []string{"otlp"}},
+				string(SignalMetrics): {Receivers: []string{"otlp"}},
+				string(SignalLogs):    {Receivers: []string{"otlp"}},
+			},
+		},
+	}
+}
+
+// withSinks returns the configuration exporting the data of all the pipelines to the sinks of their signals,
+// and enabling the health check extension, probed by the wait strategy.
+func (cfg Config) withSinks() (Config, error) {
+	cfg.Exporters = maps.Clone(cfg.Exporters)
+	cfg.Extensions = maps.Clone(cfg.Extensions)
+	cfg.Service.Extensions = slices.Clone(cfg.Service.Extensions)
+
+	cfg.Extensions["health_check"] = Endpoint{Endpoint: "0.0.0.0:13133"}
+	if !slices.Contains(cfg.Service.Extensions, "health_check") {
+		cfg.Service.Extensions = append(cfg.Service.Extensions, "health_check")
+	}
+
+	pipelines := make(map[string]Pipeline, len(cfg.Service.Pipelines))
+	for name, p := range cfg.Service.Pipelines {
+		signal, _, _ := strings.Cut(name, "/")
+		switch Signal(signal) {
+		case SignalTraces, SignalMetrics, SignalLogs:
+		default:
+			return Config{}, fmt.Errorf("pipeline %s: unknown signal %s", name, signal)
+		}
+
+		if len(p.Receivers) == 0 {
+			return Config{}, fmt.Errorf("pipeline %s: no receiver", name)
+		}
+
+		s := Signal(signal)
+		cfg.Exporters[s.sinkExporter()] = fileExporter{Path: s.sinkPath()}
+		p.Exporters = append(append([]string{}, p.Exporters...), s.sinkExporter())
+		pipelines[name] = p
+	}
+	cfg.Service.Pipelines = pipelines
+
+	return cfg, nil
+}
+
+// configFile returns the configuration file of the collector. JSON being YAML, the configuration is encoded
+// as JSON, so the module doesn't depend on a YAML library.
+func configFile(cfg Config) ([]byte, error) {
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// signals returns the signals of the pipelines of the configuration, sorted by name.
+func (cfg Config) signals() []Signal {
+	seen := map[Signal]bool{}
+	for name := range cfg.Service.Pipelines {
+		signal, _, _ := strings.Cut(name, "/")
+		seen[Signal(signal)] = true
+	}
+
+	signals := make([]Signal, 0, len(seen))
+	for s := range seen {
+		signals = append(signals, s)
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i] < signals[j] })
+
+	return signals
+}
@@ -0,0 +1,82 @@
+package otelcol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_withSinks(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cfg, err := defaultOptions().config.withSinks()
+		require.NoError(t, err)
+
+		data, err := configFile(cfg)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"receivers": {
+				"otlp": {"protocols": {"grpc": {"endpoint": "0.0.0.0:4317"}, "http": {"endpoint": "0.0.0.0:4318"}}}
+			},
+			"exporters": {
+				"file/testcontainers-traces": {"path": "/tmp/otelcol/traces.jsonl"},
+				"file/testcontainers-metrics": {"path": "/tmp/otelcol/metrics.jsonl"},
+				"file/testcontainers-logs": {"path": "/tmp/otelcol/logs.jsonl"}
+			},
+			"extensions": {
+				"health_check": {"endpoint": "0.0.0.0:13133"}
+			},
+			"service": {
+				"extensions": ["health_check"],
+				"pipelines": {
+					"traces": {"receivers": ["otlp"], "exporters": ["file/testcontainers-traces"]},
+					"metrics": {"receivers": ["otlp"], "exporters": ["file/testcontainers-metrics"]},
+					"logs": {"receivers": ["otlp"], "exporters": ["file/testcontainers-logs"]}
+				}
+			}
+		}`, string(data))
+		require.Equal(t, []Signal{SignalLogs, SignalMetrics, SignalTraces}, cfg.signals())
+	})
+
+	t.Run("pipelines", func(t *testing.T) {
+		o := defaultOptions()
+		WithProcessor("batch", BatchProcessor{Timeout: "1s"})(&o)
+		WithExporter("otlp/jaeger", OTLPExporter{Endpoint: "jaeger:4317", TLS: &TLSConfig{Insecure: true}})(&o)
+		WithPipeline("traces/jaeger", Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+			Exporters:  []string{"otlp/jaeger"},
+		})(&o)
+		WithoutPipeline("metrics")(&o)
+		WithoutPipeline("logs")(&o)
+
+		cfg, err := o.config.withSinks()
+		require.NoError(t, err)
+
+		require.Equal(t, Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+			Exporters:  []string{"otlp/jaeger", "file/testcontainers-traces"},
+		}, cfg.Service.Pipelines["traces/jaeger"])
+		require.Equal(t, []Signal{SignalTraces}, cfg.signals())
+
+		// the options aren't modified
+		require.Equal(t, []string{"otlp/jaeger"}, o.config.Service.Pipelines["traces/jaeger"].Exporters)
+		require.NotContains(t, o.config.Exporters, "file/testcontainers-traces")
+	})
+
+	t.Run("unknown-signal", func(t *testing.T) {
+		o := defaultOptions()
+		WithPipeline("profiles", Pipeline{Receivers: []string{"otlp"}})(&o)
+
+		_, err := o.config.withSinks()
+		require.EqualError(t, err, "pipeline profiles: unknown signal profiles")
+	})
+
+	t.Run("no-receiver", func(t *testing.T) {
+		o := defaultOptions()
+		WithPipeline("logs", Pipeline{})(&o)
+
+		_, err := o.config.withSinks()
+		require.EqualError(t, err, "pipeline logs: no receiver")
+	})
+}
@@ -0,0 +1,43 @@
+package otelcol_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/otelcol"
+)
+
+func ExampleRun() {
+	// runCollectorContainer {
+	ctx := context.Background()
+
+	collectorContainer, err := otelcol.Run(ctx, "otel/opentelemetry-collector-contrib:0.108.0",
+		otelcol.WithProcessor("batch", otelcol.BatchProcessor{Timeout: "1s"}),
+		otelcol.WithPipeline("traces", otelcol.Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+		}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := collectorContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	spans, err := collectorContainer.Spans(ctx)
+	if err != nil {
+		log.Fatalf("failed to get spans: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(len(spans))
+
+	// Output:
+	// 0
+}
@@ -0,0 +1,82 @@
+package otelcol
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type options struct {
+	config Config
+}
+
+func defaultOptions() options {
+	return options{
+		config: defaultConfig(),
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the OpenTelemetry Collector container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithReceiver adds a receiver to the configuration, e.g. a prometheus receiver. Its ports must be exposed,
+// e.g. with testcontainers.WithExposedPorts. The receiver is used by the pipelines listing it, see WithPipeline.
+func WithReceiver(name string, cfg any) Option {
+	return func(o *options) {
+		o.config.Receivers[name] = cfg
+	}
+}
+
+// WithProcessor adds a processor to the configuration, e.g. BatchProcessor, used by the pipelines listing it.
+func WithProcessor(name string, cfg any) Option {
+	return func(o *options) {
+		o.config.Processors[name] = cfg
+	}
+}
+
+// WithExporter adds an exporter to the configuration, e.g. OTLPExporter, used by the pipelines listing it.
+func WithExporter(name string, cfg any) Option {
+	return func(o *options) {
+		o.config.Exporters[name] = cfg
+	}
+}
+
+// WithConnector adds a connector to the configuration, e.g. spanmetrics, used as an exporter of a pipeline
+// and a receiver of another one.
+func WithConnector(name string, cfg any) Option {
+	return func(o *options) {
+		o.config.Connectors[name] = cfg
+	}
+}
+
+// WithExtension adds an extension to the configuration, and enables it.
+func WithExtension(name string, cfg any) Option {
+	return func(o *options) {
+		o.config.Extensions[name] = cfg
+		o.config.Service.Extensions = append(o.config.Service.Extensions, name)
+	}
+}
+
+// WithPipeline sets the pipeline, named after its signal, e.g. traces or metrics/prometheus, replacing
+// the default pipeline of the name, if any. By default, the traces, metrics and logs pipelines receive
+// the telemetry with the otlp receiver. The data of all the pipelines is exported to the sink of their signal,
+// in addition to their exporters.
+func WithPipeline(name string, pipeline Pipeline) Option {
+	return func(o *options) {
+		o.config.Service.Pipelines[name] = pipeline
+	}
+}
+
+// WithoutPipeline removes the pipeline, e.g. one of the default pipelines.
+func WithoutPipeline(name string) Option {
+	return func(o *options) {
+		delete(o.config.Service.Pipelines, name)
+	}
+}
@@ -0,0 +1,233 @@
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// CollectorContainer represents the OpenTelemetry Collector container type used in the module. It receives
+// the telemetry of the application under test, exports it with the pipelines of its configuration, and keeps
+// the data of all the pipelines in sinks, read with Spans, Metrics and LogRecords to assert the emitted telemetry.
+type CollectorContainer struct {
+	testcontainers.Container
+	config Config
+}
+
+// Config returns the configuration of the collector, with the exporters of the sinks.
+func (c *CollectorContainer) Config() Config {
+	return c.config
+}
+
+// OTLPGRPCEndpoint returns the address of the OTLP receiver over gRPC, e.g. localhost:4317,
+// for the OTLP gRPC exporters of the tests.
+func (c *CollectorContainer) OTLPGRPCEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("host: %w", err)
+	}
+
+	port, err := c.MappedPort(ctx, OTLPGRPCPort)
+	if err != nil {
+		return "", fmt.Errorf("mapped port: %w", err)
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// OTLPHTTPEndpoint returns the URL of the OTLP receiver over HTTP, e.g. http://localhost:4318,
+// for the OTLP HTTP exporters of the tests.
+func (c *CollectorContainer) OTLPHTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, OTLPHTTPPort, "http")
+}
+
+// OTLPEnv returns the environment variables making the OpenTelemetry SDKs of a container, e.g. the application
+// under test, export their telemetry to the collector over HTTP, with testcontainers.WithEnv. The collector is reached
+// with its IP address, on the default bridge network.
+func (c *CollectorContainer) OTLPEnv(ctx context.Context) (map[string]string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container ip: %w", err)
+	}
+
+	return map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://" + net.JoinHostPort(ip, strings.TrimSuffix(OTLPHTTPPort, "/tcp")),
+		"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+	}, nil
+}
+
+// Spans returns the spans received by the collector so far, in the order they were exported.
+func (c *CollectorContainer) Spans(ctx context.Context) ([]Span, error) {
+	data, err := c.sink(ctx, SignalTraces)
+	if err != nil {
+		return nil, err
+	}
+
+	spans, err := parseSpans(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse spans: %w", err)
+	}
+
+	return spans, nil
+}
+
+// Metrics returns the metrics received by the collector so far, in the order they were exported.
+// A metric exported several times, e.g. periodically, appears once per export.
+func (c *CollectorContainer) Metrics(ctx context.Context) ([]Metric, error) {
+	data, err := c.sink(ctx, SignalMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := parseMetrics(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// LogRecords returns the log records received by the collector so far, in the order they were exported.
+func (c *CollectorContainer) LogRecords(ctx context.Context) ([]LogRecord, error) {
+	data, err := c.sink(ctx, SignalLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := parseLogs(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse logs: %w", err)
+	}
+
+	return records, nil
+}
+
+// WaitForSpan waits until the collector receives a span with the name, returning it, or until the context is done.
+func (c *CollectorContainer) WaitForSpan(ctx context.Context, name string) (Span, error) {
+	for {
+		spans, err := c.Spans(ctx)
+		if err != nil {
+			return Span{}, err
+		}
+
+		for _, s := range spans {
+			if s.Name == name {
+				return s, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return Span{}, fmt.Errorf("wait for span %s: %w", name, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// WaitForMetric waits until the collector receives a metric with the name, returning it, or until the context is done.
+func (c *CollectorContainer) WaitForMetric(ctx context.Context, name string) (Metric, error) {
+	for {
+		metrics, err := c.Metrics(ctx)
+		if err != nil {
+			return Metric{}, err
+		}
+
+		for _, m := range metrics {
+			if m.Name == name {
+				return m, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return Metric{}, fmt.Errorf("wait for metric %s: %w", name, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// sink returns the content of the file of the sink of the signal.
+func (c *CollectorContainer) sink(ctx context.Context, signal Signal) ([]byte, error) {
+	r, err := c.CopyFileFromContainer(ctx, signal.sinkPath())
+	if err != nil {
+		return nil, fmt.Errorf("copy %s sink: %w", signal, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read %s sink: %w", signal, err)
+	}
+
+	return data, nil
+}
+
+// Run creates an instance of the OpenTelemetry Collector container type. The image must be a distribution
+// of the collector providing the file exporter, e.g. otel/opentelemetry-collector-contrib.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*CollectorContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{OTLPGRPCPort, OTLPHTTPPort, healthCheckPort},
+		Cmd:          []string{"--config=" + configPath},
+		WaitingFor:   wait.ForHTTP("/").WithPort(healthCheckPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := settings.config.withSinks()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := configFile(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+
+	genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+		Reader:            bytes.NewReader(data),
+		ContainerFilePath: configPath,
+		FileMode:          0o644,
+	})
+
+	// the sinks are created writable by all, as the collector doesn't run as root
+	for _, signal := range cfg.signals() {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(""),
+			ContainerFilePath: signal.sinkPath(),
+			FileMode:          0o666,
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *CollectorContainer
+	if container != nil {
+		c = &CollectorContainer{Container: container, config: cfg}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,146 @@
+package otelcol_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/otelcol"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const image = "otel/opentelemetry-collector-contrib:0.108.0"
+
+const traces = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+"scopeSpans":[{"scope":{"name":"tests"},"spans":[{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174",
+"name":"GET /cart","kind":2,"startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000000250000000"}]}]}]}`
+
+const metrics = `{"resourceMetrics":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+"scopeMetrics":[{"scope":{"name":"tests"},"metrics":[{"name":"orders","sum":{"dataPoints":[{"timeUnixNano":"1700000000000000000",
+"asInt":"3"}],"aggregationTemporality":2,"isMonotonic":true}}]}]}]}`
+
+const logs = `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+"scopeLogs":[{"scope":{"name":"tests"},"logRecords":[{"timeUnixNano":"1700000000000000000","severityText":"INFO",
+"body":{"stringValue":"order placed"}}]}]}]}`
+
+// export sends the OTLP JSON payload to the path of the OTLP HTTP receiver, e.g. /v1/traces.
+func export(t *testing.T, ctr *otelcol.CollectorContainer, path string, payload string) {
+	t.Helper()
+
+	endpoint, err := ctr.OTLPHTTPEndpoint(context.Background())
+	require.NoError(t, err)
+
+	resp, err := http.Post(endpoint+path, "application/json", strings.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := otelcol.Run(ctx, image)
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	export(t, ctr, "/v1/traces", traces)
+	export(t, ctr, "/v1/metrics", metrics)
+	export(t, ctr, "/v1/logs", logs)
+
+	t.Run("spans", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// waitForSpan {
+		span, err := ctr.WaitForSpan(waitCtx, "GET /cart")
+		require.NoError(t, err)
+		require.Equal(t, "checkout", span.Resource.ServiceName())
+		// }
+
+		spans, err := ctr.Spans(ctx)
+		require.NoError(t, err)
+		require.Len(t, spans, 1)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		metric, err := ctr.WaitForMetric(waitCtx, "orders")
+		require.NoError(t, err)
+		require.Equal(t, "sum", metric.Type)
+		require.Equal(t, 3.0, metric.DataPoints[0].Value)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			records, err := ctr.LogRecords(ctx)
+			return err == nil && len(records) == 1 && records[0].Body == "order placed"
+		}, 30*time.Second, 500*time.Millisecond)
+	})
+
+	t.Run("grpc-endpoint", func(t *testing.T) {
+		endpoint, err := ctr.OTLPGRPCEndpoint(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, endpoint)
+	})
+}
+
+func TestCollector_appContainer(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := otelcol.Run(ctx, image,
+		otelcol.WithProcessor("batch", otelcol.BatchProcessor{Timeout: "100ms"}),
+		otelcol.WithPipeline("traces", otelcol.Pipeline{Receivers: []string{"otlp"}, Processors: []string{"batch"}}),
+		otelcol.WithoutPipeline("metrics"),
+		otelcol.WithoutPipeline("logs"),
+	)
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	// otlpEnv {
+	env, err := ctr.OTLPEnv(ctx)
+	require.NoError(t, err)
+
+	// the application under test, exporting its telemetry to the collector
+	app, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "curlimages/curl:8.9.1",
+			Env:   env,
+			Cmd: []string{
+				"sh", "-c",
+				`curl -sf -H 'Content-Type: application/json' -d '` + strings.ReplaceAll(traces, "\n", "") + `' "$OTEL_EXPORTER_OTLP_ENDPOINT/v1/traces"`,
+			},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	// }
+	t.Cleanup(func() {
+		if app != nil {
+			require.NoError(t, app.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		spans, err := ctr.Spans(ctx)
+		return err == nil && len(spans) == 1 && spans[0].Name == "GET /cart"
+	}, 30*time.Second, 500*time.Millisecond)
+
+	_, err = ctr.Metrics(ctx)
+	require.ErrorContains(t, err, "copy metrics sink")
+}
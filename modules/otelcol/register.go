@@ -0,0 +1,21 @@
+package otelcol
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "otelcol",
+		DefaultImage: "otel/opentelemetry-collector-contrib:0.108.0",
+		ExposedPorts: []string{OTLPGRPCPort, OTLPHTTPPort, healthCheckPort},
+		Options: map[string]any{
+			"WithConnector":   WithConnector,
+			"WithExporter":    WithExporter,
+			"WithExtension":   WithExtension,
+			"WithPipeline":    WithPipeline,
+			"WithProcessor":   WithProcessor,
+			"WithReceiver":    WithReceiver,
+			"WithoutPipeline": WithoutPipeline,
+		},
+		Run: modules.Runner(Run),
+	})
+}
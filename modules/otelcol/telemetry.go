@@ -0,0 +1,395 @@
+package otelcol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Span is a span received by the collector.
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	// Kind is the kind of the span, e.g. 2 for a server span, as defined by OTLP.
+	Kind  int
+	Start time.Time
+	End   time.Time
+	// StatusCode is the code of the status of the span: 0 unset, 1 ok, 2 error.
+	StatusCode    int
+	StatusMessage string
+	Attributes    map[string]any
+	Resource      Resource
+	// Scope is the name of the instrumentation scope, e.g. the instrumentation library.
+	Scope string
+}
+
+// Metric is a metric received by the collector, with its data points.
+type Metric struct {
+	Name        string
+	Description string
+	Unit        string
+	// Type is the type of the metric: gauge, sum, histogram, exponentialHistogram or summary.
+	Type       string
+	DataPoints []DataPoint
+	Resource   Resource
+	Scope      string
+}
+
+// DataPoint is a data point of a metric. The value of a histogram is the sum of its values.
+type DataPoint struct {
+	Time       time.Time
+	Value      float64
+	Count      uint64
+	Attributes map[string]any
+}
+
+// LogRecord is a log record received by the collector.
+type LogRecord struct {
+	Time           time.Time
+	SeverityNumber int
+	SeverityText   string
+	Body           any
+	TraceID        string
+	SpanID         string
+	Attributes     map[string]any
+	Resource       Resource
+	Scope          string
+}
+
+// Resource is the resource emitting the telemetry, e.g. a service.
+type Resource struct {
+	Attributes map[string]any
+}
+
+// ServiceName returns the service.name attribute of the resource.
+func (r Resource) ServiceName() string {
+	name, _ := r.Attributes["service.name"].(string)
+	return name
+}
+
+// The types of the OTLP JSON encoding, written by the file exporter, one request per line.
+type (
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string     `json:"stringValue"`
+		BoolValue   *bool       `json:"boolValue"`
+		IntValue    json.Number `json:"intValue"`
+		DoubleValue *float64    `json:"doubleValue"`
+		BytesValue  *string     `json:"bytesValue"`
+		ArrayValue  *struct {
+			Values []otlpAnyValue `json:"values"`
+		} `json:"arrayValue"`
+		KvlistValue *struct {
+			Values []otlpKeyValue `json:"values"`
+		} `json:"kvlistValue"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpTraces struct {
+		ResourceSpans []struct {
+			Resource   otlpResource `json:"resource"`
+			ScopeSpans []struct {
+				Scope otlpScope `json:"scope"`
+				Spans []struct {
+					TraceID           string         `json:"traceId"`
+					SpanID            string         `json:"spanId"`
+					ParentSpanID      string         `json:"parentSpanId"`
+					Name              string         `json:"name"`
+					Kind              int            `json:"kind"`
+					StartTimeUnixNano json.Number    `json:"startTimeUnixNano"`
+					EndTimeUnixNano   json.Number    `json:"endTimeUnixNano"`
+					Attributes        []otlpKeyValue `json:"attributes"`
+					Status            struct {
+						Code    int    `json:"code"`
+						Message string `json:"message"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	otlpDataPoint struct {
+		TimeUnixNano json.Number    `json:"timeUnixNano"`
+		AsDouble     *float64       `json:"asDouble"`
+		AsInt        json.Number    `json:"asInt"`
+		Sum          *float64       `json:"sum"`
+		Count        json.Number    `json:"count"`
+		Attributes   []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpDataPoints struct {
+		DataPoints []otlpDataPoint `json:"dataPoints"`
+	}
+
+	otlpMetrics struct {
+		ResourceMetrics []struct {
+			Resource     otlpResource `json:"resource"`
+			ScopeMetrics []struct {
+				Scope   otlpScope `json:"scope"`
+				Metrics []struct {
+					Name                 string          `json:"name"`
+					Description          string          `json:"description"`
+					Unit                 string          `json:"unit"`
+					Gauge                *otlpDataPoints `json:"gauge"`
+					Sum                  *otlpDataPoints `json:"sum"`
+					Histogram            *otlpDataPoints `json:"histogram"`
+					ExponentialHistogram *otlpDataPoints `json:"exponentialHistogram"`
+					Summary              *otlpDataPoints `json:"summary"`
+				} `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+
+	otlpLogs struct {
+		ResourceLogs []struct {
+			Resource  otlpResource `json:"resource"`
+			ScopeLogs []struct {
+				Scope      otlpScope `json:"scope"`
+				LogRecords []struct {
+					TimeUnixNano         json.Number    `json:"timeUnixNano"`
+					ObservedTimeUnixNano json.Number    `json:"observedTimeUnixNano"`
+					SeverityNumber       int            `json:"severityNumber"`
+					SeverityText         string         `json:"severityText"`
+					Body                 otlpAnyValue   `json:"body"`
+					TraceID              string         `json:"traceId"`
+					SpanID               string         `json:"spanId"`
+					Attributes           []otlpKeyValue `json:"attributes"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+)
+
+// value returns the Go value of the OTLP value: a string, a bool, an int64, a float64, a []byte,
+// a []any or a map[string]any.
+func (v otlpAnyValue) value() any {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != "":
+		i, _ := v.IntValue.Int64()
+		return i
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.BytesValue != nil:
+		b, _ := base64.StdEncoding.DecodeString(*v.BytesValue)
+		return b
+	case v.ArrayValue != nil:
+		values := make([]any, 0, len(v.ArrayValue.Values))
+		for _, e := range v.ArrayValue.Values {
+			values = append(values, e.value())
+		}
+		return values
+	case v.KvlistValue != nil:
+		return attributes(v.KvlistValue.Values)
+	}
+
+	return nil
+}
+
+// attributes returns the attributes as a map.
+func attributes(kvs []otlpKeyValue) map[string]any {
+	m := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value.value()
+	}
+
+	return m
+}
+
+// unixNano returns the time of the nanoseconds since the epoch, encoded as a string by OTLP.
+func unixNano(n json.Number) time.Time {
+	ns, err := strconv.ParseInt(string(n), 10, 64)
+	if err != nil || ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns)
+}
+
+// forEachLine decodes each line of the file of a sink.
+func forEachLine(data []byte, decode func(line []byte) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for i := 1; scanner.Scan(); i++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := decode(line); err != nil {
+			return fmt.Errorf("line %d: %w", i, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseSpans returns the spans of the file of the traces sink.
+func parseSpans(data []byte) ([]Span, error) {
+	var spans []Span
+	err := forEachLine(data, func(line []byte) error {
+		var req otlpTraces
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+
+		for _, rs := range req.ResourceSpans {
+			resource := Resource{Attributes: attributes(rs.Resource.Attributes)}
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans = append(spans, Span{
+						TraceID:       s.TraceID,
+						SpanID:        s.SpanID,
+						ParentSpanID:  s.ParentSpanID,
+						Name:          s.Name,
+						Kind:          s.Kind,
+						Start:         unixNano(s.StartTimeUnixNano),
+						End:           unixNano(s.EndTimeUnixNano),
+						StatusCode:    s.Status.Code,
+						StatusMessage: s.Status.Message,
+						Attributes:    attributes(s.Attributes),
+						Resource:      resource,
+						Scope:         ss.Scope.Name,
+					})
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return spans, err
+}
+
+// parseMetrics returns the metrics of the file of the metrics sink.
+func parseMetrics(data []byte) ([]Metric, error) {
+	var metrics []Metric
+	err := forEachLine(data, func(line []byte) error {
+		var req otlpMetrics
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+
+		for _, rm := range req.ResourceMetrics {
+			resource := Resource{Attributes: attributes(rm.Resource.Attributes)}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					metric := Metric{
+						Name:        m.Name,
+						Description: m.Description,
+						Unit:        m.Unit,
+						Resource:    resource,
+						Scope:       sm.Scope.Name,
+					}
+
+					var points *otlpDataPoints
+					switch {
+					case m.Gauge != nil:
+						metric.Type, points = "gauge", m.Gauge
+					case m.Sum != nil:
+						metric.Type, points = "sum", m.Sum
+					case m.Histogram != nil:
+						metric.Type, points = "histogram", m.Histogram
+					case m.ExponentialHistogram != nil:
+						metric.Type, points = "exponentialHistogram", m.ExponentialHistogram
+					case m.Summary != nil:
+						metric.Type, points = "summary", m.Summary
+					default:
+						points = &otlpDataPoints{}
+					}
+
+					for _, p := range points.DataPoints {
+						metric.DataPoints = append(metric.DataPoints, p.dataPoint())
+					}
+					metrics = append(metrics, metric)
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return metrics, err
+}
+
+// dataPoint returns the data point, valued with its double or int value, or else its sum.
+func (p otlpDataPoint) dataPoint() DataPoint {
+	dp := DataPoint{
+		Time:       unixNano(p.TimeUnixNano),
+		Attributes: attributes(p.Attributes),
+	}
+
+	switch {
+	case p.AsDouble != nil:
+		dp.Value = *p.AsDouble
+	case p.AsInt != "":
+		i, _ := p.AsInt.Int64()
+		dp.Value = float64(i)
+	case p.Sum != nil:
+		dp.Value = *p.Sum
+	}
+
+	if p.Count != "" {
+		dp.Count, _ = strconv.ParseUint(string(p.Count), 10, 64)
+	}
+
+	return dp
+}
+
+// parseLogs returns the log records of the file of the logs sink.
+func parseLogs(data []byte) ([]LogRecord, error) {
+	var records []LogRecord
+	err := forEachLine(data, func(line []byte) error {
+		var req otlpLogs
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+
+		for _, rl := range req.ResourceLogs {
+			resource := Resource{Attributes: attributes(rl.Resource.Attributes)}
+			for _, sl := range rl.ScopeLogs {
+				for _, r := range sl.LogRecords {
+					t := unixNano(r.TimeUnixNano)
+					if t.IsZero() {
+						t = unixNano(r.ObservedTimeUnixNano)
+					}
+
+					records = append(records, LogRecord{
+						Time:           t,
+						SeverityNumber: r.SeverityNumber,
+						SeverityText:   r.SeverityText,
+						Body:           r.Body.value(),
+						TraceID:        r.TraceID,
+						SpanID:         r.SpanID,
+						Attributes:     attributes(r.Attributes),
+						Resource:       resource,
+						Scope:          sl.Scope.Name,
+					})
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return records, err
+}
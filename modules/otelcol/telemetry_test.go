@@ -0,0 +1,86 @@
+package otelcol
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpans(t *testing.T) {
+	data, err := os.ReadFile("testdata/traces.jsonl")
+	require.NoError(t, err)
+
+	spans, err := parseSpans(data)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	require.Equal(t, Span{
+		TraceID:    "5b8efff798038103d269b633813fc60c",
+		SpanID:     "eee19b7ec3c1b174",
+		Name:       "GET /cart",
+		Kind:       2,
+		Start:      time.Unix(0, 1700000000000000000),
+		End:        time.Unix(0, 1700000000250000000),
+		Attributes: map[string]any{"http.status_code": int64(200), "http.route": "/cart"},
+		Resource:   Resource{Attributes: map[string]any{"service.name": "checkout"}},
+		Scope:      "net/http",
+	}, spans[0])
+
+	require.Equal(t, "eee19b7ec3c1b174", spans[1].ParentSpanID)
+	require.Equal(t, 2, spans[1].StatusCode)
+	require.Equal(t, "timeout", spans[1].StatusMessage)
+	require.Equal(t, []any{int64(1), 2.5}, spans[1].Attributes["db.rows"])
+	require.Equal(t, "checkout", spans[1].Resource.ServiceName())
+}
+
+func TestParseMetrics(t *testing.T) {
+	data, err := os.ReadFile("testdata/metrics.jsonl")
+	require.NoError(t, err)
+
+	metrics, err := parseMetrics(data)
+	require.NoError(t, err)
+	require.Len(t, metrics, 3)
+
+	require.Equal(t, "orders", metrics[0].Name)
+	require.Equal(t, "sum", metrics[0].Type)
+	require.Equal(t, []DataPoint{{
+		Time:       time.Unix(0, 1700000000000000000),
+		Value:      3,
+		Attributes: map[string]any{"currency": "EUR"},
+	}}, metrics[0].DataPoints)
+
+	require.Equal(t, "gauge", metrics[1].Type)
+	require.Equal(t, 42.5, metrics[1].DataPoints[0].Value)
+
+	require.Equal(t, "histogram", metrics[2].Type)
+	require.Equal(t, 120.0, metrics[2].DataPoints[0].Value)
+	require.Equal(t, uint64(4), metrics[2].DataPoints[0].Count)
+}
+
+func TestParseLogs(t *testing.T) {
+	data, err := os.ReadFile("testdata/logs.jsonl")
+	require.NoError(t, err)
+
+	records, err := parseLogs(data)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.Equal(t, "order placed", records[0].Body)
+	require.Equal(t, "INFO", records[0].SeverityText)
+	require.Equal(t, map[string]any{"order.id": int64(42), "paid": true}, records[0].Attributes)
+	require.Equal(t, "5b8efff798038103d269b633813fc60c", records[0].TraceID)
+
+	require.Equal(t, time.Unix(0, 1700000001000000000), records[1].Time)
+	require.Equal(t, map[string]any{"error": "declined"}, records[1].Body)
+}
+
+func TestParse_empty(t *testing.T) {
+	spans, err := parseSpans(nil)
+	require.NoError(t, err)
+	require.Empty(t, spans)
+
+	_, err = parseMetrics([]byte("{}\nnot json\n"))
+	require.ErrorContains(t, err, "line 2")
+}
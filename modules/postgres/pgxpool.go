@@ -0,0 +1,72 @@
+//go:build pgx
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// PoolContainer is the postgres container exposing a pgx connection pool to its database.
+// It's only available with the pgx build tag, e.g. go test -tags pgx ./...
+type PoolContainer struct {
+	*PostgresContainer
+	pool *testcontainers.Module[*pgxpool.Pool]
+}
+
+// RunWithPool creates an instance of the Postgres container type like Run, returning a handle
+// exposing the pgx connection pool to the database, closed when the container is terminated.
+func RunWithPool(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*PoolContainer, error) {
+	ctr, err := Run(ctx, img, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPoolContainer(ctr), nil
+}
+
+// NewPoolContainer returns the handle exposing the pgx connection pool to the database of the container.
+func NewPoolContainer(ctr *PostgresContainer) *PoolContainer {
+	connect := func(ctx context.Context, _ testcontainers.Container) (*pgxpool.Pool, error) {
+		connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := pgxpool.New(ctx, connStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, err
+		}
+
+		return pool, nil
+	}
+
+	closePool := func(pool *pgxpool.Pool) error {
+		pool.Close()
+		return nil
+	}
+
+	return &PoolContainer{
+		PostgresContainer: ctr,
+		pool:              testcontainers.NewModule(testcontainers.Container(ctr), connect, closePool),
+	}
+}
+
+// ConnectionPool returns the pgx connection pool to the database, created on the first call.
+// Don't close it: it's closed when the container is terminated.
+func (c *PoolContainer) ConnectionPool(ctx context.Context) (*pgxpool.Pool, error) {
+	return c.pool.Client(ctx)
+}
+
+// Terminate closes the connection pool, if it was created, then terminates the container.
+func (c *PoolContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	return c.pool.Terminate(ctx, opts...)
+}
@@ -0,0 +1,48 @@
+//go:build pgx
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestPostgresPool(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithPool {
+	ctr, err := postgres.RunWithPool(ctx,
+		"docker.io/postgres:16-alpine",
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		// terminating the container also closes the pool
+		require.NoError(t, ctr.Terminate(ctx))
+	})
+
+	pool, err := ctr.ConnectionPool(ctx)
+	require.NoError(t, err)
+	// }
+
+	var result int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT 1").Scan(&result))
+	require.Equal(t, 1, result)
+
+	again, err := ctr.ConnectionPool(ctx)
+	require.NoError(t, err)
+	require.Same(t, pool, again)
+}
@@ -59,6 +59,29 @@ func (c *PostgresContainer) ConnectionString(ctx context.Context, args ...string
 	return connStr, nil
 }
 
+// ConnectionDetails returns the details to connect to the database of the container,
+// implementing testcontainers.ConnectionSource.
+func (c *PostgresContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "postgres", "5432/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.user
+	details.Password = c.password
+	details.Database = c.dbName
+	// the image doesn't enable TLS
+	details.Params.Set("sslmode", "disable")
+
+	return details, nil
+}
+
+// Credentials returns the username and the password of the user of the container,
+// e.g. the ones generated by WithGeneratedCredentials.
+func (c *PostgresContainer) Credentials() testcontainers.Credentials {
+	return testcontainers.Credentials{Username: c.user, Password: c.password}
+}
+
 // WithConfigFile sets the config file to be used for the postgres container
 // It will also set the "config_file" parameter to the path of the config file
 // as a command line argument to the container
@@ -117,6 +140,21 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithGeneratedCredentials sets a random username and password, see [testcontainers.GenerateCredentials].
+func WithGeneratedCredentials() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		creds, err := testcontainers.GenerateCredentials()
+		if err != nil {
+			return fmt.Errorf("generate credentials: %w", err)
+		}
+
+		req.Env["POSTGRES_USER"] = creds.Username
+		req.Env["POSTGRES_PASSWORD"] = creds.Password
+
+		return testcontainers.WithSensitiveValues(creds.Password)(req)
+	}
+}
+
 // WithUsername sets the initial username to be created when the container starts
 // It is used in conjunction with WithPassword to set a user and its password.
 // It will create the specified user with superuser power and a database with the same name.
@@ -188,6 +188,78 @@ func TestWithConfigFile(t *testing.T) {
 	defer db.Close()
 }
 
+func TestConnectionDetails(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"docker.io/postgres:16-alpine",
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+		postgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// the container is usable as any testcontainers database
+	var source testcontainers.ConnectionSource = container
+
+	details, err := source.ConnectionDetails(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "postgres", details.Scheme)
+	require.Equal(t, user, details.Username)
+	require.Equal(t, password, details.Password)
+	require.Equal(t, dbname, details.Database)
+	require.Equal(t, "disable", details.Params.Get("sslmode"))
+
+	db, err := sql.Open("postgres", details.DSN())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Ping())
+}
+
+func TestWithGeneratedCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"docker.io/postgres:16-alpine",
+		postgres.WithDatabase(dbname),
+		postgres.WithGeneratedCredentials(),
+		postgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	creds := container.Credentials()
+	require.NotEqual(t, user, creds.Username)
+	require.NotEqual(t, password, creds.Password)
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	require.Contains(t, connStr, creds.Username+":"+creds.Password+"@")
+
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Ping())
+}
+
 func TestWithInitScript(t *testing.T) {
 	ctx := context.Background()
 
@@ -500,3 +572,47 @@ func TestSnapshotWithDockerExecFallback(t *testing.T) {
 	})
 	// }
 }
+
+func TestWithSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// withSeed {
+	ctr, err := postgres.Run(ctx,
+		"docker.io/postgres:16-alpine",
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+		postgres.BasicWaitStrategies(),
+		testcontainers.WithSeed(
+			postgres.SQLSeeder([]byte("CREATE TABLE users (id int PRIMARY KEY, name text NOT NULL, age int NOT NULL);")),
+			postgres.CSVSeeder("users", []byte("id,name,age\n1,alice,30\n2,\"bob, jr\",25\n")),
+		),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var name string
+	err = db.QueryRow("SELECT name FROM users WHERE id = 2").Scan(&name)
+	require.NoError(t, err)
+	require.Equal(t, "bob, jr", name)
+
+	t.Run("failure", func(t *testing.T) {
+		ctr, err := postgres.Run(ctx,
+			"docker.io/postgres:16-alpine",
+			postgres.BasicWaitStrategies(),
+			testcontainers.WithSeed(postgres.SQLSeeder([]byte("INSERT INTO missing VALUES (1);"))),
+		)
+		if ctr != nil {
+			t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+		}
+		require.ErrorContains(t, err, `relation "missing" does not exist`)
+	})
+}
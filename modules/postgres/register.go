@@ -0,0 +1,21 @@
+package postgres
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "postgres",
+		DefaultImage: "docker.io/postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":           WithConfigFile,
+			"WithDatabase":             WithDatabase,
+			"WithGeneratedCredentials": WithGeneratedCredentials,
+			"WithInitScripts":          WithInitScripts,
+			"WithPassword":             WithPassword,
+			"WithSQLDriver":            WithSQLDriver,
+			"WithUsername":             WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
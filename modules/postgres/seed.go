@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// seedFile is the path where the fixtures are copied in the container before being loaded.
+const seedFile = "/tmp/testcontainers-seed"
+
+// psqlScript runs psql as the user of the container, in its database, stopping at the first error.
+const psqlScript = `psql -v ON_ERROR_STOP=1 -U "$POSTGRES_USER" -d "${POSTGRES_DB:-$POSTGRES_USER}" "$@"`
+
+// SQLSeeder returns a seeder running the SQL script with psql, as the user of the container and in its database,
+// to be used with the testcontainers.WithSeed option.
+func SQLSeeder(script []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		if err := c.CopyToContainer(ctx, script, seedFile, 0o644); err != nil {
+			return fmt.Errorf("copy SQL script: %w", err)
+		}
+
+		return psql(ctx, c, "-f", seedFile)
+	})
+}
+
+// CSVSeeder returns a seeder copying the rows of the CSV content into the table, to be used with the
+// testcontainers.WithSeed option. The first row is the header, with the names of the columns of the table.
+func CSVSeeder(table string, content []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		header, err := csv.NewReader(bytes.NewReader(content)).Read()
+		if err != nil {
+			return fmt.Errorf("read CSV header: %w", err)
+		}
+
+		if err := c.CopyToContainer(ctx, content, seedFile, 0o644); err != nil {
+			return fmt.Errorf("copy CSV file: %w", err)
+		}
+
+		copyCmd := fmt.Sprintf(`\copy %s (%s) FROM '%s' WITH (FORMAT csv, HEADER true)`, table, strings.Join(header, ", "), seedFile)
+
+		return psql(ctx, c, "-c", copyCmd)
+	})
+}
+
+// psql runs psql with the given arguments, returning an error with its output if it fails.
+func psql(ctx context.Context, c testcontainers.Container, args ...string) error {
+	cmd := append([]string{"sh", "-c", psqlScript, "psql"}, args...)
+
+	exitCode, reader, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		out, _ := io.ReadAll(reader)
+		return fmt.Errorf("psql: exit code %d: %s", exitCode, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package promcollector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const namespace = "testcontainers"
+
+// Implement interface
+var _ prometheus.Collector = (*Collector)(nil)
+
+// defaultStartupBuckets are the buckets of the startup histogram, from 100ms to ~100s.
+var defaultStartupBuckets = prometheus.ExponentialBuckets(0.1, 2, 11)
+
+// counter represents a counter of the library, reading its value from the library stats.
+type counter struct {
+	desc  *prometheus.Desc
+	value func(testcontainers.LibraryStats) uint64
+}
+
+// startupPhases are the values of the phase label of the startup histogram.
+var startupPhases = []string{
+	testcontainers.StartupPhasePull,
+	testcontainers.StartupPhaseCreate,
+	testcontainers.StartupPhaseStart,
+	testcontainers.StartupPhaseWait,
+	testcontainers.StartupPhaseTotal,
+}
+
+// Collector is a prometheus.Collector exporting the activity of Testcontainers for Go
+// in the current process: containers created, started and terminated, image pulls and builds,
+// failures, reaper activity, and the startup durations of the containers.
+type Collector struct {
+	counters       []counter
+	startup        *prometheus.HistogramVec
+	startupBuckets []float64
+}
+
+// Option is a function that configures the Collector.
+type Option func(*Collector)
+
+// WithStartupBuckets sets the buckets, in seconds, of the histogram of the startup durations of the containers.
+func WithStartupBuckets(buckets ...float64) Option {
+	return func(c *Collector) {
+		c.startupBuckets = buckets
+	}
+}
+
+// NewCollector returns a new Collector, to be registered in a prometheus.Registerer.
+// The startup histogram observes the containers created after the creation of the Collector.
+func NewCollector(opts ...Option) *Collector {
+	newCounter := func(name string, help string, value func(testcontainers.LibraryStats) uint64) counter {
+		return counter{
+			desc:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, nil, nil),
+			value: value,
+		}
+	}
+
+	c := &Collector{
+		counters: []counter{
+			newCounter("containers_created_total", "Number of containers created.", func(s testcontainers.LibraryStats) uint64 { return s.ContainersCreated }),
+			newCounter("containers_started_total", "Number of containers started, including restarts.", func(s testcontainers.LibraryStats) uint64 { return s.ContainersStarted }),
+			newCounter("containers_terminated_total", "Number of containers terminated.", func(s testcontainers.LibraryStats) uint64 { return s.ContainersTerminated }),
+			newCounter("container_failures_total", "Number of containers failing to be created or started.", func(s testcontainers.LibraryStats) uint64 { return s.ContainerFailures }),
+			newCounter("image_pulls_total", "Number of images pulled.", func(s testcontainers.LibraryStats) uint64 { return s.ImagePulls }),
+			newCounter("image_pull_failures_total", "Number of images failing to be pulled.", func(s testcontainers.LibraryStats) uint64 { return s.ImagePullFailures }),
+			newCounter("image_builds_total", "Number of images built.", func(s testcontainers.LibraryStats) uint64 { return s.ImageBuilds }),
+			newCounter("image_build_failures_total", "Number of images failing to be built.", func(s testcontainers.LibraryStats) uint64 { return s.ImageBuildFailures }),
+			newCounter("reapers_created_total", "Number of reaper containers created.", func(s testcontainers.LibraryStats) uint64 { return s.ReapersCreated }),
+			newCounter("reapers_reused_total", "Number of reaper containers reused from another process of the test session.", func(s testcontainers.LibraryStats) uint64 { return s.ReapersReused }),
+			newCounter("reaper_connections_total", "Number of connections established with the reaper.", func(s testcontainers.LibraryStats) uint64 { return s.ReaperConnections }),
+			newCounter("reaper_connection_failures_total", "Number of connections failing to be established with the reaper.", func(s testcontainers.LibraryStats) uint64 { return s.ReaperConnectionFailures }),
+		},
+		startupBuckets: defaultStartupBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.startup = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "container",
+		Name:      "startup_seconds",
+		Help:      "Duration of the startup phases of the containers: pull, create, start, wait and total.",
+		Buckets:   c.startupBuckets,
+	}, []string{"phase"})
+
+	// export all the phases, even before the first container is created
+	for _, phase := range startupPhases {
+		c.startup.WithLabelValues(phase)
+	}
+
+	testcontainers.ObserveMetrics(c.observe)
+
+	return c
+}
+
+// observe records the duration of a startup phase of a container, once the phase completes.
+func (c *Collector) observe(phase string, d time.Duration) {
+	c.startup.WithLabelValues(phase).Observe(d.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, counter := range c.counters {
+		ch <- counter.desc
+	}
+
+	c.startup.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := testcontainers.Stats()
+	for _, counter := range c.counters {
+		ch <- prometheus.MustNewConstMetric(counter.desc, prometheus.CounterValue, float64(counter.value(stats)))
+	}
+
+	c.startup.Collect(ch)
+}
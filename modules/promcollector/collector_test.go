@@ -0,0 +1,54 @@
+package promcollector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(c))
+
+	// 12 counters and a startup histogram for each of the 5 phases
+	require.Equal(t, 17, testutil.CollectAndCount(c))
+
+	problems, err := testutil.CollectAndLint(c)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	require.Equal(t, 1, testutil.CollectAndCount(c, "testcontainers_containers_created_total"))
+	require.Equal(t, 5, testutil.CollectAndCount(c, "testcontainers_container_startup_seconds"))
+}
+
+func TestCollector_withStartupBuckets(t *testing.T) {
+	c := NewCollector(WithStartupBuckets(1, 10))
+
+	require.Equal(t, []float64{1, 10}, c.startupBuckets)
+}
+
+func TestCollector_observe(t *testing.T) {
+	c := NewCollector(WithStartupBuckets(1, 10))
+
+	c.observe(testcontainers.StartupPhaseWait, 500*time.Millisecond)
+	c.observe(testcontainers.StartupPhaseWait, 3*time.Second)
+
+	var m dto.Metric
+	require.NoError(t, c.startup.WithLabelValues(testcontainers.StartupPhaseWait).(prometheus.Histogram).Write(&m))
+	require.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+	require.InDelta(t, 3.5, m.GetHistogram().GetSampleSum(), 0.0001)
+	require.Equal(t, uint64(1), m.GetHistogram().GetBucket()[0].GetCumulativeCount())
+	require.Equal(t, uint64(2), m.GetHistogram().GetBucket()[1].GetCumulativeCount())
+
+	// observing a phase doesn't change the others
+	require.NoError(t, c.startup.WithLabelValues(testcontainers.StartupPhasePull).(prometheus.Histogram).Write(&m))
+	require.Zero(t, m.GetHistogram().GetSampleCount())
+}
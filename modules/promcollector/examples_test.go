@@ -0,0 +1,26 @@
+package promcollector_test
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/testcontainers/testcontainers-go/modules/promcollector"
+)
+
+func ExampleNewCollector() {
+	// registerCollector {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(promcollector.NewCollector())
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	// }
+
+	go func() {
+		if err := http.ListenAndServe(":2112", nil); err != nil {
+			log.Printf("failed to serve metrics: %s", err)
+		}
+	}()
+}
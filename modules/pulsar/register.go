@@ -0,0 +1,17 @@
+package pulsar
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "pulsar",
+		DefaultImage: "docker.io/apachepulsar/pulsar:2.10.2",
+		ExposedPorts: []string{defaultPulsarPort, defaultPulsarAdminPort},
+		Options: map[string]any{
+			"WithFunctionsWorker": WithFunctionsWorker,
+			"WithPulsarEnv":       WithPulsarEnv,
+			"WithTransactions":    WithTransactions,
+		},
+		Run: modules.Runner(Run),
+	})
+}
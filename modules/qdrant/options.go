@@ -0,0 +1,47 @@
+package qdrant
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Collection is a collection created once the container is ready, with a single unnamed vector.
+type Collection struct {
+	// Name is the name of the collection.
+	Name string
+	// Size is the number of dimensions of the vectors.
+	Size int
+	// Distance is the distance function used to compare the vectors: Cosine, Euclid, Dot or Manhattan.
+	// If it is not specified, then Cosine will be used.
+	Distance string
+}
+
+type options struct {
+	apiKey      string
+	collections []Collection
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Qdrant container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithAPIKey sets the API key required by the REST and gRPC APIs, in the api-key header.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithCollections creates the given collections once the container is ready.
+func WithCollections(collections ...Collection) Option {
+	return func(o *options) {
+		o.collections = append(o.collections, collections...)
+	}
+}
@@ -1,8 +1,14 @@
 package qdrant
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -12,6 +18,13 @@ import (
 // QdrantContainer represents the Qdrant container type used in the module
 type QdrantContainer struct {
 	testcontainers.Container
+	settings options
+}
+
+// APIKey returns the API key set with WithAPIKey, to be sent in the api-key header
+// or set as the API key of the Qdrant clients.
+func (c *QdrantContainer) APIKey() string {
+	return c.settings.apiKey
 }
 
 // Deprecated: use Run instead
@@ -20,10 +33,12 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return Run(ctx, "qdrant/qdrant:v1.7.4", opts...)
 }
 
-// Run creates an instance of the Qdrant container type
+// Run creates an instance of the Qdrant container type. Once the container is ready,
+// it creates the collections set with the options.
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*QdrantContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        img,
+		Env:          map[string]string{},
 		ExposedPorts: []string{"6333/tcp", "6334/tcp"},
 		WaitingFor: wait.ForAll(
 			wait.ForListeningPort("6333/tcp").WithStartupTimeout(5*time.Second),
@@ -36,18 +51,87 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	if settings.apiKey != "" {
+		genericContainerReq.Env["QDRANT__SERVICE__API_KEY"] = settings.apiKey
+	}
+
+	c := &QdrantContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.createCollections(ctx)
+			},
+		},
+	})
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
+	c.Container = container
+
+	return c, nil
+}
+
+// createCollections creates the collections using the REST API.
+func (c *QdrantContainer) createCollections(ctx context.Context) error {
+	if len(c.settings.collections) == 0 {
+		return nil
+	}
+
+	endpoint, err := c.RESTEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range c.settings.collections {
+		distance := col.Distance
+		if distance == "" {
+			distance = "Cosine"
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"vectors": map[string]any{"size": col.Size, "distance": distance},
+		})
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/collections/"+url.PathEscape(col.Name), bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.settings.apiKey != "" {
+			req.Header.Set("api-key", c.settings.apiKey)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("create collection %s: %w", col.Name, err)
+		}
+
+		msg, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("create collection %s: unexpected status code %d: %s", col.Name, resp.StatusCode, strings.TrimSpace(string(msg)))
+		}
+	}
 
-	return &QdrantContainer{Container: container}, nil
+	return nil
 }
 
 // RESTEndpoint returns the REST endpoint of the Qdrant container
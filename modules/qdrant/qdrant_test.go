@@ -2,6 +2,7 @@ package qdrant_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -81,3 +82,89 @@ func TestQdrant(t *testing.T) {
 		}
 	})
 }
+
+func TestQdrantWithCollections(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := qdrant.Run(ctx, "qdrant/qdrant:v1.7.4",
+		// withCollections {
+		qdrant.WithAPIKey("secret"),
+		qdrant.WithCollections(
+			qdrant.Collection{Name: "documents", Size: 384},
+			qdrant.Collection{Name: "images", Size: 512, Distance: "Dot"},
+		),
+		// }
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	restEndpoint, err := container.RESTEndpoint(ctx)
+	if err != nil {
+		t.Fatalf("failed to get REST endpoint: %s", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		size     int
+		distance string
+	}{
+		{name: "documents", size: 384, distance: "Cosine"},
+		{name: "images", size: 512, distance: "Dot"},
+	} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, restEndpoint+"/collections/"+tc.name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("api-key", container.APIKey())
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to perform GET request: %s", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		var info struct {
+			Result struct {
+				Config struct {
+					Params struct {
+						Vectors struct {
+							Size     int    `json:"size"`
+							Distance string `json:"distance"`
+						} `json:"vectors"`
+					} `json:"params"`
+				} `json:"config"`
+			} `json:"result"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			t.Fatal(err)
+		}
+
+		vectors := info.Result.Config.Params.Vectors
+		if vectors.Size != tc.size || vectors.Distance != tc.distance {
+			t.Fatalf("unexpected vectors of collection %s: %+v", tc.name, vectors)
+		}
+	}
+
+	// the API key is required
+	resp, err := http.Get(restEndpoint + "/collections")
+	if err != nil {
+		t.Fatalf("failed to perform GET request: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,16 @@
+package qdrant
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "qdrant",
+		DefaultImage: "qdrant/qdrant:v1.7.4",
+		ExposedPorts: []string{"6333/tcp", "6334/tcp"},
+		Options: map[string]any{
+			"WithAPIKey":      WithAPIKey,
+			"WithCollections": WithCollections,
+		},
+		Run: modules.Runner(Run),
+	})
+}
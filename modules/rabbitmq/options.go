@@ -13,6 +13,8 @@ type options struct {
 	AdminUsername string
 	AdminPassword string
 	SSLSettings   *SSLSettings
+	// GeneratedCredentials is true if the admin credentials are generated when the container is created.
+	GeneratedCredentials bool
 }
 
 func defaultOptions() options {
@@ -63,6 +65,13 @@ func WithAdminUsername(username string) Option {
 	}
 }
 
+// WithGeneratedCredentials sets a random admin username and password, see [testcontainers.GenerateCredentials].
+func WithGeneratedCredentials() Option {
+	return func(o *options) {
+		o.GeneratedCredentials = true
+	}
+}
+
 // WithSSL enables SSL on the RabbitMQ container, configuring the Erlang config file with the provided settings.
 func WithSSL(settings SSLSettings) Option {
 	return func(o *options) {
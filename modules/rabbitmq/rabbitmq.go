@@ -66,6 +66,12 @@ func (c *RabbitMQContainer) HttpsURL(ctx context.Context) (string, error) {
 	return c.PortEndpoint(ctx, nat.Port(DefaultHTTPSPort), "https")
 }
 
+// Credentials returns the username and the password of the admin user of the container,
+// e.g. the ones generated by WithGeneratedCredentials.
+func (c *RabbitMQContainer) Credentials() testcontainers.Credentials {
+	return testcontainers.Credentials{Username: c.AdminUsername, Password: c.AdminPassword}
+}
+
 // Deprecated: use Run instead
 // RunContainer creates an instance of the RabbitMQ container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RabbitMQContainer, error) {
@@ -111,6 +117,20 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if settings.GeneratedCredentials {
+		creds, err := testcontainers.GenerateCredentials()
+		if err != nil {
+			return nil, fmt.Errorf("generate credentials: %w", err)
+		}
+
+		settings.AdminUsername = creds.Username
+		settings.AdminPassword = creds.Password
+
+		if err := testcontainers.WithSensitiveValues(creds.Password)(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
 	if settings.SSLSettings != nil {
 		if err := applySSLSettings(settings.SSLSettings)(&genericContainerReq); err != nil {
 			return nil, err
@@ -0,0 +1,23 @@
+package rabbitmq
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "rabbitmq",
+		DefaultImage: "rabbitmq:3.12.11-management-alpine",
+		ExposedPorts: []string{
+			DefaultAMQPPort,
+			DefaultAMQPSPort,
+			DefaultHTTPSPort,
+			DefaultHTTPPort,
+		},
+		Options: map[string]any{
+			"WithAdminPassword":        WithAdminPassword,
+			"WithAdminUsername":        WithAdminUsername,
+			"WithGeneratedCredentials": WithGeneratedCredentials,
+			"WithSSL":                  WithSSL,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -43,6 +43,12 @@ func (c *RedisContainer) ConnectionString(ctx context.Context) (string, error) {
 	return uri, nil
 }
 
+// ConnectionDetails returns the details to connect to the server of the container,
+// implementing testcontainers.ConnectionSource.
+func (c *RedisContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	return testcontainers.NewConnectionDetails(ctx, c, "redis", "6379/tcp")
+}
+
 // Deprecated: use Run instead
 // RunContainer creates an instance of the Redis container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RedisContainer, error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/testcontainers/testcontainers-go"
 	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
 )
 
@@ -163,3 +164,46 @@ func assertSetsGets(t *testing.T, ctx context.Context, redisContainer *tcredis.R
 func flushRedis(ctx context.Context, client redis.Client) error {
 	return client.FlushAll(ctx).Err()
 }
+
+func TestRedisWithSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// withSeed {
+	redisContainer, err := tcredis.Run(ctx, "docker.io/redis:7",
+		testcontainers.WithSeed(
+			tcredis.CommandsSeeder([]byte("SET greeting \"hello world\"\nRPUSH queue a b c\n")),
+			tcredis.JSONSeeder([]byte(`{"user:1": "alice", "config": {"enabled": true}}`)),
+		),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	require.Equal(t, "hello world", client.Get(ctx, "greeting").Val())
+	require.Equal(t, int64(3), client.LLen(ctx, "queue").Val())
+	require.Equal(t, "alice", client.Get(ctx, "user:1").Val())
+	require.JSONEq(t, `{"enabled": true}`, client.Get(ctx, "config").Val())
+
+	t.Run("failure", func(t *testing.T) {
+		ctr, err := tcredis.Run(ctx, "docker.io/redis:7",
+			testcontainers.WithSeed(tcredis.CommandsSeeder([]byte("NOTACOMMAND key\n"))),
+		)
+		if ctr != nil {
+			t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+		}
+		require.ErrorContains(t, err, "unknown command")
+	})
+}
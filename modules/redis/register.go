@@ -0,0 +1,17 @@
+package redis
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "redis",
+		DefaultImage: "docker.io/redis:7",
+		ExposedPorts: []string{"6379/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":   WithConfigFile,
+			"WithLogLevel":     WithLogLevel,
+			"WithSnapshotting": WithSnapshotting,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// seedFile is the path where the commands are copied in the container before being sent to the server.
+const seedFile = "/tmp/testcontainers-seed.redis"
+
+// CommandsSeeder returns a seeder sending the commands to the server with redis-cli in pipe mode,
+// to be used with the testcontainers.WithSeed option. The commands use the inline format, one per line,
+// e.g. SET greeting "hello world", or the Redis protocol.
+func CommandsSeeder(commands []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		return pipeCommands(ctx, c, commands)
+	})
+}
+
+// JSONSeeder returns a seeder setting the keys of the JSON object to their values, to be used with the
+// testcontainers.WithSeed option. String values are set as they are, and any other value as its JSON encoding.
+func JSONSeeder(keys []byte) testcontainers.Seeder {
+	return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(keys, &values); err != nil {
+			return fmt.Errorf("unmarshal JSON keys: %w", err)
+		}
+
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		commands := &bytes.Buffer{}
+		for _, name := range names {
+			value := string(values[name])
+
+			var s string
+			if err := json.Unmarshal(values[name], &s); err == nil {
+				value = s
+			}
+
+			writeCommand(commands, "SET", name, value)
+		}
+
+		return pipeCommands(ctx, c, commands.Bytes())
+	})
+}
+
+// writeCommand writes the command to the buffer in the Redis protocol, which is safe for any value.
+func writeCommand(buf *bytes.Buffer, args ...string) {
+	buf.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+}
+
+// pipeCommands sends the commands to the server with redis-cli in pipe mode,
+// returning an error with its output if any command fails.
+func pipeCommands(ctx context.Context, c testcontainers.Container, commands []byte) error {
+	if err := c.CopyToContainer(ctx, commands, seedFile, 0o644); err != nil {
+		return fmt.Errorf("copy commands: %w", err)
+	}
+
+	exitCode, reader, err := c.Exec(ctx, []string{"sh", "-c", "redis-cli --pipe < " + seedFile}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	// the summary of the pipe mode reports the number of errors, e.g. errors: 0, replies: 2
+	if exitCode != 0 || !strings.Contains(string(out), "errors: 0,") {
+		return fmt.Errorf("redis-cli: exit code %d: %s", exitCode, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
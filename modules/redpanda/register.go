@@ -0,0 +1,28 @@
+package redpanda
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "redpanda",
+		DefaultImage: "docker.redpanda.com/redpandadata/redpanda:v23.3.3",
+		ExposedPorts: []string{
+			defaultKafkaAPIPort,
+			defaultAdminAPIPort,
+			defaultSchemaRegistryPort,
+		},
+		Options: map[string]any{
+			"WithAutoCreateTopics":                  WithAutoCreateTopics,
+			"WithBootstrapConfig":                   WithBootstrapConfig,
+			"WithEnableKafkaAuthorization":          WithEnableKafkaAuthorization,
+			"WithEnableSASL":                        WithEnableSASL,
+			"WithEnableSchemaRegistryHTTPBasicAuth": WithEnableSchemaRegistryHTTPBasicAuth,
+			"WithEnableWasmTransform":               WithEnableWasmTransform,
+			"WithListener":                          WithListener,
+			"WithNewServiceAccount":                 WithNewServiceAccount,
+			"WithSuperusers":                        WithSuperusers,
+			"WithTLS":                               WithTLS,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,17 @@
+package registry
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "registry",
+		DefaultImage: "registry:2.8.3",
+		ExposedPorts: []string{registryPort},
+		Options: map[string]any{
+			"WithData":         WithData,
+			"WithHtpasswd":     WithHtpasswd,
+			"WithHtpasswdFile": WithHtpasswdFile,
+		},
+		Run: modules.Runner(Run),
+	})
+}
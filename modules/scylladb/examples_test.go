@@ -0,0 +1,42 @@
+package scylladb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go/modules/scylladb"
+)
+
+func ExampleRun() {
+	// runScyllaDBContainer {
+	ctx := context.Background()
+
+	scyllaContainer, err := scylladb.Run(ctx,
+		"scylladb/scylla:6.1",
+		scylladb.WithKeyspace("test_keyspace"),
+		scylladb.WithInitScripts(filepath.Join("testdata", "init.cql")),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := scyllaContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := scyllaContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,19 @@
+package scylladb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "scylladb",
+		DefaultImage: "scylladb/scylla:6.1",
+		ExposedPorts: []string{string(port), string(shardAwarePort)},
+		Options: map[string]any{
+			"WithConfigFile":  WithConfigFile,
+			"WithInitScripts": WithInitScripts,
+			"WithKeyspace":    WithKeyspace,
+			"WithPassword":    WithPassword,
+			"WithUsername":    WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
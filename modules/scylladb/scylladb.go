@@ -0,0 +1,205 @@
+package scylladb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	port           = nat.Port("9042/tcp")
+	shardAwarePort = nat.Port("19042/tcp")
+
+	// cqlshrcPath is the path of the cqlsh config file of the root user, which holds
+	// the credentials used by cqlsh in the container when the password authentication is enabled.
+	cqlshrcPath = "/root/.cassandra/cqlshrc"
+)
+
+// ScyllaDBContainer represents the ScyllaDB container type used in the module
+type ScyllaDBContainer struct {
+	testcontainers.Container
+}
+
+// ConnectionHost returns the host and port of the ScyllaDB container, using the default, native 9042 port, and
+// obtaining the host and exposed port from the container
+func (c *ScyllaDBContainer) ConnectionHost(ctx context.Context) (string, error) {
+	return c.hostPort(ctx, port)
+}
+
+// ShardAwareConnectionHost returns the host and port of the ScyllaDB container, using the shard-aware 19042 port,
+// and obtaining the host and exposed port from the container
+func (c *ScyllaDBContainer) ShardAwareConnectionHost(ctx context.Context) (string, error) {
+	return c.hostPort(ctx, shardAwarePort)
+}
+
+func (c *ScyllaDBContainer) hostPort(ctx context.Context, p nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, p)
+	if err != nil {
+		return "", err
+	}
+
+	return host + ":" + mappedPort.Port(), nil
+}
+
+// WithConfigFile sets the YAML config file to be used for the ScyllaDB container.
+func WithConfigFile(configFile string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		cf := testcontainers.ContainerFile{
+			HostFilePath:      configFile,
+			ContainerFilePath: "/etc/scylla/scylla.yaml",
+			FileMode:          0o755,
+		}
+		req.Files = append(req.Files, cf)
+
+		return nil
+	}
+}
+
+// WithInitScripts sets the init CQL queries or shell scripts to be run once the container is ready
+func WithInitScripts(scripts ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		var initScripts []testcontainers.ContainerFile
+		var execs []testcontainers.Executable
+		for _, script := range scripts {
+			cf := testcontainers.ContainerFile{
+				HostFilePath:      script,
+				ContainerFilePath: "/" + filepath.Base(script),
+				FileMode:          0o755,
+			}
+			initScripts = append(initScripts, cf)
+
+			execs = append(execs, initScript{File: cf.ContainerFilePath})
+		}
+
+		req.Files = append(req.Files, initScripts...)
+		return testcontainers.WithAfterReadyCommand(execs...)(req)
+	}
+}
+
+// Run creates an instance of the ScyllaDB container type
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*ScyllaDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{string(port), string(shardAwarePort)},
+		// a single shard with a small amount of memory is enough for testing
+		Cmd: []string{"--developer-mode=1", "--overprovisioned=1", "--smp=1", "--memory=512M"},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(port),
+			wait.ForExec([]string{"cqlsh", "-e", "SELECT bootstrapped FROM system.local"}).WithResponseMatcher(func(body io.Reader) bool {
+				data, _ := io.ReadAll(body)
+				return strings.Contains(string(data), "COMPLETED")
+			}),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	var settings options
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.authEnabled() {
+		genericContainerReq.Cmd = append(genericContainerReq.Cmd, "--authenticator=PasswordAuthenticator")
+
+		// cqlsh uses the default superuser until the user is created
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(cqlshrc(defaultUsername, defaultPassword)),
+			ContainerFilePath: cqlshrcPath,
+			FileMode:          0o600,
+		})
+	}
+
+	// the user and the keyspace must be created before running the init scripts
+	genericContainerReq.LifecycleHooks = append([]testcontainers.ContainerLifecycleHooks{
+		{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return bootstrap(ctx, c, settings)
+				},
+			},
+		},
+	}, genericContainerReq.LifecycleHooks...)
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScyllaDBContainer{Container: container}, nil
+}
+
+// bootstrap creates the superuser and the keyspace set with the options, using cqlsh in the container.
+func bootstrap(ctx context.Context, c testcontainers.Container, settings options) error {
+	if settings.authEnabled() {
+		username, password := settings.credentials()
+
+		stmt := fmt.Sprintf("CREATE ROLE IF NOT EXISTS %s WITH PASSWORD = %s AND SUPERUSER = true AND LOGIN = true", quote(username), quote(password))
+		if username == defaultUsername {
+			stmt = "ALTER ROLE cassandra WITH PASSWORD = " + quote(password)
+		}
+
+		if err := execCQL(ctx, c, stmt); err != nil {
+			return fmt.Errorf("create user %s: %w", username, err)
+		}
+
+		if err := c.CopyToContainer(ctx, []byte(cqlshrc(username, password)), cqlshrcPath, 0o600); err != nil {
+			return fmt.Errorf("copy cqlshrc: %w", err)
+		}
+	}
+
+	if settings.keyspace != "" {
+		stmt := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH REPLICATION = {'class': 'SimpleStrategy', 'replication_factor': 1}", settings.keyspace)
+		if err := execCQL(ctx, c, stmt); err != nil {
+			return fmt.Errorf("create keyspace %s: %w", settings.keyspace, err)
+		}
+	}
+
+	return nil
+}
+
+// execCQL executes the CQL statement using cqlsh in the container.
+func execCQL(ctx context.Context, c testcontainers.Container, stmt string) error {
+	code, r, err := c.Exec(ctx, []string{"cqlsh", "-e", stmt}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(r)
+		return fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// cqlshrc returns the content of the cqlsh config file with the given credentials.
+func cqlshrc(username string, password string) string {
+	return fmt.Sprintf("[authentication]\nusername = %s\npassword = %s\n", username, password)
+}
+
+// quote returns the CQL string literal of the value.
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
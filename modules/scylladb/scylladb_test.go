@@ -0,0 +1,89 @@
+package scylladb_test
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules/scylladb"
+)
+
+const testImage = "scylladb/scylla:6.1"
+
+func TestScyllaDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := scylladb.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	// connectionHost {
+	connectionHost, err := container.ConnectionHost(ctx)
+	// }
+	require.NoError(t, err)
+	require.NotEmpty(t, connectionHost)
+
+	shardAwareHost, err := container.ShardAwareConnectionHost(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, connectionHost, shardAwareHost)
+}
+
+func TestScyllaDBWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	for _, script := range []string{"init.cql", "init.sh"} {
+		t.Run(script, func(t *testing.T) {
+			container, err := scylladb.Run(ctx, testImage, scylladb.WithInitScripts(filepath.Join("testdata", script)))
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+			keyspace := "test_keyspace"
+			if script == "init.sh" {
+				keyspace = "init_sh_keyspace"
+			}
+
+			require.Contains(t, cql(t, container, "SELECT name FROM "+keyspace+".test_table WHERE id=1"), "NAME")
+		})
+	}
+}
+
+func TestScyllaDBWithKeyspaceAndAuth(t *testing.T) {
+	ctx := context.Background()
+
+	// withKeyspaceAndAuth {
+	container, err := scylladb.Run(ctx, testImage,
+		scylladb.WithKeyspace("app"),
+		scylladb.WithUsername("app_user"),
+		scylladb.WithPassword("app_password"),
+		scylladb.WithInitScripts(filepath.Join("testdata", "init.cql")),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	require.Contains(t, cql(t, container, "DESCRIBE KEYSPACES"), "app")
+	require.Contains(t, cql(t, container, "LIST ROLES"), "app_user")
+
+	// wrong credentials are rejected
+	code, _, err := container.Exec(ctx, []string{"cqlsh", "-u", "app_user", "-p", "wrong", "-e", "DESCRIBE KEYSPACES"})
+	require.NoError(t, err)
+	require.NotZero(t, code)
+}
+
+// cql executes the statement with cqlsh in the container, returning its output.
+func cql(t *testing.T, container *scylladb.ScyllaDBContainer, stmt string) string {
+	t.Helper()
+
+	code, r, err := container.Exec(context.Background(), []string{"cqlsh", "-e", stmt}, tcexec.Multiplexed())
+	require.NoError(t, err)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Zero(t, code, string(output))
+
+	return string(output)
+}
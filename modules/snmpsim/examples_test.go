@@ -0,0 +1,39 @@
+package snmpsim_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/snmpsim"
+)
+
+func ExampleRun() {
+	// runSNMPSimContainer {
+	ctx := context.Background()
+
+	snmpsimContainer, err := snmpsim.Run(ctx, "tandrup/snmpsim:v0.4",
+		snmpsim.WithCommunity("public", snmpsim.OctetString("1.3.6.1.2.1.1.5.0", "switch-1")),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := snmpsimContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := snmpsimContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,52 @@
+package snmpsim
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type community struct {
+	variables  []Variable
+	recordPath string
+}
+
+type options struct {
+	communities map[string]community
+}
+
+func defaultOptions() options {
+	return options{
+		communities: map[string]community{},
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the SNMP simulator container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithCommunity serves the given variables to the SNMPv1 and SNMPv2c requests of the community, e.g. public.
+// It can be called several times for the same community, the last variable with a given OID winning.
+// Without community, the agent serves the system group of the MIB-2 to the public community.
+func WithCommunity(name string, variables ...Variable) Option {
+	return func(o *options) {
+		c := o.communities[name]
+		c.variables = append(c.variables, variables...)
+		o.communities[name] = c
+	}
+}
+
+// WithSnmprecFile serves the variables of a file in the snmprec format of snmpsim to the requests of the community,
+// e.g. a file recorded from a real device with snmprec.py. The lines of the file must be sorted by OID.
+// It replaces the variables set with WithCommunity for the community.
+func WithSnmprecFile(name string, path string) Option {
+	return func(o *options) {
+		o.communities[name] = community{recordPath: path}
+	}
+}
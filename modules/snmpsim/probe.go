@@ -0,0 +1,124 @@
+package snmpsim
+
+import (
+	"strconv"
+	"strings"
+)
+
+// probeOID is the OID requested to check that the agent answers, the sysDescr of the MIB-2.
+const probeOID = "1.3.6.1.2.1.1.1.0"
+
+// getRequest returns an SNMPv2c GetRequest message of the OID, with the given community,
+// in the BER encoding. The OID must be valid.
+func getRequest(community string, oid string) []byte {
+	varBind := tlv(0x30, append(tlv(0x06, encodeOID(oid)), 0x05, 0x00))
+
+	pdu := tlv(0xa0, concat(
+		tlv(0x02, []byte{0x01}), // request-id
+		tlv(0x02, []byte{0x00}), // error-status
+		tlv(0x02, []byte{0x00}), // error-index
+		tlv(0x30, varBind),
+	))
+
+	return tlv(0x30, concat(
+		tlv(0x02, []byte{0x01}), // version: 1 for SNMPv2c
+		tlv(0x04, []byte(community)),
+		pdu,
+	))
+}
+
+// isResponse returns true if the message is an SNMP message with a Response PDU.
+func isResponse(message []byte) bool {
+	if len(message) < 2 || message[0] != 0x30 {
+		return false
+	}
+
+	// the PDU follows the version and the community, in the content of the sequence
+	content := message[headerLength(message):]
+	for i := 0; i < 2; i++ {
+		if len(content) < 2 {
+			return false
+		}
+		n := headerLength(content) + contentLength(content)
+		if n > len(content) {
+			return false
+		}
+		content = content[n:]
+	}
+
+	return len(content) > 0 && content[0] == 0xa2
+}
+
+// encodeOID returns the BER encoding of the content of an OID.
+func encodeOID(oid string) []byte {
+	arcs := strings.Split(strings.TrimPrefix(oid, "."), ".")
+	values := make([]uint64, len(arcs))
+	for i, arc := range arcs {
+		values[i], _ = strconv.ParseUint(arc, 10, 64)
+	}
+
+	encoded := base128(values[0]*40 + values[1])
+	for _, v := range values[2:] {
+		encoded = append(encoded, base128(v)...)
+	}
+
+	return encoded
+}
+
+// base128 returns the base-128 encoding of an arc, with the high bit set on all the bytes but the last one.
+func base128(v uint64) []byte {
+	encoded := []byte{byte(v & 0x7f)}
+	for v >>= 7; v > 0; v >>= 7 {
+		encoded = append([]byte{byte(v&0x7f) | 0x80}, encoded...)
+	}
+
+	return encoded
+}
+
+// tlv returns the BER encoding of a value with the given tag.
+func tlv(tag byte, content []byte) []byte {
+	var length []byte
+	switch n := len(content); {
+	case n < 0x80:
+		length = []byte{byte(n)}
+	case n <= 0xff:
+		length = []byte{0x81, byte(n)}
+	default:
+		length = []byte{0x82, byte(n >> 8), byte(n)}
+	}
+
+	return concat([]byte{tag}, length, content)
+}
+
+// headerLength returns the length of the tag and of the length of a BER encoded value.
+func headerLength(b []byte) int {
+	if b[1]&0x80 == 0 {
+		return 2
+	}
+
+	return 2 + int(b[1]&0x7f)
+}
+
+// contentLength returns the length of the content of a BER encoded value, or the length of the buffer
+// if it's truncated.
+func contentLength(b []byte) int {
+	if b[1]&0x80 == 0 {
+		return int(b[1])
+	}
+
+	n := 0
+	for _, c := range b[2:min(len(b), headerLength(b))] {
+		n = n<<8 | int(c)
+	}
+
+	return n
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+
+	return b
+}
@@ -0,0 +1,37 @@
+package snmpsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRequest(t *testing.T) {
+	require.Equal(t, []byte{
+		0x30, 0x26,
+		0x02, 0x01, 0x01,
+		0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+		0xa0, 0x19,
+		0x02, 0x01, 0x01,
+		0x02, 0x01, 0x00,
+		0x02, 0x01, 0x00,
+		0x30, 0x0e, 0x30, 0x0c,
+		0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00,
+		0x05, 0x00,
+	}, getRequest("public", probeOID))
+
+	require.Equal(t, []byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0xbf, 0x08}, encodeOID("1.3.6.1.4.1.8072"))
+	require.Equal(t, []byte{0x81, 0x80, 0x00}, tlv(0x04, make([]byte, 128))[1:4])
+}
+
+func TestIsResponse(t *testing.T) {
+	request := getRequest("private", probeOID)
+	require.False(t, isResponse(request))
+
+	response := append([]byte{}, request...)
+	response[14] = 0xa2
+	require.True(t, isResponse(response))
+
+	require.False(t, isResponse(nil))
+	require.False(t, isResponse([]byte{0x30, 0x05, 0x02, 0x01}))
+}
@@ -0,0 +1,16 @@
+package snmpsim
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "snmpsim",
+		DefaultImage: "tandrup/snmpsim:v0.4",
+		ExposedPorts: []string{SNMPPort},
+		Options: map[string]any{
+			"WithCommunity":   WithCommunity,
+			"WithSnmprecFile": WithSnmprecFile,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,114 @@
+package snmpsim
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// SNMPPort is the UDP port of the SNMP agent.
+	SNMPPort = "161/udp"
+
+	dataDir = "/usr/local/snmpsim/data/testcontainers"
+)
+
+// communityPattern matches the names of the communities, which are the names of their snmprec files.
+var communityPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// SNMPSimContainer represents the SNMP simulator container type used in the module
+type SNMPSimContainer struct {
+	testcontainers.Container
+}
+
+// Address returns the host and the UDP port of the SNMP agent from the host, e.g. localhost:32768,
+// to be used as the target of the SNMP clients.
+func (c *SNMPSimContainer) Address(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, SNMPPort, "")
+}
+
+// Run creates an instance of the SNMP simulator container type, serving the variables of the communities
+// set with the options. It waits for the agent to answer an SNMPv2c request of the first community.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*SNMPSimContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{SNMPPort},
+		Entrypoint: []string{
+			"snmpsimd.py",
+			"--data-dir=" + dataDir,
+			"--agent-udpv4-endpoint=0.0.0.0:161",
+			"--process-user=nobody",
+			"--process-group=nogroup",
+		},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(settings.communities) == 0 {
+		WithCommunity("public", defaultVariables()...)(&settings)
+	}
+
+	names := make([]string, 0, len(settings.communities))
+	for name := range settings.communities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !communityPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid community %q", name)
+		}
+
+		c := settings.communities[name]
+		file := testcontainers.ContainerFile{ContainerFilePath: dataDir + "/" + name + ".snmprec", FileMode: 0o644}
+		if c.recordPath != "" {
+			file.HostFilePath = c.recordPath
+		} else {
+			for _, v := range c.variables {
+				if err := v.validate(); err != nil {
+					return nil, fmt.Errorf("community %s: %w", name, err)
+				}
+			}
+			file.Reader = bytes.NewReader(snmprec(c.variables))
+		}
+		genericContainerReq.Files = append(genericContainerReq.Files, file)
+	}
+
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForUDP(SNMPPort).
+			WithPayload(getRequest(names[0], probeOID)).
+			WithResponse(isResponse).
+			WithStartupTimeout(time.Minute)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *SNMPSimContainer
+	if container != nil {
+		c = &SNMPSimContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,113 @@
+package snmpsim_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/snmpsim"
+)
+
+const testImage = "tandrup/snmpsim:v0.4"
+
+// client returns an SNMPv2c client of the agent, connected with the given community.
+func client(t *testing.T, ctr *snmpsim.SNMPSimContainer, community string) *gosnmp.GoSNMP {
+	t.Helper()
+
+	// address {
+	address, err := ctr.Address(context.Background())
+	// }
+	require.NoError(t, err)
+
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	snmp := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      uint16(portNumber),
+		Community: community,
+		Version:   gosnmp.Version2c,
+		Timeout:   2 * time.Second,
+		Retries:   2,
+	}
+	require.NoError(t, snmp.Connect())
+	t.Cleanup(func() { require.NoError(t, snmp.Conn.Close()) })
+
+	return snmp
+}
+
+func TestSNMPSim(t *testing.T) {
+	ctx := context.Background()
+
+	// withCommunity {
+	ctr, err := snmpsim.Run(ctx, testImage,
+		snmpsim.WithCommunity("switch",
+			snmpsim.OctetString("1.3.6.1.2.1.1.5.0", "switch-1"),
+			snmpsim.Integer("1.3.6.1.2.1.2.2.1.8.1", 1),
+			snmpsim.Integer("1.3.6.1.2.1.2.2.1.8.2", 2),
+			snmpsim.Counter64("1.3.6.1.2.1.31.1.1.1.6.1", 123456789),
+		),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	snmp := client(t, ctr, "switch")
+
+	result, err := snmp.Get([]string{"1.3.6.1.2.1.1.5.0", "1.3.6.1.2.1.31.1.1.1.6.1"})
+	require.NoError(t, err)
+	require.Len(t, result.Variables, 2)
+	require.Equal(t, []byte("switch-1"), result.Variables[0].Value)
+	require.Equal(t, uint64(123456789), gosnmp.ToBigInt(result.Variables[1].Value).Uint64())
+
+	var statuses []int
+	err = snmp.BulkWalk("1.3.6.1.2.1.2.2.1.8", func(pdu gosnmp.SnmpPDU) error {
+		statuses = append(statuses, pdu.Value.(int))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, statuses)
+}
+
+func TestSNMPSim_default(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := snmpsim.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	result, err := client(t, ctr, "public").Get([]string{"1.3.6.1.2.1.1.1.0"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("Testcontainers SNMP simulator"), result.Variables[0].Value)
+}
+
+func TestSNMPSim_snmprecFile(t *testing.T) {
+	ctx := context.Background()
+
+	// withSnmprecFile {
+	ctr, err := snmpsim.Run(ctx, testImage,
+		snmpsim.WithSnmprecFile("router", filepath.Join("testdata", "router.snmprec")),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	result, err := client(t, ctr, "router").Get([]string{"1.3.6.1.2.1.1.5.0"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("router-1"), result.Variables[0].Value)
+}
+
+func TestSNMPSim_invalidOID(t *testing.T) {
+	_, err := snmpsim.Run(context.Background(), testImage,
+		snmpsim.WithCommunity("public", snmpsim.Integer("ifOperStatus.1", 1)),
+	)
+	require.ErrorContains(t, err, `invalid oid "ifOperStatus.1"`)
+}
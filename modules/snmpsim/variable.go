@@ -0,0 +1,168 @@
+package snmpsim
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of the value of a variable, identified by its tag in the snmprec format of snmpsim.
+type Type int
+
+// The types of the values of the variables.
+const (
+	TypeInteger          Type = 2
+	TypeOctetString      Type = 4
+	TypeObjectIdentifier Type = 6
+	TypeIPAddress        Type = 64
+	TypeCounter32        Type = 65
+	TypeGauge32          Type = 66
+	TypeTimeTicks        Type = 67
+	TypeCounter64        Type = 70
+)
+
+// oidPattern matches the numeric OIDs, e.g. 1.3.6.1.2.1.1.1.0, with an optional leading dot.
+var oidPattern = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)+$`)
+
+// Variable is a variable served by the agent, its OID and its value.
+type Variable struct {
+	// OID is the numeric object identifier of the variable, e.g. 1.3.6.1.2.1.1.1.0.
+	OID   string
+	Type  Type
+	Value string
+}
+
+// Integer returns an Integer32 variable.
+func Integer(oid string, value int32) Variable {
+	return Variable{OID: oid, Type: TypeInteger, Value: strconv.FormatInt(int64(value), 10)}
+}
+
+// OctetString returns an OCTET STRING variable.
+func OctetString(oid string, value string) Variable {
+	return Variable{OID: oid, Type: TypeOctetString, Value: value}
+}
+
+// ObjectIdentifier returns an OBJECT IDENTIFIER variable, e.g. the sysObjectID of the device.
+func ObjectIdentifier(oid string, value string) Variable {
+	return Variable{OID: oid, Type: TypeObjectIdentifier, Value: strings.TrimPrefix(value, ".")}
+}
+
+// IPAddress returns an IpAddress variable, e.g. 192.168.1.1.
+func IPAddress(oid string, value string) Variable {
+	return Variable{OID: oid, Type: TypeIPAddress, Value: value}
+}
+
+// Counter32 returns a Counter32 variable.
+func Counter32(oid string, value uint32) Variable {
+	return Variable{OID: oid, Type: TypeCounter32, Value: strconv.FormatUint(uint64(value), 10)}
+}
+
+// Gauge32 returns a Gauge32 variable.
+func Gauge32(oid string, value uint32) Variable {
+	return Variable{OID: oid, Type: TypeGauge32, Value: strconv.FormatUint(uint64(value), 10)}
+}
+
+// TimeTicks returns a TimeTicks variable, in hundredths of a second, e.g. the sysUpTime of the device.
+func TimeTicks(oid string, value uint32) Variable {
+	return Variable{OID: oid, Type: TypeTimeTicks, Value: strconv.FormatUint(uint64(value), 10)}
+}
+
+// Counter64 returns a Counter64 variable.
+func Counter64(oid string, value uint64) Variable {
+	return Variable{OID: oid, Type: TypeCounter64, Value: strconv.FormatUint(value, 10)}
+}
+
+// defaultVariables returns the system group of the MIB-2, served by the public community without variables.
+func defaultVariables() []Variable {
+	return []Variable{
+		OctetString("1.3.6.1.2.1.1.1.0", "Testcontainers SNMP simulator"),
+		ObjectIdentifier("1.3.6.1.2.1.1.2.0", "1.3.6.1.4.1.8072.3.2.10"),
+		TimeTicks("1.3.6.1.2.1.1.3.0", 100),
+		OctetString("1.3.6.1.2.1.1.4.0", "testcontainers"),
+		OctetString("1.3.6.1.2.1.1.5.0", "snmpsim"),
+		OctetString("1.3.6.1.2.1.1.6.0", "container"),
+	}
+}
+
+// validate returns an error if the OID of the variable is not numeric.
+func (v Variable) validate() error {
+	if !oidPattern.MatchString(v.OID) {
+		return fmt.Errorf("invalid oid %q", v.OID)
+	}
+
+	if v.Type == TypeObjectIdentifier && !oidPattern.MatchString(v.Value) {
+		return fmt.Errorf("invalid object identifier %q of oid %s", v.Value, v.OID)
+	}
+
+	return nil
+}
+
+// record returns the line of the variable in the snmprec format, OID|TAG|VALUE. The octet strings which are not
+// printable, or which contain the separator, are hex encoded.
+func (v Variable) record() string {
+	oid := strings.TrimPrefix(v.OID, ".")
+
+	if v.Type == TypeOctetString && !printable(v.Value) {
+		return fmt.Sprintf("%s|%dx|%s", oid, v.Type, hex.EncodeToString([]byte(v.Value)))
+	}
+
+	return fmt.Sprintf("%s|%d|%s", oid, v.Type, v.Value)
+}
+
+// printable returns true if the value can be written as is in a snmprec file.
+func printable(value string) bool {
+	for _, r := range value {
+		if r < 0x20 || r > 0x7e || r == '|' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// snmprec returns the snmprec file of the variables, sorted by OID as required by snmpsim to walk them.
+// A variable replaces the previous variables with the same OID.
+func snmprec(variables []Variable) []byte {
+	byOID := map[string]Variable{}
+	for _, v := range variables {
+		byOID[strings.TrimPrefix(v.OID, ".")] = v
+	}
+
+	oids := make([]string, 0, len(byOID))
+	for oid := range byOID {
+		oids = append(oids, oid)
+	}
+	sort.Slice(oids, func(i, j int) bool {
+		return compareOIDs(oids[i], oids[j]) < 0
+	})
+
+	var sb strings.Builder
+	for _, oid := range oids {
+		sb.WriteString(byOID[oid].record())
+		sb.WriteString("\n")
+	}
+
+	return []byte(sb.String())
+}
+
+// compareOIDs compares two numeric OIDs arc by arc.
+func compareOIDs(a string, b string) int {
+	aArcs := strings.Split(a, ".")
+	bArcs := strings.Split(b, ".")
+
+	for i := 0; i < len(aArcs) && i < len(bArcs); i++ {
+		x, _ := strconv.ParseUint(aArcs[i], 10, 64)
+		y, _ := strconv.ParseUint(bArcs[i], 10, 64)
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return len(aArcs) - len(bArcs)
+}
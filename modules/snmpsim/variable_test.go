@@ -0,0 +1,42 @@
+package snmpsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnmprec(t *testing.T) {
+	variables := []Variable{
+		Counter64("1.3.6.1.2.1.31.1.1.1.6.10", 1<<40),
+		OctetString("1.3.6.1.2.1.1.5.0", "old-name"),
+		Integer("1.3.6.1.2.1.2.2.1.8.2", 2),
+		Integer("1.3.6.1.2.1.2.2.1.8.10", 1),
+		OctetString(".1.3.6.1.2.1.1.5.0", "switch-1"),
+		OctetString("1.3.6.1.2.1.2.2.1.6.2", "\x00\x1a\x2b\x3c\x4d\x5e"),
+		ObjectIdentifier("1.3.6.1.2.1.1.2.0", ".1.3.6.1.4.1.9.1.1"),
+		IPAddress("1.3.6.1.2.1.4.20.1.1.10.0.0.1", "10.0.0.1"),
+		TimeTicks("1.3.6.1.2.1.1.3.0", 360000),
+		Gauge32("1.3.6.1.2.1.2.2.1.5.2", 1000000000),
+		Counter32("1.3.6.1.2.1.2.2.1.10.2", 42),
+	}
+
+	require.Equal(t, `1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.9.1.1
+1.3.6.1.2.1.1.3.0|67|360000
+1.3.6.1.2.1.1.5.0|4|switch-1
+1.3.6.1.2.1.2.2.1.5.2|66|1000000000
+1.3.6.1.2.1.2.2.1.6.2|4x|001a2b3c4d5e
+1.3.6.1.2.1.2.2.1.8.2|2|2
+1.3.6.1.2.1.2.2.1.8.10|2|1
+1.3.6.1.2.1.2.2.1.10.2|65|42
+1.3.6.1.2.1.4.20.1.1.10.0.0.1|64|10.0.0.1
+1.3.6.1.2.1.31.1.1.1.6.10|70|1099511627776
+`, string(snmprec(variables)))
+}
+
+func TestVariable_validate(t *testing.T) {
+	require.NoError(t, Integer("1.3.6.1.2.1.2.1.0", 2).validate())
+	require.NoError(t, ObjectIdentifier("1.3.6.1.2.1.1.2.0", ".1.3.6.1.4.1.9").validate())
+	require.ErrorContains(t, Integer("sysUpTime.0", 2).validate(), `invalid oid "sysUpTime.0"`)
+	require.ErrorContains(t, ObjectIdentifier("1.3.6.1.2.1.1.2.0", "cisco").validate(), "invalid object identifier")
+}
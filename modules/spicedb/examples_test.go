@@ -0,0 +1,46 @@
+package spicedb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/spicedb"
+)
+
+func ExampleRun() {
+	// runSpiceDBContainer {
+	ctx := context.Background()
+
+	spicedbContainer, err := spicedb.Run(ctx, "authzed/spicedb:v1.35.0",
+		spicedb.WithSchema(`
+definition user {}
+
+definition document {
+	relation viewer: user
+	permission view = viewer
+}`),
+		spicedb.WithRelationships("document:readme#viewer@user:alice"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := spicedbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	allowed, err := spicedbContainer.CheckPermission(ctx, "document:readme", "view", "user:alice")
+	if err != nil {
+		log.Fatalf("failed to check permission: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(allowed)
+
+	// Output:
+	// true
+}
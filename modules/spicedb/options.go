@@ -0,0 +1,79 @@
+package spicedb
+
+import (
+	"os"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultPresharedKey = "spicedb-secret"
+)
+
+type options struct {
+	presharedKey  string
+	schema        string
+	schemaFile    string
+	relationships []string
+}
+
+func defaultOptions() options {
+	return options{
+		presharedKey: defaultPresharedKey,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the SpiceDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPresharedKey sets the preshared key used as the bearer token of the gRPC and HTTP APIs.
+// The default is spicedb-secret.
+func WithPresharedKey(key string) Option {
+	return func(o *options) {
+		o.presharedKey = key
+	}
+}
+
+// WithSchema writes the given schema, in the zed schema language, once the container is ready.
+func WithSchema(schema string) Option {
+	return func(o *options) {
+		o.schema = schema
+		o.schemaFile = ""
+	}
+}
+
+// WithSchemaFile writes the schema in the given .zed file once the container is ready.
+func WithSchemaFile(path string) Option {
+	return func(o *options) {
+		o.schema = ""
+		o.schemaFile = path
+	}
+}
+
+// WithRelationships writes the given relationships once the schema is written. The relationships
+// use the resource#relation@subject format of zed, e.g. document:readme#viewer@user:alice,
+// or document:readme#viewer@group:eng#member.
+func WithRelationships(relationships ...string) Option {
+	return func(o *options) {
+		o.relationships = append(o.relationships, relationships...)
+	}
+}
+
+// readSchema returns the schema set with the options, if any.
+func (o options) readSchema() (string, error) {
+	if o.schemaFile != "" {
+		bs, err := os.ReadFile(o.schemaFile)
+		return string(bs), err
+	}
+
+	return o.schema, nil
+}
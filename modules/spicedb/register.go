@@ -0,0 +1,18 @@
+package spicedb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "spicedb",
+		DefaultImage: "authzed/spicedb:v1.35.0",
+		ExposedPorts: []string{string(grpcPort), string(httpPort)},
+		Options: map[string]any{
+			"WithPresharedKey":  WithPresharedKey,
+			"WithRelationships": WithRelationships,
+			"WithSchema":        WithSchema,
+			"WithSchemaFile":    WithSchemaFile,
+		},
+		Run: modules.Runner(Run),
+	})
+}
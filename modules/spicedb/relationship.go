@@ -0,0 +1,73 @@
+package spicedb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// objectReference is a reference to an object in the HTTP API.
+type objectReference struct {
+	ObjectType string `json:"objectType"`
+	ObjectID   string `json:"objectId"`
+}
+
+// subjectReference is a reference to a subject, optionally with a relation, in the HTTP API.
+type subjectReference struct {
+	Object           objectReference `json:"object"`
+	OptionalRelation string          `json:"optionalRelation,omitempty"`
+}
+
+// relationship is a relationship in the HTTP API.
+type relationship struct {
+	Resource objectReference  `json:"resource"`
+	Relation string           `json:"relation"`
+	Subject  subjectReference `json:"subject"`
+}
+
+// parseRelationship parses a relationship in the resource#relation@subject format used by zed,
+// e.g. document:readme#viewer@user:alice, or document:readme#viewer@group:eng#member.
+func parseRelationship(s string) (relationship, error) {
+	resourceRelation, subject, ok := strings.Cut(s, "@")
+	if !ok {
+		return relationship{}, fmt.Errorf("invalid relationship %q: missing subject", s)
+	}
+
+	resource, relation, ok := strings.Cut(resourceRelation, "#")
+	if !ok || relation == "" {
+		return relationship{}, fmt.Errorf("invalid relationship %q: missing relation", s)
+	}
+
+	resourceRef, err := parseObject(resource)
+	if err != nil {
+		return relationship{}, fmt.Errorf("invalid relationship %q: %w", s, err)
+	}
+
+	subjectRef, err := parseSubject(subject)
+	if err != nil {
+		return relationship{}, fmt.Errorf("invalid relationship %q: %w", s, err)
+	}
+
+	return relationship{Resource: resourceRef, Relation: relation, Subject: subjectRef}, nil
+}
+
+// parseSubject parses a subject in the type:id or type:id#relation format.
+func parseSubject(s string) (subjectReference, error) {
+	object, relation, _ := strings.Cut(s, "#")
+
+	ref, err := parseObject(object)
+	if err != nil {
+		return subjectReference{}, err
+	}
+
+	return subjectReference{Object: ref, OptionalRelation: relation}, nil
+}
+
+// parseObject parses an object in the type:id format.
+func parseObject(s string) (objectReference, error) {
+	objectType, objectID, ok := strings.Cut(s, ":")
+	if !ok || objectType == "" || objectID == "" {
+		return objectReference{}, fmt.Errorf("invalid object %q: expected type:id", s)
+	}
+
+	return objectReference{ObjectType: objectType, ObjectID: objectID}, nil
+}
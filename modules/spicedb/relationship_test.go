@@ -0,0 +1,40 @@
+package spicedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelationship(t *testing.T) {
+	t.Run("subject", func(t *testing.T) {
+		rel, err := parseRelationship("document:readme#viewer@user:alice")
+		require.NoError(t, err)
+		require.Equal(t, relationship{
+			Resource: objectReference{ObjectType: "document", ObjectID: "readme"},
+			Relation: "viewer",
+			Subject:  subjectReference{Object: objectReference{ObjectType: "user", ObjectID: "alice"}},
+		}, rel)
+	})
+
+	t.Run("subject-relation", func(t *testing.T) {
+		rel, err := parseRelationship("document:readme#viewer@group:eng#member")
+		require.NoError(t, err)
+		require.Equal(t, subjectReference{
+			Object:           objectReference{ObjectType: "group", ObjectID: "eng"},
+			OptionalRelation: "member",
+		}, rel.Subject)
+	})
+
+	for _, invalid := range []string{
+		"document:readme#viewer",
+		"document:readme@user:alice",
+		"document#viewer@user:alice",
+		"document:readme#viewer@alice",
+	} {
+		t.Run(invalid, func(t *testing.T) {
+			_, err := parseRelationship(invalid)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package spicedb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	grpcPort = nat.Port("50051/tcp")
+	httpPort = nat.Port("8443/tcp")
+
+	hasPermission = "PERMISSIONSHIP_HAS_PERMISSION"
+)
+
+// SpiceDBContainer represents the SpiceDB container type used in the module
+type SpiceDBContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// PresharedKey returns the preshared key used as the bearer token of the gRPC and HTTP APIs.
+func (c *SpiceDBContainer) PresharedKey() string {
+	return c.settings.presharedKey
+}
+
+// GRPCEndpoint returns the host and port of the gRPC API, e.g. localhost:50051, to be used
+// as the endpoint of the authzed clients, with insecure credentials and the preshared key as bearer token.
+func (c *SpiceDBContainer) GRPCEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, grpcPort, "")
+}
+
+// HTTPEndpoint returns the URL of the HTTP API, e.g. http://localhost:8443.
+func (c *SpiceDBContainer) HTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, httpPort, "http")
+}
+
+// CheckPermission returns whether the subject has the permission on the resource, fully consistent
+// with the relationships written so far. The resource uses the type:id format, e.g. document:readme,
+// and the subject the type:id or type:id#relation format, e.g. user:alice or group:eng#member.
+func (c *SpiceDBContainer) CheckPermission(ctx context.Context, resource string, permission string, subject string) (bool, error) {
+	resourceRef, err := parseObject(resource)
+	if err != nil {
+		return false, err
+	}
+
+	subjectRef, err := parseSubject(subject)
+	if err != nil {
+		return false, err
+	}
+
+	body := map[string]any{
+		"consistency": map[string]bool{"fullyConsistent": true},
+		"resource":    resourceRef,
+		"permission":  permission,
+		"subject":     subjectRef,
+	}
+
+	var result struct {
+		Permissionship string `json:"permissionship"`
+	}
+	if err := c.apiRequest(ctx, "/v1/permissions/check", body, &result); err != nil {
+		return false, fmt.Errorf("check permission: %w", err)
+	}
+
+	return result.Permissionship == hasPermission, nil
+}
+
+// Run creates an instance of the SpiceDB container type, using the in-memory datastore.
+// Once the container is ready, it writes the schema and the relationships set with the options.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*SpiceDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		Cmd:          []string{"serve", "--datastore-engine=memory", "--http-enabled"},
+		Env:          map[string]string{},
+		ExposedPorts: []string{string(grpcPort), string(httpPort)},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("grpc server started serving"),
+			wait.ForLog("http server started serving"),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	genericContainerReq.Env["SPICEDB_GRPC_PRESHARED_KEY"] = settings.presharedKey
+
+	schema, err := settings.readSchema()
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+
+	relationships := make([]relationship, 0, len(settings.relationships))
+	for _, r := range settings.relationships {
+		rel, err := parseRelationship(r)
+		if err != nil {
+			return nil, err
+		}
+		relationships = append(relationships, rel)
+	}
+
+	c := &SpiceDBContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx, schema, relationships)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+	c.Container = container
+
+	return c, nil
+}
+
+// bootstrap writes the schema and the relationships using the HTTP API.
+func (c *SpiceDBContainer) bootstrap(ctx context.Context, schema string, relationships []relationship) error {
+	if schema != "" {
+		if err := c.apiRequest(ctx, "/v1/schema/write", map[string]string{"schema": schema}, nil); err != nil {
+			return fmt.Errorf("write schema: %w", err)
+		}
+	}
+
+	if len(relationships) == 0 {
+		return nil
+	}
+
+	updates := make([]map[string]any, 0, len(relationships))
+	for _, r := range relationships {
+		updates = append(updates, map[string]any{"operation": "OPERATION_TOUCH", "relationship": r})
+	}
+
+	if err := c.apiRequest(ctx, "/v1/relationships/write", map[string]any{"updates": updates}, nil); err != nil {
+		return fmt.Errorf("write relationships: %w", err)
+	}
+
+	return nil
+}
+
+// apiRequest posts the JSON body to the HTTP API, using the preshared key as bearer token,
+// and decodes the JSON response into out if it's not nil.
+func (c *SpiceDBContainer) apiRequest(ctx context.Context, path string, body any, out any) error {
+	endpoint, err := c.HTTPEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.settings.presharedKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,66 @@
+package spicedb_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/spicedb"
+)
+
+const testImage = "authzed/spicedb:v1.35.0"
+
+func TestSpiceDB(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := spicedb.Run(ctx, testImage,
+		// withSchema {
+		spicedb.WithSchemaFile(filepath.Join("testdata", "schema.zed")),
+		spicedb.WithRelationships(
+			"document:readme#viewer@user:alice",
+			"document:readme#editor@user:bob",
+		),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	for _, tc := range []struct {
+		permission string
+		subject    string
+		allowed    bool
+	}{
+		{permission: "view", subject: "user:alice", allowed: true},
+		{permission: "edit", subject: "user:alice", allowed: false},
+		{permission: "view", subject: "user:bob", allowed: true},
+		{permission: "view", subject: "user:carol", allowed: false},
+	} {
+		// checkPermission {
+		allowed, err := ctr.CheckPermission(ctx, "document:readme", tc.permission, tc.subject)
+		// }
+		require.NoError(t, err)
+		require.Equalf(t, tc.allowed, allowed, "%s %s", tc.subject, tc.permission)
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		// httpEndpoint {
+		endpoint, err := ctr.HTTPEndpoint(ctx)
+		// }
+		require.NoError(t, err)
+
+		resp, err := http.Post(endpoint+"/v1/schema/read", "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestSpiceDBInvalidRelationship(t *testing.T) {
+	_, err := spicedb.Run(context.Background(), testImage,
+		spicedb.WithRelationships("document:readme@user:alice"),
+	)
+	require.ErrorContains(t, err, "missing relation")
+}
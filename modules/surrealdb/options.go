@@ -0,0 +1,59 @@
+package surrealdb
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultNamespace = "test"
+	defaultDatabase  = "test"
+)
+
+type options struct {
+	namespace   string
+	database    string
+	initScripts []string
+}
+
+func defaultOptions() options {
+	return options{
+		namespace: defaultNamespace,
+		database:  defaultDatabase,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the SurrealDB container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithNamespace sets the namespace used by the init scripts, and returned by the Namespace method.
+// If it is not specified, then the "test" namespace will be used.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithDatabase sets the database used by the init scripts, and returned by the Database method.
+// If it is not specified, then the "test" database will be used.
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithInitScripts sets the SurrealQL scripts to be imported, in order, into the namespace and database
+// once the container is ready.
+func WithInitScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.initScripts = append(o.initScripts, scripts...)
+	}
+}
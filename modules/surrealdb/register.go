@@ -0,0 +1,22 @@
+package surrealdb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "surrealdb",
+		DefaultImage: "surrealdb/surrealdb:v1.1.1",
+		ExposedPorts: []string{"8000/tcp"},
+		Options: map[string]any{
+			"WithAllowAllCaps":   WithAllowAllCaps,
+			"WithAuthentication": WithAuthentication,
+			"WithDatabase":       WithDatabase,
+			"WithInitScripts":    WithInitScripts,
+			"WithNamespace":      WithNamespace,
+			"WithPassword":       WithPassword,
+			"WithStrictMode":     WithStrictMode,
+			"WithUsername":       WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -3,18 +3,40 @@ package surrealdb
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"path/filepath"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // SurrealDBContainer represents the SurrealDB container type used in the module
 type SurrealDBContainer struct {
 	testcontainers.Container
+	namespace string
+	database  string
 }
 
-// ConnectionString returns the connection string for the OpenLDAP container
+// Namespace returns the namespace set with WithNamespace.
+func (c *SurrealDBContainer) Namespace() string {
+	return c.namespace
+}
+
+// Database returns the database set with WithDatabase.
+func (c *SurrealDBContainer) Database() string {
+	return c.database
+}
+
+// ConnectionString returns the websocket URL to connect to the SurrealDB API. It's the same as URL,
+// named as in the rest of the database modules.
+func (c *SurrealDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	return c.URL(ctx)
+}
+
+// URL returns the websocket URL to connect to the SurrealDB API, in the ws://host:port/rpc format
 func (c *SurrealDBContainer) URL(ctx context.Context) (string, error) {
 	containerPort, err := c.MappedPort(ctx, "8000/tcp")
 	if err != nil {
@@ -109,16 +131,70 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	settings := defaultOptions()
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, fmt.Errorf("customize: %w", err)
 		}
 	}
 
+	if len(settings.initScripts) > 0 {
+		var files []string
+		for _, script := range settings.initScripts {
+			cf := testcontainers.ContainerFile{
+				HostFilePath:      script,
+				ContainerFilePath: "/" + filepath.Base(script),
+				FileMode:          0o644,
+			}
+			genericContainerReq.Files = append(genericContainerReq.Files, cf)
+			files = append(files, cf.ContainerFilePath)
+		}
+
+		env := genericContainerReq.Env
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return importScripts(ctx, c, env["SURREAL_USER"], env["SURREAL_PASS"], settings, files...)
+				},
+			},
+		})
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &SurrealDBContainer{Container: container}, nil
+	return &SurrealDBContainer{Container: container, namespace: settings.namespace, database: settings.database}, nil
+}
+
+// importScripts imports the SurrealQL scripts into the namespace and database of the settings,
+// using the surreal binary in the container.
+func importScripts(ctx context.Context, c testcontainers.Container, user string, pass string, settings options, files ...string) error {
+	for _, f := range files {
+		cmd := []string{
+			"/surreal", "import",
+			"--conn", "http://localhost:8000",
+			"--user", user,
+			"--pass", pass,
+			"--ns", settings.namespace,
+			"--db", settings.database,
+			f,
+		}
+
+		code, r, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("import %s: %w", f, err)
+		}
+
+		if code != 0 {
+			output, _ := io.ReadAll(r)
+			return fmt.Errorf("import %s: exit code %d: %s", f, code, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
 }
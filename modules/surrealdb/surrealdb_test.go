@@ -2,6 +2,7 @@ package surrealdb
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/surrealdb/surrealdb.go"
@@ -134,3 +135,52 @@ func TestSurrealDBWithAuth(t *testing.T) {
 		t.Fatal("marketing is not true")
 	}
 }
+
+func TestSurrealDBWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	// withInitScripts {
+	container, err := Run(ctx, "surrealdb/surrealdb:v1.1.1",
+		WithUsername("admin"),
+		WithPassword("secret"),
+		WithNamespace("app"),
+		WithDatabase("people"),
+		WithInitScripts(filepath.Join("testdata", "init.surql")),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	url, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := surrealdb.New(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Use(container.Namespace(), container.Database()); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := db.Select("person")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resultData := result.([]any)[0].(map[string]interface{})
+	if resultData["title"] != "Founder & CEO" {
+		t.Fatal("title is not Founder & CEO")
+	}
+}
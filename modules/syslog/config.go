@@ -0,0 +1,36 @@
+package syslog
+
+const (
+	configPath   = "/etc/syslog-ng/syslog-ng.conf"
+	messagesPath = "/var/log/testcontainers/messages.jsonl"
+)
+
+// config returns the configuration of syslog-ng, receiving the messages on the network and writing them
+// to the messages file, one JSON object per line.
+func config() []byte {
+	return []byte(`@version: 4.8
+
+options {
+    keep-hostname(yes);
+    use-dns(no);
+    stats(freq(0));
+};
+
+source s_network {
+    network(transport("udp") port(514));
+    network(transport("tcp") port(514));
+    syslog(transport("tcp") port(601));
+};
+
+destination d_messages {
+    file("` + messagesPath + `"
+        template("$(format-json timestamp=${ISODATE} host=${HOST} facility=${FACILITY} severity=${LEVEL} program=${PROGRAM} pid=${PID} msgid=${MSGID} message=${MESSAGE})\n")
+    );
+};
+
+log {
+    source(s_network);
+    destination(d_messages);
+};
+`)
+}
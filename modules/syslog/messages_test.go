@@ -0,0 +1,51 @@
+package syslog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessages(t *testing.T) {
+	messages, err := parseMessages(strings.NewReader(
+		`{"timestamp":"2024-06-01T10:00:00+00:00","host":"web-1","facility":"local0","severity":"err","program":"orders","pid":"42","message":"payment failed"}
+{"timestamp":"2024-06-01T10:00:01+02:00","host":"web-2","facility":"user","severity":"info","program":"orders","msgid":"ID47","message":"order created"}
+
+{"timestamp":"2024-06-01T10:00:02+00:00","host":"web-1","message":"trunc`))
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	// the locations of the parsed timestamps differ from the locations of the expected ones
+	require.True(t, messages[0].Timestamp.Equal(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)))
+	require.True(t, messages[1].Timestamp.Equal(time.Date(2024, 6, 1, 8, 0, 1, 0, time.UTC)))
+	messages[0].Timestamp = time.Time{}
+	messages[1].Timestamp = time.Time{}
+
+	require.Equal(t, []Message{
+		{
+			Hostname: "web-1",
+			Facility: "local0",
+			Severity: "err",
+			Program:  "orders",
+			PID:      "42",
+			Message:  "payment failed",
+		},
+		{
+			Hostname: "web-2",
+			Facility: "user",
+			Severity: "info",
+			Program:  "orders",
+			MsgID:    "ID47",
+			Message:  "order created",
+		},
+	}, messages)
+
+	messages, err = parseMessages(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, messages)
+
+	_, err = parseMessages(strings.NewReader("not json\n"))
+	require.ErrorContains(t, err, "parse message")
+}
@@ -0,0 +1,12 @@
+package syslog
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "syslog",
+		DefaultImage: "balabit/syslog-ng:4.8.0",
+		ExposedPorts: []string{UDPPort, TCPPort, RFC5424Port},
+		Run:          modules.Runner(Run),
+	})
+}
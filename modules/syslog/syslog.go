@@ -0,0 +1,162 @@
+package syslog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// UDPPort is the UDP port receiving the messages in the BSD format of RFC 3164.
+	UDPPort = "514/udp"
+	// TCPPort is the TCP port receiving the messages in the BSD format of RFC 3164, one message per line.
+	TCPPort = "514/tcp"
+	// RFC5424Port is the TCP port receiving the messages in the format of RFC 5424, framed as described in RFC 6587.
+	RFC5424Port = "601/tcp"
+)
+
+// Message is a message received by the syslog server.
+type Message struct {
+	Timestamp time.Time `json:"timestamp"`
+	Hostname  string    `json:"host"`
+	// Facility is the name of the facility of the message, e.g. user or local0.
+	Facility string `json:"facility"`
+	// Severity is the name of the severity of the message, e.g. err or info.
+	Severity string `json:"severity"`
+	// Program is the tag of the message in RFC 3164, or its app name in RFC 5424.
+	Program string `json:"program"`
+	PID     string `json:"pid"`
+	MsgID   string `json:"msgid"`
+	Message string `json:"message"`
+}
+
+// SyslogContainer represents the syslog container type used in the module
+type SyslogContainer struct {
+	testcontainers.Container
+}
+
+// UDPAddress returns the host and the UDP port receiving the messages from the host, e.g. localhost:32768.
+func (c *SyslogContainer) UDPAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, UDPPort, "")
+}
+
+// TCPAddress returns the host and the TCP port receiving the messages in the BSD format from the host,
+// e.g. localhost:32769.
+func (c *SyslogContainer) TCPAddress(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, TCPPort, "")
+}
+
+// RFC5424Address returns the host and the TCP port receiving the messages in the format of RFC 5424
+// from the host, e.g. localhost:32770.
+func (c *SyslogContainer) RFC5424Address(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, RFC5424Port, "")
+}
+
+// Messages returns the messages received by the server, in the order of their reception.
+func (c *SyslogContainer) Messages(ctx context.Context) ([]Message, error) {
+	r, err := c.CopyFileFromContainer(ctx, messagesPath)
+	if err != nil {
+		return nil, fmt.Errorf("copy messages: %w", err)
+	}
+	defer r.Close()
+
+	return parseMessages(r)
+}
+
+// WaitForMessage polls the messages received by the server until one of them matches, returning it,
+// or until the context is done.
+func (c *SyslogContainer) WaitForMessage(ctx context.Context, match func(Message) bool) (Message, error) {
+	for {
+		messages, err := c.Messages(ctx)
+		if err != nil {
+			return Message{}, err
+		}
+
+		for _, m := range messages {
+			if match(m) {
+				return m, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return Message{}, fmt.Errorf("wait for message: %w", ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// parseMessages parses the messages file, skipping the last line if it's not complete yet.
+func parseMessages(r io.Reader) ([]Message, error) {
+	messages := []Message{}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return messages, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read messages: %w", err)
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var m Message
+		if err := json.Unmarshal(line, &m); err != nil {
+			return nil, fmt.Errorf("parse message %q: %w", line, err)
+		}
+		messages = append(messages, m)
+	}
+}
+
+// Run creates an instance of the syslog container type, a syslog-ng server receiving the messages on the
+// UDP and TCP ports, whose messages are returned by the Messages method.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*SyslogContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: []string{UDPPort, TCPPort, RFC5424Port},
+		Files: []testcontainers.ContainerFile{
+			{Reader: bytes.NewReader(config()), ContainerFilePath: configPath, FileMode: 0o644},
+			// the file exists before the first message, so the messages can be read right away
+			{Reader: bytes.NewReader(nil), ContainerFilePath: messagesPath, FileMode: 0o644},
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(TCPPort),
+			wait.ForListeningPort(RFC5424Port),
+			wait.ForListeningPort(UDPPort),
+		).WithDeadline(time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *SyslogContainer
+	if container != nil {
+		c = &SyslogContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,95 @@
+package syslog_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/syslog"
+)
+
+const testImage = "balabit/syslog-ng:4.8.0"
+
+// send sends a message to the given address, with the given network.
+func send(t *testing.T, network string, address string, message string) {
+	t.Helper()
+
+	conn, err := net.Dial(network, address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(message))
+	require.NoError(t, err)
+}
+
+func TestSyslog(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := syslog.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	messages, err := ctr.Messages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+
+	// udpAddress {
+	udpAddress, err := ctr.UDPAddress(ctx)
+	// }
+	require.NoError(t, err)
+	send(t, "udp", udpAddress, "<131>Jun  1 10:00:00 web-1 orders[42]: payment failed")
+
+	tcpAddress, err := ctr.TCPAddress(ctx)
+	require.NoError(t, err)
+	send(t, "tcp", tcpAddress, "<14>Jun  1 10:00:01 web-2 orders[43]: order created\n")
+
+	rfc5424Address, err := ctr.RFC5424Address(ctx)
+	require.NoError(t, err)
+	message := "<165>1 2024-06-01T10:00:02.000Z web-3 billing 44 ID47 - invoice sent"
+	send(t, "tcp", rfc5424Address, fmt.Sprintf("%d %s", len(message), message))
+
+	// waitForMessage {
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	m, err := ctr.WaitForMessage(waitCtx, func(m syslog.Message) bool {
+		return m.Program == "billing"
+	})
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "web-3", m.Hostname)
+	require.Equal(t, "local4", m.Facility)
+	require.Equal(t, "notice", m.Severity)
+	require.Equal(t, "44", m.PID)
+	require.Equal(t, "ID47", m.MsgID)
+	require.Equal(t, "invoice sent", m.Message)
+
+	require.Eventually(t, func() bool {
+		messages, err := ctr.Messages(ctx)
+		return err == nil && len(messages) == 3
+	}, 10*time.Second, 200*time.Millisecond)
+
+	// messages {
+	messages, err = ctr.Messages(ctx)
+	// }
+	require.NoError(t, err)
+
+	byHost := map[string]syslog.Message{}
+	for _, m := range messages {
+		byHost[m.Hostname] = m
+	}
+
+	require.Equal(t, "local0", byHost["web-1"].Facility)
+	require.Equal(t, "err", byHost["web-1"].Severity)
+	require.Equal(t, "orders", byHost["web-1"].Program)
+	require.Equal(t, "42", byHost["web-1"].PID)
+	require.Equal(t, "payment failed", byHost["web-1"].Message)
+
+	require.Equal(t, "user", byHost["web-2"].Facility)
+	require.Equal(t, "info", byHost["web-2"].Severity)
+	require.Equal(t, "order created", byHost["web-2"].Message)
+}
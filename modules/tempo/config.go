@@ -0,0 +1,28 @@
+package tempo
+
+const configPath = "/etc/tempo.yaml"
+
+// config is the configuration of Tempo, receiving the traces with OTLP and storing them on the local file system.
+// The receivers must listen on all the interfaces to be reached through the mapped ports.
+const config = `stream_over_http_enabled: true
+server:
+  http_listen_port: 3200
+  log_level: warn
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+        http:
+          endpoint: 0.0.0.0:4318
+ingester:
+  max_block_duration: 5m
+storage:
+  trace:
+    backend: local
+    wal:
+      path: /tmp/tempo/wal
+    local:
+      path: /tmp/tempo/blocks
+`
@@ -0,0 +1,12 @@
+package tempo
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "tempo",
+		DefaultImage: "grafana/tempo:2.5.0",
+		ExposedPorts: []string{QueryPort, OTLPGRPCPort, OTLPHTTPPort},
+		Run:          modules.Runner(Run),
+	})
+}
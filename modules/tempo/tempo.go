@@ -0,0 +1,210 @@
+package tempo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// QueryPort is the port of the HTTP API of Tempo, to query the traces.
+	QueryPort = "3200/tcp"
+	// OTLPGRPCPort is the port of the OTLP receiver over gRPC.
+	OTLPGRPCPort = "4317/tcp"
+	// OTLPHTTPPort is the port of the OTLP receiver over HTTP.
+	OTLPHTTPPort = "4318/tcp"
+)
+
+// TempoContainer represents the Tempo container type used in the module. It runs Tempo in its single binary mode,
+// receiving the traces with OTLP and storing them on the file system of the container.
+type TempoContainer struct {
+	testcontainers.Container
+}
+
+// QueryURL returns the URL of the HTTP API of Tempo, e.g. http://localhost:3200, e.g. to be used
+// as the URL of a Tempo data source of Grafana.
+func (c *TempoContainer) QueryURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, QueryPort, "http")
+}
+
+// OTLPGRPCEndpoint returns the address of the OTLP receiver over gRPC, e.g. localhost:4317.
+func (c *TempoContainer) OTLPGRPCEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("host: %w", err)
+	}
+
+	port, err := c.MappedPort(ctx, OTLPGRPCPort)
+	if err != nil {
+		return "", fmt.Errorf("mapped port: %w", err)
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// OTLPHTTPEndpoint returns the URL of the OTLP receiver over HTTP, e.g. http://localhost:4318.
+func (c *TempoContainer) OTLPHTTPEndpoint(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, OTLPHTTPPort, "http")
+}
+
+// OTLPEnv returns the environment variables making the OpenTelemetry SDKs of a container export their traces
+// to Tempo over HTTP, e.g. with testcontainers.WithEnv. Tempo is reached with its IP address, on the default
+// bridge network.
+func (c *TempoContainer) OTLPEnv(ctx context.Context) (map[string]string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container ip: %w", err)
+	}
+
+	return map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://" + net.JoinHostPort(ip, strings.TrimSuffix(OTLPHTTPPort, "/tcp")),
+		"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+		"OTEL_TRACES_EXPORTER":        "otlp",
+	}, nil
+}
+
+// Search returns the traces of the last hour matching the TraceQL query, e.g. { span.http.status_code >= 500 }.
+func (c *TempoContainer) Search(ctx context.Context, traceQL string) ([]TraceSummary, error) {
+	end := time.Now()
+	values := url.Values{
+		"q":     {traceQL},
+		"start": {strconv.FormatInt(end.Add(-time.Hour).Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix()+1, 10)},
+	}
+
+	var resp apiSearch
+	if err := c.query(ctx, "/api/search?"+values.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("search %s: %w", traceQL, err)
+	}
+
+	return resp.summaries(), nil
+}
+
+// FindTraces returns the traces of the last hour with a span of the service, and of the operation if not empty.
+func (c *TempoContainer) FindTraces(ctx context.Context, service string, operation string) ([]TraceSummary, error) {
+	return c.Search(ctx, traceQL(service, operation))
+}
+
+// Trace returns the trace of the ID, in hexadecimal.
+func (c *TempoContainer) Trace(ctx context.Context, traceID string) (Trace, error) {
+	var resp apiTrace
+	if err := c.query(ctx, "/api/traces/"+url.PathEscape(traceID), &resp); err != nil {
+		return Trace{}, fmt.Errorf("trace %s: %w", traceID, err)
+	}
+
+	return resp.trace(traceID), nil
+}
+
+// WaitForTrace waits until a trace of the last hour has a span of the service, and of the operation if not empty,
+// returning it, or until the context is done.
+func (c *TempoContainer) WaitForTrace(ctx context.Context, service string, operation string) (Trace, error) {
+	for {
+		summaries, err := c.FindTraces(ctx, service, operation)
+		if err != nil {
+			return Trace{}, err
+		}
+
+		if len(summaries) > 0 {
+			return c.Trace(ctx, summaries[0].TraceID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return Trace{}, fmt.Errorf("wait for trace of %s: %w", service, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// query sends a GET request to the HTTP API, decoding the JSON response into out.
+func (c *TempoContainer) query(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := testcontainers.HTTPClient(c, QueryPort).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.New("not found")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// traceQL returns the TraceQL query of the spans of the service, and of the operation if not empty.
+func traceQL(service string, operation string) string {
+	q := "resource.service.name = " + strconv.Quote(service)
+	if operation != "" {
+		q += " && name = " + strconv.Quote(operation)
+	}
+
+	return "{ " + q + " }"
+}
+
+// Run creates an instance of the Tempo container type, receiving the traces with OTLP and storing them
+// on the file system of the container.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*TempoContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		Cmd:          []string{"-config.file=" + configPath},
+		ExposedPorts: []string{QueryPort, OTLPGRPCPort, OTLPHTTPPort},
+		Files: []testcontainers.ContainerFile{
+			{Reader: strings.NewReader(config), ContainerFilePath: configPath, FileMode: 0o644},
+		},
+		// the ingester is ready 15 seconds after it started
+		WaitingFor: wait.ForAll(
+			wait.ForHTTP("/ready").WithPort(QueryPort),
+			wait.ForListeningPort(OTLPGRPCPort),
+			wait.ForListeningPort(OTLPHTTPPort),
+		).WithDeadline(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *TempoContainer
+	if container != nil {
+		c = &TempoContainer{Container: container}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
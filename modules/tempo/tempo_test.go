@@ -0,0 +1,84 @@
+package tempo_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/tempo"
+)
+
+// traces are two spans of a trace of the checkout service, in the OTLP JSON encoding.
+const traces = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+"scopeSpans":[{"scope":{"name":"tests"},"spans":[
+{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174","name":"GET /cart","kind":2,
+"startTimeUnixNano":"%d","endTimeUnixNano":"%d","attributes":[{"key":"http.status_code","value":{"intValue":"500"}}]},
+{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b175","parentSpanId":"eee19b7ec3c1b174","name":"SELECT carts","kind":3,
+"startTimeUnixNano":"%d","endTimeUnixNano":"%d"}]}]}]}`
+
+func TestTempo(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := tempo.Run(ctx, "grafana/tempo:2.5.0")
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	endpoint, err := ctr.OTLPHTTPEndpoint(ctx)
+	require.NoError(t, err)
+
+	payload := fillTimes(traces, time.Now().UnixNano())
+
+	resp, err := http.Post(endpoint+"/v1/traces", "application/json", strings.NewReader(payload))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	// waitForTrace {
+	trace, err := ctr.WaitForTrace(waitCtx, "checkout", "GET /cart")
+	require.NoError(t, err)
+	// }
+	require.Equal(t, "5b8efff798038103d269b633813fc60c", trace.TraceID)
+	require.Len(t, trace.Spans, 2)
+
+	root, ok := trace.RootSpan()
+	require.True(t, ok)
+	require.Equal(t, "GET /cart", root.Name)
+	require.Equal(t, "eee19b7ec3c1b174", root.SpanID)
+
+	t.Run("search", func(t *testing.T) {
+		summaries, err := ctr.Search(ctx, "{ span.http.status_code >= 500 }")
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		require.Equal(t, "checkout", summaries[0].RootServiceName)
+
+		summaries, err = ctr.FindTraces(ctx, "orders", "")
+		require.NoError(t, err)
+		require.Empty(t, summaries)
+	})
+
+	t.Run("trace-not-found", func(t *testing.T) {
+		_, err := ctr.Trace(ctx, "00000000000000000000000000000001")
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+// fillTimes sets the start and end times of the spans of the payload, relative to now.
+func fillTimes(payload string, now int64) string {
+	for _, offset := range []int64{0, 250, 100, 200} {
+		payload = strings.Replace(payload, "%d", strconv.FormatInt(now+offset*int64(time.Millisecond), 10), 1)
+	}
+
+	return payload
+}
@@ -0,0 +1,191 @@
+package tempo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// TraceSummary is a trace found by a search, with its root span.
+type TraceSummary struct {
+	TraceID         string
+	RootServiceName string
+	RootTraceName   string
+	StartTime       time.Time
+	Duration        time.Duration
+}
+
+// Trace is a trace stored by Tempo.
+type Trace struct {
+	TraceID string
+	Spans   []Span
+}
+
+// Span is a span of a trace.
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	ServiceName  string
+	// Kind is the kind of the span, e.g. SPAN_KIND_SERVER.
+	Kind       string
+	StartTime  time.Time
+	EndTime    time.Time
+	Attributes map[string]any
+}
+
+// RootSpan returns the span of the trace without parent, if any.
+func (t Trace) RootSpan() (Span, bool) {
+	for _, s := range t.Spans {
+		if s.ParentSpanID == "" {
+			return s, true
+		}
+	}
+
+	return Span{}, false
+}
+
+// SpansNamed returns the spans of the trace with the name.
+func (t Trace) SpansNamed(name string) []Span {
+	var spans []Span
+	for _, s := range t.Spans {
+		if s.Name == name {
+			spans = append(spans, s)
+		}
+	}
+
+	return spans
+}
+
+// The types of the JSON responses of the query API.
+type (
+	apiSearch struct {
+		Traces []struct {
+			TraceID           string      `json:"traceID"`
+			RootServiceName   string      `json:"rootServiceName"`
+			RootTraceName     string      `json:"rootTraceName"`
+			StartTimeUnixNano json.Number `json:"startTimeUnixNano"`
+			DurationMs        int64       `json:"durationMs"`
+		} `json:"traces"`
+	}
+
+	apiTrace struct {
+		Batches []struct {
+			Resource struct {
+				Attributes []apiKeyValue `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []apiSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"batches"`
+	}
+
+	apiSpan struct {
+		TraceID           string        `json:"traceId"`
+		SpanID            string        `json:"spanId"`
+		ParentSpanID      string        `json:"parentSpanId"`
+		Name              string        `json:"name"`
+		Kind              string        `json:"kind"`
+		StartTimeUnixNano json.Number   `json:"startTimeUnixNano"`
+		EndTimeUnixNano   json.Number   `json:"endTimeUnixNano"`
+		Attributes        []apiKeyValue `json:"attributes"`
+	}
+
+	apiKeyValue struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue *string     `json:"stringValue"`
+			BoolValue   *bool       `json:"boolValue"`
+			IntValue    json.Number `json:"intValue"`
+			DoubleValue *float64    `json:"doubleValue"`
+		} `json:"value"`
+	}
+)
+
+// summaries returns the traces of the search response.
+func (s apiSearch) summaries() []TraceSummary {
+	summaries := make([]TraceSummary, 0, len(s.Traces))
+	for _, t := range s.Traces {
+		summaries = append(summaries, TraceSummary{
+			TraceID:         t.TraceID,
+			RootServiceName: t.RootServiceName,
+			RootTraceName:   t.RootTraceName,
+			StartTime:       unixNano(t.StartTimeUnixNano),
+			Duration:        time.Duration(t.DurationMs) * time.Millisecond,
+		})
+	}
+
+	return summaries
+}
+
+// trace returns the trace of the response, with the IDs in hexadecimal.
+func (t apiTrace) trace(traceID string) Trace {
+	trace := Trace{TraceID: traceID}
+	for _, b := range t.Batches {
+		resource := attributes(b.Resource.Attributes)
+		service, _ := resource["service.name"].(string)
+
+		for _, ss := range b.ScopeSpans {
+			for _, s := range ss.Spans {
+				trace.Spans = append(trace.Spans, Span{
+					TraceID:      hexID(s.TraceID),
+					SpanID:       hexID(s.SpanID),
+					ParentSpanID: hexID(s.ParentSpanID),
+					Name:         s.Name,
+					ServiceName:  service,
+					Kind:         s.Kind,
+					StartTime:    unixNano(s.StartTimeUnixNano),
+					EndTime:      unixNano(s.EndTimeUnixNano),
+					Attributes:   attributes(s.Attributes),
+				})
+			}
+		}
+	}
+
+	return trace
+}
+
+// hexID returns the ID in hexadecimal, as the IDs of the traces and of the spans, of 16 and 8 bytes,
+// are encoded in base64 by the query API. The other IDs are returned as is.
+func hexID(id string) string {
+	b, err := base64.StdEncoding.DecodeString(id)
+	if err != nil || (len(b) != 16 && len(b) != 8) {
+		return id
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// attributes returns the attributes as a map of strings, bools, int64 and float64.
+func attributes(kvs []apiKeyValue) map[string]any {
+	m := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		v := kv.Value
+		switch {
+		case v.StringValue != nil:
+			m[kv.Key] = *v.StringValue
+		case v.BoolValue != nil:
+			m[kv.Key] = *v.BoolValue
+		case v.IntValue != "":
+			i, _ := v.IntValue.Int64()
+			m[kv.Key] = i
+		case v.DoubleValue != nil:
+			m[kv.Key] = *v.DoubleValue
+		}
+	}
+
+	return m
+}
+
+// unixNano returns the time of the nanoseconds since the epoch, encoded as a string.
+func unixNano(n json.Number) time.Time {
+	ns, err := strconv.ParseInt(string(n), 10, 64)
+	if err != nil || ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns)
+}
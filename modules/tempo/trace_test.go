@@ -0,0 +1,68 @@
+package tempo
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISearch_summaries(t *testing.T) {
+	data, err := os.ReadFile("testdata/search.json")
+	require.NoError(t, err)
+
+	var resp apiSearch
+	require.NoError(t, json.Unmarshal(data, &resp))
+
+	require.Equal(t, []TraceSummary{{
+		TraceID:         "5b8efff798038103d269b633813fc60c",
+		RootServiceName: "checkout",
+		RootTraceName:   "GET /cart",
+		StartTime:       time.Unix(0, 1700000000000000000),
+		Duration:        250 * time.Millisecond,
+	}}, resp.summaries())
+}
+
+func TestAPITrace_trace(t *testing.T) {
+	data, err := os.ReadFile("testdata/trace.json")
+	require.NoError(t, err)
+
+	var resp apiTrace
+	require.NoError(t, json.Unmarshal(data, &resp))
+
+	trace := resp.trace("5b8efff798038103d269b633813fc60c")
+	require.Len(t, trace.Spans, 2)
+
+	require.Equal(t, Span{
+		TraceID:      "5b8efff798038103d269b633813fc60c",
+		SpanID:       "eee19b7ec3c1b175",
+		ParentSpanID: "eee19b7ec3c1b174",
+		Name:         "SELECT carts",
+		ServiceName:  "checkout",
+		Kind:         "SPAN_KIND_CLIENT",
+		StartTime:    time.Unix(0, 1700000000100000000),
+		EndTime:      time.Unix(0, 1700000000200000000),
+		Attributes:   map[string]any{"db.system": "postgresql", "error": true, "db.rows": 1.5},
+	}, trace.Spans[1])
+
+	root, ok := trace.RootSpan()
+	require.True(t, ok)
+	require.Equal(t, "GET /cart", root.Name)
+	require.Equal(t, int64(200), root.Attributes["http.status_code"])
+
+	require.Len(t, trace.SpansNamed("SELECT carts"), 1)
+	require.Empty(t, trace.SpansNamed("POST /orders"))
+}
+
+func TestHexID(t *testing.T) {
+	require.Equal(t, "eee19b7ec3c1b174", hexID("7uGbfsPBsXQ="))
+	require.Equal(t, "5b8efff798038103d269b633813fc60c", hexID("5b8efff798038103d269b633813fc60c"))
+	require.Equal(t, "", hexID(""))
+}
+
+func TestTraceQL(t *testing.T) {
+	require.Equal(t, `{ resource.service.name = "checkout" }`, traceQL("checkout", ""))
+	require.Equal(t, `{ resource.service.name = "checkout" && name = "GET \"/cart\"" }`, traceQL("checkout", `GET "/cart"`))
+}
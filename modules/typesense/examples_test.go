@@ -0,0 +1,39 @@
+package typesense_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/typesense"
+)
+
+func ExampleRun() {
+	// runTypesenseContainer {
+	ctx := context.Background()
+
+	typesenseContainer, err := typesense.Run(ctx, "typesense/typesense:26.0",
+		typesense.WithAPIKey("secret"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := typesenseContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := typesenseContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,67 @@
+package typesense
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultAPIKey = "xyz"
+)
+
+// Field is a field of the schema of a collection. The fields follow the schema of the Typesense API.
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Facet    bool   `json:"facet,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Sort     *bool  `json:"sort,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+}
+
+// Collection is a collection created once the container is ready.
+// The fields follow the schema of the Typesense API.
+type Collection struct {
+	Name                string  `json:"name"`
+	Fields              []Field `json:"fields"`
+	DefaultSortingField string  `json:"default_sorting_field,omitempty"`
+	// Documents are imported into the collection once it's created.
+	Documents []any `json:"-"`
+}
+
+type options struct {
+	apiKey      string
+	collections []Collection
+}
+
+func defaultOptions() options {
+	return options{
+		apiKey: defaultAPIKey,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Typesense container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithAPIKey sets the admin API key of the server. The default is xyz.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithCollections creates the given collections, in order, once the container is ready,
+// importing their documents.
+func WithCollections(collections ...Collection) Option {
+	return func(o *options) {
+		o.collections = append(o.collections, collections...)
+	}
+}
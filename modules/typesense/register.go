@@ -0,0 +1,16 @@
+package typesense
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "typesense",
+		DefaultImage: "typesense/typesense:26.0",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithAPIKey":      WithAPIKey,
+			"WithCollections": WithCollections,
+		},
+		Run: modules.Runner(Run),
+	})
+}
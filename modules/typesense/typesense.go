@@ -0,0 +1,194 @@
+package typesense
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "8108/tcp"
+
+	apiKeyHeader = "X-TYPESENSE-API-KEY"
+)
+
+// TypesenseContainer represents the Typesense container type used in the module
+type TypesenseContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// APIKey returns the admin API key of the server.
+func (c *TypesenseContainer) APIKey() string {
+	return c.settings.apiKey
+}
+
+// ServerURL returns the URL of the API of the server, e.g. http://localhost:8108,
+// to be used as the server URL of the Typesense clients, with the API key.
+func (c *TypesenseContainer) ServerURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// Run creates an instance of the Typesense container type. Once the container is ready,
+// it creates the collections set with the options, importing their documents.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*TypesenseContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"TYPESENSE_DATA_DIR": "/tmp",
+		},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor: wait.ForHTTP("/health").
+			WithPort(defaultHTTPPort).
+			WithResponseMatcher(isHealthy),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	genericContainerReq.Env["TYPESENSE_API_KEY"] = settings.apiKey
+
+	c := &TypesenseContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.bootstrap(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+	c.Container = container
+
+	return c, nil
+}
+
+// isHealthy returns true if the health endpoint reports that the server is ready to serve requests.
+func isHealthy(body io.Reader) bool {
+	var health struct {
+		OK bool `json:"ok"`
+	}
+	if err := json.NewDecoder(body).Decode(&health); err != nil {
+		return false
+	}
+
+	return health.OK
+}
+
+// bootstrap creates the collections and imports their documents.
+func (c *TypesenseContainer) bootstrap(ctx context.Context) error {
+	for _, col := range c.settings.collections {
+		body, err := json.Marshal(col)
+		if err != nil {
+			return fmt.Errorf("marshal collection %s: %w", col.Name, err)
+		}
+
+		if _, err := c.apiRequest(ctx, http.MethodPost, "/collections", "application/json", bytes.NewReader(body)); err != nil {
+			return fmt.Errorf("create collection %s: %w", col.Name, err)
+		}
+
+		if len(col.Documents) == 0 {
+			continue
+		}
+
+		if err := c.importDocuments(ctx, col); err != nil {
+			return fmt.Errorf("import documents into collection %s: %w", col.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// importDocuments imports the documents of the collection using the JSONL import endpoint,
+// which answers with one result per document.
+func (c *TypesenseContainer) importDocuments(ctx context.Context, col Collection) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, doc := range col.Documents {
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	path := "/collections/" + url.PathEscape(col.Name) + "/documents/import?action=create"
+	resp, err := c.apiRequest(ctx, http.MethodPost, path, "text/plain", &buf)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, line := range strings.Split(strings.TrimSpace(string(resp)), "\n") {
+		var result struct {
+			Success bool   `json:"success"`
+			Error   string `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			return err
+		}
+
+		if !result.Success {
+			errs = append(errs, errors.New(result.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// apiRequest sends a request to the API of the server with the admin API key,
+// returning the body of the response.
+func (c *TypesenseContainer) apiRequest(ctx context.Context, method string, path string, contentType string, body io.Reader) ([]byte, error) {
+	serverURL, err := c.ServerURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, serverURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(apiKeyHeader, c.settings.apiKey)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
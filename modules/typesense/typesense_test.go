@@ -0,0 +1,83 @@
+package typesense_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/typesense"
+)
+
+const testImage = "typesense/typesense:26.0"
+
+type book struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Year  int32  `json:"year"`
+}
+
+func TestTypesense(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := typesense.Run(ctx, testImage,
+		// withCollections {
+		typesense.WithAPIKey("secret"),
+		typesense.WithCollections(typesense.Collection{
+			Name: "books",
+			Fields: []typesense.Field{
+				{Name: "title", Type: "string"},
+				{Name: "year", Type: "int32", Facet: true},
+			},
+			DefaultSortingField: "year",
+			Documents: []any{
+				book{ID: "1", Title: "The Hobbit", Year: 1937},
+				book{ID: "2", Title: "Dune", Year: 1965},
+			},
+		}),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	require.Equal(t, "secret", ctr.APIKey())
+
+	// serverURL {
+	serverURL, err := ctr.ServerURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/collections/books/documents/search?q=dune&query_by=title", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-TYPESENSE-API-KEY", ctr.APIKey())
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Found int `json:"found"`
+		Hits  []struct {
+			Document book `json:"document"`
+		} `json:"hits"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, 1, result.Found)
+	require.Equal(t, "Dune", result.Hits[0].Document.Title)
+}
+
+func TestTypesenseInvalidDocuments(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := typesense.Run(ctx, testImage,
+		typesense.WithCollections(typesense.Collection{
+			Name:      "books",
+			Fields:    []typesense.Field{{Name: "year", Type: "int32"}},
+			Documents: []any{map[string]any{"year": "not a number"}},
+		}),
+	)
+	require.ErrorContains(t, err, "import documents into collection books")
+}
@@ -0,0 +1,39 @@
+package unleash_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/modules/unleash"
+)
+
+func ExampleRun() {
+	// runUnleashContainer {
+	ctx := context.Background()
+
+	unleashContainer, err := unleash.Run(ctx, "unleashorg/unleash-server:6.1",
+		unleash.WithFlags(unleash.Flag{Name: "new-checkout", Enabled: true}),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container and its database
+	defer func() {
+		if err := unleashContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := unleashContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
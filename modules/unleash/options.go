@@ -0,0 +1,81 @@
+package unleash
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	defaultPostgresImage = "docker.io/postgres:16-alpine"
+	defaultAdminToken    = "*:*.testcontainers-admin-token"
+	defaultClientToken   = "default:development.testcontainers-client-token"
+)
+
+// Flag is a feature flag created in the default project once the container is ready.
+type Flag struct {
+	// Name is the name of the flag.
+	Name string
+	// Description is the description of the flag.
+	Description string
+	// Enabled enables the flag in the development environment, which is the environment
+	// of the client token.
+	Enabled bool
+}
+
+type options struct {
+	postgresImage string
+	adminToken    string
+	clientToken   string
+	flags         []Flag
+}
+
+func defaultOptions() options {
+	return options{
+		postgresImage: defaultPostgresImage,
+		adminToken:    defaultAdminToken,
+		clientToken:   defaultClientToken,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Unleash container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithPostgresImage sets the image of the Postgres container used as the database of Unleash.
+// The default is docker.io/postgres:16-alpine.
+func WithPostgresImage(img string) Option {
+	return func(o *options) {
+		o.postgresImage = img
+	}
+}
+
+// WithAdminToken sets the admin API token created when the server starts,
+// in the *:*.secret format. It's used to create the flags.
+func WithAdminToken(token string) Option {
+	return func(o *options) {
+		o.adminToken = token
+	}
+}
+
+// WithClientToken sets the client API token created when the server starts, used by the
+// backend SDKs, in the project:environment.secret format.
+// The default token has access to the development environment of the default project.
+func WithClientToken(token string) Option {
+	return func(o *options) {
+		o.clientToken = token
+	}
+}
+
+// WithFlags creates the given feature flags in the default project once the container is ready.
+func WithFlags(flags ...Flag) Option {
+	return func(o *options) {
+		o.flags = append(o.flags, flags...)
+	}
+}
@@ -0,0 +1,18 @@
+package unleash
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "unleash",
+		DefaultImage: "unleashorg/unleash-server:6.1",
+		ExposedPorts: []string{defaultHTTPPort},
+		Options: map[string]any{
+			"WithAdminToken":    WithAdminToken,
+			"WithClientToken":   WithClientToken,
+			"WithFlags":         WithFlags,
+			"WithPostgresImage": WithPostgresImage,
+		},
+		Run: modules.Runner(Run),
+	})
+}
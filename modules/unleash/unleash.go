@@ -0,0 +1,224 @@
+package unleash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultHTTPPort = "4242/tcp"
+
+	postgresAlias = "unleash-db"
+	databaseURL   = "postgres://unleash:unleash@" + postgresAlias + ":5432/unleash"
+
+	defaultProject     = "default"
+	defaultEnvironment = "development"
+)
+
+// UnleashContainer represents the Unleash container type used in the module.
+// The Postgres container used as its database is terminated with it.
+type UnleashContainer struct {
+	testcontainers.Container
+	postgres testcontainers.Container
+	network  *testcontainers.DockerNetwork
+	settings options
+}
+
+// AdminToken returns the admin API token.
+func (c *UnleashContainer) AdminToken() string {
+	return c.settings.adminToken
+}
+
+// ClientToken returns the client API token, to be used as the API token of the backend SDKs.
+func (c *UnleashContainer) ClientToken() string {
+	return c.settings.clientToken
+}
+
+// URL returns the URL of the server, e.g. http://localhost:4242.
+func (c *UnleashContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
+}
+
+// APIURL returns the URL of the API of the server, e.g. http://localhost:4242/api,
+// to be used as the URL of the backend SDKs.
+func (c *UnleashContainer) APIURL(ctx context.Context) (string, error) {
+	u, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/api", nil
+}
+
+// Terminate terminates the Unleash container, its Postgres container and their network.
+func (c *UnleashContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	var errs []error
+	if c.Container != nil {
+		errs = append(errs, c.Container.Terminate(ctx, opts...))
+	}
+
+	if c.postgres != nil {
+		errs = append(errs, c.postgres.Terminate(ctx, opts...))
+	}
+
+	if c.network != nil {
+		errs = append(errs, c.network.Remove(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run creates an instance of the Unleash container type. It starts a Postgres container as its database,
+// both attached to a new network, and creates the API tokens when the server starts.
+// Once the server is ready, it creates the flags set with the options.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*UnleashContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Env: map[string]string{
+			"DATABASE_URL": databaseURL,
+			"DATABASE_SSL": "false",
+		},
+		ExposedPorts: []string{defaultHTTPPort},
+		WaitingFor:   wait.ForHTTP("/health").WithPort(defaultHTTPPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
+	c := &UnleashContainer{settings: settings}
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new network: %w", err)
+	}
+	c.network = nw
+
+	c.postgres, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: settings.postgresImage,
+			Env: map[string]string{
+				"POSTGRES_USER":     "unleash",
+				"POSTGRES_PASSWORD": "unleash",
+				"POSTGRES_DB":       "unleash",
+			},
+			Networks:       []string{nw.Name},
+			NetworkAliases: map[string][]string{nw.Name: {postgresAlias}},
+			WaitingFor:     wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("run postgres: %w", err), c.Terminate(ctx))
+	}
+
+	if err := network.WithNetwork([]string{"unleash"}, nw).Customize(&genericContainerReq); err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, errors.Join(err, c.Terminate(ctx))
+		}
+	}
+
+	genericContainerReq.Env["INIT_ADMIN_API_TOKENS"] = settings.adminToken
+	genericContainerReq.Env["INIT_CLIENT_API_TOKENS"] = settings.clientToken
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.createFlags(ctx)
+			},
+		},
+	})
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	c.Container = container
+	if err != nil {
+		return nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	return c, nil
+}
+
+// createFlags creates the flags in the default project using the admin API,
+// enabling them in the development environment.
+func (c *UnleashContainer) createFlags(ctx context.Context) error {
+	featuresPath := "/api/admin/projects/" + defaultProject + "/features"
+
+	for _, f := range c.settings.flags {
+		body := map[string]any{"name": f.Name, "description": f.Description}
+		if err := c.adminRequest(ctx, featuresPath, body); err != nil {
+			return fmt.Errorf("create flag %s: %w", f.Name, err)
+		}
+
+		if !f.Enabled {
+			continue
+		}
+
+		path := featuresPath + "/" + url.PathEscape(f.Name) + "/environments/" + defaultEnvironment + "/on"
+		if err := c.adminRequest(ctx, path, nil); err != nil {
+			return fmt.Errorf("enable flag %s: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// adminRequest posts the JSON body, if any, to the admin API using the admin token.
+func (c *UnleashContainer) adminRequest(ctx context.Context, path string, body any) error {
+	serverURL, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serverURL+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.settings.adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package unleash_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/unleash"
+)
+
+const testImage = "unleashorg/unleash-server:6.1"
+
+func TestUnleash(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := unleash.Run(ctx, testImage,
+		// withFlags {
+		unleash.WithFlags(
+			unleash.Flag{Name: "new-checkout", Enabled: true},
+			unleash.Flag{Name: "dark-mode", Description: "Dark mode for the UI"},
+		),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+
+	// apiURL {
+	apiURL, err := ctr.APIURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	// the client API returns the flags of the environment of the client token
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/client/features", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", ctr.ClientToken())
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Features []struct {
+			Name    string `json:"name"`
+			Enabled bool   `json:"enabled"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	flags := map[string]bool{}
+	for _, f := range result.Features {
+		flags[f.Name] = f.Enabled
+	}
+	require.Equal(t, map[string]bool{"new-checkout": true, "dark-mode": false}, flags)
+}
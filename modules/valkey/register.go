@@ -0,0 +1,17 @@
+package valkey
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "valkey",
+		DefaultImage: "docker.io/valkey/valkey:7.2.5",
+		ExposedPorts: []string{"6379/tcp"},
+		Options: map[string]any{
+			"WithConfigFile":   WithConfigFile,
+			"WithLogLevel":     WithLogLevel,
+			"WithSnapshotting": WithSnapshotting,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,16 @@
+package vault
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "vault",
+		DefaultImage: "hashicorp/vault:1.13.0",
+		ExposedPorts: []string{defaultPort + "/tcp"},
+		Options: map[string]any{
+			"WithInitCommand": WithInitCommand,
+			"WithToken":       WithToken,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,12 @@
+package vearch
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "vearch",
+		DefaultImage: "vearch/vearch:3.5.1",
+		ExposedPorts: []string{"8817/tcp", "9001/tcp"},
+		Run:          modules.Runner(Run),
+	})
+}
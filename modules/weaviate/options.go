@@ -0,0 +1,48 @@
+package weaviate
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Property is a property of a class. The fields follow the schema of the Weaviate API.
+type Property struct {
+	Name     string   `json:"name"`
+	DataType []string `json:"dataType"`
+}
+
+// Class is a class, also known as collection, created once the container is ready.
+// The fields follow the schema of the Weaviate API. The number of dimensions of the vectors
+// of a class is not part of its schema: it's set by the first object stored with a vector.
+type Class struct {
+	Name        string `json:"class"`
+	Description string `json:"description,omitempty"`
+	// Vectorizer is the module creating the vectors of the objects. If it is not specified,
+	// then none will be used, and the vectors must be provided with the objects.
+	Vectorizer string `json:"vectorizer"`
+	// VectorIndexType is the type of the vector index, e.g. hnsw or flat.
+	VectorIndexType string     `json:"vectorIndexType,omitempty"`
+	Properties      []Property `json:"properties,omitempty"`
+}
+
+type options struct {
+	classes []Class
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Weaviate container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithClasses creates the given classes, in order, once the container is ready.
+func WithClasses(classes ...Class) Option {
+	return func(o *options) {
+		o.classes = append(o.classes, classes...)
+	}
+}
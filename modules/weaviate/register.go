@@ -0,0 +1,15 @@
+package weaviate
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "weaviate",
+		DefaultImage: "semitechnologies/weaviate:1.25.5",
+		ExposedPorts: []string{httpPort, grpcPort},
+		Options: map[string]any{
+			"WithClasses": WithClasses,
+		},
+		Run: modules.Runner(Run),
+	})
+}
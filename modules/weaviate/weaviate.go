@@ -1,8 +1,13 @@
 package weaviate
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -17,6 +22,7 @@ const (
 // WeaviateContainer represents the Weaviate container type used in the module
 type WeaviateContainer struct {
 	testcontainers.Container
+	settings options
 }
 
 // Deprecated: use Run instead
@@ -25,7 +31,8 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return Run(ctx, "semitechnologies/weaviate:1.25.5", opts...)
 }
 
-// Run creates an instance of the Weaviate container type
+// Run creates an instance of the Weaviate container type. Once the container is ready,
+// it creates the classes set with the options.
 func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*WeaviateContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        img,
@@ -47,18 +54,77 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	c := &WeaviateContainer{settings: settings}
+
+	genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, container testcontainers.Container) error {
+				c.Container = container
+				return c.createClasses(ctx)
+			},
+		},
+	})
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
 	}
+	c.Container = container
+
+	return c, nil
+}
+
+// createClasses creates the classes using the schema endpoint of the REST API.
+func (c *WeaviateContainer) createClasses(ctx context.Context) error {
+	if len(c.settings.classes) == 0 {
+		return nil
+	}
+
+	scheme, host, err := c.HttpHostAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, class := range c.settings.classes {
+		if class.Vectorizer == "" {
+			class.Vectorizer = "none"
+		}
+
+		body, err := json.Marshal(class)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+"://"+host+"/v1/schema", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("create class %s: %w", class.Name, err)
+		}
+
+		msg, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("create class %s: unexpected status code %d: %s", class.Name, resp.StatusCode, strings.TrimSpace(string(msg)))
+		}
+	}
 
-	return &WeaviateContainer{Container: container}, nil
+	return nil
 }
 
 // HttpHostAddress returns the schema and host of the Weaviate container.
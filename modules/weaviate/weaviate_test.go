@@ -99,3 +99,47 @@ func TestWeaviate(t *testing.T) {
 		}
 	})
 }
+
+func TestWeaviateWithClasses(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := weaviate.Run(ctx, "semitechnologies/weaviate:1.25.5",
+		// withClasses {
+		weaviate.WithClasses(weaviate.Class{
+			Name: "Document",
+			Properties: []weaviate.Property{
+				{Name: "title", DataType: []string{"text"}},
+			},
+		}),
+		// }
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	httpScheme, httpHost, err := container.HttpHostAddress(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := wvt.NewClient(wvt.Config{Scheme: httpScheme, Host: httpHost})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	class, err := client.Schema().ClassGetter().WithClassName("Document").Do(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if class.Vectorizer != "none" || len(class.Properties) != 1 || class.Properties[0].Name != "title" {
+		t.Fatalf("unexpected class: %+v", class)
+	}
+}
@@ -0,0 +1,44 @@
+package yugabytedb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go/modules/yugabytedb"
+)
+
+func ExampleRun() {
+	// runYugabyteDBContainer {
+	ctx := context.Background()
+
+	yugabyteContainer, err := yugabytedb.Run(ctx,
+		"yugabytedb/yugabyte:2.20.7.1-b10",
+		yugabytedb.WithDatabase("app"),
+		yugabytedb.WithUsername("app_user"),
+		yugabytedb.WithPassword("app_password"),
+		yugabytedb.WithInitScripts(filepath.Join("testdata", "init.sql")),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := yugabyteContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	// }
+
+	state, err := yugabyteContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,32 @@
+package yugabytedb
+
+import (
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// ysqlshCmd runs the SQL script in $0 with ysqlsh, using the database and credentials of the container.
+	ysqlshCmd = `PGPASSWORD="$YSQL_PASSWORD" bin/ysqlsh -h "$(hostname)" -U "$YSQL_USER" -d "$YSQL_DB" -v ON_ERROR_STOP=1 -f "$0"`
+
+	// ycqlshCmd runs the CQL script in $0 with ycqlsh, using the credentials of the container.
+	ycqlshCmd = `bin/ycqlsh "$(hostname)" -u "$YCQL_USER" -p "$YCQL_PASSWORD" -f "$0"`
+)
+
+type initScript struct {
+	testcontainers.ExecOptions
+	File string
+}
+
+func (i initScript) AsCommand() []string {
+	switch {
+	case strings.HasSuffix(i.File, ".sql"):
+		return []string{"/bin/sh", "-c", ysqlshCmd, i.File}
+	case strings.HasSuffix(i.File, ".cql"):
+		return []string{"/bin/sh", "-c", ycqlshCmd, i.File}
+	case strings.HasSuffix(i.File, ".sh"):
+		return []string{"/bin/sh", i.File}
+	}
+	return nil
+}
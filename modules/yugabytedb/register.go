@@ -0,0 +1,19 @@
+package yugabytedb
+
+import "github.com/testcontainers/testcontainers-go/modules"
+
+func init() {
+	modules.Register(modules.Module{
+		Name:         "yugabytedb",
+		DefaultImage: "yugabytedb/yugabyte:2.20.7.1-b10",
+		ExposedPorts: []string{string(ysqlPort), string(ycqlPort)},
+		Options: map[string]any{
+			"WithDatabase":    WithDatabase,
+			"WithInitScripts": WithInitScripts,
+			"WithKeyspace":    WithKeyspace,
+			"WithPassword":    WithPassword,
+			"WithUsername":    WithUsername,
+		},
+		Run: modules.Runner(Run),
+	})
+}
@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS test_table (id bigint PRIMARY KEY, name text);
+INSERT INTO test_table (id, name) VALUES (1, 'NAME');
@@ -0,0 +1,207 @@
+package yugabytedb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	ysqlPort = nat.Port("5433/tcp")
+	ycqlPort = nat.Port("9042/tcp")
+
+	defaultUser     = "yugabyte"
+	defaultPassword = "yugabyte"
+	defaultDatabase = "yugabyte"
+	defaultKeyspace = "yugabyte"
+)
+
+// YugabyteDBContainer represents the YugabyteDB container type used in the module
+type YugabyteDBContainer struct {
+	testcontainers.Container
+	dbName   string
+	user     string
+	password string
+}
+
+// MustConnectionString panics if the address cannot be determined.
+func (c *YugabyteDBContainer) MustConnectionString(ctx context.Context, args ...string) string {
+	addr, err := c.ConnectionString(ctx, args...)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// ConnectionString returns the connection string for the YSQL API of the YugabyteDB container, using the default
+// 5433 port, and obtaining the host and exposed port from the container. It also accepts a variadic list of extra
+// arguments which will be appended to the connection string, e.g. "sslmode=disable".
+func (c *YugabyteDBContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	containerPort, err := c.MappedPort(ctx, ysqlPort)
+	if err != nil {
+		return "", err
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	extraArgs := strings.Join(args, "&")
+	connStr := fmt.Sprintf("postgres://%s:%s@%s/%s?%s", c.user, c.password, net.JoinHostPort(host, containerPort.Port()), c.dbName, extraArgs)
+	return connStr, nil
+}
+
+// ConnectionDetails returns the details to connect to the YSQL API of the container,
+// implementing testcontainers.ConnectionSource.
+func (c *YugabyteDBContainer) ConnectionDetails(ctx context.Context) (*testcontainers.ConnectionDetails, error) {
+	details, err := testcontainers.NewConnectionDetails(ctx, c, "postgres", ysqlPort)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Username = c.user
+	details.Password = c.password
+	details.Database = c.dbName
+	details.Params.Set("sslmode", "disable")
+
+	return details, nil
+}
+
+// ConnectionHost returns the host and port of the YCQL API of the YugabyteDB container, using the default 9042 port,
+// and obtaining the host and exposed port from the container
+func (c *YugabyteDBContainer) ConnectionHost(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, ycqlPort)
+	if err != nil {
+		return "", err
+	}
+
+	return host + ":" + port.Port(), nil
+}
+
+// WithDatabase sets the initial YSQL database to be created when the container starts.
+// If it is not specified, then the default yugabyte database will be used.
+func WithDatabase(dbName string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Env["YSQL_DB"] = dbName
+
+		return nil
+	}
+}
+
+// WithKeyspace sets the initial YCQL keyspace to be created when the container starts.
+// If it is not specified, then the default yugabyte keyspace will be used.
+func WithKeyspace(keyspace string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Env["YCQL_KEYSPACE"] = keyspace
+
+		return nil
+	}
+}
+
+// WithUsername sets the initial username to be created for both the YSQL and YCQL APIs when the container starts.
+// It is used in conjunction with WithPassword to set a user and its password.
+// If it is not specified, then the default yugabyte user will be used.
+func WithUsername(user string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if user == "" {
+			user = defaultUser
+		}
+
+		req.Env["YSQL_USER"] = user
+		req.Env["YCQL_USER"] = user
+
+		return nil
+	}
+}
+
+// WithPassword sets the initial password of the user to be created when the container starts.
+func WithPassword(password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Env["YSQL_PASSWORD"] = password
+		req.Env["YCQL_PASSWORD"] = password
+
+		return nil
+	}
+}
+
+// WithInitScripts sets the init scripts to be run once the container is ready: *.sql scripts are run with ysqlsh
+// in the initial database, *.cql scripts with ycqlsh, and *.sh scripts with sh.
+func WithInitScripts(scripts ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		var initScripts []testcontainers.ContainerFile
+		var execs []testcontainers.Executable
+		for _, script := range scripts {
+			cf := testcontainers.ContainerFile{
+				HostFilePath:      script,
+				ContainerFilePath: "/docker-entrypoint-initdb.d/" + filepath.Base(script),
+				FileMode:          0o755,
+			}
+			initScripts = append(initScripts, cf)
+
+			execs = append(execs, initScript{File: cf.ContainerFilePath})
+		}
+
+		req.Files = append(req.Files, initScripts...)
+		return testcontainers.WithAfterReadyCommand(execs...)(req)
+	}
+}
+
+// Run creates an instance of the YugabyteDB container type
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*YugabyteDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: img,
+		Cmd:   []string{"bin/yugabyted", "start", "--background=false"},
+		Env: map[string]string{
+			"YSQL_DB":       defaultDatabase,
+			"YSQL_USER":     defaultUser,
+			"YSQL_PASSWORD": defaultPassword,
+			"YCQL_KEYSPACE": defaultKeyspace,
+			"YCQL_USER":     defaultUser,
+			"YCQL_PASSWORD": defaultPassword,
+		},
+		ExposedPorts: []string{string(ysqlPort), string(ycqlPort)},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("YugabyteDB Started"),
+			wait.ForListeningPort(ysqlPort),
+			wait.ForListeningPort(ycqlPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	env := genericContainerReq.Env
+
+	return &YugabyteDBContainer{
+		Container: container,
+		dbName:    env["YSQL_DB"],
+		user:      env["YSQL_USER"],
+		password:  env["YSQL_PASSWORD"],
+	}, nil
+}
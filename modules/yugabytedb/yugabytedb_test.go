@@ -0,0 +1,81 @@
+package yugabytedb_test
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/modules/yugabytedb"
+)
+
+const testImage = "yugabytedb/yugabyte:2.20.7.1-b10"
+
+func TestYugabyteDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := yugabytedb.Run(ctx, testImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	// connectionString {
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	// }
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(connStr, "postgres://yugabyte:yugabyte@"))
+	require.True(t, strings.HasSuffix(connStr, "/yugabyte?sslmode=disable"))
+
+	// connectionHost {
+	connectionHost, err := container.ConnectionHost(ctx)
+	// }
+	require.NoError(t, err)
+	require.NotEmpty(t, connectionHost)
+}
+
+func TestYugabyteDBWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	// withInitScripts {
+	container, err := yugabytedb.Run(ctx, testImage,
+		yugabytedb.WithDatabase("app"),
+		yugabytedb.WithUsername("app_user"),
+		yugabytedb.WithPassword("app_password"),
+		yugabytedb.WithKeyspace("test_keyspace"),
+		yugabytedb.WithInitScripts(
+			filepath.Join("testdata", "init.sql"),
+			filepath.Join("testdata", "init.cql"),
+		),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	connStr, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.Contains(t, connStr, "app_user:app_password@")
+	require.Contains(t, connStr, "/app?")
+
+	ysql := `PGPASSWORD="$YSQL_PASSWORD" bin/ysqlsh -h "$(hostname)" -U "$YSQL_USER" -d "$YSQL_DB" -t -c "SELECT name FROM test_table WHERE id = 1"`
+	require.Contains(t, execSh(t, container, ysql), "NAME")
+
+	ycql := `bin/ycqlsh "$(hostname)" -u "$YCQL_USER" -p "$YCQL_PASSWORD" -e "SELECT name FROM test_keyspace.test_table WHERE id = 1"`
+	require.Contains(t, execSh(t, container, ycql), "NAME")
+}
+
+// execSh executes the command with sh in the container, returning its output.
+func execSh(t *testing.T, container *yugabytedb.YugabyteDBContainer, cmd string) string {
+	t.Helper()
+
+	code, r, err := container.Exec(context.Background(), []string{"/bin/sh", "-c", cmd}, tcexec.Multiplexed())
+	require.NoError(t, err)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Zero(t, code, string(output))
+
+	return string(output)
+}
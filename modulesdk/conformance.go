@@ -0,0 +1,154 @@
+package modulesdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// conformanceLabel is the label added by the conformance suite to check the customizers are applied.
+const conformanceLabel = "org.testcontainers.conformance"
+
+// RunFunc is the Run function of a module, adapted to return a testcontainers.Container.
+//
+// For Example:
+//
+//	run := func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+//		return myservice.Run(ctx, img, opts...)
+//	}
+type RunFunc func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error)
+
+// Conformance runs the conformance test suite against the Run function of a module, using the given image.
+// It checks the module behaves consistently with the official modules:
+//
+//   - the container is started and running when Run returns.
+//   - the container customizers passed to Run are applied to the container request.
+//   - the errors of the options are returned by Run, and no container is started.
+//   - an already cancelled context makes Run fail.
+//   - the container is removed when terminated.
+//
+// It requires a Docker daemon, as any other test of a module.
+func Conformance(t *testing.T, run RunFunc, img string) {
+	t.Helper()
+
+	t.Run("starts-the-container", func(t *testing.T) {
+		ctx := context.Background()
+
+		c, err := run(ctx, img)
+		terminateOnCleanup(t, c)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		state, err := c.State(ctx)
+		if err != nil {
+			t.Fatalf("state: %v", err)
+		}
+
+		if !state.Running {
+			t.Fatalf("expected the container to be running, got status %q", state.Status)
+		}
+	})
+
+	t.Run("applies-customizers", func(t *testing.T) {
+		ctx := context.Background()
+
+		withLabel := testcontainers.CustomizeRequestOption(func(req *testcontainers.GenericContainerRequest) error {
+			if req.Labels == nil {
+				req.Labels = map[string]string{}
+			}
+			req.Labels[conformanceLabel] = "true"
+			return nil
+		})
+
+		c, err := run(ctx, img, withLabel)
+		terminateOnCleanup(t, c)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		inspect, err := c.Inspect(ctx)
+		if err != nil {
+			t.Fatalf("inspect: %v", err)
+		}
+
+		if inspect.Config.Labels[conformanceLabel] != "true" {
+			t.Fatalf("expected the %q label set by a customizer, got labels %v", conformanceLabel, inspect.Config.Labels)
+		}
+	})
+
+	t.Run("returns-option-errors", func(t *testing.T) {
+		errOption := errors.New("conformance option error")
+
+		failing := testcontainers.CustomizeRequestOption(func(*testcontainers.GenericContainerRequest) error {
+			return errOption
+		})
+
+		c, err := run(context.Background(), img, failing)
+		terminateOnCleanup(t, c)
+		if !errors.Is(err, errOption) {
+			t.Fatalf("expected the option error to be returned, got %v", err)
+		}
+
+		if !isNil(c) && c.IsRunning() {
+			t.Fatal("expected no container to be started when an option fails")
+		}
+	})
+
+	t.Run("honours-cancelled-context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		c, err := run(ctx, img)
+		terminateOnCleanup(t, c)
+		if err == nil {
+			t.Fatal("expected an error running with a cancelled context")
+		}
+	})
+
+	t.Run("terminates-the-container", func(t *testing.T) {
+		ctx := context.Background()
+
+		c, err := run(ctx, img)
+		if err != nil {
+			terminateOnCleanup(t, c)
+			t.Fatalf("run: %v", err)
+		}
+
+		if err := c.Terminate(ctx); err != nil {
+			t.Fatalf("terminate: %v", err)
+		}
+
+		if _, err := c.State(ctx); err == nil {
+			t.Fatal("expected the container to be removed when terminated")
+		}
+	})
+}
+
+// terminateOnCleanup terminates the container, if any, when the test finishes.
+func terminateOnCleanup(t *testing.T, c testcontainers.Container) {
+	t.Helper()
+
+	if isNil(c) {
+		return
+	}
+
+	t.Cleanup(func() {
+		// use a fresh context, as the one of the test could be already cancelled
+		_ = c.Terminate(context.Background())
+	})
+}
+
+// isNil returns true if the container is nil, including nil pointers of the container types of the modules,
+// which are not nil once converted to the testcontainers.Container interface.
+func isNil(c testcontainers.Container) bool {
+	if c == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(c)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,155 @@
+// Package modulesdk is a toolkit for module authors, to build modules for Testcontainers for Go,
+// e.g. for internal or proprietary services, that behave consistently with the official modules:
+//
+//   - module-specific options, which are also container customizers, see [Option].
+//   - default image, overridable with an environment variable, see [Definition.ImageEnvVar].
+//   - standard wiring of the container request, options, lifecycle hooks and wait strategy, see [Run].
+//   - a conformance test suite, see [Conformance].
+package modulesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ErrNoImage is returned by Run when no image is passed, and the module has no default image.
+var ErrNoImage = errors.New("no image")
+
+// Option is a module-specific option, applied to the settings of the module, of type S.
+// It satisfies the testcontainers.ContainerCustomizer interface, so module-specific options
+// and generic options can be passed together to the Run function of the module.
+type Option[S any] func(settings *S) error
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option[S]) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// Definition describes a module, with settings of type S. Use a struct{} if the module has no settings.
+type Definition[S any] struct {
+	// Name is the name of the module, used in the errors, e.g. "myservice".
+	Name string
+
+	// DefaultImage is the image used when no image is passed to Run.
+	DefaultImage string
+
+	// ImageEnvVar is the name of an environment variable overriding the default image,
+	// e.g. "MYSERVICE_IMAGE", handy to pin the image of internal services in CI.
+	// The image passed to Run always takes precedence.
+	ImageEnvVar string
+
+	// Request is the base container request of the module: exposed ports, environment, wait strategy, etc.
+	// It's copied on each Run, so the options never modify the definition.
+	Request testcontainers.ContainerRequest
+
+	// Defaults returns the default settings of the module. If nil, the zero value of S is used.
+	Defaults func() S
+
+	// Configure applies the settings to the container request, once all the options are applied.
+	// Use it to translate the module-specific settings into environment variables, commands, files, etc.
+	Configure func(settings S, req *testcontainers.GenericContainerRequest) error
+}
+
+// Container is a container started from a module definition, including the settings of the module
+// after applying the options, to be used by the methods of the module, e.g. to build connection strings.
+type Container[S any] struct {
+	testcontainers.Container
+	Settings S
+}
+
+// Image returns the image to use: the given one if not empty, else the one in the environment
+// variable of the definition, if set, else the default image of the definition.
+func (d Definition[S]) Image(img string) string {
+	if img != "" {
+		return img
+	}
+
+	if d.ImageEnvVar != "" {
+		if img := os.Getenv(d.ImageEnvVar); img != "" {
+			return img
+		}
+	}
+
+	return d.DefaultImage
+}
+
+// Run creates and starts a container for the module, following the same steps as the official modules:
+//
+//  1. it builds the container request from the base request of the definition and the image.
+//  2. it applies the options in order: module-specific options update the settings,
+//     and container customizers update the request.
+//  3. it configures the request with the resulting settings, see [Definition.Configure].
+//  4. it creates and starts the container, waiting for it to be ready.
+//
+// If the container was created but failed to start, it's returned alongside the error,
+// so the caller can terminate it.
+func Run[S any](ctx context.Context, def Definition[S], img string, opts ...testcontainers.ContainerCustomizer) (*Container[S], error) {
+	img = def.Image(img)
+	if img == "" {
+		return nil, fmt.Errorf("%s: %w: pass it to Run, or set a default image", def.Name, ErrNoImage)
+	}
+
+	req := copyRequest(def.Request)
+	req.Image = img
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	var settings S
+	if def.Defaults != nil {
+		settings = def.Defaults()
+	}
+
+	for _, opt := range opts {
+		if apply, ok := opt.(Option[S]); ok {
+			if err := apply(&settings); err != nil {
+				return nil, fmt.Errorf("%s: apply option: %w", def.Name, err)
+			}
+		}
+
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, fmt.Errorf("%s: customize: %w", def.Name, err)
+		}
+	}
+
+	if def.Configure != nil {
+		if err := def.Configure(settings, &genericContainerReq); err != nil {
+			return nil, fmt.Errorf("%s: configure: %w", def.Name, err)
+		}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	var c *Container[S]
+	if container != nil {
+		c = &Container[S]{Container: container, Settings: settings}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("%s: generic container: %w", def.Name, err)
+	}
+
+	return c, nil
+}
+
+// copyRequest returns a copy of the request, copying the maps and slices the options usually modify.
+func copyRequest(req testcontainers.ContainerRequest) testcontainers.ContainerRequest {
+	req.Env = maps.Clone(req.Env)
+	req.Labels = maps.Clone(req.Labels)
+	req.ExposedPorts = slices.Clone(req.ExposedPorts)
+	req.Cmd = slices.Clone(req.Cmd)
+	req.Entrypoint = slices.Clone(req.Entrypoint)
+	req.Files = slices.Clone(req.Files)
+	req.Networks = slices.Clone(req.Networks)
+	req.LifecycleHooks = slices.Clone(req.LifecycleHooks)
+
+	return req
+}
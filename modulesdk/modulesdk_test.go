@@ -0,0 +1,128 @@
+package modulesdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modulesdk"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type nginxSettings struct {
+	workerProcesses string
+}
+
+func withWorkerProcesses(n string) modulesdk.Option[nginxSettings] {
+	return func(s *nginxSettings) error {
+		if n == "" {
+			return errors.New("empty worker processes")
+		}
+		s.workerProcesses = n
+		return nil
+	}
+}
+
+var nginxDefinition = modulesdk.Definition[nginxSettings]{
+	Name:         "nginx",
+	DefaultImage: "nginx:alpine",
+	ImageEnvVar:  "MODULESDK_TEST_NGINX_IMAGE",
+	Request: testcontainers.ContainerRequest{
+		ExposedPorts: []string{"80/tcp"},
+		Env:          map[string]string{"NGINX_ENTRYPOINT_QUIET_LOGS": "1"},
+		WaitingFor:   wait.ForListeningPort("80/tcp"),
+	},
+	Defaults: func() nginxSettings {
+		return nginxSettings{workerProcesses: "auto"}
+	},
+	Configure: func(s nginxSettings, req *testcontainers.GenericContainerRequest) error {
+		req.Cmd = []string{"nginx", "-g", "daemon off; worker_processes " + s.workerProcesses + ";"}
+		return nil
+	},
+}
+
+func TestDefinition_Image(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		require.Equal(t, "nginx:alpine", nginxDefinition.Image(""))
+	})
+
+	t.Run("env-var", func(t *testing.T) {
+		t.Setenv("MODULESDK_TEST_NGINX_IMAGE", "registry.example.com/nginx:alpine")
+		require.Equal(t, "registry.example.com/nginx:alpine", nginxDefinition.Image(""))
+	})
+
+	t.Run("explicit-image-wins", func(t *testing.T) {
+		t.Setenv("MODULESDK_TEST_NGINX_IMAGE", "registry.example.com/nginx:alpine")
+		require.Equal(t, "nginx:1.27", nginxDefinition.Image("nginx:1.27"))
+	})
+}
+
+func TestRun_errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no-image", func(t *testing.T) {
+		def := modulesdk.Definition[struct{}]{Name: "noimage"}
+
+		c, err := modulesdk.Run(ctx, def, "")
+		require.ErrorIs(t, err, modulesdk.ErrNoImage)
+		require.Nil(t, c)
+	})
+
+	t.Run("option-error", func(t *testing.T) {
+		c, err := modulesdk.Run(ctx, nginxDefinition, "", withWorkerProcesses(""))
+		require.ErrorContains(t, err, "empty worker processes")
+		require.Nil(t, c)
+	})
+
+	t.Run("customizer-error", func(t *testing.T) {
+		errCustomize := errors.New("customize error")
+
+		c, err := modulesdk.Run(ctx, nginxDefinition, "", testcontainers.CustomizeRequestOption(func(*testcontainers.GenericContainerRequest) error {
+			return errCustomize
+		}))
+		require.ErrorIs(t, err, errCustomize)
+		require.Nil(t, c)
+	})
+
+	t.Run("configure-error", func(t *testing.T) {
+		errConfigure := errors.New("configure error")
+
+		def := nginxDefinition
+		def.Configure = func(nginxSettings, *testcontainers.GenericContainerRequest) error {
+			return errConfigure
+		}
+
+		c, err := modulesdk.Run(ctx, def, "")
+		require.ErrorIs(t, err, errConfigure)
+		require.Nil(t, c)
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := modulesdk.Run(ctx, nginxDefinition, "", withWorkerProcesses("2"), testcontainers.WithEnv(map[string]string{"FOO": "BAR"}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(ctx))
+	})
+
+	require.Equal(t, "2", c.Settings.workerProcesses)
+
+	inspect, err := c.Inspect(ctx)
+	require.NoError(t, err)
+	require.Contains(t, inspect.Config.Env, "FOO=BAR")
+	require.Contains(t, inspect.Config.Env, "NGINX_ENTRYPOINT_QUIET_LOGS=1")
+
+	// the options never modify the definition
+	require.Equal(t, map[string]string{"NGINX_ENTRYPOINT_QUIET_LOGS": "1"}, nginxDefinition.Request.Env)
+}
+
+func TestConformance(t *testing.T) {
+	modulesdk.Conformance(t, func(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+		return modulesdk.Run(ctx, nginxDefinition, img, opts...)
+	}, "nginx:alpine")
+}
@@ -12,6 +12,7 @@ const (
 var (
 	ErrDuplicateMountTarget = errors.New("duplicate mount target detected")
 	ErrInvalidBindMount     = errors.New("invalid bind mount")
+	ErrInvalidMount         = errors.New("invalid mount")
 )
 
 var (
@@ -0,0 +1,609 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// nerdctlBinary is the name of the nerdctl binary, looked up in the PATH.
+const nerdctlBinary = "nerdctl"
+
+var _ GenericProvider = (*NerdctlProvider)(nil)
+
+// NerdctlProvider is an experimental provider for the hosts running containerd without dockerd,
+// e.g. CI runners, which runs the containers with the nerdctl CLI. nerdctl uses the containerd
+// address and namespace of the CONTAINERD_ADDRESS and CONTAINERD_NAMESPACE environment variables,
+// and CNI for the networking of the containers.
+//
+// The features not supported by nerdctl are detected with the Supports method, and creating a container
+// using them fails with an error wrapping ErrNotSupported. The reaper is not supported, so the containers
+// must be terminated by the tests.
+type NerdctlProvider struct {
+	*GenericProviderOptions
+	binary string
+	config config.Config
+
+	// runtime and snapshotter are the containerd runtime and snapshotter of the containers, if not the default ones.
+	runtime     string
+	snapshotter string
+	// microVM is true if the runtime runs the containers as microVMs, see NewMicroVMProvider.
+	microVM bool
+
+	capabilitiesOnce sync.Once
+	capabilities     map[Capability]bool
+}
+
+// NewNerdctlProvider returns the experimental provider running the containers with the nerdctl CLI,
+// which must be in the PATH.
+func NewNerdctlProvider(opts ...GenericProviderOption) (*NerdctlProvider, error) {
+	o := &GenericProviderOptions{
+		Logger: Logger,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyGenericTo(o)
+	}
+
+	binary, err := exec.LookPath(nerdctlBinary)
+	if err != nil {
+		return nil, fmt.Errorf("look up %s: %w", nerdctlBinary, err)
+	}
+
+	return &NerdctlProvider{
+		GenericProviderOptions: o,
+		binary:                 binary,
+		config:                 config.Read(),
+	}, nil
+}
+
+// command returns the nerdctl command with the given arguments, after the global flags of the provider.
+func (p *NerdctlProvider) command(ctx context.Context, args ...string) *exec.Cmd {
+	if p.snapshotter != "" {
+		args = append([]string{"--snapshotter", p.snapshotter}, args...)
+	}
+
+	return exec.CommandContext(ctx, p.binary, args...)
+}
+
+// run runs nerdctl with the given arguments, returning its standard output,
+// or an error with its standard error if it fails.
+func (p *NerdctlProvider) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := p.command(ctx, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("nerdctl %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// Supports implements CapabilitiesProvider. The pause of the containers is detected from the cgroup
+// driver of containerd, and the bind mounts are supported unless the containers run as microVMs.
+// The rest of the capabilities are not supported by nerdctl.
+func (p *NerdctlProvider) Supports(ctx context.Context, capability Capability) bool {
+	p.capabilitiesOnce.Do(func() {
+		p.capabilities = map[Capability]bool{
+			// the microVMs don't share the filesystem of the host
+			CapabilityBindMounts: !p.microVM,
+		}
+
+		if p.microVM {
+			// the microVMs are not frozen with the cgroup freezer of the host
+			return
+		}
+
+		out, err := p.run(ctx, "info", "--format", "{{json .}}")
+		if err != nil {
+			p.Logger.Printf("🔥 nerdctl info failed, assuming the minimal capabilities: %v", err)
+			return
+		}
+
+		var info struct {
+			CgroupDriver string `json:"CgroupDriver"`
+		}
+		if err := json.Unmarshal(out, &info); err == nil {
+			// the processes of the containers are frozen with the cgroup freezer
+			p.capabilities[CapabilityPause] = info.CgroupDriver != "" && info.CgroupDriver != "none"
+		}
+	})
+
+	return p.capabilities[capability]
+}
+
+// checkCapabilities returns an error wrapping ErrNotSupported if the request uses a feature
+// not supported by nerdctl.
+func (p *NerdctlProvider) checkCapabilities(ctx context.Context, req ContainerRequest) error {
+	required := map[Capability]bool{
+		CapabilityImageBuild:     req.ShouldBuildImage(),
+		CapabilityHostPortAccess: len(req.HostAccessPorts) > 0 || req.AccessToHost,
+		CapabilityNetworkAliases: len(req.NetworkAliases) > 0 || req.EnpointSettingsModifier != nil,
+		CapabilityHealthCheck:    req.HealthCheck != nil,
+		CapabilityStdin:          req.Stdin != nil,
+		CapabilityBindMounts:     len(req.Files) > 0 || hasBindMounts(req.Mounts),
+	}
+
+	var errs []error
+	for _, capability := range []Capability{
+		CapabilityImageBuild, CapabilityHostPortAccess, CapabilityNetworkAliases, CapabilityHealthCheck, CapabilityStdin, CapabilityBindMounts,
+	} {
+		if required[capability] && !p.Supports(ctx, capability) {
+			errs = append(errs, notSupportedError(capability))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// hasBindMounts returns true if any of the mounts is a bind mount of the host.
+func hasBindMounts(mounts ContainerMounts) bool {
+	for _, m := range mounts {
+		if m.Source != nil && m.Source.Type() == MountTypeBind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Close implements ContainerProvider. It's a NOOP, as there is no connection to close.
+func (p *NerdctlProvider) Close() error {
+	return nil
+}
+
+// Health implements ContainerProvider, checking that nerdctl reaches containerd.
+func (p *NerdctlProvider) Health(ctx context.Context) error {
+	_, err := p.run(ctx, "info")
+	return err
+}
+
+// Config implements ContainerProvider.
+func (p *NerdctlProvider) Config() TestcontainersConfig {
+	return TestcontainersConfig{
+		Host:           p.config.Host,
+		TLSVerify:      p.config.TLSVerify,
+		CertPath:       p.config.CertPath,
+		RyukDisabled:   p.config.RyukDisabled,
+		RyukPrivileged: p.config.RyukPrivileged,
+		Config:         p.config,
+	}
+}
+
+// host returns the host where the ports of the containers are exposed, from the host.override property
+// or the TESTCONTAINERS_HOST_OVERRIDE environment variable, or localhost otherwise.
+func (p *NerdctlProvider) host() string {
+	if p.config.HostOverride != "" {
+		return p.config.HostOverride
+	}
+
+	return "localhost"
+}
+
+// CreateContainer implements ContainerProvider, creating the container with nerdctl create.
+// The files of the request are bind mounted in the container, as nerdctl can't copy files
+// to a container that is not running.
+func (p *NerdctlProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkCapabilities(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// the image substitutors are applied to the image, as with the Docker provider
+	imageName := req.Image
+	for _, is := range req.ImageSubstitutors {
+		modifiedTag, err := is.Substitute(imageName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute image %s with %s: %w", imageName, is.Description(), err)
+		}
+
+		if modifiedTag != imageName {
+			p.Logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
+			imageName = modifiedTag
+		}
+	}
+	req.Image = imageName
+
+	if req.Labels == nil {
+		req.Labels = map[string]string{}
+	}
+	for k, v := range core.DefaultLabels(core.SessionID()) {
+		req.Labels[k] = v
+	}
+
+	defaultHooks := []ContainerLifecycleHooks{
+		DefaultLoggingHook(p.Logger),
+		nerdctlLogConsumersHook(req.LogConsumerCfg),
+		nerdctlReadinessHook(),
+	}
+	req.LifecycleHooks = []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)}
+
+	if err := req.creatingHook(ctx); err != nil {
+		return nil, err
+	}
+
+	c := &NerdctlContainer{
+		Image:          imageName,
+		WaitingFor:     req.WaitingFor,
+		provider:       p,
+		sessionID:      core.SessionID(),
+		logger:         req.redactLogger(p.Logger),
+		lifecycleHooks: req.LifecycleHooks,
+		keepOnFailure:  keepOnFailureTest(req.KeepOnFailure),
+	}
+
+	if len(req.Files) > 0 {
+		dir, err := os.MkdirTemp("", "testcontainers-nerdctl")
+		if err != nil {
+			return nil, fmt.Errorf("create files directory: %w", err)
+		}
+		c.filesDir = dir
+	}
+
+	args, err := c.createArgs(req)
+	if err != nil {
+		return nil, errors.Join(err, c.removeFiles())
+	}
+
+	if req.AlwaysPullImage {
+		if err := p.PullImage(ctx, imageName); err != nil {
+			return nil, errors.Join(err, c.removeFiles())
+		}
+	}
+
+	out, err := p.run(ctx, args...)
+	if err != nil {
+		return nil, errors.Join(err, c.removeFiles())
+	}
+	c.ID = strings.TrimSpace(string(out))
+	stats.containersCreated.Add(1)
+
+	if err := c.createdHook(ctx); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// ReuseOrCreateContainer implements ContainerProvider. The reuse of the containers is not supported.
+func (p *NerdctlProvider) ReuseOrCreateContainer(context.Context, ContainerRequest) (Container, error) {
+	return nil, notSupportedError(CapabilityReuse)
+}
+
+// RunContainer implements ContainerProvider, creating and starting the container.
+func (p *NerdctlProvider) RunContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	c, err := p.CreateContainer(ctx, req)
+	if err != nil {
+		return c, err
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return c, fmt.Errorf("%w: could not start container", err)
+	}
+
+	return c, nil
+}
+
+// CreateNetwork implements NetworkProvider, creating a CNI network with nerdctl network create.
+func (p *NerdctlProvider) CreateNetwork(ctx context.Context, req NetworkRequest) (Network, error) {
+	args := []string{"network", "create"}
+	if req.Driver != "" {
+		args = append(args, "--driver", req.Driver)
+	}
+	if req.Internal {
+		args = append(args, "--internal")
+	}
+
+	labels := GenericLabels()
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+	args = append(args, labelArgs(labels)...)
+	args = append(args, req.Name)
+
+	if _, err := p.run(ctx, args...); err != nil {
+		return nil, err
+	}
+
+	return &NerdctlNetwork{Name: req.Name, provider: p}, nil
+}
+
+// GetNetwork implements NetworkProvider, inspecting the network with nerdctl network inspect.
+func (p *NerdctlProvider) GetNetwork(ctx context.Context, req NetworkRequest) (network.Inspect, error) {
+	out, err := p.run(ctx, "network", "inspect", "--mode", "dockercompat", req.Name)
+	if err != nil {
+		return network.Inspect{}, err
+	}
+
+	var networks []network.Inspect
+	if err := json.Unmarshal(out, &networks); err != nil {
+		return network.Inspect{}, fmt.Errorf("decode network: %w", err)
+	}
+
+	if len(networks) == 0 {
+		return network.Inspect{}, fmt.Errorf("network %s not found", req.Name)
+	}
+
+	return networks[0], nil
+}
+
+// ListImages implements ImageProvider.
+func (p *NerdctlProvider) ListImages(ctx context.Context) ([]ImageInfo, error) {
+	out, err := p.run(ctx, "images", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var images []ImageInfo
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var img struct {
+			ID         string `json:"ID"`
+			Repository string `json:"Repository"`
+			Tag        string `json:"Tag"`
+		}
+		if err := dec.Decode(&img); err != nil {
+			return nil, fmt.Errorf("decode image: %w", err)
+		}
+
+		images = append(images, ImageInfo{ID: img.ID, Name: img.Repository + ":" + img.Tag})
+	}
+
+	return images, nil
+}
+
+// SaveImages implements ImageProvider, saving the images to a tar archive.
+func (p *NerdctlProvider) SaveImages(ctx context.Context, output string, images ...string) error {
+	_, err := p.run(ctx, append([]string{"save", "--output", output}, images...)...)
+	return err
+}
+
+// PullImage implements ImageProvider.
+func (p *NerdctlProvider) PullImage(ctx context.Context, img string) error {
+	_, err := p.run(ctx, "pull", "--quiet", img)
+	countResult(err, &stats.imagePulls, &stats.imagePullFailures)
+	return err
+}
+
+// NerdctlNetwork is a network created with the NerdctlProvider.
+type NerdctlNetwork struct {
+	Name     string
+	provider *NerdctlProvider
+}
+
+// Remove implements Network.
+func (n *NerdctlNetwork) Remove(ctx context.Context) error {
+	_, err := n.provider.run(ctx, "network", "rm", n.Name)
+	return err
+}
+
+// createArgs returns the arguments of nerdctl create for the request, applying the modifiers
+// of the Docker configuration of the request, and translating the fields supported by nerdctl.
+func (c *NerdctlContainer) createArgs(req ContainerRequest) ([]string, error) {
+	env := make([]string, 0, len(req.Env))
+	for k, v := range req.Env {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+
+	cfg := &container.Config{
+		Entrypoint: req.Entrypoint,
+		Image:      req.Image,
+		Env:        env,
+		Labels:     req.Labels,
+		Cmd:        req.Cmd,
+		Hostname:   req.Hostname,
+		User:       req.User,
+		WorkingDir: req.WorkingDir,
+	}
+
+	hostConfig := &container.HostConfig{
+		Privileged: req.Privileged,
+		ShmSize:    req.ShmSize,
+		Tmpfs:      req.Tmpfs,
+		Mounts:     mapToDockerMounts(req.Mounts),
+	}
+
+	if req.ConfigModifier != nil {
+		req.ConfigModifier(cfg)
+	}
+
+	if req.HostConfigModifier == nil {
+		req.HostConfigModifier = defaultHostConfigModifier(req)
+	}
+	req.HostConfigModifier(hostConfig)
+
+	args := []string{"create"}
+	if req.Name != "" {
+		args = append(args, "--name", req.Name)
+	}
+	if c.provider.runtime != "" {
+		args = append(args, "--runtime", c.provider.runtime)
+	}
+	if req.ImagePlatform != "" {
+		args = append(args, "--platform", req.ImagePlatform)
+	}
+	if cfg.Hostname != "" {
+		args = append(args, "--hostname", cfg.Hostname)
+	}
+	if cfg.User != "" {
+		args = append(args, "--user", cfg.User)
+	}
+	if cfg.WorkingDir != "" {
+		args = append(args, "--workdir", cfg.WorkingDir)
+	}
+	if cfg.StopSignal != "" {
+		args = append(args, "--stop-signal", cfg.StopSignal)
+	}
+	for _, e := range cfg.Env {
+		args = append(args, "--env", e)
+	}
+	args = append(args, labelArgs(cfg.Labels)...)
+
+	for _, port := range req.ExposedPorts {
+		args = append(args, "--publish", port)
+	}
+
+	if hostConfig.NetworkMode != "" && !hostConfig.NetworkMode.IsDefault() {
+		args = append(args, "--network", string(hostConfig.NetworkMode))
+	}
+	for _, nw := range req.Networks {
+		args = append(args, "--network", nw)
+	}
+
+	if hostConfig.Privileged {
+		args = append(args, "--privileged")
+	}
+	if hostConfig.ShmSize > 0 {
+		args = append(args, "--shm-size", strconv.FormatInt(hostConfig.ShmSize, 10))
+	}
+	if hostConfig.Memory > 0 {
+		args = append(args, "--memory", strconv.FormatInt(hostConfig.Memory, 10))
+	}
+	if hostConfig.NanoCPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(hostConfig.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+	for _, capability := range hostConfig.CapAdd {
+		args = append(args, "--cap-add", capability)
+	}
+	for _, capability := range hostConfig.CapDrop {
+		args = append(args, "--cap-drop", capability)
+	}
+	for _, h := range hostConfig.ExtraHosts {
+		args = append(args, "--add-host", h)
+	}
+	if len(hostConfig.Binds) > 0 && !c.provider.Supports(context.Background(), CapabilityBindMounts) {
+		return nil, notSupportedError(CapabilityBindMounts)
+	}
+	for _, b := range hostConfig.Binds {
+		args = append(args, "--volume", b)
+	}
+	for _, target := range sortedKeys(hostConfig.Tmpfs) {
+		tmpfs := target
+		if opts := hostConfig.Tmpfs[target]; opts != "" {
+			tmpfs += ":" + opts
+		}
+		args = append(args, "--tmpfs", tmpfs)
+	}
+	for _, m := range hostConfig.Mounts {
+		args = append(args, "--mount", mountArg(m))
+	}
+	if hostConfig.AutoRemove {
+		args = append(args, "--rm")
+	}
+
+	files, err := c.fileMounts(req.Files)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, files...)
+
+	// nerdctl accepts a single element as entrypoint: the rest of them are prepended to the command
+	cmd := cfg.Cmd
+	if len(cfg.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", cfg.Entrypoint[0])
+		cmd = append(append([]string{}, cfg.Entrypoint[1:]...), cmd...)
+	}
+
+	args = append(args, cfg.Image)
+	args = append(args, cmd...)
+
+	return args, nil
+}
+
+// fileMounts writes the files of the request to the files directory of the container, with their modes,
+// returning the arguments bind mounting them in the container.
+func (c *NerdctlContainer) fileMounts(files []ContainerFile) ([]string, error) {
+	args := make([]string, 0, len(files)*2)
+	for i, f := range files {
+		if err := f.validate(); err != nil {
+			return nil, fmt.Errorf("invalid file: %w", err)
+		}
+
+		var content []byte
+		var err error
+		if f.Reader != nil {
+			content, err = io.ReadAll(f.Reader)
+		} else {
+			content, err = os.ReadFile(f.HostFilePath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read file %s: %w", f.ContainerFilePath, err)
+		}
+
+		mode := os.FileMode(f.FileMode)
+		if mode == 0 {
+			mode = 0o644
+		}
+
+		hostPath := filepath.Join(c.filesDir, strconv.Itoa(i))
+		if err := os.WriteFile(hostPath, content, mode); err != nil {
+			return nil, fmt.Errorf("write file %s: %w", f.ContainerFilePath, err)
+		}
+		// the mode is not applied on top of the umask by os.WriteFile
+		if err := os.Chmod(hostPath, mode); err != nil {
+			return nil, fmt.Errorf("chmod file %s: %w", f.ContainerFilePath, err)
+		}
+
+		args = append(args, "--volume", hostPath+":"+f.ContainerFilePath)
+	}
+
+	return args, nil
+}
+
+// labelArgs returns the arguments setting the labels, sorted by key.
+func labelArgs(labels map[string]string) []string {
+	args := make([]string, 0, len(labels)*2)
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+
+	return args
+}
+
+// mountArg returns the value of the --mount argument of the mount.
+func mountArg(m mount.Mount) string {
+	parts := []string{"type=" + string(m.Type)}
+	if m.Source != "" {
+		parts = append(parts, "source="+m.Source)
+	}
+	parts = append(parts, "target="+m.Target)
+	if m.ReadOnly {
+		parts = append(parts, "readonly")
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,745 @@
+package testcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ Container = (*NerdctlContainer)(nil)
+
+// NerdctlContainer is a container created with the experimental NerdctlProvider.
+type NerdctlContainer struct {
+	// ID is the container ID
+	ID         string
+	WaitingFor wait.Strategy
+	Image      string
+
+	provider       *NerdctlProvider
+	sessionID      string
+	logger         Logging
+	lifecycleHooks []ContainerLifecycleHooks
+	isRunning      bool
+
+	// filesDir is the temporary directory of the files of the request, bind mounted in the container.
+	filesDir string
+
+	consumers          []LogConsumer
+	logsCmd            *exec.Cmd
+	logsWg             sync.WaitGroup
+	logProductionError chan error
+
+	// relays are the socat containers relaying the ports exposed with ExposeAdditionalPort.
+	relays portRelays
+
+	// keepOnFailure is the test the container is kept running for if it failed,
+	// see SkipTerminationOnFailure and KeepContainersOnFailure.
+	keepOnFailure testing.TB
+}
+
+// GetContainerID implements Container.
+func (c *NerdctlContainer) GetContainerID() string {
+	return c.ID
+}
+
+// IsRunning implements Container.
+func (c *NerdctlContainer) IsRunning() bool {
+	return c.isRunning
+}
+
+// SessionID implements Container.
+func (c *NerdctlContainer) SessionID() string {
+	return c.sessionID
+}
+
+// Endpoint gets proto://host:port string for the lowest numbered exposed port
+// Will returns just host:port if proto is ""
+func (c *NerdctlContainer) Endpoint(ctx context.Context, proto string) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return c.PortEndpoint(ctx, lowestPort(inspect.NetworkSettings.Ports, proto), proto)
+}
+
+// PortEndpoint gets proto://host:port string for the given exposed port
+// Will returns just host:port if proto is ""
+func (c *NerdctlContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	outerPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	protoFull := ""
+	if proto != "" {
+		protoFull = fmt.Sprintf("%s://", proto)
+	}
+
+	return fmt.Sprintf("%s%s:%s", protoFull, host, outerPort.Port()), nil
+}
+
+// Host gets the host where the container ports are exposed: localhost, as containerd runs locally,
+// unless it's set with the "TESTCONTAINERS_HOST_OVERRIDE" env variable.
+func (c *NerdctlContainer) Host(context.Context) (string, error) {
+	return c.provider.host(), nil
+}
+
+// Inspect gets the container info, in the format of the Docker API.
+// The info is not cached, so the options are ignored.
+func (c *NerdctlContainer) Inspect(ctx context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
+	out, err := c.provider.run(ctx, "container", "inspect", "--mode", "dockercompat", c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []types.ContainerJSON
+	if err := json.Unmarshal(out, &containers); err != nil {
+		return nil, fmt.Errorf("decode container: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("container %s not found", c.ID)
+	}
+
+	inspect := &containers[0]
+	if inspect.ContainerJSONBase == nil {
+		inspect.ContainerJSONBase = &types.ContainerJSONBase{}
+	}
+	if inspect.NetworkSettings == nil {
+		inspect.NetworkSettings = &types.NetworkSettings{}
+	}
+
+	return inspect, nil
+}
+
+// MappedPort gets externally mapped port for a container port
+func (c *NerdctlContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if relay := c.relays.get(port); relay != nil {
+		return relay.MappedPort(ctx, port)
+	}
+
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for k, p := range inspect.NetworkSettings.Ports {
+		if k.Port() != port.Port() {
+			continue
+		}
+		if port.Proto() != "" && k.Proto() != port.Proto() {
+			continue
+		}
+		if len(p) == 0 {
+			continue
+		}
+
+		return nat.NewPort(k.Proto(), p[0].HostPort)
+	}
+
+	return "", errors.New("port not found")
+}
+
+// Deprecated: use c.Inspect(ctx).NetworkSettings.Ports instead.
+// Ports gets the exposed ports for the container.
+func (c *NerdctlContainer) Ports(ctx context.Context) (nat.PortMap, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.NetworkSettings.Ports, nil
+}
+
+// Start will start an already created container
+func (c *NerdctlContainer) Start(ctx context.Context) (err error) {
+	defer func() {
+		countResult(err, &stats.containersStarted, &stats.containerFailures)
+	}()
+
+	if err := c.startingHook(ctx); err != nil {
+		return fmt.Errorf("starting hook: %w", err)
+	}
+
+	if _, err := c.provider.run(ctx, "start", c.ID); err != nil {
+		return err
+	}
+	c.isRunning = true
+
+	if err := c.startedHook(ctx); err != nil {
+		return fmt.Errorf("started hook: %w", err)
+	}
+
+	if err := c.readiedHook(ctx); err != nil {
+		return fmt.Errorf("readied hook: %w", err)
+	}
+
+	return nil
+}
+
+// Stop stops the container, killing it if it didn't exit after the timeout.
+// A nil timeout uses the default timeout of nerdctl.
+func (c *NerdctlContainer) Stop(ctx context.Context, timeout *time.Duration) error {
+	return c.StopWithSignal(ctx, "", timeout)
+}
+
+// StopWithSignal stops the container sending the given signal instead of the stop signal of the image,
+// and killing it if it didn't exit after the timeout. A nil timeout uses the default timeout of nerdctl.
+func (c *NerdctlContainer) StopWithSignal(ctx context.Context, signal string, timeout *time.Duration) error {
+	if err := c.stoppingHook(ctx); err != nil {
+		return fmt.Errorf("stopping hook: %w", err)
+	}
+
+	args := []string{"stop"}
+	if signal != "" {
+		args = append(args, "--signal", signal)
+	}
+	if timeout != nil {
+		args = append(args, "--time", strconv.Itoa(int(timeout.Seconds())))
+	}
+
+	if _, err := c.provider.run(ctx, append(args, c.ID)...); err != nil {
+		return err
+	}
+	c.isRunning = false
+
+	if err := c.stoppedHook(ctx); err != nil {
+		return fmt.Errorf("stopped hook: %w", err)
+	}
+
+	return nil
+}
+
+// Pause pauses all the processes of the container, if the cgroup driver of containerd supports it.
+func (c *NerdctlContainer) Pause(ctx context.Context) error {
+	if !c.provider.Supports(ctx, CapabilityPause) {
+		return notSupportedError(CapabilityPause)
+	}
+
+	_, err := c.provider.run(ctx, "pause", c.ID)
+	return err
+}
+
+// Unpause unpauses all the processes of a paused container.
+func (c *NerdctlContainer) Unpause(ctx context.Context) error {
+	if !c.provider.Supports(ctx, CapabilityPause) {
+		return notSupportedError(CapabilityPause)
+	}
+
+	_, err := c.provider.run(ctx, "unpause", c.ID)
+	return err
+}
+
+// Signal sends the signal to the main process of the container, without stopping it,
+// unless the process exits on the signal.
+func (c *NerdctlContainer) Signal(ctx context.Context, signal string) error {
+	_, err := c.provider.run(ctx, "kill", "--signal", signal, c.ID)
+	return err
+}
+
+// KillProcess kills the processes of the container whose command line matches the pattern,
+// see the KillProcess function.
+func (c *NerdctlContainer) KillProcess(ctx context.Context, pattern string) error {
+	return killProcess(ctx, c, pattern)
+}
+
+// Terminate stops and removes the container, and the files of the request.
+// If the container is kept for the debugging of a failed test, see SkipTerminationOnFailure,
+// it's left running and the instructions to connect to it are logged in the test.
+func (c *NerdctlContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	if tb := failedTest(c.keepOnFailure); tb != nil {
+		tb.Log(c.keptContainer(ctx).message(tb.Name()))
+		return nil
+	}
+
+	options := newTerminateOptions(opts...)
+
+	var errs []error
+	if options.stopTimeout != nil {
+		errs = append(errs, c.Stop(ctx, options.stopTimeout))
+	}
+
+	errs = append(errs, c.terminatingHook(ctx), c.stopLogProduction(), c.relays.terminate(ctx))
+
+	args := []string{"rm", "--force"}
+	if !options.retainVolumes {
+		args = append(args, "--volumes")
+	}
+
+	if _, err := c.provider.run(ctx, append(args, c.ID)...); err != nil {
+		errs = append(errs, err)
+	} else {
+		stats.containersTerminated.Add(1)
+	}
+
+	c.isRunning = false
+	errs = append(errs, c.removeFiles(), c.terminatedHook(ctx))
+
+	return errors.Join(errs...)
+}
+
+// keptContainer describes the container kept for the debugging of a failed test.
+func (c *NerdctlContainer) keptContainer(ctx context.Context) keptContainer {
+	kept := keptContainer{
+		cli: "nerdctl",
+		id:  c.ID,
+	}
+
+	// the description is best effort, as the test already failed
+	if inspect, err := c.Inspect(ctx); err == nil {
+		kept.name = inspect.Name
+		kept.ports = inspect.NetworkSettings.Ports
+	}
+	kept.host, _ = c.Host(ctx)
+
+	return kept
+}
+
+// removeFiles removes the directory of the files of the request, if any.
+func (c *NerdctlContainer) removeFiles() error {
+	if c.filesDir == "" {
+		return nil
+	}
+
+	return os.RemoveAll(c.filesDir)
+}
+
+// Logs returns the combined stdout and stderr of the container.
+func (c *NerdctlContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
+	out, err := c.provider.run(ctx, "logs", c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(out)), nil
+}
+
+// Deprecated: use the ContainerRequest.LogConsumerConfig field instead.
+func (c *NerdctlContainer) FollowOutput(consumer LogConsumer) {
+	c.consumers = append(c.consumers, consumer)
+}
+
+// Deprecated: use the ContainerRequest.LogConsumerConfig field instead.
+func (c *NerdctlContainer) StartLogProducer(ctx context.Context, _ ...LogProductionOption) error {
+	return c.startLogProduction(ctx)
+}
+
+// Deprecated: it will be removed in the next major release.
+func (c *NerdctlContainer) StopLogProducer() error {
+	return c.stopLogProduction()
+}
+
+// GetLogProductionErrorChannel implements Container.
+func (c *NerdctlContainer) GetLogProductionErrorChannel() <-chan error {
+	return c.logProductionError
+}
+
+// startLogProduction follows the logs of the container with nerdctl logs, sending them to the consumers.
+func (c *NerdctlContainer) startLogProduction(context.Context) error {
+	if c.logsCmd != nil {
+		return errors.New("log production already started")
+	}
+
+	// the log production outlives the context of the hook, and is stopped with stopLogProduction
+	cmd := c.provider.command(context.Background(), "logs", "--follow", c.ID)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("nerdctl logs: %w", err)
+	}
+
+	c.logsCmd = cmd
+	c.logProductionError = make(chan error, 1)
+
+	consumers := append([]LogConsumer{}, c.consumers...)
+	produce := func(r io.Reader, logType string) {
+		defer c.logsWg.Done()
+
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				for _, consumer := range consumers {
+					consumer.Accept(Log{LogType: logType, Content: line})
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	c.logsWg.Add(2)
+	go produce(stdout, StdoutLog)
+	go produce(stderr, StderrLog)
+
+	return nil
+}
+
+// stopLogProduction stops following the logs of the container, if it was started.
+func (c *NerdctlContainer) stopLogProduction() error {
+	if c.logsCmd == nil {
+		return nil
+	}
+
+	cmd := c.logsCmd
+	c.logsCmd = nil
+
+	// killing the process closes the pipes, which ends the producers
+	_ = cmd.Process.Kill()
+	c.logsWg.Wait()
+	_ = cmd.Wait()
+
+	close(c.logProductionError)
+
+	return nil
+}
+
+// Deprecated: use c.Inspect(ctx).Name instead.
+// Name gets the name of the container.
+func (c *NerdctlContainer) Name(ctx context.Context) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+	return inspect.Name, nil
+}
+
+// State returns container's running state.
+func (c *NerdctlContainer) State(ctx context.Context) (*types.ContainerState, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.State, nil
+}
+
+// Networks gets the names of the networks the container is attached to.
+func (c *NerdctlContainer) Networks(ctx context.Context) ([]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return []string{}, err
+	}
+
+	n := []string{}
+	for k := range inspect.NetworkSettings.Networks {
+		n = append(n, k)
+	}
+
+	return n, nil
+}
+
+// NetworkAliases gets the aliases of the container for the networks it is attached to.
+// The network aliases are not supported by nerdctl, so they are always empty.
+func (c *NerdctlContainer) NetworkAliases(ctx context.Context) (map[string][]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return map[string][]string{}, err
+	}
+
+	a := map[string][]string{}
+	for k := range inspect.NetworkSettings.Networks {
+		a[k] = inspect.NetworkSettings.Networks[k].Aliases
+	}
+
+	return a, nil
+}
+
+// ConnectNetwork is not supported by nerdctl, it returns an error wrapping ErrNotSupported.
+func (c *NerdctlContainer) ConnectNetwork(context.Context, string, ...string) error {
+	return notSupportedError(CapabilityNetworkConnect)
+}
+
+// DisconnectNetwork is not supported by nerdctl, it returns an error wrapping ErrNotSupported.
+func (c *NerdctlContainer) DisconnectNetwork(context.Context, string) error {
+	return notSupportedError(CapabilityNetworkConnect)
+}
+
+// ContainerIP gets the IP address of the primary network within the container.
+func (c *NerdctlContainer) ContainerIP(ctx context.Context) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ip := inspect.NetworkSettings.IPAddress
+	if ip == "" {
+		// use IP from "Networks" if only single network defined
+		networks := inspect.NetworkSettings.Networks
+		if len(networks) == 1 {
+			for _, v := range networks {
+				ip = v.IPAddress
+			}
+		}
+	}
+
+	return ip, nil
+}
+
+// ContainerIPs gets the IP addresses of all the networks within the container.
+func (c *NerdctlContainer) ContainerIPs(ctx context.Context) ([]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, 0)
+	for _, nw := range inspect.NetworkSettings.Networks {
+		ips = append(ips, nw.IPAddress)
+	}
+
+	return ips, nil
+}
+
+// Exec executes a command in the current container.
+// As with the Docker provider, the returned [io.Reader] multiplexes the stdout and stderr,
+// use [tcexec.Multiplexed] option to read the combined output without the multiplexing headers.
+func (c *NerdctlContainer) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	processOptions := tcexec.NewProcessOptions(cmd)
+	for _, o := range options {
+		o.Apply(processOptions)
+	}
+
+	cfg := processOptions.ExecConfig
+	args := []string{"exec"}
+	if cfg.User != "" {
+		args = append(args, "--user", cfg.User)
+	}
+	if cfg.WorkingDir != "" {
+		args = append(args, "--workdir", cfg.WorkingDir)
+	}
+	for _, e := range cfg.Env {
+		args = append(args, "--env", e)
+	}
+	if cfg.Privileged {
+		args = append(args, "--privileged")
+	}
+	args = append(args, c.ID)
+	args = append(args, cfg.Cmd...)
+
+	var output bytes.Buffer
+	execCmd := c.provider.command(ctx, args...)
+	execCmd.Stdout = stdcopy.NewStdWriter(&output, stdcopy.Stdout)
+	execCmd.Stderr = stdcopy.NewStdWriter(&output, stdcopy.Stderr)
+
+	if err := execCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return 0, nil, fmt.Errorf("nerdctl exec: %w", err)
+		}
+	}
+
+	processOptions.Reader = &output
+
+	// second loop to process the multiplexed option, as now we have a reader
+	for _, o := range options {
+		o.Apply(processOptions)
+	}
+
+	return execCmd.ProcessState.ExitCode(), processOptions.Reader, nil
+}
+
+// CopyToContainer copies the content to the container, which must be running.
+func (c *NerdctlContainer) CopyToContainer(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64) error {
+	dir, err := os.MkdirTemp("", "testcontainers-nerdctl-cp")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	hostFilePath := filepath.Join(dir, filepath.Base(containerFilePath))
+	if err := os.WriteFile(hostFilePath, fileContent, os.FileMode(fileMode)); err != nil {
+		return err
+	}
+
+	return c.CopyFileToContainer(ctx, hostFilePath, containerFilePath, fileMode)
+}
+
+// CopyFileToContainer copies the file of the host to the container, which must be running.
+func (c *NerdctlContainer) CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error {
+	if err := os.Chmod(hostFilePath, os.FileMode(fileMode)); err != nil {
+		return err
+	}
+
+	_, err := c.provider.run(ctx, "cp", hostFilePath, c.ID+":"+containerFilePath)
+	return err
+}
+
+// CopyDirToContainer copies the directory of the host to the parent path of the container, which must be running.
+func (c *NerdctlContainer) CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, _ int64) error {
+	dir := filepath.Base(hostDirPath)
+	_, err := c.provider.run(ctx, "cp", hostDirPath, c.ID+":"+filepath.Join(containerParentPath, dir))
+	return err
+}
+
+// CopyFileFromContainer copies the file of the container to the host, returning its content.
+func (c *NerdctlContainer) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	dir, err := os.MkdirTemp("", "testcontainers-nerdctl-cp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	hostFilePath := filepath.Join(dir, filepath.Base(filePath))
+	if _, err := c.provider.run(ctx, "cp", c.ID+":"+filePath, hostFilePath); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(hostFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// createdHook is a hook that will be called after a container is created.
+func (c *NerdctlContainer) createdHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostCreates
+	})
+}
+
+// startingHook is a hook that will be called before a container is started.
+func (c *NerdctlContainer) startingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreStarts
+	})
+}
+
+// startedHook is a hook that will be called after a container is started.
+func (c *NerdctlContainer) startedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostStarts
+	})
+}
+
+// readiedHook is a hook that will be called after a container is ready.
+func (c *NerdctlContainer) readiedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostReadies
+	})
+}
+
+// stoppingHook is a hook that will be called before a container is stopped.
+func (c *NerdctlContainer) stoppingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreStops
+	})
+}
+
+// stoppedHook is a hook that will be called after a container is stopped.
+func (c *NerdctlContainer) stoppedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostStops
+	})
+}
+
+// terminatingHook is a hook that will be called before a container is terminated.
+func (c *NerdctlContainer) terminatingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreTerminates
+	})
+}
+
+// terminatedHook is a hook that will be called after a container is terminated.
+func (c *NerdctlContainer) terminatedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostTerminates
+	})
+}
+
+// applyLifecycleHooks applies all lifecycle hooks, stopping at the first error of a fail-fast bundle.
+func (c *NerdctlContainer) applyLifecycleHooks(ctx context.Context, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook) error {
+	errs := make([]error, 0, len(c.lifecycleHooks))
+	for _, lifecycleHooks := range c.lifecycleHooks {
+		err := containerHookFn(ctx, wrapHooks(lifecycleHooks, hooks(lifecycleHooks)))(c)
+		errs = append(errs, err)
+		if isFailFast(err) {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// nerdctlReadinessHook is a hook that waits for the container to be ready with its waiting strategy.
+var nerdctlReadinessHook = func() ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				nerdctlContainer := c.(*NerdctlContainer)
+				if nerdctlContainer.WaitingFor == nil {
+					return nil
+				}
+
+				nerdctlContainer.logger.Printf(
+					"⏳ Waiting for container id %s image: %s. Waiting for: %+v",
+					nerdctlContainer.ID[:12], nerdctlContainer.Image, nerdctlContainer.WaitingFor,
+				)
+				if err := nerdctlContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+					return fmt.Errorf("wait until ready: %w", err)
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// nerdctlLogConsumersHook is a hook that sends the logs of the container to the log consumers of the request.
+var nerdctlLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				nerdctlContainer := c.(*NerdctlContainer)
+				nerdctlContainer.consumers = append(nerdctlContainer.consumers[:0], cfg.Consumers...)
+
+				return nerdctlContainer.startLogProduction(ctx)
+			},
+		},
+		PostStops: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				return c.(*NerdctlContainer).stopLogProduction()
+			},
+		},
+	}
+}
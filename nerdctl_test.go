@@ -0,0 +1,173 @@
+package testcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestNerdctlProvider returns a nerdctl provider with the given capabilities, without running nerdctl.
+func newTestNerdctlProvider(capabilities ...Capability) *NerdctlProvider {
+	p := &NerdctlProvider{
+		GenericProviderOptions: &GenericProviderOptions{Logger: Logger},
+		binary:                 nerdctlBinary,
+		capabilities:           map[Capability]bool{},
+	}
+	p.capabilitiesOnce.Do(func() {})
+
+	for _, capability := range capabilities {
+		p.capabilities[capability] = true
+	}
+
+	return p
+}
+
+func TestSupports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("docker", func(t *testing.T) {
+		require.True(t, Supports(ctx, &DockerProvider{}, CapabilityReaper))
+	})
+
+	t.Run("nerdctl", func(t *testing.T) {
+		p := newTestNerdctlProvider(CapabilityPause)
+
+		require.True(t, Supports(ctx, p, CapabilityPause))
+		require.False(t, Supports(ctx, p, CapabilityReaper))
+		require.False(t, Supports(ctx, p, CapabilityNetworkConnect))
+	})
+}
+
+func TestNerdctlProvider_checkCapabilities(t *testing.T) {
+	ctx := context.Background()
+	p := newTestNerdctlProvider()
+
+	require.NoError(t, p.checkCapabilities(ctx, ContainerRequest{Image: nginxAlpineImage}))
+
+	err := p.checkCapabilities(ctx, ContainerRequest{
+		Image:           nginxAlpineImage,
+		NetworkAliases:  map[string][]string{"net": {"alias"}},
+		HostAccessPorts: []int{8080},
+		Stdin:           strings.NewReader("input"),
+	})
+	require.ErrorIs(t, err, ErrNotSupported)
+	require.ErrorContains(t, err, string(CapabilityNetworkAliases))
+	require.ErrorContains(t, err, string(CapabilityHostPortAccess))
+	require.ErrorContains(t, err, string(CapabilityStdin))
+
+	_, err = p.ReuseOrCreateContainer(ctx, ContainerRequest{Image: nginxAlpineImage})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestNerdctlContainer_createArgs(t *testing.T) {
+	c := &NerdctlContainer{provider: newTestNerdctlProvider(), filesDir: t.TempDir()}
+
+	args, err := c.createArgs(ContainerRequest{
+		Image:        nginxAlpineImage,
+		Name:         "web",
+		Env:          map[string]string{"B": "2", "A": "1"},
+		Labels:       map[string]string{"app": "web"},
+		ExposedPorts: []string{"80/tcp"},
+		Entrypoint:   []string{"sh", "-c"},
+		Cmd:          []string{"nginx -g 'daemon off;'"},
+		Networks:     []string{"backend"},
+		Files: []ContainerFile{
+			{Reader: strings.NewReader("hello"), ContainerFilePath: "/etc/hello.txt", FileMode: 0o600},
+		},
+		HostConfigModifier: func(hc *container.HostConfig) {
+			hc.CapAdd = []string{"NET_ADMIN"}
+			hc.Memory = 64 * 1024 * 1024
+		},
+	})
+	require.NoError(t, err)
+
+	hostFile := filepath.Join(c.filesDir, "0")
+	require.Equal(t, []string{
+		"create",
+		"--name", "web",
+		"--env", "A=1",
+		"--env", "B=2",
+		"--label", "app=web",
+		"--publish", "80/tcp",
+		"--network", "backend",
+		"--memory", "67108864",
+		"--cap-add", "NET_ADMIN",
+		"--volume", hostFile + ":/etc/hello.txt",
+		"--entrypoint", "sh",
+		nginxAlpineImage,
+		"-c", "nginx -g 'daemon off;'",
+	}, args)
+
+	content, err := os.ReadFile(hostFile)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(content))
+
+	info, err := os.Stat(hostFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestNerdctlContainer_notSupported(t *testing.T) {
+	ctx := context.Background()
+	c := &NerdctlContainer{provider: newTestNerdctlProvider()}
+
+	require.ErrorIs(t, c.ConnectNetwork(ctx, "net"), ErrNotSupported)
+	require.ErrorIs(t, c.DisconnectNetwork(ctx, "net"), ErrNotSupported)
+	require.ErrorIs(t, c.Pause(ctx), ErrNotSupported)
+}
+
+func TestMicroVMProvider(t *testing.T) {
+	ctx := context.Background()
+	p := &NerdctlProvider{
+		GenericProviderOptions: &GenericProviderOptions{Logger: Logger},
+		binary:                 nerdctlBinary,
+		runtime:                defaultMicroVMRuntime,
+		snapshotter:            defaultMicroVMSnapshotter,
+		microVM:                true,
+	}
+
+	t.Run("capabilities", func(t *testing.T) {
+		require.False(t, Supports(ctx, p, CapabilityBindMounts))
+		require.False(t, Supports(ctx, p, CapabilityPause))
+		require.True(t, Supports(ctx, newTestNerdctlProvider(CapabilityBindMounts), CapabilityBindMounts))
+	})
+
+	t.Run("files", func(t *testing.T) {
+		err := p.checkCapabilities(ctx, ContainerRequest{
+			Image: nginxAlpineImage,
+			Files: []ContainerFile{{Reader: strings.NewReader("hello"), ContainerFilePath: "/hello.txt"}},
+		})
+		require.ErrorIs(t, err, ErrNotSupported)
+		require.ErrorContains(t, err, string(CapabilityBindMounts))
+
+		err = p.checkCapabilities(ctx, ContainerRequest{
+			Image:  nginxAlpineImage,
+			Mounts: ContainerMounts{{Source: GenericVolumeMountSource{Name: "data"}, Target: "/data"}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("args", func(t *testing.T) {
+		c := &NerdctlContainer{provider: p}
+
+		args, err := c.createArgs(ContainerRequest{Image: nginxAlpineImage})
+		require.NoError(t, err)
+		require.Equal(t, []string{"create", "--runtime", defaultMicroVMRuntime, nginxAlpineImage}, args)
+
+		_, err = c.createArgs(ContainerRequest{
+			Image: nginxAlpineImage,
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.Binds = []string{"/tmp:/tmp"}
+			},
+		})
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		cmd := p.command(ctx, "ps")
+		require.Equal(t, []string{nerdctlBinary, "--snapshotter", defaultMicroVMSnapshotter, "ps"}, cmd.Args)
+	})
+}
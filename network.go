@@ -2,6 +2,9 @@ package testcontainers
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
+	"sort"
 
 	"github.com/docker/docker/api/types/network"
 )
@@ -42,8 +45,99 @@ type NetworkRequest struct {
 	Labels         map[string]string
 	Attachable     bool
 	IPAM           *network.IPAM
+	Options        map[string]string // the options of the network driver
 
 	SkipReaper    bool              // Deprecated: The reaper is globally controlled by the .testcontainers.properties file or the TESTCONTAINERS_RYUK_DISABLED environment variable
 	ReaperImage   string            // Deprecated: use WithImageName ContainerOption instead. Alternative reaper registry
 	ReaperOptions []ContainerOption // Deprecated: the reaper is configured at the properties level, for an entire test session
 }
+
+// NetworkDetails represents the details of a network, as returned by DockerNetwork.Inspect.
+type NetworkDetails struct {
+	ID         string
+	Name       string
+	Driver     string
+	Internal   bool
+	Attachable bool
+	EnableIPv6 bool
+	Labels     map[string]string
+	Options    map[string]string  // the options of the network driver
+	Subnets    []NetworkSubnet    // the subnets of the network, from its IPAM configuration
+	Containers []NetworkContainer // the containers connected to the network, sorted by name
+}
+
+// NetworkSubnet represents a subnet of a network, in CIDR notation, with its gateway.
+type NetworkSubnet struct {
+	Subnet  string
+	Gateway string
+	IPRange string
+}
+
+// NetworkContainer represents a container connected to a network, with its addresses in the network.
+type NetworkContainer struct {
+	ID          string
+	Name        string
+	IPv4Address string // the IPv4 address, without the prefix length
+	IPv6Address string // the IPv6 address, without the prefix length
+	MacAddress  string
+}
+
+// Inspect returns the details of the network, including its subnets and the containers connected to it.
+func (n *DockerNetwork) Inspect(ctx context.Context) (NetworkDetails, error) {
+	defer n.provider.Close()
+
+	inspect, err := n.provider.client.NetworkInspect(ctx, n.ID, network.InspectOptions{})
+	if err != nil {
+		return NetworkDetails{}, fmt.Errorf("inspect network %s: %w", n.Name, err)
+	}
+
+	return newNetworkDetails(inspect), nil
+}
+
+// newNetworkDetails converts the network inspection of the Docker API.
+func newNetworkDetails(inspect network.Inspect) NetworkDetails {
+	details := NetworkDetails{
+		ID:         inspect.ID,
+		Name:       inspect.Name,
+		Driver:     inspect.Driver,
+		Internal:   inspect.Internal,
+		Attachable: inspect.Attachable,
+		EnableIPv6: inspect.EnableIPv6,
+		Labels:     inspect.Labels,
+		Options:    inspect.Options,
+	}
+
+	for _, cfg := range inspect.IPAM.Config {
+		details.Subnets = append(details.Subnets, NetworkSubnet{
+			Subnet:  cfg.Subnet,
+			Gateway: cfg.Gateway,
+			IPRange: cfg.IPRange,
+		})
+	}
+
+	for id, endpoint := range inspect.Containers {
+		details.Containers = append(details.Containers, NetworkContainer{
+			ID:          id,
+			Name:        endpoint.Name,
+			IPv4Address: addressWithoutPrefix(endpoint.IPv4Address),
+			IPv6Address: addressWithoutPrefix(endpoint.IPv6Address),
+			MacAddress:  endpoint.MacAddress,
+		})
+	}
+
+	sort.Slice(details.Containers, func(i, j int) bool {
+		return details.Containers[i].Name < details.Containers[j].Name
+	})
+
+	return details
+}
+
+// addressWithoutPrefix returns the address of the CIDR notation, e.g. 172.18.0.2 for 172.18.0.2/16.
+func addressWithoutPrefix(cidr string) string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return cidr
+	}
+
+	return prefix.Addr().String()
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"context"
 	"fmt"
+	"testing"
 
 	"github.com/docker/docker/api/types/network"
 	"github.com/google/uuid"
@@ -38,6 +39,7 @@ func New(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.Docker
 		Labels:     nc.Labels,
 		Attachable: nc.Attachable,
 		IPAM:       nc.IPAM,
+		Options:    nc.Options,
 	}
 
 	//nolint:staticcheck
@@ -48,9 +50,17 @@ func New(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.Docker
 		return nil, err
 	}
 
+	nw := n.(*testcontainers.DockerNetwork)
+
+	for _, opt := range opts {
+		if c, ok := opt.(cleanupOption); ok {
+			c.register(nw)
+		}
+	}
+
 	// Return a DockerNetwork struct instead of the Network interface,
 	// following the "accept interface, return struct" pattern.
-	return n.(*testcontainers.DockerNetwork), nil
+	return nw, nil
 }
 
 // NetworkCustomizer is an interface that can be used to configure the network create request.
@@ -94,6 +104,49 @@ func WithDriver(driver string) CustomizeNetworkOption {
 	}
 }
 
+// WithDriverOptions allows to set the options of the network driver, e.g.
+// "com.docker.network.bridge.enable_icc" for the bridge driver, adding them to the existing ones.
+func WithDriverOptions(options map[string]string) CustomizeNetworkOption {
+	return func(original *network.CreateOptions) error {
+		if original.Options == nil {
+			original.Options = make(map[string]string, len(options))
+		}
+
+		for k, v := range options {
+			original.Options[k] = v
+		}
+
+		return nil
+	}
+}
+
+// WithCleanup removes the network when the test and all its subtests complete,
+// reporting an error to the test if it can't be removed.
+func WithCleanup(tb testing.TB) NetworkCustomizer {
+	return cleanupOption{tb: tb}
+}
+
+// cleanupOption registers the removal of the network in the test, once it's created.
+type cleanupOption struct {
+	tb testing.TB
+}
+
+// Customize implements the NetworkCustomizer interface. The network create request is not modified.
+func (o cleanupOption) Customize(_ *network.CreateOptions) error {
+	return nil
+}
+
+// register removes the network when the test completes.
+func (o cleanupOption) register(nw *testcontainers.DockerNetwork) {
+	o.tb.Helper()
+
+	o.tb.Cleanup(func() {
+		if err := nw.Remove(context.Background()); err != nil {
+			o.tb.Errorf("remove network %s: %v", nw.Name, err)
+		}
+	})
+}
+
 // WithEnableIPv6 allows to set the network as IPv6 enabled.
 // Please use this option if and only if IPv6 is enabled on the Docker daemon.
 func WithEnableIPv6() CustomizeNetworkOption {
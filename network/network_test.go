@@ -306,6 +306,64 @@ func TestMultipleContainersInTheNewNetwork(t *testing.T) {
 	assert.Equal(t, networkName, rNets[0])
 }
 
+func TestContainerDisconnectAndConnectNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	net, err := network.New(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, net.Remove(ctx))
+	}()
+
+	server, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          nginxAlpineImage,
+			Networks:       []string{net.Name},
+			NetworkAliases: map[string][]string{net.Name: {"server"}},
+			WaitingFor:     wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Terminate(ctx))
+	}()
+
+	client, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:    nginxAlpineImage,
+			Networks: []string{net.Name},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Terminate(ctx))
+	}()
+
+	reachable := func() bool {
+		code, _, err := client.Exec(ctx, []string{"wget", "-q", "-T", "2", "-O", "/dev/null", "http://server"})
+		require.NoError(t, err)
+		return code == 0
+	}
+
+	require.True(t, reachable())
+
+	// networkPartition {
+	// simulate a network partition
+	require.NoError(t, testcontainers.DisconnectNetwork(ctx, server, net.Name))
+	require.False(t, reachable())
+
+	nets, err := server.Networks(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, nets, net.Name)
+
+	// recover from the partition
+	require.NoError(t, testcontainers.ConnectNetwork(ctx, server, net.Name, "server"))
+	require.True(t, reachable())
+	// }
+}
+
 func TestNew_withOptions(t *testing.T) {
 	// newNetworkWithOptions {
 	ctx := context.Background()
@@ -516,3 +574,63 @@ func TestWithNewNetworkContextTimeout(t *testing.T) {
 	assert.Empty(t, req.Networks)
 	assert.Empty(t, req.NetworkAliases)
 }
+
+func TestWithDriverOptions(t *testing.T) {
+	nc := dockernetwork.CreateOptions{}
+
+	err := network.WithDriverOptions(map[string]string{"com.docker.network.driver.mtu": "1400"}).Customize(&nc)
+	require.NoError(t, err)
+
+	err = network.WithDriverOptions(map[string]string{"com.docker.network.bridge.enable_icc": "false"}).Customize(&nc)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"com.docker.network.driver.mtu":        "1400",
+		"com.docker.network.bridge.enable_icc": "false",
+	}, nc.Options)
+
+	// the cleanup option doesn't modify the request
+	require.NoError(t, network.WithCleanup(t).Customize(&nc))
+	require.Len(t, nc.Options, 2)
+}
+
+func TestNew_inspect(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx,
+		network.WithCleanup(t),
+		network.WithDriverOptions(map[string]string{"com.docker.network.driver.mtu": "1400"}),
+		network.WithIPAM(&dockernetwork.IPAM{
+			Config: []dockernetwork.IPAMConfig{{Subnet: "10.1.3.0/24", Gateway: "10.1.3.1"}},
+		}),
+	)
+	require.NoError(t, err)
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+			Name:  "nginx-inspect-" + nw.ID[:8],
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(context.Background()))
+	})
+
+	// the container is created in the default network, and connected to the new one
+	require.NoError(t, testcontainers.ConnectNetwork(ctx, nginx, nw.Name))
+
+	details, err := nw.Inspect(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, nw.ID, details.ID)
+	require.Equal(t, "bridge", details.Driver)
+	require.Equal(t, "1400", details.Options["com.docker.network.driver.mtu"])
+	require.Equal(t, []testcontainers.NetworkSubnet{{Subnet: "10.1.3.0/24", Gateway: "10.1.3.1"}}, details.Subnets)
+
+	require.Len(t, details.Containers, 1)
+	require.Equal(t, nginx.GetContainerID(), details.Containers[0].ID)
+	require.Equal(t, "nginx-inspect-"+nw.ID[:8], details.Containers[0].Name)
+	require.Contains(t, details.Containers[0].IPv4Address, "10.1.3.")
+}
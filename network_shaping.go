@@ -0,0 +1,147 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// netshootImage is the image of the sidecars shaping the network traffic of the containers with tc,
+	// and capturing it with tcpdump.
+	// hubNetshootImage {
+	netshootImage = "nicolaka/netshoot:v0.13"
+	// }
+)
+
+// NetemOptions are the degradations of the network traffic sent by a container, see ShapeNetwork.
+// The zero value removes the degradations.
+type NetemOptions struct {
+	// DelayMs is the latency added to the packets, in milliseconds.
+	DelayMs int
+	// LossPct is the percentage of the packets dropped, between 0 and 100.
+	LossPct float64
+	// RateKbit is the bandwidth, in kbit/s. Zero doesn't limit it.
+	RateKbit int
+}
+
+// validate returns an error if an option is out of range.
+func (o NetemOptions) validate() error {
+	var errs []error
+	if o.DelayMs < 0 {
+		errs = append(errs, fmt.Errorf("negative delay %dms", o.DelayMs))
+	}
+	if o.LossPct < 0 || o.LossPct > 100 {
+		errs = append(errs, fmt.Errorf("loss %v%% out of range", o.LossPct))
+	}
+	if o.RateKbit < 0 {
+		errs = append(errs, fmt.Errorf("negative rate %dkbit", o.RateKbit))
+	}
+
+	return errors.Join(errs...)
+}
+
+// netem returns the parameters of the netem queueing discipline, empty for the zero value.
+func (o NetemOptions) netem() string {
+	var params []string
+	if o.DelayMs > 0 {
+		params = append(params, "delay "+strconv.Itoa(o.DelayMs)+"ms")
+	}
+	if o.LossPct > 0 {
+		params = append(params, "loss "+strconv.FormatFloat(o.LossPct, 'f', -1, 64)+"%")
+	}
+	if o.RateKbit > 0 {
+		params = append(params, "rate "+strconv.Itoa(o.RateKbit)+"kbit")
+	}
+
+	return strings.Join(params, " ")
+}
+
+// script returns the shell script replacing the root queueing discipline of the network interfaces,
+// except the loopback one, with netem, or deleting it for the zero value.
+func (o NetemOptions) script() string {
+	action := `tc qdisc del dev "$dev" root 2>/dev/null || true`
+	if netem := o.netem(); netem != "" {
+		action = `tc qdisc replace dev "$dev" root netem ` + netem + " || exit 1"
+	}
+
+	return `for dev in $(ls /sys/class/net); do [ "$dev" = lo ] && continue; ` + action + `; done`
+}
+
+// shapingRequest returns the request of the sidecar running the script of the options with tc,
+// in the network namespace of the container with the given ID.
+func shapingRequest(containerID string, opts NetemOptions) ContainerRequest {
+	return ContainerRequest{
+		Image:      netshootImage,
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{opts.script()},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + containerID)
+			hostConfig.Privileged = true
+		},
+		WaitingFor: wait.ForExit(),
+	}
+}
+
+// networkShaper is implemented by the containers whose network traffic can be degraded, see ShapeNetwork.
+type networkShaper interface {
+	ShapeNetwork(ctx context.Context, opts NetemOptions) error
+}
+
+// ShapeNetwork degrades the network traffic sent by the running container, adding latency, dropping packets
+// or limiting the bandwidth. The zero value of the options removes the degradations.
+func ShapeNetwork(ctx context.Context, ctr Container, opts NetemOptions) error {
+	s, ok := asContainer[networkShaper](ctr)
+	if !ok {
+		return notSupportedError(CapabilityNetworkShaping)
+	}
+
+	return s.ShapeNetwork(ctx, opts)
+}
+
+// ShapeNetwork degrades the network traffic sent by the running container, adding latency, dropping packets
+// or limiting the bandwidth, e.g. for quick degradation tests where running toxiproxy in the path
+// of the traffic isn't feasible, like UDP or many ports. The options replace the previous ones,
+// and their zero value removes the degradations. It runs tc with netem in a privileged netshoot sidecar
+// sharing the network namespace of the container, removed once it exits. The container must not use
+// the host network mode, which would degrade the network of the host.
+func (c *DockerContainer) ShapeNetwork(ctx context.Context, opts NetemOptions) error {
+	if err := opts.validate(); err != nil {
+		return fmt.Errorf("shape network: %w", err)
+	}
+
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return fmt.Errorf("shape network: %w", err)
+	}
+
+	if inspect.HostConfig != nil && inspect.HostConfig.NetworkMode.IsHost() {
+		return errors.New("shape network: the container uses the host network mode")
+	}
+
+	req := GenericContainerRequest{
+		ContainerRequest: shapingRequest(c.ID, opts),
+		ProviderType:     ProviderDocker,
+		Logger:           c.logger,
+	}
+	if c.provider.isDaemonOverride() {
+		req.DockerClient = c.provider.client
+	}
+
+	if err := runUntilExit(ctx, req); err != nil {
+		return fmt.Errorf("shape network: %w", err)
+	}
+
+	return nil
+}
+
+// ShapeNetwork is not supported by nerdctl, it returns an error wrapping ErrNotSupported.
+func (c *NerdctlContainer) ShapeNetwork(context.Context, NetemOptions) error {
+	return notSupportedError(CapabilityNetworkShaping)
+}
@@ -0,0 +1,87 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNetemOptions(t *testing.T) {
+	t.Run("script", func(t *testing.T) {
+		opts := NetemOptions{DelayMs: 100, LossPct: 0.5, RateKbit: 512}
+
+		require.NoError(t, opts.validate())
+		require.Equal(t, "delay 100ms loss 0.5% rate 512kbit", opts.netem())
+		require.Equal(t,
+			`for dev in $(ls /sys/class/net); do [ "$dev" = lo ] && continue; tc qdisc replace dev "$dev" root netem delay 100ms loss 0.5% rate 512kbit || exit 1; done`,
+			opts.script())
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		require.Equal(t,
+			`for dev in $(ls /sys/class/net); do [ "$dev" = lo ] && continue; tc qdisc del dev "$dev" root 2>/dev/null || true; done`,
+			NetemOptions{}.script())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := NetemOptions{DelayMs: -1, LossPct: 101, RateKbit: -1}.validate()
+		require.ErrorContains(t, err, "negative delay")
+		require.ErrorContains(t, err, "loss 101% out of range")
+		require.ErrorContains(t, err, "negative rate")
+	})
+}
+
+func TestShapingRequest(t *testing.T) {
+	req := shapingRequest("abc", NetemOptions{DelayMs: 100})
+
+	require.Equal(t, netshootImage, req.Image)
+	require.IsType(t, &wait.ExitStrategy{}, req.WaitingFor)
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+	require.Equal(t, container.NetworkMode("container:abc"), hostConfig.NetworkMode)
+	require.True(t, hostConfig.Privileged)
+}
+
+func TestDockerContainer_ShapeNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	get := func() time.Duration {
+		start := time.Now()
+		resp, err := http.Get(endpoint)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		return time.Since(start)
+	}
+
+	// shapeNetwork {
+	err = ShapeNetwork(ctx, ctr, NetemOptions{DelayMs: 500})
+	// }
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, get(), 500*time.Millisecond)
+
+	require.NoError(t, ShapeNetwork(ctx, ctr, NetemOptions{}))
+	require.Less(t, get(), 500*time.Millisecond)
+}
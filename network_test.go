@@ -0,0 +1,48 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetworkDetails(t *testing.T) {
+	details := newNetworkDetails(network.Inspect{
+		ID:         "net-id",
+		Name:       "backend",
+		Driver:     "bridge",
+		Internal:   true,
+		Attachable: true,
+		Labels:     map[string]string{"app": "test"},
+		Options:    map[string]string{"com.docker.network.driver.mtu": "1400"},
+		IPAM: network.IPAM{
+			Config: []network.IPAMConfig{
+				{Subnet: "10.1.3.0/24", Gateway: "10.1.3.1"},
+				{Subnet: "fd00::/64", IPRange: "fd00::/80"},
+			},
+		},
+		Containers: map[string]network.EndpointResource{
+			"web-id": {Name: "web", IPv4Address: "10.1.3.3/24", IPv6Address: "fd00::3/64", MacAddress: "02:42:0a:01:03:03"},
+			"db-id":  {Name: "db", IPv4Address: "10.1.3.2/24"},
+		},
+	})
+
+	require.Equal(t, NetworkDetails{
+		ID:         "net-id",
+		Name:       "backend",
+		Driver:     "bridge",
+		Internal:   true,
+		Attachable: true,
+		Labels:     map[string]string{"app": "test"},
+		Options:    map[string]string{"com.docker.network.driver.mtu": "1400"},
+		Subnets: []NetworkSubnet{
+			{Subnet: "10.1.3.0/24", Gateway: "10.1.3.1"},
+			{Subnet: "fd00::/64", IPRange: "fd00::/80"},
+		},
+		Containers: []NetworkContainer{
+			{ID: "db-id", Name: "db", IPv4Address: "10.1.3.2"},
+			{ID: "web-id", Name: "web", IPv4Address: "10.1.3.3", IPv6Address: "fd00::3", MacAddress: "02:42:0a:01:03:03"},
+		},
+	}, details)
+}
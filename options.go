@@ -1,8 +1,12 @@
 package testcontainers
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/url"
 	"time"
 
@@ -75,6 +79,60 @@ func WithEnv(envs map[string]string) CustomizeRequestOption {
 	}
 }
 
+// WithHealthCheck sets the healthcheck for a container, overriding the one defined in the image.
+// Combine it with wait.ForHealthCheck to wait for the container to be healthy.
+func WithHealthCheck(healthCheck HealthCheck) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.HealthCheck = &healthCheck
+
+		return nil
+	}
+}
+
+// WithFileContent copies the content to a file in the container, before the container is started.
+// It doesn't need temporary files on the host, and works with remote Docker daemons, where bind mounts don't.
+func WithFileContent(containerFilePath string, content []byte, fileMode int64) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Files = append(req.Files, ContainerFile{
+			Reader:            bytes.NewReader(content),
+			ContainerFilePath: containerFilePath,
+			FileMode:          fileMode,
+		})
+
+		return nil
+	}
+}
+
+// WithFS copies the files of the file system, e.g. an embed.FS, to the target directory in the container,
+// before the container is started, preserving their permissions. The files are uploaded in a single archive,
+// without temporary files on the host, so it works with remote Docker daemons, where bind mounts don't.
+func WithFS(target string, fsys fs.FS) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if fsys == nil {
+			return errors.New("nil file system")
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostCreates: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					dc, ok := c.(*DockerContainer)
+					if !ok {
+						return fmt.Errorf("copy file system: unsupported container type %T", c)
+					}
+
+					if err := dc.copyFSToContainer(ctx, fsys, target); err != nil {
+						return fmt.Errorf("copy file system to %s: %w", target, err)
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
 // WithHostConfigModifier allows to override the default host config
 func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) error {
@@ -84,6 +142,29 @@ func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) Cus
 	}
 }
 
+// WithHostNetwork runs the container in the host network mode, sharing the network stack of the Docker host,
+// which avoids the NAT overhead of the mapped ports. It's supported on Linux hosts only, and the exposed ports
+// cannot be mapped to different host ports: MappedPort returns the container port itself.
+// The original host config modifier, if any, is preserved.
+func WithHostNetwork() CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.HostNetwork = true
+
+		if req.HostConfigModifier == nil {
+			req.HostConfigModifier = func(hostConfig *container.HostConfig) {}
+		}
+
+		originalHCM := req.HostConfigModifier
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			originalHCM(hostConfig)
+
+			hostConfig.NetworkMode = "host"
+		}
+
+		return nil
+	}
+}
+
 // WithHostPortAccess allows to expose the host ports to the container
 func WithHostPortAccess(ports ...int) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) error {
@@ -96,6 +177,21 @@ func WithHostPortAccess(ports ...int) CustomizeRequestOption {
 	}
 }
 
+// WithReuseByName names the container, and reuses the container with this name if it exists,
+// instead of creating a new one, e.g. to keep a container running across the runs of the tests.
+func WithReuseByName(name string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if name == "" {
+			return ErrReuseEmptyName
+		}
+
+		req.Name = name
+		req.Reuse = true
+
+		return nil
+	}
+}
+
 // Deprecated: the modules API forces passing the image as part of the signature of the Run function.
 // WithImage sets the image for a container
 func WithImage(image string) CustomizeRequestOption {
@@ -273,6 +369,18 @@ func (r RawCommand) AsCommand() []string {
 	return r.cmds
 }
 
+// WithStdin attaches the given reader to the container's stdin when the container starts.
+// The content of the reader is streamed to the main process of the container, and stdin
+// is closed as soon as the reader returns io.EOF, signalling the end of the input.
+// Use an [io.Pipe] to keep stdin open and close the writer to signal the EOF.
+func WithStdin(r io.Reader) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Stdin = r
+
+		return nil
+	}
+}
+
 // WithStartupCommand will execute the command representation of each Executable into the container.
 // It will leverage the container lifecycle hooks to call the command right after the container
 // is started.
@@ -3,8 +3,12 @@ package testcontainers_test
 import (
 	"context"
 	"io"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -245,3 +249,150 @@ func TestWithHostPortAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestWithHostNetwork(t *testing.T) {
+	var modified bool
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				modified = true
+				hostConfig.NetworkMode = "bridge"
+			},
+		},
+	}
+
+	err := testcontainers.WithHostNetwork()(&req)
+	require.NoError(t, err)
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+
+	require.True(t, modified)
+	require.True(t, hostConfig.NetworkMode.IsHost())
+	require.True(t, req.HostNetwork)
+}
+
+func TestWithFileContent(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{}
+
+	err := testcontainers.WithFileContent("/etc/app/config.yaml", []byte("key: value"), 0o644)(&req)
+	require.NoError(t, err)
+
+	require.Len(t, req.Files, 1)
+	require.Equal(t, "/etc/app/config.yaml", req.Files[0].ContainerFilePath)
+	require.Equal(t, int64(0o644), req.Files[0].FileMode)
+
+	content, err := io.ReadAll(req.Files[0].Reader)
+	require.NoError(t, err)
+	require.Equal(t, "key: value", string(content))
+}
+
+func TestWithFS(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+
+		err := testcontainers.WithFS("/etc/app", nil)(&req)
+		require.Error(t, err)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"config.yaml":     {Data: []byte("key: value"), Mode: 0o644},
+			"scripts/init.sh": {Data: []byte("#!/bin/sh"), Mode: 0o755},
+		}
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "alpine",
+				Entrypoint: []string{"tail", "-f", "/dev/null"},
+			},
+			Started: true,
+		}
+
+		err := testcontainers.WithFS("/etc/app", fsys)(&req)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		r, err := c.CopyFileFromContainer(ctx, "/etc/app/scripts/init.sh")
+		require.NoError(t, err)
+		defer r.Close()
+
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "#!/bin/sh", string(content))
+	})
+}
+
+func TestWithStdin(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "alpine",
+			Cmd:        []string{"sh", "-c", "while read line; do echo \"got: $line\"; done; echo done"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	}
+
+	err := testcontainers.WithStdin(strings.NewReader("foo\nbar\n"))(&req)
+	require.NoError(t, err)
+	require.NotNil(t, req.Stdin)
+
+	ctx := context.Background()
+	c, err := testcontainers.GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "got: foo\ngot: bar\ndone\n", string(logs))
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "alpine",
+			Entrypoint: []string{"sh", "-c", "sleep 2 && touch /tmp/ready && tail -f /dev/null"},
+			WaitingFor: wait.ForHealthCheck().WithFailOnUnhealthy().WithStartPeriodAware(),
+		},
+		Started: true,
+	}
+
+	err := testcontainers.WithHealthCheck(testcontainers.HealthCheck{
+		Test:          []string{"CMD", "test", "-f", "/tmp/ready"},
+		Interval:      500 * time.Millisecond,
+		StartPeriod:   5 * time.Second,
+		StartInterval: 100 * time.Millisecond,
+		Retries:       1,
+	})(&req)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	c, err := testcontainers.GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	inspect, err := c.Inspect(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, inspect.Config.Healthcheck)
+	assert.Equal(t, []string{"CMD", "test", "-f", "/tmp/ready"}, inspect.Config.Healthcheck.Test)
+	assert.Equal(t, 5*time.Second, inspect.Config.Healthcheck.StartPeriod)
+	assert.Equal(t, "healthy", inspect.State.Health.Status)
+}
+
+func TestWithReuseByName(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{}
+
+	require.ErrorIs(t, testcontainers.WithReuseByName("")(&req), testcontainers.ErrReuseEmptyName)
+	require.False(t, req.Reuse)
+
+	require.NoError(t, testcontainers.WithReuseByName("compat-postgres-16")(&req))
+	require.Equal(t, "compat-postgres-16", req.Name)
+	require.True(t, req.Reuse)
+}
@@ -0,0 +1,220 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// capturePath is the path of the capture in the sidecar capturing the packets of a container.
+const capturePath = "/tmp/capture.pcap"
+
+// PacketCapture is a capture of the network traffic of a container, started with StartPacketCapture.
+type PacketCapture struct {
+	sidecar Container
+
+	once sync.Once
+	file string
+	err  error
+}
+
+// captureRequest returns the request of the sidecar capturing the packets matching the filter, if any,
+// with tcpdump in the network namespace of the container with the given ID. The packets are written
+// to the capture as soon as they are captured, so it can be copied while tcpdump is running.
+func captureRequest(containerID string, filter string) ContainerRequest {
+	cmd := []string{"tcpdump", "-i", "any", "-U", "-w", capturePath}
+	if filter != "" {
+		cmd = append(cmd, filter)
+	}
+
+	return ContainerRequest{
+		Image: netshootImage,
+		Cmd:   cmd,
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + containerID)
+			hostConfig.CapAdd = append(hostConfig.CapAdd, "NET_ADMIN", "NET_RAW")
+		},
+		WaitingFor: wait.ForLog("listening on"),
+	}
+}
+
+// packetCapturer is implemented by the containers whose network traffic can be captured, see StartPacketCapture.
+type packetCapturer interface {
+	StartPacketCapture(ctx context.Context, filter string) (*PacketCapture, error)
+}
+
+// StartPacketCapture starts capturing the network traffic of the running container matching the pcap filter,
+// e.g. tcp port 5432, or all its traffic if empty. Stopping the capture returns the pcap file of the captured packets.
+func StartPacketCapture(ctx context.Context, ctr Container, filter string) (*PacketCapture, error) {
+	c, ok := asContainer[packetCapturer](ctr)
+	if !ok {
+		return nil, notSupportedError(CapabilityPacketCapture)
+	}
+
+	return c.StartPacketCapture(ctx, filter)
+}
+
+// StartPacketCapture starts capturing the network traffic of the running container matching the pcap filter,
+// e.g. tcp port 5432, or all its traffic if empty. It runs tcpdump in a netshoot sidecar sharing the network namespace
+// of the container, until the capture is stopped.
+func (c *DockerContainer) StartPacketCapture(ctx context.Context, filter string) (*PacketCapture, error) {
+	req := GenericContainerRequest{
+		ContainerRequest: captureRequest(c.ID, filter),
+		ProviderType:     ProviderDocker,
+		Started:          true,
+		Logger:           c.logger,
+	}
+	if c.provider.isDaemonOverride() {
+		req.DockerClient = c.provider.client
+	}
+
+	sidecar, err := GenericContainer(ctx, req)
+	if err != nil {
+		if sidecar != nil {
+			err = errors.Join(err, sidecar.Terminate(context.Background()))
+		}
+		return nil, fmt.Errorf("start packet capture: %w", err)
+	}
+
+	return &PacketCapture{sidecar: sidecar}, nil
+}
+
+// StartPacketCapture is not supported by nerdctl, it returns an error wrapping ErrNotSupported.
+func (c *NerdctlContainer) StartPacketCapture(context.Context, string) (*PacketCapture, error) {
+	return nil, notSupportedError(CapabilityPacketCapture)
+}
+
+// Stop stops the capture, removing its sidecar, and returns the path of the pcap file of the captured packets,
+// created in the temporary directory, e.g. to be read by Wireshark. The next calls return the same file.
+func (p *PacketCapture) Stop(ctx context.Context) (string, error) {
+	p.once.Do(func() {
+		p.file, p.err = p.save(ctx)
+		if err := p.sidecar.Terminate(context.Background()); err != nil {
+			p.err = errors.Join(p.err, fmt.Errorf("terminate sidecar: %w", err))
+		}
+	})
+
+	return p.file, p.err
+}
+
+// save copies the capture of the sidecar to a temporary file, returning its path.
+func (p *PacketCapture) save(ctx context.Context) (string, error) {
+	r, err := p.sidecar.CopyFileFromContainer(ctx, capturePath)
+	if err != nil {
+		return "", fmt.Errorf("copy capture: %w", err)
+	}
+	defer r.Close()
+
+	f, err := os.CreateTemp("", "capture-*.pcap")
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		return "", errors.Join(fmt.Errorf("write capture: %w", err), f.Close(), os.Remove(f.Name()))
+	}
+
+	return f.Name(), f.Close()
+}
+
+// WithPacketCaptureOnFailure captures the network traffic of the container matching the pcap filter, e.g. tcp port 5432,
+// or all its traffic if empty, from its start until it's terminated. If the test failed, the capture is moved to
+// capture.pcap in a directory named after the test and the container in destDir, as the artifacts collected
+// by WithArtifactsOnFailure, to be archived by the CI build, otherwise it's removed. The errors of the capture
+// are logged to the test, without failing the container.
+func WithPacketCaptureOnFailure(tb testing.TB, destDir string, filter string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if destDir == "" {
+			return errors.New("empty artifacts directory")
+		}
+
+		var capture *PacketCapture
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostStarts: []ContainerHook{
+				func(ctx context.Context, ctr Container) error {
+					var err error
+					if capture, err = StartPacketCapture(ctx, ctr, filter); err != nil {
+						tb.Logf("packet capture: %v", err)
+					}
+
+					return nil
+				},
+			},
+			PreTerminates: []ContainerHook{
+				func(ctx context.Context, ctr Container) error {
+					if capture == nil {
+						return nil
+					}
+
+					file, err := capture.Stop(ctx)
+					if err != nil {
+						tb.Logf("packet capture: %v", err)
+					}
+					if file == "" {
+						return nil
+					}
+
+					if !tb.Failed() {
+						_ = os.Remove(file)
+						return nil
+					}
+
+					if err := keepCapture(ctx, tb, ctr, file, destDir); err != nil {
+						tb.Logf("packet capture: %v", err)
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// keepCapture moves the capture of the container to the directory of its artifacts in destDir.
+func keepCapture(ctx context.Context, tb testing.TB, ctr Container, file string, destDir string) error {
+	name, err := containerLogName(ctx, ctr)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(destDir, unsafeFileNameChars.ReplaceAllString(tb.Name(), "_"), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	dest := filepath.Join(dir, "capture.pcap")
+	if err := os.Rename(file, dest); err != nil {
+		// the temporary directory may be on another device
+		if err := copyFile(file, dest); err != nil {
+			return err
+		}
+		_ = os.Remove(file)
+	}
+
+	tb.Logf("packet capture of %s kept in %s", name, dest)
+
+	return nil
+}
+
+// copyFile copies the file src to dst.
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return writeArtifact(dst, in)
+}
@@ -0,0 +1,156 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// pcapMagic is the magic number of the pcap files, in little endian.
+var pcapMagic = []byte{0xd4, 0xc3, 0xb2, 0xa1}
+
+// fakeSidecar is a capture sidecar returning the given capture.
+type fakeSidecar struct {
+	Container
+	capture    []byte
+	terminated int
+}
+
+func (c *fakeSidecar) CopyFileFromContainer(context.Context, string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(c.capture)), nil
+}
+
+func (c *fakeSidecar) Terminate(context.Context, ...TerminateOption) error {
+	c.terminated++
+	return nil
+}
+
+// fakeCaptureContainer is a container whose captures run in the fake sidecar.
+type fakeCaptureContainer struct {
+	fakeArtifactsContainer
+	sidecar *fakeSidecar
+	filter  string
+}
+
+func (c *fakeCaptureContainer) StartPacketCapture(_ context.Context, filter string) (*PacketCapture, error) {
+	c.filter = filter
+	return &PacketCapture{sidecar: c.sidecar}, nil
+}
+
+func TestCaptureRequest(t *testing.T) {
+	req := captureRequest("abc", "tcp port 5432")
+
+	require.Equal(t, netshootImage, req.Image)
+	require.Equal(t, []string{"tcpdump", "-i", "any", "-U", "-w", capturePath, "tcp port 5432"}, req.Cmd)
+	require.IsType(t, &wait.LogStrategy{}, req.WaitingFor)
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+	require.Equal(t, container.NetworkMode("container:abc"), hostConfig.NetworkMode)
+	require.ElementsMatch(t, []string{"NET_ADMIN", "NET_RAW"}, hostConfig.CapAdd)
+
+	require.Equal(t, []string{"tcpdump", "-i", "any", "-U", "-w", capturePath}, captureRequest("abc", "").Cmd)
+}
+
+func TestPacketCapture_Stop(t *testing.T) {
+	sidecar := &fakeSidecar{capture: pcapMagic}
+	capture := &PacketCapture{sidecar: sidecar}
+
+	file, err := capture.Stop(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.Remove(file))
+	})
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, pcapMagic, content)
+
+	again, err := capture.Stop(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, file, again)
+	require.Equal(t, 1, sidecar.terminated)
+}
+
+func TestWithPacketCaptureOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Error(t, WithPacketCaptureOnFailure(t, "", "")(&GenericContainerRequest{}))
+	})
+
+	for _, failed := range []bool{false, true} {
+		tb := &failingTest{TB: t, name: "TestHandler/create order", failed: failed}
+		destDir := t.TempDir()
+
+		req := GenericContainerRequest{}
+		require.NoError(t, WithPacketCaptureOnFailure(tb, destDir, "tcp port 80")(&req))
+		require.Len(t, req.LifecycleHooks, 1)
+
+		ctr := &fakeCaptureContainer{sidecar: &fakeSidecar{capture: pcapMagic}}
+		require.NoError(t, req.LifecycleHooks[0].PostStarts[0](ctx, ctr))
+		require.Equal(t, "tcp port 80", ctr.filter)
+
+		require.NoError(t, req.LifecycleHooks[0].PreTerminates[0](ctx, ctr))
+		require.Equal(t, 1, ctr.sidecar.terminated)
+
+		file := filepath.Join(destDir, "TestHandler_create_order", "funny_name", "capture.pcap")
+		if !failed {
+			require.NoFileExists(t, file)
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, pcapMagic, content)
+	}
+}
+
+func TestDockerContainer_StartPacketCapture(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// startPacketCapture {
+	capture, err := StartPacketCapture(ctx, ctr, "tcp port 80")
+	require.NoError(t, err)
+	// }
+
+	endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint + "/captured")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// stopPacketCapture {
+	file, err := capture.Stop(ctx)
+	require.NoError(t, err)
+	// }
+	t.Cleanup(func() {
+		require.NoError(t, os.Remove(file))
+	})
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, pcapMagic, content[:4])
+	require.Contains(t, string(content), "GET /captured")
+}
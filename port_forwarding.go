@@ -148,6 +148,23 @@ func exposeHostPorts(ctx context.Context, req *ContainerRequest, ports ...int) (
 	return sshdConnectHook, nil
 }
 
+// exposeHostInternal resolves the internal hostname to the given address in the container,
+// for the cases where the host is directly reachable from the container, e.g. over a VPN.
+func exposeHostInternal(req *ContainerRequest, address string) {
+	if req.HostConfigModifier == nil {
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {}
+	}
+
+	// do not override the original HostConfigModifier
+	originalHCM := req.HostConfigModifier
+	req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, fmt.Sprintf("%s:%s", HostInternal, address))
+
+		// invoke the original HostConfigModifier with the updated hostConfig
+		originalHCM(hostConfig)
+	}
+}
+
 // newSshdContainer creates a new SSHD container with the provided options.
 func newSshdContainer(ctx context.Context, opts ...ContainerCustomizer) (*sshdContainer, error) {
 	req := GenericContainerRequest{
@@ -196,21 +213,30 @@ func newSshdContainer(ctx context.Context, opts ...ContainerCustomizer) (*sshdCo
 // It's an internal type that extends the DockerContainer type, to add the SSH tunneling capabilities.
 type sshdContainer struct {
 	*DockerContainer
+	host           string
 	port           string
 	sshConfig      *ssh.ClientConfig
 	portForwarders []PortForwarder
 }
 
 // Terminate stops the container and closes the SSH session
-func (sshdC *sshdContainer) Terminate(ctx context.Context) error {
+func (sshdC *sshdContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
 	for _, pfw := range sshdC.portForwarders {
 		pfw.Close(ctx)
 	}
 
-	return sshdC.DockerContainer.Terminate(ctx)
+	return sshdC.DockerContainer.Terminate(ctx, opts...)
 }
 
 func configureSSHConfig(ctx context.Context, sshdC *sshdContainer) (*ssh.ClientConfig, error) {
+	// the SSHD container is reachable on the host of the mapped ports, e.g. the gateway
+	// of the Docker network when the tests run in a container.
+	host, err := sshdC.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sshdC.host = host
+
 	mappedPort, err := sshdC.MappedPort(ctx, sshPort)
 	if err != nil {
 		return nil, err
@@ -229,10 +255,11 @@ func configureSSHConfig(ctx context.Context, sshdC *sshdContainer) (*ssh.ClientC
 
 func (sshdC *sshdContainer) exposeHostPort(ctx context.Context, ports ...int) error {
 	for _, port := range ports {
-		pw := NewPortForwarder(fmt.Sprintf("localhost:%s", sshdC.port), sshdC.sshConfig, port, port)
+		pw := NewPortForwarder(net.JoinHostPort(sshdC.host, sshdC.port), sshdC.sshConfig, port, port)
 		sshdC.portForwarders = append(sshdC.portForwarders, *pw)
 
-		go pw.Forward(ctx) //nolint:errcheck // Nothing we can usefully do with the error
+		// the forwarding outlives the context of the hook, e.g. with a start timeout, and is stopped when the container is terminated
+		go pw.Forward(context.WithoutCancel(ctx)) //nolint:errcheck // Nothing we can usefully do with the error
 	}
 
 	var err error
@@ -0,0 +1,196 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// socatImage is the image of the relays of the ports exposed after the start of the containers.
+	// hubSocatImage {
+	socatImage = "alpine/socat:1.8.0.0"
+	// }
+)
+
+// relayRequest returns the request of the socat container relaying the port to the same port
+// of the given IP address, in the given network.
+func relayRequest(networkName string, ip string, port nat.Port) ContainerRequest {
+	listen, connect := "TCP-LISTEN", "TCP"
+	if port.Proto() == "udp" {
+		listen, connect = "UDP-LISTEN", "UDP"
+	}
+
+	req := ContainerRequest{
+		Image:        socatImage,
+		ExposedPorts: []string{string(port)},
+		Cmd: []string{
+			fmt.Sprintf("%s:%s,fork,reuseaddr", listen, port.Port()),
+			fmt.Sprintf("%s:%s:%s", connect, ip, port.Port()),
+		},
+	}
+
+	if networkName != "" && networkName != Bridge {
+		req.Networks = []string{networkName}
+	}
+
+	if port.Proto() != "udp" {
+		req.WaitingFor = wait.ForListeningPort(port)
+	}
+
+	return req
+}
+
+// normalizePort returns the port with its protocol, tcp by default.
+func normalizePort(port nat.Port) (nat.Port, error) {
+	return nat.NewPort(port.Proto(), port.Port())
+}
+
+// portExposer is implemented by the containers exposing ports when running, see ExposeAdditionalPort.
+type portExposer interface {
+	ExposeAdditionalPort(ctx context.Context, containerPort nat.Port) (nat.Port, error)
+}
+
+// ExposeAdditionalPort exposes a port of the running container which was not exposed when it was created,
+// e.g. a port opened lazily by the service, returning its mapped port.
+func ExposeAdditionalPort(ctx context.Context, ctr Container, containerPort nat.Port) (nat.Port, error) {
+	e, ok := asContainer[portExposer](ctr)
+	if !ok {
+		return "", unsupportedError(ctr, "expose additional port")
+	}
+
+	return e.ExposeAdditionalPort(ctx, containerPort)
+}
+
+// ExposeAdditionalPort exposes a port of the running container which was not exposed when it was created,
+// e.g. a port opened lazily by the service after its configuration, returning its mapped port.
+// As Docker can't publish new ports of a running container, a socat container relaying the port is started
+// in the same network, and the mapped port of the relay is returned by MappedPort and PortEndpoint.
+// The relay is terminated with the container.
+func (c *DockerContainer) ExposeAdditionalPort(ctx context.Context, containerPort nat.Port) (nat.Port, error) {
+	return c.relays.expose(ctx, c, containerPort, func(ctx context.Context, req ContainerRequest) (Container, error) {
+		genericReq := GenericContainerRequest{
+			ContainerRequest: req,
+			ProviderType:     ProviderDocker,
+			Started:          true,
+			Logger:           c.logger,
+		}
+		if c.provider.isDaemonOverride() {
+			genericReq.DockerClient = c.provider.client
+		}
+
+		return GenericContainer(ctx, genericReq)
+	})
+}
+
+// ExposeAdditionalPort exposes a port of the running container which was not exposed when it was created,
+// relaying it with a socat container in the same network, as with the Docker provider.
+func (c *NerdctlContainer) ExposeAdditionalPort(ctx context.Context, containerPort nat.Port) (nat.Port, error) {
+	return c.relays.expose(ctx, c, containerPort, c.provider.RunContainer)
+}
+
+// portRelays are the socat containers relaying the ports exposed with ExposeAdditionalPort, by port.
+type portRelays struct {
+	relays map[nat.Port]Container
+	mu     sync.Mutex
+}
+
+// expose starts the relay of the port of the container with run, if it's not running yet,
+// returning its mapped port.
+func (r *portRelays) expose(ctx context.Context, c Container, containerPort nat.Port, run func(context.Context, ContainerRequest) (Container, error)) (nat.Port, error) {
+	port, err := normalizePort(containerPort)
+	if err != nil {
+		return "", fmt.Errorf("invalid port %s: %w", containerPort, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if relay, ok := r.relays[port]; ok {
+		return relay.MappedPort(ctx, port)
+	}
+
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if inspect.HostConfig != nil && inspect.HostConfig.NetworkMode.IsHost() {
+		// the ports are reachable on the host in the host network mode
+		return port, nil
+	}
+
+	networkName, ip, err := relayTarget(inspect.NetworkSettings.Networks)
+	if err != nil {
+		return "", err
+	}
+
+	relay, err := run(ctx, relayRequest(networkName, ip, port))
+	if err != nil {
+		if relay != nil {
+			err = errors.Join(err, relay.Terminate(ctx))
+		}
+		return "", fmt.Errorf("start relay of port %s: %w", port, err)
+	}
+
+	if r.relays == nil {
+		r.relays = map[nat.Port]Container{}
+	}
+	r.relays[port] = relay
+
+	return relay.MappedPort(ctx, port)
+}
+
+// get returns the relay of the port, if it was exposed with ExposeAdditionalPort.
+func (r *portRelays) get(port nat.Port) Container {
+	port, err := normalizePort(port)
+	if err != nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.relays[port]
+}
+
+// terminate terminates the relays.
+func (r *portRelays) terminate(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, 0, len(r.relays))
+	for port, relay := range r.relays {
+		errs = append(errs, relay.Terminate(ctx))
+		delete(r.relays, port)
+	}
+
+	return errors.Join(errs...)
+}
+
+// relayTarget returns the network of the relay of a port, and the IP address of the container in it,
+// preferring a user-defined network, where the containers can be reached by name.
+func relayTarget(networks map[string]*network.EndpointSettings) (string, string, error) {
+	var name, ip string
+	for n, settings := range networks {
+		if settings == nil || settings.IPAddress == "" {
+			continue
+		}
+
+		if name == "" || name == Bridge || (n != Bridge && n < name) {
+			name, ip = n, settings.IPAddress
+		}
+	}
+
+	if name == "" {
+		return "", "", errors.New("the container has no IP address to relay the port to")
+	}
+
+	return name, ip, nil
+}
@@ -0,0 +1,70 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayRequest(t *testing.T) {
+	t.Run("tcp", func(t *testing.T) {
+		req := relayRequest("backend", "172.18.0.2", "8080/tcp")
+
+		require.Equal(t, []string{"8080/tcp"}, req.ExposedPorts)
+		require.Equal(t, []string{"TCP-LISTEN:8080,fork,reuseaddr", "TCP:172.18.0.2:8080"}, req.Cmd)
+		require.Equal(t, []string{"backend"}, req.Networks)
+		require.NotNil(t, req.WaitingFor)
+	})
+
+	t.Run("udp-bridge", func(t *testing.T) {
+		req := relayRequest(Bridge, "172.17.0.2", "53/udp")
+
+		require.Equal(t, []string{"UDP-LISTEN:53,fork,reuseaddr", "UDP:172.17.0.2:53"}, req.Cmd)
+		require.Empty(t, req.Networks)
+		require.Nil(t, req.WaitingFor)
+	})
+}
+
+func TestRelayTarget(t *testing.T) {
+	name, ip, err := relayTarget(map[string]*network.EndpointSettings{
+		Bridge:    {IPAddress: "172.17.0.2"},
+		"backend": {IPAddress: "172.18.0.2"},
+		"pending": {},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "backend", name)
+	require.Equal(t, "172.18.0.2", ip)
+
+	_, _, err = relayTarget(map[string]*network.EndpointSettings{"none": {}})
+	require.Error(t, err)
+}
+
+func TestDockerContainer_ExposeAdditionalPort(t *testing.T) {
+	ctx := context.Background()
+
+	// the port is not exposed when the container is created
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	mappedPort, err := ExposeAdditionalPort(ctx, ctr, "80")
+	require.NoError(t, err)
+
+	port, err := ctr.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	require.Equal(t, mappedPort, port)
+
+	endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
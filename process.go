@@ -0,0 +1,67 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrProcessNotFound is returned by KillProcess when no process of the container matches the pattern.
+var ErrProcessNotFound = errors.New("process not found")
+
+// killProcess kills with SIGKILL the processes of the container whose full command line matches the extended
+// regular expression, found with pgrep. The image of the container must provide pgrep and kill, e.g. with procps
+// or busybox. The container exits if its main process is killed.
+func killProcess(ctx context.Context, ctr Container, pattern string) error {
+	if pattern == "" {
+		return errors.New("kill process: empty pattern")
+	}
+
+	// pgrep doesn't match itself, unlike a shell running it
+	exitCode, output, err := execOutput(ctx, ctr, []string{"pgrep", "-f", pattern})
+	if err != nil {
+		return fmt.Errorf("kill process: pgrep: %w", err)
+	}
+
+	switch exitCode {
+	case 0:
+	case 1:
+		return fmt.Errorf("kill process: %w: %s", ErrProcessNotFound, pattern)
+	default:
+		return fmt.Errorf("kill process: pgrep: exit code %d: %s", exitCode, output)
+	}
+
+	pids, err := parsePIDs(output)
+	if err != nil {
+		return fmt.Errorf("kill process: %w", err)
+	}
+
+	exitCode, output, err = execOutput(ctx, ctr, append([]string{"kill", "-KILL"}, pids...))
+	if err != nil {
+		return fmt.Errorf("kill process: kill: %w", err)
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("kill process: kill: exit code %d: %s", exitCode, output)
+	}
+
+	return nil
+}
+
+// parsePIDs returns the process IDs printed by pgrep, one per line.
+func parsePIDs(output string) ([]string, error) {
+	pids := strings.Fields(output)
+	for _, pid := range pids {
+		if _, err := strconv.Atoi(pid); err != nil {
+			return nil, fmt.Errorf("unexpected pgrep output %q", output)
+		}
+	}
+
+	if len(pids) == 0 {
+		return nil, fmt.Errorf("unexpected pgrep output %q", output)
+	}
+
+	return pids, nil
+}
@@ -0,0 +1,108 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// fakeProcessContainer is a container recording the commands it executes, answering pgrep with the given output.
+type fakeProcessContainer struct {
+	Container
+	pgrepExitCode int
+	pgrepOutput   string
+	cmds          [][]string
+}
+
+func (c *fakeProcessContainer) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	c.cmds = append(c.cmds, cmd)
+
+	if cmd[0] == "pgrep" {
+		return c.pgrepExitCode, strings.NewReader(c.pgrepOutput), nil
+	}
+
+	return 0, strings.NewReader(""), nil
+}
+
+func TestKillProcess(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("kill", func(t *testing.T) {
+		ctr := &fakeProcessContainer{pgrepOutput: "12\n34\n"}
+
+		require.NoError(t, killProcess(ctx, ctr, "nginx: worker"))
+		require.Equal(t, [][]string{
+			{"pgrep", "-f", "nginx: worker"},
+			{"kill", "-KILL", "12", "34"},
+		}, ctr.cmds)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		ctr := &fakeProcessContainer{pgrepExitCode: 1}
+
+		require.ErrorIs(t, killProcess(ctx, ctr, "nginx: worker"), ErrProcessNotFound)
+		require.Len(t, ctr.cmds, 1)
+	})
+
+	t.Run("no-pgrep", func(t *testing.T) {
+		ctr := &fakeProcessContainer{pgrepExitCode: 126, pgrepOutput: "pgrep: executable file not found in $PATH"}
+
+		err := killProcess(ctx, ctr, "nginx: worker")
+		require.ErrorContains(t, err, "exit code 126")
+		require.NotErrorIs(t, err, ErrProcessNotFound)
+	})
+
+	t.Run("empty-pattern", func(t *testing.T) {
+		require.Error(t, killProcess(ctx, &fakeProcessContainer{}, ""))
+	})
+}
+
+func TestDockerContainer_KillProcess(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// killProcess {
+	err = KillProcess(ctx, ctr, "nginx: worker process")
+	// }
+	require.NoError(t, err)
+
+	// the main process is not affected
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Running)
+
+	err = KillProcess(ctx, ctr, "no such process")
+	require.ErrorIs(t, err, ErrProcessNotFound)
+}
+
+func TestDockerContainer_Signal(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// signal {
+	// nginx reloads its configuration on SIGHUP
+	err = Signal(ctx, ctr, "SIGHUP")
+	// }
+	require.NoError(t, err)
+
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Running)
+}
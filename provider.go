@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/docker/docker/client"
+
 	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
@@ -16,6 +18,8 @@ const (
 	ProviderDefault ProviderType = iota // default will auto-detect provider from DOCKER_HOST environment variable
 	ProviderDocker
 	ProviderPodman
+	ProviderNerdctl // experimental: runs the containers in containerd with the nerdctl CLI, see NerdctlProvider
+	ProviderMicroVM // experimental: runs the containers as microVMs with firecracker-containerd, see NewMicroVMProvider
 )
 
 type (
@@ -40,6 +44,10 @@ type (
 	// DockerProviderOptions defines options applicable to DockerProvider
 	DockerProviderOptions struct {
 		defaultBridgeNetworkName string
+		hostOverride             string
+		hostInternalOverride     string
+		dockerClient             client.APIClient
+		dockerHost               string
 		*GenericProviderOptions
 	}
 
@@ -79,6 +87,24 @@ func WithDefaultBridgeNetwork(bridgeNetworkName string) DockerProviderOption {
 	})
 }
 
+// WithHostOverride sets the host used to reach the mapped ports of the containers created by the provider,
+// taking precedence over the host.override property and the TESTCONTAINERS_HOST_OVERRIDE environment variable.
+func WithHostOverride(host string) DockerProviderOption {
+	return DockerProviderOptionFunc(func(opts *DockerProviderOptions) {
+		opts.hostOverride = host
+	})
+}
+
+// WithHostInternalOverride sets the address the containers created by the provider use to reach the host,
+// taking precedence over the host.internal.override property and the TESTCONTAINERS_HOST_INTERNAL_OVERRIDE
+// environment variable. The host.testcontainers.internal hostname is resolved to it in the containers
+// exposing host ports, so no SSHD container is started to forward them.
+func WithHostInternalOverride(address string) DockerProviderOption {
+	return DockerProviderOptionFunc(func(opts *DockerProviderOptions) {
+		opts.hostInternalOverride = address
+	})
+}
+
 func (f GenericProviderOptionFunc) ApplyGenericTo(opts *GenericProviderOptions) {
 	f(opts)
 }
@@ -104,7 +130,7 @@ func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvide
 	}
 
 	pt := t
-	if pt == ProviderDefault && strings.Contains(os.Getenv("DOCKER_HOST"), "podman.sock") {
+	if pt == ProviderDefault && isPodmanHost() {
 		pt = ProviderPodman
 	}
 
@@ -123,10 +149,32 @@ func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvide
 			return nil, fmt.Errorf("%w, failed to create Docker provider", err)
 		}
 		return provider, nil
+	case ProviderNerdctl:
+		provider, err := NewNerdctlProvider(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%w, failed to create nerdctl provider", err)
+		}
+		return provider, nil
+	case ProviderMicroVM:
+		provider, err := NewMicroVMProvider(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%w, failed to create microVM provider", err)
+		}
+		return provider, nil
 	}
 	return nil, errors.New("unknown provider")
 }
 
+// isPodmanHost returns true if the Docker host is a Podman socket, either set in the
+// DOCKER_HOST environment variable, or discovered from the well-known locations (e.g. Podman machine).
+func isPodmanHost() bool {
+	if strings.Contains(os.Getenv("DOCKER_HOST"), "podman.sock") {
+		return true
+	}
+
+	return strings.Contains(core.MustExtractDockerHost(context.Background()), "podman.sock")
+}
+
 // NewDockerProvider creates a Docker provider with the EnvClient
 func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error) {
 	o := &DockerProviderOptions{
@@ -140,6 +188,22 @@ func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error
 	}
 
 	ctx := context.Background()
+
+	// the Docker daemon set with WithDockerClient or WithDockerHost
+	if o.dockerClient != nil || o.dockerHost != "" {
+		c, host, err := newDaemonClient(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DockerProvider{
+			DockerProviderOptions: o,
+			host:                  host,
+			client:                c,
+			config:                config.Read(),
+		}, nil
+	}
+
 	c, err := NewDockerClientWithOpts(ctx)
 	if err != nil {
 		return nil, err
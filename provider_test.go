@@ -4,6 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
@@ -79,3 +83,75 @@ func TestProviderTypeGetProviderAutodetect(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerProviderHostOverrides(t *testing.T) {
+	newProvider := func(cfg config.Config, opts ...DockerProviderOption) *DockerProvider {
+		o := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+		for _, opt := range opts {
+			opt.ApplyDockerTo(o)
+		}
+
+		return &DockerProvider{DockerProviderOptions: o, config: cfg}
+	}
+
+	t.Run("mapped-ports/option-wins", func(t *testing.T) {
+		t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "env.example.com")
+
+		p := newProvider(config.Config{HostOverride: "config.example.com"}, WithHostOverride("option.example.com"))
+
+		host, err := p.DaemonHost(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "option.example.com", host)
+	})
+
+	t.Run("mapped-ports/config", func(t *testing.T) {
+		p := newProvider(config.Config{HostOverride: "config.example.com"})
+
+		host, err := p.DaemonHost(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "config.example.com", host)
+	})
+
+	t.Run("mapped-ports/config-wins-over-env", func(t *testing.T) {
+		// the env variable is only read into the configuration, which SetConfig overrides
+		t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "env.example.com")
+
+		p := newProvider(config.Config{HostOverride: "config.example.com"})
+
+		host, err := p.DaemonHost(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "config.example.com", host)
+	})
+
+	t.Run("host-internal/option-wins", func(t *testing.T) {
+		p := newProvider(config.Config{HostInternalOverride: "10.0.0.1"}, WithHostInternalOverride("10.0.0.2"))
+		require.Equal(t, "10.0.0.2", p.HostInternalOverride())
+	})
+
+	t.Run("host-internal/config", func(t *testing.T) {
+		p := newProvider(config.Config{HostInternalOverride: "10.0.0.1"})
+		require.Equal(t, "10.0.0.1", p.HostInternalOverride())
+	})
+
+	t.Run("host-internal/not-set", func(t *testing.T) {
+		p := newProvider(config.Config{})
+		require.Empty(t, p.HostInternalOverride())
+	})
+}
+
+func TestExposeHostInternal(t *testing.T) {
+	var modified bool
+	req := ContainerRequest{
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			modified = true
+		},
+	}
+
+	exposeHostInternal(&req, "10.0.0.1")
+
+	hostConfig := &container.HostConfig{}
+	req.HostConfigModifier(hostConfig)
+
+	require.True(t, modified)
+	require.Equal(t, []string{HostInternal + ":10.0.0.1"}, hostConfig.ExtraHosts)
+}
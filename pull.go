@@ -0,0 +1,94 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/distribution/reference"
+)
+
+// pullLimiter limits the number of images pulled at the same time by the process,
+// so the parallel tests don't exceed the rate limits of the registries.
+type pullLimiter struct {
+	once  sync.Once
+	slots chan struct{}
+}
+
+// pulls is the limiter of the pulls of the process.
+var pulls pullLimiter
+
+// acquire waits for a pull slot, the limit being read on the first call. Zero means no limit.
+// It returns the function releasing the slot.
+func (l *pullLimiter) acquire(ctx context.Context, limit int) (func(), error) {
+	l.once.Do(func() {
+		if limit > 0 {
+			l.slots = make(chan struct{}, limit)
+		}
+	})
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for a pull slot: %w", ctx.Err())
+	}
+}
+
+// isRateLimitError reports whether the pull failed because of the rate limit of the registry,
+// i.e. a 429 Too Many Requests response, which the Docker daemon reports as a system error.
+func isRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	return strings.Contains(msg, "toomanyrequests") ||
+		strings.Contains(msg, "too many requests") ||
+		strings.Contains(msg, "rate limit")
+}
+
+// pullBackOff returns the backoff of the retries of a pull, jittered so the parallel pulls
+// don't hit the registry at the same time, and long enough for its rate limit to recover.
+func pullBackOff(ctx context.Context) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = time.Minute
+	b.RandomizationFactor = 0.5
+
+	return backoff.WithContext(b, ctx)
+}
+
+// hubMirrorImage returns the name of the Docker Hub image in the mirror, e.g. mirror.gcr.io/library/nginx:alpine
+// for nginx:alpine, or false if the image is not a Docker Hub image or there is no mirror.
+func hubMirrorImage(mirror, img string) (string, bool) {
+	if mirror == "" {
+		return "", false
+	}
+
+	named, err := reference.ParseNormalizedNamed(img)
+	if err != nil || reference.Domain(named) != "docker.io" {
+		return "", false
+	}
+
+	mirror = strings.TrimPrefix(mirror, "https://")
+	mirror = strings.TrimPrefix(mirror, "http://")
+	name := strings.TrimSuffix(mirror, "/") + "/" + reference.Path(named)
+
+	switch ref := reference.TagNameOnly(named).(type) {
+	case reference.Digested:
+		return name + "@" + ref.Digest().String(), true
+	case reference.Tagged:
+		return name + ":" + ref.Tag(), true
+	default:
+		return name, true
+	}
+}
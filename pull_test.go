@@ -0,0 +1,127 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullLimiter(t *testing.T) {
+	t.Run("no-limit", func(t *testing.T) {
+		var l pullLimiter
+		for range 10 {
+			_, err := l.acquire(context.Background(), 0)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		var l pullLimiter
+		release, err := l.acquire(context.Background(), 1)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// the limit is read on the first call
+		_, err = l.acquire(ctx, 2)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+
+		release, err = l.acquire(context.Background(), 1)
+		require.NoError(t, err)
+		release()
+	})
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "daemon",
+			err:  errdefs.System(errors.New("toomanyrequests: You have reached your pull rate limit. You may increase the limit by authenticating and upgrading")),
+			want: true,
+		},
+		{
+			name: "stream",
+			err:  &jsonmessage.JSONError{Code: 429, Message: "429 Too Many Requests"},
+			want: true,
+		},
+		{
+			name: "not-found",
+			err:  errdefs.NotFound(errors.New("manifest unknown")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isRateLimitError(tt.err))
+		})
+	}
+}
+
+func TestHubMirrorImage(t *testing.T) {
+	const digest = "sha256:0d5ca6ba7a94d6d8a0e0b7c3c5b0d1f4f2e2f6d7b1c4a5e8f9a0b1c2d3e4f5a6"
+
+	tests := []struct {
+		name   string
+		mirror string
+		image  string
+		want   string
+		ok     bool
+	}{
+		{name: "no-mirror", image: "nginx:alpine"},
+		{name: "official", mirror: "mirror.gcr.io", image: "nginx:alpine", want: "mirror.gcr.io/library/nginx:alpine", ok: true},
+		{name: "latest", mirror: "mirror.gcr.io", image: "nginx", want: "mirror.gcr.io/library/nginx:latest", ok: true},
+		{name: "docker.io", mirror: "mirror.gcr.io", image: "docker.io/testcontainers/ryuk:0.9.0", want: "mirror.gcr.io/testcontainers/ryuk:0.9.0", ok: true},
+		{name: "digest", mirror: "mirror.gcr.io", image: "nginx@" + digest, want: "mirror.gcr.io/library/nginx@" + digest, ok: true},
+		{name: "scheme-and-path", mirror: "https://registry.example.com/dockerhub/", image: "redis:7", want: "registry.example.com/dockerhub/library/redis:7", ok: true},
+		{name: "other-registry", mirror: "mirror.gcr.io", image: "quay.io/prometheus/prometheus:v2.53.0"},
+		{name: "invalid", mirror: "mirror.gcr.io", image: "Nginx:alpine"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := hubMirrorImage(tt.mirror, tt.image)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsContainerdImageStore(t *testing.T) {
+	tests := []struct {
+		name         string
+		driverStatus [][2]string
+		want         bool
+	}{
+		{name: "empty"},
+		{
+			name:         "overlay2",
+			driverStatus: [][2]string{{"Backing Filesystem", "extfs"}, {"Supports d_type", "true"}},
+		},
+		{
+			name:         "containerd",
+			driverStatus: [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}},
+			want:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isContainerdImageStore(tt.driverStatus))
+		})
+	}
+}
@@ -6,14 +6,15 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 
@@ -66,11 +67,24 @@ func reaperContainerNameFromSessionID(sessionID string) string {
 // It will perform a retry with exponential backoff to allow for the container to be started and
 // avoid potential false negatives.
 func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContainer, error) {
-	dockerClient, err := NewDockerClientWithOpts(ctx)
-	if err != nil {
-		return nil, err
+	return lookUpDaemonReaperContainer(ctx, sessionID, nil)
+}
+
+// lookUpDaemonReaperContainer looks up the reaper container like lookUpReaperContainer,
+// in the Docker daemon of the given provider, or in the default Docker daemon if it's nil.
+func lookUpDaemonReaperContainer(ctx context.Context, sessionID string, provider *DockerProvider) (*DockerContainer, error) {
+	var dockerClient client.APIClient
+	if provider != nil {
+		dockerClient = provider.client
+	} else {
+		defaultClient, err := NewDockerClientWithOpts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer defaultClient.Close()
+
+		dockerClient = defaultClient
 	}
-	defer dockerClient.Close()
 
 	// the backoff will take at most 5 seconds to find the reaper container
 	// doing each attempt every 100ms
@@ -113,7 +127,12 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 				return nil, fmt.Errorf("not possible to have multiple reaper containers found for session ID %s", sessionID)
 			}
 
-			r, err := containerFromDockerResponse(ctx, resp[0])
+			var r *DockerContainer
+			if provider != nil {
+				r, err = containerFromDockerResponseWithProvider(ctx, provider, resp[0])
+			} else {
+				r, err = containerFromDockerResponse(ctx, resp[0])
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -139,6 +158,11 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 // reuseOrCreateReaper returns an existing Reaper instance if it exists and is running. Otherwise, a new Reaper instance
 // will be created with a sessionID to identify containers in the same test session/program.
 func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperProvider) (*Reaper, error) {
+	// the Docker daemons set with WithDockerClient or WithDockerHost have their own reaper
+	if p, ok := provider.(*DockerProvider); ok && p.isDaemonOverride() {
+		return reuseOrCreateDaemonReaper(ctx, sessionID, p)
+	}
+
 	reaperMutex.Lock()
 	defer reaperMutex.Unlock()
 
@@ -222,6 +246,8 @@ func reuseReaperContainer(ctx context.Context, sessionID string, provider Reaper
 		}
 	}
 
+	stats.reapersReused.Add(1)
+
 	return &Reaper{
 		Provider:  provider,
 		SessionID: sessionID,
@@ -233,7 +259,14 @@ func reuseReaperContainer(ctx context.Context, sessionID string, provider Reaper
 // newReaper creates a Reaper with a sessionID to identify containers and a
 // provider to use. Do not call this directly, use reuseOrCreateReaper instead.
 func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (*Reaper, error) {
-	dockerHostMount := core.MustExtractDockerSocket(ctx)
+	// the Docker daemons set with WithDockerClient or WithDockerHost are reached through their own socket
+	var daemonProvider *DockerProvider
+	dockerHostMount := daemonDockerSocket
+	if p, ok := provider.(*DockerProvider); ok && p.isDaemonOverride() {
+		daemonProvider = p
+	} else {
+		dockerHostMount = core.MustExtractDockerSocket(ctx)
+	}
 
 	reaper := &Reaper{
 		Provider:  provider,
@@ -295,7 +328,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 			start := time.Now()
 			var reaperContainer *DockerContainer
 			for time.Since(start) < timeout {
-				reaperContainer, err = lookUpReaperContainer(ctx, sessionID)
+				reaperContainer, err = lookUpDaemonReaperContainer(ctx, sessionID, daemonProvider)
 				if err == nil && reaperContainer != nil {
 					break
 				}
@@ -331,6 +364,8 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 	}
 	reaper.Endpoint = endpoint
 
+	stats.reapersCreated.Add(1)
+
 	return reaper, nil
 }
 
@@ -340,30 +375,40 @@ type Reaper struct {
 	SessionID string
 	Endpoint  string
 	container Container
+
+	// connections counts the open connections to the reaper, which removes the resources once the last one
+	// is closed and the reconnection timeout elapsed.
+	connections atomic.Int32
 }
 
 // Connect runs a goroutine which can be terminated by sending true into the returned channel
 func (r *Reaper) Connect() (chan bool, error) {
 	conn, err := net.DialTimeout("tcp", r.Endpoint, 10*time.Second)
+	countResult(err, &stats.reaperConnections, &stats.reaperConnectionFailures)
 	if err != nil {
 		return nil, fmt.Errorf("%w: Connecting to Ryuk on %s failed", err, r.Endpoint)
 	}
 
+	r.connections.Add(1)
+
+	// in dry-run mode, the filter is not sent, so the reaper removes nothing
+	dryRun := r.Provider.Config().Config.RyukDryRun
+
 	terminationSignal := make(chan bool)
 	go func(conn net.Conn) {
 		sock := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
 		defer conn.Close()
 
-		labelFilters := []string{}
-		for l, v := range core.DefaultLabels(r.SessionID) {
-			labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, v))
-		}
+		labels := core.DefaultLabels(r.SessionID)
 
 		retryLimit := 3
+		if dryRun {
+			retryLimit = 0
+		}
 		for retryLimit > 0 {
 			retryLimit--
 
-			if _, err := sock.WriteString(strings.Join(labelFilters, "&")); err != nil {
+			if _, err := sock.WriteString(reaperFilter(labels)); err != nil {
 				continue
 			}
 
@@ -386,6 +431,10 @@ func (r *Reaper) Connect() (chan bool, error) {
 		}
 
 		<-terminationSignal
+
+		if r.connections.Add(-1) == 0 {
+			r.audit(labels)
+		}
 	}(conn)
 	return terminationSignal, nil
 }
@@ -0,0 +1,167 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ReapedResource is a resource of the test session pending reaping when the last connection of the test
+// process to the reaper, Ryuk, is closed. Ryuk removes it once the ryuk.reconnection.timeout elapsed,
+// unless a new connection is established before, or would remove it in dry-run mode.
+type ReapedResource struct {
+	// Type is the type of the resource: container, network, volume or image.
+	Type string
+	// ID is the ID of the resource, or the name of a volume.
+	ID string
+	// Name is the name of the resource, or the tags of an image.
+	Name string
+	// Filter is the label filter the resource matches, which the reaper removes the resources by.
+	Filter string
+	// DryRun is true if the resource is not removed, because of the ryuk.dry.run property.
+	DryRun bool
+}
+
+// ReaperAuditFunc receives the resources pending reaping at the last disconnection, see SetReaperAuditFunc.
+type ReaperAuditFunc func(ReapedResource)
+
+// reaperAudit is the function receiving the resources pending reaping.
+var reaperAudit struct {
+	sync.Mutex
+	fn ReaperAuditFunc
+}
+
+// SetReaperAuditFunc sets the function receiving the resources pending reaping when the last connection
+// of the test process to the reaper is closed, e.g. to find out why a container disappeared during a test.
+// The reaper only removes them if no new connection is established before the ryuk.reconnection.timeout.
+// A nil function removes it. The resources are logged with the ryuk.audit property.
+func SetReaperAuditFunc(fn ReaperAuditFunc) {
+	reaperAudit.Lock()
+	defer reaperAudit.Unlock()
+
+	reaperAudit.fn = fn
+}
+
+// reaperAuditFunc returns the function receiving the resources pending reaping, if any.
+func reaperAuditFunc() ReaperAuditFunc {
+	reaperAudit.Lock()
+	defer reaperAudit.Unlock()
+
+	return reaperAudit.fn
+}
+
+// reaperFilter returns the label filter of the resources of the session, in the format of the reaper protocol.
+func reaperFilter(labels map[string]string) string {
+	labelFilters := make([]string, 0, len(labels))
+	for l, v := range labels {
+		labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, v))
+	}
+	sort.Strings(labelFilters)
+
+	return strings.Join(labelFilters, "&")
+}
+
+// audit reports the resources of the session pending reaping at the last disconnection, which the reaper
+// removes unless a new connection is established in time, to the audit function and to the logs, if enabled.
+func (r *Reaper) audit(labels map[string]string) {
+	tcConfig := r.Provider.Config().Config
+	fn := reaperAuditFunc()
+	if fn == nil && !tcConfig.RyukAudit && !tcConfig.RyukDryRun {
+		return
+	}
+
+	p, ok := r.Provider.(*DockerProvider)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resources, err := listReapedResources(ctx, p.client, labels)
+	if err != nil {
+		Logger.Printf("🧹 Failed to list the resources pending reaping by Ryuk: %v", err)
+	}
+
+	filter := reaperFilter(labels)
+	for _, res := range resources {
+		res.Filter = filter
+		res.DryRun = tcConfig.RyukDryRun
+
+		if fn != nil {
+			fn(res)
+		}
+
+		switch {
+		case res.DryRun:
+			Logger.Printf("🧹 Ryuk dry-run: the %s %s (%s) matching %s would be removed", res.Type, res.Name, res.ID, res.Filter)
+		case tcConfig.RyukAudit:
+			Logger.Printf("🧹 Ryuk pending reaping at last disconnect: the %s %s (%s) matching %s is removed unless a new connection is established within %s", res.Type, res.Name, res.ID, res.Filter, tcConfig.RyukReconnectionTimeout)
+		}
+	}
+}
+
+// listReapedResources returns the resources matching all the labels, as the reaper removes them,
+// except the reaper container itself, which exits on its own.
+func listReapedResources(ctx context.Context, cli client.APIClient, labels map[string]string) ([]ReapedResource, error) {
+	args := filters.NewArgs()
+	for l, v := range labels {
+		args.Add("label", l+"="+v)
+	}
+
+	var resources []ReapedResource
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+	for _, c := range containers {
+		if c.Labels[core.LabelReaper] == "true" {
+			continue
+		}
+
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		resources = append(resources, ReapedResource{Type: "container", ID: c.ID, Name: name})
+	}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return resources, fmt.Errorf("list networks: %w", err)
+	}
+	for _, n := range networks {
+		resources = append(resources, ReapedResource{Type: "network", ID: n.ID, Name: n.Name})
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return resources, fmt.Errorf("list volumes: %w", err)
+	}
+	for _, v := range volumes.Volumes {
+		resources = append(resources, ReapedResource{Type: "volume", ID: v.Name, Name: v.Name})
+	}
+
+	images, err := cli.ImageList(ctx, image.ListOptions{Filters: args})
+	if err != nil {
+		return resources, fmt.Errorf("list images: %w", err)
+	}
+	for _, img := range images {
+		resources = append(resources, ReapedResource{Type: "image", ID: img.ID, Name: strings.Join(img.RepoTags, ",")})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,177 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// reaperAuditMockCli is a mock implementation of client.APIClient listing the resources of a session.
+type reaperAuditMockCli struct {
+	client.APIClient
+}
+
+func (f *reaperAuditMockCli) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return []types.Container{
+		{ID: "ryuk-id", Names: []string{"/reaper"}, Labels: map[string]string{core.LabelReaper: "true"}},
+		{ID: "web-id", Names: []string{"/web"}},
+	}, nil
+}
+
+func (f *reaperAuditMockCli) NetworkList(_ context.Context, _ network.ListOptions) ([]network.Summary, error) {
+	return []network.Summary{{ID: "net-id", Name: "backend"}}, nil
+}
+
+func (f *reaperAuditMockCli) VolumeList(_ context.Context, _ volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: []*volume.Volume{{Name: "data"}}}, nil
+}
+
+func (f *reaperAuditMockCli) ImageList(_ context.Context, _ image.ListOptions) ([]image.Summary, error) {
+	return []image.Summary{{ID: "img-id", RepoTags: []string{"app:test"}}}, nil
+}
+
+func (f *reaperAuditMockCli) Close() error {
+	return nil
+}
+
+func TestReaperFilter(t *testing.T) {
+	filter := reaperFilter(map[string]string{"b": "2", "a": "1"})
+	require.Equal(t, "label=a=1&label=b=2", filter)
+}
+
+func TestListReapedResources(t *testing.T) {
+	resources, err := listReapedResources(context.Background(), &reaperAuditMockCli{}, map[string]string{"a": "1"})
+	require.NoError(t, err)
+	require.Equal(t, []ReapedResource{
+		{Type: "container", ID: "web-id", Name: "web"},
+		{Type: "network", ID: "net-id", Name: "backend"},
+		{Type: "volume", ID: "data", Name: "data"},
+		{Type: "image", ID: "img-id", Name: "app:test"},
+	}, resources)
+}
+
+// fakeRyuk is a server implementing the reaper protocol, recording the filters it receives.
+func fakeRyuk(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	filters := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					filters <- line
+					if _, err := conn.Write([]byte("ACK\n")); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return ln.Addr().String(), filters
+}
+
+func TestReaperAudit(t *testing.T) {
+	newReaper := func(t *testing.T, cfg config.Config) (*Reaper, <-chan string) {
+		t.Helper()
+
+		endpoint, filters := fakeRyuk(t)
+
+		return &Reaper{
+			Provider:  &DockerProvider{client: &reaperAuditMockCli{}, config: cfg},
+			SessionID: "audit-session",
+			Endpoint:  endpoint,
+		}, filters
+	}
+
+	// audited connects to the reaper twice, and returns the resources reported
+	// once both connections are closed.
+	audited := func(t *testing.T, r *Reaper) []ReapedResource {
+		t.Helper()
+
+		reaped := make(chan ReapedResource, 10)
+		SetReaperAuditFunc(func(res ReapedResource) {
+			reaped <- res
+		})
+		t.Cleanup(func() { SetReaperAuditFunc(nil) })
+
+		signal1, err := r.Connect()
+		require.NoError(t, err)
+		signal2, err := r.Connect()
+		require.NoError(t, err)
+
+		signal1 <- true
+		select {
+		case res := <-reaped:
+			require.Failf(t, "reported while connected", "%v", res)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		signal2 <- true
+
+		var resources []ReapedResource
+		for range 4 {
+			select {
+			case res := <-reaped:
+				resources = append(resources, res)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "resources not reported")
+			}
+		}
+
+		return resources
+	}
+
+	t.Run("audit", func(t *testing.T) {
+		r, filters := newReaper(t, config.Config{RyukAudit: true})
+
+		resources := audited(t, r)
+		require.Len(t, filters, 2)
+
+		filter := reaperFilter(core.DefaultLabels("audit-session"))
+		require.Equal(t, filter+"\n", <-filters)
+		for _, res := range resources {
+			require.Equal(t, filter, res.Filter)
+			require.False(t, res.DryRun)
+		}
+	})
+
+	t.Run("dry-run", func(t *testing.T) {
+		r, filters := newReaper(t, config.Config{RyukDryRun: true})
+
+		resources := audited(t, r)
+		require.Empty(t, filters)
+		for _, res := range resources {
+			require.True(t, res.DryRun)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// scheduledJobHistory is the number of the last runs of a scheduled job kept in its history.
+const scheduledJobHistory = 100
+
+// JobRun is a run of a scheduled job.
+type JobRun struct {
+	// Started is the time the run started.
+	Started time.Time
+	// Duration is the duration of the run.
+	Duration time.Duration
+	// Result is the result of the job, if it could be run.
+	Result JobResult
+	// Err is the error running the job, if any.
+	Err error
+}
+
+// Failed reports whether the job couldn't be run, or exited with a non-zero code.
+func (r JobRun) Failed() bool {
+	return r.Err != nil || r.Result.ExitCode != 0
+}
+
+type scheduleOptions struct {
+	jitter  time.Duration
+	maxRuns int
+	session *Session
+	onRun   func(JobRun)
+}
+
+// ScheduleOption is an option of ScheduleJob.
+type ScheduleOption func(*scheduleOptions)
+
+// WithJitter adds a random delay of up to the given duration to the interval before each run,
+// so the jobs of several schedules, e.g. chaos actions, don't run in lockstep.
+func WithJitter(jitter time.Duration) ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.jitter = jitter
+	}
+}
+
+// WithMaxRuns stops the schedule after the given number of runs.
+func WithMaxRuns(n int) ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.maxRuns = n
+	}
+}
+
+// WithScheduleSession adds the containers of the jobs to the session, so they are removed
+// when the session is cleared, and the schedule is stopped by ClearSession.
+func WithScheduleSession(s *Session) ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.session = s
+	}
+}
+
+// WithOnRun calls the function after each run of the job, e.g. to log its failures.
+// The function is called by the goroutine of the schedule, delaying the next run until it returns.
+func WithOnRun(fn func(JobRun)) ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.onRun = fn
+	}
+}
+
+// ScheduledJob is a job run repeatedly by ScheduleJob.
+type ScheduledJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mtx   sync.Mutex
+	runs  []JobRun
+	count int
+}
+
+// ScheduleJob runs the container of the request to completion with RunJob, e.g. a load generator or a chaos action,
+// every interval until the context is done, Stop is called or the maximum number of runs is reached.
+// The first run starts after the first interval, and the runs never overlap: the interval starts when the previous run ends.
+// A failed run doesn't stop the schedule.
+func ScheduleJob(ctx context.Context, req GenericContainerRequest, interval time.Duration, opts ...ScheduleOption) (*ScheduledJob, error) {
+	if interval <= 0 {
+		return nil, errors.New("schedule job: the interval must be positive")
+	}
+
+	var settings scheduleOptions
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	if settings.session != nil {
+		if err := settings.session.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	run := func(ctx context.Context) (JobResult, error) {
+		return RunJob(ctx, req)
+	}
+
+	return scheduleJob(ctx, run, interval, settings), nil
+}
+
+// scheduleJob starts the goroutine of the schedule of the run function.
+func scheduleJob(ctx context.Context, run func(context.Context) (JobResult, error), interval time.Duration, settings scheduleOptions) *ScheduledJob {
+	ctx, cancel := context.WithCancel(ctx)
+	j := &ScheduledJob{cancel: cancel, done: make(chan struct{})}
+
+	if settings.session != nil {
+		settings.session.onClear(j.Stop)
+	}
+
+	go func() {
+		defer close(j.done)
+		defer cancel()
+
+		for i := 0; settings.maxRuns <= 0 || i < settings.maxRuns; i++ {
+			delay := interval
+			if settings.jitter > 0 {
+				delay += time.Duration(rand.Int63n(int64(settings.jitter)))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			r := JobRun{Started: time.Now()}
+			r.Result, r.Err = run(ctx)
+			r.Duration = time.Since(r.Started)
+
+			if ctx.Err() != nil {
+				// the run was interrupted by the end of the schedule
+				return
+			}
+
+			j.record(r)
+			if settings.onRun != nil {
+				settings.onRun(r)
+			}
+		}
+	}()
+
+	return j
+}
+
+// record adds the run to the history of the job.
+func (j *ScheduledJob) record(r JobRun) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	j.count++
+	j.runs = append(j.runs, r)
+	if len(j.runs) > scheduledJobHistory {
+		j.runs = j.runs[len(j.runs)-scheduledJobHistory:]
+	}
+}
+
+// Runs returns the last runs of the job, up to 100, in the order they ran.
+func (j *ScheduledJob) Runs() []JobRun {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	runs := make([]JobRun, len(j.runs))
+	copy(runs, j.runs)
+
+	return runs
+}
+
+// Count returns the number of runs of the job so far, including the runs no longer in its history.
+func (j *ScheduledJob) Count() int {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.count
+}
+
+// Done returns a channel closed when the schedule ends, e.g. once the maximum number of runs is reached.
+func (j *ScheduledJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Stop stops the schedule, interrupting the current run, if any, and waits for its container to be removed.
+// The interrupted run isn't recorded.
+func (j *ScheduledJob) Stop() {
+	j.cancel()
+	<-j.done
+}
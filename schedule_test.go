@@ -0,0 +1,123 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("max-runs", func(t *testing.T) {
+		var calls atomic.Int32
+		run := func(context.Context) (JobResult, error) {
+			if calls.Add(1) == 2 {
+				return JobResult{ExitCode: 1, Stderr: "connection refused"}, nil
+			}
+			return JobResult{Stdout: "ok"}, nil
+		}
+
+		var onRun atomic.Int32
+		j := scheduleJob(ctx, run, time.Millisecond, scheduleOptions{
+			maxRuns: 3,
+			onRun:   func(JobRun) { onRun.Add(1) },
+		})
+
+		select {
+		case <-j.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("schedule not done")
+		}
+
+		runs := j.Runs()
+		require.Len(t, runs, 3)
+		require.Equal(t, 3, j.Count())
+		require.Equal(t, int32(3), onRun.Load())
+		require.False(t, runs[0].Failed())
+		require.True(t, runs[1].Failed())
+		require.Equal(t, "connection refused", runs[1].Result.Stderr)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		started := make(chan struct{})
+		run := func(ctx context.Context) (JobResult, error) {
+			close(started)
+			<-ctx.Done()
+			return JobResult{}, ctx.Err()
+		}
+
+		j := scheduleJob(ctx, run, time.Millisecond, scheduleOptions{})
+		<-started
+		j.Stop()
+
+		require.Empty(t, j.Runs())
+		require.Zero(t, j.Count())
+	})
+
+	t.Run("history", func(t *testing.T) {
+		run := func(context.Context) (JobResult, error) {
+			return JobResult{}, errors.New("no such image")
+		}
+
+		j := scheduleJob(ctx, run, time.Nanosecond, scheduleOptions{maxRuns: scheduledJobHistory + 10})
+		<-j.Done()
+
+		require.Len(t, j.Runs(), scheduledJobHistory)
+		require.Equal(t, scheduledJobHistory+10, j.Count())
+		require.True(t, j.Runs()[0].Failed())
+	})
+
+	t.Run("session-cleared", func(t *testing.T) {
+		s := NewSession("schedule")
+		nested := s.NewSession("nested")
+
+		run := func(ctx context.Context) (JobResult, error) {
+			return JobResult{}, nil
+		}
+
+		j := scheduleJob(ctx, run, time.Hour, scheduleOptions{session: nested})
+		runClearHooks(s)
+
+		select {
+		case <-j.Done():
+		default:
+			t.Fatal("schedule not stopped")
+		}
+	})
+
+	t.Run("containers", func(t *testing.T) {
+		// scheduleJob {
+		j, err := ScheduleJob(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"echo", "chaos"},
+			},
+		}, 100*time.Millisecond, WithJitter(50*time.Millisecond), WithMaxRuns(2))
+		require.NoError(t, err)
+		t.Cleanup(j.Stop)
+		// }
+
+		select {
+		case <-j.Done():
+		case <-time.After(time.Minute):
+			t.Fatal("schedule not done")
+		}
+
+		runs := j.Runs()
+		require.Len(t, runs, 2)
+		for _, r := range runs {
+			require.NoError(t, r.Err)
+			require.Equal(t, "chaos\n", r.Result.Stdout)
+		}
+	})
+
+	t.Run("invalid-interval", func(t *testing.T) {
+		_, err := ScheduleJob(ctx, GenericContainerRequest{}, 0)
+		require.ErrorContains(t, err, "interval must be positive")
+	})
+}
@@ -0,0 +1,144 @@
+package testcontainers
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// secretsDir is the directory of the container where the secrets are delivered as files.
+const secretsDir = "/run/secrets"
+
+// redactedValue replaces the sensitive values in the logs and the errors.
+const redactedValue = "******"
+
+// WithSecretEnv sets the environment variable of the container to the sensitive value, e.g. a password,
+// which is masked in the logs and the errors of the library. Note that the value is still visible
+// when inspecting the container, e.g. with `docker inspect`.
+func WithSecretEnv(key string, value string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if err := WithEnv(map[string]string{key: value})(req); err != nil {
+			return err
+		}
+
+		return WithSensitiveValues(value)(req)
+	}
+}
+
+// WithSecretEnvFile delivers the sensitive value as a file in the /run/secrets directory of the container,
+// named after the lowercased key, setting the <key>_FILE environment variable to its path, e.g.
+// POSTGRES_PASSWORD_FILE=/run/secrets/postgres_password. Use it for the images supporting the *_FILE variables,
+// so the value is not visible in the environment of the container. The value is masked in the logs and the
+// errors of the library.
+func WithSecretEnvFile(key string, value string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		secretPath := path.Join(secretsDir, strings.ToLower(key))
+
+		req.Files = append(req.Files, ContainerFile{
+			Reader:            bytes.NewReader([]byte(value)),
+			ContainerFilePath: secretPath,
+			// readable by the non-root users the images usually run as
+			FileMode: 0o444,
+		})
+
+		if err := WithEnv(map[string]string{key + "_FILE": secretPath})(req); err != nil {
+			return err
+		}
+
+		return WithSensitiveValues(value)(req)
+	}
+}
+
+// WithSensitiveValues masks the values in the logs and the errors of the library, e.g. the credentials
+// passed in the command of the container.
+func WithSensitiveValues(values ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		for _, value := range values {
+			if value != "" && !slices.Contains(req.SensitiveValues, value) {
+				req.SensitiveValues = append(req.SensitiveValues, value)
+			}
+		}
+
+		return nil
+	}
+}
+
+// newRedactor returns a replacer masking the sensitive values, or nil if there are none.
+func newRedactor(values []string) *strings.Replacer {
+	var sorted []string
+	for _, value := range values {
+		if value != "" {
+			sorted = append(sorted, value)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	// mask the longest values first, as a value may contain another one
+	slices.SortFunc(sorted, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, value := range sorted {
+		oldnew = append(oldnew, value, redactedValue)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+// redactingLogger masks the sensitive values in the messages of the wrapped logger.
+type redactingLogger struct {
+	Logging
+	redactor *strings.Replacer
+}
+
+// Printf implements Logging.
+func (l redactingLogger) Printf(format string, v ...interface{}) {
+	l.Logging.Printf("%s", l.redactor.Replace(fmt.Sprintf(format, v...)))
+}
+
+// redactedError masks the sensitive values in the message of the wrapped error,
+// which can still be checked with errors.Is and errors.As.
+type redactedError struct {
+	err      error
+	redactor *strings.Replacer
+}
+
+// Error implements error.
+func (e redactedError) Error() string {
+	return e.redactor.Replace(e.err.Error())
+}
+
+// Unwrap returns the wrapped error.
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactLogger returns the logger masking the sensitive values of the request, if any.
+func (c *ContainerRequest) redactLogger(logger Logging) Logging {
+	redactor := newRedactor(c.SensitiveValues)
+	if redactor == nil {
+		return logger
+	}
+
+	return redactingLogger{Logging: logger, redactor: redactor}
+}
+
+// redactError returns the error masking the sensitive values of the request, if any.
+func (c *ContainerRequest) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	redactor := newRedactor(c.SensitiveValues)
+	if redactor == nil {
+		return err
+	}
+
+	return redactedError{err: err, redactor: redactor}
+}
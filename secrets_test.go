@@ -0,0 +1,86 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSecretEnv(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	require.NoError(t, WithSecretEnv("POSTGRES_PASSWORD", "s3cr3t")(&req))
+	require.NoError(t, WithSecretEnv("API_TOKEN", "s3cr3t")(&req))
+	require.NoError(t, WithSensitiveValues("", "token")(&req))
+
+	require.Equal(t, map[string]string{"POSTGRES_PASSWORD": "s3cr3t", "API_TOKEN": "s3cr3t"}, req.Env)
+	require.Equal(t, []string{"s3cr3t", "token"}, req.SensitiveValues)
+	require.Empty(t, req.Files)
+}
+
+func TestWithSecretEnvFile(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	require.NoError(t, WithSecretEnvFile("POSTGRES_PASSWORD", "s3cr3t")(&req))
+
+	require.Equal(t, map[string]string{"POSTGRES_PASSWORD_FILE": "/run/secrets/postgres_password"}, req.Env)
+	require.Equal(t, []string{"s3cr3t"}, req.SensitiveValues)
+
+	require.Len(t, req.Files, 1)
+	require.Equal(t, "/run/secrets/postgres_password", req.Files[0].ContainerFilePath)
+	require.Equal(t, int64(0o444), req.Files[0].FileMode)
+
+	content, err := io.ReadAll(req.Files[0].Reader)
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", string(content))
+}
+
+func TestRedaction(t *testing.T) {
+	req := ContainerRequest{SensitiveValues: []string{"pass", "password123"}}
+
+	t.Run("logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := req.redactLogger(log.New(&buf, "", 0))
+
+		logger.Printf("connecting with %s and %s", "password123", "pass")
+		require.Equal(t, "connecting with ****** and ******\n", buf.String())
+
+		plain := log.New(&buf, "", 0)
+		require.Same(t, plain, (&ContainerRequest{}).redactLogger(plain))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errSentinel := errors.New("sentinel")
+
+		err := req.redactError(fmt.Errorf("auth with password123 failed: %w", errSentinel))
+		require.EqualError(t, err, "auth with ****** failed: sentinel")
+		require.ErrorIs(t, err, errSentinel)
+
+		require.NoError(t, req.redactError(nil))
+
+		plain := errors.New("password123")
+		require.Same(t, plain, (&ContainerRequest{}).redactError(plain))
+	})
+
+	t.Run("generic-container", func(t *testing.T) {
+		// the invalid port is reported, with the secret, before connecting to the Docker daemon
+		greq := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{"80/password123"},
+			},
+		}
+		require.NoError(t, WithSensitiveValues("password123")(&greq))
+
+		_, err := GenericContainer(context.Background(), greq)
+		require.ErrorIs(t, err, ErrInvalidPort)
+		require.NotContains(t, err.Error(), "password123")
+		require.Contains(t, err.Error(), redactedValue)
+	})
+}
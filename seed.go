@@ -0,0 +1,44 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+)
+
+// Seeder loads fixtures into a container once it's ready, e.g. SQL scripts into a database,
+// or documents into a search engine. The modules provide seeders for their services.
+type Seeder interface {
+	// Seed loads the fixtures into the ready container.
+	Seed(ctx context.Context, c Container) error
+}
+
+// SeederFunc is a function implementing the Seeder interface.
+type SeederFunc func(ctx context.Context, c Container) error
+
+// Seed calls the function.
+func (f SeederFunc) Seed(ctx context.Context, c Container) error {
+	return f(ctx, c)
+}
+
+// WithSeed loads the fixtures of the seeders, in order, once the container is ready,
+// and before it's returned. It fails the creation of the container if any seeder fails.
+func WithSeed(seeders ...Seeder) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		hooks := make([]ContainerHook, 0, len(seeders))
+		for i, seeder := range seeders {
+			hooks = append(hooks, func(ctx context.Context, c Container) error {
+				if err := seeder.Seed(ctx, c); err != nil {
+					return fmt.Errorf("seeder %d: %w", i, err)
+				}
+
+				return nil
+			})
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostReadies: hooks,
+		})
+
+		return nil
+	}
+}
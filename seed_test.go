@@ -0,0 +1,67 @@
+package testcontainers_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestWithSeed(t *testing.T) {
+	alpineRequest := func() testcontainers.GenericContainerRequest {
+		return testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "alpine",
+				Entrypoint: []string{"tail", "-f", "/dev/null"},
+			},
+			Started: true,
+		}
+	}
+
+	t.Run("in-order", func(t *testing.T) {
+		ctx := context.Background()
+
+		appendLine := func(line string) testcontainers.Seeder {
+			return testcontainers.SeederFunc(func(ctx context.Context, c testcontainers.Container) error {
+				_, _, err := c.Exec(ctx, []string{"sh", "-c", "echo " + line + " >> /tmp/fixtures"})
+				return err
+			})
+		}
+
+		req := alpineRequest()
+		err := testcontainers.WithSeed(appendLine("first"), appendLine("second"))(&req)
+		require.NoError(t, err)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		_, r, err := c.Exec(ctx, []string{"cat", "/tmp/fixtures"}, tcexec.Multiplexed())
+		require.NoError(t, err)
+
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "first\nsecond\n", string(out))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		ctx := context.Background()
+
+		failing := testcontainers.SeederFunc(func(context.Context, testcontainers.Container) error {
+			return errors.New("broken fixture")
+		})
+
+		req := alpineRequest()
+		err := testcontainers.WithSeed(failing)(&req)
+		require.NoError(t, err)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.ErrorContains(t, err, "seeder 0: broken fixture")
+	})
+}
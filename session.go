@@ -0,0 +1,231 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/google/uuid"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// Session is a scope of the resources of the test session, e.g. the containers of a subtest, which can be
+// removed with ClearSession when the scope ends, without removing the resources shared by the whole test session.
+// Sessions can be nested, clearing a session clearing its nested sessions too. The resources of a session
+// belong to the test session as well, so they are still removed by the reaper when the test process exits.
+//
+// A Session is a ContainerCustomizer adding the container to the session. Use its labels to add
+// the other resources to it, e.g. the networks with network.WithLabels.
+type Session struct {
+	id     string
+	key    string
+	parent *Session
+}
+
+// invalidSessionNameChars are the characters not allowed in the label of a session.
+var invalidSessionNameChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// NewSession creates a session nested in the test session, with the given name, e.g. the name of a test.
+func NewSession(name string) *Session {
+	return newSession(nil, name)
+}
+
+// NewSession creates a session nested in the session, with the given name.
+func (s *Session) NewSession(name string) *Session {
+	return newSession(s, name)
+}
+
+// newSession creates a session nested in the parent session, or in the test session if nil.
+// The key of the session is unique, as the same name can be used by several sessions.
+func newSession(parent *Session, name string) *Session {
+	key := strings.Trim(invalidSessionNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if key == "" {
+		key = "session"
+	}
+	key += "-" + uuid.NewString()[:8]
+
+	id := core.SessionID() + "/" + key
+	if parent != nil {
+		id = parent.id + "/" + key
+	}
+
+	return &Session{id: id, key: key, parent: parent}
+}
+
+// ID returns the ID of the session, which is the ID of its parent session followed by its own,
+// e.g. <test session>/orders-1a2b3c4d/create-5e6f7a8b.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// label returns the label of the resources of the session.
+func (s *Session) label() string {
+	return core.LabelSessionScope + s.key
+}
+
+// Labels returns the labels of the resources of the session, which include the labels of its parent
+// sessions, so the resources are removed when any of them is cleared.
+func (s *Session) Labels() map[string]string {
+	labels := map[string]string{}
+	for session := s; session != nil; session = session.parent {
+		labels[session.label()] = "true"
+	}
+
+	return labels
+}
+
+// Customize implements the ContainerCustomizer interface, adding the container to the session.
+// The volumes created for the container are added to the session too.
+func (s *Session) Customize(req *GenericContainerRequest) error {
+	if req.Labels == nil {
+		req.Labels = map[string]string{}
+	}
+	for k, v := range s.Labels() {
+		req.Labels[k] = v
+	}
+
+	return nil
+}
+
+// labelSessionVolumes adds the volumes created for the container to the sessions of the container.
+func labelSessionVolumes(mounts []mount.Mount, containerLabels map[string]string) {
+	for i := range mounts {
+		m := &mounts[i]
+		if m.Type != mount.TypeVolume || m.VolumeOptions == nil {
+			continue
+		}
+
+		for k, v := range containerLabels {
+			if strings.HasPrefix(k, core.LabelSessionScope) {
+				m.VolumeOptions.Labels[k] = v
+			}
+		}
+	}
+}
+
+// ClearSession removes the containers, the networks and the volumes of the session and of its nested sessions,
+// e.g. at the end of a subtest, while the resources of the parent sessions are kept. The removed containers
+// must not be used, or terminated, anymore.
+func ClearSession(ctx context.Context, s *Session) error {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("create docker client: %w", err)
+	}
+	defer cli.Close()
+
+	return clearSession(ctx, cli, s)
+}
+
+// sessionClearHooks are the functions called when the sessions are cleared, e.g. stopping the jobs scheduled in them.
+var sessionClearHooks = struct {
+	sync.Mutex
+	hooks map[*Session][]func()
+}{hooks: map[*Session][]func(){}}
+
+// onClear registers the function to be called when the session, or one of its parent sessions, is cleared,
+// before its containers are removed.
+func (s *Session) onClear(fn func()) {
+	sessionClearHooks.Lock()
+	defer sessionClearHooks.Unlock()
+
+	sessionClearHooks.hooks[s] = append(sessionClearHooks.hooks[s], fn)
+}
+
+// runClearHooks calls the functions registered for the session and for its nested sessions, only once.
+func runClearHooks(s *Session) {
+	var fns []func()
+
+	sessionClearHooks.Lock()
+	for session, hooks := range sessionClearHooks.hooks {
+		for ancestor := session; ancestor != nil; ancestor = ancestor.parent {
+			if ancestor == s {
+				fns = append(fns, hooks...)
+				delete(sessionClearHooks.hooks, session)
+				break
+			}
+		}
+	}
+	sessionClearHooks.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// clearSession removes the resources of the session, the containers first as they use the networks and the volumes.
+func clearSession(ctx context.Context, cli client.APIClient, s *Session) error {
+	runClearHooks(s)
+
+	args := filters.NewArgs(filters.Arg("label", s.label()))
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	ids := make([]string, len(containers))
+	for i, c := range containers {
+		ids[i] = c.ID
+	}
+
+	errs := []error{removeConcurrently(ids, func(id string) error {
+		return cli.ContainerRemove(ctx, id, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	})}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list networks: %w", err))...)
+	}
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", n.Name, err))
+		}
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list volumes: %w", err))...)
+	}
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil {
+			errs = append(errs, fmt.Errorf("remove volume %s: %w", v.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// removeConcurrently removes the containers with the given IDs, as many sessions contain a lot of containers.
+func removeConcurrently(ids []string, remove func(id string) error) error {
+	slots := make(chan struct{}, defaultWorkersCount)
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := remove(id); err != nil {
+				errs[i] = fmt.Errorf("remove container %s: %w", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
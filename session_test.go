@@ -0,0 +1,119 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// sessionMockCli is a mock implementation of client.APIClient recording the removed resources of a session.
+type sessionMockCli struct {
+	client.APIClient
+	removeErr error
+
+	mtx     sync.Mutex
+	filter  string
+	removed []string
+}
+
+func (f *sessionMockCli) ContainerList(_ context.Context, opts container.ListOptions) ([]types.Container, error) {
+	f.filter = opts.Filters.Get("label")[0]
+	return []types.Container{{ID: "web-id"}, {ID: "db-id"}}, nil
+}
+
+func (f *sessionMockCli) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.removed = append(f.removed, "container "+id)
+	if id == "db-id" {
+		return f.removeErr
+	}
+	return nil
+}
+
+func (f *sessionMockCli) NetworkList(_ context.Context, _ network.ListOptions) ([]network.Summary, error) {
+	return []network.Summary{{ID: "net-id", Name: "backend"}}, nil
+}
+
+func (f *sessionMockCli) NetworkRemove(_ context.Context, id string) error {
+	f.removed = append(f.removed, "network "+id)
+	return nil
+}
+
+func (f *sessionMockCli) VolumeList(_ context.Context, _ volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: []*volume.Volume{{Name: "data"}}}, nil
+}
+
+func (f *sessionMockCli) VolumeRemove(_ context.Context, id string, _ bool) error {
+	f.removed = append(f.removed, "volume "+id)
+	return nil
+}
+
+func TestSession(t *testing.T) {
+	suite := NewSession("Orders Suite")
+	test := suite.NewSession("TestCreate/valid")
+
+	require.True(t, strings.HasPrefix(suite.ID(), core.SessionID()+"/orders-suite-"))
+	require.True(t, strings.HasPrefix(test.ID(), suite.ID()+"/testcreate-valid-"))
+	require.NotEqual(t, suite.ID(), NewSession("Orders Suite").ID())
+
+	require.Equal(t, map[string]string{suite.label(): "true"}, suite.Labels())
+	require.Equal(t, map[string]string{suite.label(): "true", test.label(): "true"}, test.Labels())
+
+	req := GenericContainerRequest{ContainerRequest: ContainerRequest{Labels: map[string]string{"app": "orders"}}}
+	require.NoError(t, test.Customize(&req))
+	require.Equal(t, "orders", req.Labels["app"])
+	require.Equal(t, "true", req.Labels[suite.label()])
+	require.Equal(t, "true", req.Labels[test.label()])
+}
+
+func TestLabelSessionVolumes(t *testing.T) {
+	session := NewSession("volumes")
+
+	mounts := mapToDockerMounts(ContainerMounts{
+		VolumeMount("data", "/data"),
+		BindMount("/tmp", "/tmp"), //nolint:staticcheck // the bind mounts are not labelled
+	})
+	labelSessionVolumes(mounts, session.Labels())
+
+	require.Equal(t, "true", mounts[0].VolumeOptions.Labels[session.label()])
+	require.Equal(t, mount.TypeBind, mounts[1].Type)
+	require.Nil(t, mounts[1].VolumeOptions)
+}
+
+func TestClearSession(t *testing.T) {
+	ctx := context.Background()
+	session := NewSession("suite").NewSession("test")
+
+	t.Run("cleared", func(t *testing.T) {
+		cli := &sessionMockCli{}
+
+		require.NoError(t, clearSession(ctx, cli, session))
+		require.Equal(t, session.label(), cli.filter)
+		require.ElementsMatch(t, []string{"container web-id", "container db-id"}, cli.removed[:2])
+		require.Equal(t, []string{"network net-id", "volume data"}, cli.removed[2:])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errRemove := errors.New("remove failed")
+		cli := &sessionMockCli{removeErr: errRemove}
+
+		err := clearSession(ctx, cli, session)
+		require.ErrorIs(t, err, errRemove)
+		require.ErrorContains(t, err, "remove container db-id")
+		require.Len(t, cli.removed, 4)
+	})
+}
@@ -0,0 +1,220 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// dialFunc connects to the address on the named network.
+type dialFunc func(network, addr string) (net.Conn, error)
+
+// portTunnel forwards the connections accepted on a random port of the local host
+// to a remote address, using the given dial function. It's used to reach the mapped
+// ports of the containers running in a remote Docker daemon accessed over SSH.
+type portTunnel struct {
+	listener   net.Listener
+	dial       dialFunc
+	remoteAddr string
+
+	mtx   sync.Mutex
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+// newPortTunnel starts listening on a random port of the local host, forwarding
+// every accepted connection to remoteAddr.
+func newPortTunnel(dial dialFunc, remoteAddr string) (*portTunnel, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+
+	t := &portTunnel{
+		listener:   listener,
+		dial:       dial,
+		remoteAddr: remoteAddr,
+		conns:      map[net.Conn]struct{}{},
+	}
+
+	t.wg.Add(1)
+	go t.accept()
+
+	return t, nil
+}
+
+// port returns the local port of the tunnel.
+func (t *portTunnel) port() int {
+	return t.listener.Addr().(*net.TCPAddr).Port
+}
+
+// accept forwards the accepted connections until the listener is closed.
+func (t *portTunnel) accept() {
+	defer t.wg.Done()
+
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		t.wg.Add(1)
+		go t.forward(local)
+	}
+}
+
+// forward copies the data between the local connection and a new connection to the remote address.
+func (t *portTunnel) forward(local net.Conn) {
+	defer t.wg.Done()
+
+	remote, err := t.dial("tcp", t.remoteAddr)
+	if err != nil {
+		Logger.Printf("ssh tunnel: dial %s: %v", t.remoteAddr, err)
+		local.Close()
+		return
+	}
+
+	if !t.track(local, remote) {
+		// the tunnel was closed in the meantime
+		local.Close()
+		remote.Close()
+		return
+	}
+	defer t.untrack(local, remote)
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+
+	go pipe(remote, local)
+	go pipe(local, remote)
+
+	// close both ends as soon as one of the directions is done
+	<-done
+	local.Close()
+	remote.Close()
+	<-done
+}
+
+// track registers the connections, so they are closed with the tunnel.
+// It returns false if the tunnel is already closed.
+func (t *portTunnel) track(conns ...net.Conn) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.conns == nil {
+		return false
+	}
+
+	for _, c := range conns {
+		t.conns[c] = struct{}{}
+	}
+
+	return true
+}
+
+func (t *portTunnel) untrack(conns ...net.Conn) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	for _, c := range conns {
+		delete(t.conns, c)
+	}
+}
+
+// Close stops listening and closes all the forwarded connections.
+func (t *portTunnel) Close() error {
+	err := t.listener.Close()
+
+	t.mtx.Lock()
+	for c := range t.conns {
+		c.Close()
+	}
+	t.conns = nil
+	t.mtx.Unlock()
+
+	t.wg.Wait()
+
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// sshTunnelEnabled returns true if the mapped ports of the containers must be tunneled to the local host,
+// which happens when the Docker host is a remote daemon accessed over SSH and the tunnels are enabled.
+func (p *DockerProvider) sshTunnelEnabled() bool {
+	return p.config.SSHTunnel && core.IsSSHDockerHost(p.client.DaemonHost())
+}
+
+// tunnelPort returns the port of the local host where the given mapped port of the remote
+// Docker host is tunneled to, creating the tunnel if it does not exist yet.
+// Only TCP ports can be tunneled, so any other port is returned as is.
+func (c *DockerContainer) tunnelPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if port.Proto() != "tcp" {
+		return port, nil
+	}
+
+	c.tunnelsLock.Lock()
+	defer c.tunnelsLock.Unlock()
+
+	if t, ok := c.tunnels[port]; ok {
+		return nat.NewPort(port.Proto(), strconv.Itoa(t.port()))
+	}
+
+	if c.tunnelsClient == nil {
+		// the tunnels use their own SSH connection, so closing them doesn't break the Docker client
+		sshClient, err := core.DialSSH(ctx, c.provider.client.DaemonHost())
+		if err != nil {
+			return "", fmt.Errorf("ssh client: %w", err)
+		}
+		c.tunnelsClient = sshClient
+	}
+
+	// the mapped ports are bound to all the interfaces of the remote machine
+	t, err := newPortTunnel(c.tunnelsClient.Dial, net.JoinHostPort("localhost", port.Port()))
+	if err != nil {
+		return "", fmt.Errorf("tunnel port %s: %w", port, err)
+	}
+
+	if c.tunnels == nil {
+		c.tunnels = map[nat.Port]*portTunnel{}
+	}
+	c.tunnels[port] = t
+
+	return nat.NewPort(port.Proto(), strconv.Itoa(t.port()))
+}
+
+// closeTunnels closes the SSH tunnels of the container, if any, and their SSH connection.
+func (c *DockerContainer) closeTunnels() error {
+	c.tunnelsLock.Lock()
+	defer c.tunnelsLock.Unlock()
+
+	var errs []error
+	for port, t := range c.tunnels {
+		if err := t.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close tunnel %s: %w", port, err))
+		}
+	}
+	c.tunnels = nil
+
+	if c.tunnelsClient != nil {
+		if err := c.tunnelsClient.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, fmt.Errorf("close ssh client: %w", err))
+		}
+		c.tunnelsClient = nil
+	}
+
+	return errors.Join(errs...)
+}
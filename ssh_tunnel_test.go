@@ -0,0 +1,55 @@
+package testcontainers
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortTunnel(t *testing.T) {
+	// echo server standing for the mapped port of a container in the remote Docker host
+	remote, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { remote.Close() })
+
+	go func() {
+		for {
+			conn, err := remote.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	tunnel, err := newPortTunnel(net.Dial, remote.Addr().String())
+	require.NoError(t, err)
+
+	require.NotZero(t, tunnel.port())
+	require.NotEqual(t, remote.Addr().(*net.TCPAddr).Port, tunnel.port())
+
+	conn, err := net.Dial("tcp", tunnel.listener.Addr().String())
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "hello\n", line)
+
+	require.NoError(t, tunnel.Close())
+
+	// the forwarded connections are closed with the tunnel
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+
+	_, err = net.Dial("tcp", tunnel.listener.Addr().String())
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package testcontainers
+
+import "sync/atomic"
+
+// LibraryStats holds the counters of the activity of the library in the test session.
+// They are handy to monitor the behaviour of long-lived test processes.
+type LibraryStats struct {
+	ContainersCreated        uint64 // containers successfully created
+	ContainersStarted        uint64 // containers successfully started, including restarts
+	ContainersTerminated     uint64 // containers terminated
+	ContainerFailures        uint64 // containers failing to be created or started
+	ImagePulls               uint64 // images successfully pulled
+	ImagePullFailures        uint64 // images failing to be pulled
+	ImageBuilds              uint64 // images successfully built
+	ImageBuildFailures       uint64 // images failing to be built
+	ReapersCreated           uint64 // reaper containers created
+	ReapersReused            uint64 // reaper containers reused from another test process of the session
+	ReaperConnections        uint64 // connections established with the reaper
+	ReaperConnectionFailures uint64 // connections failing to be established with the reaper
+}
+
+// libraryStats is the atomic counterpart of LibraryStats, updated by the library.
+type libraryStats struct {
+	containersCreated        atomic.Uint64
+	containersStarted        atomic.Uint64
+	containersTerminated     atomic.Uint64
+	containerFailures        atomic.Uint64
+	imagePulls               atomic.Uint64
+	imagePullFailures        atomic.Uint64
+	imageBuilds              atomic.Uint64
+	imageBuildFailures       atomic.Uint64
+	reapersCreated           atomic.Uint64
+	reapersReused            atomic.Uint64
+	reaperConnections        atomic.Uint64
+	reaperConnectionFailures atomic.Uint64
+}
+
+var stats libraryStats
+
+// countResult increments the success counter if err is nil, else the failure counter.
+func countResult(err error, success *atomic.Uint64, failure *atomic.Uint64) {
+	if err != nil {
+		failure.Add(1)
+		return
+	}
+
+	success.Add(1)
+}
+
+// Stats returns a snapshot of the counters of the activity of the library in the test session.
+func Stats() LibraryStats {
+	return LibraryStats{
+		ContainersCreated:        stats.containersCreated.Load(),
+		ContainersStarted:        stats.containersStarted.Load(),
+		ContainersTerminated:     stats.containersTerminated.Load(),
+		ContainerFailures:        stats.containerFailures.Load(),
+		ImagePulls:               stats.imagePulls.Load(),
+		ImagePullFailures:        stats.imagePullFailures.Load(),
+		ImageBuilds:              stats.imageBuilds.Load(),
+		ImageBuildFailures:       stats.imageBuildFailures.Load(),
+		ReapersCreated:           stats.reapersCreated.Load(),
+		ReapersReused:            stats.reapersReused.Load(),
+		ReaperConnections:        stats.reaperConnections.Load(),
+		ReaperConnectionFailures: stats.reaperConnectionFailures.Load(),
+	}
+}
@@ -0,0 +1,20 @@
+package testcontainers
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountResult(t *testing.T) {
+	var success, failure atomic.Uint64
+
+	countResult(nil, &success, &failure)
+	countResult(nil, &success, &failure)
+	countResult(errors.New("boom"), &success, &failure)
+
+	require.Equal(t, uint64(2), success.Load())
+	require.Equal(t, uint64(1), failure.Load())
+}
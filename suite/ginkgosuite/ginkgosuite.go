@@ -0,0 +1,67 @@
+// Package ginkgosuite integrates the suite environments with the suites of Ginkgo, returning the functions
+// of its SynchronizedBeforeSuite and SynchronizedAfterSuite nodes, so the package doesn't depend on Ginkgo:
+//
+//	var env = suite.New("orders").Add("db", startPostgres)
+//
+//	var _ = ginkgo.SynchronizedBeforeSuite(ginkgosuite.SynchronizedBeforeSuite(env, ginkgo.Fail))
+//	var _ = ginkgo.SynchronizedAfterSuite(ginkgosuite.SynchronizedAfterSuite(env, ginkgo.Fail))
+//
+// With parallel processes, e.g. ginkgo -p, the first process creates the network of the environment, then each process
+// starts its own containers attached to it. After the tests, each process terminates its containers, then the first
+// process removes the network.
+package ginkgosuite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go/suite"
+)
+
+// FailFunc fails the current node of the suite, i.e. ginkgo.Fail.
+type FailFunc func(message string, callerSkip ...int)
+
+// SynchronizedBeforeSuite returns the functions of ginkgo.SynchronizedBeforeSuite: the first one, run by the first process,
+// creates the network of the environment and passes its name to the second one, run by all the processes,
+// which starts their containers. The errors are reported with fail.
+func SynchronizedBeforeSuite(env *suite.Environment, fail FailFunc) (func(context.Context) []byte, func(context.Context, []byte)) {
+	first := func(ctx context.Context) []byte {
+		if err := env.CreateNetwork(ctx); err != nil {
+			fail(fmt.Sprintf("create suite network: %s", err), 1)
+		}
+
+		return []byte(env.NetworkName())
+	}
+
+	all := func(ctx context.Context, network []byte) {
+		if name := string(network); name != env.NetworkName() {
+			fail(fmt.Sprintf("suite network %s, but the process expects %s: the processes don't share the test session", name, env.NetworkName()), 1)
+			return
+		}
+
+		if err := env.Start(ctx); err != nil {
+			fail(fmt.Sprintf("start suite environment: %s", err), 1)
+		}
+	}
+
+	return first, all
+}
+
+// SynchronizedAfterSuite returns the functions of ginkgo.SynchronizedAfterSuite: the first one, run by all the processes,
+// terminates their containers, and the second one, run by the first process after the others, removes the network.
+// The errors are reported with fail.
+func SynchronizedAfterSuite(env *suite.Environment, fail FailFunc) (func(context.Context), func(context.Context)) {
+	all := func(ctx context.Context) {
+		if err := env.TerminateContainers(ctx); err != nil {
+			fail(fmt.Sprintf("terminate suite containers: %s", err), 1)
+		}
+	}
+
+	first := func(ctx context.Context) {
+		if err := env.RemoveNetwork(ctx); err != nil {
+			fail(fmt.Sprintf("remove suite network: %s", err), 1)
+		}
+	}
+
+	return all, first
+}
@@ -0,0 +1,40 @@
+package ginkgosuite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/suite"
+)
+
+func TestSynchronizedBeforeSuite(t *testing.T) {
+	var failures []string
+	fail := func(message string, _ ...int) {
+		failures = append(failures, message)
+	}
+
+	env := suite.New("ginkgosuite")
+	_, all := SynchronizedBeforeSuite(env, fail)
+
+	// the first process ran in another test session
+	all(context.Background(), []byte("ginkgosuite-other"))
+	require.Len(t, failures, 1)
+	require.Contains(t, failures[0], "the processes don't share the test session")
+}
+
+func TestSynchronizedAfterSuite(t *testing.T) {
+	var failures []string
+	fail := func(message string, _ ...int) {
+		failures = append(failures, message)
+	}
+
+	env := suite.New("ginkgosuite")
+	all, first := SynchronizedAfterSuite(env, fail)
+
+	// nothing was started by the process
+	all(context.Background())
+	first(context.Background())
+	require.Empty(t, failures)
+}
@@ -0,0 +1,267 @@
+// Package suite manages the containers shared by the tests of a suite, started once before the tests
+// and terminated after them, for the test frameworks running the tests in suites. See the testifysuite
+// and ginkgosuite packages for the integration with testify and Ginkgo.
+//
+// The containers of an environment are attached to a network shared by the processes of the test session,
+// named after the environment, so the parallel processes of a framework agree on it without communicating.
+// Each process runs its own containers, with names unique to the process, which are reused if the environment
+// is started again by the same process.
+package suite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	corenetwork "github.com/testcontainers/testcontainers-go/internal/core/network"
+)
+
+// ginkgoProcessEnv is the environment variable set by Ginkgo to the index of the parallel process, starting at 1.
+const ginkgoProcessEnv = "GINKGO_PARALLEL_PROCESS"
+
+// StartFunc creates and starts a container of the suite, with the given options, e.g. the Run function of a module:
+//
+//	func(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+//		return postgres.Run(ctx, "postgres:16-alpine", opts...)
+//	}
+type StartFunc func(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error)
+
+type service struct {
+	name  string
+	start StartFunc
+}
+
+// Environment is the set of containers shared by the tests of a suite.
+type Environment struct {
+	name     string
+	services []service
+
+	mtx        sync.Mutex
+	network    *testcontainers.DockerNetwork
+	containers map[string]testcontainers.Container
+}
+
+// New returns the environment of the suite with the given name, which must be a valid Docker name,
+// e.g. orders-api. Add its containers with Add.
+func New(name string) *Environment {
+	return &Environment{
+		name:       name,
+		containers: make(map[string]testcontainers.Container),
+	}
+}
+
+// Add adds the container of a service to the environment, started with start, in the order of the calls.
+// The container is reachable from the other containers of the environment with the name of the service.
+func (e *Environment) Add(name string, start StartFunc) *Environment {
+	e.services = append(e.services, service{name: name, start: start})
+	return e
+}
+
+// Process returns the index of the parallel process of the test framework running the tests,
+// read from the GINKGO_PARALLEL_PROCESS environment variable, or 0 if the tests are not run in parallel processes.
+func Process() int {
+	process, err := strconv.Atoi(os.Getenv(ginkgoProcessEnv))
+	if err != nil {
+		return 0
+	}
+
+	return process
+}
+
+// NetworkName returns the name of the network of the environment, shared by the processes of the test session.
+func (e *Environment) NetworkName() string {
+	return e.name + "-" + shortID(testcontainers.SessionID())
+}
+
+// ContainerName returns the name of the container of the service, unique to the process.
+func (e *Environment) ContainerName(service string) string {
+	process := shortID(core.ProcessID())
+	if p := Process(); p > 0 {
+		process = "p" + strconv.Itoa(p)
+	}
+
+	return fmt.Sprintf("%s-%s-%s-%s", e.name, service, shortID(testcontainers.SessionID()), process)
+}
+
+// Container returns the container of the service, or nil if it's not started.
+func (e *Environment) Container(service string) testcontainers.Container {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.containers[service]
+}
+
+// CreateNetwork creates the network of the environment, unless it already exists, e.g. created by another process.
+func (e *Environment) CreateNetwork(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.createNetwork(ctx)
+}
+
+// createNetwork creates the network of the environment, unless it already exists. It must be called with the lock held.
+func (e *Environment) createNetwork(ctx context.Context) error {
+	if e.network != nil || e.networkExists(ctx) {
+		return nil
+	}
+
+	//nolint:staticcheck
+	nw, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{
+			Driver: "bridge",
+			Name:   e.NetworkName(),
+			Labels: testcontainers.GenericLabels(),
+		},
+	})
+	if err != nil {
+		// another process may have created it in the meantime
+		if e.networkExists(ctx) {
+			return nil
+		}
+		return fmt.Errorf("create network %s: %w", e.NetworkName(), err)
+	}
+
+	e.network = nw.(*testcontainers.DockerNetwork)
+
+	return nil
+}
+
+// networkExists reports whether the network of the environment exists.
+func (e *Environment) networkExists(ctx context.Context) bool {
+	// the name filter matches the networks containing the name
+	nw, err := corenetwork.GetByName(ctx, e.NetworkName())
+	return err == nil && nw.Name == e.NetworkName()
+}
+
+// Start creates the network of the environment if needed, then starts the containers of the services,
+// in the order they were added. If a container fails to start, the started ones are terminated.
+func (e *Environment) Start(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if err := e.createNetwork(ctx); err != nil {
+		return err
+	}
+
+	for _, svc := range e.services {
+		if _, ok := e.containers[svc.name]; ok {
+			continue
+		}
+
+		ctr, err := svc.start(ctx, e.customizer(svc.name))
+		if ctr != nil {
+			e.containers[svc.name] = ctr
+		}
+		if err != nil {
+			err = fmt.Errorf("start %s: %w", svc.name, err)
+			if termErr := e.terminateContainers(context.Background()); termErr != nil {
+				err = errors.Join(err, termErr)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customizer returns the option naming the container of the service, for the process to reuse it,
+// and attaching it to the network of the environment.
+func (e *Environment) customizer(service string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Name = e.ContainerName(service)
+		req.Reuse = true
+
+		networkName := e.NetworkName()
+		req.Networks = append(req.Networks, networkName)
+
+		if req.NetworkAliases == nil {
+			req.NetworkAliases = make(map[string][]string)
+		}
+		req.NetworkAliases[networkName] = append(req.NetworkAliases[networkName], service)
+
+		return nil
+	}
+}
+
+// TerminateContainers terminates the containers of the process, in the reverse order of their start.
+func (e *Environment) TerminateContainers(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.terminateContainers(ctx)
+}
+
+// terminateContainers terminates the containers of the process. It must be called with the lock held.
+func (e *Environment) terminateContainers(ctx context.Context) error {
+	var errs []error
+	for i := len(e.services) - 1; i >= 0; i-- {
+		name := e.services[i].name
+		ctr, ok := e.containers[name]
+		if !ok {
+			continue
+		}
+
+		if err := ctr.Terminate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("terminate %s: %w", name, err))
+			continue
+		}
+		delete(e.containers, name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// RemoveNetwork removes the network of the environment if it was created by the process. If the containers
+// of other processes are still attached to it, the network is left to the garbage collector.
+func (e *Environment) RemoveNetwork(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.removeNetwork(ctx)
+}
+
+// removeNetwork removes the network created by the process. It must be called with the lock held.
+func (e *Environment) removeNetwork(ctx context.Context) error {
+	if e.network == nil {
+		return nil
+	}
+
+	if err := e.network.Remove(ctx); err != nil {
+		// the network still has active endpoints
+		if errdefs.IsForbidden(err) {
+			return nil
+		}
+		return fmt.Errorf("remove network %s: %w", e.network.Name, err)
+	}
+
+	e.network = nil
+
+	return nil
+}
+
+// Terminate terminates the containers of the process, then removes the network if it was created by the process.
+func (e *Environment) Terminate(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if err := e.terminateContainers(ctx); err != nil {
+		return err
+	}
+
+	return e.removeNetwork(ctx)
+}
+
+// shortID returns the first characters of the identifier, enough to tell the sessions and processes apart.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,84 @@
+package suite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestProcess(t *testing.T) {
+	t.Setenv(ginkgoProcessEnv, "")
+	require.Zero(t, Process())
+
+	t.Setenv(ginkgoProcessEnv, "3")
+	require.Equal(t, 3, Process())
+}
+
+func TestEnvironmentNames(t *testing.T) {
+	env := New("orders")
+	session := shortID(testcontainers.SessionID())
+
+	require.Equal(t, "orders-"+session, env.NetworkName())
+
+	t.Run("process", func(t *testing.T) {
+		t.Setenv(ginkgoProcessEnv, "2")
+		require.Equal(t, "orders-db-"+session+"-p2", env.ContainerName("db"))
+	})
+
+	t.Run("no-process", func(t *testing.T) {
+		t.Setenv(ginkgoProcessEnv, "")
+
+		name := env.ContainerName("db")
+		require.True(t, strings.HasPrefix(name, "orders-db-"+session+"-"))
+		require.NotEqual(t, "orders-db-"+session+"-p0", name)
+		// the name is stable in the process, for the container to be reused
+		require.Equal(t, name, env.ContainerName("db"))
+	})
+}
+
+func TestEnvironmentCustomizer(t *testing.T) {
+	t.Setenv(ginkgoProcessEnv, "1")
+
+	env := New("orders")
+
+	req := testcontainers.GenericContainerRequest{}
+	require.NoError(t, env.customizer("db").Customize(&req))
+
+	require.Equal(t, env.ContainerName("db"), req.Name)
+	require.True(t, req.Reuse)
+	require.Equal(t, []string{env.NetworkName()}, req.Networks)
+	require.Equal(t, map[string][]string{env.NetworkName(): {"db"}}, req.NetworkAliases)
+}
+
+// suiteTestContainer is a container recording its termination.
+type suiteTestContainer struct {
+	testcontainers.Container
+	terminated *[]string
+	name       string
+}
+
+func (c *suiteTestContainer) Terminate(context.Context, ...testcontainers.TerminateOption) error {
+	*c.terminated = append(*c.terminated, c.name)
+	return nil
+}
+
+func TestEnvironmentTerminateContainers(t *testing.T) {
+	var terminated []string
+
+	env := New("orders")
+	for _, name := range []string{"db", "cache", "api"} {
+		env.Add(name, nil)
+		env.containers[name] = &suiteTestContainer{name: name, terminated: &terminated}
+	}
+
+	require.NoError(t, env.TerminateContainers(context.Background()))
+	require.Equal(t, []string{"api", "cache", "db"}, terminated)
+	require.Nil(t, env.Container("db"))
+
+	// the network was not created by the process
+	require.NoError(t, env.RemoveNetwork(context.Background()))
+}
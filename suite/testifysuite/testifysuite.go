@@ -0,0 +1,62 @@
+// Package testifysuite integrates the suite environments with the suites of testify,
+// starting the containers in SetupSuite and terminating them in TearDownSuite.
+package testifysuite
+
+import (
+	"context"
+	"testing"
+
+	testify "github.com/stretchr/testify/suite"
+
+	"github.com/testcontainers/testcontainers-go/suite"
+)
+
+// Suite is a testify suite starting the containers of its environment before the tests, and terminating
+// them after the tests. Embed it in the suite, setting its environment:
+//
+//	type OrdersSuite struct {
+//		testifysuite.Suite
+//	}
+//
+//	func TestOrders(t *testing.T) {
+//		env := suite.New("orders").Add("db", startPostgres)
+//		testify.Run(t, &OrdersSuite{Suite: testifysuite.Suite{Env: env}})
+//	}
+//
+// A suite defining its own SetupSuite or TearDownSuite must call the ones of Suite.
+type Suite struct {
+	testify.Suite
+
+	// Env is the environment of the suite.
+	Env *suite.Environment
+}
+
+// SetupSuite starts the containers of the environment, failing the suite if one can't be started.
+func (s *Suite) SetupSuite() {
+	SetupSuite(s.T(), s.Env)
+}
+
+// TearDownSuite terminates the containers of the environment.
+func (s *Suite) TearDownSuite() {
+	TearDownSuite(s.T(), s.Env)
+}
+
+// SetupSuite starts the containers of the environment, failing the test if one can't be started.
+// Call it from the SetupSuite method of the suites not embedding Suite.
+func SetupSuite(tb testing.TB, env *suite.Environment) {
+	tb.Helper()
+
+	if err := env.Start(context.Background()); err != nil {
+		tb.Fatalf("start suite environment: %s", err)
+	}
+}
+
+// TearDownSuite terminates the containers of the environment, failing the test if one can't be terminated.
+// Call it from the TearDownSuite method of the suites not embedding Suite.
+func TearDownSuite(tb testing.TB, env *suite.Environment) {
+	tb.Helper()
+
+	if err := env.Terminate(context.Background()); err != nil {
+		tb.Errorf("terminate suite environment: %s", err)
+	}
+}
@@ -0,0 +1,80 @@
+package testifysuite_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	testify "github.com/stretchr/testify/suite"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/suite"
+	"github.com/testcontainers/testcontainers-go/suite/testifysuite"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func startNginx(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "docker.io/nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	return testcontainers.GenericContainer(ctx, req)
+}
+
+func startClient(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/alpine:3.20",
+			Cmd:   []string{"sleep", "300"},
+		},
+		Started: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	return testcontainers.GenericContainer(ctx, req)
+}
+
+type webSuite struct {
+	testifysuite.Suite
+}
+
+func (s *webSuite) TestReachable() {
+	ctx := context.Background()
+
+	code, reader, err := s.Env.Container("client").Exec(ctx, []string{"wget", "-q", "-O", "-", "http://web"})
+	s.Require().NoError(err)
+	s.Require().Zero(code)
+
+	out, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Require().Contains(string(out), "Welcome to nginx")
+}
+
+func TestSuite(t *testing.T) {
+	env := suite.New("testifysuite").
+		Add("web", startNginx).
+		Add("client", startClient)
+
+	testify.Run(t, &webSuite{Suite: testifysuite.Suite{Env: env}})
+
+	// the containers are terminated after the suite
+	require.Nil(t, env.Container("web"))
+	require.Nil(t, env.Container("client"))
+}
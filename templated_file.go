@@ -0,0 +1,128 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// WithTemplatedFile renders the text/template to the target file in the container just before the container
+// is started, so the configuration can depend on values only known at runtime, e.g. the IPs and the mapped
+// ports of other containers, or generated passwords, without starting, inspecting and restarting the container.
+//
+// The template is executed with the value returned by data, which can be nil, called with the created container.
+// The container is not started yet, so its own IPs and mapped ports are not known.
+// Besides the builtin functions, the template can use the following functions on containers:
+//   - host: the host where the ports of the container are exposed.
+//   - mappedPort: the mapped port of the given container port, e.g. {{ mappedPort .DB "5432/tcp" }}.
+//   - containerIP: the IP of the container.
+//   - networkIP: the IP of the container in the given network.
+//   - networkAlias: the first alias of the container in the given network.
+//   - containerName: the name of the container, without the leading slash.
+//
+// The file is created with the 0o644 permissions.
+func WithTemplatedFile(target string, tmpl string, data func(ctx context.Context, c Container) (any, error)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		// parse the template early to report the syntax errors before creating the container
+		parsed, err := template.New(target).Option("missingkey=error").Funcs(templatedFileFuncs(context.Background())).Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("parse template of %s: %w", target, err)
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreStarts: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					content, err := renderTemplatedFile(ctx, parsed, c, data)
+					if err != nil {
+						return fmt.Errorf("render template of %s: %w", target, err)
+					}
+
+					if err := c.CopyToContainer(ctx, content, target, 0o644); err != nil {
+						return fmt.Errorf("copy templated file to %s: %w", target, err)
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// renderTemplatedFile executes the template with the value returned by data.
+func renderTemplatedFile(ctx context.Context, parsed *template.Template, c Container, data func(ctx context.Context, c Container) (any, error)) ([]byte, error) {
+	var value any
+	if data != nil {
+		var err error
+		if value, err = data(ctx, c); err != nil {
+			return nil, fmt.Errorf("data: %w", err)
+		}
+	}
+
+	// the functions need the context of the hook, so they are bound to a clone of the template
+	tmpl, err := parsed.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("clone: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(templatedFileFuncs(ctx)).Execute(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// templatedFileFuncs returns the template functions on containers, using the given context.
+func templatedFileFuncs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		"host": func(c Container) (string, error) {
+			return c.Host(ctx)
+		},
+		"mappedPort": func(c Container, port string) (string, error) {
+			mapped, err := c.MappedPort(ctx, nat.Port(port))
+			if err != nil {
+				return "", err
+			}
+			return mapped.Port(), nil
+		},
+		"containerIP": func(c Container) (string, error) {
+			return c.ContainerIP(ctx)
+		},
+		"networkIP": func(c Container, network string) (string, error) {
+			inspect, err := c.Inspect(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			settings, ok := inspect.NetworkSettings.Networks[network]
+			if !ok {
+				return "", fmt.Errorf("container %s is not connected to network %s", c.GetContainerID(), network)
+			}
+			return settings.IPAddress, nil
+		},
+		"networkAlias": func(c Container, network string) (string, error) {
+			aliases, err := c.NetworkAliases(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			if len(aliases[network]) == 0 {
+				return "", fmt.Errorf("container %s has no alias in network %s", c.GetContainerID(), network)
+			}
+			return aliases[network][0], nil
+		},
+		"containerName": func(c Container) (string, error) {
+			inspect, err := c.Inspect(ctx)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimPrefix(inspect.Name, "/"), nil
+		},
+	}
+}
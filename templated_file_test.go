@@ -0,0 +1,123 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// templateTestContainer is a container with fixed runtime values, recording the copied files.
+type templateTestContainer struct {
+	Container
+	files map[string]string
+}
+
+func (c *templateTestContainer) GetContainerID() string {
+	return "db-id"
+}
+
+func (c *templateTestContainer) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (c *templateTestContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	if port != "5432/tcp" {
+		return "", errors.New("port not found")
+	}
+	return "32768/tcp", nil
+}
+
+func (c *templateTestContainer) ContainerIP(context.Context) (string, error) {
+	return "172.17.0.2", nil
+}
+
+func (c *templateTestContainer) Inspect(context.Context, ...InspectOption) (*types.ContainerJSON, error) {
+	return &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{Name: "/db"},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"backend": {IPAddress: "10.0.0.2"}},
+		},
+	}, nil
+}
+
+func (c *templateTestContainer) NetworkAliases(context.Context) (map[string][]string, error) {
+	return map[string][]string{"backend": {"postgres", "db"}}, nil
+}
+
+func (c *templateTestContainer) CopyToContainer(_ context.Context, content []byte, path string, _ int64) error {
+	c.files[path] = string(content)
+	return nil
+}
+
+func TestWithTemplatedFile(t *testing.T) {
+	ctx := context.Background()
+	db := &templateTestContainer{}
+
+	t.Run("render", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		err := WithTemplatedFile("/etc/app/config.yaml", `db:
+  name: {{ containerName .DB }}
+  external: {{ host .DB }}:{{ mappedPort .DB "5432/tcp" }}
+  internal: {{ containerIP .DB }}
+  network: {{ networkIP .DB "backend" }}/{{ networkAlias .DB "backend" }}
+  password: {{ .Password }}
+`, func(_ context.Context, _ Container) (any, error) {
+			return map[string]any{"DB": db, "Password": "s3cr3t"}, nil
+		})(&req)
+		require.NoError(t, err)
+		require.Len(t, req.LifecycleHooks, 1)
+		require.Len(t, req.LifecycleHooks[0].PreStarts, 1)
+
+		app := &templateTestContainer{files: map[string]string{}}
+		require.NoError(t, req.LifecycleHooks[0].PreStarts[0](ctx, app))
+		require.Equal(t, `db:
+  name: db
+  external: localhost:32768
+  internal: 172.17.0.2
+  network: 10.0.0.2/postgres
+  password: s3cr3t
+`, app.files["/etc/app/config.yaml"])
+	})
+
+	t.Run("parse-error", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		err := WithTemplatedFile("/etc/app/config.yaml", "{{ .Unterminated", nil)(&req)
+		require.ErrorContains(t, err, "parse template of /etc/app/config.yaml")
+		require.Empty(t, req.LifecycleHooks)
+	})
+
+	t.Run("render-errors", func(t *testing.T) {
+		for tmpl, expected := range map[string]string{
+			`{{ mappedPort .DB "80/tcp" }}`:     "port not found",
+			`{{ networkIP .DB "frontend" }}`:    "not connected to network frontend",
+			`{{ networkAlias .DB "frontend" }}`: "no alias in network frontend",
+			`{{ .Missing }}`:                    "map has no entry for key",
+		} {
+			req := GenericContainerRequest{}
+			require.NoError(t, WithTemplatedFile("/config", tmpl, func(_ context.Context, _ Container) (any, error) {
+				return map[string]any{"DB": db}, nil
+			})(&req))
+
+			app := &templateTestContainer{files: map[string]string{}}
+			err := req.LifecycleHooks[0].PreStarts[0](ctx, app)
+			require.ErrorContains(t, err, "render template of /config", tmpl)
+			require.ErrorContains(t, err, expected, tmpl)
+			require.Empty(t, app.files)
+		}
+	})
+
+	t.Run("data-error", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		require.NoError(t, WithTemplatedFile("/config", "static", func(_ context.Context, _ Container) (any, error) {
+			return nil, errors.New("boom")
+		})(&req))
+
+		err := req.LifecycleHooks[0].PreStarts[0](ctx, &templateTestContainer{files: map[string]string{}})
+		require.ErrorContains(t, err, "data: boom")
+	})
+}
@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// terminateOptions are the options of the termination of the containers.
+type terminateOptions struct {
+	stopTimeout   *time.Duration
+	retainVolumes bool
+	parallelism   int
+}
+
+// TerminateOption is an option of Container.Terminate and TerminateAll.
+type TerminateOption func(*terminateOptions)
+
+// WithStopTimeout stops the container gracefully before removing it, waiting up to the timeout
+// for it to exit before killing it. By default, the container is killed and removed right away.
+func WithStopTimeout(timeout time.Duration) TerminateOption {
+	return func(opts *terminateOptions) {
+		opts.stopTimeout = &timeout
+	}
+}
+
+// WithVolumesRetained keeps the anonymous volumes of the container when it's removed,
+// e.g. to inspect the data written by the test. By default, they are removed with the container.
+func WithVolumesRetained() TerminateOption {
+	return func(opts *terminateOptions) {
+		opts.retainVolumes = true
+	}
+}
+
+// WithParallelism sets the number of containers terminated at the same time by TerminateAll, which defaults to 8.
+func WithParallelism(n int) TerminateOption {
+	return func(opts *terminateOptions) {
+		opts.parallelism = n
+	}
+}
+
+// newTerminateOptions returns the termination options with the given options applied.
+func newTerminateOptions(opts ...TerminateOption) terminateOptions {
+	options := terminateOptions{
+		parallelism: defaultWorkersCount,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// TerminateAll terminates the containers concurrently, e.g. the fixtures of a package at the end of TestMain,
+// returning the errors of all the failed terminations joined. The nil containers are ignored, so the result
+// of a failed run can be passed as is. The options are passed to the termination of each container.
+func TerminateAll(ctx context.Context, containers []Container, opts ...TerminateOption) error {
+	parallelism := newTerminateOptions(opts...).parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	slots := make(chan struct{}, parallelism)
+	errs := make([]error, len(containers))
+
+	var wg sync.WaitGroup
+	for i, ctr := range containers {
+		if ctr == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("terminate %s: %w", ctr.GetContainerID(), ctx.Err())
+				return
+			}
+
+			if err := ctr.Terminate(ctx, opts...); err != nil {
+				errs[i] = fmt.Errorf("terminate %s: %w", ctr.GetContainerID(), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// SkipTerminationOnFailure keeps the container running when it's terminated after the test failed,
+// and logs how to connect to it, for local debugging. See KeepContainersOnFailure to keep all the containers
+// created by the test.
+func SkipTerminationOnFailure(tb testing.TB) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.KeepOnFailure = tb
+
+		return nil
+	}
+}
+
+// keepOnFailureTests are the running tests registered with KeepContainersOnFailure, the most recent last.
+var keepOnFailureTests struct {
+	sync.Mutex
+	tests []testing.TB
+}
+
+// KeepContainersOnFailure keeps the containers created by the test, from now on, running when they are
+// terminated after the test failed, and logs how to connect to them, for local debugging. The containers
+// are kept for the last test registered when they are created, so don't use it with parallel tests.
+// The containers whose request has its own test, see SkipTerminationOnFailure, are kept for that test.
+func KeepContainersOnFailure(tb testing.TB) {
+	keepOnFailureTests.Lock()
+	keepOnFailureTests.tests = append(keepOnFailureTests.tests, tb)
+	keepOnFailureTests.Unlock()
+
+	tb.Cleanup(func() {
+		keepOnFailureTests.Lock()
+		defer keepOnFailureTests.Unlock()
+
+		keepOnFailureTests.tests = slices.DeleteFunc(keepOnFailureTests.tests, func(t testing.TB) bool {
+			return t == tb
+		})
+	})
+}
+
+// keepOnFailureTest returns the test a new container is kept running for if it failed: the test of its
+// request, if any, or the last test registered with KeepContainersOnFailure, or nil.
+func keepOnFailureTest(tb testing.TB) testing.TB {
+	if tb != nil {
+		return tb
+	}
+
+	keepOnFailureTests.Lock()
+	defer keepOnFailureTests.Unlock()
+
+	if n := len(keepOnFailureTests.tests); n > 0 {
+		return keepOnFailureTests.tests[n-1]
+	}
+
+	return nil
+}
+
+// failedTest returns the test the container is kept running for, if it failed,
+// or nil if the container can be terminated.
+func failedTest(tb testing.TB) testing.TB {
+	if tb != nil && tb.Failed() {
+		return tb
+	}
+
+	return nil
+}
+
+// keptContainer describes a container kept for the debugging of a failed test.
+type keptContainer struct {
+	cli    string // the CLI of the container runtime, e.g. docker
+	id     string
+	name   string
+	host   string
+	ports  nat.PortMap
+	reaped bool // the container is removed by Ryuk when the test process exits
+}
+
+// message returns the instructions to connect to the container.
+func (k keptContainer) message(test string) string {
+	id := k.id
+	if len(id) > 12 {
+		id = id[:12]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "The test %s failed: the container %s (%s) is kept running for debugging.\n", test, strings.TrimPrefix(k.name, "/"), id)
+	fmt.Fprintf(&sb, "  Open a shell:  %s exec -it %s sh\n", k.cli, id)
+	fmt.Fprintf(&sb, "  Read the logs: %s logs %s\n", k.cli, id)
+
+	ports := make([]nat.Port, 0, len(k.ports))
+	for port, bindings := range k.ports {
+		if len(bindings) > 0 {
+			ports = append(ports, port)
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+	for _, port := range ports {
+		fmt.Fprintf(&sb, "  Port %s is mapped to %s:%s\n", port, k.host, k.ports[port][0].HostPort)
+	}
+
+	fmt.Fprintf(&sb, "  Remove it:     %s rm -f -v %s", k.cli, id)
+	if k.reaped {
+		sb.WriteString("\nIt's removed by Ryuk when the test process exits: set TESTCONTAINERS_RYUK_DISABLED=true to keep it longer.")
+	}
+
+	return sb.String()
+}
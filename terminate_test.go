@@ -0,0 +1,214 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// terminateTestContainer is a container recording its termination.
+type terminateTestContainer struct {
+	Container
+	id           string
+	options      terminateOptions
+	terminateErr error
+	terminated   bool
+
+	// running and maxRunning count the concurrent terminations.
+	running    *atomic.Int32
+	maxRunning *atomic.Int32
+}
+
+func (c *terminateTestContainer) GetContainerID() string {
+	return c.id
+}
+
+func (c *terminateTestContainer) Terminate(_ context.Context, opts ...TerminateOption) error {
+	if c.running != nil {
+		n := c.running.Add(1)
+		defer c.running.Add(-1)
+
+		for {
+			maxRunning := c.maxRunning.Load()
+			if n <= maxRunning || c.maxRunning.CompareAndSwap(maxRunning, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c.options = newTerminateOptions(opts...)
+	c.terminated = true
+	return c.terminateErr
+}
+
+func TestTerminateAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("terminated", func(t *testing.T) {
+		ctr1 := &terminateTestContainer{id: "ctr1"}
+		ctr2 := &terminateTestContainer{id: "ctr2"}
+
+		require.NoError(t, TerminateAll(ctx, []Container{ctr1, nil, ctr2}))
+		require.True(t, ctr1.terminated)
+		require.True(t, ctr2.terminated)
+		require.Nil(t, ctr1.options.stopTimeout)
+		require.False(t, ctr1.options.retainVolumes)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		ctr := &terminateTestContainer{id: "ctr"}
+
+		require.NoError(t, TerminateAll(ctx, []Container{ctr}, WithStopTimeout(5*time.Second), WithVolumesRetained()))
+		require.True(t, ctr.terminated)
+		require.NotNil(t, ctr.options.stopTimeout)
+		require.Equal(t, 5*time.Second, *ctr.options.stopTimeout)
+		require.True(t, ctr.options.retainVolumes)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		errTerminate := errors.New("terminate failed")
+
+		ctr1 := &terminateTestContainer{id: "ctr1", terminateErr: errTerminate}
+		ctr2 := &terminateTestContainer{id: "ctr2"}
+
+		err := TerminateAll(ctx, []Container{ctr1, ctr2})
+		require.ErrorIs(t, err, errTerminate)
+		require.ErrorContains(t, err, "terminate ctr1")
+		require.True(t, ctr2.terminated)
+	})
+
+	t.Run("parallelism", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+
+		containers := make([]Container, 10)
+		for i := range containers {
+			containers[i] = &terminateTestContainer{id: fmt.Sprintf("ctr%d", i), running: &running, maxRunning: &maxRunning}
+		}
+
+		require.NoError(t, TerminateAll(ctx, containers, WithParallelism(3)))
+		require.LessOrEqual(t, maxRunning.Load(), int32(3))
+		require.Positive(t, maxRunning.Load())
+
+		for _, ctr := range containers {
+			require.True(t, ctr.(*terminateTestContainer).terminated)
+		}
+	})
+}
+
+// failingTest is a test recording its cleanups and logs, which can be marked as failed.
+type failingTest struct {
+	testing.TB
+	name     string
+	failed   bool
+	cleanups []func()
+	logs     []string
+}
+
+func (t *failingTest) Name() string {
+	return t.name
+}
+
+func (t *failingTest) Failed() bool {
+	return t.failed
+}
+
+func (t *failingTest) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *failingTest) Log(args ...any) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func TestFailedTest(t *testing.T) {
+	t.Run("request", func(t *testing.T) {
+		tb := &failingTest{name: "TestRequest"}
+		require.Nil(t, failedTest(tb))
+
+		tb.failed = true
+		require.Same(t, tb, failedTest(tb))
+	})
+
+	t.Run("keep-containers-on-failure", func(t *testing.T) {
+		tb := &failingTest{name: "TestKeep"}
+		require.Nil(t, keepOnFailureTest(nil))
+
+		KeepContainersOnFailure(tb)
+		kept := keepOnFailureTest(nil)
+		require.Same(t, tb, kept)
+		require.Nil(t, failedTest(kept))
+
+		tb.failed = true
+		require.Same(t, tb, failedTest(kept))
+
+		// the test of the request wins
+		request := &failingTest{name: "TestRequest"}
+		require.Same(t, request, keepOnFailureTest(request))
+
+		for _, cleanup := range tb.cleanups {
+			cleanup()
+		}
+		require.Nil(t, keepOnFailureTest(nil))
+	})
+
+	t.Run("other-tests", func(t *testing.T) {
+		// the containers created before the test registered are not kept for it
+		tb := &failingTest{name: "TestOther"}
+		before := keepOnFailureTest(nil)
+
+		KeepContainersOnFailure(tb)
+		tb.failed = true
+		require.Nil(t, failedTest(before))
+
+		for _, cleanup := range tb.cleanups {
+			cleanup()
+		}
+	})
+}
+
+func TestDockerContainer_Terminate_keptOnFailure(t *testing.T) {
+	var released int
+	tb := &failingTest{name: "TestKept", failed: true}
+	ctr := &DockerContainer{
+		ID:            "kept-on-failure",
+		provider:      &DockerProvider{client: &inspectMockCli{status: "running"}, hostCache: "localhost"},
+		keepOnFailure: tb,
+		releaseBudget: func() { released++ },
+	}
+
+	require.NoError(t, ctr.Terminate(context.Background()))
+	require.Equal(t, 1, released)
+	require.Len(t, tb.logs, 1)
+	require.Contains(t, tb.logs[0], "is kept running for debugging")
+}
+
+func TestKeptContainerMessage(t *testing.T) {
+	kept := keptContainer{
+		cli:  "docker",
+		id:   "0123456789abcdef",
+		name: "/db",
+		host: "localhost",
+		ports: nat.PortMap{
+			"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}},
+			"8080/tcp": {},
+			"443/tcp":  {{HostIP: "0.0.0.0", HostPort: "32769"}},
+		},
+		reaped: true,
+	}
+
+	msg := kept.message("TestOrders")
+	require.Equal(t, `The test TestOrders failed: the container db (0123456789ab) is kept running for debugging.
+  Open a shell:  docker exec -it 0123456789ab sh
+  Read the logs: docker logs 0123456789ab
+  Port 443/tcp is mapped to localhost:32769
+  Port 5432/tcp is mapped to localhost:32768
+  Remove it:     docker rm -f -v 0123456789ab
+It's removed by Ryuk when the test process exits: set TESTCONTAINERS_RYUK_DISABLED=true to keep it longer.`, msg)
+}
@@ -40,6 +40,27 @@ func SkipIfDockerDesktop(t *testing.T, ctx context.Context) {
 	}
 }
 
+// SkipIfDaemonOSTypeIsNot is a utility function capable of skipping tests
+// if the Docker daemon does not run containers for the given operating system,
+// i.e. "linux" or "windows". Use it to gate tests relying on images that are
+// only available for one operating system.
+func SkipIfDaemonOSTypeIsNot(t *testing.T, ctx context.Context, osType string) {
+	provider, err := NewDockerProvider()
+	if err != nil {
+		t.Fatalf("failed to create docker provider: %s", err)
+	}
+	defer provider.Close()
+
+	daemonOSType, err := provider.DaemonOSType(ctx)
+	if err != nil {
+		t.Fatalf("failed to get docker daemon OS type: %s", err)
+	}
+
+	if daemonOSType != osType {
+		t.Skipf("Skipping test that requires %s containers, the Docker daemon runs %s containers", osType, daemonOSType)
+	}
+}
+
 // exampleLogConsumer {
 
 // StdoutLogConsumer is a LogConsumer that prints the log to stdout
@@ -0,0 +1,111 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// OperationTimeouts are the maximum durations of the long operations on a container, so an unresponsive
+// registry or container fails the test with an explicit error, instead of stalling it until the timeout
+// of go test. A zero duration means the timeout of the configuration, e.g. the pull.timeout property,
+// and a negative duration means no timeout.
+type OperationTimeouts struct {
+	// Pull is the timeout to pull the image, including the retries.
+	Pull time.Duration
+	// Start is the timeout to start the container, including the lifecycle hooks and the wait strategy.
+	Start time.Duration
+	// Stop is the timeout to stop or terminate the container, including the lifecycle hooks.
+	Stop time.Duration
+}
+
+// WithOperationTimeouts sets the timeouts of the pull, the start and the stop of the container,
+// overriding the ones of the configuration. A zero duration keeps the timeout of the configuration,
+// and a negative duration disables it.
+func WithOperationTimeouts(pull, start, stop time.Duration) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.OperationTimeouts = &OperationTimeouts{
+			Pull:  pull,
+			Start: start,
+			Stop:  stop,
+		}
+
+		return nil
+	}
+}
+
+// resolve returns the timeouts of the request, completed with the ones of the configuration.
+// The resolved timeouts are zero when there is no timeout.
+func (t *OperationTimeouts) resolve(cfg config.Config) OperationTimeouts {
+	var req OperationTimeouts
+	if t != nil {
+		req = *t
+	}
+
+	return OperationTimeouts{
+		Pull:  resolveTimeout(req.Pull, cfg.PullTimeout),
+		Start: resolveTimeout(req.Start, cfg.StartTimeout),
+		Stop:  resolveTimeout(req.Stop, cfg.StopTimeout),
+	}
+}
+
+// resolveTimeout returns the timeout of the request if set, otherwise the one of the configuration.
+func resolveTimeout(req, cfg time.Duration) time.Duration {
+	switch {
+	case req > 0:
+		return req
+	case req < 0:
+		return 0
+	case cfg > 0:
+		return cfg
+	default:
+		return 0
+	}
+}
+
+// OperationTimeoutError is the cause of the cancellation of an operation exceeding its timeout.
+// It wraps context.DeadlineExceeded.
+type OperationTimeoutError struct {
+	// Operation is the operation, e.g. pull docker.io/nginx:alpine.
+	Operation string
+	// Timeout is the exceeded timeout.
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *OperationTimeoutError) Error() string {
+	return fmt.Sprintf("%s: timed out after %s", e.Operation, e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *OperationTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// withOperationTimeout returns a context canceled after the timeout of the operation,
+// with an OperationTimeoutError as its cause. It returns ctx itself if there is no timeout.
+func withOperationTimeout(ctx context.Context, operation string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, &OperationTimeoutError{Operation: operation, Timeout: timeout})
+}
+
+// operationError returns err, prefixed by the OperationTimeoutError cause of ctx if the operation timed out,
+// because the errors of the Docker client only report a context deadline exceeded.
+func operationError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var timeoutErr *OperationTimeoutError
+	if errors.As(err, &timeoutErr) || !errors.As(context.Cause(ctx), &timeoutErr) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", timeoutErr, err)
+}
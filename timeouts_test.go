@@ -0,0 +1,79 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestWithOperationTimeouts(t *testing.T) {
+	req := GenericContainerRequest{}
+	require.NoError(t, WithOperationTimeouts(time.Minute, 2*time.Minute, -1)(&req))
+	require.Equal(t, &OperationTimeouts{Pull: time.Minute, Start: 2 * time.Minute, Stop: -1}, req.OperationTimeouts)
+}
+
+func TestOperationTimeoutsResolve(t *testing.T) {
+	cfg := config.Config{
+		PullTimeout:  5 * time.Minute,
+		StartTimeout: 3 * time.Minute,
+		StopTimeout:  30 * time.Second,
+	}
+
+	t.Run("config", func(t *testing.T) {
+		var timeouts *OperationTimeouts
+		require.Equal(t, OperationTimeouts{Pull: 5 * time.Minute, Start: 3 * time.Minute, Stop: 30 * time.Second}, timeouts.resolve(cfg))
+	})
+
+	t.Run("request", func(t *testing.T) {
+		timeouts := &OperationTimeouts{Pull: time.Minute, Stop: -1}
+		require.Equal(t, OperationTimeouts{Pull: time.Minute, Start: 3 * time.Minute}, timeouts.resolve(cfg))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		timeouts := &OperationTimeouts{Start: time.Minute}
+		require.Equal(t, OperationTimeouts{Start: time.Minute}, timeouts.resolve(config.Config{}))
+	})
+}
+
+func TestOperationError(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := withOperationTimeout(context.Background(), "pull docker.io/nginx:alpine", time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		err := operationError(ctx, errors.New("error during connect: context deadline exceeded"))
+		require.EqualError(t, err, "pull docker.io/nginx:alpine: timed out after 1ms: error during connect: context deadline exceeded")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var timeoutErr *OperationTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, time.Millisecond, timeoutErr.Timeout)
+
+		// the cause is not repeated
+		require.Equal(t, err, operationError(ctx, err))
+	})
+
+	t.Run("no-timeout", func(t *testing.T) {
+		ctx, cancel := withOperationTimeout(context.Background(), "pull docker.io/nginx:alpine", 0)
+		defer cancel()
+		require.Equal(t, context.Background(), ctx)
+
+		errPull := errors.New("pull failed")
+		require.Equal(t, errPull, operationError(ctx, errPull))
+		require.NoError(t, operationError(ctx, nil))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := withOperationTimeout(parent, "start docker.io/nginx:alpine", time.Hour)
+		defer cancel()
+		cancelParent()
+
+		require.Equal(t, context.Canceled, operationError(ctx, context.Canceled))
+	})
+}
@@ -0,0 +1,184 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Process is a process running in a container, as listed by ps on the host of the container runtime.
+type Process struct {
+	// PID is the ID of the process in the namespace of the host, not of the container.
+	PID int
+	// PPID is the ID of the parent process in the namespace of the host.
+	PPID int
+	// User is the user running the process, e.g. root, or its ID if the user is not known by the host.
+	User string
+	// Command is the command line of the process.
+	Command string
+	// Fields are all the columns of ps, by title, e.g. STIME or TTY.
+	Fields map[string]string
+}
+
+// Processes are the processes running in a container, see Top.
+type Processes []Process
+
+// ProcessMatcher matches a process, see Processes.Filter.
+type ProcessMatcher func(Process) bool
+
+// CommandContains matches the processes whose command line contains the string.
+func CommandContains(s string) ProcessMatcher {
+	return func(p Process) bool {
+		return strings.Contains(p.Command, s)
+	}
+}
+
+// CommandMatches matches the processes whose command line matches the regular expression.
+func CommandMatches(re *regexp.Regexp) ProcessMatcher {
+	return func(p Process) bool {
+		return re.MatchString(p.Command)
+	}
+}
+
+// ProcessUser matches the processes run by the user.
+func ProcessUser(user string) ProcessMatcher {
+	return func(p Process) bool {
+		return p.User == user
+	}
+}
+
+// Filter returns the processes matching all the matchers.
+func (ps Processes) Filter(matchers ...ProcessMatcher) Processes {
+	var matching Processes
+	for _, p := range ps {
+		if matchAll(p, matchers) {
+			matching = append(matching, p)
+		}
+	}
+
+	return matching
+}
+
+// Contains returns true if a process matches all the matchers,
+// e.g. to check that a worker process started in the container.
+func (ps Processes) Contains(matchers ...ProcessMatcher) bool {
+	for _, p := range ps {
+		if matchAll(p, matchers) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAll returns true if the process matches all the matchers.
+func matchAll(p Process, matchers []ProcessMatcher) bool {
+	for _, match := range matchers {
+		if !match(p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// processLister is implemented by the containers listing their processes, see Top.
+type processLister interface {
+	Top(ctx context.Context) (Processes, error)
+}
+
+// Top returns the processes running in the container, as docker top does.
+func Top(ctx context.Context, ctr Container) (Processes, error) {
+	l, ok := asContainer[processLister](ctr)
+	if !ok {
+		return nil, unsupportedError(ctr, "top")
+	}
+
+	return l.Top(ctx)
+}
+
+// Top returns the processes running in the container, as docker top does.
+func (c *DockerContainer) Top(ctx context.Context) (Processes, error) {
+	top, err := c.provider.client.ContainerTop(ctx, c.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("container top: %w", err)
+	}
+	defer c.provider.Close()
+
+	return parseProcesses(top.Titles, top.Processes)
+}
+
+// Top returns the processes running in the container, as nerdctl top does.
+func (c *NerdctlContainer) Top(ctx context.Context) (Processes, error) {
+	out, err := c.provider.run(ctx, "top", c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, rows := splitTopOutput(string(out))
+
+	return parseProcesses(titles, rows)
+}
+
+// splitTopOutput splits the table printed by top, whose first line has the titles,
+// and whose last column, the command line, may contain spaces.
+func splitTopOutput(out string) ([]string, [][]string) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, nil
+	}
+
+	titles := strings.Fields(lines[0])
+
+	rows := make([][]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) > len(titles) && len(titles) > 0 {
+			fields = append(fields[:len(titles)-1], strings.Join(fields[len(titles)-1:], " "))
+		}
+		rows = append(rows, fields)
+	}
+
+	return titles, rows
+}
+
+// parseProcesses returns the processes of the rows of ps, whose columns have the given titles.
+func parseProcesses(titles []string, rows [][]string) (Processes, error) {
+	processes := make(Processes, 0, len(rows))
+	for _, row := range rows {
+		if len(row) != len(titles) {
+			return nil, fmt.Errorf("unexpected process %q for the titles %q", row, titles)
+		}
+
+		p := Process{Fields: make(map[string]string, len(titles))}
+		for i, title := range titles {
+			value := row[i]
+			p.Fields[title] = value
+
+			var err error
+			switch title {
+			case "PID":
+				p.PID, err = strconv.Atoi(value)
+			case "PPID":
+				p.PPID, err = strconv.Atoi(value)
+			case "UID", "USER":
+				p.User = value
+			case "CMD", "COMMAND":
+				p.Command = value
+			}
+			if err != nil {
+				return nil, fmt.Errorf("process %s %q: %w", title, value, err)
+			}
+		}
+
+		processes = append(processes, p)
+	}
+
+	return processes, nil
+}
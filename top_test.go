@@ -0,0 +1,81 @@
+package testcontainers
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcesses(t *testing.T) {
+	processes, err := parseProcesses(
+		[]string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+		[][]string{
+			{"root", "4321", "4300", "0", "10:00", "?", "00:00:00", "nginx: master process nginx -g daemon off;"},
+			{"101", "4350", "4321", "0", "10:00", "?", "00:00:00", "nginx: worker process"},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, processes, 2)
+
+	require.Equal(t, 4350, processes[1].PID)
+	require.Equal(t, 4321, processes[1].PPID)
+	require.Equal(t, "101", processes[1].User)
+	require.Equal(t, "nginx: worker process", processes[1].Command)
+	require.Equal(t, "10:00", processes[1].Fields["STIME"])
+
+	_, err = parseProcesses([]string{"PID", "CMD"}, [][]string{{"abc", "sh"}})
+	require.Error(t, err)
+
+	_, err = parseProcesses([]string{"PID", "CMD"}, [][]string{{"1"}})
+	require.Error(t, err)
+}
+
+func TestSplitTopOutput(t *testing.T) {
+	titles, rows := splitTopOutput(`UID    PID     PPID    C    STIME    TTY    TIME        CMD
+root   4321    4300    0    10:00    ?      00:00:00    nginx: master process nginx -g daemon off;
+`)
+
+	require.Equal(t, []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"}, titles)
+	require.Equal(t, [][]string{
+		{"root", "4321", "4300", "0", "10:00", "?", "00:00:00", "nginx: master process nginx -g daemon off;"},
+	}, rows)
+
+	titles, rows = splitTopOutput("")
+	require.Empty(t, titles)
+	require.Empty(t, rows)
+}
+
+func TestProcesses_Filter(t *testing.T) {
+	processes := Processes{
+		{PID: 1, User: "root", Command: "supervisord -c /etc/supervisord.conf"},
+		{PID: 2, User: "app", Command: "worker --queue emails"},
+		{PID: 3, User: "app", Command: "worker --queue orders"},
+	}
+
+	require.Len(t, processes.Filter(CommandContains("worker")), 2)
+	require.Len(t, processes.Filter(CommandContains("worker"), CommandMatches(regexp.MustCompile(`--queue orders$`))), 1)
+	require.Empty(t, processes.Filter(ProcessUser("root"), CommandContains("worker")))
+
+	require.True(t, processes.Contains(ProcessUser("app")))
+	require.False(t, processes.Contains(CommandContains("cron")))
+	require.True(t, processes.Contains())
+}
+
+func TestDockerContainer_Top(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// top {
+	processes, err := Top(ctx, ctr)
+	require.NoError(t, err)
+	require.True(t, processes.Contains(CommandContains("nginx: worker process")))
+	// }
+}
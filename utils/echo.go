@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// EchoPort is the port of the TCP echo server.
+const EchoPort = "7/tcp"
+
+// EchoServer is a TCP echo server, writing back the data it receives.
+type EchoServer struct {
+	testcontainers.Container
+}
+
+// RunEchoServer starts a TCP echo server listening on EchoPort, with socat, e.g. to test a client
+// or the degradations of the network. It accepts many connections at once.
+func RunEchoServer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*EchoServer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        socatImage,
+		Cmd:          []string{"TCP-LISTEN:7,fork,reuseaddr", "PIPE"},
+		ExposedPorts: []string{EchoPort},
+		WaitingFor:   wait.ForListeningPort(EchoPort),
+	}
+
+	ctr, err := run(ctx, req, opts)
+	var s *EchoServer
+	if ctr != nil {
+		s = &EchoServer{Container: ctr}
+	}
+
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// Address returns the address of the echo server, e.g. localhost:32768.
+func (s *EchoServer) Address(ctx context.Context) (string, error) {
+	return address(ctx, s, EchoPort)
+}
@@ -0,0 +1,40 @@
+package utils_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/utils"
+)
+
+func TestRunEchoServer(t *testing.T) {
+	ctx := context.Background()
+
+	// runEchoServer {
+	echo, err := utils.RunEchoServer(ctx)
+	t.Cleanup(func() {
+		if echo != nil {
+			require.NoError(t, echo.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+
+	addr, err := echo.Address(ctx)
+	require.NoError(t, err)
+	// }
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "ping\n", line)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Relay is a container relaying a port to another address.
+type Relay struct {
+	testcontainers.Container
+	port nat.Port
+}
+
+// relayCmd returns the socat command relaying the port to the target address, over TCP or UDP.
+func relayCmd(port nat.Port, target string) []string {
+	listen, connect := "TCP-LISTEN", "TCP"
+	if port.Proto() == "udp" {
+		listen, connect = "UDP-LISTEN", "UDP"
+	}
+
+	return []string{
+		fmt.Sprintf("%s:%s,fork,reuseaddr", listen, port.Port()),
+		fmt.Sprintf("%s:%s", connect, target),
+	}
+}
+
+// RunRelay starts a relay of the port, e.g. 8080/tcp or 53/udp, to the target address, e.g. orders:8080, with socat.
+// The port is exposed, so the target is reachable from the tests with the Address of the relay, and from the containers
+// of the networks of the relay with its aliases. A service of the host is reachable with testcontainers.HostInternal,
+// with testcontainers.WithHostPortAccess, e.g. to be reached by the containers with the alias of the relay.
+func RunRelay(ctx context.Context, port string, target string, opts ...testcontainers.ContainerCustomizer) (*Relay, error) {
+	p, err := nat.NewPort(nat.SplitProtoPort(port))
+	if err != nil {
+		return nil, fmt.Errorf("port: %w", err)
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        socatImage,
+		Cmd:          relayCmd(p, target),
+		ExposedPorts: []string{string(p)},
+	}
+	if p.Proto() != "udp" {
+		req.WaitingFor = wait.ForListeningPort(p)
+	}
+
+	ctr, err := run(ctx, req, opts)
+	var r *Relay
+	if ctr != nil {
+		r = &Relay{Container: ctr, port: p}
+	}
+
+	if err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// Address returns the address of the relayed port, e.g. localhost:32768.
+func (r *Relay) Address(ctx context.Context) (string, error) {
+	return address(ctx, r, r.port)
+}
@@ -0,0 +1,60 @@
+package utils_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/utils"
+)
+
+func TestRunRelay(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(context.Background()))
+	})
+
+	echo, err := utils.RunEchoServer(ctx, network.WithNetwork([]string{"echo"}, nw))
+	t.Cleanup(func() {
+		if echo != nil {
+			require.NoError(t, echo.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+
+	// runRelay {
+	relay, err := utils.RunRelay(ctx, "9000/tcp", "echo:7", network.WithNetwork([]string{"relay"}, nw))
+	t.Cleanup(func() {
+		if relay != nil {
+			require.NoError(t, relay.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+
+	addr, err := relay.Address(ctx)
+	require.NoError(t, err)
+	// }
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("relayed\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "relayed\n", line)
+
+	t.Run("invalid-port", func(t *testing.T) {
+		_, err := utils.RunRelay(ctx, "http/tcp", "echo:7")
+		require.ErrorContains(t, err, "port")
+	})
+}
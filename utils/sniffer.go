@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// pcapPath is the path of the capture in the container of the sniffer.
+const pcapPath = "/tmp/capture.pcap"
+
+// Sniffer is a container capturing the traffic of another container with tcpdump.
+type Sniffer struct {
+	testcontainers.Container
+}
+
+// snifferCmd returns the tcpdump command capturing the packets of all the interfaces matching the filter, if any,
+// written to the capture as soon as they are captured, so it can be copied while tcpdump is running.
+func snifferCmd(filter string) []string {
+	cmd := []string{"tcpdump", "-i", "any", "-U", "-w", pcapPath}
+	if filter != "" {
+		cmd = append(cmd, filter)
+	}
+
+	return cmd
+}
+
+// RunSniffer starts capturing the traffic of the target container matching the pcap filter, e.g. tcp port 5432,
+// or all its traffic if empty, with tcpdump in a netshoot container sharing the network namespace of the target.
+// The capture goes on until the sniffer is terminated.
+func RunSniffer(ctx context.Context, target testcontainers.Container, filter string, opts ...testcontainers.ContainerCustomizer) (*Sniffer, error) {
+	req := testcontainers.ContainerRequest{
+		Image: netshootImage,
+		Cmd:   snifferCmd(filter),
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + target.GetContainerID())
+			hostConfig.CapAdd = append(hostConfig.CapAdd, "NET_ADMIN", "NET_RAW")
+		},
+		WaitingFor: wait.ForLog("listening on"),
+	}
+
+	ctr, err := run(ctx, req, opts)
+	var s *Sniffer
+	if ctr != nil {
+		s = &Sniffer{Container: ctr}
+	}
+
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// Pcap returns the packets captured so far, in the pcap format, e.g. to be read by Wireshark.
+func (s *Sniffer) Pcap(ctx context.Context) (io.ReadCloser, error) {
+	r, err := s.CopyFileFromContainer(ctx, pcapPath)
+	if err != nil {
+		return nil, fmt.Errorf("copy capture: %w", err)
+	}
+
+	return r, nil
+}
+
+// SavePcap writes the packets captured so far to the file, in the pcap format, creating its directory if needed.
+func (s *Sniffer) SavePcap(ctx context.Context, file string) error {
+	r, err := s.Pcap(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("write capture: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Artifact returns the artifact collector saving the capture as <name>.pcap in the directory of the artifacts,
+// e.g. to keep the capture of a failed test with testcontainers.WithArtifactsOnFailure. The collector saves the capture
+// of the sniffer, whatever the container it's given.
+func (s *Sniffer) Artifact(name string) testcontainers.ArtifactCollector {
+	return func(ctx context.Context, _ testcontainers.Container, destDir string) error {
+		return s.SavePcap(ctx, filepath.Join(destDir, name+".pcap"))
+	}
+}
@@ -0,0 +1,73 @@
+package utils_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/utils"
+)
+
+// pcapMagic is the magic number of the pcap files, in little endian.
+var pcapMagic = []byte{0xd4, 0xc3, 0xb2, 0xa1}
+
+func TestRunSniffer(t *testing.T) {
+	ctx := context.Background()
+
+	echo, err := utils.RunEchoServer(ctx)
+	t.Cleanup(func() {
+		if echo != nil {
+			require.NoError(t, echo.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+
+	// runSniffer {
+	sniffer, err := utils.RunSniffer(ctx, echo, "tcp port 7")
+	t.Cleanup(func() {
+		if sniffer != nil {
+			require.NoError(t, sniffer.Terminate(context.Background()))
+		}
+	})
+	require.NoError(t, err)
+	// }
+
+	addr, err := echo.Address(ctx)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("sniffed\n"))
+	require.NoError(t, err)
+	_, err = bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	file := filepath.Join(t.TempDir(), "captures", "echo.pcap")
+	require.NoError(t, sniffer.SavePcap(ctx, file))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, pcapMagic, data[:4])
+	require.Contains(t, string(data), "sniffed")
+
+	t.Run("artifact", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, sniffer.Artifact("echo")(ctx, echo, dir))
+
+		f, err := os.Open(filepath.Join(dir, "echo.pcap"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		header := make([]byte, 4)
+		_, err = io.ReadFull(f, header)
+		require.NoError(t, err)
+		require.Equal(t, pcapMagic, header)
+	})
+}
@@ -0,0 +1,61 @@
+// Package utils provides ready-made containers for the networking tests, built on tiny images:
+//
+//   - a TCP echo server, writing back the data it receives, e.g. to test clients or network degradations,
+//   - a relay of a port to another address, e.g. to reach a service of the host from the containers,
+//   - a sniffer capturing the traffic of a container with tcpdump, saved as a pcap file, e.g. as an artifact of a failed test.
+//
+// The helpers accept the options of the containers, e.g. network.WithNetwork to attach them to a network.
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// socatImage is the image of the echo servers and of the relays.
+	socatImage = "alpine/socat:1.8.0.0"
+	// netshootImage is the image of the sniffers.
+	netshootImage = "nicolaka/netshoot:v0.13"
+)
+
+// run starts the container of the request, customized with the options.
+func run(ctx context.Context, req testcontainers.ContainerRequest, opts []testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
+
+	ctr, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return ctr, fmt.Errorf("generic container: %w", err)
+	}
+
+	return ctr, nil
+}
+
+// address returns the address of the mapped port of the container, e.g. localhost:32768.
+func address(ctx context.Context, ctr testcontainers.Container, port nat.Port) (string, error) {
+	host, err := ctr.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("host: %w", err)
+	}
+
+	mapped, err := ctr.MappedPort(ctx, port)
+	if err != nil {
+		return "", fmt.Errorf("mapped port: %w", err)
+	}
+
+	return net.JoinHostPort(host, mapped.Port()), nil
+}
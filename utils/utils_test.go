@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayCmd(t *testing.T) {
+	require.Equal(t, []string{"TCP-LISTEN:8080,fork,reuseaddr", "TCP:orders:80"}, relayCmd("8080/tcp", "orders:80"))
+	require.Equal(t, []string{"UDP-LISTEN:53,fork,reuseaddr", "UDP:10.0.0.2:53"}, relayCmd("53/udp", "10.0.0.2:53"))
+}
+
+func TestSnifferCmd(t *testing.T) {
+	require.Equal(t, []string{"tcpdump", "-i", "any", "-U", "-w", pcapPath}, snifferCmd(""))
+	require.Equal(t, []string{"tcpdump", "-i", "any", "-U", "-w", pcapPath, "tcp port 5432"}, snifferCmd("tcp port 5432"))
+}
@@ -62,7 +62,7 @@ func (st mockExecTarget) Host(_ context.Context) (string, error) {
 	return "", errors.New("not implemented")
 }
 
-func (st mockExecTarget) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
+func (st mockExecTarget) Inspect(ctx context.Context, _ ...wait.InspectOption) (*types.ContainerJSON, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -81,7 +81,9 @@ func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 				}
 			}
 			if state.Running {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
 				continue
 			}
 			return nil
@@ -21,7 +21,7 @@ func (st exitStrategyTarget) Host(ctx context.Context) (string, error) {
 	return "", nil
 }
 
-func (st exitStrategyTarget) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
+func (st exitStrategyTarget) Inspect(ctx context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
 	return nil, nil
 }
 
@@ -60,3 +60,20 @@ func TestWaitForExit(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWaitForExitHonorsContext(t *testing.T) {
+	target := exitStrategyTarget{
+		isRunning: true,
+	}
+	wg := NewExitStrategy().WithExitTimeout(100 * time.Millisecond).WithPollInterval(time.Hour)
+
+	start := time.Now()
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+	// the poll interval doesn't delay the timeout
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("the strategy returned after %s", elapsed)
+	}
+}
@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -20,6 +21,14 @@ type HealthStrategy struct {
 
 	// additional properties
 	PollInterval time.Duration
+
+	// FailOnUnhealthy makes the strategy fail as soon as the container is reported unhealthy,
+	// instead of waiting for the startup timeout to expire.
+	FailOnUnhealthy bool
+
+	// StartPeriodAware extends the startup timeout with the start period of the healthcheck
+	// defined for the container, so the timeout only starts counting once the start period is over.
+	StartPeriodAware bool
 }
 
 // NewHealthStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
@@ -45,6 +54,22 @@ func (ws *HealthStrategy) WithPollInterval(pollInterval time.Duration) *HealthSt
 	return ws
 }
 
+// WithFailOnUnhealthy makes the strategy fail as soon as the container is reported unhealthy.
+// Docker only reports a container as unhealthy once the start period of the healthcheck
+// is over and the configured number of consecutive retries have failed, so failing
+// fast does not break containers that take long to boot.
+func (ws *HealthStrategy) WithFailOnUnhealthy() *HealthStrategy {
+	ws.FailOnUnhealthy = true
+	return ws
+}
+
+// WithStartPeriodAware extends the startup timeout with the start period of the container's
+// healthcheck, if any, so slow starting containers are not given up on during their start period.
+func (ws *HealthStrategy) WithStartPeriodAware() *HealthStrategy {
+	ws.StartPeriodAware = true
+	return ws
+}
+
 // ForHealthCheck is the default construction for the fluid interface.
 //
 // For Example:
@@ -67,6 +92,14 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 		timeout = *ws.timeout
 	}
 
+	if ws.StartPeriodAware {
+		startPeriod, err := healthCheckStartPeriod(ctx, target)
+		if err != nil {
+			return err
+		}
+		timeout += startPeriod
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -82,11 +115,43 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 			if err := checkState(state); err != nil {
 				return err
 			}
+			if ws.FailOnUnhealthy && state.Health != nil && state.Health.Status == types.Unhealthy {
+				return unhealthyError(state.Health)
+			}
 			if state.Health == nil || state.Health.Status != types.Healthy {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+// healthCheckStartPeriod returns the start period of the healthcheck defined for the target,
+// or zero if the target does not define any.
+func healthCheckStartPeriod(ctx context.Context, target StrategyTarget) (time.Duration, error) {
+	inspect, err := target.Inspect(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("inspect: %w", err)
+	}
+
+	if inspect == nil || inspect.Config == nil || inspect.Config.Healthcheck == nil {
+		return 0, nil
+	}
+
+	return inspect.Config.Healthcheck.StartPeriod, nil
+}
+
+// unhealthyError builds the error returned when the container is unhealthy,
+// including the output of the last healthcheck probe, if any.
+func unhealthyError(health *types.Health) error {
+	if len(health.Log) == 0 {
+		return fmt.Errorf("container is unhealthy after %d failing checks", health.FailingStreak)
+	}
+
+	last := health.Log[len(health.Log)-1]
+
+	return fmt.Errorf("container is unhealthy after %d failing checks, last check exited with code %d: %s", health.FailingStreak, last.ExitCode, last.Output)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 
@@ -16,16 +17,23 @@ import (
 )
 
 type healthStrategyTarget struct {
-	state *types.ContainerState
-	mtx   sync.Mutex
+	state       *types.ContainerState
+	healthcheck *container.HealthConfig
+	mtx         sync.Mutex
 }
 
 func (st *healthStrategyTarget) Host(ctx context.Context) (string, error) {
 	return "", nil
 }
 
-func (st *healthStrategyTarget) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
-	return nil, nil
+func (st *healthStrategyTarget) Inspect(ctx context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
+	if st.healthcheck == nil {
+		return nil, nil
+	}
+
+	return &types.ContainerJSON{
+		Config: &container.Config{Healthcheck: st.healthcheck},
+	}, nil
 }
 
 // Deprecated: use Inspect instead
@@ -181,3 +189,53 @@ func TestWaitForHealthFailsDueToUnexpectedContainerStatus(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "unexpected container status \"dead\"")
 }
+
+// TestWaitForHealthFailsFastForUnhealthy confirms that an unhealthy container fails
+// right away when the strategy is configured to fail on unhealthy.
+func TestWaitForHealthFailsFastForUnhealthy(t *testing.T) {
+	target := &healthStrategyTarget{
+		state: &types.ContainerState{
+			Running: true,
+			Health: &types.Health{
+				Status:        types.Unhealthy,
+				FailingStreak: 3,
+				Log: []*types.HealthcheckResult{
+					{ExitCode: 1, Output: "connection refused"},
+				},
+			},
+		},
+	}
+	wg := NewHealthStrategy().
+		WithStartupTimeout(5 * time.Second).
+		WithFailOnUnhealthy()
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.EqualError(t, err, "container is unhealthy after 3 failing checks, last check exited with code 1: connection refused")
+}
+
+// TestWaitForHealthStartPeriodAware checks that the start period of the container's healthcheck
+// is added to the startup timeout.
+func TestWaitForHealthStartPeriodAware(t *testing.T) {
+	target := &healthStrategyTarget{
+		state: &types.ContainerState{
+			Running: true,
+			Health:  &types.Health{Status: types.Starting},
+		},
+		healthcheck: &container.HealthConfig{
+			StartPeriod: 500 * time.Millisecond,
+		},
+	}
+	wg := NewHealthStrategy().
+		WithStartupTimeout(100 * time.Millisecond).
+		WithPollInterval(50 * time.Millisecond).
+		WithStartPeriodAware()
+
+	go func(target *healthStrategyTarget) {
+		// become healthy after the startup timeout, but before the start period is over
+		time.Sleep(300 * time.Millisecond)
+		target.setState(&types.Health{Status: types.Healthy})
+	}(target)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+}
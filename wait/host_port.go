@@ -21,6 +21,26 @@ var (
 
 var errShellNotExecutable = errors.New("/bin/sh command not executable")
 
+// PortCheckMode defines where the host port strategy checks the port from.
+type PortCheckMode int
+
+const (
+	// PortCheckAll checks the port from the host, then inside the container. This is the default.
+	PortCheckAll PortCheckMode = iota
+	// PortCheckExternal only checks the port from the host.
+	PortCheckExternal
+	// PortCheckInternal only checks the port inside the container, which needs a shell in the container.
+	PortCheckInternal
+	// PortCheckAny considers the port ready as soon as either the check from the host or
+	// the check inside the container passes. It avoids false negatives from the host, e.g.
+	// when the NAT loopback of the daemon doesn't work, while still checking from the host.
+	PortCheckAny
+)
+
+// anyCheckDialTimeout is the timeout of a connection from the host in the PortCheckAny mode,
+// as an unreachable port may drop the packets instead of refusing the connection.
+const anyCheckDialTimeout = time.Second
+
 type HostPortStrategy struct {
 	// Port is a string containing port number and protocol in the format "80/tcp"
 	// which
@@ -29,10 +49,8 @@ type HostPortStrategy struct {
 	timeout      *time.Duration
 	PollInterval time.Duration
 
-	// skipInternalCheck is a flag to skip the internal check, which is useful when
-	// a shell is not available in the container or when the container doesn't bind
-	// the port internally until additional conditions are met.
-	skipInternalCheck bool
+	// checkMode defines where the port is checked from: the host, inside the container, or both.
+	checkMode PortCheckMode
 }
 
 // NewHostPortStrategy constructs a default host port strategy that waits for the given
@@ -65,7 +83,23 @@ func ForExposedPort() *HostPortStrategy {
 // which is useful when a shell is not available in the container or when the
 // container doesn't bind the port internally until additional conditions are met.
 func (hp *HostPortStrategy) SkipInternalCheck() *HostPortStrategy {
-	hp.skipInternalCheck = true
+	hp.checkMode = PortCheckExternal
+
+	return hp
+}
+
+// SkipExternalCheck changes the host port strategy to skip the check from the host,
+// which is useful when the daemon can't reach the mapped ports from the host, e.g.
+// because of NAT loopback issues. Alias for `WithCheckMode(PortCheckInternal)`.
+func (hp *HostPortStrategy) SkipExternalCheck() *HostPortStrategy {
+	hp.checkMode = PortCheckInternal
+
+	return hp
+}
+
+// WithCheckMode sets where the port is checked from. The default is PortCheckAll.
+func (hp *HostPortStrategy) WithCheckMode(mode PortCheckMode) *HostPortStrategy {
+	hp.checkMode = mode
 
 	return hp
 }
@@ -142,12 +176,20 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		}
 	}
 
-	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
-		return err
+	switch hp.checkMode {
+	case PortCheckExternal:
+		return externalCheck(ctx, ipAddress, port, target, waitInterval)
+	case PortCheckInternal:
+		if err := internalCheck(ctx, internalPort, target); err != nil {
+			return fmt.Errorf("internal check: %w", err)
+		}
+		return nil
+	case PortCheckAny:
+		return anyCheck(ctx, ipAddress, port, internalPort, target, waitInterval)
 	}
 
-	if hp.skipInternalCheck {
-		return nil
+	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
+		return err
 	}
 
 	err = internalCheck(ctx, internalPort, target)
@@ -162,6 +204,11 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 
 func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, waitInterval time.Duration) error {
 	proto := port.Proto()
+	if proto != "tcp" {
+		// dialing a UDP port always succeeds, and SCTP can't be dialed:
+		// rely on the internal check, or use ForUDP for a round trip from the host.
+		return nil
+	}
 	portNumber := port.Int()
 	portString := strconv.Itoa(portNumber)
 
@@ -178,7 +225,9 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 				var v2 *os.SyscallError
 				if errors.As(v.Err, &v2) {
 					if isConnRefusedErr(v2.Err) {
-						time.Sleep(waitInterval)
+						if err := sleep(ctx, waitInterval); err != nil {
+							return err
+						}
 						continue
 					}
 				}
@@ -191,8 +240,55 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 	}
 }
 
+// anyCheck waits until the port is reachable either from the host or inside the container.
+func anyCheck(ctx context.Context, ipAddress string, port nat.Port, internalPort nat.Port, target StrategyTarget, waitInterval time.Duration) error {
+	command := buildInternalCheckCommand(internalPort)
+	// the connectionless protocols can only be checked inside the container
+	external := port.Proto() == "tcp"
+	internal := true
+
+	dialer := net.Dialer{Timeout: anyCheckDialTimeout}
+	address := net.JoinHostPort(ipAddress, port.Port())
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		if external {
+			if conn, err := dialer.DialContext(ctx, "tcp", address); err == nil {
+				conn.Close()
+				return nil
+			}
+		}
+
+		if internal {
+			exitCode, _, err := target.Exec(ctx, []string{"/bin/sh", "-c", command})
+			if err != nil {
+				return fmt.Errorf("%w, host port waiting failed", err)
+			}
+
+			switch exitCode {
+			case 0:
+				return nil
+			case 126:
+				if !external {
+					return fmt.Errorf("internal check: %w", errShellNotExecutable)
+				}
+				log.Println("Shell not executable in container, only external port check will be performed")
+				internal = false
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitInterval):
+		}
+	}
+}
+
 func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget) error {
-	command := buildInternalCheckCommand(internalPort.Int())
+	command := buildInternalCheckCommand(internalPort)
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
@@ -214,12 +310,24 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 	return nil
 }
 
-func buildInternalCheckCommand(internalPort int) string {
-	command := `(
+func buildInternalCheckCommand(internalPort nat.Port) string {
+	port := internalPort.Int()
+
+	var command string
+	switch internalPort.Proto() {
+	case "udp":
+		command = fmt.Sprintf(`cat /proc/net/udp* | awk '{print $2}' | grep -i :%04x`, port)
+	case "sctp":
+		// the local port is the sixth column of the SCTP endpoints
+		command = fmt.Sprintf(`awk '{print $6}' /proc/net/sctp/eps | grep -x %d`, port)
+	default:
+		command = fmt.Sprintf(`(
 					cat /proc/net/tcp* | awk '{print $2}' | grep -i :%04x ||
 					nc -vz -w 1 localhost %d ||
 					/bin/sh -c '</dev/tcp/localhost/%d'
 				)
-				`
-	return "true && " + fmt.Sprintf(command, internalPort, internalPort, internalPort)
+				`, port, port, port)
+	}
+
+	return "true && " + command
 }
@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"strconv"
@@ -544,3 +545,154 @@ func TestHostPortStrategySucceedsGivenShellIsNotInstalled(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// closedTCPPort returns a TCP port on localhost nothing listens on.
+func closedTCPPort(t *testing.T) nat.Port {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return port
+}
+
+func TestHostPortStrategyCheckModes(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	openPort, err := nat.NewPort("tcp", strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closedTCPPort(t)
+
+	// newTarget returns a target mapping the port to the given host port, with the given
+	// exit codes of the internal check, the last one being repeated.
+	newTarget := func(hostPort nat.Port, exitCodes ...int) (*MockStrategyTarget, *int) {
+		var execCount int
+		return &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return hostPort, nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				defer func() { execCount++ }()
+				return exitCodes[min(execCount, len(exitCodes)-1)], nil, nil
+			},
+		}, &execCount
+	}
+
+	t.Run("external", func(t *testing.T) {
+		target, execCount := newTarget(openPort, 1)
+
+		wg := ForListeningPort("80/tcp").
+			WithCheckMode(PortCheckExternal).
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+		if *execCount != 0 {
+			t.Fatalf("expected no internal check, got %d", *execCount)
+		}
+	})
+
+	t.Run("internal", func(t *testing.T) {
+		target, execCount := newTarget(closedPort, 1, 0)
+
+		wg := ForListeningPort("80/tcp").
+			SkipExternalCheck().
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+		if *execCount != 2 {
+			t.Fatalf("expected 2 internal checks, got %d", *execCount)
+		}
+	})
+
+	t.Run("internal/shell-not-executable", func(t *testing.T) {
+		target, _ := newTarget(openPort, 126)
+
+		wg := ForListeningPort("80/tcp").
+			SkipExternalCheck().
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, errShellNotExecutable) {
+			t.Fatalf("expected %v, got %v", errShellNotExecutable, err)
+		}
+	})
+
+	t.Run("any/internal", func(t *testing.T) {
+		target, execCount := newTarget(closedPort, 1, 1, 0)
+
+		wg := ForListeningPort("80/tcp").
+			WithCheckMode(PortCheckAny).
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+		if *execCount != 3 {
+			t.Fatalf("expected 3 internal checks, got %d", *execCount)
+		}
+	})
+
+	t.Run("any/external", func(t *testing.T) {
+		target, execCount := newTarget(openPort, 1)
+
+		wg := ForListeningPort("80/tcp").
+			WithCheckMode(PortCheckAny).
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(100 * time.Millisecond)
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+		if *execCount != 0 {
+			t.Fatalf("expected no internal check, got %d", *execCount)
+		}
+	})
+
+	t.Run("any/timeout", func(t *testing.T) {
+		target, _ := newTarget(closedPort, 1)
+
+		wg := ForListeningPort("80/tcp").
+			WithCheckMode(PortCheckAny).
+			WithStartupTimeout(500 * time.Millisecond).
+			WithPollInterval(100 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
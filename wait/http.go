@@ -188,7 +188,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			return err
 		}
 
-		inspect, err := target.Inspect(ctx)
+		inspect, err := target.Inspect(ctx, Refresh())
 		if err != nil {
 			return err
 		}
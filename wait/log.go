@@ -104,13 +104,17 @@ LOOP:
 
 			reader, err := target.Logs(ctx)
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
 				continue
 			}
 
 			b, err := io.ReadAll(reader)
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
 				continue
 			}
 
@@ -123,7 +127,9 @@ LOOP:
 				break LOOP
 			default:
 				length = len(logs)
-				time.Sleep(ws.PollInterval)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
 				continue
 			}
 		}
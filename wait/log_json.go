@@ -0,0 +1,226 @@
+package wait
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*LogJSONStrategy)(nil)
+	_ StrategyTimeout = (*LogJSONStrategy)(nil)
+)
+
+// LogJSONField is a field that a JSON log line must contain, and its expected value.
+type LogJSONField struct {
+	// Path is the path of the field, with the keys of the nested objects separated by dots, e.g. http.port.
+	Path string
+	// Value is the expected value of the field, compared to the value of the line once both are encoded as JSON,
+	// so 8080 matches the number 8080, and "8080" matches the string "8080".
+	Value any
+}
+
+// LogJSONStrategy will wait until the docker logs contain a JSON line with all the given fields,
+// which is more robust than matching the text of the logs of services with structured logging.
+// The lines that are not JSON objects are ignored.
+type LogJSONStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	Fields       []LogJSONField
+	Occurrence   int
+	PollInterval time.Duration
+}
+
+// NewLogJSONStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewLogJSONStrategy(path string, value any) *LogJSONStrategy {
+	return &LogJSONStrategy{
+		Fields:       []LogJSONField{{Path: path, Value: value}},
+		Occurrence:   1,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForLogJSON is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForLogJSON("msg", "server started").
+//		WithField("level", "info").
+//		WithField("port", 8080)
+func ForLogJSON(path string, value any) *LogJSONStrategy {
+	return NewLogJSONStrategy(path, value)
+}
+
+// WithField adds a field that the JSON log line must contain, besides the other fields
+func (ws *LogJSONStrategy) WithField(path string, value any) *LogJSONStrategy {
+	ws.Fields = append(ws.Fields, LogJSONField{Path: path, Value: value})
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *LogJSONStrategy) WithStartupTimeout(timeout time.Duration) *LogJSONStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *LogJSONStrategy) WithPollInterval(pollInterval time.Duration) *LogJSONStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithOccurrence can be used to wait for the given number of matching lines, default is 1
+func (ws *LogJSONStrategy) WithOccurrence(o int) *LogJSONStrategy {
+	// the number of occurrence needs to be positive
+	if o <= 0 {
+		o = 1
+	}
+	ws.Occurrence = o
+	return ws
+}
+
+func (ws *LogJSONStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *LogJSONStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	expected, err := ws.expectedValues()
+	if err != nil {
+		return err
+	}
+
+	length := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			checkErr := checkTarget(ctx, target)
+
+			reader, err := target.Logs(ctx)
+			if err != nil {
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
+				continue
+			}
+
+			b, err := io.ReadAll(reader)
+			if err != nil {
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
+				continue
+			}
+
+			switch {
+			case length == len(b) && checkErr != nil:
+				return checkErr
+			case ws.countMatches(b, expected) >= ws.Occurrence:
+				return nil
+			default:
+				length = len(b)
+				if err := sleep(ctx, ws.PollInterval); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// expectedValues returns the expected values of the fields encoded as JSON.
+func (ws *LogJSONStrategy) expectedValues() ([][]byte, error) {
+	expected := make([][]byte, len(ws.Fields))
+	for i, f := range ws.Fields {
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		expected[i] = v
+	}
+
+	return expected, nil
+}
+
+// countMatches returns the number of JSON lines of the logs containing all the fields.
+func (ws *LogJSONStrategy) countMatches(b []byte, expected [][]byte) int {
+	count := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			continue
+		}
+
+		if ws.matches(entry, expected) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// matches returns true if the entry contains all the fields with their expected values.
+func (ws *LogJSONStrategy) matches(entry map[string]any, expected [][]byte) bool {
+	for i, f := range ws.Fields {
+		v, ok := lookupJSONPath(entry, f.Path)
+		if !ok {
+			return false
+		}
+
+		actual, err := json.Marshal(v)
+		if err != nil || !bytes.Equal(actual, expected[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupJSONPath returns the value at the path of the entry, with the keys of the nested objects
+// separated by dots. A key containing dots, e.g. "http.port", is matched before the nested objects.
+func lookupJSONPath(entry map[string]any, path string) (any, bool) {
+	if v, ok := entry[path]; ok {
+		return v, true
+	}
+
+	key, rest, ok := strings.Cut(path, ".")
+	if !ok {
+		return nil, false
+	}
+
+	nested, ok := entry[key].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupJSONPath(nested, rest)
+}
@@ -0,0 +1,62 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const jsonLogs = `starting server
+{"level":"debug","msg":"loading config","http":{"port":8080}}
+{"level":"error","msg":"server started","error":"panic: msg=\"server started\""}
+	at main.go:12
+{"level":"info","msg":"server started","http":{"port":8080},"tls":false}
+{"level":"info","msg":"server started","http.port":"9090"}
+not json {"level":"info"}`
+
+func TestWaitForLogJSON(t *testing.T) {
+	waitFor := func(ws *LogJSONStrategy) error {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(jsonLogs))),
+		}
+
+		return ws.WithStartupTimeout(100*time.Millisecond).WaitUntilReady(context.Background(), target)
+	}
+
+	t.Run("field", func(t *testing.T) {
+		require.NoError(t, waitFor(ForLogJSON("msg", "server started")))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		require.NoError(t, waitFor(ForLogJSON("level", "info").WithField("msg", "server started").WithField("tls", false)))
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		require.NoError(t, waitFor(ForLogJSON("http.port", 8080).WithField("level", "info")))
+	})
+
+	t.Run("dotted-key", func(t *testing.T) {
+		require.NoError(t, waitFor(ForLogJSON("http.port", "9090")))
+	})
+
+	t.Run("occurrences", func(t *testing.T) {
+		require.NoError(t, waitFor(ForLogJSON("level", "info").WithOccurrence(2)))
+		require.ErrorIs(t, waitFor(ForLogJSON("level", "info").WithOccurrence(3)), context.DeadlineExceeded)
+	})
+
+	t.Run("type-mismatch", func(t *testing.T) {
+		require.ErrorIs(t, waitFor(ForLogJSON("http.port", "8080").WithField("level", "info")), context.DeadlineExceeded)
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		require.ErrorIs(t, waitFor(ForLogJSON("level", "warn")), context.DeadlineExceeded)
+	})
+
+	t.Run("invalid-value", func(t *testing.T) {
+		require.Error(t, waitFor(ForLogJSON("level", func() {})))
+	})
+}
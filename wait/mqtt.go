@@ -0,0 +1,236 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*MQTTStrategy)(nil)
+	_ StrategyTimeout = (*MQTTStrategy)(nil)
+)
+
+const (
+	defaultMQTTClientID    = "testcontainers-wait"
+	defaultMQTTReadTimeout = 2 * time.Second
+
+	mqttPacketConnect    = 0x10
+	mqttPacketConnAck    = 0x20
+	mqttPacketDisconnect = 0xe0
+)
+
+// errMQTTNotReady is returned by a handshake when the broker is not ready yet.
+var errMQTTNotReady = errors.New("mqtt broker not ready")
+
+// MQTTStrategy waits for a MQTT broker to complete the MQTT 3.1.1 handshake on a port of the container:
+// it sends a CONNECT packet and waits for the CONNACK packet, rather than for a log line, which brokers
+// print before accepting the clients. With credentials, the broker must accept the connection.
+type MQTTStrategy struct {
+	// Port is the port of the broker, e.g. "1883/tcp".
+	Port     nat.Port
+	ClientID string
+	Username string
+	Password string
+	// TLSConfig, if not nil, makes the handshake over TLS, e.g. on the 8883 port.
+	TLSConfig    *tls.Config
+	ReadTimeout  time.Duration
+	PollInterval time.Duration
+
+	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+}
+
+// ForMQTT returns a strategy waiting for the MQTT handshake on the given port of the container.
+func ForMQTT(port nat.Port) *MQTTStrategy {
+	return &MQTTStrategy{
+		Port:         port,
+		ClientID:     defaultMQTTClientID,
+		ReadTimeout:  defaultMQTTReadTimeout,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// WithCredentials sets the username and the password of the CONNECT packet. The broker must accept them.
+func (ms *MQTTStrategy) WithCredentials(username string, password string) *MQTTStrategy {
+	ms.Username = username
+	ms.Password = password
+	return ms
+}
+
+// WithTLS makes the handshake over TLS, with the given configuration.
+func (ms *MQTTStrategy) WithTLS(cfg *tls.Config) *MQTTStrategy {
+	ms.TLSConfig = cfg
+	return ms
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ms *MQTTStrategy) WithStartupTimeout(startupTimeout time.Duration) *MQTTStrategy {
+	ms.timeout = &startupTimeout
+	return ms
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ms *MQTTStrategy) WithPollInterval(pollInterval time.Duration) *MQTTStrategy {
+	ms.PollInterval = pollInterval
+	return ms
+}
+
+func (ms *MQTTStrategy) Timeout() *time.Duration {
+	return ms.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ms *MQTTStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ms.timeout != nil {
+		timeout = *ms.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(ms.PollInterval)
+	defer ticker.Stop()
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ms.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ms.Port)
+		}
+	}
+
+	address := net.JoinHostPort(host, port.Port())
+
+	var handshakeErr error
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		if handshakeErr = ms.handshake(address); handshakeErr == nil {
+			return nil
+		}
+
+		if !errors.Is(handshakeErr, errMQTTNotReady) {
+			return handshakeErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), handshakeErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// handshake connects to the broker and waits for the CONNACK packet, returning errMQTTNotReady
+// if the broker is not ready yet.
+func (ms *MQTTStrategy) handshake(address string) error {
+	conn, err := net.DialTimeout("tcp", address, ms.ReadTimeout)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errMQTTNotReady, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(ms.ReadTimeout)); err != nil {
+		return fmt.Errorf("set deadline: %w", err)
+	}
+
+	if ms.TLSConfig != nil {
+		tlsConn := tls.Client(conn, ms.TLSConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return fmt.Errorf("%w: tls handshake: %w", errMQTTNotReady, err)
+		}
+		conn = tlsConn
+	}
+
+	if _, err := conn.Write(ms.connectPacket()); err != nil {
+		return fmt.Errorf("%w: write connect: %w", errMQTTNotReady, err)
+	}
+
+	// the CONNACK packet: fixed header, remaining length of 2, flags and return code
+	connAck := make([]byte, 4)
+	if _, err := io.ReadFull(conn, connAck); err != nil {
+		return fmt.Errorf("%w: read connack: %w", errMQTTNotReady, err)
+	}
+
+	if connAck[0] != mqttPacketConnAck || connAck[1] != 2 {
+		return fmt.Errorf("%w: unexpected packet %x", errMQTTNotReady, connAck)
+	}
+
+	if code := connAck[3]; code != 0 && ms.Username != "" {
+		return fmt.Errorf("%w: connection refused with return code %d", errMQTTNotReady, code)
+	}
+
+	_, _ = conn.Write([]byte{mqttPacketDisconnect, 0})
+
+	return nil
+}
+
+// connectPacket returns the MQTT 3.1.1 CONNECT packet, with a clean session and the credentials, if any.
+func (ms *MQTTStrategy) connectPacket() []byte {
+	// variable header: protocol name, protocol level 4, flags and keep alive
+	var flags byte = 0x02 // clean session
+	if ms.Username != "" {
+		flags |= 0x80
+		if ms.Password != "" {
+			flags |= 0x40
+		}
+	}
+
+	body := mqttString("MQTT")
+	body = append(body, 4, flags, 0, 30)
+
+	body = append(body, mqttString(ms.ClientID)...)
+	if ms.Username != "" {
+		body = append(body, mqttString(ms.Username)...)
+		if ms.Password != "" {
+			body = append(body, mqttString(ms.Password)...)
+		}
+	}
+
+	packet := []byte{mqttPacketConnect}
+
+	// remaining length, encoded with 7 bits per byte
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		packet = append(packet, b)
+		if length == 0 {
+			break
+		}
+	}
+
+	return append(packet, body...)
+}
+
+// mqttString returns the string prefixed with its length, as encoded in the MQTT packets.
+func mqttString(s string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(s))), s...)
+}
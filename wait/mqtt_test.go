@@ -0,0 +1,122 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// mqttTarget returns a target mapping any port to the given TCP port on localhost.
+func mqttTarget(port int) *MockStrategyTarget {
+	return &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", strconv.Itoa(port))
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+// mqttBroker starts a fake broker on localhost, answering the CONNECT packets with a CONNACK packet
+// with the given return code, and sending the received CONNECT packets to the channel.
+func mqttBroker(t *testing.T, code byte) (int, <-chan []byte) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	packets := make(chan []byte, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			header := make([]byte, 2)
+			if _, err := io.ReadFull(conn, header); err == nil {
+				body := make([]byte, header[1])
+				if _, err := io.ReadFull(conn, body); err == nil {
+					packets <- append(header, body...)
+					_, _ = conn.Write([]byte{0x20, 2, 0, code})
+				}
+			}
+			conn.Close()
+		}
+	}()
+
+	return l.Addr().(*net.TCPAddr).Port, packets
+}
+
+func TestMQTTStrategy_connectPacket(t *testing.T) {
+	packet := ForMQTT("1883/tcp").WithCredentials("alice", "secret").connectPacket()
+
+	expected := []byte{0x10, 46, 0, 4, 'M', 'Q', 'T', 'T', 4, 0xc2, 0, 30}
+	expected = append(expected, 0, 19)
+	expected = append(expected, "testcontainers-wait"...)
+	expected = append(expected, 0, 5)
+	expected = append(expected, "alice"...)
+	expected = append(expected, 0, 6)
+	expected = append(expected, "secret"...)
+	require.Equal(t, expected, packet)
+
+	// long packets have a multi-byte remaining length
+	ms := ForMQTT("1883/tcp")
+	ms.ClientID = string(bytes.Repeat([]byte{'a'}, 200))
+	packet = ms.connectPacket()
+	require.Equal(t, []byte{0x10, 0xd4, 0x01}, packet[:3])
+	require.Len(t, packet, 3+212)
+}
+
+func TestMQTTStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("connack", func(t *testing.T) {
+		port, packets := mqttBroker(t, 0)
+
+		err := ForMQTT("1883/tcp").WithStartupTimeout(5*time.Second).WaitUntilReady(ctx, mqttTarget(port))
+		require.NoError(t, err)
+		require.Equal(t, byte(0x10), (<-packets)[0])
+	})
+
+	t.Run("refused-without-credentials", func(t *testing.T) {
+		// the handshake completes, although the anonymous clients are not authorized
+		port, _ := mqttBroker(t, 5)
+
+		err := ForMQTT("1883/tcp").WithStartupTimeout(5*time.Second).WaitUntilReady(ctx, mqttTarget(port))
+		require.NoError(t, err)
+	})
+
+	t.Run("refused-with-credentials", func(t *testing.T) {
+		port, _ := mqttBroker(t, 4)
+
+		err := ForMQTT("1883/tcp").
+			WithCredentials("alice", "wrong").
+			WithStartupTimeout(500*time.Millisecond).
+			WaitUntilReady(ctx, mqttTarget(port))
+		require.ErrorContains(t, err, "return code 4")
+	})
+
+	t.Run("not-listening", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := l.Addr().(*net.TCPAddr).Port
+		require.NoError(t, l.Close())
+
+		err = ForMQTT("1883/tcp").WithStartupTimeout(500*time.Millisecond).WaitUntilReady(ctx, mqttTarget(port))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -51,7 +51,7 @@ func (st NopStrategyTarget) Host(_ context.Context) (string, error) {
 	return "", nil
 }
 
-func (st NopStrategyTarget) Inspect(_ context.Context) (*types.ContainerJSON, error) {
+func (st NopStrategyTarget) Inspect(_ context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
 	return nil, nil
 }
 
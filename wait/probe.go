@@ -0,0 +1,219 @@
+package wait
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*ProbeStrategy)(nil)
+	_ StrategyTimeout = (*ProbeStrategy)(nil)
+)
+
+// ProbeLabelPrefix is the prefix of the labels defining the readiness probes of an image.
+const ProbeLabelPrefix = "org.testcontainers.probe."
+
+// The labels defining the readiness probes of an image, e.g. in its Dockerfile:
+//
+//	LABEL org.testcontainers.probe.http="/health" \
+//	      org.testcontainers.probe.http.port="8080/tcp" \
+//	      org.testcontainers.probe.timeout="2m"
+const (
+	// ProbeLabelExec is the command which must succeed, either a JSON array of arguments
+	// or a command run by /bin/sh.
+	ProbeLabelExec = ProbeLabelPrefix + "exec"
+	// ProbeLabelHTTP is the path which must answer with a 2xx status.
+	ProbeLabelHTTP = ProbeLabelPrefix + "http"
+	// ProbeLabelHTTPPort is the port of the HTTP probe, the lowest exposed port by default.
+	ProbeLabelHTTPPort = ProbeLabelPrefix + "http.port"
+	// ProbeLabelPort is the comma separated list of the ports which must be listening.
+	ProbeLabelPort = ProbeLabelPrefix + "port"
+	// ProbeLabelLog is the regular expression which must match a line of the logs.
+	ProbeLabelLog = ProbeLabelPrefix + "log"
+	// ProbeLabelTimeout is the startup timeout of the probes, e.g. 2m.
+	ProbeLabelTimeout = ProbeLabelPrefix + "timeout"
+	// ProbeLabelInterval is the polling interval of the probes, e.g. 500ms.
+	ProbeLabelInterval = ProbeLabelPrefix + "interval"
+)
+
+// ProbeStrategy waits for the readiness probes defined by the labels of the image of the container,
+// see ProbeLabelPrefix, so well-labeled images need no wait configuration. The probes are checked
+// one after the other: the ports, the logs, the command and the HTTP endpoint. Without probe labels,
+// the strategy waits for the container to be healthy if the image defines a healthcheck.
+type ProbeStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	PollInterval time.Duration
+}
+
+// NewProbeStrategy constructs a probe strategy, using the timeout and the polling interval
+// of the labels, or the defaults.
+func NewProbeStrategy() *ProbeStrategy {
+	return &ProbeStrategy{}
+}
+
+// ForImageProbes is a convenience method to assign ProbeStrategy
+func ForImageProbes() *ProbeStrategy {
+	return NewProbeStrategy()
+}
+
+// WithStartupTimeout can be used to change the startup timeout, overriding the timeout of the labels
+func (ws *ProbeStrategy) WithStartupTimeout(startupTimeout time.Duration) *ProbeStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to change the polling interval, overriding the interval of the labels
+func (ws *ProbeStrategy) WithPollInterval(pollInterval time.Duration) *ProbeStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+func (ws *ProbeStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *ProbeStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	inspect, err := target.Inspect(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect: %w", err)
+	}
+
+	if inspect == nil || inspect.Config == nil {
+		return nil
+	}
+
+	strategy, err := ws.probes(inspect.Config)
+	if err != nil {
+		return err
+	}
+
+	if strategy == nil {
+		return nil
+	}
+
+	return strategy.WaitUntilReady(ctx, target)
+}
+
+// probes returns the strategy waiting for the probes of the container configuration, or nil if none.
+func (ws *ProbeStrategy) probes(cfg *container.Config) (*MultiStrategy, error) {
+	labels := cfg.Labels
+
+	timeout, err := probeDuration(labels, ProbeLabelTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	interval, err := probeDuration(labels, ProbeLabelInterval)
+	if err != nil {
+		return nil, err
+	}
+	if ws.PollInterval > 0 {
+		interval = ws.PollInterval
+	}
+	if interval == 0 {
+		interval = defaultPollInterval()
+	}
+
+	var strategies []Strategy
+
+	if ports := labels[ProbeLabelPort]; ports != "" {
+		for _, port := range strings.Split(ports, ",") {
+			strategies = append(strategies, ForListeningPort(nat.Port(strings.TrimSpace(port))).WithPollInterval(interval))
+		}
+	}
+
+	if log := labels[ProbeLabelLog]; log != "" {
+		strategies = append(strategies, ForLog(log).AsRegexp().WithPollInterval(interval))
+	}
+
+	if cmd := labels[ProbeLabelExec]; cmd != "" {
+		args, err := probeCommand(cmd)
+		if err != nil {
+			return nil, err
+		}
+		strategies = append(strategies, ForExec(args).WithPollInterval(interval))
+	}
+
+	if path := labels[ProbeLabelHTTP]; path != "" {
+		http := ForHTTP(path).WithPollInterval(interval)
+		if port := labels[ProbeLabelHTTPPort]; port != "" {
+			http = http.WithPort(nat.Port(port))
+		}
+		strategies = append(strategies, http)
+	}
+
+	if len(strategies) == 0 && hasHealthcheck(cfg) {
+		strategies = append(strategies, ForHealthCheck().WithPollInterval(interval).WithFailOnUnhealthy().WithStartPeriodAware())
+	}
+
+	if len(strategies) == 0 {
+		return nil, nil
+	}
+
+	strategy := ForAll(strategies...)
+	if timeout > 0 {
+		strategy = strategy.WithDeadline(timeout)
+	}
+
+	return strategy, nil
+}
+
+// probeDuration returns the duration of the label, or zero if not set.
+func probeDuration(labels map[string]string, label string) (time.Duration, error) {
+	value, ok := labels[label]
+	if !ok {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse label %s: %w", label, err)
+	}
+
+	return d, nil
+}
+
+// probeCommand returns the arguments of the exec probe, either a JSON array, like the exec form
+// of a Dockerfile, or a command run by the shell.
+func probeCommand(cmd string) ([]string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(cmd), "[") {
+		return []string{"/bin/sh", "-c", cmd}, nil
+	}
+
+	var args []string
+	if err := json.Unmarshal([]byte(cmd), &args); err != nil {
+		return nil, fmt.Errorf("parse label %s: %w", ProbeLabelExec, err)
+	}
+
+	return args, nil
+}
+
+// hasHealthcheck returns true if the container has a healthcheck, which is not disabled.
+func hasHealthcheck(cfg *container.Config) bool {
+	return cfg.Healthcheck != nil && len(cfg.Healthcheck.Test) > 0 && cfg.Healthcheck.Test[0] != "NONE"
+}
+
+// HasProbeLabels returns true if the labels define readiness probes, see ProbeLabelPrefix.
+func HasProbeLabels(labels map[string]string) bool {
+	for k := range labels {
+		if strings.HasPrefix(k, ProbeLabelPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
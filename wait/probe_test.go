@@ -0,0 +1,122 @@
+package wait
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// probeStrategyTarget is a container with labels, recording the executed commands.
+type probeStrategyTarget struct {
+	NopStrategyTarget
+	config *container.Config
+	execs  [][]string
+}
+
+func (st *probeStrategyTarget) Inspect(_ context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
+	return &types.ContainerJSON{Config: st.config}, nil
+}
+
+func (st *probeStrategyTarget) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	st.execs = append(st.execs, cmd)
+	if len(st.execs) < 3 {
+		return 1, nil, nil
+	}
+	return 0, nil, nil
+}
+
+func TestProbeStrategy_probes(t *testing.T) {
+	t.Run("labels", func(t *testing.T) {
+		strategy, err := ForImageProbes().probes(&container.Config{
+			Labels: map[string]string{
+				ProbeLabelPort:     "5432/tcp, 8080/tcp",
+				ProbeLabelLog:      "ready to accept",
+				ProbeLabelExec:     `["pg_isready", "-U", "postgres"]`,
+				ProbeLabelHTTP:     "/health",
+				ProbeLabelHTTPPort: "8080/tcp",
+				ProbeLabelTimeout:  "2m",
+				ProbeLabelInterval: "500ms",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2*time.Minute, *strategy.deadline)
+		require.Len(t, strategy.Strategies, 5)
+
+		require.Equal(t, "5432/tcp", string(strategy.Strategies[0].(*HostPortStrategy).Port))
+		require.Equal(t, "8080/tcp", string(strategy.Strategies[1].(*HostPortStrategy).Port))
+		require.Equal(t, 500*time.Millisecond, strategy.Strategies[1].(*HostPortStrategy).PollInterval)
+
+		log := strategy.Strategies[2].(*LogStrategy)
+		require.Equal(t, "ready to accept", log.Log)
+		require.True(t, log.IsRegexp)
+
+		require.Equal(t, []string{"pg_isready", "-U", "postgres"}, strategy.Strategies[3].(*ExecStrategy).cmd)
+
+		http := strategy.Strategies[4].(*HTTPStrategy)
+		require.Equal(t, "/health", http.Path)
+		require.Equal(t, "8080/tcp", string(http.Port))
+	})
+
+	t.Run("shell-command", func(t *testing.T) {
+		strategy, err := ForImageProbes().WithStartupTimeout(time.Minute).probes(&container.Config{
+			Labels: map[string]string{ProbeLabelExec: "test -f /ready", ProbeLabelTimeout: "2m"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, *strategy.deadline)
+		require.Equal(t, []string{"/bin/sh", "-c", "test -f /ready"}, strategy.Strategies[0].(*ExecStrategy).cmd)
+	})
+
+	t.Run("healthcheck", func(t *testing.T) {
+		strategy, err := ForImageProbes().probes(&container.Config{
+			Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}},
+		})
+		require.NoError(t, err)
+		require.Nil(t, strategy.deadline)
+
+		health := strategy.Strategies[0].(*HealthStrategy)
+		require.True(t, health.FailOnUnhealthy)
+		require.True(t, health.StartPeriodAware)
+	})
+
+	t.Run("no-probes", func(t *testing.T) {
+		strategy, err := ForImageProbes().probes(&container.Config{
+			Healthcheck: &container.HealthConfig{Test: []string{"NONE"}},
+		})
+		require.NoError(t, err)
+		require.Nil(t, strategy)
+	})
+
+	t.Run("invalid-labels", func(t *testing.T) {
+		_, err := ForImageProbes().probes(&container.Config{Labels: map[string]string{ProbeLabelTimeout: "2 minutes"}})
+		require.ErrorContains(t, err, "parse label "+ProbeLabelTimeout)
+
+		_, err = ForImageProbes().probes(&container.Config{Labels: map[string]string{ProbeLabelExec: `["pg_isready"`}})
+		require.ErrorContains(t, err, "parse label "+ProbeLabelExec)
+	})
+}
+
+func TestProbeStrategy_WaitUntilReady(t *testing.T) {
+	target := &probeStrategyTarget{
+		config: &container.Config{Labels: map[string]string{ProbeLabelExec: "test -f /ready", ProbeLabelInterval: "10ms"}},
+	}
+
+	require.NoError(t, ForImageProbes().WaitUntilReady(context.Background(), target))
+	require.Len(t, target.execs, 3)
+
+	target = &probeStrategyTarget{
+		config: &container.Config{Labels: map[string]string{ProbeLabelExec: "test -f /ready", ProbeLabelInterval: "10ms", ProbeLabelTimeout: "15ms"}},
+	}
+	require.ErrorIs(t, ForImageProbes().WaitUntilReady(context.Background(), target), context.DeadlineExceeded)
+}
+
+func TestHasProbeLabels(t *testing.T) {
+	require.True(t, HasProbeLabels(map[string]string{ProbeLabelHTTP: "/health"}))
+	require.False(t, HasProbeLabels(map[string]string{"org.testcontainers": "true"}))
+}
@@ -0,0 +1,183 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*ReachableStrategy)(nil)
+	_ StrategyTimeout = (*ReachableStrategy)(nil)
+)
+
+const defaultReachableConnectTimeout = time.Second
+
+// diagnosticTimeout is the timeout of the command diagnosing why the host is not reachable.
+const diagnosticTimeout = 5 * time.Second
+
+// errNoProbeCommand is returned when the container has no shell, or neither nc nor bash to open the connection.
+var errNoProbeCommand = errors.New("/bin/sh, nc or bash not found in the container")
+
+// reachableHost matches the hostnames, network aliases and IP addresses that can be passed to the shell.
+var reachableHost = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// ReachableStrategy waits until the container can open a TCP connection to a host and port, e.g. the network
+// alias and the port of another container in a shared network. The connection is opened from inside the container,
+// with nc or bash, so it catches the DNS and network alias misconfigurations before the test logic runs.
+type ReachableStrategy struct {
+	// Host is the host to connect to, e.g. the network alias of another container.
+	Host string
+	// Port is the port to connect to, in the format "5432/tcp".
+	Port nat.Port
+	// ConnectTimeout is the timeout of each connection attempt.
+	ConnectTimeout time.Duration
+	PollInterval   time.Duration
+
+	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+}
+
+// ForReachable returns a strategy waiting until the container can connect to the given host and port,
+// e.g. wait.ForReachable("db", "5432/tcp") where db is the network alias of a database container.
+func ForReachable(host string, port nat.Port) *ReachableStrategy {
+	return &ReachableStrategy{
+		Host:           host,
+		Port:           port,
+		ConnectTimeout: defaultReachableConnectTimeout,
+		PollInterval:   defaultPollInterval(),
+	}
+}
+
+// WithConnectTimeout can be used to change the timeout of each connection attempt, which defaults to 1 second.
+// It's rounded up to the second, as nc only supports seconds.
+func (rs *ReachableStrategy) WithConnectTimeout(connectTimeout time.Duration) *ReachableStrategy {
+	rs.ConnectTimeout = connectTimeout
+	return rs
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (rs *ReachableStrategy) WithStartupTimeout(startupTimeout time.Duration) *ReachableStrategy {
+	rs.timeout = &startupTimeout
+	return rs
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (rs *ReachableStrategy) WithPollInterval(pollInterval time.Duration) *ReachableStrategy {
+	rs.PollInterval = pollInterval
+	return rs
+}
+
+func (rs *ReachableStrategy) Timeout() *time.Duration {
+	return rs.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (rs *ReachableStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if !reachableHost.MatchString(rs.Host) {
+		return fmt.Errorf("invalid host %q", rs.Host)
+	}
+
+	if proto := rs.Port.Proto(); proto != "tcp" {
+		return fmt.Errorf("port %s: protocol %s is not tcp", rs.Port, proto)
+	}
+
+	timeout := defaultStartupTimeout()
+	if rs.timeout != nil {
+		timeout = *rs.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	command := rs.probeCommand()
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		exitCode, _, err := target.Exec(ctx, []string{"/bin/sh", "-c", command})
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%w: %s", ctx.Err(), rs.diagnose(target))
+			}
+			return fmt.Errorf("probe %s:%s: %w", rs.Host, rs.Port.Port(), err)
+		}
+
+		switch exitCode {
+		case 0:
+			return nil
+		case 126, 127:
+			return fmt.Errorf("probe %s:%s: %w", rs.Host, rs.Port.Port(), errNoProbeCommand)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ctx.Err(), rs.diagnose(target))
+		case <-time.After(rs.PollInterval):
+		}
+	}
+}
+
+// probeCommand returns the shell command opening a TCP connection to the host and port,
+// with nc, or with the /dev/tcp pseudo-device of bash if nc is not available.
+func (rs *ReachableStrategy) probeCommand() string {
+	seconds := int((rs.ConnectTimeout + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	port := rs.Port.Port()
+
+	return fmt.Sprintf(
+		`if command -v nc >/dev/null 2>&1; then nc -z -w %d %s %s; else timeout %d bash -c '</dev/tcp/%s/%s'; fi`,
+		seconds, rs.Host, port, seconds, rs.Host, port,
+	)
+}
+
+// diagnose tells why the host was not reachable, checking if the host resolves in the container.
+func (rs *ReachableStrategy) diagnose(target StrategyTarget) string {
+	unreachable := fmt.Sprintf("%s:%s is not reachable from the container", rs.Host, rs.Port.Port())
+
+	// the context of the strategy is done, so use a new one
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticTimeout)
+	defer cancel()
+
+	// only getent prints the addresses of the host concisely
+	command := fmt.Sprintf(`if command -v getent >/dev/null 2>&1; then getent hosts %[1]s; else nslookup %[1]s >/dev/null; fi`, rs.Host)
+	exitCode, reader, err := target.Exec(ctx, []string{"/bin/sh", "-c", command}, tcexec.Multiplexed())
+	if err != nil {
+		return unreachable
+	}
+
+	var output string
+	if reader != nil {
+		if b, err := io.ReadAll(reader); err == nil {
+			output = strings.TrimSpace(string(b))
+		}
+	}
+
+	switch exitCode {
+	case 0:
+	case 126, 127:
+		// neither getent nor nslookup are available
+		return unreachable
+	default:
+		return fmt.Sprintf("%s: the host doesn't resolve, check the network aliases and that both containers share a network", unreachable)
+	}
+
+	if output != "" {
+		return fmt.Sprintf("%s: the host resolves (%s), check that the port is listening", unreachable, strings.Join(strings.Fields(output), " "))
+	}
+
+	return unreachable
+}
@@ -0,0 +1,101 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/exec"
+)
+
+// reachableTarget returns a running target whose probes exit with the given codes, the last one being repeated,
+// and whose diagnostic command exits with the diagnostic code, printing the output.
+func reachableTarget(diagnosticCode int, diagnosticOutput string, probeCodes ...int) (*MockStrategyTarget, *[]string) {
+	var commands []string
+	var probes int
+
+	return &MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+		ExecImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			commands = append(commands, cmd[2])
+
+			if strings.Contains(cmd[2], "getent") {
+				return diagnosticCode, bytes.NewReader([]byte(diagnosticOutput)), nil
+			}
+
+			defer func() { probes++ }()
+			return probeCodes[min(probes, len(probeCodes)-1)], nil, nil
+		},
+	}, &commands
+}
+
+func TestReachableStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reachable", func(t *testing.T) {
+		target, commands := reachableTarget(0, "", 1, 1, 0)
+
+		err := ForReachable("db", "5432/tcp").
+			WithStartupTimeout(5*time.Second).
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(ctx, target)
+		require.NoError(t, err)
+
+		require.Len(t, *commands, 3)
+		require.Equal(t, `if command -v nc >/dev/null 2>&1; then nc -z -w 1 db 5432; else timeout 1 bash -c '</dev/tcp/db/5432'; fi`, (*commands)[0])
+	})
+
+	t.Run("connect-timeout", func(t *testing.T) {
+		rs := ForReachable("db", "5432/tcp").WithConnectTimeout(1500 * time.Millisecond)
+		require.Contains(t, rs.probeCommand(), "nc -z -w 2 db 5432")
+	})
+
+	t.Run("not-resolving", func(t *testing.T) {
+		target, _ := reachableTarget(2, "", 1)
+
+		err := ForReachable("db", "5432/tcp").
+			WithStartupTimeout(200*time.Millisecond).
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(ctx, target)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "db:5432 is not reachable from the container: the host doesn't resolve")
+	})
+
+	t.Run("resolving", func(t *testing.T) {
+		target, _ := reachableTarget(0, "172.18.0.2      db\n", 1)
+
+		err := ForReachable("db", "5432/tcp").
+			WithStartupTimeout(200*time.Millisecond).
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(ctx, target)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "the host resolves (172.18.0.2 db), check that the port is listening")
+	})
+
+	t.Run("no-probe-command", func(t *testing.T) {
+		target, _ := reachableTarget(0, "", 127)
+
+		err := ForReachable("db", "5432/tcp").WaitUntilReady(ctx, target)
+		require.ErrorIs(t, err, errNoProbeCommand)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		target, commands := reachableTarget(0, "", 0)
+
+		err := ForReachable("db; rm -rf /", "5432/tcp").WaitUntilReady(ctx, target)
+		require.ErrorContains(t, err, "invalid host")
+
+		err = ForReachable("dns", "53/udp").WaitUntilReady(ctx, target)
+		require.ErrorContains(t, err, "not tcp")
+
+		require.Empty(t, *commands)
+	})
+}
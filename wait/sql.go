@@ -3,7 +3,11 @@ package wait
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/docker/go-connections/nat"
@@ -16,7 +20,10 @@ var (
 
 const defaultForSqlQuery = "SELECT 1"
 
-// ForSQL constructs a new waitForSql strategy for the given driver
+// ForSQL constructs a new waitForSql strategy for the given driver.
+// The url function receives the host and the mapped port of the container,
+// and it can be nil if the URL is set using WithURLTemplate, or a connector
+// is set using WithConnector.
 func ForSQL(port nat.Port, driver string, url func(host string, port nat.Port) string) *waitForSql {
 	return &waitForSql{
 		Port:           port,
@@ -37,6 +44,18 @@ type waitForSql struct {
 	startupTimeout time.Duration
 	PollInterval   time.Duration
 	query          string
+
+	// urlTemplate is a text/template for the URL, rendered with the host and the mapped port.
+	urlTemplate string
+
+	// connector creates the connector to the database, for drivers not registered by name.
+	connector func(host string, port nat.Port) (driver.Connector, error)
+
+	// ping checks the database using a ping instead of a query.
+	ping bool
+
+	// txCheck checks the database in a transaction, which is always rolled back.
+	txCheck func(ctx context.Context, tx *sql.Tx) error
 }
 
 // WithStartupTimeout can be used to change the default startup timeout
@@ -57,11 +76,47 @@ func (w *waitForSql) WithQuery(query string) *waitForSql {
 	return w
 }
 
+// WithURLTemplate sets the URL of the database as a text/template, rendered with
+// the host of the container, as {{.Host}}, and the mapped port, as {{.Port}},
+// overriding the url function passed to ForSQL.
+//
+// For Example:
+//
+//	wait.ForSQL("5432/tcp", "pgx", nil).
+//		WithURLTemplate("postgres://user:password@{{.Host}}:{{.Port}}/db?sslmode=disable")
+func (w *waitForSql) WithURLTemplate(tmpl string) *waitForSql {
+	w.urlTemplate = tmpl
+	return w
+}
+
+// WithConnector sets a function creating the connector to the database, which is
+// opened with sql.OpenDB instead of sql.Open. Use it for drivers that are not registered
+// by name in the database/sql package. The driver name and the URL are ignored.
+func (w *waitForSql) WithConnector(connector func(host string, port nat.Port) (driver.Connector, error)) *waitForSql {
+	w.connector = connector
+	return w
+}
+
+// WithPing checks the database using a ping, which is driver-agnostic, instead of running a query.
+func (w *waitForSql) WithPing() *waitForSql {
+	w.ping = true
+	return w
+}
+
+// WithTxCheck checks the database running the given function in a transaction,
+// which is always rolled back, instead of running a query. Use it for checks
+// needing several statements, e.g. setting the search path before a schema-qualified probe.
+func (w *waitForSql) WithTxCheck(check func(ctx context.Context, tx *sql.Tx) error) *waitForSql {
+	w.txCheck = check
+	return w
+}
+
 func (w *waitForSql) Timeout() *time.Duration {
 	return w.timeout
 }
 
 // WaitUntilReady repeatedly tries to run "SELECT 1" or user defined query on the given port using sql and driver.
+// If a ping or a transaction check is set, they are used instead of the query.
 //
 // If it doesn't succeed until the timeout value which defaults to 60 seconds, it will return an error.
 func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
@@ -96,23 +151,105 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		}
 	}
 
-	db, err := sql.Open(w.Driver, w.URL(host, port))
+	db, err := w.open(host, port)
 	if err != nil {
-		return fmt.Errorf("sql.Open: %w", err)
+		return err
 	}
 	defer db.Close()
+
+	var checkErr error
 	for {
 		select {
 		case <-ctx.Done():
+			if checkErr != nil {
+				return fmt.Errorf("%w: %w", ctx.Err(), checkErr)
+			}
 			return ctx.Err()
 		case <-ticker.C:
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
-			if _, err := db.ExecContext(ctx, w.query); err != nil {
+			if checkErr = w.check(ctx, db); checkErr != nil {
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+// open opens the database, using the connector if set, else the driver and the URL.
+func (w *waitForSql) open(host string, port nat.Port) (*sql.DB, error) {
+	if w.connector != nil {
+		connector, err := w.connector(host, port)
+		if err != nil {
+			return nil, fmt.Errorf("connector: %w", err)
+		}
+
+		return sql.OpenDB(connector), nil
+	}
+
+	// drivers can be registered at any time before waiting, so check it here
+	if !slices.Contains(sql.Drivers(), w.Driver) {
+		return nil, fmt.Errorf("sql driver %q is not registered: import the driver package in your test, or use WithConnector", w.Driver)
+	}
+
+	url, err := w.url(host, port)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(w.Driver, url)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+
+	return db, nil
+}
+
+// url returns the URL of the database, rendering the URL template if set.
+func (w *waitForSql) url(host string, port nat.Port) (string, error) {
+	if w.urlTemplate == "" {
+		if w.URL == nil {
+			return "", fmt.Errorf("no URL for the database: pass an url function to ForSQL, or use WithURLTemplate")
+		}
+		return w.URL(host, port), nil
+	}
+
+	tmpl, err := template.New("url").Option("missingkey=error").Parse(w.urlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse url template: %w", err)
+	}
+
+	var sb strings.Builder
+	err = tmpl.Execute(&sb, struct {
+		Host string
+		Port string
+	}{
+		Host: host,
+		Port: port.Port(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("execute url template: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+// check runs the configured check against the database: a transaction check, a ping, or the query.
+func (w *waitForSql) check(ctx context.Context, db *sql.DB) error {
+	switch {
+	case w.txCheck != nil:
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin transaction: %w", err)
+		}
+		defer tx.Rollback() //nolint:errcheck // the check never commits.
+
+		return w.txCheck(ctx, tx)
+	case w.ping:
+		return db.PingContext(ctx)
+	default:
+		_, err := db.ExecContext(ctx, w.query)
+		return err
+	}
+}
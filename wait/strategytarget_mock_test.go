@@ -13,6 +13,8 @@ import (
 	nat "github.com/docker/go-connections/nat"
 
 	types "github.com/docker/docker/api/types"
+
+	wait "github.com/testcontainers/testcontainers-go/wait"
 )
 
 // mockStrategyTarget is an autogenerated mock type for the StrategyTarget type
@@ -224,9 +226,16 @@ func (_c *mockStrategyTarget_Host_Call) RunAndReturn(run func(context.Context) (
 	return _c
 }
 
-// Inspect provides a mock function with given fields: _a0
-func (_m *mockStrategyTarget) Inspect(_a0 context.Context) (*types.ContainerJSON, error) {
-	ret := _m.Called(_a0)
+// Inspect provides a mock function with given fields: _a0, _a1
+func (_m *mockStrategyTarget) Inspect(_a0 context.Context, _a1 ...wait.InspectOption) (*types.ContainerJSON, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Inspect")
@@ -234,19 +243,19 @@ func (_m *mockStrategyTarget) Inspect(_a0 context.Context) (*types.ContainerJSON
 
 	var r0 *types.ContainerJSON
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*types.ContainerJSON, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, ...wait.InspectOption) (*types.ContainerJSON, error)); ok {
+		return rf(_a0, _a1...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *types.ContainerJSON); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, ...wait.InspectOption) *types.ContainerJSON); ok {
+		r0 = rf(_a0, _a1...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.ContainerJSON)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, ...wait.InspectOption) error); ok {
+		r1 = rf(_a0, _a1...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -261,13 +270,21 @@ type mockStrategyTarget_Inspect_Call struct {
 
 // Inspect is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *mockStrategyTarget_Expecter) Inspect(_a0 interface{}) *mockStrategyTarget_Inspect_Call {
-	return &mockStrategyTarget_Inspect_Call{Call: _e.mock.On("Inspect", _a0)}
+//   - _a1 ...wait.InspectOption
+func (_e *mockStrategyTarget_Expecter) Inspect(_a0 interface{}, _a1 ...interface{}) *mockStrategyTarget_Inspect_Call {
+	return &mockStrategyTarget_Inspect_Call{Call: _e.mock.On("Inspect",
+		append([]interface{}{_a0}, _a1...)...)}
 }
 
-func (_c *mockStrategyTarget_Inspect_Call) Run(run func(_a0 context.Context)) *mockStrategyTarget_Inspect_Call {
+func (_c *mockStrategyTarget_Inspect_Call) Run(run func(_a0 context.Context, _a1 ...wait.InspectOption)) *mockStrategyTarget_Inspect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		variadicArgs := make([]wait.InspectOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(wait.InspectOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
@@ -277,7 +294,7 @@ func (_c *mockStrategyTarget_Inspect_Call) Return(_a0 *types.ContainerJSON, _a1
 	return _c
 }
 
-func (_c *mockStrategyTarget_Inspect_Call) RunAndReturn(run func(context.Context) (*types.ContainerJSON, error)) *mockStrategyTarget_Inspect_Call {
+func (_c *mockStrategyTarget_Inspect_Call) RunAndReturn(run func(context.Context, ...wait.InspectOption) (*types.ContainerJSON, error)) *mockStrategyTarget_Inspect_Call {
 	_c.Call.Return(run)
 	return _c
 }
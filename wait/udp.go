@@ -0,0 +1,210 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*UDPStrategy)(nil)
+	_ StrategyTimeout = (*UDPStrategy)(nil)
+)
+
+const defaultUDPReadTimeout = 500 * time.Millisecond
+
+// errUDPNotReady is returned by a probe when the port is not ready yet.
+var errUDPNotReady = errors.New("udp port not ready")
+
+// UDPStrategy waits for a UDP port of the container to be reachable from the host,
+// sending a datagram to the mapped port.
+//
+// By default, the port is considered ready when no ICMP port unreachable is received
+// for the datagram within the read timeout. When a response is expected, the port is
+// ready when the container replies, and the reply is accepted by the matcher if any.
+//
+// Note that the Docker userland proxy binds the UDP port on the host even if nothing
+// listens in the container, so no ICMP port unreachable is received: expect a response
+// for a reliable check when the userland proxy is used.
+type UDPStrategy struct {
+	// Port is the port of the container, in the format "53/udp".
+	Port nat.Port
+	// Payload is the datagram sent to the port.
+	Payload []byte
+	// ReadTimeout is the time to wait for a response to a datagram.
+	ReadTimeout  time.Duration
+	PollInterval time.Duration
+
+	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// expectResponse requires a response to the datagram.
+	expectResponse bool
+
+	// responseMatcher checks the response, if set.
+	responseMatcher func(response []byte) bool
+}
+
+// ForUDP returns a strategy waiting for the given UDP port to be reachable from the host.
+// The protocol defaults to udp if the port doesn't specify one.
+func ForUDP(port nat.Port) *UDPStrategy {
+	if !strings.Contains(string(port), "/") {
+		port = nat.Port(port.Port() + "/udp")
+	}
+
+	return &UDPStrategy{
+		Port:         port,
+		ReadTimeout:  defaultUDPReadTimeout,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// WithPayload sets the datagram sent to the port, e.g. a DNS query. It defaults to an empty datagram.
+func (us *UDPStrategy) WithPayload(payload []byte) *UDPStrategy {
+	us.Payload = payload
+	return us
+}
+
+// WithResponse requires a response to the datagram. The matcher, if not nil,
+// must accept the response for the port to be ready.
+func (us *UDPStrategy) WithResponse(matcher func(response []byte) bool) *UDPStrategy {
+	us.expectResponse = true
+	us.responseMatcher = matcher
+	return us
+}
+
+// WithReadTimeout can be used to change the time to wait for a response to a datagram,
+// which defaults to 500 milliseconds.
+func (us *UDPStrategy) WithReadTimeout(readTimeout time.Duration) *UDPStrategy {
+	us.ReadTimeout = readTimeout
+	return us
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (us *UDPStrategy) WithStartupTimeout(startupTimeout time.Duration) *UDPStrategy {
+	us.timeout = &startupTimeout
+	return us
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (us *UDPStrategy) WithPollInterval(pollInterval time.Duration) *UDPStrategy {
+	us.PollInterval = pollInterval
+	return us
+}
+
+func (us *UDPStrategy) Timeout() *time.Duration {
+	return us.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (us *UDPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if proto := us.Port.Proto(); proto != "udp" {
+		return fmt.Errorf("port %s: protocol %s is not udp", us.Port, proto)
+	}
+
+	timeout := defaultStartupTimeout()
+	if us.timeout != nil {
+		timeout = *us.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(us.PollInterval)
+	defer ticker.Stop()
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, us.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, us.Port)
+		}
+	}
+
+	address := net.JoinHostPort(host, port.Port())
+
+	var probeErr error
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		if probeErr = us.probe(address); probeErr == nil {
+			return nil
+		}
+
+		if !errors.Is(probeErr, errUDPNotReady) {
+			return probeErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), probeErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe sends the payload to the address and reads the response, returning
+// errUDPNotReady if the port is not ready yet.
+func (us *UDPStrategy) probe(address string) error {
+	// dialing UDP doesn't send anything, so it doesn't block
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(us.Payload); err != nil {
+		if isConnRefusedErr(err) {
+			return fmt.Errorf("%w: %w", errUDPNotReady, err)
+		}
+		return fmt.Errorf("write: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(us.ReadTimeout)); err != nil {
+		return fmt.Errorf("set read deadline: %w", err)
+	}
+
+	buf := make([]byte, 64*1024)
+	n, err := conn.Read(buf)
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case isConnRefusedErr(err):
+			// ICMP port unreachable: nothing listens on the port.
+			return fmt.Errorf("%w: %w", errUDPNotReady, err)
+		case errors.As(err, &netErr) && netErr.Timeout():
+			if us.expectResponse {
+				return fmt.Errorf("%w: no response", errUDPNotReady)
+			}
+			return nil
+		default:
+			return fmt.Errorf("read: %w", err)
+		}
+	}
+
+	if us.responseMatcher != nil && !us.responseMatcher(buf[:n]) {
+		return fmt.Errorf("%w: unexpected response %q", errUDPNotReady, buf[:n])
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// udpTarget returns a target mapping any port to the given UDP port on localhost.
+func udpTarget(port int) *MockStrategyTarget {
+	return &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("udp", strconv.Itoa(port))
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+// udpEchoServer starts a UDP server on localhost replying to every datagram
+// with the given response, or echoing it if the response is nil.
+func udpEchoServer(t *testing.T, response []byte) int {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			reply := response
+			if reply == nil {
+				reply = buf[:n]
+			}
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+// closedUDPPort returns a UDP port on localhost nothing listens on.
+func closedUDPPort(t *testing.T) int {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+
+	return port
+}
+
+func TestForUDP(t *testing.T) {
+	require.Equal(t, nat.Port("53/udp"), ForUDP("53").Port)
+	require.Equal(t, nat.Port("53/udp"), ForUDP("53/udp").Port)
+
+	err := ForUDP("53/tcp").WaitUntilReady(context.Background(), udpTarget(53))
+	require.ErrorContains(t, err, "not udp")
+}
+
+func TestUDPStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("response", func(t *testing.T) {
+		port := udpEchoServer(t, nil)
+
+		wg := ForUDP("53/udp").
+			WithPayload([]byte("ping")).
+			WithResponse(func(response []byte) bool {
+				return bytes.Equal(response, []byte("ping"))
+			}).
+			WithStartupTimeout(5 * time.Second)
+
+		require.NoError(t, wg.WaitUntilReady(ctx, udpTarget(port)))
+	})
+
+	t.Run("unexpected-response", func(t *testing.T) {
+		port := udpEchoServer(t, []byte("nope"))
+
+		wg := ForUDP("53/udp").
+			WithResponse(func(response []byte) bool {
+				return bytes.Equal(response, []byte("pong"))
+			}).
+			WithReadTimeout(100 * time.Millisecond).
+			WithStartupTimeout(500 * time.Millisecond)
+
+		err := wg.WaitUntilReady(ctx, udpTarget(port))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "unexpected response")
+	})
+
+	t.Run("no-icmp-unreachable", func(t *testing.T) {
+		// a server not replying to the datagrams
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		wg := ForUDP("514/udp").
+			WithReadTimeout(100 * time.Millisecond).
+			WithStartupTimeout(5 * time.Second)
+
+		require.NoError(t, wg.WaitUntilReady(ctx, udpTarget(conn.LocalAddr().(*net.UDPAddr).Port)))
+	})
+
+	t.Run("icmp-unreachable", func(t *testing.T) {
+		wg := ForUDP("514/udp").
+			WithReadTimeout(100 * time.Millisecond).
+			WithStartupTimeout(500 * time.Millisecond)
+
+		err := wg.WaitUntilReady(ctx, udpTarget(closedUDPPort(t)))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, errUDPNotReady)
+	})
+}
+
+func TestBuildInternalCheckCommand(t *testing.T) {
+	require.Contains(t, buildInternalCheckCommand("80/tcp"), "cat /proc/net/tcp* | awk '{print $2}' | grep -i :0050")
+	require.Contains(t, buildInternalCheckCommand("80"), "nc -vz -w 1 localhost 80")
+	require.Equal(t, "true && cat /proc/net/udp* | awk '{print $2}' | grep -i :0035", buildInternalCheckCommand("53/udp"))
+	require.Equal(t, "true && awk '{print $6}' /proc/net/sctp/eps | grep -x 3868", buildInternalCheckCommand("3868/sctp"))
+}
@@ -25,7 +25,7 @@ type StrategyTimeout interface {
 
 type StrategyTarget interface {
 	Host(context.Context) (string, error)
-	Inspect(context.Context) (*types.ContainerJSON, error)
+	Inspect(context.Context, ...InspectOption) (*types.ContainerJSON, error)
 	Ports(ctx context.Context) (nat.PortMap, error) // Deprecated: use Inspect instead
 	MappedPort(context.Context, nat.Port) (nat.Port, error)
 	Logs(context.Context) (io.ReadCloser, error)
@@ -34,6 +34,33 @@ type StrategyTarget interface {
 	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
 }
 
+// InspectOptions are the options of the inspection of a container.
+type InspectOptions struct {
+	// Refresh makes the inspection query the container runtime, instead of returning the cached inspection.
+	Refresh bool
+}
+
+// InspectOption customizes the inspection of a container.
+type InspectOption func(*InspectOptions)
+
+// Refresh makes the inspection query the container runtime, instead of returning the inspection cached
+// by the container, e.g. to read the health status of a running container.
+func Refresh() InspectOption {
+	return func(opts *InspectOptions) {
+		opts.Refresh = true
+	}
+}
+
+// NewInspectOptions returns the inspect options with the given options applied.
+func NewInspectOptions(opts ...InspectOption) InspectOptions {
+	var options InspectOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
 func checkTarget(ctx context.Context, target StrategyTarget) error {
 	state, err := target.State(ctx)
 	if err != nil {
@@ -63,3 +90,16 @@ func defaultStartupTimeout() time.Duration {
 func defaultPollInterval() time.Duration {
 	return 100 * time.Millisecond
 }
+
+// sleep waits for the duration, returning the error of the context if it's done before.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -28,7 +28,7 @@ func (st MockStrategyTarget) Host(ctx context.Context) (string, error) {
 	return st.HostImpl(ctx)
 }
 
-func (st MockStrategyTarget) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
+func (st MockStrategyTarget) Inspect(ctx context.Context, _ ...InspectOption) (*types.ContainerJSON, error) {
 	return st.InspectImpl(ctx)
 }
 